@@ -156,6 +156,16 @@ type BlobIngester interface {
 	Resume(ctx context.Context, id string) (BlobWriter, error)
 }
 
+// BlobMounter may be implemented by a BlobIngester that can link a blob
+// stored in another repository of the same registry without transferring its
+// content.
+type BlobMounter interface {
+	// Mount links the blob identified by dgst from the repository named
+	// sourceRepo. If the registry cannot mount the blob, a BlobWriter is
+	// returned instead to upload its content.
+	Mount(ctx context.Context, sourceRepo string, dgst digest.Digest) (Descriptor, BlobWriter, error)
+}
+
 // BlobWriter provides a handle for inserting data into a blob store.
 // Instances should be obtained from BlobWriteService.Writer and
 // BlobWriteService.Resume. If supported by the store, a writer can be
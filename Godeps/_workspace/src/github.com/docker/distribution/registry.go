@@ -116,6 +116,19 @@ type ManifestService interface {
 	//       really be concerned with the storage format.
 }
 
+// RawManifestService may be implemented by a ManifestService that can store
+// and retrieve manifests of any schema, such as schema 2 manifests and
+// manifest lists, as raw payloads identified by their media type.
+type RawManifestService interface {
+	// GetRaw retrieves the manifest identified by a tag or a digest in one of
+	// the accepted media types, returning its payload and media type.
+	GetRaw(reference string, accept []string) ([]byte, string, error)
+
+	// PutRaw stores a manifest payload of the given media type, tagging it
+	// when tag is not empty, and returns the digest of the payload.
+	PutRaw(payload []byte, mediaType, tag string) (digest.Digest, error)
+}
+
 // SignatureService provides operations on signatures.
 type SignatureService interface {
 	// Get retrieves all of the signature blobs for the specified digest.
@@ -308,6 +308,71 @@ func (ms *manifests) GetByTag(tag string, options ...distribution.ManifestServic
 	return nil, handleErrorResponse(resp)
 }
 
+// GetRaw retrieves the manifest identified by reference, announcing the
+// accepted media types to the registry, and returns the raw payload along with
+// the media type the registry responded with.
+func (ms *manifests) GetRaw(reference string, accept []string) ([]byte, string, error) {
+	u, err := ms.ub.BuildManifestURL(ms.name, reference)
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, mediaType := range accept {
+		req.Header.Add("Accept", mediaType)
+	}
+
+	resp, err := ms.client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if !SuccessStatus(resp.StatusCode) {
+		return nil, "", handleErrorResponse(resp)
+	}
+	payload, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return payload, resp.Header.Get("Content-Type"), nil
+}
+
+// PutRaw uploads the manifest payload with the given media type under tag, or
+// under its digest when tag is empty.
+func (ms *manifests) PutRaw(payload []byte, mediaType, tag string) (digest.Digest, error) {
+	dgst, err := digest.FromBytes(payload)
+	if err != nil {
+		return "", err
+	}
+	ref := tag
+	if len(ref) == 0 {
+		ref = dgst.String()
+	}
+	manifestURL, err := ms.ub.BuildManifestURL(ms.name, ref)
+	if err != nil {
+		return "", err
+	}
+
+	putRequest, err := http.NewRequest("PUT", manifestURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	putRequest.Header.Set("Content-Type", mediaType)
+
+	resp, err := ms.client.Do(putRequest)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if SuccessStatus(resp.StatusCode) {
+		return dgst, nil
+	}
+	return "", handleErrorResponse(resp)
+}
+
 func (ms *manifests) Enumerate() ([]digest.Digest, error) {
 	return nil, distribution.ErrUnsupported
 }
@@ -472,6 +537,42 @@ func (bs *blobs) Create(ctx context.Context) (distribution.BlobWriter, error) {
 	return nil, handleErrorResponse(resp)
 }
 
+// Mount asks the registry to link the blob dgst from the repository sourceRepo.
+// Registries that do not support cross repository mounts start a regular
+// upload, which is returned as a BlobWriter.
+func (bs *blobs) Mount(ctx context.Context, sourceRepo string, dgst digest.Digest) (distribution.Descriptor, distribution.BlobWriter, error) {
+	u, err := bs.ub.BuildBlobUploadURL(bs.name, url.Values{"mount": {dgst.String()}, "from": {sourceRepo}})
+	if err != nil {
+		return distribution.Descriptor{}, nil, err
+	}
+
+	resp, err := bs.client.Post(u, "", nil)
+	if err != nil {
+		return distribution.Descriptor{}, nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusCreated:
+		desc, err := bs.Stat(ctx, dgst)
+		return desc, nil, err
+	case SuccessStatus(resp.StatusCode):
+		uuid := resp.Header.Get("Docker-Upload-UUID")
+		location, err := sanitizeLocation(resp.Header.Get("Location"), u)
+		if err != nil {
+			return distribution.Descriptor{}, nil, err
+		}
+		return distribution.Descriptor{}, &httpBlobUpload{
+			statter:   bs.statter,
+			client:    bs.client,
+			uuid:      uuid,
+			startedAt: time.Now(),
+			location:  location,
+		}, nil
+	}
+	return distribution.Descriptor{}, nil, handleErrorResponse(resp)
+}
+
 func (bs *blobs) Resume(ctx context.Context, id string) (distribution.BlobWriter, error) {
 	panic("not implemented")
 }
@@ -62,6 +62,15 @@ func (imh *imageManifestHandler) GetImageManifest(w http.ResponseWriter, r *http
 		return
 	}
 
+	// clients announcing support for newer manifest schemas are served raw
+	// manifests by services able to store them
+	if rms, ok := manifests.(distribution.RawManifestService); ok {
+		if accept := acceptedMediaTypes(r); acceptsRawManifests(accept) {
+			imh.getRawImageManifest(w, r, rms, accept)
+			return
+		}
+	}
+
 	var sm *schema1.SignedManifest
 	if imh.Tag != "" {
 		sm, err = manifests.GetByTag(imh.Tag)
@@ -100,6 +109,88 @@ func (imh *imageManifestHandler) GetImageManifest(w http.ResponseWriter, r *http
 	w.Write(sm.Raw)
 }
 
+// getRawImageManifest serves the manifest in one of the media types accepted
+// by the client.
+func (imh *imageManifestHandler) getRawImageManifest(w http.ResponseWriter, r *http.Request, rms distribution.RawManifestService, accept []string) {
+	reference := imh.Tag
+	if reference == "" {
+		if etagMatch(r, imh.Digest.String()) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		reference = imh.Digest.String()
+	}
+
+	payload, mediaType, err := rms.GetRaw(reference, accept)
+	if err != nil {
+		imh.Errors = append(imh.Errors, v2.ErrorCodeManifestUnknown.WithDetail(err))
+		return
+	}
+
+	if imh.Digest == "" {
+		var dgst digest.Digest
+		if mediaType != schema2MediaType && mediaType != manifestListMediaType {
+			var sm schema1.SignedManifest
+			if err := json.Unmarshal(payload, &sm); err != nil {
+				imh.Errors = append(imh.Errors, v2.ErrorCodeManifestInvalid.WithDetail(err))
+				return
+			}
+			dgst, err = digestManifest(imh, &sm)
+		} else {
+			dgst, err = digest.FromBytes(payload)
+		}
+		if err != nil {
+			imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid.WithDetail(err))
+			return
+		}
+		if etagMatch(r, dgst.String()) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		imh.Digest = dgst
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(payload)))
+	w.Header().Set("Docker-Content-Digest", imh.Digest.String())
+	w.Header().Set("Etag", fmt.Sprintf(`"%s"`, imh.Digest))
+	w.Write(payload)
+}
+
+// acceptedMediaTypes returns the media types listed in the Accept headers of
+// the request, without their parameters.
+func acceptedMediaTypes(r *http.Request) []string {
+	var mediaTypes []string
+	for _, header := range r.Header["Accept"] {
+		for _, mediaType := range strings.Split(header, ",") {
+			if i := strings.Index(mediaType, ";"); i >= 0 {
+				mediaType = mediaType[:i]
+			}
+			if mediaType = strings.TrimSpace(mediaType); mediaType != "" {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+	return mediaTypes
+}
+
+// acceptsRawManifests returns true if any of the accepted media types is a
+// schema 2 manifest or a manifest list.
+func acceptsRawManifests(accept []string) bool {
+	for _, mediaType := range accept {
+		if mediaType == schema2MediaType || mediaType == manifestListMediaType {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	schema2MediaType      = "application/vnd.docker.distribution.manifest.v2+json"
+	manifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
 func etagMatch(r *http.Request, etag string) bool {
 	for _, headerVal := range r.Header["If-None-Match"] {
 		if headerVal == etag || headerVal == fmt.Sprintf(`"%s"`, etag) { // allow quoted or unquoted
@@ -124,6 +215,24 @@ func (imh *imageManifestHandler) PutImageManifest(w http.ResponseWriter, r *http
 		return
 	}
 
+	var versioned struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		MediaType     string `json:"mediaType"`
+	}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &versioned); err == nil && versioned.SchemaVersion == 2 {
+		rms, ok := manifests.(distribution.RawManifestService)
+		if !ok {
+			imh.Errors = append(imh.Errors, v2.ErrorCodeManifestInvalid.WithDetail("schema 2 manifests are not supported"))
+			return
+		}
+		mediaType := versioned.MediaType
+		if mediaType == "" {
+			mediaType = r.Header.Get("Content-Type")
+		}
+		imh.putRawImageManifest(w, rms, jsonBuf.Bytes(), mediaType)
+		return
+	}
+
 	var manifest schema1.SignedManifest
 	if err := json.Unmarshal(jsonBuf.Bytes(), &manifest); err != nil {
 		imh.Errors = append(imh.Errors, v2.ErrorCodeManifestInvalid.WithDetail(err))
@@ -157,37 +266,78 @@ func (imh *imageManifestHandler) PutImageManifest(w http.ResponseWriter, r *http
 	}
 
 	if err := manifests.Put(&manifest); err != nil {
-		// TODO(stevvooe): These error handling switches really need to be
-		// handled by an app global mapper.
-		if err == distribution.ErrUnsupported {
-			imh.Errors = append(imh.Errors, errcode.ErrorCodeUnsupported)
+		imh.appendPutErrors(err)
+		return
+	}
+
+	imh.writeCreated(w)
+}
+
+// putRawImageManifest validates the digest of a manifest payload of a newer
+// schema and stores it with the raw manifest service.
+func (imh *imageManifestHandler) putRawImageManifest(w http.ResponseWriter, rms distribution.RawManifestService, payload []byte, mediaType string) {
+	dgst, err := digest.FromBytes(payload)
+	if err != nil {
+		imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid.WithDetail(err))
+		return
+	}
+	if imh.Tag == "" {
+		if imh.Digest == "" {
+			imh.Errors = append(imh.Errors, v2.ErrorCodeTagInvalid.WithDetail("no tag or digest specified"))
 			return
 		}
-		switch err := err.(type) {
-		case distribution.ErrManifestVerification:
-			for _, verificationError := range err {
-				switch verificationError := verificationError.(type) {
-				case distribution.ErrManifestBlobUnknown:
-					imh.Errors = append(imh.Errors, v2.ErrorCodeManifestBlobUnknown.WithDetail(verificationError.Digest))
-				case distribution.ErrManifestNameInvalid:
-					imh.Errors = append(imh.Errors, v2.ErrorCodeNameInvalid.WithDetail(err))
-				case distribution.ErrManifestUnverified:
-					imh.Errors = append(imh.Errors, v2.ErrorCodeManifestUnverified)
-				default:
-					if verificationError == digest.ErrDigestInvalidFormat {
-						imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid)
-					} else {
-						imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown, verificationError)
-					}
-				}
-			}
-		default:
-			imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+		if dgst != imh.Digest {
+			ctxu.GetLogger(imh).Errorf("payload digest does match: %q != %q", dgst, imh.Digest)
+			imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid)
+			return
 		}
+	}
+	imh.Digest = dgst
 
+	if _, err := rms.PutRaw(payload, mediaType, imh.Tag); err != nil {
+		imh.appendPutErrors(err)
 		return
 	}
 
+	imh.writeCreated(w)
+}
+
+// appendPutErrors translates an error returned when storing a manifest into
+// registry errors.
+func (imh *imageManifestHandler) appendPutErrors(err error) {
+	// TODO(stevvooe): These error handling switches really need to be
+	// handled by an app global mapper.
+	if err == distribution.ErrUnsupported {
+		imh.Errors = append(imh.Errors, errcode.ErrorCodeUnsupported)
+		return
+	}
+	switch err := err.(type) {
+	case distribution.ErrManifestVerification:
+		for _, verificationError := range err {
+			switch verificationError := verificationError.(type) {
+			case distribution.ErrManifestBlobUnknown:
+				imh.Errors = append(imh.Errors, v2.ErrorCodeManifestBlobUnknown.WithDetail(verificationError.Digest))
+			case distribution.ErrManifestNameInvalid:
+				imh.Errors = append(imh.Errors, v2.ErrorCodeNameInvalid.WithDetail(err))
+			case distribution.ErrManifestUnverified:
+				imh.Errors = append(imh.Errors, v2.ErrorCodeManifestUnverified)
+			default:
+				if verificationError == digest.ErrDigestInvalidFormat {
+					imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid)
+				} else {
+					imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown, verificationError)
+				}
+			}
+		}
+	case errcode.Error:
+		imh.Errors = append(imh.Errors, err)
+	default:
+		imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+	}
+}
+
+// writeCreated responds with the location of the manifest just stored.
+func (imh *imageManifestHandler) writeCreated(w http.ResponseWriter) {
 	// Construct a canonical url for the uploaded manifest.
 	location, err := imh.urlBuilder.BuildManifestURL(imh.Repository.Name(), imh.Digest.String())
 	if err != nil {
@@ -464,6 +464,13 @@ type blobDataPathSpec struct {
 
 func (blobDataPathSpec) pathSpec() {}
 
+// BlobDataPath returns the path of the data of the blob identified by dgst in
+// the registry global blob store. It allows tools working directly with the
+// storage driver, like garbage collectors, to inspect blobs.
+func BlobDataPath(dgst digest.Digest) (string, error) {
+	return pathFor(blobDataPathSpec{digest: dgst})
+}
+
 // uploadDataPathSpec defines the path parameters of the data file for
 // uploads.
 type uploadDataPathSpec struct {
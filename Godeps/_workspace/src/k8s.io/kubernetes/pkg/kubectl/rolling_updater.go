@@ -82,6 +82,12 @@ type RollingUpdaterConfig struct {
 	// further, ensuring that total number of pods running at any time during
 	// the update is atmost 130% of desired pods.
 	MaxSurge intstr.IntOrString
+	// OnProgress is invoked if set after each scale up of the new controller,
+	// to allow the caller to perform additional logic or abort the update. If
+	// an error is returned the update is aborted and the cleanup method will
+	// not be invoked. The percentage value is the approximate completion of
+	// the update, calculated from the new controller's replica count.
+	OnProgress func(oldRc, newRc *api.ReplicationController, percentage int) error
 }
 
 // RollingUpdaterCleanupPolicy is a cleanup action to take after the
@@ -241,6 +247,17 @@ func (r *RollingUpdater) Update(config *RollingUpdaterConfig) error {
 		}
 		newRc = scaledRc
 
+		// Notify the caller of the scale up, if any.
+		if config.OnProgress != nil && newRc.Spec.Replicas != newReplicas {
+			percentage := 100
+			if desired > 0 {
+				percentage = newRc.Spec.Replicas * 100 / desired
+			}
+			if err := config.OnProgress(oldRc, newRc, percentage); err != nil {
+				return err
+			}
+		}
+
 		// Wait between scaling operations for things to settle.
 		time.Sleep(config.UpdatePeriod)
 
@@ -1,2 +1,12 @@
 // Package main contains the main executable for the integrated OpenShift Docker registry 2.0.
+//
+// Setting DOCKER_REGISTRY_METRICS=true serves Prometheus metrics at /metrics to the users allowed to
+// list images. Setting DOCKER_REGISTRY_AUDIT_LOG to a file, or to - for the standard output, writes a
+// JSON record of every image pushed, pulled or deleted along with the user who made the request.
+//
+// Run with the gc subcommand, it deletes the blobs of the registry storage that are no longer
+// referenced by any image instead of serving requests. Registries sharing the storage refuse manifests
+// while the blobs are deleted:
+//
+//	dockerregistry gc [-dry-run] [-grace-period=1h] <config file>
 package main
@@ -8,6 +8,7 @@ import (
 
 	log "github.com/Sirupsen/logrus"
 	"github.com/openshift/origin/pkg/cmd/dockerregistry"
+	"github.com/openshift/origin/pkg/dockerregistry/server"
 
 	// install all APIs
 	_ "github.com/openshift/origin/pkg/api/install"
@@ -19,10 +20,20 @@ func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
 	flag.Parse()
 
+	args := flag.Args()
+	gc := len(args) > 0 && args[0] == "gc"
+	gcFlags := flag.NewFlagSet("gc", flag.ExitOnError)
+	gracePeriod := gcFlags.Duration("grace-period", server.DefaultGCGracePeriod, "Minimum age of the unreferenced blobs to delete; younger blobs may belong to a push in progress")
+	dryRun := gcFlags.Bool("dry-run", false, "Report the blobs that would be deleted without deleting them")
+	if gc {
+		gcFlags.Parse(args[1:])
+		args = gcFlags.Args()
+	}
+
 	// TODO convert to flags instead of a config file?
 	configurationPath := ""
-	if flag.NArg() > 0 {
-		configurationPath = flag.Arg(0)
+	if len(args) > 0 {
+		configurationPath = args[0]
 	}
 	if configurationPath == "" {
 		configurationPath = os.Getenv("REGISTRY_CONFIGURATION_PATH")
@@ -40,5 +51,9 @@ func main() {
 		log.Fatalf("Unable to open configuration file: %s", err)
 	}
 
+	if gc {
+		dockerregistry.ExecuteGarbageCollect(configFile, *gracePeriod, *dryRun)
+		return
+	}
 	dockerregistry.Execute(configFile)
 }
@@ -296,6 +296,14 @@ func deepCopy_api_LocalSubjectAccessReview(in api.LocalSubjectAccessReview, out
 	} else {
 		out.Groups = nil
 	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -652,6 +660,14 @@ func deepCopy_api_SubjectAccessReview(in api.SubjectAccessReview, out *api.Subje
 	} else {
 		out.Groups = nil
 	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -1686,6 +1702,20 @@ func deepCopy_api_DeploymentTriggerPolicy(in deployapi.DeploymentTriggerPolicy,
 	return nil
 }
 
+func deepCopy_api_ExecInPodsHook(in deployapi.ExecInPodsHook, out *deployapi.ExecInPodsHook, c *conversion.Cloner) error {
+	if in.Command != nil {
+		out.Command = make([]string, len(in.Command))
+		for i := range in.Command {
+			out.Command[i] = in.Command[i]
+		}
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.MaxPods = in.MaxPods
+	return nil
+}
+
 func deepCopy_api_ExecNewPodHook(in deployapi.ExecNewPodHook, out *deployapi.ExecNewPodHook, c *conversion.Cloner) error {
 	if in.Command != nil {
 		out.Command = make([]string, len(in.Command))
@@ -1729,6 +1759,24 @@ func deepCopy_api_LifecycleHook(in deployapi.LifecycleHook, out *deployapi.Lifec
 	} else {
 		out.ExecNewPod = nil
 	}
+	if in.TagImages != nil {
+		out.TagImages = make([]deployapi.TagImageHook, len(in.TagImages))
+		for i := range in.TagImages {
+			if err := deepCopy_api_TagImageHook(in.TagImages[i], &out.TagImages[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.TagImages = nil
+	}
+	if in.ExecInPods != nil {
+		out.ExecInPods = new(deployapi.ExecInPodsHook)
+		if err := deepCopy_api_ExecInPodsHook(*in.ExecInPods, out.ExecInPods, c); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPods = nil
+	}
 	return nil
 }
 
@@ -1809,6 +1857,14 @@ func deepCopy_api_RollingDeploymentStrategyParams(in deployapi.RollingDeployment
 	} else {
 		out.Pre = nil
 	}
+	if in.Mid != nil {
+		out.Mid = new(deployapi.LifecycleHook)
+		if err := deepCopy_api_LifecycleHook(*in.Mid, out.Mid, c); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
 	if in.Post != nil {
 		out.Post = new(deployapi.LifecycleHook)
 		if err := deepCopy_api_LifecycleHook(*in.Post, out.Post, c); err != nil {
@@ -1817,6 +1873,22 @@ func deepCopy_api_RollingDeploymentStrategyParams(in deployapi.RollingDeployment
 	} else {
 		out.Post = nil
 	}
+	if in.PauseAfterPercent != nil {
+		out.PauseAfterPercent = new(int)
+		*out.PauseAfterPercent = *in.PauseAfterPercent
+	} else {
+		out.PauseAfterPercent = nil
+	}
+	return nil
+}
+
+func deepCopy_api_TagImageHook(in deployapi.TagImageHook, out *deployapi.TagImageHook, c *conversion.Cloner) error {
+	out.ContainerName = in.ContainerName
+	if newVal, err := c.DeepCopy(in.To); err != nil {
+		return err
+	} else {
+		out.To = newVal.(pkgapi.ObjectReference)
+	}
 	return nil
 }
 
@@ -1979,6 +2051,8 @@ func deepCopy_api_Image(in imageapi.Image, out *imageapi.Image, c *conversion.Cl
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		out.DockerImageLayers = make([]imageapi.ImageLayer, len(in.DockerImageLayers))
 		for i := range in.DockerImageLayers {
@@ -1989,6 +2063,16 @@ func deepCopy_api_Image(in imageapi.Image, out *imageapi.Image, c *conversion.Cl
 	} else {
 		out.DockerImageLayers = nil
 	}
+	if in.Signatures != nil {
+		out.Signatures = make([]imageapi.ImageSignature, len(in.Signatures))
+		for i := range in.Signatures {
+			if err := deepCopy_api_ImageSignature(in.Signatures[i], &out.Signatures[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Signatures = nil
+	}
 	return nil
 }
 
@@ -2062,6 +2146,29 @@ func deepCopy_api_ImageList(in imageapi.ImageList, out *imageapi.ImageList, c *c
 	return nil
 }
 
+func deepCopy_api_ImageSignature(in imageapi.ImageSignature, out *imageapi.ImageSignature, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapi.ObjectMeta)
+	}
+	out.Type = in.Type
+	if in.Content != nil {
+		out.Content = make([]uint8, len(in.Content))
+		for i := range in.Content {
+			out.Content[i] = in.Content[i]
+		}
+	} else {
+		out.Content = nil
+	}
+	return nil
+}
+
 func deepCopy_api_ImageStream(in imageapi.ImageStream, out *imageapi.ImageStream, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
 		return err
@@ -2215,6 +2322,26 @@ func deepCopy_api_ImageStreamMapping(in imageapi.ImageStreamMapping, out *imagea
 	return nil
 }
 
+func deepCopy_api_ImageStreamNotification(in imageapi.ImageStreamNotification, out *imageapi.ImageStreamNotification, c *conversion.Cloner) error {
+	out.URL = in.URL
+	out.SecretName = in.SecretName
+	return nil
+}
+
+func deepCopy_api_ImageStreamRetentionPolicy(in imageapi.ImageStreamRetentionPolicy, out *imageapi.ImageStreamRetentionPolicy, c *conversion.Cloner) error {
+	out.KeepTagRevisions = in.KeepTagRevisions
+	out.KeepYoungerThanSeconds = in.KeepYoungerThanSeconds
+	if in.ProtectedTags != nil {
+		out.ProtectedTags = make([]string, len(in.ProtectedTags))
+		for i := range in.ProtectedTags {
+			out.ProtectedTags[i] = in.ProtectedTags[i]
+		}
+	} else {
+		out.ProtectedTags = nil
+	}
+	return nil
+}
+
 func deepCopy_api_ImageStreamSpec(in imageapi.ImageStreamSpec, out *imageapi.ImageStreamSpec, c *conversion.Cloner) error {
 	out.DockerImageRepository = in.DockerImageRepository
 	if in.Tags != nil {
@@ -2229,6 +2356,24 @@ func deepCopy_api_ImageStreamSpec(in imageapi.ImageStreamSpec, out *imageapi.Ima
 	} else {
 		out.Tags = nil
 	}
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = new(imageapi.ImageStreamRetentionPolicy)
+		if err := deepCopy_api_ImageStreamRetentionPolicy(*in.RetentionPolicy, out.RetentionPolicy, c); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
+	if in.Notifications != nil {
+		out.Notifications = make([]imageapi.ImageStreamNotification, len(in.Notifications))
+		for i := range in.Notifications {
+			if err := deepCopy_api_ImageStreamNotification(in.Notifications[i], &out.Notifications[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Notifications = nil
+	}
 	return nil
 }
 
@@ -2444,6 +2589,15 @@ func deepCopy_api_OAuthAccessToken(in oauthapi.OAuthAccessToken, out *oauthapi.O
 	out.UserUID = in.UserUID
 	out.AuthorizeToken = in.AuthorizeToken
 	out.RefreshToken = in.RefreshToken
+	if in.LastUsedTimestamp != nil {
+		if newVal, err := c.DeepCopy(in.LastUsedTimestamp); err != nil {
+			return err
+		} else {
+			out.LastUsedTimestamp = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
 	return nil
 }
 
@@ -2620,6 +2774,129 @@ func deepCopy_api_OAuthClientList(in oauthapi.OAuthClientList, out *oauthapi.OAu
 	return nil
 }
 
+func deepCopy_api_OAuthTOTPEnrollment(in oauthapi.OAuthTOTPEnrollment, out *oauthapi.OAuthTOTPEnrollment, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapi.ObjectMeta)
+	}
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.Secret = in.Secret
+	out.Confirmed = in.Confirmed
+	if in.RecoveryCodeHashes != nil {
+		out.RecoveryCodeHashes = make([]string, len(in.RecoveryCodeHashes))
+		for i := range in.RecoveryCodeHashes {
+			out.RecoveryCodeHashes[i] = in.RecoveryCodeHashes[i]
+		}
+	} else {
+		out.RecoveryCodeHashes = nil
+	}
+	out.LastUsedTimeStep = in.LastUsedTimeStep
+	out.FailedAttempts = in.FailedAttempts
+	if in.LockedUntil != nil {
+		if newVal, err := c.DeepCopy(in.LockedUntil); err != nil {
+			return err
+		} else {
+			out.LockedUntil = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LockedUntil = nil
+	}
+	return nil
+}
+
+func deepCopy_api_OAuthTOTPEnrollmentList(in oauthapi.OAuthTOTPEnrollmentList, out *oauthapi.OAuthTOTPEnrollmentList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapi.OAuthTOTPEnrollment, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_api_OAuthTOTPEnrollment(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func deepCopy_api_UserOAuthAccessToken(in oauthapi.UserOAuthAccessToken, out *oauthapi.UserOAuthAccessToken, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapi.ObjectMeta)
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	if in.LastUsedTimestamp != nil {
+		if newVal, err := c.DeepCopy(in.LastUsedTimestamp); err != nil {
+			return err
+		} else {
+			out.LastUsedTimestamp = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
+	return nil
+}
+
+func deepCopy_api_UserOAuthAccessTokenList(in oauthapi.UserOAuthAccessTokenList, out *oauthapi.UserOAuthAccessTokenList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapi.UserOAuthAccessToken, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_api_UserOAuthAccessToken(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
 func deepCopy_api_Project(in projectapi.Project, out *projectapi.Project, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
 		return err
@@ -2921,6 +3198,18 @@ func deepCopy_api_Parameter(in templateapi.Parameter, out *templateapi.Parameter
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = in.Type
+	out.Pattern = in.Pattern
+	if in.AllowedValues != nil {
+		out.AllowedValues = make([]string, len(in.AllowedValues))
+		for i := range in.AllowedValues {
+			out.AllowedValues[i] = in.AllowedValues[i]
+		}
+	} else {
+		out.AllowedValues = nil
+	}
+	out.MinLength = in.MinLength
+	out.MaxLength = in.MaxLength
 	return nil
 }
 
@@ -2970,6 +3259,136 @@ func deepCopy_api_Template(in templateapi.Template, out *templateapi.Template, c
 	return nil
 }
 
+func deepCopy_api_TemplateInstance(in templateapi.TemplateInstance, out *templateapi.TemplateInstance, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapi.ObjectMeta)
+	}
+	if err := deepCopy_api_TemplateInstanceSpec(in.Spec, &out.Spec, c); err != nil {
+		return err
+	}
+	if err := deepCopy_api_TemplateInstanceStatus(in.Status, &out.Status, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func deepCopy_api_TemplateInstanceCondition(in templateapi.TemplateInstanceCondition, out *templateapi.TemplateInstanceCondition, c *conversion.Cloner) error {
+	out.Type = in.Type
+	out.Status = in.Status
+	if newVal, err := c.DeepCopy(in.LastTransitionTime); err != nil {
+		return err
+	} else {
+		out.LastTransitionTime = newVal.(unversioned.Time)
+	}
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+func deepCopy_api_TemplateInstanceList(in templateapi.TemplateInstanceList, out *templateapi.TemplateInstanceList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]templateapi.TemplateInstance, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_api_TemplateInstance(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func deepCopy_api_TemplateInstanceRequester(in templateapi.TemplateInstanceRequester, out *templateapi.TemplateInstanceRequester, c *conversion.Cloner) error {
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	return nil
+}
+
+func deepCopy_api_TemplateInstanceSpec(in templateapi.TemplateInstanceSpec, out *templateapi.TemplateInstanceSpec, c *conversion.Cloner) error {
+	if err := deepCopy_api_Template(in.Template, &out.Template, c); err != nil {
+		return err
+	}
+	if in.Secret != nil {
+		if newVal, err := c.DeepCopy(in.Secret); err != nil {
+			return err
+		} else {
+			out.Secret = newVal.(*pkgapi.LocalObjectReference)
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.Requester != nil {
+		out.Requester = new(templateapi.TemplateInstanceRequester)
+		if err := deepCopy_api_TemplateInstanceRequester(*in.Requester, out.Requester, c); err != nil {
+			return err
+		}
+	} else {
+		out.Requester = nil
+	}
+	return nil
+}
+
+func deepCopy_api_TemplateInstanceStatus(in templateapi.TemplateInstanceStatus, out *templateapi.TemplateInstanceStatus, c *conversion.Cloner) error {
+	out.ObservedGeneration = in.ObservedGeneration
+	out.ObservedSecretResourceVersion = in.ObservedSecretResourceVersion
+	if in.Conditions != nil {
+		out.Conditions = make([]templateapi.TemplateInstanceCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := deepCopy_api_TemplateInstanceCondition(in.Conditions[i], &out.Conditions[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.Objects != nil {
+		out.Objects = make([]pkgapi.ObjectReference, len(in.Objects))
+		for i := range in.Objects {
+			if newVal, err := c.DeepCopy(in.Objects[i]); err != nil {
+				return err
+			} else {
+				out.Objects[i] = newVal.(pkgapi.ObjectReference)
+			}
+		}
+	} else {
+		out.Objects = nil
+	}
+	return nil
+}
+
 func deepCopy_api_TemplateList(in templateapi.TemplateList, out *templateapi.TemplateList, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
 		return err
@@ -3244,10 +3663,12 @@ func init() {
 		deepCopy_api_DeploymentStrategy,
 		deepCopy_api_DeploymentTriggerImageChangeParams,
 		deepCopy_api_DeploymentTriggerPolicy,
+		deepCopy_api_ExecInPodsHook,
 		deepCopy_api_ExecNewPodHook,
 		deepCopy_api_LifecycleHook,
 		deepCopy_api_RecreateDeploymentStrategyParams,
 		deepCopy_api_RollingDeploymentStrategyParams,
+		deepCopy_api_TagImageHook,
 		deepCopy_api_DockerConfig,
 		deepCopy_api_DockerImage,
 		deepCopy_api_Image,
@@ -3255,6 +3676,7 @@ func init() {
 		deepCopy_api_ImageImportStatus,
 		deepCopy_api_ImageLayer,
 		deepCopy_api_ImageList,
+		deepCopy_api_ImageSignature,
 		deepCopy_api_ImageStream,
 		deepCopy_api_ImageStreamImage,
 		deepCopy_api_ImageStreamImport,
@@ -3262,6 +3684,8 @@ func init() {
 		deepCopy_api_ImageStreamImportStatus,
 		deepCopy_api_ImageStreamList,
 		deepCopy_api_ImageStreamMapping,
+		deepCopy_api_ImageStreamNotification,
+		deepCopy_api_ImageStreamRetentionPolicy,
 		deepCopy_api_ImageStreamSpec,
 		deepCopy_api_ImageStreamStatus,
 		deepCopy_api_ImageStreamTag,
@@ -3281,6 +3705,10 @@ func init() {
 		deepCopy_api_OAuthClientAuthorization,
 		deepCopy_api_OAuthClientAuthorizationList,
 		deepCopy_api_OAuthClientList,
+		deepCopy_api_OAuthTOTPEnrollment,
+		deepCopy_api_OAuthTOTPEnrollmentList,
+		deepCopy_api_UserOAuthAccessToken,
+		deepCopy_api_UserOAuthAccessTokenList,
 		deepCopy_api_Project,
 		deepCopy_api_ProjectList,
 		deepCopy_api_ProjectRequest,
@@ -3300,6 +3728,12 @@ func init() {
 		deepCopy_api_NetNamespaceList,
 		deepCopy_api_Parameter,
 		deepCopy_api_Template,
+		deepCopy_api_TemplateInstance,
+		deepCopy_api_TemplateInstanceCondition,
+		deepCopy_api_TemplateInstanceList,
+		deepCopy_api_TemplateInstanceRequester,
+		deepCopy_api_TemplateInstanceSpec,
+		deepCopy_api_TemplateInstanceStatus,
 		deepCopy_api_TemplateList,
 		deepCopy_api_Group,
 		deepCopy_api_GroupList,
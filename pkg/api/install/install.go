@@ -11,6 +11,7 @@ import (
 	_ "github.com/openshift/origin/pkg/build/api/install"
 	_ "github.com/openshift/origin/pkg/cmd/server/api/install"
 	_ "github.com/openshift/origin/pkg/deploy/api/install"
+	_ "github.com/openshift/origin/pkg/image/admission/signatureverification/api/install"
 	_ "github.com/openshift/origin/pkg/image/api/install"
 	_ "github.com/openshift/origin/pkg/oauth/api/install"
 	_ "github.com/openshift/origin/pkg/project/admission/requestlimit/api/install"
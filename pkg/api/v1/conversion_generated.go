@@ -236,6 +236,14 @@ func autoConvert_api_LocalSubjectAccessReview_To_v1_LocalSubjectAccessReview(in
 	// in.Action has no peer in out
 	out.User = in.User
 	// in.Groups has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -464,6 +472,14 @@ func autoConvert_api_SubjectAccessReview_To_v1_SubjectAccessReview(in *authoriza
 	// in.Action has no peer in out
 	out.User = in.User
 	// in.Groups has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -687,6 +703,14 @@ func autoConvert_v1_LocalSubjectAccessReview_To_api_LocalSubjectAccessReview(in
 	// in.AuthorizationAttributes has no peer in out
 	out.User = in.User
 	// in.GroupsSlice has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -917,6 +941,14 @@ func autoConvert_v1_SubjectAccessReview_To_api_SubjectAccessReview(in *authoriza
 	// in.AuthorizationAttributes has no peer in out
 	out.User = in.User
 	// in.GroupsSlice has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -2941,6 +2973,27 @@ func Convert_api_DeploymentTriggerPolicy_To_v1_DeploymentTriggerPolicy(in *deplo
 	return autoConvert_api_DeploymentTriggerPolicy_To_v1_DeploymentTriggerPolicy(in, out, s)
 }
 
+func autoConvert_api_ExecInPodsHook_To_v1_ExecInPodsHook(in *deployapi.ExecInPodsHook, out *deployapiv1.ExecInPodsHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapi.ExecInPodsHook))(in)
+	}
+	if in.Command != nil {
+		out.Command = make([]string, len(in.Command))
+		for i := range in.Command {
+			out.Command[i] = in.Command[i]
+		}
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.MaxPods = in.MaxPods
+	return nil
+}
+
+func Convert_api_ExecInPodsHook_To_v1_ExecInPodsHook(in *deployapi.ExecInPodsHook, out *deployapiv1.ExecInPodsHook, s conversion.Scope) error {
+	return autoConvert_api_ExecInPodsHook_To_v1_ExecInPodsHook(in, out, s)
+}
+
 func autoConvert_api_ExecNewPodHook_To_v1_ExecNewPodHook(in *deployapi.ExecNewPodHook, out *deployapiv1.ExecNewPodHook, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapi.ExecNewPodHook))(in)
@@ -2993,6 +3046,25 @@ func autoConvert_api_LifecycleHook_To_v1_LifecycleHook(in *deployapi.LifecycleHo
 	} else {
 		out.ExecNewPod = nil
 	}
+	if in.TagImages != nil {
+		out.TagImages = make([]deployapiv1.TagImageHook, len(in.TagImages))
+		for i := range in.TagImages {
+			if err := Convert_api_TagImageHook_To_v1_TagImageHook(&in.TagImages[i], &out.TagImages[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.TagImages = nil
+	}
+	// unable to generate simple pointer conversion for api.ExecInPodsHook -> v1.ExecInPodsHook
+	if in.ExecInPods != nil {
+		out.ExecInPods = new(deployapiv1.ExecInPodsHook)
+		if err := Convert_api_ExecInPodsHook_To_v1_ExecInPodsHook(in.ExecInPods, out.ExecInPods, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPods = nil
+	}
 	return nil
 }
 
@@ -3088,6 +3160,15 @@ func autoConvert_api_RollingDeploymentStrategyParams_To_v1_RollingDeploymentStra
 		out.Pre = nil
 	}
 	// unable to generate simple pointer conversion for api.LifecycleHook -> v1.LifecycleHook
+	if in.Mid != nil {
+		out.Mid = new(deployapiv1.LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(in.Mid, out.Mid, s); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
+	// unable to generate simple pointer conversion for api.LifecycleHook -> v1.LifecycleHook
 	if in.Post != nil {
 		out.Post = new(deployapiv1.LifecycleHook)
 		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(in.Post, out.Post, s); err != nil {
@@ -3096,9 +3177,30 @@ func autoConvert_api_RollingDeploymentStrategyParams_To_v1_RollingDeploymentStra
 	} else {
 		out.Post = nil
 	}
+	if in.PauseAfterPercent != nil {
+		out.PauseAfterPercent = new(int)
+		*out.PauseAfterPercent = *in.PauseAfterPercent
+	} else {
+		out.PauseAfterPercent = nil
+	}
+	return nil
+}
+
+func autoConvert_api_TagImageHook_To_v1_TagImageHook(in *deployapi.TagImageHook, out *deployapiv1.TagImageHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapi.TagImageHook))(in)
+	}
+	out.ContainerName = in.ContainerName
+	if err := Convert_api_ObjectReference_To_v1_ObjectReference(&in.To, &out.To, s); err != nil {
+		return err
+	}
 	return nil
 }
 
+func Convert_api_TagImageHook_To_v1_TagImageHook(in *deployapi.TagImageHook, out *deployapiv1.TagImageHook, s conversion.Scope) error {
+	return autoConvert_api_TagImageHook_To_v1_TagImageHook(in, out, s)
+}
+
 func autoConvert_v1_CustomDeploymentStrategyParams_To_api_CustomDeploymentStrategyParams(in *deployapiv1.CustomDeploymentStrategyParams, out *deployapi.CustomDeploymentStrategyParams, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapiv1.CustomDeploymentStrategyParams))(in)
@@ -3483,6 +3585,27 @@ func Convert_v1_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy(in *deplo
 	return autoConvert_v1_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy(in, out, s)
 }
 
+func autoConvert_v1_ExecInPodsHook_To_api_ExecInPodsHook(in *deployapiv1.ExecInPodsHook, out *deployapi.ExecInPodsHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapiv1.ExecInPodsHook))(in)
+	}
+	if in.Command != nil {
+		out.Command = make([]string, len(in.Command))
+		for i := range in.Command {
+			out.Command[i] = in.Command[i]
+		}
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.MaxPods = in.MaxPods
+	return nil
+}
+
+func Convert_v1_ExecInPodsHook_To_api_ExecInPodsHook(in *deployapiv1.ExecInPodsHook, out *deployapi.ExecInPodsHook, s conversion.Scope) error {
+	return autoConvert_v1_ExecInPodsHook_To_api_ExecInPodsHook(in, out, s)
+}
+
 func autoConvert_v1_ExecNewPodHook_To_api_ExecNewPodHook(in *deployapiv1.ExecNewPodHook, out *deployapi.ExecNewPodHook, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapiv1.ExecNewPodHook))(in)
@@ -3535,6 +3658,25 @@ func autoConvert_v1_LifecycleHook_To_api_LifecycleHook(in *deployapiv1.Lifecycle
 	} else {
 		out.ExecNewPod = nil
 	}
+	if in.TagImages != nil {
+		out.TagImages = make([]deployapi.TagImageHook, len(in.TagImages))
+		for i := range in.TagImages {
+			if err := Convert_v1_TagImageHook_To_api_TagImageHook(&in.TagImages[i], &out.TagImages[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.TagImages = nil
+	}
+	// unable to generate simple pointer conversion for v1.ExecInPodsHook -> api.ExecInPodsHook
+	if in.ExecInPods != nil {
+		out.ExecInPods = new(deployapi.ExecInPodsHook)
+		if err := Convert_v1_ExecInPodsHook_To_api_ExecInPodsHook(in.ExecInPods, out.ExecInPods, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPods = nil
+	}
 	return nil
 }
 
@@ -3626,6 +3768,15 @@ func autoConvert_v1_RollingDeploymentStrategyParams_To_api_RollingDeploymentStra
 		out.Pre = nil
 	}
 	// unable to generate simple pointer conversion for v1.LifecycleHook -> api.LifecycleHook
+	if in.Mid != nil {
+		out.Mid = new(deployapi.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(in.Mid, out.Mid, s); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
+	// unable to generate simple pointer conversion for v1.LifecycleHook -> api.LifecycleHook
 	if in.Post != nil {
 		out.Post = new(deployapi.LifecycleHook)
 		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(in.Post, out.Post, s); err != nil {
@@ -3634,9 +3785,30 @@ func autoConvert_v1_RollingDeploymentStrategyParams_To_api_RollingDeploymentStra
 	} else {
 		out.Post = nil
 	}
+	if in.PauseAfterPercent != nil {
+		out.PauseAfterPercent = new(int)
+		*out.PauseAfterPercent = *in.PauseAfterPercent
+	} else {
+		out.PauseAfterPercent = nil
+	}
+	return nil
+}
+
+func autoConvert_v1_TagImageHook_To_api_TagImageHook(in *deployapiv1.TagImageHook, out *deployapi.TagImageHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapiv1.TagImageHook))(in)
+	}
+	out.ContainerName = in.ContainerName
+	if err := Convert_v1_ObjectReference_To_api_ObjectReference(&in.To, &out.To, s); err != nil {
+		return err
+	}
 	return nil
 }
 
+func Convert_v1_TagImageHook_To_api_TagImageHook(in *deployapiv1.TagImageHook, out *deployapi.TagImageHook, s conversion.Scope) error {
+	return autoConvert_v1_TagImageHook_To_api_TagImageHook(in, out, s)
+}
+
 func autoConvert_api_Image_To_v1_Image(in *imageapi.Image, out *imageapiv1.Image, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapi.Image))(in)
@@ -3650,6 +3822,8 @@ func autoConvert_api_Image_To_v1_Image(in *imageapi.Image, out *imageapiv1.Image
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		out.DockerImageLayers = make([]imageapiv1.ImageLayer, len(in.DockerImageLayers))
 		for i := range in.DockerImageLayers {
@@ -3660,6 +3834,16 @@ func autoConvert_api_Image_To_v1_Image(in *imageapi.Image, out *imageapiv1.Image
 	} else {
 		out.DockerImageLayers = nil
 	}
+	if in.Signatures != nil {
+		out.Signatures = make([]imageapiv1.ImageSignature, len(in.Signatures))
+		for i := range in.Signatures {
+			if err := Convert_api_ImageSignature_To_v1_ImageSignature(&in.Signatures[i], &out.Signatures[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Signatures = nil
+	}
 	return nil
 }
 
@@ -3737,6 +3921,24 @@ func Convert_api_ImageList_To_v1_ImageList(in *imageapi.ImageList, out *imageapi
 	return autoConvert_api_ImageList_To_v1_ImageList(in, out, s)
 }
 
+func autoConvert_api_ImageSignature_To_v1_ImageSignature(in *imageapi.ImageSignature, out *imageapiv1.ImageSignature, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapi.ImageSignature))(in)
+	}
+	if err := Convert_api_ObjectMeta_To_v1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	out.Type = in.Type
+	if err := conversion.ByteSliceCopy(&in.Content, &out.Content, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_ImageSignature_To_v1_ImageSignature(in *imageapi.ImageSignature, out *imageapiv1.ImageSignature, s conversion.Scope) error {
+	return autoConvert_api_ImageSignature_To_v1_ImageSignature(in, out, s)
+}
+
 func autoConvert_api_ImageStream_To_v1_ImageStream(in *imageapi.ImageStream, out *imageapiv1.ImageStream, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapi.ImageStream))(in)
@@ -3903,6 +4105,40 @@ func autoConvert_api_ImageStreamMapping_To_v1_ImageStreamMapping(in *imageapi.Im
 	return nil
 }
 
+func autoConvert_api_ImageStreamNotification_To_v1_ImageStreamNotification(in *imageapi.ImageStreamNotification, out *imageapiv1.ImageStreamNotification, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapi.ImageStreamNotification))(in)
+	}
+	out.URL = in.URL
+	out.SecretName = in.SecretName
+	return nil
+}
+
+func Convert_api_ImageStreamNotification_To_v1_ImageStreamNotification(in *imageapi.ImageStreamNotification, out *imageapiv1.ImageStreamNotification, s conversion.Scope) error {
+	return autoConvert_api_ImageStreamNotification_To_v1_ImageStreamNotification(in, out, s)
+}
+
+func autoConvert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy(in *imageapi.ImageStreamRetentionPolicy, out *imageapiv1.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapi.ImageStreamRetentionPolicy))(in)
+	}
+	out.KeepTagRevisions = in.KeepTagRevisions
+	out.KeepYoungerThanSeconds = in.KeepYoungerThanSeconds
+	if in.ProtectedTags != nil {
+		out.ProtectedTags = make([]string, len(in.ProtectedTags))
+		for i := range in.ProtectedTags {
+			out.ProtectedTags[i] = in.ProtectedTags[i]
+		}
+	} else {
+		out.ProtectedTags = nil
+	}
+	return nil
+}
+
+func Convert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy(in *imageapi.ImageStreamRetentionPolicy, out *imageapiv1.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy(in, out, s)
+}
+
 func autoConvert_api_ImageStreamSpec_To_v1_ImageStreamSpec(in *imageapi.ImageStreamSpec, out *imageapiv1.ImageStreamSpec, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapi.ImageStreamSpec))(in)
@@ -3911,6 +4147,25 @@ func autoConvert_api_ImageStreamSpec_To_v1_ImageStreamSpec(in *imageapi.ImageStr
 	if err := s.Convert(&in.Tags, &out.Tags, 0); err != nil {
 		return err
 	}
+	// unable to generate simple pointer conversion for api.ImageStreamRetentionPolicy -> v1.ImageStreamRetentionPolicy
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = new(imageapiv1.ImageStreamRetentionPolicy)
+		if err := Convert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy(in.RetentionPolicy, out.RetentionPolicy, s); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
+	if in.Notifications != nil {
+		out.Notifications = make([]imageapiv1.ImageStreamNotification, len(in.Notifications))
+		for i := range in.Notifications {
+			if err := Convert_api_ImageStreamNotification_To_v1_ImageStreamNotification(&in.Notifications[i], &out.Notifications[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Notifications = nil
+	}
 	return nil
 }
 
@@ -4042,6 +4297,8 @@ func autoConvert_v1_Image_To_api_Image(in *imageapiv1.Image, out *imageapi.Image
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		out.DockerImageLayers = make([]imageapi.ImageLayer, len(in.DockerImageLayers))
 		for i := range in.DockerImageLayers {
@@ -4052,6 +4309,16 @@ func autoConvert_v1_Image_To_api_Image(in *imageapiv1.Image, out *imageapi.Image
 	} else {
 		out.DockerImageLayers = nil
 	}
+	if in.Signatures != nil {
+		out.Signatures = make([]imageapi.ImageSignature, len(in.Signatures))
+		for i := range in.Signatures {
+			if err := Convert_v1_ImageSignature_To_api_ImageSignature(&in.Signatures[i], &out.Signatures[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Signatures = nil
+	}
 	return nil
 }
 
@@ -4129,6 +4396,24 @@ func Convert_v1_ImageList_To_api_ImageList(in *imageapiv1.ImageList, out *imagea
 	return autoConvert_v1_ImageList_To_api_ImageList(in, out, s)
 }
 
+func autoConvert_v1_ImageSignature_To_api_ImageSignature(in *imageapiv1.ImageSignature, out *imageapi.ImageSignature, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapiv1.ImageSignature))(in)
+	}
+	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	out.Type = in.Type
+	if err := conversion.ByteSliceCopy(&in.Content, &out.Content, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_ImageSignature_To_api_ImageSignature(in *imageapiv1.ImageSignature, out *imageapi.ImageSignature, s conversion.Scope) error {
+	return autoConvert_v1_ImageSignature_To_api_ImageSignature(in, out, s)
+}
+
 func autoConvert_v1_ImageStream_To_api_ImageStream(in *imageapiv1.ImageStream, out *imageapi.ImageStream, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapiv1.ImageStream))(in)
@@ -4294,6 +4579,40 @@ func autoConvert_v1_ImageStreamMapping_To_api_ImageStreamMapping(in *imageapiv1.
 	return nil
 }
 
+func autoConvert_v1_ImageStreamNotification_To_api_ImageStreamNotification(in *imageapiv1.ImageStreamNotification, out *imageapi.ImageStreamNotification, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapiv1.ImageStreamNotification))(in)
+	}
+	out.URL = in.URL
+	out.SecretName = in.SecretName
+	return nil
+}
+
+func Convert_v1_ImageStreamNotification_To_api_ImageStreamNotification(in *imageapiv1.ImageStreamNotification, out *imageapi.ImageStreamNotification, s conversion.Scope) error {
+	return autoConvert_v1_ImageStreamNotification_To_api_ImageStreamNotification(in, out, s)
+}
+
+func autoConvert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in *imageapiv1.ImageStreamRetentionPolicy, out *imageapi.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapiv1.ImageStreamRetentionPolicy))(in)
+	}
+	out.KeepTagRevisions = in.KeepTagRevisions
+	out.KeepYoungerThanSeconds = in.KeepYoungerThanSeconds
+	if in.ProtectedTags != nil {
+		out.ProtectedTags = make([]string, len(in.ProtectedTags))
+		for i := range in.ProtectedTags {
+			out.ProtectedTags[i] = in.ProtectedTags[i]
+		}
+	} else {
+		out.ProtectedTags = nil
+	}
+	return nil
+}
+
+func Convert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in *imageapiv1.ImageStreamRetentionPolicy, out *imageapi.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in, out, s)
+}
+
 func autoConvert_v1_ImageStreamSpec_To_api_ImageStreamSpec(in *imageapiv1.ImageStreamSpec, out *imageapi.ImageStreamSpec, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapiv1.ImageStreamSpec))(in)
@@ -4302,6 +4621,25 @@ func autoConvert_v1_ImageStreamSpec_To_api_ImageStreamSpec(in *imageapiv1.ImageS
 	if err := s.Convert(&in.Tags, &out.Tags, 0); err != nil {
 		return err
 	}
+	// unable to generate simple pointer conversion for v1.ImageStreamRetentionPolicy -> api.ImageStreamRetentionPolicy
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = new(imageapi.ImageStreamRetentionPolicy)
+		if err := Convert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in.RetentionPolicy, out.RetentionPolicy, s); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
+	if in.Notifications != nil {
+		out.Notifications = make([]imageapi.ImageStreamNotification, len(in.Notifications))
+		for i := range in.Notifications {
+			if err := Convert_v1_ImageStreamNotification_To_api_ImageStreamNotification(&in.Notifications[i], &out.Notifications[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Notifications = nil
+	}
 	return nil
 }
 
@@ -4442,6 +4780,15 @@ func autoConvert_api_OAuthAccessToken_To_v1_OAuthAccessToken(in *oauthapi.OAuthA
 	out.UserUID = in.UserUID
 	out.AuthorizeToken = in.AuthorizeToken
 	out.RefreshToken = in.RefreshToken
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LastUsedTimestamp != nil {
+		out.LastUsedTimestamp = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LastUsedTimestamp, out.LastUsedTimestamp, s); err != nil {
+			return err
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
 	return nil
 }
 
@@ -4622,46 +4969,54 @@ func Convert_api_OAuthClientList_To_v1_OAuthClientList(in *oauthapi.OAuthClientL
 	return autoConvert_api_OAuthClientList_To_v1_OAuthClientList(in, out, s)
 }
 
-func autoConvert_v1_OAuthAccessToken_To_api_OAuthAccessToken(in *oauthapiv1.OAuthAccessToken, out *oauthapi.OAuthAccessToken, s conversion.Scope) error {
+func autoConvert_api_OAuthTOTPEnrollment_To_v1_OAuthTOTPEnrollment(in *oauthapi.OAuthTOTPEnrollment, out *oauthapiv1.OAuthTOTPEnrollment, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
-		defaulting.(func(*oauthapiv1.OAuthAccessToken))(in)
+		defaulting.(func(*oauthapi.OAuthTOTPEnrollment))(in)
 	}
-	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+	if err := Convert_api_ObjectMeta_To_v1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
 		return err
 	}
-	out.ClientName = in.ClientName
-	out.ExpiresIn = in.ExpiresIn
-	if in.Scopes != nil {
-		out.Scopes = make([]string, len(in.Scopes))
-		for i := range in.Scopes {
-			out.Scopes[i] = in.Scopes[i]
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.Secret = in.Secret
+	out.Confirmed = in.Confirmed
+	if in.RecoveryCodeHashes != nil {
+		out.RecoveryCodeHashes = make([]string, len(in.RecoveryCodeHashes))
+		for i := range in.RecoveryCodeHashes {
+			out.RecoveryCodeHashes[i] = in.RecoveryCodeHashes[i]
 		}
 	} else {
-		out.Scopes = nil
+		out.RecoveryCodeHashes = nil
+	}
+	out.LastUsedTimeStep = in.LastUsedTimeStep
+	out.FailedAttempts = in.FailedAttempts
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LockedUntil != nil {
+		out.LockedUntil = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LockedUntil, out.LockedUntil, s); err != nil {
+			return err
+		}
+	} else {
+		out.LockedUntil = nil
 	}
-	out.RedirectURI = in.RedirectURI
-	out.UserName = in.UserName
-	out.UserUID = in.UserUID
-	out.AuthorizeToken = in.AuthorizeToken
-	out.RefreshToken = in.RefreshToken
 	return nil
 }
 
-func Convert_v1_OAuthAccessToken_To_api_OAuthAccessToken(in *oauthapiv1.OAuthAccessToken, out *oauthapi.OAuthAccessToken, s conversion.Scope) error {
-	return autoConvert_v1_OAuthAccessToken_To_api_OAuthAccessToken(in, out, s)
+func Convert_api_OAuthTOTPEnrollment_To_v1_OAuthTOTPEnrollment(in *oauthapi.OAuthTOTPEnrollment, out *oauthapiv1.OAuthTOTPEnrollment, s conversion.Scope) error {
+	return autoConvert_api_OAuthTOTPEnrollment_To_v1_OAuthTOTPEnrollment(in, out, s)
 }
 
-func autoConvert_v1_OAuthAccessTokenList_To_api_OAuthAccessTokenList(in *oauthapiv1.OAuthAccessTokenList, out *oauthapi.OAuthAccessTokenList, s conversion.Scope) error {
+func autoConvert_api_OAuthTOTPEnrollmentList_To_v1_OAuthTOTPEnrollmentList(in *oauthapi.OAuthTOTPEnrollmentList, out *oauthapiv1.OAuthTOTPEnrollmentList, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
-		defaulting.(func(*oauthapiv1.OAuthAccessTokenList))(in)
+		defaulting.(func(*oauthapi.OAuthTOTPEnrollmentList))(in)
 	}
 	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
 		return err
 	}
 	if in.Items != nil {
-		out.Items = make([]oauthapi.OAuthAccessToken, len(in.Items))
+		out.Items = make([]oauthapiv1.OAuthTOTPEnrollment, len(in.Items))
 		for i := range in.Items {
-			if err := Convert_v1_OAuthAccessToken_To_api_OAuthAccessToken(&in.Items[i], &out.Items[i], s); err != nil {
+			if err := Convert_api_OAuthTOTPEnrollment_To_v1_OAuthTOTPEnrollment(&in.Items[i], &out.Items[i], s); err != nil {
 				return err
 			}
 		}
@@ -4671,15 +5026,139 @@ func autoConvert_v1_OAuthAccessTokenList_To_api_OAuthAccessTokenList(in *oauthap
 	return nil
 }
 
-func Convert_v1_OAuthAccessTokenList_To_api_OAuthAccessTokenList(in *oauthapiv1.OAuthAccessTokenList, out *oauthapi.OAuthAccessTokenList, s conversion.Scope) error {
-	return autoConvert_v1_OAuthAccessTokenList_To_api_OAuthAccessTokenList(in, out, s)
+func Convert_api_OAuthTOTPEnrollmentList_To_v1_OAuthTOTPEnrollmentList(in *oauthapi.OAuthTOTPEnrollmentList, out *oauthapiv1.OAuthTOTPEnrollmentList, s conversion.Scope) error {
+	return autoConvert_api_OAuthTOTPEnrollmentList_To_v1_OAuthTOTPEnrollmentList(in, out, s)
 }
 
-func autoConvert_v1_OAuthAuthorizeToken_To_api_OAuthAuthorizeToken(in *oauthapiv1.OAuthAuthorizeToken, out *oauthapi.OAuthAuthorizeToken, s conversion.Scope) error {
+func autoConvert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(in *oauthapi.UserOAuthAccessToken, out *oauthapiv1.UserOAuthAccessToken, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
-		defaulting.(func(*oauthapiv1.OAuthAuthorizeToken))(in)
+		defaulting.(func(*oauthapi.UserOAuthAccessToken))(in)
 	}
-	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+	if err := Convert_api_ObjectMeta_To_v1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LastUsedTimestamp != nil {
+		out.LastUsedTimestamp = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LastUsedTimestamp, out.LastUsedTimestamp, s); err != nil {
+			return err
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
+	return nil
+}
+
+func Convert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(in *oauthapi.UserOAuthAccessToken, out *oauthapiv1.UserOAuthAccessToken, s conversion.Scope) error {
+	return autoConvert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(in, out, s)
+}
+
+func autoConvert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList(in *oauthapi.UserOAuthAccessTokenList, out *oauthapiv1.UserOAuthAccessTokenList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapi.UserOAuthAccessTokenList))(in)
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapiv1.UserOAuthAccessToken, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList(in *oauthapi.UserOAuthAccessTokenList, out *oauthapiv1.UserOAuthAccessTokenList, s conversion.Scope) error {
+	return autoConvert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList(in, out, s)
+}
+
+func autoConvert_v1_OAuthAccessToken_To_api_OAuthAccessToken(in *oauthapiv1.OAuthAccessToken, out *oauthapi.OAuthAccessToken, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapiv1.OAuthAccessToken))(in)
+	}
+	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LastUsedTimestamp != nil {
+		out.LastUsedTimestamp = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LastUsedTimestamp, out.LastUsedTimestamp, s); err != nil {
+			return err
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
+	return nil
+}
+
+func Convert_v1_OAuthAccessToken_To_api_OAuthAccessToken(in *oauthapiv1.OAuthAccessToken, out *oauthapi.OAuthAccessToken, s conversion.Scope) error {
+	return autoConvert_v1_OAuthAccessToken_To_api_OAuthAccessToken(in, out, s)
+}
+
+func autoConvert_v1_OAuthAccessTokenList_To_api_OAuthAccessTokenList(in *oauthapiv1.OAuthAccessTokenList, out *oauthapi.OAuthAccessTokenList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapiv1.OAuthAccessTokenList))(in)
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapi.OAuthAccessToken, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_v1_OAuthAccessToken_To_api_OAuthAccessToken(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_v1_OAuthAccessTokenList_To_api_OAuthAccessTokenList(in *oauthapiv1.OAuthAccessTokenList, out *oauthapi.OAuthAccessTokenList, s conversion.Scope) error {
+	return autoConvert_v1_OAuthAccessTokenList_To_api_OAuthAccessTokenList(in, out, s)
+}
+
+func autoConvert_v1_OAuthAuthorizeToken_To_api_OAuthAuthorizeToken(in *oauthapiv1.OAuthAuthorizeToken, out *oauthapi.OAuthAuthorizeToken, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapiv1.OAuthAuthorizeToken))(in)
+	}
+	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
 		return err
 	}
 	out.ClientName = in.ClientName
@@ -4824,6 +5303,129 @@ func Convert_v1_OAuthClientList_To_api_OAuthClientList(in *oauthapiv1.OAuthClien
 	return autoConvert_v1_OAuthClientList_To_api_OAuthClientList(in, out, s)
 }
 
+func autoConvert_v1_OAuthTOTPEnrollment_To_api_OAuthTOTPEnrollment(in *oauthapiv1.OAuthTOTPEnrollment, out *oauthapi.OAuthTOTPEnrollment, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapiv1.OAuthTOTPEnrollment))(in)
+	}
+	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.Secret = in.Secret
+	out.Confirmed = in.Confirmed
+	if in.RecoveryCodeHashes != nil {
+		out.RecoveryCodeHashes = make([]string, len(in.RecoveryCodeHashes))
+		for i := range in.RecoveryCodeHashes {
+			out.RecoveryCodeHashes[i] = in.RecoveryCodeHashes[i]
+		}
+	} else {
+		out.RecoveryCodeHashes = nil
+	}
+	out.LastUsedTimeStep = in.LastUsedTimeStep
+	out.FailedAttempts = in.FailedAttempts
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LockedUntil != nil {
+		out.LockedUntil = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LockedUntil, out.LockedUntil, s); err != nil {
+			return err
+		}
+	} else {
+		out.LockedUntil = nil
+	}
+	return nil
+}
+
+func Convert_v1_OAuthTOTPEnrollment_To_api_OAuthTOTPEnrollment(in *oauthapiv1.OAuthTOTPEnrollment, out *oauthapi.OAuthTOTPEnrollment, s conversion.Scope) error {
+	return autoConvert_v1_OAuthTOTPEnrollment_To_api_OAuthTOTPEnrollment(in, out, s)
+}
+
+func autoConvert_v1_OAuthTOTPEnrollmentList_To_api_OAuthTOTPEnrollmentList(in *oauthapiv1.OAuthTOTPEnrollmentList, out *oauthapi.OAuthTOTPEnrollmentList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapiv1.OAuthTOTPEnrollmentList))(in)
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapi.OAuthTOTPEnrollment, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_v1_OAuthTOTPEnrollment_To_api_OAuthTOTPEnrollment(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_v1_OAuthTOTPEnrollmentList_To_api_OAuthTOTPEnrollmentList(in *oauthapiv1.OAuthTOTPEnrollmentList, out *oauthapi.OAuthTOTPEnrollmentList, s conversion.Scope) error {
+	return autoConvert_v1_OAuthTOTPEnrollmentList_To_api_OAuthTOTPEnrollmentList(in, out, s)
+}
+
+func autoConvert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(in *oauthapiv1.UserOAuthAccessToken, out *oauthapi.UserOAuthAccessToken, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapiv1.UserOAuthAccessToken))(in)
+	}
+	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LastUsedTimestamp != nil {
+		out.LastUsedTimestamp = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LastUsedTimestamp, out.LastUsedTimestamp, s); err != nil {
+			return err
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
+	return nil
+}
+
+func Convert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(in *oauthapiv1.UserOAuthAccessToken, out *oauthapi.UserOAuthAccessToken, s conversion.Scope) error {
+	return autoConvert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(in, out, s)
+}
+
+func autoConvert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList(in *oauthapiv1.UserOAuthAccessTokenList, out *oauthapi.UserOAuthAccessTokenList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*oauthapiv1.UserOAuthAccessTokenList))(in)
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapi.UserOAuthAccessToken, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList(in *oauthapiv1.UserOAuthAccessTokenList, out *oauthapi.UserOAuthAccessTokenList, s conversion.Scope) error {
+	return autoConvert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList(in, out, s)
+}
+
 func autoConvert_api_Project_To_v1_Project(in *projectapi.Project, out *projectapiv1.Project, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*projectapi.Project))(in)
@@ -5501,6 +6103,18 @@ func autoConvert_api_Parameter_To_v1_Parameter(in *templateapi.Parameter, out *t
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = templateapiv1.ParameterType(in.Type)
+	out.Pattern = in.Pattern
+	if in.AllowedValues != nil {
+		out.AllowedValues = make([]string, len(in.AllowedValues))
+		for i := range in.AllowedValues {
+			out.AllowedValues[i] = in.AllowedValues[i]
+		}
+	} else {
+		out.AllowedValues = nil
+	}
+	out.MinLength = in.MinLength
+	out.MaxLength = in.MaxLength
 	return nil
 }
 
@@ -5539,6 +6153,161 @@ func autoConvert_api_Template_To_v1_Template(in *templateapi.Template, out *temp
 	return nil
 }
 
+func autoConvert_api_TemplateInstance_To_v1_TemplateInstance(in *templateapi.TemplateInstance, out *templateapiv1.TemplateInstance, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapi.TemplateInstance))(in)
+	}
+	if err := Convert_api_ObjectMeta_To_v1_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := Convert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstance_To_v1_TemplateInstance(in *templateapi.TemplateInstance, out *templateapiv1.TemplateInstance, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstance_To_v1_TemplateInstance(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceCondition_To_v1_TemplateInstanceCondition(in *templateapi.TemplateInstanceCondition, out *templateapiv1.TemplateInstanceCondition, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapi.TemplateInstanceCondition))(in)
+	}
+	out.Type = templateapiv1.TemplateInstanceConditionType(in.Type)
+	out.Status = apiv1.ConditionStatus(in.Status)
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.LastTransitionTime, &out.LastTransitionTime, s); err != nil {
+		return err
+	}
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+func Convert_api_TemplateInstanceCondition_To_v1_TemplateInstanceCondition(in *templateapi.TemplateInstanceCondition, out *templateapiv1.TemplateInstanceCondition, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceCondition_To_v1_TemplateInstanceCondition(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceList_To_v1_TemplateInstanceList(in *templateapi.TemplateInstanceList, out *templateapiv1.TemplateInstanceList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapi.TemplateInstanceList))(in)
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]templateapiv1.TemplateInstance, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_api_TemplateInstance_To_v1_TemplateInstance(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstanceList_To_v1_TemplateInstanceList(in *templateapi.TemplateInstanceList, out *templateapiv1.TemplateInstanceList, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceList_To_v1_TemplateInstanceList(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceRequester_To_v1_TemplateInstanceRequester(in *templateapi.TemplateInstanceRequester, out *templateapiv1.TemplateInstanceRequester, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapi.TemplateInstanceRequester))(in)
+	}
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstanceRequester_To_v1_TemplateInstanceRequester(in *templateapi.TemplateInstanceRequester, out *templateapiv1.TemplateInstanceRequester, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceRequester_To_v1_TemplateInstanceRequester(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(in *templateapi.TemplateInstanceSpec, out *templateapiv1.TemplateInstanceSpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapi.TemplateInstanceSpec))(in)
+	}
+	if err := s.Convert(&in.Template, &out.Template, 0); err != nil {
+		return err
+	}
+	// unable to generate simple pointer conversion for api.LocalObjectReference -> v1.LocalObjectReference
+	if in.Secret != nil {
+		out.Secret = new(apiv1.LocalObjectReference)
+		if err := Convert_api_LocalObjectReference_To_v1_LocalObjectReference(in.Secret, out.Secret, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	// unable to generate simple pointer conversion for api.TemplateInstanceRequester -> v1.TemplateInstanceRequester
+	if in.Requester != nil {
+		out.Requester = new(templateapiv1.TemplateInstanceRequester)
+		if err := Convert_api_TemplateInstanceRequester_To_v1_TemplateInstanceRequester(in.Requester, out.Requester, s); err != nil {
+			return err
+		}
+	} else {
+		out.Requester = nil
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(in *templateapi.TemplateInstanceSpec, out *templateapiv1.TemplateInstanceSpec, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec(in, out, s)
+}
+
+func autoConvert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(in *templateapi.TemplateInstanceStatus, out *templateapiv1.TemplateInstanceStatus, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapi.TemplateInstanceStatus))(in)
+	}
+	out.ObservedGeneration = in.ObservedGeneration
+	out.ObservedSecretResourceVersion = in.ObservedSecretResourceVersion
+	if in.Conditions != nil {
+		out.Conditions = make([]templateapiv1.TemplateInstanceCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := Convert_api_TemplateInstanceCondition_To_v1_TemplateInstanceCondition(&in.Conditions[i], &out.Conditions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.Objects != nil {
+		out.Objects = make([]apiv1.ObjectReference, len(in.Objects))
+		for i := range in.Objects {
+			if err := Convert_api_ObjectReference_To_v1_ObjectReference(&in.Objects[i], &out.Objects[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Objects = nil
+	}
+	return nil
+}
+
+func Convert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(in *templateapi.TemplateInstanceStatus, out *templateapiv1.TemplateInstanceStatus, s conversion.Scope) error {
+	return autoConvert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus(in, out, s)
+}
+
 func autoConvert_api_TemplateList_To_v1_TemplateList(in *templateapi.TemplateList, out *templateapiv1.TemplateList, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*templateapi.TemplateList))(in)
@@ -5574,6 +6343,18 @@ func autoConvert_v1_Parameter_To_api_Parameter(in *templateapiv1.Parameter, out
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = templateapi.ParameterType(in.Type)
+	out.Pattern = in.Pattern
+	if in.AllowedValues != nil {
+		out.AllowedValues = make([]string, len(in.AllowedValues))
+		for i := range in.AllowedValues {
+			out.AllowedValues[i] = in.AllowedValues[i]
+		}
+	} else {
+		out.AllowedValues = nil
+	}
+	out.MinLength = in.MinLength
+	out.MaxLength = in.MaxLength
 	return nil
 }
 
@@ -5619,6 +6400,161 @@ func autoConvert_v1_Template_To_api_Template(in *templateapiv1.Template, out *te
 	return nil
 }
 
+func autoConvert_v1_TemplateInstance_To_api_TemplateInstance(in *templateapiv1.TemplateInstance, out *templateapi.TemplateInstance, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapiv1.TemplateInstance))(in)
+	}
+	if err := Convert_v1_ObjectMeta_To_api_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstance_To_api_TemplateInstance(in *templateapiv1.TemplateInstance, out *templateapi.TemplateInstance, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstance_To_api_TemplateInstance(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceCondition_To_api_TemplateInstanceCondition(in *templateapiv1.TemplateInstanceCondition, out *templateapi.TemplateInstanceCondition, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapiv1.TemplateInstanceCondition))(in)
+	}
+	out.Type = templateapi.TemplateInstanceConditionType(in.Type)
+	out.Status = api.ConditionStatus(in.Status)
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.LastTransitionTime, &out.LastTransitionTime, s); err != nil {
+		return err
+	}
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+func Convert_v1_TemplateInstanceCondition_To_api_TemplateInstanceCondition(in *templateapiv1.TemplateInstanceCondition, out *templateapi.TemplateInstanceCondition, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceCondition_To_api_TemplateInstanceCondition(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceList_To_api_TemplateInstanceList(in *templateapiv1.TemplateInstanceList, out *templateapi.TemplateInstanceList, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapiv1.TemplateInstanceList))(in)
+	}
+	if err := api.Convert_unversioned_ListMeta_To_unversioned_ListMeta(&in.ListMeta, &out.ListMeta, s); err != nil {
+		return err
+	}
+	if in.Items != nil {
+		out.Items = make([]templateapi.TemplateInstance, len(in.Items))
+		for i := range in.Items {
+			if err := Convert_v1_TemplateInstance_To_api_TemplateInstance(&in.Items[i], &out.Items[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstanceList_To_api_TemplateInstanceList(in *templateapiv1.TemplateInstanceList, out *templateapi.TemplateInstanceList, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceList_To_api_TemplateInstanceList(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceRequester_To_api_TemplateInstanceRequester(in *templateapiv1.TemplateInstanceRequester, out *templateapi.TemplateInstanceRequester, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapiv1.TemplateInstanceRequester))(in)
+	}
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstanceRequester_To_api_TemplateInstanceRequester(in *templateapiv1.TemplateInstanceRequester, out *templateapi.TemplateInstanceRequester, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceRequester_To_api_TemplateInstanceRequester(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(in *templateapiv1.TemplateInstanceSpec, out *templateapi.TemplateInstanceSpec, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapiv1.TemplateInstanceSpec))(in)
+	}
+	if err := s.Convert(&in.Template, &out.Template, 0); err != nil {
+		return err
+	}
+	// unable to generate simple pointer conversion for v1.LocalObjectReference -> api.LocalObjectReference
+	if in.Secret != nil {
+		out.Secret = new(api.LocalObjectReference)
+		if err := Convert_v1_LocalObjectReference_To_api_LocalObjectReference(in.Secret, out.Secret, s); err != nil {
+			return err
+		}
+	} else {
+		out.Secret = nil
+	}
+	// unable to generate simple pointer conversion for v1.TemplateInstanceRequester -> api.TemplateInstanceRequester
+	if in.Requester != nil {
+		out.Requester = new(templateapi.TemplateInstanceRequester)
+		if err := Convert_v1_TemplateInstanceRequester_To_api_TemplateInstanceRequester(in.Requester, out.Requester, s); err != nil {
+			return err
+		}
+	} else {
+		out.Requester = nil
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(in *templateapiv1.TemplateInstanceSpec, out *templateapi.TemplateInstanceSpec, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec(in, out, s)
+}
+
+func autoConvert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(in *templateapiv1.TemplateInstanceStatus, out *templateapi.TemplateInstanceStatus, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*templateapiv1.TemplateInstanceStatus))(in)
+	}
+	out.ObservedGeneration = in.ObservedGeneration
+	out.ObservedSecretResourceVersion = in.ObservedSecretResourceVersion
+	if in.Conditions != nil {
+		out.Conditions = make([]templateapi.TemplateInstanceCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := Convert_v1_TemplateInstanceCondition_To_api_TemplateInstanceCondition(&in.Conditions[i], &out.Conditions[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.Objects != nil {
+		out.Objects = make([]api.ObjectReference, len(in.Objects))
+		for i := range in.Objects {
+			if err := Convert_v1_ObjectReference_To_api_ObjectReference(&in.Objects[i], &out.Objects[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Objects = nil
+	}
+	return nil
+}
+
+func Convert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(in *templateapiv1.TemplateInstanceStatus, out *templateapi.TemplateInstanceStatus, s conversion.Scope) error {
+	return autoConvert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus(in, out, s)
+}
+
 func autoConvert_v1_TemplateList_To_api_TemplateList(in *templateapiv1.TemplateList, out *templateapi.TemplateList, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*templateapiv1.TemplateList))(in)
@@ -8360,6 +9296,7 @@ func init() {
 		autoConvert_api_EnvVarSource_To_v1_EnvVarSource,
 		autoConvert_api_EnvVar_To_v1_EnvVar,
 		autoConvert_api_ExecAction_To_v1_ExecAction,
+		autoConvert_api_ExecInPodsHook_To_v1_ExecInPodsHook,
 		autoConvert_api_ExecNewPodHook_To_v1_ExecNewPodHook,
 		autoConvert_api_FCVolumeSource_To_v1_FCVolumeSource,
 		autoConvert_api_FlexVolumeSource_To_v1_FlexVolumeSource,
@@ -8383,6 +9320,7 @@ func init() {
 		autoConvert_api_ImageImportSpec_To_v1_ImageImportSpec,
 		autoConvert_api_ImageImportStatus_To_v1_ImageImportStatus,
 		autoConvert_api_ImageList_To_v1_ImageList,
+		autoConvert_api_ImageSignature_To_v1_ImageSignature,
 		autoConvert_api_ImageSourcePath_To_v1_ImageSourcePath,
 		autoConvert_api_ImageSource_To_v1_ImageSource,
 		autoConvert_api_ImageStreamImage_To_v1_ImageStreamImage,
@@ -8391,6 +9329,8 @@ func init() {
 		autoConvert_api_ImageStreamImport_To_v1_ImageStreamImport,
 		autoConvert_api_ImageStreamList_To_v1_ImageStreamList,
 		autoConvert_api_ImageStreamMapping_To_v1_ImageStreamMapping,
+		autoConvert_api_ImageStreamNotification_To_v1_ImageStreamNotification,
+		autoConvert_api_ImageStreamRetentionPolicy_To_v1_ImageStreamRetentionPolicy,
 		autoConvert_api_ImageStreamSpec_To_v1_ImageStreamSpec,
 		autoConvert_api_ImageStreamStatus_To_v1_ImageStreamStatus,
 		autoConvert_api_ImageStreamTagList_To_v1_ImageStreamTagList,
@@ -8414,6 +9354,8 @@ func init() {
 		autoConvert_api_OAuthClientAuthorization_To_v1_OAuthClientAuthorization,
 		autoConvert_api_OAuthClientList_To_v1_OAuthClientList,
 		autoConvert_api_OAuthClient_To_v1_OAuthClient,
+		autoConvert_api_OAuthTOTPEnrollmentList_To_v1_OAuthTOTPEnrollmentList,
+		autoConvert_api_OAuthTOTPEnrollment_To_v1_OAuthTOTPEnrollment,
 		autoConvert_api_ObjectFieldSelector_To_v1_ObjectFieldSelector,
 		autoConvert_api_ObjectMeta_To_v1_ObjectMeta,
 		autoConvert_api_ObjectReference_To_v1_ObjectReference,
@@ -8462,11 +9404,20 @@ func init() {
 		autoConvert_api_SubjectAccessReview_To_v1_SubjectAccessReview,
 		autoConvert_api_TCPSocketAction_To_v1_TCPSocketAction,
 		autoConvert_api_TLSConfig_To_v1_TLSConfig,
+		autoConvert_api_TagImageHook_To_v1_TagImageHook,
 		autoConvert_api_TagImportPolicy_To_v1_TagImportPolicy,
+		autoConvert_api_TemplateInstanceCondition_To_v1_TemplateInstanceCondition,
+		autoConvert_api_TemplateInstanceList_To_v1_TemplateInstanceList,
+		autoConvert_api_TemplateInstanceRequester_To_v1_TemplateInstanceRequester,
+		autoConvert_api_TemplateInstanceSpec_To_v1_TemplateInstanceSpec,
+		autoConvert_api_TemplateInstanceStatus_To_v1_TemplateInstanceStatus,
+		autoConvert_api_TemplateInstance_To_v1_TemplateInstance,
 		autoConvert_api_TemplateList_To_v1_TemplateList,
 		autoConvert_api_Template_To_v1_Template,
 		autoConvert_api_UserIdentityMapping_To_v1_UserIdentityMapping,
 		autoConvert_api_UserList_To_v1_UserList,
+		autoConvert_api_UserOAuthAccessTokenList_To_v1_UserOAuthAccessTokenList,
+		autoConvert_api_UserOAuthAccessToken_To_v1_UserOAuthAccessToken,
 		autoConvert_api_User_To_v1_User,
 		autoConvert_api_VolumeMount_To_v1_VolumeMount,
 		autoConvert_api_VolumeSource_To_v1_VolumeSource,
@@ -8529,6 +9480,7 @@ func init() {
 		autoConvert_v1_EnvVarSource_To_api_EnvVarSource,
 		autoConvert_v1_EnvVar_To_api_EnvVar,
 		autoConvert_v1_ExecAction_To_api_ExecAction,
+		autoConvert_v1_ExecInPodsHook_To_api_ExecInPodsHook,
 		autoConvert_v1_ExecNewPodHook_To_api_ExecNewPodHook,
 		autoConvert_v1_FCVolumeSource_To_api_FCVolumeSource,
 		autoConvert_v1_FlexVolumeSource_To_api_FlexVolumeSource,
@@ -8552,6 +9504,7 @@ func init() {
 		autoConvert_v1_ImageImportSpec_To_api_ImageImportSpec,
 		autoConvert_v1_ImageImportStatus_To_api_ImageImportStatus,
 		autoConvert_v1_ImageList_To_api_ImageList,
+		autoConvert_v1_ImageSignature_To_api_ImageSignature,
 		autoConvert_v1_ImageSourcePath_To_api_ImageSourcePath,
 		autoConvert_v1_ImageSource_To_api_ImageSource,
 		autoConvert_v1_ImageStreamImage_To_api_ImageStreamImage,
@@ -8560,6 +9513,8 @@ func init() {
 		autoConvert_v1_ImageStreamImport_To_api_ImageStreamImport,
 		autoConvert_v1_ImageStreamList_To_api_ImageStreamList,
 		autoConvert_v1_ImageStreamMapping_To_api_ImageStreamMapping,
+		autoConvert_v1_ImageStreamNotification_To_api_ImageStreamNotification,
+		autoConvert_v1_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy,
 		autoConvert_v1_ImageStreamSpec_To_api_ImageStreamSpec,
 		autoConvert_v1_ImageStreamStatus_To_api_ImageStreamStatus,
 		autoConvert_v1_ImageStreamTagList_To_api_ImageStreamTagList,
@@ -8583,6 +9538,8 @@ func init() {
 		autoConvert_v1_OAuthClientAuthorization_To_api_OAuthClientAuthorization,
 		autoConvert_v1_OAuthClientList_To_api_OAuthClientList,
 		autoConvert_v1_OAuthClient_To_api_OAuthClient,
+		autoConvert_v1_OAuthTOTPEnrollmentList_To_api_OAuthTOTPEnrollmentList,
+		autoConvert_v1_OAuthTOTPEnrollment_To_api_OAuthTOTPEnrollment,
 		autoConvert_v1_ObjectFieldSelector_To_api_ObjectFieldSelector,
 		autoConvert_v1_ObjectMeta_To_api_ObjectMeta,
 		autoConvert_v1_ObjectReference_To_api_ObjectReference,
@@ -8631,11 +9588,20 @@ func init() {
 		autoConvert_v1_SubjectAccessReview_To_api_SubjectAccessReview,
 		autoConvert_v1_TCPSocketAction_To_api_TCPSocketAction,
 		autoConvert_v1_TLSConfig_To_api_TLSConfig,
+		autoConvert_v1_TagImageHook_To_api_TagImageHook,
 		autoConvert_v1_TagImportPolicy_To_api_TagImportPolicy,
+		autoConvert_v1_TemplateInstanceCondition_To_api_TemplateInstanceCondition,
+		autoConvert_v1_TemplateInstanceList_To_api_TemplateInstanceList,
+		autoConvert_v1_TemplateInstanceRequester_To_api_TemplateInstanceRequester,
+		autoConvert_v1_TemplateInstanceSpec_To_api_TemplateInstanceSpec,
+		autoConvert_v1_TemplateInstanceStatus_To_api_TemplateInstanceStatus,
+		autoConvert_v1_TemplateInstance_To_api_TemplateInstance,
 		autoConvert_v1_TemplateList_To_api_TemplateList,
 		autoConvert_v1_Template_To_api_Template,
 		autoConvert_v1_UserIdentityMapping_To_api_UserIdentityMapping,
 		autoConvert_v1_UserList_To_api_UserList,
+		autoConvert_v1_UserOAuthAccessTokenList_To_api_UserOAuthAccessTokenList,
+		autoConvert_v1_UserOAuthAccessToken_To_api_UserOAuthAccessToken,
 		autoConvert_v1_User_To_api_User,
 		autoConvert_v1_VolumeMount_To_api_VolumeMount,
 		autoConvert_v1_VolumeSource_To_api_VolumeSource,
@@ -302,6 +302,14 @@ func deepCopy_v1_LocalSubjectAccessReview(in v1.LocalSubjectAccessReview, out *v
 	} else {
 		out.GroupsSlice = nil
 	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -672,6 +680,14 @@ func deepCopy_v1_SubjectAccessReview(in v1.SubjectAccessReview, out *v1.SubjectA
 	} else {
 		out.GroupsSlice = nil
 	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -1709,6 +1725,20 @@ func deepCopy_v1_DeploymentTriggerPolicy(in deployapiv1.DeploymentTriggerPolicy,
 	return nil
 }
 
+func deepCopy_v1_ExecInPodsHook(in deployapiv1.ExecInPodsHook, out *deployapiv1.ExecInPodsHook, c *conversion.Cloner) error {
+	if in.Command != nil {
+		out.Command = make([]string, len(in.Command))
+		for i := range in.Command {
+			out.Command[i] = in.Command[i]
+		}
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.MaxPods = in.MaxPods
+	return nil
+}
+
 func deepCopy_v1_ExecNewPodHook(in deployapiv1.ExecNewPodHook, out *deployapiv1.ExecNewPodHook, c *conversion.Cloner) error {
 	if in.Command != nil {
 		out.Command = make([]string, len(in.Command))
@@ -1752,6 +1782,24 @@ func deepCopy_v1_LifecycleHook(in deployapiv1.LifecycleHook, out *deployapiv1.Li
 	} else {
 		out.ExecNewPod = nil
 	}
+	if in.TagImages != nil {
+		out.TagImages = make([]deployapiv1.TagImageHook, len(in.TagImages))
+		for i := range in.TagImages {
+			if err := deepCopy_v1_TagImageHook(in.TagImages[i], &out.TagImages[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.TagImages = nil
+	}
+	if in.ExecInPods != nil {
+		out.ExecInPods = new(deployapiv1.ExecInPodsHook)
+		if err := deepCopy_v1_ExecInPodsHook(*in.ExecInPods, out.ExecInPods, c); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPods = nil
+	}
 	return nil
 }
 
@@ -1840,6 +1888,14 @@ func deepCopy_v1_RollingDeploymentStrategyParams(in deployapiv1.RollingDeploymen
 	} else {
 		out.Pre = nil
 	}
+	if in.Mid != nil {
+		out.Mid = new(deployapiv1.LifecycleHook)
+		if err := deepCopy_v1_LifecycleHook(*in.Mid, out.Mid, c); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
 	if in.Post != nil {
 		out.Post = new(deployapiv1.LifecycleHook)
 		if err := deepCopy_v1_LifecycleHook(*in.Post, out.Post, c); err != nil {
@@ -1848,6 +1904,22 @@ func deepCopy_v1_RollingDeploymentStrategyParams(in deployapiv1.RollingDeploymen
 	} else {
 		out.Post = nil
 	}
+	if in.PauseAfterPercent != nil {
+		out.PauseAfterPercent = new(int)
+		*out.PauseAfterPercent = *in.PauseAfterPercent
+	} else {
+		out.PauseAfterPercent = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_TagImageHook(in deployapiv1.TagImageHook, out *deployapiv1.TagImageHook, c *conversion.Cloner) error {
+	out.ContainerName = in.ContainerName
+	if newVal, err := c.DeepCopy(in.To); err != nil {
+		return err
+	} else {
+		out.To = newVal.(pkgapiv1.ObjectReference)
+	}
 	return nil
 }
 
@@ -1870,6 +1942,8 @@ func deepCopy_v1_Image(in imageapiv1.Image, out *imageapiv1.Image, c *conversion
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		out.DockerImageLayers = make([]imageapiv1.ImageLayer, len(in.DockerImageLayers))
 		for i := range in.DockerImageLayers {
@@ -1880,6 +1954,16 @@ func deepCopy_v1_Image(in imageapiv1.Image, out *imageapiv1.Image, c *conversion
 	} else {
 		out.DockerImageLayers = nil
 	}
+	if in.Signatures != nil {
+		out.Signatures = make([]imageapiv1.ImageSignature, len(in.Signatures))
+		for i := range in.Signatures {
+			if err := deepCopy_v1_ImageSignature(in.Signatures[i], &out.Signatures[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Signatures = nil
+	}
 	return nil
 }
 
@@ -1953,6 +2037,29 @@ func deepCopy_v1_ImageList(in imageapiv1.ImageList, out *imageapiv1.ImageList, c
 	return nil
 }
 
+func deepCopy_v1_ImageSignature(in imageapiv1.ImageSignature, out *imageapiv1.ImageSignature, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapiv1.ObjectMeta)
+	}
+	out.Type = in.Type
+	if in.Content != nil {
+		out.Content = make([]uint8, len(in.Content))
+		for i := range in.Content {
+			out.Content[i] = in.Content[i]
+		}
+	} else {
+		out.Content = nil
+	}
+	return nil
+}
+
 func deepCopy_v1_ImageStream(in imageapiv1.ImageStream, out *imageapiv1.ImageStream, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
 		return err
@@ -2105,6 +2212,26 @@ func deepCopy_v1_ImageStreamMapping(in imageapiv1.ImageStreamMapping, out *image
 	return nil
 }
 
+func deepCopy_v1_ImageStreamNotification(in imageapiv1.ImageStreamNotification, out *imageapiv1.ImageStreamNotification, c *conversion.Cloner) error {
+	out.URL = in.URL
+	out.SecretName = in.SecretName
+	return nil
+}
+
+func deepCopy_v1_ImageStreamRetentionPolicy(in imageapiv1.ImageStreamRetentionPolicy, out *imageapiv1.ImageStreamRetentionPolicy, c *conversion.Cloner) error {
+	out.KeepTagRevisions = in.KeepTagRevisions
+	out.KeepYoungerThanSeconds = in.KeepYoungerThanSeconds
+	if in.ProtectedTags != nil {
+		out.ProtectedTags = make([]string, len(in.ProtectedTags))
+		for i := range in.ProtectedTags {
+			out.ProtectedTags[i] = in.ProtectedTags[i]
+		}
+	} else {
+		out.ProtectedTags = nil
+	}
+	return nil
+}
+
 func deepCopy_v1_ImageStreamSpec(in imageapiv1.ImageStreamSpec, out *imageapiv1.ImageStreamSpec, c *conversion.Cloner) error {
 	out.DockerImageRepository = in.DockerImageRepository
 	if in.Tags != nil {
@@ -2117,6 +2244,24 @@ func deepCopy_v1_ImageStreamSpec(in imageapiv1.ImageStreamSpec, out *imageapiv1.
 	} else {
 		out.Tags = nil
 	}
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = new(imageapiv1.ImageStreamRetentionPolicy)
+		if err := deepCopy_v1_ImageStreamRetentionPolicy(*in.RetentionPolicy, out.RetentionPolicy, c); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
+	if in.Notifications != nil {
+		out.Notifications = make([]imageapiv1.ImageStreamNotification, len(in.Notifications))
+		for i := range in.Notifications {
+			if err := deepCopy_v1_ImageStreamNotification(in.Notifications[i], &out.Notifications[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Notifications = nil
+	}
 	return nil
 }
 
@@ -2331,6 +2476,15 @@ func deepCopy_v1_OAuthAccessToken(in oauthapiv1.OAuthAccessToken, out *oauthapiv
 	out.UserUID = in.UserUID
 	out.AuthorizeToken = in.AuthorizeToken
 	out.RefreshToken = in.RefreshToken
+	if in.LastUsedTimestamp != nil {
+		if newVal, err := c.DeepCopy(in.LastUsedTimestamp); err != nil {
+			return err
+		} else {
+			out.LastUsedTimestamp = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
 	return nil
 }
 
@@ -2507,6 +2661,129 @@ func deepCopy_v1_OAuthClientList(in oauthapiv1.OAuthClientList, out *oauthapiv1.
 	return nil
 }
 
+func deepCopy_v1_OAuthTOTPEnrollment(in oauthapiv1.OAuthTOTPEnrollment, out *oauthapiv1.OAuthTOTPEnrollment, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapiv1.ObjectMeta)
+	}
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.Secret = in.Secret
+	out.Confirmed = in.Confirmed
+	if in.RecoveryCodeHashes != nil {
+		out.RecoveryCodeHashes = make([]string, len(in.RecoveryCodeHashes))
+		for i := range in.RecoveryCodeHashes {
+			out.RecoveryCodeHashes[i] = in.RecoveryCodeHashes[i]
+		}
+	} else {
+		out.RecoveryCodeHashes = nil
+	}
+	out.LastUsedTimeStep = in.LastUsedTimeStep
+	out.FailedAttempts = in.FailedAttempts
+	if in.LockedUntil != nil {
+		if newVal, err := c.DeepCopy(in.LockedUntil); err != nil {
+			return err
+		} else {
+			out.LockedUntil = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LockedUntil = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_OAuthTOTPEnrollmentList(in oauthapiv1.OAuthTOTPEnrollmentList, out *oauthapiv1.OAuthTOTPEnrollmentList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapiv1.OAuthTOTPEnrollment, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_v1_OAuthTOTPEnrollment(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_UserOAuthAccessToken(in oauthapiv1.UserOAuthAccessToken, out *oauthapiv1.UserOAuthAccessToken, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapiv1.ObjectMeta)
+	}
+	out.ClientName = in.ClientName
+	out.ExpiresIn = in.ExpiresIn
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	out.RedirectURI = in.RedirectURI
+	out.UserName = in.UserName
+	out.UserUID = in.UserUID
+	out.AuthorizeToken = in.AuthorizeToken
+	out.RefreshToken = in.RefreshToken
+	if in.LastUsedTimestamp != nil {
+		if newVal, err := c.DeepCopy(in.LastUsedTimestamp); err != nil {
+			return err
+		} else {
+			out.LastUsedTimestamp = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_UserOAuthAccessTokenList(in oauthapiv1.UserOAuthAccessTokenList, out *oauthapiv1.UserOAuthAccessTokenList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]oauthapiv1.UserOAuthAccessToken, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_v1_UserOAuthAccessToken(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
 func deepCopy_v1_Project(in projectapiv1.Project, out *projectapiv1.Project, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
 		return err
@@ -2808,6 +3085,18 @@ func deepCopy_v1_Parameter(in templateapiv1.Parameter, out *templateapiv1.Parame
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = in.Type
+	out.Pattern = in.Pattern
+	if in.AllowedValues != nil {
+		out.AllowedValues = make([]string, len(in.AllowedValues))
+		for i := range in.AllowedValues {
+			out.AllowedValues[i] = in.AllowedValues[i]
+		}
+	} else {
+		out.AllowedValues = nil
+	}
+	out.MinLength = in.MinLength
+	out.MaxLength = in.MaxLength
 	return nil
 }
 
@@ -2855,6 +3144,136 @@ func deepCopy_v1_Template(in templateapiv1.Template, out *templateapiv1.Template
 	return nil
 }
 
+func deepCopy_v1_TemplateInstance(in templateapiv1.TemplateInstance, out *templateapiv1.TemplateInstance, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ObjectMeta); err != nil {
+		return err
+	} else {
+		out.ObjectMeta = newVal.(pkgapiv1.ObjectMeta)
+	}
+	if err := deepCopy_v1_TemplateInstanceSpec(in.Spec, &out.Spec, c); err != nil {
+		return err
+	}
+	if err := deepCopy_v1_TemplateInstanceStatus(in.Status, &out.Status, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func deepCopy_v1_TemplateInstanceCondition(in templateapiv1.TemplateInstanceCondition, out *templateapiv1.TemplateInstanceCondition, c *conversion.Cloner) error {
+	out.Type = in.Type
+	out.Status = in.Status
+	if newVal, err := c.DeepCopy(in.LastTransitionTime); err != nil {
+		return err
+	} else {
+		out.LastTransitionTime = newVal.(unversioned.Time)
+	}
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+func deepCopy_v1_TemplateInstanceList(in templateapiv1.TemplateInstanceList, out *templateapiv1.TemplateInstanceList, c *conversion.Cloner) error {
+	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
+		return err
+	} else {
+		out.TypeMeta = newVal.(unversioned.TypeMeta)
+	}
+	if newVal, err := c.DeepCopy(in.ListMeta); err != nil {
+		return err
+	} else {
+		out.ListMeta = newVal.(unversioned.ListMeta)
+	}
+	if in.Items != nil {
+		out.Items = make([]templateapiv1.TemplateInstance, len(in.Items))
+		for i := range in.Items {
+			if err := deepCopy_v1_TemplateInstance(in.Items[i], &out.Items[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_TemplateInstanceRequester(in templateapiv1.TemplateInstanceRequester, out *templateapiv1.TemplateInstanceRequester, c *conversion.Cloner) error {
+	out.Username = in.Username
+	if in.Groups != nil {
+		out.Groups = make([]string, len(in.Groups))
+		for i := range in.Groups {
+			out.Groups[i] = in.Groups[i]
+		}
+	} else {
+		out.Groups = nil
+	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_TemplateInstanceSpec(in templateapiv1.TemplateInstanceSpec, out *templateapiv1.TemplateInstanceSpec, c *conversion.Cloner) error {
+	if err := deepCopy_v1_Template(in.Template, &out.Template, c); err != nil {
+		return err
+	}
+	if in.Secret != nil {
+		if newVal, err := c.DeepCopy(in.Secret); err != nil {
+			return err
+		} else {
+			out.Secret = newVal.(*pkgapiv1.LocalObjectReference)
+		}
+	} else {
+		out.Secret = nil
+	}
+	if in.Requester != nil {
+		out.Requester = new(templateapiv1.TemplateInstanceRequester)
+		if err := deepCopy_v1_TemplateInstanceRequester(*in.Requester, out.Requester, c); err != nil {
+			return err
+		}
+	} else {
+		out.Requester = nil
+	}
+	return nil
+}
+
+func deepCopy_v1_TemplateInstanceStatus(in templateapiv1.TemplateInstanceStatus, out *templateapiv1.TemplateInstanceStatus, c *conversion.Cloner) error {
+	out.ObservedGeneration = in.ObservedGeneration
+	out.ObservedSecretResourceVersion = in.ObservedSecretResourceVersion
+	if in.Conditions != nil {
+		out.Conditions = make([]templateapiv1.TemplateInstanceCondition, len(in.Conditions))
+		for i := range in.Conditions {
+			if err := deepCopy_v1_TemplateInstanceCondition(in.Conditions[i], &out.Conditions[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.Objects != nil {
+		out.Objects = make([]pkgapiv1.ObjectReference, len(in.Objects))
+		for i := range in.Objects {
+			if newVal, err := c.DeepCopy(in.Objects[i]); err != nil {
+				return err
+			} else {
+				out.Objects[i] = newVal.(pkgapiv1.ObjectReference)
+			}
+		}
+	} else {
+		out.Objects = nil
+	}
+	return nil
+}
+
 func deepCopy_v1_TemplateList(in templateapiv1.TemplateList, out *templateapiv1.TemplateList, c *conversion.Cloner) error {
 	if newVal, err := c.DeepCopy(in.TypeMeta); err != nil {
 		return err
@@ -3133,15 +3552,18 @@ func init() {
 		deepCopy_v1_DeploymentStrategy,
 		deepCopy_v1_DeploymentTriggerImageChangeParams,
 		deepCopy_v1_DeploymentTriggerPolicy,
+		deepCopy_v1_ExecInPodsHook,
 		deepCopy_v1_ExecNewPodHook,
 		deepCopy_v1_LifecycleHook,
 		deepCopy_v1_RecreateDeploymentStrategyParams,
 		deepCopy_v1_RollingDeploymentStrategyParams,
+		deepCopy_v1_TagImageHook,
 		deepCopy_v1_Image,
 		deepCopy_v1_ImageImportSpec,
 		deepCopy_v1_ImageImportStatus,
 		deepCopy_v1_ImageLayer,
 		deepCopy_v1_ImageList,
+		deepCopy_v1_ImageSignature,
 		deepCopy_v1_ImageStream,
 		deepCopy_v1_ImageStreamImage,
 		deepCopy_v1_ImageStreamImport,
@@ -3149,6 +3571,8 @@ func init() {
 		deepCopy_v1_ImageStreamImportStatus,
 		deepCopy_v1_ImageStreamList,
 		deepCopy_v1_ImageStreamMapping,
+		deepCopy_v1_ImageStreamNotification,
+		deepCopy_v1_ImageStreamRetentionPolicy,
 		deepCopy_v1_ImageStreamSpec,
 		deepCopy_v1_ImageStreamStatus,
 		deepCopy_v1_ImageStreamTag,
@@ -3168,6 +3592,10 @@ func init() {
 		deepCopy_v1_OAuthClientAuthorization,
 		deepCopy_v1_OAuthClientAuthorizationList,
 		deepCopy_v1_OAuthClientList,
+		deepCopy_v1_OAuthTOTPEnrollment,
+		deepCopy_v1_OAuthTOTPEnrollmentList,
+		deepCopy_v1_UserOAuthAccessToken,
+		deepCopy_v1_UserOAuthAccessTokenList,
 		deepCopy_v1_Project,
 		deepCopy_v1_ProjectList,
 		deepCopy_v1_ProjectRequest,
@@ -3187,6 +3615,12 @@ func init() {
 		deepCopy_v1_NetNamespaceList,
 		deepCopy_v1_Parameter,
 		deepCopy_v1_Template,
+		deepCopy_v1_TemplateInstance,
+		deepCopy_v1_TemplateInstanceCondition,
+		deepCopy_v1_TemplateInstanceList,
+		deepCopy_v1_TemplateInstanceRequester,
+		deepCopy_v1_TemplateInstanceSpec,
+		deepCopy_v1_TemplateInstanceStatus,
 		deepCopy_v1_TemplateList,
 		deepCopy_v1_Group,
 		deepCopy_v1_GroupList,
@@ -239,6 +239,14 @@ func autoConvert_api_LocalSubjectAccessReview_To_v1beta3_LocalSubjectAccessRevie
 	// in.Action has no peer in out
 	out.User = in.User
 	// in.Groups has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -467,6 +475,14 @@ func autoConvert_api_SubjectAccessReview_To_v1beta3_SubjectAccessReview(in *auth
 	// in.Action has no peer in out
 	out.User = in.User
 	// in.Groups has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -694,6 +710,14 @@ func autoConvert_v1beta3_LocalSubjectAccessReview_To_api_LocalSubjectAccessRevie
 	// in.AuthorizationAttributes has no peer in out
 	out.User = in.User
 	// in.GroupsSlice has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -925,6 +949,14 @@ func autoConvert_v1beta3_SubjectAccessReview_To_api_SubjectAccessReview(in *auth
 	// in.AuthorizationAttributes has no peer in out
 	out.User = in.User
 	// in.GroupsSlice has no peer in out
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -2624,6 +2656,27 @@ func Convert_api_DeploymentTriggerPolicy_To_v1beta3_DeploymentTriggerPolicy(in *
 	return autoConvert_api_DeploymentTriggerPolicy_To_v1beta3_DeploymentTriggerPolicy(in, out, s)
 }
 
+func autoConvert_api_ExecInPodsHook_To_v1beta3_ExecInPodsHook(in *deployapi.ExecInPodsHook, out *deployapiv1beta3.ExecInPodsHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapi.ExecInPodsHook))(in)
+	}
+	if in.Command != nil {
+		out.Command = make([]string, len(in.Command))
+		for i := range in.Command {
+			out.Command[i] = in.Command[i]
+		}
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.MaxPods = in.MaxPods
+	return nil
+}
+
+func Convert_api_ExecInPodsHook_To_v1beta3_ExecInPodsHook(in *deployapi.ExecInPodsHook, out *deployapiv1beta3.ExecInPodsHook, s conversion.Scope) error {
+	return autoConvert_api_ExecInPodsHook_To_v1beta3_ExecInPodsHook(in, out, s)
+}
+
 func autoConvert_api_RollingDeploymentStrategyParams_To_v1beta3_RollingDeploymentStrategyParams(in *deployapi.RollingDeploymentStrategyParams, out *deployapiv1beta3.RollingDeploymentStrategyParams, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapi.RollingDeploymentStrategyParams))(in)
@@ -2667,6 +2720,14 @@ func autoConvert_api_RollingDeploymentStrategyParams_To_v1beta3_RollingDeploymen
 		out.Pre = nil
 	}
 	// unable to generate simple pointer conversion for api.LifecycleHook -> v1beta3.LifecycleHook
+	if in.Mid != nil {
+		if err := s.Convert(&in.Mid, &out.Mid, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
+	// unable to generate simple pointer conversion for api.LifecycleHook -> v1beta3.LifecycleHook
 	if in.Post != nil {
 		if err := s.Convert(&in.Post, &out.Post, 0); err != nil {
 			return err
@@ -2674,9 +2735,30 @@ func autoConvert_api_RollingDeploymentStrategyParams_To_v1beta3_RollingDeploymen
 	} else {
 		out.Post = nil
 	}
+	if in.PauseAfterPercent != nil {
+		out.PauseAfterPercent = new(int)
+		*out.PauseAfterPercent = *in.PauseAfterPercent
+	} else {
+		out.PauseAfterPercent = nil
+	}
+	return nil
+}
+
+func autoConvert_api_TagImageHook_To_v1beta3_TagImageHook(in *deployapi.TagImageHook, out *deployapiv1beta3.TagImageHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapi.TagImageHook))(in)
+	}
+	out.ContainerName = in.ContainerName
+	if err := Convert_api_ObjectReference_To_v1beta3_ObjectReference(&in.To, &out.To, s); err != nil {
+		return err
+	}
 	return nil
 }
 
+func Convert_api_TagImageHook_To_v1beta3_TagImageHook(in *deployapi.TagImageHook, out *deployapiv1beta3.TagImageHook, s conversion.Scope) error {
+	return autoConvert_api_TagImageHook_To_v1beta3_TagImageHook(in, out, s)
+}
+
 func autoConvert_v1beta3_DeploymentCause_To_api_DeploymentCause(in *deployapiv1beta3.DeploymentCause, out *deployapi.DeploymentCause, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapiv1beta3.DeploymentCause))(in)
@@ -2887,6 +2969,27 @@ func Convert_v1beta3_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy(in *
 	return autoConvert_v1beta3_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy(in, out, s)
 }
 
+func autoConvert_v1beta3_ExecInPodsHook_To_api_ExecInPodsHook(in *deployapiv1beta3.ExecInPodsHook, out *deployapi.ExecInPodsHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapiv1beta3.ExecInPodsHook))(in)
+	}
+	if in.Command != nil {
+		out.Command = make([]string, len(in.Command))
+		for i := range in.Command {
+			out.Command[i] = in.Command[i]
+		}
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.MaxPods = in.MaxPods
+	return nil
+}
+
+func Convert_v1beta3_ExecInPodsHook_To_api_ExecInPodsHook(in *deployapiv1beta3.ExecInPodsHook, out *deployapi.ExecInPodsHook, s conversion.Scope) error {
+	return autoConvert_v1beta3_ExecInPodsHook_To_api_ExecInPodsHook(in, out, s)
+}
+
 func autoConvert_v1beta3_RollingDeploymentStrategyParams_To_api_RollingDeploymentStrategyParams(in *deployapiv1beta3.RollingDeploymentStrategyParams, out *deployapi.RollingDeploymentStrategyParams, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*deployapiv1beta3.RollingDeploymentStrategyParams))(in)
@@ -2926,6 +3029,14 @@ func autoConvert_v1beta3_RollingDeploymentStrategyParams_To_api_RollingDeploymen
 		out.Pre = nil
 	}
 	// unable to generate simple pointer conversion for v1beta3.LifecycleHook -> api.LifecycleHook
+	if in.Mid != nil {
+		if err := s.Convert(&in.Mid, &out.Mid, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
+	// unable to generate simple pointer conversion for v1beta3.LifecycleHook -> api.LifecycleHook
 	if in.Post != nil {
 		if err := s.Convert(&in.Post, &out.Post, 0); err != nil {
 			return err
@@ -2933,9 +3044,30 @@ func autoConvert_v1beta3_RollingDeploymentStrategyParams_To_api_RollingDeploymen
 	} else {
 		out.Post = nil
 	}
+	if in.PauseAfterPercent != nil {
+		out.PauseAfterPercent = new(int)
+		*out.PauseAfterPercent = *in.PauseAfterPercent
+	} else {
+		out.PauseAfterPercent = nil
+	}
+	return nil
+}
+
+func autoConvert_v1beta3_TagImageHook_To_api_TagImageHook(in *deployapiv1beta3.TagImageHook, out *deployapi.TagImageHook, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*deployapiv1beta3.TagImageHook))(in)
+	}
+	out.ContainerName = in.ContainerName
+	if err := Convert_v1beta3_ObjectReference_To_api_ObjectReference(&in.To, &out.To, s); err != nil {
+		return err
+	}
 	return nil
 }
 
+func Convert_v1beta3_TagImageHook_To_api_TagImageHook(in *deployapiv1beta3.TagImageHook, out *deployapi.TagImageHook, s conversion.Scope) error {
+	return autoConvert_v1beta3_TagImageHook_To_api_TagImageHook(in, out, s)
+}
+
 func autoConvert_api_Image_To_v1beta3_Image(in *imageapi.Image, out *imageapiv1beta3.Image, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapi.Image))(in)
@@ -2949,6 +3081,8 @@ func autoConvert_api_Image_To_v1beta3_Image(in *imageapi.Image, out *imageapiv1b
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		out.DockerImageLayers = make([]imageapiv1beta3.ImageLayer, len(in.DockerImageLayers))
 		for i := range in.DockerImageLayers {
@@ -2959,6 +3093,7 @@ func autoConvert_api_Image_To_v1beta3_Image(in *imageapi.Image, out *imageapiv1b
 	} else {
 		out.DockerImageLayers = nil
 	}
+	// in.Signatures has no peer in out
 	return nil
 }
 
@@ -3054,6 +3189,40 @@ func autoConvert_api_ImageStreamMapping_To_v1beta3_ImageStreamMapping(in *imagea
 	return nil
 }
 
+func autoConvert_api_ImageStreamNotification_To_v1beta3_ImageStreamNotification(in *imageapi.ImageStreamNotification, out *imageapiv1beta3.ImageStreamNotification, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapi.ImageStreamNotification))(in)
+	}
+	out.URL = in.URL
+	out.SecretName = in.SecretName
+	return nil
+}
+
+func Convert_api_ImageStreamNotification_To_v1beta3_ImageStreamNotification(in *imageapi.ImageStreamNotification, out *imageapiv1beta3.ImageStreamNotification, s conversion.Scope) error {
+	return autoConvert_api_ImageStreamNotification_To_v1beta3_ImageStreamNotification(in, out, s)
+}
+
+func autoConvert_api_ImageStreamRetentionPolicy_To_v1beta3_ImageStreamRetentionPolicy(in *imageapi.ImageStreamRetentionPolicy, out *imageapiv1beta3.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapi.ImageStreamRetentionPolicy))(in)
+	}
+	out.KeepTagRevisions = in.KeepTagRevisions
+	out.KeepYoungerThanSeconds = in.KeepYoungerThanSeconds
+	if in.ProtectedTags != nil {
+		out.ProtectedTags = make([]string, len(in.ProtectedTags))
+		for i := range in.ProtectedTags {
+			out.ProtectedTags[i] = in.ProtectedTags[i]
+		}
+	} else {
+		out.ProtectedTags = nil
+	}
+	return nil
+}
+
+func Convert_api_ImageStreamRetentionPolicy_To_v1beta3_ImageStreamRetentionPolicy(in *imageapi.ImageStreamRetentionPolicy, out *imageapiv1beta3.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_api_ImageStreamRetentionPolicy_To_v1beta3_ImageStreamRetentionPolicy(in, out, s)
+}
+
 func autoConvert_api_ImageStreamSpec_To_v1beta3_ImageStreamSpec(in *imageapi.ImageStreamSpec, out *imageapiv1beta3.ImageStreamSpec, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapi.ImageStreamSpec))(in)
@@ -3062,6 +3231,25 @@ func autoConvert_api_ImageStreamSpec_To_v1beta3_ImageStreamSpec(in *imageapi.Ima
 	if err := s.Convert(&in.Tags, &out.Tags, 0); err != nil {
 		return err
 	}
+	// unable to generate simple pointer conversion for api.ImageStreamRetentionPolicy -> v1beta3.ImageStreamRetentionPolicy
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = new(imageapiv1beta3.ImageStreamRetentionPolicy)
+		if err := Convert_api_ImageStreamRetentionPolicy_To_v1beta3_ImageStreamRetentionPolicy(in.RetentionPolicy, out.RetentionPolicy, s); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
+	if in.Notifications != nil {
+		out.Notifications = make([]imageapiv1beta3.ImageStreamNotification, len(in.Notifications))
+		for i := range in.Notifications {
+			if err := Convert_api_ImageStreamNotification_To_v1beta3_ImageStreamNotification(&in.Notifications[i], &out.Notifications[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Notifications = nil
+	}
 	return nil
 }
 
@@ -3126,6 +3314,8 @@ func autoConvert_v1beta3_Image_To_api_Image(in *imageapiv1beta3.Image, out *imag
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		out.DockerImageLayers = make([]imageapi.ImageLayer, len(in.DockerImageLayers))
 		for i := range in.DockerImageLayers {
@@ -3228,6 +3418,40 @@ func autoConvert_v1beta3_ImageStreamMapping_To_api_ImageStreamMapping(in *imagea
 	return nil
 }
 
+func autoConvert_v1beta3_ImageStreamNotification_To_api_ImageStreamNotification(in *imageapiv1beta3.ImageStreamNotification, out *imageapi.ImageStreamNotification, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapiv1beta3.ImageStreamNotification))(in)
+	}
+	out.URL = in.URL
+	out.SecretName = in.SecretName
+	return nil
+}
+
+func Convert_v1beta3_ImageStreamNotification_To_api_ImageStreamNotification(in *imageapiv1beta3.ImageStreamNotification, out *imageapi.ImageStreamNotification, s conversion.Scope) error {
+	return autoConvert_v1beta3_ImageStreamNotification_To_api_ImageStreamNotification(in, out, s)
+}
+
+func autoConvert_v1beta3_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in *imageapiv1beta3.ImageStreamRetentionPolicy, out *imageapi.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
+		defaulting.(func(*imageapiv1beta3.ImageStreamRetentionPolicy))(in)
+	}
+	out.KeepTagRevisions = in.KeepTagRevisions
+	out.KeepYoungerThanSeconds = in.KeepYoungerThanSeconds
+	if in.ProtectedTags != nil {
+		out.ProtectedTags = make([]string, len(in.ProtectedTags))
+		for i := range in.ProtectedTags {
+			out.ProtectedTags[i] = in.ProtectedTags[i]
+		}
+	} else {
+		out.ProtectedTags = nil
+	}
+	return nil
+}
+
+func Convert_v1beta3_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in *imageapiv1beta3.ImageStreamRetentionPolicy, out *imageapi.ImageStreamRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta3_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in, out, s)
+}
+
 func autoConvert_v1beta3_ImageStreamSpec_To_api_ImageStreamSpec(in *imageapiv1beta3.ImageStreamSpec, out *imageapi.ImageStreamSpec, s conversion.Scope) error {
 	if defaulting, found := s.DefaultingInterface(reflect.TypeOf(*in)); found {
 		defaulting.(func(*imageapiv1beta3.ImageStreamSpec))(in)
@@ -3236,6 +3460,25 @@ func autoConvert_v1beta3_ImageStreamSpec_To_api_ImageStreamSpec(in *imageapiv1be
 	if err := s.Convert(&in.Tags, &out.Tags, 0); err != nil {
 		return err
 	}
+	// unable to generate simple pointer conversion for v1beta3.ImageStreamRetentionPolicy -> api.ImageStreamRetentionPolicy
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = new(imageapi.ImageStreamRetentionPolicy)
+		if err := Convert_v1beta3_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy(in.RetentionPolicy, out.RetentionPolicy, s); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
+	if in.Notifications != nil {
+		out.Notifications = make([]imageapi.ImageStreamNotification, len(in.Notifications))
+		for i := range in.Notifications {
+			if err := Convert_v1beta3_ImageStreamNotification_To_api_ImageStreamNotification(&in.Notifications[i], &out.Notifications[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Notifications = nil
+	}
 	return nil
 }
 
@@ -3307,6 +3550,15 @@ func autoConvert_api_OAuthAccessToken_To_v1beta3_OAuthAccessToken(in *oauthapi.O
 	out.UserUID = in.UserUID
 	out.AuthorizeToken = in.AuthorizeToken
 	out.RefreshToken = in.RefreshToken
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LastUsedTimestamp != nil {
+		out.LastUsedTimestamp = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LastUsedTimestamp, out.LastUsedTimestamp, s); err != nil {
+			return err
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
 	return nil
 }
 
@@ -3509,6 +3761,15 @@ func autoConvert_v1beta3_OAuthAccessToken_To_api_OAuthAccessToken(in *oauthapiv1
 	out.UserUID = in.UserUID
 	out.AuthorizeToken = in.AuthorizeToken
 	out.RefreshToken = in.RefreshToken
+	// unable to generate simple pointer conversion for unversioned.Time -> unversioned.Time
+	if in.LastUsedTimestamp != nil {
+		out.LastUsedTimestamp = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(in.LastUsedTimestamp, out.LastUsedTimestamp, s); err != nil {
+			return err
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
 	return nil
 }
 
@@ -4366,6 +4627,18 @@ func autoConvert_api_Parameter_To_v1beta3_Parameter(in *templateapi.Parameter, o
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = templateapiv1beta3.ParameterType(in.Type)
+	out.Pattern = in.Pattern
+	if in.AllowedValues != nil {
+		out.AllowedValues = make([]string, len(in.AllowedValues))
+		for i := range in.AllowedValues {
+			out.AllowedValues[i] = in.AllowedValues[i]
+		}
+	} else {
+		out.AllowedValues = nil
+	}
+	out.MinLength = in.MinLength
+	out.MaxLength = in.MaxLength
 	return nil
 }
 
@@ -4439,6 +4712,18 @@ func autoConvert_v1beta3_Parameter_To_api_Parameter(in *templateapiv1beta3.Param
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = templateapi.ParameterType(in.Type)
+	out.Pattern = in.Pattern
+	if in.AllowedValues != nil {
+		out.AllowedValues = make([]string, len(in.AllowedValues))
+		for i := range in.AllowedValues {
+			out.AllowedValues[i] = in.AllowedValues[i]
+		}
+	} else {
+		out.AllowedValues = nil
+	}
+	out.MinLength = in.MinLength
+	out.MaxLength = in.MaxLength
 	return nil
 }
 
@@ -6677,6 +6962,7 @@ func init() {
 		autoConvert_api_DownwardAPIVolumeSource_To_v1beta3_DownwardAPIVolumeSource,
 		autoConvert_api_EmptyDirVolumeSource_To_v1beta3_EmptyDirVolumeSource,
 		autoConvert_api_ExecAction_To_v1beta3_ExecAction,
+		autoConvert_api_ExecInPodsHook_To_v1beta3_ExecInPodsHook,
 		autoConvert_api_FCVolumeSource_To_v1beta3_FCVolumeSource,
 		autoConvert_api_FlockerVolumeSource_To_v1beta3_FlockerVolumeSource,
 		autoConvert_api_GCEPersistentDiskVolumeSource_To_v1beta3_GCEPersistentDiskVolumeSource,
@@ -6699,6 +6985,8 @@ func init() {
 		autoConvert_api_ImageStreamImage_To_v1beta3_ImageStreamImage,
 		autoConvert_api_ImageStreamList_To_v1beta3_ImageStreamList,
 		autoConvert_api_ImageStreamMapping_To_v1beta3_ImageStreamMapping,
+		autoConvert_api_ImageStreamNotification_To_v1beta3_ImageStreamNotification,
+		autoConvert_api_ImageStreamRetentionPolicy_To_v1beta3_ImageStreamRetentionPolicy,
 		autoConvert_api_ImageStreamSpec_To_v1beta3_ImageStreamSpec,
 		autoConvert_api_ImageStreamStatus_To_v1beta3_ImageStreamStatus,
 		autoConvert_api_ImageStreamTagList_To_v1beta3_ImageStreamTagList,
@@ -6762,6 +7050,7 @@ func init() {
 		autoConvert_api_SubjectAccessReview_To_v1beta3_SubjectAccessReview,
 		autoConvert_api_TCPSocketAction_To_v1beta3_TCPSocketAction,
 		autoConvert_api_TLSConfig_To_v1beta3_TLSConfig,
+		autoConvert_api_TagImageHook_To_v1beta3_TagImageHook,
 		autoConvert_api_TemplateList_To_v1beta3_TemplateList,
 		autoConvert_api_Template_To_v1beta3_Template,
 		autoConvert_api_UserIdentityMapping_To_v1beta3_UserIdentityMapping,
@@ -6818,6 +7107,7 @@ func init() {
 		autoConvert_v1beta3_DownwardAPIVolumeSource_To_api_DownwardAPIVolumeSource,
 		autoConvert_v1beta3_EmptyDirVolumeSource_To_api_EmptyDirVolumeSource,
 		autoConvert_v1beta3_ExecAction_To_api_ExecAction,
+		autoConvert_v1beta3_ExecInPodsHook_To_api_ExecInPodsHook,
 		autoConvert_v1beta3_FCVolumeSource_To_api_FCVolumeSource,
 		autoConvert_v1beta3_FlockerVolumeSource_To_api_FlockerVolumeSource,
 		autoConvert_v1beta3_GCEPersistentDiskVolumeSource_To_api_GCEPersistentDiskVolumeSource,
@@ -6840,6 +7130,8 @@ func init() {
 		autoConvert_v1beta3_ImageStreamImage_To_api_ImageStreamImage,
 		autoConvert_v1beta3_ImageStreamList_To_api_ImageStreamList,
 		autoConvert_v1beta3_ImageStreamMapping_To_api_ImageStreamMapping,
+		autoConvert_v1beta3_ImageStreamNotification_To_api_ImageStreamNotification,
+		autoConvert_v1beta3_ImageStreamRetentionPolicy_To_api_ImageStreamRetentionPolicy,
 		autoConvert_v1beta3_ImageStreamSpec_To_api_ImageStreamSpec,
 		autoConvert_v1beta3_ImageStreamStatus_To_api_ImageStreamStatus,
 		autoConvert_v1beta3_ImageStreamTagList_To_api_ImageStreamTagList,
@@ -6903,6 +7195,7 @@ func init() {
 		autoConvert_v1beta3_SubjectAccessReview_To_api_SubjectAccessReview,
 		autoConvert_v1beta3_TCPSocketAction_To_api_TCPSocketAction,
 		autoConvert_v1beta3_TLSConfig_To_api_TLSConfig,
+		autoConvert_v1beta3_TagImageHook_To_api_TagImageHook,
 		autoConvert_v1beta3_TemplateList_To_api_TemplateList,
 		autoConvert_v1beta3_Template_To_api_Template,
 		autoConvert_v1beta3_UserIdentityMapping_To_api_UserIdentityMapping,
@@ -302,6 +302,14 @@ func deepCopy_v1beta3_LocalSubjectAccessReview(in v1beta3.LocalSubjectAccessRevi
 	} else {
 		out.GroupsSlice = nil
 	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -680,6 +688,14 @@ func deepCopy_v1beta3_SubjectAccessReview(in v1beta3.SubjectAccessReview, out *v
 	} else {
 		out.GroupsSlice = nil
 	}
+	if in.Scopes != nil {
+		out.Scopes = make([]string, len(in.Scopes))
+		for i := range in.Scopes {
+			out.Scopes[i] = in.Scopes[i]
+		}
+	} else {
+		out.Scopes = nil
+	}
 	return nil
 }
 
@@ -1717,6 +1733,20 @@ func deepCopy_v1beta3_DeploymentTriggerPolicy(in deployapiv1beta3.DeploymentTrig
 	return nil
 }
 
+func deepCopy_v1beta3_ExecInPodsHook(in deployapiv1beta3.ExecInPodsHook, out *deployapiv1beta3.ExecInPodsHook, c *conversion.Cloner) error {
+	if in.Command != nil {
+		out.Command = make([]string, len(in.Command))
+		for i := range in.Command {
+			out.Command[i] = in.Command[i]
+		}
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.MaxPods = in.MaxPods
+	return nil
+}
+
 func deepCopy_v1beta3_ExecNewPodHook(in deployapiv1beta3.ExecNewPodHook, out *deployapiv1beta3.ExecNewPodHook, c *conversion.Cloner) error {
 	if in.Command != nil {
 		out.Command = make([]string, len(in.Command))
@@ -1760,6 +1790,24 @@ func deepCopy_v1beta3_LifecycleHook(in deployapiv1beta3.LifecycleHook, out *depl
 	} else {
 		out.ExecNewPod = nil
 	}
+	if in.TagImages != nil {
+		out.TagImages = make([]deployapiv1beta3.TagImageHook, len(in.TagImages))
+		for i := range in.TagImages {
+			if err := deepCopy_v1beta3_TagImageHook(in.TagImages[i], &out.TagImages[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.TagImages = nil
+	}
+	if in.ExecInPods != nil {
+		out.ExecInPods = new(deployapiv1beta3.ExecInPodsHook)
+		if err := deepCopy_v1beta3_ExecInPodsHook(*in.ExecInPods, out.ExecInPods, c); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPods = nil
+	}
 	return nil
 }
 
@@ -1848,6 +1896,14 @@ func deepCopy_v1beta3_RollingDeploymentStrategyParams(in deployapiv1beta3.Rollin
 	} else {
 		out.Pre = nil
 	}
+	if in.Mid != nil {
+		out.Mid = new(deployapiv1beta3.LifecycleHook)
+		if err := deepCopy_v1beta3_LifecycleHook(*in.Mid, out.Mid, c); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
 	if in.Post != nil {
 		out.Post = new(deployapiv1beta3.LifecycleHook)
 		if err := deepCopy_v1beta3_LifecycleHook(*in.Post, out.Post, c); err != nil {
@@ -1856,6 +1912,22 @@ func deepCopy_v1beta3_RollingDeploymentStrategyParams(in deployapiv1beta3.Rollin
 	} else {
 		out.Post = nil
 	}
+	if in.PauseAfterPercent != nil {
+		out.PauseAfterPercent = new(int)
+		*out.PauseAfterPercent = *in.PauseAfterPercent
+	} else {
+		out.PauseAfterPercent = nil
+	}
+	return nil
+}
+
+func deepCopy_v1beta3_TagImageHook(in deployapiv1beta3.TagImageHook, out *deployapiv1beta3.TagImageHook, c *conversion.Cloner) error {
+	out.ContainerName = in.ContainerName
+	if newVal, err := c.DeepCopy(in.To); err != nil {
+		return err
+	} else {
+		out.To = newVal.(pkgapiv1beta3.ObjectReference)
+	}
 	return nil
 }
 
@@ -1878,6 +1950,8 @@ func deepCopy_v1beta3_Image(in imageapiv1beta3.Image, out *imageapiv1beta3.Image
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		out.DockerImageLayers = make([]imageapiv1beta3.ImageLayer, len(in.DockerImageLayers))
 		for i := range in.DockerImageLayers {
@@ -1991,6 +2065,26 @@ func deepCopy_v1beta3_ImageStreamMapping(in imageapiv1beta3.ImageStreamMapping,
 	return nil
 }
 
+func deepCopy_v1beta3_ImageStreamNotification(in imageapiv1beta3.ImageStreamNotification, out *imageapiv1beta3.ImageStreamNotification, c *conversion.Cloner) error {
+	out.URL = in.URL
+	out.SecretName = in.SecretName
+	return nil
+}
+
+func deepCopy_v1beta3_ImageStreamRetentionPolicy(in imageapiv1beta3.ImageStreamRetentionPolicy, out *imageapiv1beta3.ImageStreamRetentionPolicy, c *conversion.Cloner) error {
+	out.KeepTagRevisions = in.KeepTagRevisions
+	out.KeepYoungerThanSeconds = in.KeepYoungerThanSeconds
+	if in.ProtectedTags != nil {
+		out.ProtectedTags = make([]string, len(in.ProtectedTags))
+		for i := range in.ProtectedTags {
+			out.ProtectedTags[i] = in.ProtectedTags[i]
+		}
+	} else {
+		out.ProtectedTags = nil
+	}
+	return nil
+}
+
 func deepCopy_v1beta3_ImageStreamSpec(in imageapiv1beta3.ImageStreamSpec, out *imageapiv1beta3.ImageStreamSpec, c *conversion.Cloner) error {
 	out.DockerImageRepository = in.DockerImageRepository
 	if in.Tags != nil {
@@ -2003,6 +2097,24 @@ func deepCopy_v1beta3_ImageStreamSpec(in imageapiv1beta3.ImageStreamSpec, out *i
 	} else {
 		out.Tags = nil
 	}
+	if in.RetentionPolicy != nil {
+		out.RetentionPolicy = new(imageapiv1beta3.ImageStreamRetentionPolicy)
+		if err := deepCopy_v1beta3_ImageStreamRetentionPolicy(*in.RetentionPolicy, out.RetentionPolicy, c); err != nil {
+			return err
+		}
+	} else {
+		out.RetentionPolicy = nil
+	}
+	if in.Notifications != nil {
+		out.Notifications = make([]imageapiv1beta3.ImageStreamNotification, len(in.Notifications))
+		for i := range in.Notifications {
+			if err := deepCopy_v1beta3_ImageStreamNotification(in.Notifications[i], &out.Notifications[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Notifications = nil
+	}
 	return nil
 }
 
@@ -2168,6 +2280,15 @@ func deepCopy_v1beta3_OAuthAccessToken(in oauthapiv1beta3.OAuthAccessToken, out
 	out.UserUID = in.UserUID
 	out.AuthorizeToken = in.AuthorizeToken
 	out.RefreshToken = in.RefreshToken
+	if in.LastUsedTimestamp != nil {
+		if newVal, err := c.DeepCopy(in.LastUsedTimestamp); err != nil {
+			return err
+		} else {
+			out.LastUsedTimestamp = newVal.(*unversioned.Time)
+		}
+	} else {
+		out.LastUsedTimestamp = nil
+	}
 	return nil
 }
 
@@ -2645,6 +2766,18 @@ func deepCopy_v1beta3_Parameter(in templateapiv1beta3.Parameter, out *templateap
 	out.Generate = in.Generate
 	out.From = in.From
 	out.Required = in.Required
+	out.Type = in.Type
+	out.Pattern = in.Pattern
+	if in.AllowedValues != nil {
+		out.AllowedValues = make([]string, len(in.AllowedValues))
+		for i := range in.AllowedValues {
+			out.AllowedValues[i] = in.AllowedValues[i]
+		}
+	} else {
+		out.AllowedValues = nil
+	}
+	out.MinLength = in.MinLength
+	out.MaxLength = in.MaxLength
 	return nil
 }
 
@@ -2970,10 +3103,12 @@ func init() {
 		deepCopy_v1beta3_DeploymentStrategy,
 		deepCopy_v1beta3_DeploymentTriggerImageChangeParams,
 		deepCopy_v1beta3_DeploymentTriggerPolicy,
+		deepCopy_v1beta3_ExecInPodsHook,
 		deepCopy_v1beta3_ExecNewPodHook,
 		deepCopy_v1beta3_LifecycleHook,
 		deepCopy_v1beta3_RecreateDeploymentStrategyParams,
 		deepCopy_v1beta3_RollingDeploymentStrategyParams,
+		deepCopy_v1beta3_TagImageHook,
 		deepCopy_v1beta3_Image,
 		deepCopy_v1beta3_ImageLayer,
 		deepCopy_v1beta3_ImageList,
@@ -2981,6 +3116,8 @@ func init() {
 		deepCopy_v1beta3_ImageStreamImage,
 		deepCopy_v1beta3_ImageStreamList,
 		deepCopy_v1beta3_ImageStreamMapping,
+		deepCopy_v1beta3_ImageStreamNotification,
+		deepCopy_v1beta3_ImageStreamRetentionPolicy,
 		deepCopy_v1beta3_ImageStreamSpec,
 		deepCopy_v1beta3_ImageStreamStatus,
 		deepCopy_v1beta3_ImageStreamTag,
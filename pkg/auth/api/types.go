@@ -79,3 +79,29 @@ func (i *DefaultUserIdentityInfo) GetProviderUserName() string {
 func (i *DefaultUserIdentityInfo) GetExtra() map[string]string {
 	return i.Extra
 }
+
+// ScopedUserInfo is a user.Info whose permissions are restricted to the scopes of the OAuth access token it authenticated with.
+type ScopedUserInfo interface {
+	user.Info
+	// GetScopes returns the scopes the user is restricted to
+	GetScopes() []string
+}
+
+// DefaultScopedUserInfo is the default implementation of ScopedUserInfo
+type DefaultScopedUserInfo struct {
+	user.DefaultInfo
+	Scopes []string
+}
+
+func (i *DefaultScopedUserInfo) GetScopes() []string {
+	return i.Scopes
+}
+
+// ScopesFor returns the scopes the given user is restricted to, and false if the user is not restricted by scopes.
+func ScopesFor(info user.Info) ([]string, bool) {
+	scoped, ok := info.(ScopedUserInfo)
+	if !ok || len(scoped.GetScopes()) == 0 {
+		return nil, false
+	}
+	return scoped.GetScopes(), true
+}
@@ -0,0 +1,69 @@
+package totpchallenger
+
+import (
+	"fmt"
+	"net/http"
+
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/auth/authenticator/challenger/passwordchallenger"
+	oauthhandlers "github.com/openshift/origin/pkg/auth/oauth/handlers"
+	"github.com/openshift/origin/pkg/auth/totp"
+)
+
+type totpChallenger struct {
+	realm           string
+	tokenRequestURL string
+}
+
+// New returns an AuthenticationErrorHandler that challenges clients which need to give a verification
+// code. Users that must enroll are sent to the web UI for requesting a token, where they can enroll.
+func New(realm, tokenRequestURL string) oauthhandlers.AuthenticationErrorHandler {
+	return &totpChallenger{realm: realm, tokenRequestURL: tokenRequestURL}
+}
+
+// AuthenticationError only handles the second factor errors of clients that request challenges
+func (c *totpChallenger) AuthenticationError(err error, w http.ResponseWriter, req *http.Request) (bool, error) {
+	if len(req.Header.Get(passwordchallenger.CSRFTokenHeader)) == 0 {
+		return false, err
+	}
+
+	switch totpError(err) {
+	case totp.ErrCodeRequired:
+		c.challenge(w, "A verification code is required")
+	case totp.ErrInvalidCode:
+		c.challenge(w, "The verification code is invalid")
+	case totp.ErrLocked:
+		// no challenge, the client would not get a different answer until the lockout expires
+		http.Error(w, "Too many invalid verification codes were given. Try again later.", http.StatusUnauthorized)
+	case totp.ErrEnrollmentRequired:
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="related"`, c.tokenRequestURL))
+		http.Error(w, fmt.Sprintf("You must enroll an authenticator app by logging in at %s", c.tokenRequestURL), http.StatusUnauthorized)
+	default:
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *totpChallenger) challenge(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", fmt.Sprintf(`TOTP realm="%s"`, c.realm))
+	http.Error(w, message, http.StatusUnauthorized)
+}
+
+// totpError returns the second factor error contained in err, if any. Several request authenticators
+// may have failed, so the error can be an aggregate.
+func totpError(err error) error {
+	if aggregate, ok := err.(kerrors.Aggregate); ok {
+		for _, e := range aggregate.Errors() {
+			if found := totpError(e); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	switch err {
+	case totp.ErrCodeRequired, totp.ErrInvalidCode, totp.ErrEnrollmentRequired, totp.ErrLocked:
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package totpchallenger
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/auth/authenticator/challenger/passwordchallenger"
+	"github.com/openshift/origin/pkg/auth/totp"
+)
+
+func TestAuthenticationError(t *testing.T) {
+	tests := map[string]struct {
+		err       error
+		challenge bool
+
+		expectedHandled   bool
+		expectedChallenge string
+		expectedBody      string
+	}{
+		"code required": {
+			err:               totp.ErrCodeRequired,
+			challenge:         true,
+			expectedHandled:   true,
+			expectedChallenge: `TOTP realm="myrealm"`,
+			expectedBody:      "A verification code is required",
+		},
+		"invalid code among other errors": {
+			err:               kerrors.NewAggregate([]error{errors.New("other"), totp.ErrInvalidCode}),
+			challenge:         true,
+			expectedHandled:   true,
+			expectedChallenge: `TOTP realm="myrealm"`,
+			expectedBody:      "The verification code is invalid",
+		},
+		"locked": {
+			err:             totp.ErrLocked,
+			challenge:       true,
+			expectedHandled: true,
+			expectedBody:    "Too many invalid verification codes",
+		},
+		"enrollment required": {
+			err:             totp.ErrEnrollmentRequired,
+			challenge:       true,
+			expectedHandled: true,
+			expectedBody:    "https://master/oauth/token/request",
+		},
+		"other error": {
+			err:       errors.New("other"),
+			challenge: true,
+		},
+		"browser": {
+			err: totp.ErrCodeRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		req, _ := http.NewRequest("GET", "/oauth/authorize", nil)
+		if tc.challenge {
+			req.Header.Set(passwordchallenger.CSRFTokenHeader, "1")
+		}
+		w := httptest.NewRecorder()
+
+		handled, err := New("myrealm", "https://master/oauth/token/request").AuthenticationError(tc.err, w, req)
+		if handled != tc.expectedHandled {
+			t.Errorf("%s: expected handled=%v, got %v", name, tc.expectedHandled, handled)
+		}
+		if !handled {
+			if err != tc.err {
+				t.Errorf("%s: expected the error to be passed on, got %v", name, err)
+			}
+			continue
+		}
+		if w.Code != http.StatusUnauthorized {
+			t.Errorf("%s: expected 401, got %d", name, w.Code)
+		}
+		if challenge := w.Header().Get("WWW-Authenticate"); challenge != tc.expectedChallenge {
+			t.Errorf("%s: expected challenge %q, got %q", name, tc.expectedChallenge, challenge)
+		}
+		if !strings.Contains(w.Body.String(), tc.expectedBody) {
+			t.Errorf("%s: expected body to contain %q, got %q", name, tc.expectedBody, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package totprequest
+
+import (
+	"net/http"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+
+	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/totp"
+)
+
+// CodeHeader carries the verification code of clients answering a TOTP challenge
+const CodeHeader = "X-TOTP-Code"
+
+type Authenticator struct {
+	delegate authenticator.Request
+	verifier *totp.Verifier
+	required bool
+}
+
+// NewAuthenticator returns a request authenticator that requires users authenticated by the delegate to
+// also give a valid verification code. If required is false, users that have not enrolled are only
+// authenticated by the delegate.
+func NewAuthenticator(delegate authenticator.Request, verifier *totp.Verifier, required bool) authenticator.Request {
+	return &Authenticator{delegate: delegate, verifier: verifier, required: required}
+}
+
+// AuthenticateRequest returns totp.ErrCodeRequired, totp.ErrInvalidCode, totp.ErrEnrollmentRequired or
+// totp.ErrLocked when the delegate authenticated the request, but the second factor is missing.
+func (a *Authenticator) AuthenticateRequest(req *http.Request) (user.Info, bool, error) {
+	u, ok, err := a.delegate.AuthenticateRequest(req)
+	if err != nil || !ok {
+		return u, ok, err
+	}
+
+	code := req.Header.Get(CodeHeader)
+	req.Header.Del(CodeHeader)
+
+	enrolled, err := a.verifier.Enrolled(u)
+	if err != nil {
+		return nil, false, err
+	}
+	if !enrolled {
+		if a.required {
+			return nil, false, totp.ErrEnrollmentRequired
+		}
+		return u, true, nil
+	}
+
+	if len(code) == 0 {
+		return nil, false, totp.ErrCodeRequired
+	}
+	valid, err := a.verifier.Verify(u, code)
+	if err != nil {
+		return nil, false, err
+	}
+	if !valid {
+		return nil, false, totp.ErrInvalidCode
+	}
+	return u, true, nil
+}
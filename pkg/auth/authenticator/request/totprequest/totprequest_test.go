@@ -0,0 +1,104 @@
+package totprequest
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/auth/authenticator"
+	"github.com/openshift/origin/pkg/auth/totp"
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/test"
+)
+
+const secret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func TestAuthenticateRequest(t *testing.T) {
+	clock := &kutil.FakeClock{Time: time.Unix(1450000000, 0)}
+	code, err := totp.GenerateCode(secret, totp.TimeStep(clock.Now()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	bob := &user.DefaultInfo{Name: "bob", UID: "1"}
+	enrollment := &api.OAuthTOTPEnrollment{
+		ObjectMeta: kapi.ObjectMeta{Name: "bob"},
+		UserName:   "bob",
+		UserUID:    "1",
+		Secret:     secret,
+		Confirmed:  true,
+	}
+
+	tests := map[string]struct {
+		delegateUser user.Info
+		enrollment   *api.OAuthTOTPEnrollment
+		required     bool
+		code         string
+
+		expectedOK  bool
+		expectedErr error
+	}{
+		"not authenticated by the delegate": {
+			code: code,
+		},
+		"enrolled with a valid code": {
+			delegateUser: bob,
+			enrollment:   enrollment,
+			code:         code,
+			expectedOK:   true,
+		},
+		"enrolled without a code": {
+			delegateUser: bob,
+			enrollment:   enrollment,
+			expectedErr:  totp.ErrCodeRequired,
+		},
+		"enrolled with an invalid code": {
+			delegateUser: bob,
+			enrollment:   enrollment,
+			code:         "000000",
+			expectedErr:  totp.ErrInvalidCode,
+		},
+		"not enrolled": {
+			delegateUser: bob,
+			expectedOK:   true,
+		},
+		"not enrolled when enrollment is required": {
+			delegateUser: bob,
+			required:     true,
+			expectedErr:  totp.ErrEnrollmentRequired,
+		},
+	}
+
+	for name, tc := range tests {
+		registry := &test.TOTPEnrollmentRegistry{}
+		if tc.enrollment != nil {
+			copied := *tc.enrollment
+			registry.Enrollment = &copied
+		}
+		delegate := authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+			return tc.delegateUser, tc.delegateUser != nil, nil
+		})
+		auth := NewAuthenticator(delegate, totp.NewVerifier(registry, "OpenShift", clock), tc.required)
+
+		req, _ := http.NewRequest("GET", "/oauth/authorize", nil)
+		if len(tc.code) > 0 {
+			req.Header.Set(CodeHeader, tc.code)
+		}
+		u, ok, err := auth.AuthenticateRequest(req)
+		if err != tc.expectedErr {
+			t.Errorf("%s: expected error %v, got %v", name, tc.expectedErr, err)
+		}
+		if ok != tc.expectedOK {
+			t.Errorf("%s: expected authenticated=%v, got %v", name, tc.expectedOK, ok)
+		}
+		if ok && u.GetName() != "bob" {
+			t.Errorf("%s: unexpected user %#v", name, u)
+		}
+		if tc.delegateUser != nil && len(req.Header.Get(CodeHeader)) > 0 {
+			t.Errorf("%s: expected the code header to be removed", name)
+		}
+	}
+}
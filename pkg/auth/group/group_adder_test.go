@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/auth/authenticator"
 	"k8s.io/kubernetes/pkg/auth/user"
 )
@@ -24,3 +25,19 @@ func TestGroupAdder(t *testing.T) {
 		t.Errorf("Expected original,added groups, got %#v", user.GetGroups())
 	}
 }
+
+func TestGroupAdderScoped(t *testing.T) {
+	adder := authenticator.Request(
+		NewGroupAdder(
+			authenticator.RequestFunc(func(req *http.Request) (user.Info, bool, error) {
+				return &authapi.DefaultScopedUserInfo{DefaultInfo: user.DefaultInfo{Name: "user"}, Scopes: []string{"user:info"}}, true, nil
+			}),
+			[]string{"added"},
+		),
+	)
+
+	user, _, _ := adder.AuthenticateRequest(nil)
+	if scopes, ok := authapi.ScopesFor(user); !ok || !reflect.DeepEqual(scopes, []string{"user:info"}) {
+		t.Errorf("Expected the user:info scope to be preserved, got %#v", user)
+	}
+}
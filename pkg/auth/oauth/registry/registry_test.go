@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
 	"time"
 
@@ -332,3 +333,74 @@ func TestAuthenticateTokenValidated(t *testing.T) {
 		t.Error("Did not get a user!")
 	}
 }
+func TestAuthenticateTokenRecordsUse(t *testing.T) {
+	recent := unversioned.Time{Time: time.Now().Add(-10 * time.Second)}
+	stale := unversioned.Time{Time: time.Now().Add(-10 * time.Minute)}
+	tests := map[string]struct {
+		lastUsed       *unversioned.Time
+		expectedUpdate bool
+	}{
+		"never used":    {lastUsed: nil, expectedUpdate: true},
+		"used recently": {lastUsed: &recent, expectedUpdate: false},
+		"used long ago": {lastUsed: &stale, expectedUpdate: true},
+	}
+	for name, tc := range tests {
+		tokenRegistry := &test.AccessTokenRegistry{
+			AccessToken: &oapi.OAuthAccessToken{
+				ObjectMeta:        kapi.ObjectMeta{Name: "token", CreationTimestamp: unversioned.Time{Time: time.Now()}},
+				ExpiresIn:         600, // 10 minutes
+				UserName:          "foo",
+				UserUID:           string("bar"),
+				LastUsedTimestamp: tc.lastUsed,
+			},
+		}
+		userRegistry := usertest.NewUserRegistry()
+		userRegistry.Get["foo"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{UID: "bar"}}
+
+		tokenAuthenticator := NewTokenAuthenticator(tokenRegistry, userRegistry, identitymapper.NoopGroupMapper{})
+		if _, found, err := tokenAuthenticator.AuthenticateToken("token"); !found || err != nil {
+			t.Errorf("%s: unexpected result: %v %v", name, found, err)
+			continue
+		}
+
+		updated := tokenRegistry.UpdatedAccessToken
+		if !tc.expectedUpdate {
+			if updated != nil {
+				t.Errorf("%s: unexpected update: %#v", name, updated)
+			}
+			continue
+		}
+		if updated == nil || updated.LastUsedTimestamp == nil || time.Since(updated.LastUsedTimestamp.Time) > time.Minute {
+			t.Errorf("%s: expected the last use to be recorded, got %#v", name, updated)
+		}
+		if tokenRegistry.AccessToken.LastUsedTimestamp != tc.lastUsed {
+			t.Errorf("%s: the stored token was modified", name)
+		}
+	}
+}
+
+func TestAuthenticateTokenScoped(t *testing.T) {
+	tokenRegistry := &test.AccessTokenRegistry{
+		Err: nil,
+		AccessToken: &oapi.OAuthAccessToken{
+			ObjectMeta: kapi.ObjectMeta{CreationTimestamp: unversioned.Time{Time: time.Now()}},
+			ExpiresIn:  600, // 10 minutes
+			UserName:   "foo",
+			UserUID:    string("bar"),
+			Scopes:     []string{"user:info", "user:check-access"},
+		},
+	}
+	userRegistry := usertest.NewUserRegistry()
+	userRegistry.Get["foo"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{UID: "bar"}}
+
+	tokenAuthenticator := NewTokenAuthenticator(tokenRegistry, userRegistry, identitymapper.NoopGroupMapper{})
+
+	userInfo, found, err := tokenAuthenticator.AuthenticateToken("token")
+	if !found || err != nil {
+		t.Fatalf("Unexpected result: %v %v", found, err)
+	}
+	scopes, ok := api.ScopesFor(userInfo)
+	if !ok || !reflect.DeepEqual(scopes, []string{"user:info", "user:check-access"}) {
+		t.Errorf("Expected the scopes of the token, got %#v", userInfo)
+	}
+}
@@ -5,10 +5,15 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/golang/glog"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
 	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
 	"github.com/openshift/origin/pkg/user/registry/user"
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	kuser "k8s.io/kubernetes/pkg/auth/user"
 )
 
@@ -20,6 +25,10 @@ type TokenAuthenticator struct {
 
 var ErrExpired = errors.New("Token is expired")
 
+// lastUsedUpdateInterval is how stale the recorded last use of a token may become before it is updated.  Tokens are
+// used on every request, so recording every use would mean a write for every request.
+const lastUsedUpdateInterval = time.Minute
+
 func NewTokenAuthenticator(tokens oauthaccesstoken.Registry, users user.Registry, groupMapper identitymapper.UserToGroupMapper) *TokenAuthenticator {
 	return &TokenAuthenticator{
 		tokens:      tokens,
@@ -47,6 +56,8 @@ func (a *TokenAuthenticator) AuthenticateToken(value string) (kuser.Info, bool,
 		return nil, false, fmt.Errorf("user.UID (%s) does not match token.userUID (%s)", u.UID, token.UserUID)
 	}
 
+	a.recordUse(ctx, token)
+
 	groups, err := a.groupMapper.GroupsFor(u.Name)
 	if err != nil {
 		return nil, false, err
@@ -57,9 +68,27 @@ func (a *TokenAuthenticator) AuthenticateToken(value string) (kuser.Info, bool,
 	}
 	groupNames = append(groupNames, u.Groups...)
 
-	return &kuser.DefaultInfo{
+	info := kuser.DefaultInfo{
 		Name:   u.Name,
 		UID:    string(u.UID),
 		Groups: groupNames,
-	}, true, nil
+	}
+	// tokens with scopes may only be used within those scopes
+	if len(token.Scopes) > 0 {
+		return &authapi.DefaultScopedUserInfo{DefaultInfo: info, Scopes: token.Scopes}, true, nil
+	}
+	return &info, true, nil
+}
+
+// recordUse updates the time the token was last used.  Failing to record it must not fail the authentication.
+func (a *TokenAuthenticator) recordUse(ctx api.Context, token *oauthapi.OAuthAccessToken) {
+	now := time.Now()
+	if token.LastUsedTimestamp != nil && now.Sub(token.LastUsedTimestamp.Time) < lastUsedUpdateInterval {
+		return
+	}
+	updated := *token
+	updated.LastUsedTimestamp = &unversioned.Time{Time: now}
+	if _, err := a.tokens.UpdateAccessToken(ctx, &updated); err != nil {
+		glog.V(4).Infof("Unable to record the use of token for user %q: %v", token.UserName, err)
+	}
 }
@@ -0,0 +1,129 @@
+package saml
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/golang/glog"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/auth/oauth/external"
+	"github.com/openshift/origin/pkg/auth/oauth/handlers"
+)
+
+// Handler exposes a SAML identity provider flow (including the assertion consumer service) as an
+// oauth.handlers.AuthenticationRedirector to allow our internal oauth server to use a SAML identity
+// provider for authentication
+type Handler struct {
+	provider     *Provider
+	state        external.State
+	success      handlers.AuthenticationSuccessHandler
+	errorHandler handlers.AuthenticationErrorHandler
+	mapper       authapi.UserIdentityMapper
+}
+
+// NewHandler returns a handler sending users to the identity provider, with the state as relay state.
+// The handler serves the assertion consumer service.
+func NewHandler(provider *Provider, state external.State, success handlers.AuthenticationSuccessHandler, errorHandler handlers.AuthenticationErrorHandler, mapper authapi.UserIdentityMapper) *Handler {
+	return &Handler{
+		provider:     provider,
+		state:        state,
+		success:      success,
+		errorHandler: errorHandler,
+		mapper:       mapper,
+	}
+}
+
+// AuthenticationRedirect implements oauth.handlers.RedirectAuthHandler
+func (h *Handler) AuthenticationRedirect(w http.ResponseWriter, req *http.Request) error {
+	glog.V(4).Infof("Authentication needed for %v", h)
+
+	state, err := h.state.Generate(w, req)
+	if err != nil {
+		glog.V(4).Infof("Error generating state: %v", err)
+		return err
+	}
+
+	ssoURL, err := h.provider.AuthnRequestURL(state)
+	if err != nil {
+		glog.V(4).Infof("Error building authentication request: %v", err)
+		return err
+	}
+	glog.V(4).Infof("redirect to %v", ssoURL)
+
+	http.Redirect(w, req, ssoURL, http.StatusFound)
+	return nil
+}
+
+// ServeHTTP handles the responses posted by the identity provider
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	encodedResponse := req.PostFormValue("SAMLResponse")
+	relayState := req.PostFormValue("RelayState")
+	if len(encodedResponse) == 0 {
+		h.handleError(errors.New("SAMLResponse is missing"), w, req)
+		return
+	}
+
+	// Validate state before looking at the response
+	ok, err := h.state.Check(relayState, req)
+	if !ok {
+		glog.V(4).Infof("State is invalid")
+		err := errors.New("State is invalid")
+		h.handleError(err, w, req)
+		return
+	}
+	if err != nil {
+		glog.V(4).Infof("Error verifying state: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	identity, err := h.provider.GetUserIdentity(encodedResponse, relayState)
+	if err != nil {
+		glog.V(4).Infof("Error getting userIdentityInfo info: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	user, err := h.mapper.UserFor(identity)
+	glog.V(4).Infof("Got userIdentityMapping: %#v", user)
+	if err != nil {
+		glog.V(4).Infof("Error creating or updating mapping for: %#v due to %v", identity, err)
+		h.handleError(err, w, req)
+		return
+	}
+
+	_, err = h.success.AuthenticationSucceeded(user, relayState, w, req)
+	if err != nil {
+		glog.V(4).Infof("Error calling success handler: %v", err)
+		h.handleError(err, w, req)
+		return
+	}
+}
+
+// ServeMetadata serves the metadata of the master, to register it with the identity provider
+func (h *Handler) ServeMetadata(w http.ResponseWriter, req *http.Request) {
+	metadata, err := h.provider.Metadata()
+	if err != nil {
+		glog.Errorf("Error building SAML metadata: %v", err)
+		http.Error(w, "An error occurred", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	w.Write(metadata)
+}
+
+func (h *Handler) handleError(err error, w http.ResponseWriter, req *http.Request) {
+	handled, err := h.errorHandler.AuthenticationError(err, w, req)
+	if handled {
+		return
+	}
+	w.WriteHeader(http.StatusInternalServerError)
+	w.Write([]byte(`An error occurred`))
+}
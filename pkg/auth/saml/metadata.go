@@ -0,0 +1,129 @@
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+type entityDescriptor struct {
+	XMLName           xml.Name           `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID          string             `xml:"entityID,attr"`
+	IDPSSODescriptors []idpSSODescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+	SPSSODescriptors  []spSSODescriptor  `xml:"urn:oasis:names:tc:SAML:2.0:metadata SPSSODescriptor"`
+}
+
+type idpSSODescriptor struct {
+	KeyDescriptors       []keyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	SingleSignOnServices []endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool            `xml:",attr"`
+	ProtocolSupportEnumeration string          `xml:"protocolSupportEnumeration,attr"`
+	KeyDescriptors             []keyDescriptor `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+	NameIDFormats              []string        `xml:"urn:oasis:names:tc:SAML:2.0:metadata NameIDFormat,omitempty"`
+	AssertionConsumerServices  []endpoint      `xml:"urn:oasis:names:tc:SAML:2.0:metadata AssertionConsumerService"`
+}
+
+type keyDescriptor struct {
+	Use     string  `xml:"use,attr,omitempty"`
+	KeyInfo keyInfo `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo"`
+}
+
+type keyInfo struct {
+	X509Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# X509Data>X509Certificate"`
+}
+
+type endpoint struct {
+	Binding  string `xml:",attr"`
+	Location string `xml:",attr"`
+	Index    string `xml:"index,attr,omitempty"`
+}
+
+// IdentityProviderMetadata holds what the master needs to know about an identity provider
+type IdentityProviderMetadata struct {
+	// EntityID is the entity ID of the identity provider, which issues responses and assertions
+	EntityID string
+	// SingleSignOnURL accepts authentication requests through the HTTP-Redirect binding
+	SingleSignOnURL string
+	// Certificates sign responses and assertions
+	Certificates []*x509.Certificate
+}
+
+// ParseIdentityProviderMetadata reads the EntityDescriptor of an identity provider
+func ParseIdentityProviderMetadata(data []byte) (*IdentityProviderMetadata, error) {
+	descriptor := &entityDescriptor{}
+	if err := xml.Unmarshal(data, descriptor); err != nil {
+		return nil, fmt.Errorf("invalid metadata: %v", err)
+	}
+	if len(descriptor.EntityID) == 0 {
+		return nil, errors.New("metadata has no entityID")
+	}
+	if len(descriptor.IDPSSODescriptors) != 1 {
+		return nil, fmt.Errorf("expected exactly one IDPSSODescriptor in metadata, got %d", len(descriptor.IDPSSODescriptors))
+	}
+	idp := descriptor.IDPSSODescriptors[0]
+
+	metadata := &IdentityProviderMetadata{EntityID: descriptor.EntityID}
+	for _, service := range idp.SingleSignOnServices {
+		if service.Binding == bindingHTTPRedirect {
+			metadata.SingleSignOnURL = service.Location
+			break
+		}
+	}
+	if len(metadata.SingleSignOnURL) == 0 {
+		return nil, errors.New("metadata has no SingleSignOnService with the HTTP-Redirect binding")
+	}
+
+	for _, key := range idp.KeyDescriptors {
+		// keys without a use are used for both signing and encryption
+		if len(key.Use) > 0 && key.Use != "signing" {
+			continue
+		}
+		for _, encoded := range key.KeyInfo.X509Certificates {
+			der, err := decodeBase64(encoded)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate in metadata: %v", err)
+			}
+			cert, err := x509.ParseCertificate(der)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate in metadata: %v", err)
+			}
+			metadata.Certificates = append(metadata.Certificates, cert)
+		}
+	}
+	if len(metadata.Certificates) == 0 {
+		return nil, errors.New("metadata has no signing certificate")
+	}
+
+	return metadata, nil
+}
+
+// Metadata returns the EntityDescriptor of the master, to register it with the identity provider
+func (p *Provider) Metadata() ([]byte, error) {
+	sp := spSSODescriptor{
+		AuthnRequestsSigned:        true,
+		ProtocolSupportEnumeration: protocolNamespace,
+		KeyDescriptors: []keyDescriptor{{
+			Use:     "signing",
+			KeyInfo: keyInfo{X509Certificates: []string{base64.StdEncoding.EncodeToString(p.SigningCert.Raw)}},
+		}},
+		AssertionConsumerServices: []endpoint{{
+			Binding:  bindingHTTPPost,
+			Location: p.AssertionConsumerServiceURL,
+			Index:    "0",
+		}},
+	}
+	if len(p.NameIDFormat) > 0 {
+		sp.NameIDFormats = []string{p.NameIDFormat}
+	}
+
+	data, err := xml.MarshalIndent(entityDescriptor{EntityID: p.EntityID, SPSSODescriptors: []spSSODescriptor{sp}}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
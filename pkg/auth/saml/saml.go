@@ -0,0 +1,479 @@
+// Package saml implements the SAML 2.0 Web Browser SSO profile with an external identity provider.
+// Authentication requests are sent through the HTTP-Redirect binding, and responses are received through
+// the HTTP-POST binding. Encrypted assertions are not supported.
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+)
+
+const (
+	protocolNamespace  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	assertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	bindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	statusSuccess            = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmationMethodBearer = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+
+	// clockSkew is the difference allowed between the clocks of the master and the identity provider
+	clockSkew = 3 * time.Minute
+)
+
+type Config struct {
+	// EntityID identifies the master to the identity provider
+	EntityID string
+	// AssertionConsumerServiceURL is the URL the identity provider posts responses to
+	AssertionConsumerServiceURL string
+	// SigningKey signs authentication requests. SigningCert is published in the metadata of the master.
+	SigningKey  *rsa.PrivateKey
+	SigningCert *x509.Certificate
+	// NameIDFormat is the optional format of the name identifier requested from the identity provider
+	NameIDFormat string
+
+	// SingleSignOnURL is the URL of the identity provider accepting authentication requests
+	SingleSignOnURL string
+	// Issuer is the entity ID of the identity provider
+	Issuer string
+	// Certificates are the certificates the identity provider signs responses and assertions with
+	Certificates []*x509.Certificate
+
+	IDAttributes                []string
+	PreferredUsernameAttributes []string
+	EmailAttributes             []string
+	NameAttributes              []string
+}
+
+type Provider struct {
+	providerName string
+	Config
+
+	clock kutil.Clock
+
+	// usedAssertions holds the IDs of the assertions already used, until they expire
+	usedAssertionsLock sync.Mutex
+	usedAssertions     map[string]time.Time
+}
+
+// NewProvider returns a SAML 2.0 service provider relying on a single identity provider
+func NewProvider(providerName string, config Config) (*Provider, error) {
+	if len(config.EntityID) == 0 {
+		return nil, errors.New("EntityID is required")
+	}
+	if len(config.AssertionConsumerServiceURL) == 0 {
+		return nil, errors.New("AssertionConsumerServiceURL is required")
+	}
+	if config.SigningKey == nil || config.SigningCert == nil {
+		return nil, errors.New("a signing key and certificate are required")
+	}
+
+	if len(config.SingleSignOnURL) == 0 {
+		return nil, errors.New("SingleSignOnURL is required")
+	} else if u, err := url.Parse(config.SingleSignOnURL); err != nil {
+		return nil, errors.New("SingleSignOnURL is invalid")
+	} else if u.Scheme != "https" {
+		return nil, errors.New("SingleSignOnURL must use https scheme")
+	}
+	if len(config.Issuer) == 0 {
+		return nil, errors.New("Issuer is required")
+	}
+	if len(config.Certificates) == 0 {
+		return nil, errors.New("at least one identity provider certificate is required")
+	}
+
+	return &Provider{
+		providerName:   providerName,
+		Config:         config,
+		clock:          kutil.RealClock{},
+		usedAssertions: map[string]time.Time{},
+	}, nil
+}
+
+type authnRequest struct {
+	XMLName                     xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string        `xml:",attr"`
+	Version                     string        `xml:",attr"`
+	IssueInstant                string        `xml:",attr"`
+	Destination                 string        `xml:",attr"`
+	ProtocolBinding             string        `xml:",attr"`
+	AssertionConsumerServiceURL string        `xml:",attr"`
+	Issuer                      string        `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                *nameIDPolicy `xml:"urn:oasis:names:tc:SAML:2.0:protocol NameIDPolicy"`
+}
+
+type nameIDPolicy struct {
+	Format      string `xml:",attr,omitempty"`
+	AllowCreate bool   `xml:",attr"`
+}
+
+// requestID returns the ID of the authentication request carrying the relay state. Responses must be in
+// response to it, which ties them to the relay state, and so to the browser that started the login.
+func requestID(relayState string) string {
+	sum := sha256.Sum256([]byte(relayState))
+	return "_" + hex.EncodeToString(sum[:])
+}
+
+// AuthnRequestURL returns the URL sending the user to the identity provider with a signed authentication
+// request, using the HTTP-Redirect binding
+func (p *Provider) AuthnRequestURL(relayState string) (string, error) {
+	request := authnRequest{
+		ID:                          requestID(relayState),
+		Version:                     "2.0",
+		IssueInstant:                p.clock.Now().UTC().Format(time.RFC3339),
+		Destination:                 p.SingleSignOnURL,
+		ProtocolBinding:             bindingHTTPPost,
+		AssertionConsumerServiceURL: p.AssertionConsumerServiceURL,
+		Issuer:                      p.EntityID,
+		NameIDPolicy:                &nameIDPolicy{Format: p.NameIDFormat, AllowCreate: true},
+	}
+	requestXML, err := xml.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	// The request is deflated and base64 encoded
+	deflated := &bytes.Buffer{}
+	writer, err := flate.NewWriter(deflated, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := writer.Write(requestXML); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	// The signature covers the encoded parameters, in this order
+	query := "SAMLRequest=" + url.QueryEscape(base64.StdEncoding.EncodeToString(deflated.Bytes()))
+	if len(relayState) > 0 {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	query += "&SigAlg=" + url.QueryEscape(algorithmRSASHA256)
+	digest := sha256.Sum256([]byte(query))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.SigningKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(signature))
+
+	ssoURL, err := url.Parse(p.SingleSignOnURL)
+	if err != nil {
+		return "", err
+	}
+	if len(ssoURL.RawQuery) > 0 {
+		ssoURL.RawQuery += "&" + query
+	} else {
+		ssoURL.RawQuery = query
+	}
+	return ssoURL.String(), nil
+}
+
+type response struct {
+	XMLName      xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:protocol Response"`
+	ID           string   `xml:",attr"`
+	Version      string   `xml:",attr"`
+	InResponseTo string   `xml:",attr"`
+	Destination  string   `xml:",attr"`
+	Issuer       string   `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Status       status   `xml:"urn:oasis:names:tc:SAML:2.0:protocol Status"`
+}
+
+type status struct {
+	StatusCode    statusCode `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusCode"`
+	StatusMessage string     `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusMessage"`
+}
+
+type statusCode struct {
+	Value      string      `xml:",attr"`
+	StatusCode *statusCode `xml:"urn:oasis:names:tc:SAML:2.0:protocol StatusCode"`
+}
+
+type assertion struct {
+	XMLName             xml.Name             `xml:"urn:oasis:names:tc:SAML:2.0:assertion Assertion"`
+	ID                  string               `xml:",attr"`
+	Version             string               `xml:",attr"`
+	Issuer              string               `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Subject             subject              `xml:"urn:oasis:names:tc:SAML:2.0:assertion Subject"`
+	Conditions          *conditions          `xml:"urn:oasis:names:tc:SAML:2.0:assertion Conditions"`
+	AuthnStatements     []authnStatement     `xml:"urn:oasis:names:tc:SAML:2.0:assertion AuthnStatement"`
+	AttributeStatements []attributeStatement `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeStatement"`
+}
+
+type subject struct {
+	NameID               string                `xml:"urn:oasis:names:tc:SAML:2.0:assertion NameID"`
+	SubjectConfirmations []subjectConfirmation `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmation"`
+}
+
+type subjectConfirmation struct {
+	Method                  string                   `xml:",attr"`
+	SubjectConfirmationData *subjectConfirmationData `xml:"urn:oasis:names:tc:SAML:2.0:assertion SubjectConfirmationData"`
+}
+
+type subjectConfirmationData struct {
+	NotOnOrAfter time.Time `xml:",attr"`
+	Recipient    string    `xml:",attr"`
+	InResponseTo string    `xml:",attr"`
+}
+
+type conditions struct {
+	NotBefore            time.Time             `xml:",attr"`
+	NotOnOrAfter         time.Time             `xml:",attr"`
+	AudienceRestrictions []audienceRestriction `xml:"urn:oasis:names:tc:SAML:2.0:assertion AudienceRestriction"`
+}
+
+type audienceRestriction struct {
+	Audiences []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion Audience"`
+}
+
+type authnStatement struct {
+	AuthnInstant time.Time `xml:",attr"`
+}
+
+type attributeStatement struct {
+	Attributes []attribute `xml:"urn:oasis:names:tc:SAML:2.0:assertion Attribute"`
+}
+
+type attribute struct {
+	Name         string   `xml:",attr"`
+	FriendlyName string   `xml:",attr"`
+	Values       []string `xml:"urn:oasis:names:tc:SAML:2.0:assertion AttributeValue"`
+}
+
+// GetUserIdentity checks the base64 encoded response posted by the identity provider for the
+// authentication request carrying the relay state, and returns the identity asserted by it
+func (p *Provider) GetUserIdentity(encodedResponse, relayState string) (authapi.UserIdentityInfo, error) {
+	data, err := decodeBase64(encodedResponse)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAMLResponse: %v", err)
+	}
+	assertion, err := p.parseResponse(data, requestID(relayState))
+	if err != nil {
+		return nil, err
+	}
+
+	id := strings.TrimSpace(assertion.Subject.NameID)
+	if len(p.IDAttributes) > 0 {
+		id = attributeValue(assertion, p.IDAttributes)
+	}
+	if len(id) == 0 {
+		return nil, fmt.Errorf("Could not retrieve id attribute for %#v", p.IDAttributes)
+	}
+	identity := authapi.NewDefaultUserIdentityInfo(p.providerName, id)
+
+	if preferredUsername := attributeValue(assertion, p.PreferredUsernameAttributes); len(preferredUsername) != 0 {
+		identity.Extra[authapi.IdentityPreferredUsernameKey] = preferredUsername
+	}
+
+	if email := attributeValue(assertion, p.EmailAttributes); len(email) != 0 {
+		identity.Extra[authapi.IdentityEmailKey] = email
+	}
+
+	if name := attributeValue(assertion, p.NameAttributes); len(name) != 0 {
+		identity.Extra[authapi.IdentityDisplayNameKey] = name
+	}
+
+	glog.V(4).Infof("identity=%v", identity)
+
+	return identity, nil
+}
+
+// parseResponse verifies the signatures of a response and returns its assertion once it is checked.
+// Either the response or the assertion must be signed by the identity provider. Only the signed elements
+// are read, so content added around them can't be mistaken for signed content.
+func (p *Provider) parseResponse(data []byte, expectedRequestID string) (*assertion, error) {
+	root, err := parseDocument(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SAMLResponse: %v", err)
+	}
+	if !root.is(protocolNamespace, "Response") {
+		return nil, fmt.Errorf("expected a Response, got %s", root.local)
+	}
+	if err := checkUniqueIDs(root); err != nil {
+		return nil, err
+	}
+
+	responseSigned := false
+	switch err := verifySignature(root, p.Certificates); err {
+	case nil:
+		responseSigned = true
+	case errNotSigned:
+	default:
+		return nil, err
+	}
+
+	resp := &response{}
+	if err := xml.Unmarshal(canonicalize(root, nil, nil), resp); err != nil {
+		return nil, err
+	}
+	if resp.Version != "2.0" {
+		return nil, fmt.Errorf("unsupported SAML version %q", resp.Version)
+	}
+	if code := resp.Status.StatusCode; code.Value != statusSuccess {
+		if code.StatusCode != nil {
+			return nil, fmt.Errorf("identity provider returned status %s (%s): %s", code.Value, code.StatusCode.Value, resp.Status.StatusMessage)
+		}
+		return nil, fmt.Errorf("identity provider returned status %s: %s", code.Value, resp.Status.StatusMessage)
+	}
+	if len(resp.Destination) > 0 && resp.Destination != p.AssertionConsumerServiceURL {
+		return nil, fmt.Errorf("response was sent to %s", resp.Destination)
+	}
+	if len(resp.InResponseTo) > 0 && resp.InResponseTo != expectedRequestID {
+		return nil, errors.New("response is not in response to the authentication request")
+	}
+	if issuer := strings.TrimSpace(resp.Issuer); len(issuer) > 0 && issuer != p.Issuer {
+		return nil, fmt.Errorf("response was issued by %s", issuer)
+	}
+
+	if len(root.childElements(assertionNamespace, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("encrypted assertions are not supported")
+	}
+	assertionElement, err := root.singleChild(assertionNamespace, "Assertion")
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(assertionElement, p.Certificates); err != nil && !(err == errNotSigned && responseSigned) {
+		return nil, err
+	}
+
+	a := &assertion{}
+	if err := xml.Unmarshal(canonicalize(assertionElement, nil, nil), a); err != nil {
+		return nil, err
+	}
+	if err := p.checkAssertion(a, expectedRequestID); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// checkAssertion checks an assertion is meant for the master, for the authentication request, and is
+// used once while it is valid
+func (p *Provider) checkAssertion(a *assertion, expectedRequestID string) error {
+	now := p.clock.Now()
+
+	if a.Version != "2.0" {
+		return fmt.Errorf("unsupported SAML version %q", a.Version)
+	}
+	if issuer := strings.TrimSpace(a.Issuer); issuer != p.Issuer {
+		return fmt.Errorf("assertion was issued by %s", issuer)
+	}
+	if len(a.AuthnStatements) == 0 {
+		return errors.New("assertion has no authentication statement")
+	}
+
+	// A bearer confirmation must be meant for the authentication request and the master
+	var expires time.Time
+	for _, confirmation := range a.Subject.SubjectConfirmations {
+		data := confirmation.SubjectConfirmationData
+		if confirmation.Method != confirmationMethodBearer || data == nil {
+			continue
+		}
+		if data.Recipient != p.AssertionConsumerServiceURL || data.InResponseTo != expectedRequestID {
+			continue
+		}
+		if data.NotOnOrAfter.IsZero() || !now.Before(data.NotOnOrAfter.Add(clockSkew)) {
+			continue
+		}
+		expires = data.NotOnOrAfter
+		break
+	}
+	if expires.IsZero() {
+		return errors.New("assertion has no valid bearer subject confirmation")
+	}
+
+	if c := a.Conditions; c != nil {
+		if !c.NotBefore.IsZero() && now.Add(clockSkew).Before(c.NotBefore) {
+			return errors.New("assertion is not valid yet")
+		}
+		if !c.NotOnOrAfter.IsZero() && !now.Before(c.NotOnOrAfter.Add(clockSkew)) {
+			return errors.New("assertion has expired")
+		}
+		for _, restriction := range c.AudienceRestrictions {
+			found := false
+			for _, audience := range restriction.Audiences {
+				if strings.TrimSpace(audience) == p.EntityID {
+					found = true
+				}
+			}
+			if !found {
+				return errors.New("assertion is not meant for this service provider")
+			}
+		}
+	}
+
+	// Bearer assertions may only be used once
+	p.usedAssertionsLock.Lock()
+	defer p.usedAssertionsLock.Unlock()
+	for id, expiry := range p.usedAssertions {
+		if !now.Before(expiry) {
+			delete(p.usedAssertions, id)
+		}
+	}
+	if len(a.ID) == 0 {
+		return errors.New("assertion has no ID")
+	}
+	if _, used := p.usedAssertions[a.ID]; used {
+		return errors.New("assertion was already used")
+	}
+	p.usedAssertions[a.ID] = expires.Add(clockSkew)
+
+	return nil
+}
+
+// checkUniqueIDs refuses documents where several elements have the same ID, as signatures reference
+// elements by ID
+func checkUniqueIDs(root *element) error {
+	ids := map[string]bool{}
+	return root.walk(func(e *element) error {
+		id := e.attr("ID")
+		if len(id) == 0 {
+			return nil
+		}
+		if ids[id] {
+			return fmt.Errorf("ID %q is used by multiple elements", id)
+		}
+		ids[id] = true
+		return nil
+	})
+}
+
+// attributeValue returns the first value of the first attribute found, matching attributes by name or
+// friendly name
+func attributeValue(a *assertion, names []string) string {
+	for _, name := range names {
+		for _, statement := range a.AttributeStatements {
+			for _, attr := range statement.Attributes {
+				if attr.Name != name && attr.FriendlyName != name {
+					continue
+				}
+				for _, value := range attr.Values {
+					if value = strings.TrimSpace(value); len(value) > 0 {
+						return value
+					}
+				}
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,470 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/xml"
+	"io/ioutil"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+)
+
+const (
+	testEntityID   = "https://master.example.com/oauth2callback/saml/metadata"
+	testACSURL     = "https://master.example.com/oauth2callback/saml"
+	testIssuer     = "https://idp.example.com/metadata"
+	testSSOURL     = "https://idp.example.com/sso?tenant=1"
+	testRelayState = "state"
+)
+
+// testCertificate generates a self-signed certificate, like the one an identity provider signs with
+func testCertificate(t *testing.T, commonName string) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return key, cert
+}
+
+type testIdentityProvider struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+}
+
+type testResponse struct {
+	Status       string
+	Destination  string
+	InResponseTo string
+	Issuer       string
+	AssertionID  string
+	NameID       string
+	Recipient    string
+	Audience     string
+	IssueInstant time.Time
+}
+
+func newTestResponse(now time.Time) testResponse {
+	return testResponse{
+		Status:       statusSuccess,
+		Destination:  testACSURL,
+		InResponseTo: requestID(testRelayState),
+		Issuer:       testIssuer,
+		AssertionID:  "_assertion",
+		NameID:       "jdoe@example.com",
+		Recipient:    testACSURL,
+		Audience:     testEntityID,
+		IssueInstant: now,
+	}
+}
+
+func (r testResponse) xml() string {
+	instant := func(d time.Duration) string {
+		return r.IssueInstant.Add(d).UTC().Format(time.RFC3339)
+	}
+	return strings.NewReplacer(
+		"$STATUS", r.Status,
+		"$DESTINATION", r.Destination,
+		"$IN_RESPONSE_TO", r.InResponseTo,
+		"$ISSUER", r.Issuer,
+		"$ASSERTION_ID", r.AssertionID,
+		"$NAME_ID", r.NameID,
+		"$RECIPIENT", r.Recipient,
+		"$AUDIENCE", r.Audience,
+		"$NOW", instant(0),
+		"$NOT_BEFORE", instant(-time.Minute),
+		"$NOT_ON_OR_AFTER", instant(5*time.Minute),
+	).Replace(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_response" Version="2.0" IssueInstant="$NOW" Destination="$DESTINATION" InResponseTo="$IN_RESPONSE_TO">
+  <saml:Issuer>$ISSUER</saml:Issuer>
+  <samlp:Status><samlp:StatusCode Value="$STATUS"/></samlp:Status>
+  <saml:Assertion xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" ID="$ASSERTION_ID" Version="2.0" IssueInstant="$NOW">
+    <saml:Issuer>$ISSUER</saml:Issuer>
+    <saml:Subject>
+      <saml:NameID Format="urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress">$NAME_ID</saml:NameID>
+      <saml:SubjectConfirmation Method="urn:oasis:names:tc:SAML:2.0:cm:bearer">
+        <saml:SubjectConfirmationData NotOnOrAfter="$NOT_ON_OR_AFTER" Recipient="$RECIPIENT" InResponseTo="$IN_RESPONSE_TO"/>
+      </saml:SubjectConfirmation>
+    </saml:Subject>
+    <saml:Conditions NotBefore="$NOT_BEFORE" NotOnOrAfter="$NOT_ON_OR_AFTER">
+      <saml:AudienceRestriction><saml:Audience>$AUDIENCE</saml:Audience></saml:AudienceRestriction>
+    </saml:Conditions>
+    <saml:AuthnStatement AuthnInstant="$NOW"/>
+    <saml:AttributeStatement>
+      <saml:Attribute Name="urn:oid:0.9.2342.19200300.100.1.1" FriendlyName="uid"><saml:AttributeValue xsi:type="xs:string">jdoe</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="mail"><saml:AttributeValue xsi:type="xs:string">jdoe@example.com</saml:AttributeValue></saml:Attribute>
+      <saml:Attribute Name="displayName"><saml:AttributeValue xsi:type="xs:string">John Doe</saml:AttributeValue></saml:Attribute>
+    </saml:AttributeStatement>
+  </saml:Assertion>
+</samlp:Response>`)
+}
+
+// sign adds an enveloped signature after the Issuer of the element with the given ID
+func (idp *testIdentityProvider) sign(t *testing.T, document, id string) string {
+	root, err := parseDocument([]byte(document))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	digest := sha256.Sum256(canonicalize(findID(root, id), nil, []string{"xs"}))
+
+	signature := `<ds:Signature xmlns:ds="http://www.w3.org/2000/09/xmldsig#"><ds:SignedInfo>` +
+		`<ds:CanonicalizationMethod Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"/>` +
+		`<ds:SignatureMethod Algorithm="http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"/>` +
+		`<ds:Reference URI="#` + id + `"><ds:Transforms>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2000/09/xmldsig#enveloped-signature"/>` +
+		`<ds:Transform Algorithm="http://www.w3.org/2001/10/xml-exc-c14n#"><ec:InclusiveNamespaces xmlns:ec="http://www.w3.org/2001/10/xml-exc-c14n#" PrefixList="xs"/></ds:Transform>` +
+		`</ds:Transforms><ds:DigestMethod Algorithm="http://www.w3.org/2001/04/xmlenc#sha256"/>` +
+		`<ds:DigestValue>` + base64.StdEncoding.EncodeToString(digest[:]) + `</ds:DigestValue>` +
+		`</ds:Reference></ds:SignedInfo><ds:SignatureValue>$SIGNATURE</ds:SignatureValue>` +
+		`<ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(idp.cert.Raw) + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo>` +
+		`</ds:Signature>`
+
+	start := strings.Index(document, `ID="`+id+`"`)
+	issuerEnd := strings.Index(document[start:], "</saml:Issuer>") + start + len("</saml:Issuer>")
+	document = document[:issuerEnd] + signature + document[issuerEnd:]
+
+	root, err = parseDocument([]byte(document))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	signedInfo := findID(root, id).childElements(signatureNamespace, "Signature")[0].childElements(signatureNamespace, "SignedInfo")[0]
+	signedInfoDigest := sha256.Sum256(canonicalize(signedInfo, nil, nil))
+	signatureValue, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, signedInfoDigest[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return strings.Replace(document, "$SIGNATURE", base64.StdEncoding.EncodeToString(signatureValue), 1)
+}
+
+func newTestProvider(t *testing.T, idpCert *x509.Certificate, clock kutil.Clock) *Provider {
+	spKey, spCert := testCertificate(t, "master")
+	provider, err := NewProvider("saml", Config{
+		EntityID:                    testEntityID,
+		AssertionConsumerServiceURL: testACSURL,
+		SigningKey:                  spKey,
+		SigningCert:                 spCert,
+		NameIDFormat:                "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress",
+
+		SingleSignOnURL: testSSOURL,
+		Issuer:          testIssuer,
+		Certificates:    []*x509.Certificate{idpCert},
+
+		PreferredUsernameAttributes: []string{"uid"},
+		EmailAttributes:             []string{"mail"},
+		NameAttributes:              []string{"displayName"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	provider.clock = clock
+	return provider
+}
+
+func TestGetUserIdentity(t *testing.T) {
+	idpKey, idpCert := testCertificate(t, "idp")
+	idp := &testIdentityProvider{key: idpKey, cert: idpCert}
+	otherKey, otherCert := testCertificate(t, "other")
+	other := &testIdentityProvider{key: otherKey, cert: otherCert}
+
+	now := time.Now()
+
+	expectedIdentity := authapi.NewDefaultUserIdentityInfo("saml", "jdoe@example.com")
+	expectedIdentity.Extra[authapi.IdentityPreferredUsernameKey] = "jdoe"
+	expectedIdentity.Extra[authapi.IdentityEmailKey] = "jdoe@example.com"
+	expectedIdentity.Extra[authapi.IdentityDisplayNameKey] = "John Doe"
+
+	testCases := map[string]struct {
+		Response      func() string
+		RelayState    string
+		Elapsed       time.Duration
+		IDAttributes  []string
+		ExpectedError string
+	}{
+		"signed assertion": {
+			Response: func() string { return idp.sign(t, newTestResponse(now).xml(), "_assertion") },
+		},
+		"signed response": {
+			Response: func() string { return idp.sign(t, newTestResponse(now).xml(), "_response") },
+		},
+		"signed response and assertion": {
+			Response: func() string {
+				return idp.sign(t, idp.sign(t, newTestResponse(now).xml(), "_assertion"), "_response")
+			},
+		},
+		"id attribute": {
+			Response:     func() string { return idp.sign(t, newTestResponse(now).xml(), "_assertion") },
+			IDAttributes: []string{"missing", "urn:oid:0.9.2342.19200300.100.1.1"},
+		},
+		"missing id attribute": {
+			Response:      func() string { return idp.sign(t, newTestResponse(now).xml(), "_assertion") },
+			IDAttributes:  []string{"missing"},
+			ExpectedError: "Could not retrieve id attribute",
+		},
+		"unsigned": {
+			Response:      func() string { return newTestResponse(now).xml() },
+			ExpectedError: "element is not signed",
+		},
+		"signed by another identity provider": {
+			Response:      func() string { return other.sign(t, newTestResponse(now).xml(), "_assertion") },
+			ExpectedError: "not made by a trusted certificate",
+		},
+		"modified after signing": {
+			Response: func() string {
+				return strings.Replace(idp.sign(t, newTestResponse(now).xml(), "_assertion"), ">jdoe@example.com</saml:NameID>", ">admin@example.com</saml:NameID>", 1)
+			},
+			ExpectedError: "digest of Assertion does not match",
+		},
+		"signed assertion wrapped by an unsigned one": {
+			Response: func() string {
+				signed := idp.sign(t, newTestResponse(now).xml(), "_assertion")
+				start := strings.Index(signed, "<saml:Assertion ")
+				end := strings.Index(signed, "</saml:Assertion>") + len("</saml:Assertion>")
+				forged := strings.Replace(signed[start:end], ">jdoe@example.com</saml:NameID>", ">admin@example.com</saml:NameID>", 1)
+				forged = strings.Replace(forged, `ID="_assertion"`, `ID="_forged"`, 1)
+				forged = strings.Replace(forged, "<saml:Subject>", "<saml:Subject><saml:Advice>"+signed[start:end]+"</saml:Advice>", 1)
+				return signed[:start] + forged + signed[end:]
+			},
+			ExpectedError: "does not reference it",
+		},
+		"duplicate IDs": {
+			Response: func() string {
+				signed := idp.sign(t, newTestResponse(now).xml(), "_assertion")
+				return strings.Replace(signed, "<samlp:Status>", `<samlp:Extensions><saml:Assertion ID="_assertion"/></samlp:Extensions><samlp:Status>`, 1)
+			},
+			ExpectedError: `ID "_assertion" is used by multiple elements`,
+		},
+		"failed status": {
+			Response: func() string {
+				response := newTestResponse(now)
+				response.Status = "urn:oasis:names:tc:SAML:2.0:status:Responder"
+				return idp.sign(t, response.xml(), "_response")
+			},
+			ExpectedError: "identity provider returned status urn:oasis:names:tc:SAML:2.0:status:Responder",
+		},
+		"other request": {
+			Response:      func() string { return idp.sign(t, newTestResponse(now).xml(), "_assertion") },
+			RelayState:    "other state",
+			ExpectedError: "not in response to the authentication request",
+		},
+		"other recipient": {
+			Response: func() string {
+				response := newTestResponse(now)
+				response.Destination = ""
+				response.Recipient = "https://other.example.com/acs"
+				return idp.sign(t, response.xml(), "_assertion")
+			},
+			ExpectedError: "no valid bearer subject confirmation",
+		},
+		"other audience": {
+			Response: func() string {
+				response := newTestResponse(now)
+				response.Audience = "https://other.example.com"
+				return idp.sign(t, response.xml(), "_assertion")
+			},
+			ExpectedError: "not meant for this service provider",
+		},
+		"other issuer": {
+			Response: func() string {
+				response := newTestResponse(now)
+				response.Issuer = "https://other.example.com"
+				return idp.sign(t, response.xml(), "_assertion")
+			},
+			ExpectedError: "response was issued by https://other.example.com",
+		},
+		"expired": {
+			Response:      func() string { return idp.sign(t, newTestResponse(now).xml(), "_assertion") },
+			Elapsed:       10 * time.Minute,
+			ExpectedError: "no valid bearer subject confirmation",
+		},
+		"not valid yet": {
+			Response: func() string {
+				return idp.sign(t, newTestResponse(now.Add(10*time.Minute)).xml(), "_assertion")
+			},
+			ExpectedError: "assertion is not valid yet",
+		},
+	}
+
+	for k, tc := range testCases {
+		clock := &kutil.FakeClock{Time: now}
+		provider := newTestProvider(t, idpCert, clock)
+		provider.IDAttributes = tc.IDAttributes
+		clock.Step(tc.Elapsed)
+
+		relayState := tc.RelayState
+		if len(relayState) == 0 {
+			relayState = testRelayState
+		}
+
+		encoded := base64.StdEncoding.EncodeToString([]byte(tc.Response()))
+		identity, err := provider.GetUserIdentity(encoded, relayState)
+		if len(tc.ExpectedError) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tc.ExpectedError) {
+				t.Errorf("%s: expected error containing %q, got %v", k, tc.ExpectedError, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+
+		expected := expectedIdentity
+		if len(tc.IDAttributes) > 0 {
+			expected = authapi.NewDefaultUserIdentityInfo("saml", "jdoe")
+			for key, value := range expectedIdentity.Extra {
+				expected.Extra[key] = value
+			}
+		}
+		if identity.GetProviderName() != expected.GetProviderName() || identity.GetProviderUserName() != expected.GetProviderUserName() {
+			t.Errorf("%s: expected identity %s:%s, got %s:%s", k, expected.GetProviderName(), expected.GetProviderUserName(), identity.GetProviderName(), identity.GetProviderUserName())
+		}
+		for key, value := range expected.Extra {
+			if identity.GetExtra()[key] != value {
+				t.Errorf("%s: expected %s=%q, got %q", k, key, value, identity.GetExtra()[key])
+			}
+		}
+	}
+}
+
+func TestAssertionReplay(t *testing.T) {
+	idpKey, idpCert := testCertificate(t, "idp")
+	idp := &testIdentityProvider{key: idpKey, cert: idpCert}
+	now := time.Now()
+	provider := newTestProvider(t, idpCert, &kutil.FakeClock{Time: now})
+
+	encoded := base64.StdEncoding.EncodeToString([]byte(idp.sign(t, newTestResponse(now).xml(), "_assertion")))
+	if _, err := provider.GetUserIdentity(encoded, testRelayState); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := provider.GetUserIdentity(encoded, testRelayState); err == nil || !strings.Contains(err.Error(), "already used") {
+		t.Fatalf("expected a replayed assertion to be refused, got %v", err)
+	}
+}
+
+func TestAuthnRequestURL(t *testing.T) {
+	_, idpCert := testCertificate(t, "idp")
+	now := time.Now()
+	provider := newTestProvider(t, idpCert, &kutil.FakeClock{Time: now})
+
+	requestURL, err := provider.AuthnRequestURL(testRelayState)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(requestURL, testSSOURL+"&SAMLRequest=") {
+		t.Fatalf("expected the request to be sent to %s, got %s", testSSOURL, requestURL)
+	}
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := u.Query()
+	if query.Get("tenant") != "1" || query.Get("RelayState") != testRelayState || query.Get("SigAlg") != algorithmRSASHA256 {
+		t.Errorf("unexpected query %v", query)
+	}
+
+	// The signature covers the raw query, up to the signature
+	signed := u.RawQuery[strings.Index(u.RawQuery, "SAMLRequest="):strings.Index(u.RawQuery, "&Signature=")]
+	signature, err := base64.StdEncoding.DecodeString(query.Get("Signature"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	digest := sha256.Sum256([]byte(signed))
+	if err := rsa.VerifyPKCS1v15(provider.SigningCert.PublicKey.(*rsa.PublicKey), crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("invalid signature: %v", err)
+	}
+
+	deflated, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	requestXML, err := ioutil.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	request := &authnRequest{}
+	if err := xml.Unmarshal(requestXML, request); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if request.ID != requestID(testRelayState) {
+		t.Errorf("expected the request ID to be derived from the relay state, got %s", request.ID)
+	}
+	if request.Issuer != testEntityID || request.AssertionConsumerServiceURL != testACSURL || request.Destination != testSSOURL || request.ProtocolBinding != bindingHTTPPost {
+		t.Errorf("unexpected request %s", requestXML)
+	}
+	if request.NameIDPolicy == nil || request.NameIDPolicy.Format != provider.NameIDFormat || !request.NameIDPolicy.AllowCreate {
+		t.Errorf("unexpected name ID policy in %s", requestXML)
+	}
+}
+
+func TestMetadata(t *testing.T) {
+	_, idpCert := testCertificate(t, "idp")
+	_, encryptionCert := testCertificate(t, "encryption")
+	idpMetadata := `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="` + testIssuer + `">
+  <md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + base64.StdEncoding.EncodeToString(encryptionCert.Raw) + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+    <md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>
+` + base64.StdEncoding.EncodeToString(idpCert.Raw) + `
+    </ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example.com/sso/post"/>
+    <md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="` + testSSOURL + `"/>
+  </md:IDPSSODescriptor>
+</md:EntityDescriptor>`
+
+	metadata, err := ParseIdentityProviderMetadata([]byte(idpMetadata))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if metadata.EntityID != testIssuer || metadata.SingleSignOnURL != testSSOURL {
+		t.Errorf("unexpected metadata %#v", metadata)
+	}
+	if len(metadata.Certificates) != 1 || !metadata.Certificates[0].Equal(idpCert) {
+		t.Errorf("expected the signing certificate only, got %#v", metadata.Certificates)
+	}
+
+	provider := newTestProvider(t, idpCert, kutil.RealClock{})
+	spMetadata, err := provider.Metadata()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	descriptor := &entityDescriptor{}
+	if err := xml.Unmarshal(spMetadata, descriptor); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if descriptor.EntityID != testEntityID || len(descriptor.SPSSODescriptors) != 1 {
+		t.Fatalf("unexpected metadata %s", spMetadata)
+	}
+	sp := descriptor.SPSSODescriptors[0]
+	if !sp.AuthnRequestsSigned || len(sp.AssertionConsumerServices) != 1 || sp.AssertionConsumerServices[0].Location != testACSURL || sp.AssertionConsumerServices[0].Binding != bindingHTTPPost {
+		t.Errorf("unexpected metadata %s", spMetadata)
+	}
+	if len(sp.KeyDescriptors) != 1 || len(sp.KeyDescriptors[0].KeyInfo.X509Certificates) != 1 || sp.KeyDescriptors[0].KeyInfo.X509Certificates[0] != base64.StdEncoding.EncodeToString(provider.SigningCert.Raw) {
+		t.Errorf("expected the signing certificate in metadata %s", spMetadata)
+	}
+}
@@ -0,0 +1,166 @@
+package saml
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	// register the digests used by signatures
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+)
+
+const (
+	signatureNamespace = "http://www.w3.org/2000/09/xmldsig#"
+	excC14NNamespace   = "http://www.w3.org/2001/10/xml-exc-c14n#"
+
+	algorithmExcC14N            = "http://www.w3.org/2001/10/xml-exc-c14n#"
+	algorithmEnvelopedSignature = "http://www.w3.org/2000/09/xmldsig#enveloped-signature"
+
+	algorithmRSASHA1   = "http://www.w3.org/2000/09/xmldsig#rsa-sha1"
+	algorithmRSASHA256 = "http://www.w3.org/2001/04/xmldsig-more#rsa-sha256"
+
+	algorithmSHA1   = "http://www.w3.org/2000/09/xmldsig#sha1"
+	algorithmSHA256 = "http://www.w3.org/2001/04/xmlenc#sha256"
+)
+
+var (
+	signatureHashes = map[string]crypto.Hash{
+		algorithmRSASHA1:   crypto.SHA1,
+		algorithmRSASHA256: crypto.SHA256,
+	}
+	digestHashes = map[string]crypto.Hash{
+		algorithmSHA1:   crypto.SHA1,
+		algorithmSHA256: crypto.SHA256,
+	}
+)
+
+// errNotSigned is returned when an element has no signature
+var errNotSigned = errors.New("element is not signed")
+
+// verifySignature checks that the enveloped signature of an element was made by one of the certificates.
+// Only the XML signature profile used by SAML is supported: the signature is a direct child of the element,
+// and has a single reference to the ID of the element, with the enveloped signature and exclusive
+// canonicalization transforms. Key information included in the signature is ignored.
+func verifySignature(e *element, certs []*x509.Certificate) error {
+	signatures := e.childElements(signatureNamespace, "Signature")
+	if len(signatures) == 0 {
+		return errNotSigned
+	}
+	if len(signatures) > 1 {
+		return fmt.Errorf("%s has multiple signatures", e.local)
+	}
+	signature := signatures[0]
+
+	signedInfo, err := signature.singleChild(signatureNamespace, "SignedInfo")
+	if err != nil {
+		return err
+	}
+	canonicalizationMethod, err := signedInfo.singleChild(signatureNamespace, "CanonicalizationMethod")
+	if err != nil {
+		return err
+	}
+	if algorithm := canonicalizationMethod.attr("Algorithm"); algorithm != algorithmExcC14N {
+		return fmt.Errorf("unsupported canonicalization method %q", algorithm)
+	}
+	signatureMethod, err := signedInfo.singleChild(signatureNamespace, "SignatureMethod")
+	if err != nil {
+		return err
+	}
+	signatureHash, ok := signatureHashes[signatureMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("unsupported signature method %q", signatureMethod.attr("Algorithm"))
+	}
+
+	reference, err := signedInfo.singleChild(signatureNamespace, "Reference")
+	if err != nil {
+		return err
+	}
+	// The reference must point to the element holding the signature, so what was signed is what is used
+	if id := e.attr("ID"); len(id) == 0 || reference.attr("URI") != "#"+id {
+		return fmt.Errorf("signature of %s does not reference it", e.local)
+	}
+
+	transforms, err := reference.singleChild(signatureNamespace, "Transforms")
+	if err != nil {
+		return err
+	}
+	enveloped, canonicalized := false, false
+	var inclusivePrefixes []string
+	for _, transform := range transforms.childElements(signatureNamespace, "Transform") {
+		switch algorithm := transform.attr("Algorithm"); algorithm {
+		case algorithmEnvelopedSignature:
+			enveloped = true
+		case algorithmExcC14N:
+			canonicalized = true
+			inclusivePrefixes = inclusiveNamespacePrefixes(transform)
+		default:
+			return fmt.Errorf("unsupported transform %q", algorithm)
+		}
+	}
+	if !enveloped || !canonicalized {
+		return errors.New("signature must use the enveloped signature and exclusive canonicalization transforms")
+	}
+
+	digestMethod, err := reference.singleChild(signatureNamespace, "DigestMethod")
+	if err != nil {
+		return err
+	}
+	digestHash, ok := digestHashes[digestMethod.attr("Algorithm")]
+	if !ok {
+		return fmt.Errorf("unsupported digest method %q", digestMethod.attr("Algorithm"))
+	}
+	digestValue, err := reference.singleChild(signatureNamespace, "DigestValue")
+	if err != nil {
+		return err
+	}
+	expectedDigest, err := decodeBase64(digestValue.text())
+	if err != nil {
+		return fmt.Errorf("invalid digest value: %v", err)
+	}
+	if digest := hashBytes(digestHash, canonicalize(e, signature, inclusivePrefixes)); subtle.ConstantTimeCompare(digest, expectedDigest) != 1 {
+		return fmt.Errorf("digest of %s does not match its signature", e.local)
+	}
+
+	signatureValue, err := signature.singleChild(signatureNamespace, "SignatureValue")
+	if err != nil {
+		return err
+	}
+	signatureBytes, err := decodeBase64(signatureValue.text())
+	if err != nil {
+		return fmt.Errorf("invalid signature value: %v", err)
+	}
+	signedInfoDigest := hashBytes(signatureHash, canonicalize(signedInfo, nil, inclusiveNamespacePrefixes(canonicalizationMethod)))
+	for _, cert := range certs {
+		if publicKey, ok := cert.PublicKey.(*rsa.PublicKey); ok && rsa.VerifyPKCS1v15(publicKey, signatureHash, signedInfoDigest, signatureBytes) == nil {
+			return nil
+		}
+	}
+	return fmt.Errorf("signature of %s was not made by a trusted certificate", e.local)
+}
+
+// inclusiveNamespacePrefixes returns the PrefixList of the InclusiveNamespaces parameter of an exclusive
+// canonicalization transform or method
+func inclusiveNamespacePrefixes(e *element) []string {
+	prefixes := []string{}
+	for _, inclusiveNamespaces := range e.childElements(excC14NNamespace, "InclusiveNamespaces") {
+		prefixes = append(prefixes, strings.Fields(inclusiveNamespaces.attr("PrefixList"))...)
+	}
+	return prefixes
+}
+
+func hashBytes(hash crypto.Hash, data []byte) []byte {
+	h := hash.New()
+	h.Write(data)
+	return h.Sum(nil)
+}
+
+// decodeBase64 decodes base64 content, which may be broken into lines
+func decodeBase64(s string) ([]byte, error) {
+	return base64.StdEncoding.DecodeString(strings.Join(strings.Fields(s), ""))
+}
@@ -0,0 +1,325 @@
+package saml
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+const xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+
+// element is an element of a parsed XML document. Unlike the structs filled by encoding/xml, it keeps the
+// prefixes and namespace declarations of the document, which are needed to canonicalize signed elements.
+type element struct {
+	parent *element
+
+	prefix string
+	local  string
+
+	// namespaces holds the namespaces declared on the element, keyed by prefix ("" for the default namespace)
+	namespaces map[string]string
+	// attrs holds the other attributes, with the prefix in Name.Space
+	attrs []xml.Attr
+
+	// children holds *element and text nodes, in document order. Comments and processing instructions are dropped.
+	children []interface{}
+}
+
+// text is a text node
+type text string
+
+// parseDocument parses an XML document into a tree of elements. Documents with a DTD are refused.
+func parseDocument(data []byte) (*element, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+
+	var root, current *element
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			if current == nil && root != nil {
+				return nil, errors.New("document has multiple root elements")
+			}
+			e := &element{parent: current, prefix: t.Name.Space, local: t.Name.Local, namespaces: map[string]string{}}
+			for _, attr := range t.Attr {
+				switch {
+				case attr.Name.Space == "" && attr.Name.Local == "xmlns":
+					e.namespaces[""] = attr.Value
+				case attr.Name.Space == "xmlns":
+					e.namespaces[attr.Name.Local] = attr.Value
+				default:
+					e.attrs = append(e.attrs, attr)
+				}
+			}
+			if _, ok := e.lookupNamespace(e.prefix); !ok {
+				return nil, fmt.Errorf("element %s uses an undeclared namespace prefix", e.qualifiedName())
+			}
+			for _, attr := range e.attrs {
+				if _, ok := e.lookupNamespace(attr.Name.Space); !ok {
+					return nil, fmt.Errorf("attribute %s:%s uses an undeclared namespace prefix", attr.Name.Space, attr.Name.Local)
+				}
+			}
+
+			if current == nil {
+				root = e
+			} else {
+				current.children = append(current.children, e)
+			}
+			current = e
+
+		case xml.EndElement:
+			if current == nil || t.Name.Space != current.prefix || t.Name.Local != current.local {
+				return nil, fmt.Errorf("unexpected end element %s", t.Name.Local)
+			}
+			current = current.parent
+
+		case xml.CharData:
+			if current != nil {
+				current.children = append(current.children, text(t))
+			} else if len(bytes.TrimSpace(t)) > 0 {
+				return nil, errors.New("document has text outside of the root element")
+			}
+
+		case xml.Directive:
+			return nil, errors.New("document type definitions are not allowed")
+		}
+	}
+
+	if root == nil || current != nil {
+		return nil, errors.New("document is incomplete")
+	}
+	return root, nil
+}
+
+// lookupNamespace returns the namespace a prefix is bound to in the scope of the element
+func (e *element) lookupNamespace(prefix string) (string, bool) {
+	if prefix == "xml" {
+		return xmlNamespace, true
+	}
+	for el := e; el != nil; el = el.parent {
+		if namespace, ok := el.namespaces[prefix]; ok {
+			return namespace, true
+		}
+	}
+	// elements without a prefix are in no namespace unless a default namespace was declared
+	return "", len(prefix) == 0
+}
+
+func (e *element) namespace() string {
+	namespace, _ := e.lookupNamespace(e.prefix)
+	return namespace
+}
+
+func (e *element) qualifiedName() string {
+	if len(e.prefix) == 0 {
+		return e.local
+	}
+	return e.prefix + ":" + e.local
+}
+
+// is returns true if the element has the given namespace and local name
+func (e *element) is(namespace, local string) bool {
+	return e.local == local && e.namespace() == namespace
+}
+
+// attr returns the value of an attribute without a prefix
+func (e *element) attr(name string) string {
+	for _, attr := range e.attrs {
+		if len(attr.Name.Space) == 0 && attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// childElements returns the direct children with the given namespace and local name
+func (e *element) childElements(namespace, local string) []*element {
+	elements := []*element{}
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok && el.is(namespace, local) {
+			elements = append(elements, el)
+		}
+	}
+	return elements
+}
+
+// singleChild returns the only direct child with the given namespace and local name
+func (e *element) singleChild(namespace, local string) (*element, error) {
+	elements := e.childElements(namespace, local)
+	if len(elements) != 1 {
+		return nil, fmt.Errorf("expected exactly one %s element in %s, got %d", local, e.local, len(elements))
+	}
+	return elements[0], nil
+}
+
+// text returns the concatenated text nodes of the element
+func (e *element) text() string {
+	value := ""
+	for _, child := range e.children {
+		if t, ok := child.(text); ok {
+			value += string(t)
+		}
+	}
+	return value
+}
+
+// walk calls f for the element and all its descendants, in document order
+func (e *element) walk(f func(*element) error) error {
+	if err := f(e); err != nil {
+		return err
+	}
+	for _, child := range e.children {
+		if el, ok := child.(*element); ok {
+			if err := el.walk(f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// canonicalize returns the exclusive canonical form without comments (http://www.w3.org/TR/xml-exc-c14n/)
+// of the subtree rooted at the element, leaving out the excluded element (for the enveloped signature
+// transform). The namespaces bound to inclusivePrefixes ("#default" for the default namespace) are rendered
+// like inclusive canonicalization does.
+func canonicalize(e *element, exclude *element, inclusivePrefixes []string) []byte {
+	c := &canonicalizer{exclude: exclude, inclusivePrefixes: map[string]bool{}}
+	for _, prefix := range inclusivePrefixes {
+		if prefix == "#default" {
+			prefix = ""
+		}
+		c.inclusivePrefixes[prefix] = true
+	}
+	c.writeElement(e, map[string]string{})
+	return c.buf.Bytes()
+}
+
+type canonicalizer struct {
+	buf               bytes.Buffer
+	exclude           *element
+	inclusivePrefixes map[string]bool
+}
+
+// writeElement writes an element. rendered holds the namespaces declared by the output ancestors.
+func (c *canonicalizer) writeElement(e *element, rendered map[string]string) {
+	// The namespaces visibly utilized by the element and its attributes are rendered, unless an output
+	// ancestor already declared them with the same value
+	prefixes := map[string]bool{e.prefix: true}
+	for _, attr := range e.attrs {
+		if len(attr.Name.Space) > 0 {
+			prefixes[attr.Name.Space] = true
+		}
+	}
+	for prefix := range c.inclusivePrefixes {
+		if _, ok := e.lookupNamespace(prefix); ok {
+			prefixes[prefix] = true
+		}
+	}
+
+	declarations := []string{}
+	childRendered := rendered
+	for prefix := range prefixes {
+		if prefix == "xml" {
+			continue
+		}
+		namespace, _ := e.lookupNamespace(prefix)
+		if previous, ok := rendered[prefix]; previous == namespace && (ok || len(prefix) == 0) {
+			continue
+		}
+		if len(declarations) == 0 {
+			childRendered = map[string]string{}
+			for k, v := range rendered {
+				childRendered[k] = v
+			}
+		}
+		childRendered[prefix] = namespace
+		declarations = append(declarations, prefix)
+	}
+	// the default namespace declaration sorts first
+	sort.Strings(declarations)
+
+	attrs := make([]xml.Attr, len(e.attrs))
+	copy(attrs, e.attrs)
+	sort.Sort(byNamespaceAndLocal{e, attrs})
+
+	c.buf.WriteString("<")
+	c.buf.WriteString(e.qualifiedName())
+	for _, prefix := range declarations {
+		if len(prefix) == 0 {
+			c.buf.WriteString(` xmlns="`)
+		} else {
+			c.buf.WriteString(` xmlns:` + prefix + `="`)
+		}
+		c.buf.WriteString(escapeAttr(childRendered[prefix]))
+		c.buf.WriteString(`"`)
+	}
+	for _, attr := range attrs {
+		c.buf.WriteString(" ")
+		if len(attr.Name.Space) > 0 {
+			c.buf.WriteString(attr.Name.Space + ":")
+		}
+		c.buf.WriteString(attr.Name.Local + `="` + escapeAttr(attr.Value) + `"`)
+	}
+	c.buf.WriteString(">")
+
+	for _, child := range e.children {
+		switch child := child.(type) {
+		case *element:
+			if child != c.exclude {
+				c.writeElement(child, childRendered)
+			}
+		case text:
+			c.buf.WriteString(escapeText(string(child)))
+		}
+	}
+
+	c.buf.WriteString("</" + e.qualifiedName() + ">")
+}
+
+// byNamespaceAndLocal sorts attributes by namespace URI, then local name. Attributes without a prefix have
+// no namespace, so they sort first.
+type byNamespaceAndLocal struct {
+	e     *element
+	attrs []xml.Attr
+}
+
+func (s byNamespaceAndLocal) Len() int      { return len(s.attrs) }
+func (s byNamespaceAndLocal) Swap(i, j int) { s.attrs[i], s.attrs[j] = s.attrs[j], s.attrs[i] }
+func (s byNamespaceAndLocal) Less(i, j int) bool {
+	nsI, nsJ := s.namespace(s.attrs[i]), s.namespace(s.attrs[j])
+	if nsI != nsJ {
+		return nsI < nsJ
+	}
+	return s.attrs[i].Name.Local < s.attrs[j].Name.Local
+}
+func (s byNamespaceAndLocal) namespace(attr xml.Attr) string {
+	if len(attr.Name.Space) == 0 {
+		return ""
+	}
+	namespace, _ := s.e.lookupNamespace(attr.Name.Space)
+	return namespace
+}
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\r", "&#xD;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", `"`, "&quot;", "\t", "&#x9;", "\n", "&#xA;", "\r", "&#xD;")
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func escapeAttr(s string) string {
+	return attrEscaper.Replace(s)
+}
@@ -0,0 +1,88 @@
+package saml
+
+import (
+	"testing"
+)
+
+func TestCanonicalize(t *testing.T) {
+	testCases := map[string]struct {
+		Document          string
+		ID                string
+		InclusivePrefixes []string
+		Expected          string
+	}{
+		// http://www.w3.org/TR/xml-exc-c14n/#sec-Enveloping
+		"exclusive canonicalization example": {
+			Document: `<n0:local xmlns:n0="foo:bar" xmlns:n3="ftp://example.org"><n1:elem2 ID="elem2" xmlns:n1="http://example.net" xml:lang="en">
+    <n3:stuff xmlns:n3="ftp://example.org"/>
+  </n1:elem2></n0:local>`,
+			ID: "elem2",
+			Expected: `<n1:elem2 xmlns:n1="http://example.net" ID="elem2" xml:lang="en">
+    <n3:stuff xmlns:n3="ftp://example.org"></n3:stuff>
+  </n1:elem2>`,
+		},
+		"sorting and escaping": {
+			Document: `<?xml version="1.0"?>
+<!-- comment -->
+<doc ID="doc" xmlns="http://example.com/default" xmlns:a="http://www.w3.org" xmlns:b="http://www.ietf.org" xmlns:unused="urn:unused"><e5 b:attr="sorted" attr2="all" attr="I'm" a:attr="out" xmlns:b="http://www.ietf.org"/><!-- comment --><e6 xmlns=""><e7 text="a&lt;b&#9;&quot;"> x &amp; y &gt; z<![CDATA[ <&> ]]></e7></e6></doc>`,
+			ID:       "doc",
+			Expected: `<doc xmlns="http://example.com/default" ID="doc"><e5 xmlns:a="http://www.w3.org" xmlns:b="http://www.ietf.org" attr="I'm" attr2="all" b:attr="sorted" a:attr="out"></e5><e6 xmlns=""><e7 text="a&lt;b&#x9;&quot;"> x &amp; y &gt; z &lt;&amp;&gt; </e7></e6></doc>`,
+		},
+		"inclusive prefixes": {
+			Document:          `<doc ID="doc" xmlns="http://example.com/default" xmlns:unused="urn:unused"><e1 xmlns:unused="urn:unused"><unused:e2/></e1></doc>`,
+			ID:                "doc",
+			InclusivePrefixes: []string{"unused"},
+			Expected:          `<doc xmlns="http://example.com/default" xmlns:unused="urn:unused" ID="doc"><e1><unused:e2></unused:e2></e1></doc>`,
+		},
+		"inner element": {
+			Document: `<a:root xmlns:a="urn:a" xmlns="urn:default" xmlns:b="urn:b"><b:inner ID="inner"><leaf b:x="1"/></b:inner></a:root>`,
+			ID:       "inner",
+			Expected: `<b:inner xmlns:b="urn:b" ID="inner"><leaf xmlns="urn:default" b:x="1"></leaf></b:inner>`,
+		},
+	}
+
+	for k, tc := range testCases {
+		root, err := parseDocument([]byte(tc.Document))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", k, err)
+			continue
+		}
+		e := findID(root, tc.ID)
+		if e == nil {
+			t.Errorf("%s: no element with ID %s", k, tc.ID)
+			continue
+		}
+		if canonical := string(canonicalize(e, nil, tc.InclusivePrefixes)); canonical != tc.Expected {
+			t.Errorf("%s: expected\n\t%s\ngot\n\t%s", k, tc.Expected, canonical)
+		}
+	}
+}
+
+func TestParseDocumentErrors(t *testing.T) {
+	testCases := map[string]string{
+		"empty":              ``,
+		"doctype":            `<!DOCTYPE doc [<!ENTITY e "e">]><doc/>`,
+		"undeclared prefix":  `<a:doc/>`,
+		"mismatched end":     `<doc></other>`,
+		"multiple roots":     `<doc/><doc/>`,
+		"text outside root":  `<doc/>text`,
+		"unclosed element":   `<doc>`,
+		"undeclared attr ns": `<doc a:b="c"/>`,
+	}
+	for k, document := range testCases {
+		if _, err := parseDocument([]byte(document)); err == nil {
+			t.Errorf("%s: expected error", k)
+		}
+	}
+}
+
+func findID(root *element, id string) *element {
+	var found *element
+	root.walk(func(e *element) error {
+		if e.attr("ID") == id {
+			found = e
+		}
+		return nil
+	})
+	return found
+}
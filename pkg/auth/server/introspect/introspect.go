@@ -0,0 +1,177 @@
+package introspect
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/RangelReale/osin"
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+
+	"github.com/openshift/origin/pkg/auth/server/login"
+	authorizationscope "github.com/openshift/origin/pkg/authorization/authorizer/scope"
+	oapi "github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthclient"
+	"github.com/openshift/origin/pkg/oauth/scope"
+	"github.com/openshift/origin/pkg/user/registry/user"
+)
+
+const (
+	// IntrospectEndpoint is the path of the token introspection endpoint, relative to the OAuth server prefix
+	IntrospectEndpoint = "/introspect"
+
+	tokenParam        = "token"
+	clientIDParam     = "client_id"
+	clientSecretParam = "client_secret"
+
+	bearerTokenType = "Bearer"
+)
+
+// Response is the response of the introspection endpoint, as described in RFC 7662.  Only Active is set for tokens
+// that are not active.
+type Response struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Expires   int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
+
+// Introspect lets resource servers ask whether an access token is active and what it may be used for.  Callers must
+// authenticate as an OAuth client, so the endpoint can't be used to guess tokens anonymously.
+type Introspect struct {
+	tokens  oauthaccesstoken.Registry
+	clients oauthclient.Registry
+	users   user.Registry
+}
+
+func NewIntrospect(tokens oauthaccesstoken.Registry, clients oauthclient.Registry, users user.Registry) *Introspect {
+	return &Introspect{tokens: tokens, clients: clients, users: users}
+}
+
+// Install registers the introspection endpoint into a mux. It is expected that the
+// provided prefix will serve all operations
+func (i *Introspect) Install(mux login.Mux, paths ...string) {
+	for _, prefix := range paths {
+		mux.Handle(path.Join(prefix, IntrospectEndpoint), i)
+	}
+}
+
+func (i *Introspect) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := req.ParseForm(); err != nil {
+		http.Error(w, "Unable to parse the request", http.StatusBadRequest)
+		return
+	}
+
+	if !i.authenticateClient(req) {
+		w.Header().Set("WWW-Authenticate", `Basic realm="openshift"`)
+		http.Error(w, "Client authentication is required", http.StatusUnauthorized)
+		return
+	}
+
+	value := req.PostForm.Get(tokenParam)
+	if len(value) == 0 {
+		http.Error(w, "The token parameter is required", http.StatusBadRequest)
+		return
+	}
+
+	response, err := i.introspect(value)
+	if err != nil {
+		glog.Errorf("Unable to introspect token: %v", err)
+		http.Error(w, "Unable to introspect the token", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		glog.Errorf("Unable to write the introspection response: %v", err)
+	}
+}
+
+// authenticateClient returns true if the request carries the credentials of an OAuth client, either with basic
+// authentication or in the form.
+func (i *Introspect) authenticateClient(req *http.Request) bool {
+	clientID, secret := req.PostForm.Get(clientIDParam), req.PostForm.Get(clientSecretParam)
+	if basicAuth, err := osin.CheckBasicAuth(req); err != nil {
+		return false
+	} else if basicAuth != nil {
+		clientID, secret = basicAuth.Username, basicAuth.Password
+	}
+	if len(clientID) == 0 || len(secret) == 0 {
+		return false
+	}
+
+	client, err := i.clients.GetClient(kapi.NewContext(), clientID)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			glog.Errorf("Unable to get OAuth client %q: %v", clientID, err)
+		}
+		return false
+	}
+	return client.Name == clientID && subtle.ConstantTimeCompare([]byte(client.Secret), []byte(secret)) == 1
+}
+
+// introspect describes the named token.  Tokens that do not exist, have expired or belong to a user that no longer
+// exists are inactive.
+func (i *Introspect) introspect(value string) (*Response, error) {
+	inactive := &Response{Active: false}
+	ctx := kapi.NewContext()
+
+	token, err := i.tokens.GetAccessToken(ctx, value)
+	if kerrors.IsNotFound(err) {
+		return inactive, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	expires := token.CreationTimestamp.Add(time.Duration(token.ExpiresIn) * time.Second)
+	if expires.Before(time.Now()) {
+		return inactive, nil
+	}
+
+	u, err := i.users.GetUser(ctx, token.UserName)
+	if kerrors.IsNotFound(err) {
+		return inactive, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if string(u.UID) != token.UserUID {
+		return inactive, nil
+	}
+
+	return &Response{
+		Active:    true,
+		Scope:     tokenScope(token),
+		ClientID:  token.ClientName,
+		Username:  token.UserName,
+		Subject:   token.UserUID,
+		TokenType: bearerTokenType,
+		Expires:   expires.Unix(),
+		IssuedAt:  token.CreationTimestamp.Unix(),
+	}, nil
+}
+
+// tokenScope returns the scopes of the token.  Tokens without scopes act with the full permissions of their user.
+func tokenScope(token *oapi.OAuthAccessToken) string {
+	if len(token.Scopes) == 0 {
+		return authorizationscope.UserFull
+	}
+	return scope.Join(token.Scopes)
+}
@@ -0,0 +1,185 @@
+package introspect
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+
+	oapi "github.com/openshift/origin/pkg/oauth/api"
+	oauthtest "github.com/openshift/origin/pkg/oauth/registry/test"
+	userapi "github.com/openshift/origin/pkg/user/api"
+	usertest "github.com/openshift/origin/pkg/user/registry/test"
+)
+
+func TestIntrospect(t *testing.T) {
+	created := time.Now().Add(-time.Minute).Truncate(time.Second)
+	validToken := &oapi.OAuthAccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: "token", CreationTimestamp: unversioned.Time{Time: created}},
+		ClientName: "myclient",
+		ExpiresIn:  600,
+		UserName:   "bob",
+		UserUID:    "1",
+		Scopes:     []string{"user:info", "user:check-access"},
+	}
+	expiredToken := *validToken
+	expiredToken.ExpiresIn = 10
+	unscopedToken := *validToken
+	unscopedToken.Scopes = nil
+	otherUserToken := *validToken
+	otherUserToken.UserUID = "0"
+
+	inactive := &Response{Active: false}
+
+	testCases := map[string]struct {
+		method   string
+		form     url.Values
+		username string
+		password string
+		token    *oapi.OAuthAccessToken
+		tokenErr error
+
+		expectedCode     int
+		expectedResponse *Response
+	}{
+		"active token": {
+			form:         url.Values{"token": {"token"}},
+			username:     "resourceserver",
+			password:     "secret",
+			token:        validToken,
+			expectedCode: http.StatusOK,
+			expectedResponse: &Response{
+				Active:    true,
+				Scope:     "user:info user:check-access",
+				ClientID:  "myclient",
+				Username:  "bob",
+				Subject:   "1",
+				TokenType: "Bearer",
+				Expires:   created.Add(600 * time.Second).Unix(),
+				IssuedAt:  created.Unix(),
+			},
+		},
+		"client credentials in the form": {
+			form:         url.Values{"token": {"token"}, "client_id": {"resourceserver"}, "client_secret": {"secret"}},
+			token:        &unscopedToken,
+			expectedCode: http.StatusOK,
+			expectedResponse: &Response{
+				Active:    true,
+				Scope:     "user:full",
+				ClientID:  "myclient",
+				Username:  "bob",
+				Subject:   "1",
+				TokenType: "Bearer",
+				Expires:   created.Add(600 * time.Second).Unix(),
+				IssuedAt:  created.Unix(),
+			},
+		},
+		"expired token": {
+			form:             url.Values{"token": {"token"}},
+			username:         "resourceserver",
+			password:         "secret",
+			token:            &expiredToken,
+			expectedCode:     http.StatusOK,
+			expectedResponse: inactive,
+		},
+		"token of a previous user": {
+			form:             url.Values{"token": {"token"}},
+			username:         "resourceserver",
+			password:         "secret",
+			token:            &otherUserToken,
+			expectedCode:     http.StatusOK,
+			expectedResponse: inactive,
+		},
+		"missing token": {
+			form:             url.Values{"token": {"token"}},
+			username:         "resourceserver",
+			password:         "secret",
+			tokenErr:         kerrors.NewNotFound(oapi.Resource("oauthaccesstokens"), "token"),
+			expectedCode:     http.StatusOK,
+			expectedResponse: inactive,
+		},
+		"storage error": {
+			form:         url.Values{"token": {"token"}},
+			username:     "resourceserver",
+			password:     "secret",
+			tokenErr:     errors.New("etcd is down"),
+			expectedCode: http.StatusInternalServerError,
+		},
+		"no token parameter": {
+			form:         url.Values{},
+			username:     "resourceserver",
+			password:     "secret",
+			expectedCode: http.StatusBadRequest,
+		},
+		"no client credentials": {
+			form:         url.Values{"token": {"token"}},
+			token:        validToken,
+			expectedCode: http.StatusUnauthorized,
+		},
+		"wrong client secret": {
+			form:         url.Values{"token": {"token"}},
+			username:     "resourceserver",
+			password:     "wrong",
+			token:        validToken,
+			expectedCode: http.StatusUnauthorized,
+		},
+		"GET": {
+			method:       "GET",
+			username:     "resourceserver",
+			password:     "secret",
+			token:        validToken,
+			expectedCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for name, testCase := range testCases {
+		tokens := &oauthtest.AccessTokenRegistry{AccessToken: testCase.token, Err: testCase.tokenErr}
+		clients := &oauthtest.ClientRegistry{Client: &oapi.OAuthClient{ObjectMeta: kapi.ObjectMeta{Name: "resourceserver"}, Secret: "secret"}}
+		users := usertest.NewUserRegistry()
+		users.Get["bob"] = &userapi.User{ObjectMeta: kapi.ObjectMeta{Name: "bob", UID: "1"}}
+
+		server := httptest.NewServer(NewIntrospect(tokens, clients, users))
+
+		method := testCase.method
+		if len(method) == 0 {
+			method = "POST"
+		}
+		req, err := http.NewRequest(method, server.URL, strings.NewReader(testCase.form.Encode()))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", name, err)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		if len(testCase.username) > 0 {
+			req.SetBasicAuth(testCase.username, testCase.password)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			server.Close()
+			continue
+		}
+
+		if resp.StatusCode != testCase.expectedCode {
+			t.Errorf("%s: expected status %d, got %d", name, testCase.expectedCode, resp.StatusCode)
+		}
+		if testCase.expectedResponse != nil {
+			response := &Response{}
+			if err := json.NewDecoder(resp.Body).Decode(response); err != nil {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			} else if !reflect.DeepEqual(response, testCase.expectedResponse) {
+				t.Errorf("%s: expected %#v, got %#v", name, testCase.expectedResponse, response)
+			}
+		}
+		resp.Body.Close()
+		server.Close()
+	}
+}
@@ -30,6 +30,9 @@ const (
 	errorCodeUserRequired = "user_required"
 	errorCodeTokenExpired = "token_expired"
 	errorCodeAccessDenied = "access_denied"
+
+	// ErrorCodeSecondFactorFailed is used by the second factor step to send users back to the login page
+	ErrorCodeSecondFactorFailed = "second_factor_failed"
 )
 
 // Error messages that correlate to the error codes above.
@@ -38,6 +41,8 @@ var errorMessages = map[string]string{
 	errorCodeUserRequired: "Login is required. Please try again.",
 	errorCodeTokenExpired: "Could not check CSRF token. Please try again.",
 	errorCodeAccessDenied: "Invalid login or password. Please try again.",
+
+	ErrorCodeSecondFactorFailed: "Your login expired or too many verification codes were invalid. Please log in again.",
 }
 
 type PasswordAuthenticator interface {
@@ -167,7 +172,10 @@ func (l *Login) handleLogin(w http.ResponseWriter, req *http.Request) {
 		failed(errorCodeAccessDenied, w, req)
 		return
 	}
-	l.auth.AuthenticationSucceeded(context, then, w, req)
+	if _, err := l.auth.AuthenticationSucceeded(context, then, w, req); err != nil {
+		glog.Errorf("Unable to complete the login: %v", err)
+		failed(errorpage.AuthenticationErrorCode(err), w, req)
+	}
 }
 
 // NewLoginFormRenderer creates a login form renderer that takes in an optional custom template to
@@ -0,0 +1,85 @@
+package totplogin
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+
+	kutil "k8s.io/kubernetes/pkg/util"
+)
+
+// DefaultFormRenderer displays a page prompting the user for a verification code, or to enroll an
+// authenticator app.
+var DefaultFormRenderer = totpTemplateRenderer{}
+
+type totpTemplateRenderer struct{}
+
+func (r totpTemplateRenderer) Render(form Form, w http.ResponseWriter, req *http.Request) {
+	w.Header().Add("Content-Type", "text/html")
+	// the page may contain the secret or the recovery codes of the user
+	w.Header().Add("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := totpTemplate.Execute(w, form); err != nil {
+		kutil.HandleError(fmt.Errorf("unable to render TOTP template: %v", err))
+	}
+}
+
+// TODO: allow template to be read from an external file
+var totpTemplate = template.Must(template.New("totpForm").Parse(`<!DOCTYPE html>
+<html>
+  <head>
+    <title>Verification</title>
+    <style type="text/css">
+      body   { font-family: "Open Sans", Helvetica, Arial, sans-serif; font-size: 14px; margin: 15px; }
+      input  { margin-bottom: 10px; width: 300px; }
+      code   { font-size: 16px; }
+      .error { color: red; margin-bottom: 10px; }
+    </style>
+  </head>
+  <body>
+
+    {{ if .Error }}
+      <div class="error">{{ .Error }}</div>
+      <!-- Error code: {{ .ErrorCode }} -->
+    {{ end }}
+
+    {{ if .RecoveryCodes }}
+      <h3>Save your recovery codes</h3>
+      <p>If you lose your device, each of these codes can be used once instead of a verification code.
+      They will not be shown again.</p>
+      <pre>{{ range .RecoveryCodes }}{{ . }}
+{{ end }}</pre>
+      <a href="{{ .Values.Then }}">Continue</a>
+    {{ else }}
+    <form action="{{ .Action }}" method="POST">
+      <input type="hidden" name="{{ .Names.Then }}" value="{{ .Values.Then }}">
+      <input type="hidden" name="{{ .Names.CSRF }}" value="{{ .Values.CSRF }}">
+
+      {{ if .Enroll }}
+        <h3>Set up an authenticator app for {{ .UserName }}</h3>
+        <p>Add an account to your authenticator app with this key:</p>
+        <p><code>{{ .Secret }}</code></p>
+        <p>Or open <a href="{{ .KeyURI }}">this link</a> on the device running the app.</p>
+        <div>
+          <label for="inputCode">Enter the code generated by the app</label>
+        </div>
+      {{ else }}
+        <h3>Verification required for {{ .UserName }}</h3>
+        <div>
+          <label for="inputCode">Enter the code generated by your authenticator app, or one of your recovery codes</label>
+        </div>
+      {{ end }}
+      <div>
+        <input type="text" id="inputCode" autofocus="autofocus" autocomplete="off" name="{{ .Names.Code }}" value="">
+      </div>
+
+      <button type="submit">Verify</button>
+      {{ if and .Enroll (not .Required) }}
+        <button type="submit" name="{{ .Names.Skip }}" value="true">Skip for now</button>
+      {{ end }}
+    </form>
+    {{ end }}
+
+  </body>
+</html>
+`))
@@ -0,0 +1,399 @@
+package totplogin
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	"k8s.io/kubernetes/pkg/auth/user"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/auth/oauth/handlers"
+	"github.com/openshift/origin/pkg/auth/server/csrf"
+	"github.com/openshift/origin/pkg/auth/server/login"
+	"github.com/openshift/origin/pkg/auth/server/session"
+	"github.com/openshift/origin/pkg/auth/totp"
+)
+
+const (
+	thenParam   = "then"
+	csrfParam   = "csrf"
+	codeParam   = "code"
+	skipParam   = "skip"
+	reasonParam = "reason"
+
+	// these error codes are specific to the second factor step
+	errorCodeInvalidCode  = "invalid_code"
+	errorCodeTokenExpired = "token_expired"
+	errorCodeLocked       = "locked"
+
+	pendingUserNameKey = "user.name"
+	pendingUserUIDKey  = "user.uid"
+	pendingExpiresKey  = "expires"
+	pendingFailuresKey = "failures"
+
+	// pendingTimeout is the time users have to give a verification code after giving their password
+	pendingTimeout = 5 * time.Minute
+	// maxFailures is the number of invalid codes after which users have to give their password again
+	maxFailures = 5
+)
+
+var errorMessages = map[string]string{
+	errorCodeInvalidCode:  "The verification code is invalid. Please try again.",
+	errorCodeTokenExpired: "Could not check CSRF token. Please try again.",
+	errorCodeLocked:       "Too many invalid verification codes were given. Please try again in a few minutes.",
+}
+
+// FormRenderer is responsible for rendering a Form to prompt the user for a verification code, or to
+// enroll an authenticator app.
+type FormRenderer interface {
+	Render(form Form, w http.ResponseWriter, req *http.Request)
+}
+
+type Form struct {
+	Action string
+
+	Error     string
+	ErrorCode string
+
+	UserName string
+
+	// Enroll is true if the user must add an account to an authenticator app first
+	Enroll bool
+	// Required is false if the user may skip enrolling
+	Required bool
+	Secret   string
+	KeyURI   template.URL
+
+	// RecoveryCodes are shown once the enrollment is confirmed
+	RecoveryCodes []string
+
+	Names  FormFields
+	Values FormFields
+}
+
+type FormFields struct {
+	Then string
+	CSRF string
+	Code string
+	Skip string
+}
+
+// TOTPLogin asks users that gave a valid password for a verification code, before they are remembered
+// as logged in. Users that have not enrolled an authenticator app are asked to enroll one. The user
+// waiting for the second factor is kept in a separate short lived session.
+type TOTPLogin struct {
+	path        string
+	loginPath   string
+	csrf        csrf.CSRF
+	verifier    *totp.Verifier
+	required    bool
+	store       session.Store
+	sessionName string
+	success     handlers.AuthenticationSuccessHandler
+	render      FormRenderer
+	clock       kutil.Clock
+}
+
+// NewTOTPLogin returns the second factor step served at path. Users are sent back to loginPath when
+// the step expires. The success handler is called once the second factor was given, before users are
+// redirected to the "then" parameter.
+func NewTOTPLogin(path, loginPath string, csrf csrf.CSRF, verifier *totp.Verifier, required bool, store session.Store, sessionName string, success handlers.AuthenticationSuccessHandler, render FormRenderer, clock kutil.Clock) *TOTPLogin {
+	return &TOTPLogin{
+		path:        path,
+		loginPath:   loginPath,
+		csrf:        csrf,
+		verifier:    verifier,
+		required:    required,
+		store:       store,
+		sessionName: sessionName,
+		success:     success,
+		render:      render,
+		clock:       clock,
+	}
+}
+
+// Install registers the second factor handler into a mux.
+func (l *TOTPLogin) Install(mux login.Mux) {
+	mux.HandleFunc(l.path, l.ServeHTTP)
+}
+
+// AuthenticationSucceeded is called once the password of the user was checked. It remembers the user
+// until the second factor is given, and redirects to the second factor step.
+func (l *TOTPLogin) AuthenticationSucceeded(user user.Info, then string, w http.ResponseWriter, req *http.Request) (bool, error) {
+	if err := l.setPending(w, req, user, l.clock.Now().Add(pendingTimeout), 0); err != nil {
+		return false, err
+	}
+	l.redirectToForm(then, "", w, req)
+	return true, nil
+}
+
+func (l *TOTPLogin) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	user, failures, ok := l.pending(req)
+	if !ok {
+		l.restart(w, req)
+		return
+	}
+
+	switch req.Method {
+	case "GET":
+		l.handleForm(user, w, req)
+	case "POST":
+		l.handleCode(user, failures, w, req)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (l *TOTPLogin) handleForm(user user.Info, w http.ResponseWriter, req *http.Request) {
+	form := l.newForm(user, req.URL.Query().Get(thenParam), w, req)
+	form.ErrorCode = req.URL.Query().Get(reasonParam)
+	if len(form.ErrorCode) > 0 {
+		form.Error = errorMessages[form.ErrorCode]
+	}
+
+	enrolled, err := l.verifier.Enrolled(user)
+	if err != nil {
+		l.internalError("Unable to check the enrollment", err, w)
+		return
+	}
+	if !enrolled {
+		enrollment, err := l.verifier.StartEnrollment(user)
+		if err != nil {
+			l.internalError("Unable to start the enrollment", err, w)
+			return
+		}
+		form.Enroll = true
+		form.Secret = groupSecret(enrollment.Secret)
+		form.KeyURI = template.URL(l.verifier.KeyURI(user, enrollment.Secret))
+	}
+
+	l.render.Render(form, w, req)
+}
+
+func (l *TOTPLogin) handleCode(user user.Info, failures int, w http.ResponseWriter, req *http.Request) {
+	then := req.FormValue(thenParam)
+	if ok, err := l.csrf.Check(req, req.FormValue(csrfParam)); !ok || err != nil {
+		glog.Errorf("Unable to check CSRF token: %v", err)
+		l.redirectToForm(then, errorCodeTokenExpired, w, req)
+		return
+	}
+	code := req.FormValue(codeParam)
+
+	enrolled, err := l.verifier.Enrolled(user)
+	if err != nil {
+		l.internalError("Unable to check the enrollment", err, w)
+		return
+	}
+
+	if !enrolled {
+		if !l.required && len(req.FormValue(skipParam)) > 0 {
+			l.complete(user, then, w, req)
+			return
+		}
+
+		recoveryCodes, ok, err := l.verifier.ConfirmEnrollment(user, code)
+		if err == totp.ErrLocked {
+			glog.V(4).Infof("Enrollment of %s is locked", user.GetName())
+			l.redirectToForm(then, errorCodeLocked, w, req)
+			return
+		}
+		if err != nil {
+			l.internalError("Unable to confirm the enrollment", err, w)
+			return
+		}
+		if !ok {
+			l.invalidCode(user, failures, then, w, req)
+			return
+		}
+
+		// show the recovery codes this one time, with a link to continue
+		form := l.newForm(user, then, w, req)
+		if !l.login(user, then, w, req) {
+			return
+		}
+		form.RecoveryCodes = recoveryCodes
+		l.render.Render(form, w, req)
+		return
+	}
+
+	valid, err := l.verifier.Verify(user, code)
+	if err == totp.ErrLocked {
+		glog.V(4).Infof("Verification codes of %s are locked", user.GetName())
+		l.redirectToForm(then, errorCodeLocked, w, req)
+		return
+	}
+	if err != nil {
+		l.internalError("Unable to check the verification code", err, w)
+		return
+	}
+	if !valid {
+		l.invalidCode(user, failures, then, w, req)
+		return
+	}
+	l.complete(user, then, w, req)
+}
+
+// complete logs the user in and redirects to then
+func (l *TOTPLogin) complete(user user.Info, then string, w http.ResponseWriter, req *http.Request) {
+	if len(then) == 0 {
+		l.internalError("Login succeeded, but no redirect existed", fmt.Errorf("no then parameter for %s", user.GetName()), w)
+		return
+	}
+	if !l.login(user, then, w, req) {
+		return
+	}
+	http.Redirect(w, req, then, http.StatusFound)
+}
+
+// login forgets the pending user and calls the success handler. It returns false if the response was written.
+func (l *TOTPLogin) login(user user.Info, then string, w http.ResponseWriter, req *http.Request) bool {
+	if err := l.clearPending(w, req); err != nil {
+		l.internalError("Unable to complete the login", err, w)
+		return false
+	}
+	handled, err := l.success.AuthenticationSucceeded(user, then, w, req)
+	if err != nil {
+		l.internalError("Unable to complete the login", err, w)
+		return false
+	}
+	return !handled
+}
+
+// invalidCode lets the user try again, until too many codes were invalid
+func (l *TOTPLogin) invalidCode(user user.Info, failures int, then string, w http.ResponseWriter, req *http.Request) {
+	failures++
+	if failures >= maxFailures {
+		glog.V(4).Infof("Too many invalid verification codes for %s", user.GetName())
+		if err := l.clearPending(w, req); err != nil {
+			glog.Errorf("Unable to clear the pending login: %v", err)
+		}
+		l.restart(w, req)
+		return
+	}
+
+	_, _, expires, _ := l.pendingValues(req)
+	if err := l.setPending(w, req, user, expires, failures); err != nil {
+		l.internalError("Unable to save the login", err, w)
+		return
+	}
+	l.redirectToForm(then, errorCodeInvalidCode, w, req)
+}
+
+func (l *TOTPLogin) newForm(user user.Info, then string, w http.ResponseWriter, req *http.Request) Form {
+	csrf, err := l.csrf.Generate(w, req)
+	if err != nil {
+		kutil.HandleError(fmt.Errorf("unable to generate CSRF token: %v", err))
+	}
+
+	form := Form{
+		Action:   l.path,
+		UserName: user.GetName(),
+		Required: l.required,
+		Names: FormFields{
+			Then: thenParam,
+			CSRF: csrfParam,
+			Code: codeParam,
+			Skip: skipParam,
+		},
+		Values: FormFields{
+			Then: then,
+			CSRF: csrf,
+		},
+	}
+	return form
+}
+
+// restart sends the user back to the login page
+func (l *TOTPLogin) restart(w http.ResponseWriter, req *http.Request) {
+	query := url.Values{}
+	query.Set(reasonParam, login.ErrorCodeSecondFactorFailed)
+	if then := req.FormValue(thenParam); len(then) > 0 {
+		query.Set(thenParam, then)
+	}
+	http.Redirect(w, req, l.loginPath+"?"+query.Encode(), http.StatusFound)
+}
+
+func (l *TOTPLogin) redirectToForm(then, reason string, w http.ResponseWriter, req *http.Request) {
+	query := url.Values{}
+	if len(then) > 0 {
+		query.Set(thenParam, then)
+	}
+	if len(reason) > 0 {
+		query.Set(reasonParam, reason)
+	}
+	uri := l.path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	http.Redirect(w, req, uri, http.StatusFound)
+}
+
+func (l *TOTPLogin) internalError(message string, err error, w http.ResponseWriter) {
+	glog.Errorf("%s: %v", message, err)
+	http.Error(w, message, http.StatusInternalServerError)
+}
+
+// pending returns the user waiting for the second factor, and the number of invalid codes they gave
+func (l *TOTPLogin) pending(req *http.Request) (user.Info, int, bool) {
+	name, uid, expires, failures := l.pendingValues(req)
+	if len(name) == 0 || l.clock.Now().After(expires) {
+		return nil, 0, false
+	}
+	return &user.DefaultInfo{Name: name, UID: uid}, failures, true
+}
+
+func (l *TOTPLogin) pendingValues(req *http.Request) (string, string, time.Time, int) {
+	session, err := l.store.Get(req, l.sessionName)
+	if err != nil {
+		glog.V(4).Infof("Unable to get the pending login: %v", err)
+		return "", "", time.Time{}, 0
+	}
+	values := session.Values()
+	name, _ := values[pendingUserNameKey].(string)
+	uid, _ := values[pendingUserUIDKey].(string)
+	expires, _ := values[pendingExpiresKey].(int64)
+	failures, _ := values[pendingFailuresKey].(int)
+	return name, uid, time.Unix(expires, 0), failures
+}
+
+func (l *TOTPLogin) setPending(w http.ResponseWriter, req *http.Request, user user.Info, expires time.Time, failures int) error {
+	session, err := l.store.Get(req, l.sessionName)
+	if err != nil {
+		return err
+	}
+	values := session.Values()
+	values[pendingUserNameKey] = user.GetName()
+	values[pendingUserUIDKey] = user.GetUID()
+	values[pendingExpiresKey] = expires.Unix()
+	values[pendingFailuresKey] = failures
+	return l.store.Save(w, req)
+}
+
+func (l *TOTPLogin) clearPending(w http.ResponseWriter, req *http.Request) error {
+	session, err := l.store.Get(req, l.sessionName)
+	if err != nil {
+		return err
+	}
+	values := session.Values()
+	for _, key := range []string{pendingUserNameKey, pendingUserUIDKey, pendingExpiresKey, pendingFailuresKey} {
+		delete(values, key)
+	}
+	return l.store.Save(w, req)
+}
+
+// groupSecret splits the secret in groups of four characters, to make it easier to type
+func groupSecret(secret string) string {
+	secret = strings.TrimRight(secret, "=")
+	groups := []string{}
+	for len(secret) > 4 {
+		groups = append(groups, secret[:4])
+		secret = secret[4:]
+	}
+	return strings.Join(append(groups, secret), " ")
+}
@@ -0,0 +1,269 @@
+package totplogin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/auth/server/csrf"
+	"github.com/openshift/origin/pkg/auth/server/login"
+	"github.com/openshift/origin/pkg/auth/server/session"
+	"github.com/openshift/origin/pkg/auth/totp"
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/test"
+)
+
+const secret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+type testSuccessHandler struct {
+	User user.Info
+}
+
+func (h *testSuccessHandler) AuthenticationSucceeded(user user.Info, then string, w http.ResponseWriter, req *http.Request) (bool, error) {
+	h.User = user
+	return false, nil
+}
+
+// browser sends requests to the handler, keeping the cookies it was given
+type browser struct {
+	t       *testing.T
+	handler http.Handler
+	cookies map[string]*http.Cookie
+}
+
+func (b *browser) do(method, path string, form url.Values, handler http.Handler) *httptest.ResponseRecorder {
+	var req *http.Request
+	if method == "POST" {
+		req, _ = http.NewRequest(method, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		req, _ = http.NewRequest(method, path, nil)
+	}
+	for _, cookie := range b.cookies {
+		req.AddCookie(cookie)
+	}
+	if handler == nil {
+		handler = b.handler
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	for _, cookie := range (&http.Response{Header: w.Header()}).Cookies() {
+		b.cookies[cookie.Name] = cookie
+	}
+	return w
+}
+
+func expectRedirect(t *testing.T, name string, w *httptest.ResponseRecorder, expected string) {
+	if w.Code != http.StatusFound {
+		t.Errorf("%s: expected a redirect, got %d: %s", name, w.Code, w.Body.String())
+		return
+	}
+	if location := w.Header().Get("Location"); location != expected {
+		t.Errorf("%s: expected a redirect to %s, got %s", name, expected, location)
+	}
+}
+
+func expectBody(t *testing.T, name string, w *httptest.ResponseRecorder, expected ...string) {
+	if w.Code != http.StatusOK {
+		t.Errorf("%s: expected 200, got %d", name, w.Code)
+	}
+	for _, s := range expected {
+		if !strings.Contains(w.Body.String(), s) {
+			t.Errorf("%s: expected the page to contain %q, got %s", name, s, w.Body.String())
+		}
+	}
+}
+
+type fixture struct {
+	browser  *browser
+	login    *TOTPLogin
+	registry *test.TOTPEnrollmentRegistry
+	success  *testSuccessHandler
+	clock    *kutil.FakeClock
+}
+
+func newFixture(t *testing.T, enrollment *api.OAuthTOTPEnrollment, required bool) *fixture {
+	f := &fixture{
+		registry: &test.TOTPEnrollmentRegistry{Enrollment: enrollment},
+		success:  &testSuccessHandler{},
+		clock:    &kutil.FakeClock{Time: time.Unix(1450000000, 0)},
+	}
+	store := session.NewStore(false, 0, "secret")
+	verifier := totp.NewVerifier(f.registry, "OpenShift", f.clock)
+	f.login = NewTOTPLogin("/login/totp", "/login", &csrf.FakeCSRF{Token: "test"}, verifier, required, store, "ssn-totp", f.success, DefaultFormRenderer, f.clock)
+	f.browser = &browser{t: t, handler: store.Wrap(f.login), cookies: map[string]*http.Cookie{}}
+
+	// the password was checked
+	passwordChecked := store.Wrap(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if _, err := f.login.AuthenticationSucceeded(&user.DefaultInfo{Name: "bob", UID: "1"}, "/authorize", w, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}))
+	w := f.browser.do("POST", "/login", url.Values{}, passwordChecked)
+	expectRedirect(t, "password checked", w, "/login/totp?then=%2Fauthorize")
+	return f
+}
+
+func (f *fixture) code(t *testing.T) string {
+	code, err := totp.GenerateCode(f.registry.Enrollment.Secret, totp.TimeStep(f.clock.Now()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return code
+}
+
+func submit(code string) url.Values {
+	return url.Values{"csrf": {"test"}, "then": {"/authorize"}, "code": {code}}
+}
+
+func enrolledBob() *api.OAuthTOTPEnrollment {
+	return &api.OAuthTOTPEnrollment{
+		ObjectMeta: kapi.ObjectMeta{Name: "bob"},
+		UserName:   "bob",
+		UserUID:    "1",
+		Secret:     secret,
+		Confirmed:  true,
+	}
+}
+
+func TestVerify(t *testing.T) {
+	f := newFixture(t, enrolledBob(), false)
+
+	w := f.browser.do("GET", "/login/totp?then=%2Fauthorize", nil, nil)
+	expectBody(t, "form", w, "Verification required for bob", `name="csrf" value="test"`, `name="then" value="/authorize"`)
+
+	w = f.browser.do("POST", "/login/totp", url.Values{"csrf": {"wrong"}, "then": {"/authorize"}, "code": {f.code(t)}}, nil)
+	expectRedirect(t, "invalid CSRF", w, "/login/totp?reason=token_expired&then=%2Fauthorize")
+
+	w = f.browser.do("POST", "/login/totp", submit("000000"), nil)
+	expectRedirect(t, "invalid code", w, "/login/totp?reason=invalid_code&then=%2Fauthorize")
+	if f.success.User != nil {
+		t.Fatalf("expected the user not to be logged in")
+	}
+
+	w = f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectRedirect(t, "valid code", w, "/authorize")
+	if f.success.User == nil || f.success.User.GetName() != "bob" || f.success.User.GetUID() != "1" {
+		t.Fatalf("expected bob to be logged in, got %#v", f.success.User)
+	}
+
+	w = f.browser.do("GET", "/login/totp?then=%2Fauthorize", nil, nil)
+	expectRedirect(t, "after login", w, "/login?reason="+login.ErrorCodeSecondFactorFailed+"&then=%2Fauthorize")
+}
+
+func TestTooManyInvalidCodes(t *testing.T) {
+	f := newFixture(t, enrolledBob(), false)
+
+	for i := 1; i < maxFailures; i++ {
+		w := f.browser.do("POST", "/login/totp", submit("000000"), nil)
+		expectRedirect(t, "invalid code", w, "/login/totp?reason=invalid_code&then=%2Fauthorize")
+	}
+	w := f.browser.do("POST", "/login/totp", submit("000000"), nil)
+	expectRedirect(t, "last invalid code", w, "/login?reason="+login.ErrorCodeSecondFactorFailed+"&then=%2Fauthorize")
+
+	w = f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectRedirect(t, "valid code after too many invalid codes", w, "/login?reason="+login.ErrorCodeSecondFactorFailed+"&then=%2Fauthorize")
+	if f.success.User != nil {
+		t.Errorf("expected the user not to be logged in")
+	}
+	if f.registry.Enrollment.LockedUntil == nil {
+		t.Errorf("expected the enrollment to be locked")
+	}
+}
+
+func TestLockedEnrollment(t *testing.T) {
+	enrollment := enrolledBob()
+	lockedUntil := unversioned.NewTime(time.Unix(1450000000, 0).Add(time.Minute))
+	enrollment.LockedUntil = &lockedUntil
+	f := newFixture(t, enrollment, false)
+
+	w := f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectRedirect(t, "locked", w, "/login/totp?reason=locked&then=%2Fauthorize")
+	if f.success.User != nil {
+		t.Fatalf("expected the user not to be logged in")
+	}
+	w = f.browser.do("GET", "/login/totp?reason=locked&then=%2Fauthorize", nil, nil)
+	expectBody(t, "locked form", w, "Too many invalid verification codes")
+
+	f.clock.Step(time.Minute)
+	w = f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectRedirect(t, "lockout expired", w, "/authorize")
+}
+
+func TestLockedEnrollmentConfirmation(t *testing.T) {
+	enrollment := enrolledBob()
+	enrollment.Confirmed = false
+	lockedUntil := unversioned.NewTime(time.Unix(1450000000, 0).Add(time.Minute))
+	enrollment.LockedUntil = &lockedUntil
+	f := newFixture(t, enrollment, true)
+
+	w := f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectRedirect(t, "locked", w, "/login/totp?reason=locked&then=%2Fauthorize")
+	if f.success.User != nil || f.registry.Enrollment.Confirmed {
+		t.Fatalf("expected the enrollment not to be confirmed")
+	}
+
+	f.clock.Step(time.Minute)
+	w = f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectBody(t, "lockout expired", w, "Save your recovery codes")
+}
+
+func TestPendingLoginExpires(t *testing.T) {
+	f := newFixture(t, enrolledBob(), false)
+
+	f.clock.Step(pendingTimeout + time.Second)
+	w := f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectRedirect(t, "expired", w, "/login?reason="+login.ErrorCodeSecondFactorFailed+"&then=%2Fauthorize")
+	if f.success.User != nil {
+		t.Errorf("expected the user not to be logged in")
+	}
+}
+
+func TestEnroll(t *testing.T) {
+	f := newFixture(t, nil, true)
+
+	w := f.browser.do("GET", "/login/totp?then=%2Fauthorize", nil, nil)
+	if f.registry.Enrollment == nil {
+		t.Fatalf("expected an enrollment to be started")
+	}
+	expectBody(t, "enrollment form", w, "Set up an authenticator app for bob", groupSecret(f.registry.Enrollment.Secret), "otpauth://totp/OpenShift:bob?")
+	if strings.Contains(w.Body.String(), "Skip") {
+		t.Errorf("expected a required enrollment not to be skippable")
+	}
+
+	w = f.browser.do("POST", "/login/totp", url.Values{"csrf": {"test"}, "then": {"/authorize"}, "skip": {"true"}}, nil)
+	expectRedirect(t, "skip", w, "/login/totp?reason=invalid_code&then=%2Fauthorize")
+
+	w = f.browser.do("POST", "/login/totp", submit(f.code(t)), nil)
+	expectBody(t, "confirmed", w, "Save your recovery codes", `href="/authorize"`)
+	if !f.registry.Enrollment.Confirmed || len(f.registry.Enrollment.RecoveryCodeHashes) != totp.RecoveryCodeCount {
+		t.Errorf("expected the enrollment to be confirmed, got %#v", f.registry.Enrollment)
+	}
+	if f.success.User == nil || f.success.User.GetName() != "bob" {
+		t.Errorf("expected bob to be logged in, got %#v", f.success.User)
+	}
+}
+
+func TestSkipEnrollment(t *testing.T) {
+	f := newFixture(t, nil, false)
+
+	w := f.browser.do("GET", "/login/totp?then=%2Fauthorize", nil, nil)
+	expectBody(t, "enrollment form", w, "Set up an authenticator app for bob", "Skip for now")
+
+	w = f.browser.do("POST", "/login/totp", url.Values{"csrf": {"test"}, "then": {"/authorize"}, "skip": {"true"}}, nil)
+	expectRedirect(t, "skip", w, "/authorize")
+	if f.success.User == nil || f.success.User.GetName() != "bob" {
+		t.Errorf("expected bob to be logged in, got %#v", f.success.User)
+	}
+	if f.registry.Enrollment.Confirmed {
+		t.Errorf("expected the enrollment not to be confirmed")
+	}
+}
@@ -0,0 +1,142 @@
+// Package totp implements time-based one-time passwords (RFC 6238) as a second authentication factor.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Digits is the length of the generated codes
+	Digits = 6
+	// Period is the time a code is valid for
+	Period = 30 * time.Second
+	// Skew is the number of periods before and after the current one whose codes are accepted, to
+	// tolerate clocks that drift
+	Skew = 1
+
+	// SecretLength is the length in bytes of generated secrets
+	SecretLength = 20
+
+	// RecoveryCodeCount is the number of recovery codes generated for an enrollment
+	RecoveryCodeCount = 10
+	// recoveryCodeLength is the length in bytes of the randomness of a recovery code
+	recoveryCodeLength = 10
+)
+
+// GenerateSecret returns a new random base32 encoded secret
+func GenerateSecret() (string, error) {
+	key := make([]byte, SecretLength)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.EncodeToString(key), nil
+}
+
+// TimeStep returns the number of periods elapsed since the Unix epoch at the given time
+func TimeStep(t time.Time) int64 {
+	return t.Unix() / int64(Period/time.Second)
+}
+
+// GenerateCode returns the code of the given base32 encoded secret for the given time step
+func GenerateCode(secret string, step int64) (string, error) {
+	key, err := base32.StdEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", fmt.Errorf("invalid secret: %v", err)
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// dynamic truncation, as described in RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0xf
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < Digits; i++ {
+		modulo *= 10
+	}
+	return fmt.Sprintf("%0*d", Digits, value%modulo), nil
+}
+
+// ValidateCode checks the code against the codes of the time steps around the given time. Steps at or
+// before lastUsedStep are never accepted, so that a code can't be replayed. It returns the time step
+// that matched.
+func ValidateCode(secret, code string, t time.Time, lastUsedStep int64) (int64, bool, error) {
+	code = normalizeCode(code)
+	if len(code) != Digits {
+		return 0, false, nil
+	}
+
+	current := TimeStep(t)
+	for step := current - Skew; step <= current+Skew; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+		expected, err := GenerateCode(secret, step)
+		if err != nil {
+			return 0, false, err
+		}
+		if hmac.Equal([]byte(expected), []byte(code)) {
+			return step, true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// KeyURI returns the otpauth:// URI that authenticator apps use to add an account, usually shown as a QR code
+func KeyURI(issuer, accountName, secret string) string {
+	label := url.URL{Path: issuer + ":" + accountName}
+	params := url.Values{}
+	params.Set("secret", strings.TrimRight(secret, "="))
+	params.Set("issuer", issuer)
+	params.Set("algorithm", "SHA1")
+	params.Set("digits", fmt.Sprintf("%d", Digits))
+	params.Set("period", fmt.Sprintf("%d", int64(Period/time.Second)))
+	return "otpauth://totp/" + label.EscapedPath() + "?" + params.Encode()
+}
+
+// GenerateRecoveryCodes returns new random recovery codes, and the hashes to store for them
+func GenerateRecoveryCodes() ([]string, []string, error) {
+	codes := []string{}
+	hashes := []string{}
+	for i := 0; i < RecoveryCodeCount; i++ {
+		data := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(data); err != nil {
+			return nil, nil, err
+		}
+		encoded := strings.ToLower(base32.StdEncoding.EncodeToString(data))
+		code := fmt.Sprintf("%s-%s-%s-%s", encoded[0:4], encoded[4:8], encoded[8:12], encoded[12:16])
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash stored for a recovery code. Dashes, spaces and case are ignored.
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(normalizeCode(code))))
+	return hex.EncodeToString(sum[:])
+}
+
+// normalizeCode removes the separators users may type within a code
+func normalizeCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(code))
+}
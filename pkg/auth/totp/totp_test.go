@@ -0,0 +1,148 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA1 key of the test vectors of RFC 6238 appendix B
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateCode(t *testing.T) {
+	// The RFC lists 8 digit codes, the last 6 digits are the 6 digit codes
+	tests := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+	for unix, expected := range tests {
+		code, err := GenerateCode(rfcSecret, TimeStep(time.Unix(unix, 0)))
+		if err != nil {
+			t.Errorf("%d: unexpected error: %v", unix, err)
+			continue
+		}
+		if code != expected {
+			t.Errorf("%d: expected %s, got %s", unix, expected, code)
+		}
+	}
+
+	if _, err := GenerateCode("not base32!", 1); err == nil {
+		t.Errorf("expected an error for an invalid secret")
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := TimeStep(now)
+	code := func(step int64) string {
+		code, err := GenerateCode(rfcSecret, step)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return code
+	}
+
+	tests := map[string]struct {
+		code         string
+		lastUsedStep int64
+
+		expectedOK   bool
+		expectedStep int64
+	}{
+		"current code": {
+			code:         code(step),
+			expectedOK:   true,
+			expectedStep: step,
+		},
+		"code with spaces": {
+			code:         code(step)[:3] + " " + code(step)[3:],
+			expectedOK:   true,
+			expectedStep: step,
+		},
+		"previous code": {
+			code:         code(step - 1),
+			expectedOK:   true,
+			expectedStep: step - 1,
+		},
+		"next code": {
+			code:         code(step + 1),
+			expectedOK:   true,
+			expectedStep: step + 1,
+		},
+		"expired code": {
+			code: code(step - 2),
+		},
+		"replayed code": {
+			code:         code(step),
+			lastUsedStep: step,
+		},
+		"older code than the last used one": {
+			code:         code(step - 1),
+			lastUsedStep: step,
+		},
+		"wrong length": {
+			code: "12345",
+		},
+	}
+	for name, test := range tests {
+		matched, ok, err := ValidateCode(rfcSecret, test.code, now, test.lastUsedStep)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if ok != test.expectedOK || matched != test.expectedStep {
+			t.Errorf("%s: expected %v at step %d, got %v at step %d", name, test.expectedOK, test.expectedStep, ok, matched)
+		}
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	key, err := base32.StdEncoding.DecodeString(secret)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(key) != SecretLength {
+		t.Errorf("expected a %d byte secret, got %d", SecretLength, len(key))
+	}
+	if other, _ := GenerateSecret(); other == secret {
+		t.Errorf("expected different secrets")
+	}
+}
+
+func TestKeyURI(t *testing.T) {
+	uri := KeyURI("OpenShift", "bob smith", "JBSWY3DPEHPK3PXP")
+	expected := "otpauth://totp/OpenShift:bob%20smith?algorithm=SHA1&digits=6&issuer=OpenShift&period=30&secret=JBSWY3DPEHPK3PXP"
+	if uri != expected {
+		t.Errorf("expected %s, got %s", expected, uri)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != RecoveryCodeCount || len(hashes) != RecoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d codes and %d hashes", RecoveryCodeCount, len(codes), len(hashes))
+	}
+	for i, code := range codes {
+		if HashRecoveryCode(code) != hashes[i] {
+			t.Errorf("hash %d does not match code %s", i, code)
+		}
+		if HashRecoveryCode(strings.ToUpper(strings.Replace(code, "-", " ", -1))) != hashes[i] {
+			t.Errorf("expected the hash of %s to ignore case and separators", code)
+		}
+		if strings.Contains(hashes[i], code) {
+			t.Errorf("expected the code %s not to be stored", code)
+		}
+	}
+}
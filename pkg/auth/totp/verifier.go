@@ -0,0 +1,233 @@
+package totp
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthtotpenrollment"
+)
+
+var (
+	// ErrCodeRequired is returned when an enrolled user authenticates without a verification code
+	ErrCodeRequired = errors.New("a verification code is required")
+	// ErrInvalidCode is returned when the verification code is wrong, expired or was already used
+	ErrInvalidCode = errors.New("the verification code is invalid")
+	// ErrEnrollmentRequired is returned when a user must enroll an authenticator app before logging in
+	ErrEnrollmentRequired = errors.New("an authenticator app must be enrolled before logging in")
+	// ErrAlreadyEnrolled is returned when a user that already enrolled starts another enrollment
+	ErrAlreadyEnrolled = errors.New("an authenticator app is already enrolled")
+	// ErrLocked is returned when a user gave too many invalid codes in a row, until the lockout expires
+	ErrLocked = errors.New("too many invalid verification codes were given")
+)
+
+const (
+	// MaxFailedAttempts is the number of invalid codes in a row after which an enrollment is locked
+	MaxFailedAttempts = 5
+	// LockoutDuration is the time during which no code of a locked enrollment is accepted
+	LockoutDuration = 5 * time.Minute
+
+	// maxUpdateRetries bounds the attempts to record an invalid code on an enrollment updated concurrently
+	maxUpdateRetries = 5
+)
+
+// Verifier manages the enrollments of users and checks their codes
+type Verifier struct {
+	enrollments oauthtotpenrollment.Registry
+	issuer      string
+	clock       kutil.Clock
+}
+
+// NewVerifier returns a Verifier storing enrollments in the given registry. Accounts are named after
+// the issuer in authenticator apps.
+func NewVerifier(enrollments oauthtotpenrollment.Registry, issuer string, clock kutil.Clock) *Verifier {
+	return &Verifier{enrollments: enrollments, issuer: issuer, clock: clock}
+}
+
+// Enrolled returns true if the user has confirmed an enrollment
+func (v *Verifier) Enrolled(u user.Info) (bool, error) {
+	enrollment, err := v.enrollment(u)
+	if err != nil {
+		return false, err
+	}
+	return enrollment != nil && enrollment.Confirmed, nil
+}
+
+// Verify checks a verification code, or one of the recovery codes, of an enrolled user. Accepted codes
+// can't be used again. Invalid codes are counted on the enrollment, and ErrLocked is returned without
+// checking the code for LockoutDuration once MaxFailedAttempts invalid codes were given in a row.
+func (v *Verifier) Verify(u user.Info, code string) (bool, error) {
+	enrollment, err := v.enrollment(u)
+	if err != nil {
+		return false, err
+	}
+	if enrollment == nil || !enrollment.Confirmed {
+		return false, nil
+	}
+	now := v.clock.Now()
+	if enrollment.LockedUntil != nil && now.Before(enrollment.LockedUntil.Time) {
+		return false, ErrLocked
+	}
+
+	step, ok, err := ValidateCode(enrollment.Secret, code, now, enrollment.LastUsedTimeStep)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		enrollment.LastUsedTimeStep = step
+	} else if !removeRecoveryCode(enrollment, code) {
+		return false, v.recordFailure(enrollment)
+	}
+	enrollment.FailedAttempts = 0
+	enrollment.LockedUntil = nil
+
+	// The update fails with a conflict if the same code was accepted concurrently
+	if _, err := v.enrollments.UpdateTOTPEnrollment(kapi.NewContext(), enrollment); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// StartEnrollment returns the unconfirmed enrollment of the user, creating it if needed. Enrollments
+// left behind by a previous user with the same name are replaced.
+func (v *Verifier) StartEnrollment(u user.Info) (*api.OAuthTOTPEnrollment, error) {
+	ctx := kapi.NewContext()
+	existing, err := v.enrollments.GetTOTPEnrollment(ctx, u.GetName())
+	switch {
+	case kerrors.IsNotFound(err):
+	case err != nil:
+		return nil, err
+	case existing.UserUID != u.GetUID():
+		if err := v.enrollments.DeleteTOTPEnrollment(ctx, existing.Name); err != nil && !kerrors.IsNotFound(err) {
+			return nil, err
+		}
+	case existing.Confirmed:
+		return nil, ErrAlreadyEnrolled
+	default:
+		// keep the secret the user may already have added to an authenticator app
+		return existing, nil
+	}
+
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	enrollment := &api.OAuthTOTPEnrollment{
+		ObjectMeta: kapi.ObjectMeta{Name: u.GetName()},
+		UserName:   u.GetName(),
+		UserUID:    u.GetUID(),
+		Secret:     secret,
+	}
+	return v.enrollments.CreateTOTPEnrollment(ctx, enrollment)
+}
+
+// ConfirmEnrollment confirms the enrollment of the user if the code was generated by the authenticator
+// app. It returns the recovery codes of the user, which are only shown this one time. Invalid codes are
+// counted and locked out as in Verify.
+func (v *Verifier) ConfirmEnrollment(u user.Info, code string) ([]string, bool, error) {
+	enrollment, err := v.enrollment(u)
+	if err != nil {
+		return nil, false, err
+	}
+	if enrollment == nil {
+		return nil, false, fmt.Errorf("no enrollment was started for %s", u.GetName())
+	}
+	if enrollment.Confirmed {
+		return nil, false, ErrAlreadyEnrolled
+	}
+
+	now := v.clock.Now()
+	if enrollment.LockedUntil != nil && now.Before(enrollment.LockedUntil.Time) {
+		return nil, false, ErrLocked
+	}
+
+	step, ok, err := ValidateCode(enrollment.Secret, code, now, enrollment.LastUsedTimeStep)
+	if err != nil {
+		return nil, false, err
+	}
+	if !ok {
+		return nil, false, v.recordFailure(enrollment)
+	}
+
+	codes, hashes, err := GenerateRecoveryCodes()
+	if err != nil {
+		return nil, false, err
+	}
+	enrollment.Confirmed = true
+	enrollment.RecoveryCodeHashes = hashes
+	enrollment.LastUsedTimeStep = step
+	enrollment.FailedAttempts = 0
+	enrollment.LockedUntil = nil
+	if _, err := v.enrollments.UpdateTOTPEnrollment(kapi.NewContext(), enrollment); err != nil {
+		return nil, false, err
+	}
+	return codes, true, nil
+}
+
+// recordFailure counts an invalid code on the enrollment, and locks it once too many invalid codes were
+// given in a row. The enrollment is read again on conflicts, so that concurrent attempts are all counted.
+func (v *Verifier) recordFailure(enrollment *api.OAuthTOTPEnrollment) error {
+	ctx := kapi.NewContext()
+	for i := 0; ; i++ {
+		enrollment.FailedAttempts++
+		if enrollment.FailedAttempts >= MaxFailedAttempts {
+			lockedUntil := unversioned.NewTime(v.clock.Now().Add(LockoutDuration))
+			enrollment.LockedUntil = &lockedUntil
+			enrollment.FailedAttempts = 0
+		}
+		_, err := v.enrollments.UpdateTOTPEnrollment(ctx, enrollment)
+		if !kerrors.IsConflict(err) || i >= maxUpdateRetries {
+			return err
+		}
+		uid := enrollment.UserUID
+		if enrollment, err = v.enrollments.GetTOTPEnrollment(ctx, enrollment.Name); err != nil {
+			return err
+		}
+		if enrollment.UserUID != uid {
+			return nil
+		}
+	}
+}
+
+// KeyURI returns the URI that adds the account of the user to an authenticator app
+func (v *Verifier) KeyURI(u user.Info, secret string) string {
+	return KeyURI(v.issuer, u.GetName(), secret)
+}
+
+// enrollment returns the enrollment of the user, or nil if the user has none
+func (v *Verifier) enrollment(u user.Info) (*api.OAuthTOTPEnrollment, error) {
+	enrollment, err := v.enrollments.GetTOTPEnrollment(kapi.NewContext(), u.GetName())
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// the enrollment of a deleted user must not protect a new user with the same name
+	if enrollment.UserUID != u.GetUID() {
+		return nil, nil
+	}
+	return enrollment, nil
+}
+
+// removeRecoveryCode removes the hash of the code from the enrollment, and returns false if the code
+// is not one of the unused recovery codes
+func removeRecoveryCode(enrollment *api.OAuthTOTPEnrollment, code string) bool {
+	hash := HashRecoveryCode(code)
+	for i, existing := range enrollment.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(existing), []byte(hash)) == 1 {
+			hashes := append([]string{}, enrollment.RecoveryCodeHashes[:i]...)
+			enrollment.RecoveryCodeHashes = append(hashes, enrollment.RecoveryCodeHashes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,229 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+	kutil "k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/test"
+)
+
+func TestEnrollAndVerify(t *testing.T) {
+	registry := &test.TOTPEnrollmentRegistry{}
+	clock := &kutil.FakeClock{Time: time.Unix(1450000000, 0)}
+	verifier := NewVerifier(registry, "OpenShift", clock)
+	bob := &user.DefaultInfo{Name: "bob", UID: "1"}
+
+	currentCode := func() string {
+		code, err := GenerateCode(registry.Enrollment.Secret, TimeStep(clock.Now()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return code
+	}
+
+	if enrolled, err := verifier.Enrolled(bob); err != nil || enrolled {
+		t.Fatalf("expected bob not to be enrolled: %v", err)
+	}
+
+	enrollment, err := verifier.StartEnrollment(bob)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if enrollment.Name != "bob" || enrollment.UserUID != "1" || len(enrollment.Secret) == 0 || enrollment.Confirmed {
+		t.Fatalf("unexpected enrollment: %#v", enrollment)
+	}
+	if again, err := verifier.StartEnrollment(bob); err != nil || again.Secret != enrollment.Secret {
+		t.Fatalf("expected an unconfirmed enrollment to keep its secret: %v", err)
+	}
+	if enrolled, err := verifier.Enrolled(bob); err != nil || enrolled {
+		t.Fatalf("expected an unconfirmed enrollment not to count: %v", err)
+	}
+	if ok, err := verifier.Verify(bob, currentCode()); err != nil || ok {
+		t.Fatalf("expected codes of an unconfirmed enrollment to be refused: %v", err)
+	}
+
+	if _, ok, err := verifier.ConfirmEnrollment(bob, "000000"); err != nil || ok {
+		t.Fatalf("expected a wrong code not to confirm the enrollment: %v", err)
+	}
+	recoveryCodes, ok, err := verifier.ConfirmEnrollment(bob, currentCode())
+	if err != nil || !ok {
+		t.Fatalf("expected the enrollment to be confirmed: %v", err)
+	}
+	if len(recoveryCodes) != RecoveryCodeCount {
+		t.Fatalf("expected %d recovery codes, got %v", RecoveryCodeCount, recoveryCodes)
+	}
+	if enrolled, err := verifier.Enrolled(bob); err != nil || !enrolled {
+		t.Fatalf("expected bob to be enrolled: %v", err)
+	}
+	if _, err := verifier.StartEnrollment(bob); err != ErrAlreadyEnrolled {
+		t.Fatalf("expected %v, got %v", ErrAlreadyEnrolled, err)
+	}
+
+	// the code used to confirm the enrollment can't be used to log in
+	if ok, err := verifier.Verify(bob, currentCode()); err != nil || ok {
+		t.Fatalf("expected a used code to be refused: %v", err)
+	}
+
+	clock.Step(Period)
+	code := currentCode()
+	if ok, err := verifier.Verify(bob, code); err != nil || !ok {
+		t.Fatalf("expected the next code to be accepted: %v", err)
+	}
+	if ok, err := verifier.Verify(bob, code); err != nil || ok {
+		t.Fatalf("expected a replayed code to be refused: %v", err)
+	}
+
+	clock.Step(10 * Period)
+	if ok, err := verifier.Verify(bob, code); err != nil || ok {
+		t.Fatalf("expected an expired code to be refused: %v", err)
+	}
+
+	if ok, err := verifier.Verify(bob, recoveryCodes[3]); err != nil || !ok {
+		t.Fatalf("expected a recovery code to be accepted: %v", err)
+	}
+	if len(registry.Enrollment.RecoveryCodeHashes) != RecoveryCodeCount-1 {
+		t.Fatalf("expected the recovery code to be removed, got %v", registry.Enrollment.RecoveryCodeHashes)
+	}
+	if ok, err := verifier.Verify(bob, recoveryCodes[3]); err != nil || ok {
+		t.Fatalf("expected a used recovery code to be refused: %v", err)
+	}
+}
+
+func TestLockout(t *testing.T) {
+	registry := &test.TOTPEnrollmentRegistry{
+		Enrollment: &api.OAuthTOTPEnrollment{
+			ObjectMeta: kapi.ObjectMeta{Name: "bob"},
+			UserName:   "bob",
+			UserUID:    "1",
+			Secret:     "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+			Confirmed:  true,
+		},
+	}
+	clock := &kutil.FakeClock{Time: time.Unix(1450000000, 0)}
+	verifier := NewVerifier(registry, "OpenShift", clock)
+	bob := &user.DefaultInfo{Name: "bob", UID: "1"}
+
+	currentCode := func() string {
+		code, err := GenerateCode(registry.Enrollment.Secret, TimeStep(clock.Now()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return code
+	}
+
+	// an accepted code resets the count of invalid codes
+	for i := 1; i < MaxFailedAttempts; i++ {
+		if ok, err := verifier.Verify(bob, "000000"); err != nil || ok {
+			t.Fatalf("expected an invalid code to be refused: %v", err)
+		}
+	}
+	if registry.Enrollment.FailedAttempts != MaxFailedAttempts-1 {
+		t.Fatalf("expected the invalid codes to be counted, got %d", registry.Enrollment.FailedAttempts)
+	}
+	if ok, err := verifier.Verify(bob, currentCode()); err != nil || !ok {
+		t.Fatalf("expected a valid code to be accepted: %v", err)
+	}
+	if registry.Enrollment.FailedAttempts != 0 {
+		t.Fatalf("expected the count of invalid codes to be reset, got %d", registry.Enrollment.FailedAttempts)
+	}
+
+	for i := 0; i < MaxFailedAttempts; i++ {
+		if ok, err := verifier.Verify(bob, "000000"); err != nil || ok {
+			t.Fatalf("expected an invalid code to be refused: %v", err)
+		}
+	}
+	if registry.Enrollment.LockedUntil == nil {
+		t.Fatalf("expected the enrollment to be locked")
+	}
+
+	clock.Step(Period)
+	if ok, err := verifier.Verify(bob, currentCode()); err != ErrLocked || ok {
+		t.Fatalf("expected %v, got %v", ErrLocked, err)
+	}
+
+	clock.Step(LockoutDuration)
+	if ok, err := verifier.Verify(bob, currentCode()); err != nil || !ok {
+		t.Fatalf("expected a valid code to be accepted once the lockout expired: %v", err)
+	}
+	if registry.Enrollment.LockedUntil != nil {
+		t.Errorf("expected the lockout to be cleared")
+	}
+}
+
+func TestConfirmEnrollmentLockout(t *testing.T) {
+	registry := &test.TOTPEnrollmentRegistry{
+		Enrollment: &api.OAuthTOTPEnrollment{
+			ObjectMeta: kapi.ObjectMeta{Name: "bob"},
+			UserName:   "bob",
+			UserUID:    "1",
+			Secret:     "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+		},
+	}
+	clock := &kutil.FakeClock{Time: time.Unix(1450000000, 0)}
+	verifier := NewVerifier(registry, "OpenShift", clock)
+	bob := &user.DefaultInfo{Name: "bob", UID: "1"}
+
+	currentCode := func() string {
+		code, err := GenerateCode(registry.Enrollment.Secret, TimeStep(clock.Now()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return code
+	}
+
+	for i := 0; i < MaxFailedAttempts; i++ {
+		if _, ok, err := verifier.ConfirmEnrollment(bob, "000000"); err != nil || ok {
+			t.Fatalf("expected an invalid code to be refused: %v", err)
+		}
+	}
+	if registry.Enrollment.LockedUntil == nil {
+		t.Fatalf("expected the enrollment to be locked")
+	}
+	if _, ok, err := verifier.ConfirmEnrollment(bob, currentCode()); err != ErrLocked || ok {
+		t.Fatalf("expected %v, got %v", ErrLocked, err)
+	}
+	if registry.Enrollment.Confirmed {
+		t.Fatalf("expected a locked enrollment not to be confirmed")
+	}
+
+	clock.Step(LockoutDuration)
+	if _, ok, err := verifier.ConfirmEnrollment(bob, currentCode()); err != nil || !ok {
+		t.Fatalf("expected the enrollment to be confirmed once the lockout expired: %v", err)
+	}
+	if registry.Enrollment.LockedUntil != nil || registry.Enrollment.FailedAttempts != 0 {
+		t.Errorf("expected the lockout to be cleared, got %#v", registry.Enrollment)
+	}
+}
+
+func TestEnrollmentOfPreviousUser(t *testing.T) {
+	registry := &test.TOTPEnrollmentRegistry{
+		Enrollment: &api.OAuthTOTPEnrollment{
+			ObjectMeta: kapi.ObjectMeta{Name: "bob"},
+			UserName:   "bob",
+			UserUID:    "0",
+			Secret:     "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+			Confirmed:  true,
+		},
+	}
+	verifier := NewVerifier(registry, "OpenShift", &kutil.FakeClock{Time: time.Unix(1450000000, 0)})
+	bob := &user.DefaultInfo{Name: "bob", UID: "1"}
+
+	if enrolled, err := verifier.Enrolled(bob); err != nil || enrolled {
+		t.Fatalf("expected the enrollment of a previous user not to count: %v", err)
+	}
+	enrollment, err := verifier.StartEnrollment(bob)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registry.DeletedEnrollmentName != "bob" {
+		t.Errorf("expected the enrollment of the previous user to be deleted")
+	}
+	if enrollment.UserUID != "1" || enrollment.Secret == "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP" {
+		t.Errorf("expected a new enrollment, got %#v", enrollment)
+	}
+}
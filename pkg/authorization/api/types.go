@@ -78,9 +78,9 @@ var (
 		ImageGroupName:       {"imagestreams", "imagestreammappings", "imagestreamtags", "imagestreamimages", "imagestreamimports"},
 		DeploymentGroupName:  {"deployments", "deploymentconfigs", "generatedeploymentconfigs", "deploymentconfigrollbacks", "deploymentconfigs/log", "deploymentconfigs/scale"},
 		SDNGroupName:         {"clusternetworks", "hostsubnets", "netnamespaces"},
-		TemplateGroupName:    {"templates", "templateconfigs", "processedtemplates"},
+		TemplateGroupName:    {"templates", "templateconfigs", "processedtemplates", "templateinstances"},
 		UserGroupName:        {"identities", "users", "useridentitymappings", "groups"},
-		OAuthGroupName:       {"oauthauthorizetokens", "oauthaccesstokens", "oauthclients", "oauthclientauthorizations"},
+		OAuthGroupName:       {"oauthauthorizetokens", "oauthaccesstokens", "oauthclients", "oauthclientauthorizations", "oauthtotpenrollments", "useroauthaccesstokens"},
 		PolicyOwnerGroupName: {"policies", "policybindings"},
 
 		// RAR and SAR are in this list to support backwards compatibility with clients that expect access to those resource in a namespace scope and a cluster scope.
@@ -88,8 +88,8 @@ var (
 		PermissionGrantingGroupName: {"roles", "rolebindings", "resourceaccessreviews" /* cluster scoped*/, "subjectaccessreviews" /* cluster scoped*/, "localresourceaccessreviews", "localsubjectaccessreviews"},
 		OpenshiftExposedGroupName:   {BuildGroupName, ImageGroupName, DeploymentGroupName, TemplateGroupName, "routes"},
 		OpenshiftAllGroupName: {OpenshiftExposedGroupName, UserGroupName, OAuthGroupName, PolicyOwnerGroupName, SDNGroupName, PermissionGrantingGroupName, OpenshiftStatusGroupName, "projects",
-			"clusterroles", "clusterrolebindings", "clusterpolicies", "clusterpolicybindings", "images" /* cluster scoped*/, "projectrequests", "builds/details", "imagestreams/secrets"},
-		OpenshiftStatusGroupName: {"imagestreams/status", "routes/status"},
+			"clusterroles", "clusterrolebindings", "clusterpolicies", "clusterpolicybindings", "images" /* cluster scoped*/, "imagesignatures" /* cluster scoped*/, "projectrequests", "builds/details", "imagestreams/secrets"},
+		OpenshiftStatusGroupName: {"imagestreams/status", "routes/status", "templateinstances/status"},
 
 		QuotaGroupName:         {"limitranges", "resourcequotas", "resourcequotausages"},
 		KubeExposedGroupName:   {"pods", "replicationcontrollers", "serviceaccounts", "services", "endpoints", "persistentvolumeclaims", "pods/log"},
@@ -97,7 +97,7 @@ var (
 		KubeAllGroupName:       {KubeInternalsGroupName, KubeExposedGroupName, QuotaGroupName},
 		KubeStatusGroupName:    {"pods/status", "resourcequotas/status", "namespaces/status", "replicationcontrollers/status"},
 
-		OpenshiftEscalatingViewableGroupName: {"oauthauthorizetokens", "oauthaccesstokens", "imagestreams/secrets"},
+		OpenshiftEscalatingViewableGroupName: {"oauthauthorizetokens", "oauthaccesstokens", "useroauthaccesstokens", "oauthtotpenrollments", "imagestreams/secrets"},
 		KubeEscalatingViewableGroupName:      {"secrets"},
 		EscalatingResourcesGroupName:         {OpenshiftEscalatingViewableGroupName, KubeEscalatingViewableGroupName},
 
@@ -236,6 +236,9 @@ type SubjectAccessReview struct {
 	User string
 	// Groups is optional.  Groups is the list of groups to which the User belongs.
 	Groups sets.String
+	// Scopes is optional.  Scopes are the scopes of the OAuth access token the User is restricted to.  They are ignored
+	// if both User and Groups are empty.
+	Scopes []string
 }
 
 // LocalResourceAccessReview is a means to request a list of which users and groups are authorized to perform the action specified by spec in a particular namespace
@@ -256,6 +259,9 @@ type LocalSubjectAccessReview struct {
 	User string
 	// Groups is optional.  Groups is the list of groups to which the User belongs.
 	Groups sets.String
+	// Scopes is optional.  Scopes are the scopes of the OAuth access token the User is restricted to.  They are ignored
+	// if both User and Groups are empty.
+	Scopes []string
 }
 
 type AuthorizationAttributes struct {
@@ -149,6 +149,8 @@ type SubjectAccessReview struct {
 	User string `json:"user" description:"optional, if both user and groups are empty, the current authenticated user is used"`
 	// GroupsSlice is optional. Groups is the list of groups to which the User belongs.
 	GroupsSlice []string `json:"groups" description:"optional, list of groups to which the user belongs"`
+	// Scopes is optional. Scopes are the scopes of the OAuth access token the User is restricted to.
+	Scopes []string `json:"scopes,omitempty" description:"optional, scopes of the OAuth access token the user is restricted to"`
 }
 
 // LocalResourceAccessReview is a means to request a list of which users and groups are authorized to perform the action specified by spec in a particular namespace
@@ -169,6 +171,8 @@ type LocalSubjectAccessReview struct {
 	User string `json:"user" description:"optional, if both user and groups are empty, the current authenticated user is used"`
 	// Groups is optional.  Groups is the list of groups to which the User belongs.
 	GroupsSlice []string `json:"groups" description:"optional, list of groups to which the user belongs"`
+	// Scopes is optional. Scopes are the scopes of the OAuth access token the User is restricted to.
+	Scopes []string `json:"scopes,omitempty" description:"optional, scopes of the OAuth access token the user is restricted to"`
 }
 
 type AuthorizationAttributes struct {
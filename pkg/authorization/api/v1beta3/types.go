@@ -152,6 +152,8 @@ type SubjectAccessReview struct {
 	User string `json:"user"`
 	// Groups is optional.  Groups is the list of groups to which the User belongs.
 	GroupsSlice []string `json:"groups"`
+	// Scopes is optional.  Scopes are the scopes of the OAuth access token the User is restricted to.
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 // LocalResourceAccessReview is a means to request a list of which users and groups are authorized to perform the action specified by spec in a particular namespace
@@ -172,6 +174,8 @@ type LocalSubjectAccessReview struct {
 	User string `json:"user"`
 	// Groups is optional.  Groups is the list of groups to which the User belongs.
 	GroupsSlice []string `json:"groups"`
+	// Scopes is optional.  Scopes are the scopes of the OAuth access token the User is restricted to.
+	Scopes []string `json:"scopes,omitempty"`
 }
 
 type AuthorizationAttributes struct {
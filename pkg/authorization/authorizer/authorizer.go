@@ -1,11 +1,17 @@
 package authorizer
 
 import (
+	"fmt"
+	"strings"
+
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/auth/user"
 	kerrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	"github.com/openshift/origin/pkg/authorization/authorizer/scope"
 	"github.com/openshift/origin/pkg/authorization/rulevalidation"
 )
 
@@ -26,8 +32,23 @@ func (a *openshiftAuthorizer) Authorize(ctx kapi.Context, passedAttributes Autho
 	// This is most common when a bound role is missing, but enough roles are still present and bound to authorize the request.
 	errs := []error{}
 
+	user, _ := kapi.UserFrom(ctx)
+	namespace, _ := kapi.NamespaceFrom(ctx)
+
+	// a user that authenticated with a scoped token may only use the rules allowed by the scopes in the namespace of the request.
+	// A nil list of scope rules means that the user is not restricted.
+	var scopeRules []authorizationapi.PolicyRule
+	scopes, scoped := authapi.ScopesFor(user)
+	if scoped && !scope.IsUnrestricted(scopes) {
+		rules, err := scope.ScopesToRules(scopes, namespace, a.ruleResolver)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		scopeRules = rules
+	}
+
 	masterContext := kapi.WithNamespace(ctx, kapi.NamespaceNone)
-	globalAllowed, globalReason, err := a.authorizeWithNamespaceRules(masterContext, attributes)
+	globalAllowed, globalReason, err := a.authorizeWithNamespaceRules(masterContext, attributes, scopeRules)
 	if globalAllowed {
 		return true, globalReason, nil
 	}
@@ -35,9 +56,8 @@ func (a *openshiftAuthorizer) Authorize(ctx kapi.Context, passedAttributes Autho
 		errs = append(errs, err)
 	}
 
-	namespace, _ := kapi.NamespaceFrom(ctx)
 	if len(namespace) != 0 {
-		namespaceAllowed, namespaceReason, err := a.authorizeWithNamespaceRules(ctx, attributes)
+		namespaceAllowed, namespaceReason, err := a.authorizeWithNamespaceRules(ctx, attributes, scopeRules)
 		if namespaceAllowed {
 			return true, namespaceReason, nil
 		}
@@ -50,11 +70,13 @@ func (a *openshiftAuthorizer) Authorize(ctx kapi.Context, passedAttributes Autho
 		return false, "", kerrors.NewAggregate(errs)
 	}
 
-	user, _ := kapi.UserFrom(ctx)
 	denyReason, err := a.forbiddenMessageMaker.MakeMessage(MessageContext{user, namespace, attributes})
 	if err != nil {
 		denyReason = err.Error()
 	}
+	if scopeRules != nil {
+		denyReason += fmt.Sprintf(" with a token restricted to the scopes %s", strings.Join(scopes, ", "))
+	}
 
 	return false, denyReason, nil
 }
@@ -129,10 +151,14 @@ func (a *openshiftAuthorizer) getAllowedSubjectsFromNamespaceBindings(ctx kapi.C
 // authorizeWithNamespaceRules returns isAllowed, reason, and error.  If an error is returned, isAllowed and reason are still valid.  This seems strange
 // but errors are not always fatal to the authorization process.  It is entirely possible to get an error and be able to continue determine authorization
 // status in spite of it.  This is most common when a bound role is missing, but enough roles are still present and bound to authorize the request.
-func (a *openshiftAuthorizer) authorizeWithNamespaceRules(ctx kapi.Context, passedAttributes AuthorizationAttributes) (bool, string, error) {
+// If scopeRules is not nil, the rules of the user are narrowed to the actions the scopeRules allow.
+func (a *openshiftAuthorizer) authorizeWithNamespaceRules(ctx kapi.Context, passedAttributes AuthorizationAttributes, scopeRules []authorizationapi.PolicyRule) (bool, string, error) {
 	attributes := coerceToDefaultAuthorizationAttributes(passedAttributes)
 
 	allRules, ruleRetrievalError := a.ruleResolver.GetEffectivePolicyRules(ctx)
+	if scopeRules != nil {
+		allRules = rulevalidation.NarrowRules(allRules, scopeRules)
+	}
 
 	for _, rule := range allRules {
 		matches, err := attributes.RuleMatches(rule)
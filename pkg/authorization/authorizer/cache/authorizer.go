@@ -14,6 +14,7 @@ import (
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/authorization/authorizer"
 )
 
@@ -144,6 +145,9 @@ func cacheKey(ctx kapi.Context, a authorizer.AuthorizationAttributes) (string, e
 	if user, ok := kapi.UserFrom(ctx); ok {
 		keyData["user"] = user.GetName()
 		keyData["groups"] = user.GetGroups()
+		if scopes, ok := authapi.ScopesFor(user); ok {
+			keyData["scopes"] = scopes
+		}
 	}
 
 	key, err := json.Marshal(keyData)
@@ -11,6 +11,7 @@ import (
 	"k8s.io/kubernetes/pkg/auth/user"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	"github.com/openshift/origin/pkg/authorization/authorizer"
 )
 
@@ -50,6 +51,11 @@ func TestCacheKey(t *testing.T) {
 			},
 			ExpectedKey: `{"apiGroup":"ag","apiVersion":"av","groups":["group1","group2"],"namespace":"myns","nonResourceURL":true,"resource":"r","resourceName":"rn","url":"/abc","user":"me","verb":"v"}`,
 		},
+		"scoped": {
+			Context:     kapi.WithUser(kapi.NewContext(), &authapi.DefaultScopedUserInfo{DefaultInfo: user.DefaultInfo{Name: "me"}, Scopes: []string{"user:info"}}),
+			Attrs:       &authorizer.DefaultAuthorizationAttributes{},
+			ExpectedKey: `{"apiGroup":"","apiVersion":"","groups":null,"nonResourceURL":false,"resource":"","resourceName":"","scopes":["user:info"],"url":"","user":"me","verb":""}`,
+		},
 	}
 
 	for k, tc := range tests {
@@ -0,0 +1,177 @@
+package scope
+
+import (
+	"fmt"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	authorizationinterfaces "github.com/openshift/origin/pkg/authorization/interfaces"
+	"github.com/openshift/origin/pkg/authorization/rulevalidation"
+)
+
+const (
+	UserIndicator        = "user:"
+	ClusterRoleIndicator = "role:"
+
+	// UserInfo allows reading the information of the user the token belongs to.
+	UserInfo = UserIndicator + "info"
+	// UserAccessCheck allows checking what the user the token belongs to is allowed to do.
+	UserAccessCheck = UserIndicator + "check-access"
+	// UserListProject allows listing the projects the user the token belongs to can see.
+	UserListProject = UserIndicator + "list-projects"
+	// UserFull allows the token to act with the full permissions of the user it belongs to.
+	UserFull = UserIndicator + "full"
+
+	// EscalatingScopeSuffix is added to a role scope to include the rules of the role on resources that can be used to
+	// escalate privileges, like secrets.
+	EscalatingScopeSuffix = ":!"
+
+	// AllNamespaces is the namespace of a role scope that applies in every namespace
+	AllNamespaces = "*"
+)
+
+// userScopeRules are the rules allowed by each user scope, except UserFull
+var userScopeRules = map[string][]authorizationapi.PolicyRule{
+	UserInfo: {
+		{Verbs: sets.NewString("get"), Resources: sets.NewString("users"), ResourceNames: sets.NewString("~")},
+	},
+	UserAccessCheck: {
+		{Verbs: sets.NewString("create"), Resources: sets.NewString("subjectaccessreviews", "localsubjectaccessreviews"), AttributeRestrictions: &authorizationapi.IsPersonalSubjectAccessReview{}},
+	},
+	UserListProject: {
+		{Verbs: sets.NewString("list"), Resources: sets.NewString("projects")},
+	},
+}
+
+// RoleScope returns the scope that allows the rules of the named cluster role in the given namespace.  The escalating
+// form of the scope also allows the rules on resources that can be used to escalate privileges.
+func RoleScope(name, namespace string, escalating bool) string {
+	scope := ClusterRoleIndicator + name + ":" + namespace
+	if escalating {
+		scope += EscalatingScopeSuffix
+	}
+	return scope
+}
+
+// ParseRoleScope returns the role name and namespace of a role scope, and whether it is escalating.  Role names may
+// contain colons, so the namespace is the last segment of the scope.
+func ParseRoleScope(scope string) (name, namespace string, escalating bool, err error) {
+	if !strings.HasPrefix(scope, ClusterRoleIndicator) {
+		return "", "", false, fmt.Errorf("%q is not a role scope", scope)
+	}
+	value := strings.TrimPrefix(scope, ClusterRoleIndicator)
+	if strings.HasSuffix(value, EscalatingScopeSuffix) {
+		escalating = true
+		value = strings.TrimSuffix(value, EscalatingScopeSuffix)
+	}
+	i := strings.LastIndex(value, ":")
+	if i <= 0 || i == len(value)-1 {
+		return "", "", false, fmt.Errorf("%q must be in the format %s<role name>:<namespace>", scope, ClusterRoleIndicator)
+	}
+	return value[:i], value[i+1:], escalating, nil
+}
+
+// ValidateScopes returns an error for every scope that is not part of the scope language.
+func ValidateScopes(scopes []string) error {
+	errs := []error{}
+	for _, scope := range scopes {
+		switch {
+		case scope == UserFull:
+		case strings.HasPrefix(scope, UserIndicator):
+			if _, ok := userScopeRules[scope]; !ok {
+				errs = append(errs, fmt.Errorf("unknown user scope %q", scope))
+			}
+		case strings.HasPrefix(scope, ClusterRoleIndicator):
+			if _, _, _, err := ParseRoleScope(scope); err != nil {
+				errs = append(errs, err)
+			}
+		default:
+			errs = append(errs, fmt.Errorf("unknown scope %q", scope))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// IsUnrestricted returns true if the scopes allow everything the user is allowed to do.
+func IsUnrestricted(scopes []string) bool {
+	return len(scopes) == 0 || sets.NewString(scopes...).Has(UserFull)
+}
+
+// ScopesToRules returns the rules the scopes allow for a request in the given namespace.  Role scopes are resolved with the
+// roleResolver.  If an error is returned, the rules contain all the rules that could be determined.
+func ScopesToRules(scopes []string, namespace string, roleResolver rulevalidation.AuthorizationRuleResolver) ([]authorizationapi.PolicyRule, error) {
+	errs := []error{}
+	rules := []authorizationapi.PolicyRule{}
+	for _, scope := range scopes {
+		switch {
+		case scope == UserFull:
+			rules = append(rules, authorizationapi.PolicyRule{
+				Verbs:           sets.NewString(authorizationapi.VerbAll),
+				APIGroups:       []string{authorizationapi.APIGroupAll},
+				Resources:       sets.NewString(authorizationapi.ResourceAll),
+				NonResourceURLs: sets.NewString(authorizationapi.NonResourceAll),
+			})
+		case strings.HasPrefix(scope, UserIndicator):
+			userRules, ok := userScopeRules[scope]
+			if !ok {
+				errs = append(errs, fmt.Errorf("unknown user scope %q", scope))
+				continue
+			}
+			rules = append(rules, userRules...)
+		case strings.HasPrefix(scope, ClusterRoleIndicator):
+			roleRules, err := roleScopeRules(scope, namespace, roleResolver)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			rules = append(rules, roleRules...)
+		default:
+			errs = append(errs, fmt.Errorf("unknown scope %q", scope))
+		}
+	}
+	return rules, kerrors.NewAggregate(errs)
+}
+
+// roleScopeRules returns the rules of the cluster role of a role scope if the scope applies to the namespace.  Unless
+// the scope is escalating, the rules on escalating resources are removed.
+func roleScopeRules(scope, namespace string, roleResolver rulevalidation.AuthorizationRuleResolver) ([]authorizationapi.PolicyRule, error) {
+	name, scopeNamespace, escalating, err := ParseRoleScope(scope)
+	if err != nil {
+		return nil, err
+	}
+	if scopeNamespace != AllNamespaces && scopeNamespace != namespace {
+		return nil, nil
+	}
+
+	binding := authorizationinterfaces.NewClusterRoleBindingAdapter(&authorizationapi.ClusterRoleBinding{RoleRef: kapi.ObjectReference{Name: name}})
+	role, err := roleResolver.GetRole(binding)
+	if err != nil {
+		return nil, err
+	}
+	if escalating {
+		return role.Rules(), nil
+	}
+
+	escalatingResources := authorizationapi.NormalizeResources(sets.NewString(authorizationapi.GroupsToResources[authorizationapi.EscalatingResourcesGroupName]...))
+	rules := []authorizationapi.PolicyRule{}
+	for _, rule := range role.Rules() {
+		if len(rule.Resources) == 0 {
+			rules = append(rules, rule)
+			continue
+		}
+		// a wildcard would include the escalating resources, so it is removed as well
+		resources := sets.NewString(authorizationapi.NormalizeResources(rule.Resources).List()...)
+		resources.Delete(authorizationapi.ResourceAll)
+		resources = resources.Difference(escalatingResources)
+		if len(resources) == 0 && len(rule.NonResourceURLs) == 0 {
+			continue
+		}
+		rule.Resources = resources
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,140 @@
+package scope
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	testpolicyregistry "github.com/openshift/origin/pkg/authorization/registry/test"
+	"github.com/openshift/origin/pkg/authorization/rulevalidation"
+)
+
+func TestParseRoleScope(t *testing.T) {
+	tests := []struct {
+		scope      string
+		name       string
+		namespace  string
+		escalating bool
+		err        bool
+	}{
+		{scope: "role:view:mallet", name: "view", namespace: "mallet"},
+		{scope: "role:admin:*:!", name: "admin", namespace: "*", escalating: true},
+		{scope: "role:system:image-puller:mallet", name: "system:image-puller", namespace: "mallet"},
+		{scope: "role:view", err: true},
+		{scope: "role:view:", err: true},
+		{scope: "role::mallet", err: true},
+		{scope: "user:info", err: true},
+	}
+	for _, test := range tests {
+		name, namespace, escalating, err := ParseRoleScope(test.scope)
+		if test.err != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", test.scope, err)
+			continue
+		}
+		if name != test.name || namespace != test.namespace || escalating != test.escalating {
+			t.Errorf("%s: unexpected result: %q %q %v", test.scope, name, namespace, escalating)
+		}
+		if err == nil && RoleScope(name, namespace, escalating) != test.scope {
+			t.Errorf("%s: the scope did not round trip: %s", test.scope, RoleScope(name, namespace, escalating))
+		}
+	}
+}
+
+func TestValidateScopes(t *testing.T) {
+	if err := ValidateScopes([]string{UserInfo, UserAccessCheck, UserListProject, UserFull, "role:view:mallet"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := ValidateScopes([]string{"user:unknown", "role:view", "unknown"})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	for _, expected := range []string{`unknown user scope "user:unknown"`, `"role:view" must be in the format`, `unknown scope "unknown"`} {
+		if !strings.Contains(err.Error(), expected) {
+			t.Errorf("expected %q in %v", expected, err)
+		}
+	}
+}
+
+func TestIsUnrestricted(t *testing.T) {
+	if !IsUnrestricted(nil) || !IsUnrestricted([]string{UserInfo, UserFull}) {
+		t.Errorf("expected no scopes and the full user scope to be unrestricted")
+	}
+	if IsUnrestricted([]string{UserInfo}) {
+		t.Errorf("expected the user info scope to be restricted")
+	}
+}
+
+func TestScopesToRules(t *testing.T) {
+	roleRules := []authorizationapi.PolicyRule{
+		{Verbs: sets.NewString("get"), Resources: sets.NewString("pods", "secrets")},
+		{Verbs: sets.NewString("get"), Resources: sets.NewString("oauthaccesstokens")},
+		{Verbs: sets.NewString("get"), NonResourceURLs: sets.NewString("/healthz")},
+	}
+	policy := authorizationapi.ClusterPolicy{
+		ObjectMeta: kapi.ObjectMeta{Name: authorizationapi.PolicyName},
+		Roles: map[string]*authorizationapi.ClusterRole{
+			"reader": {ObjectMeta: kapi.ObjectMeta{Name: "reader"}, Rules: roleRules},
+		},
+	}
+	resolver := rulevalidation.NewDefaultRuleResolver(
+		testpolicyregistry.NewPolicyRegistry(nil, nil),
+		testpolicyregistry.NewPolicyBindingRegistry(nil, nil),
+		testpolicyregistry.NewClusterPolicyRegistry([]authorizationapi.ClusterPolicy{policy}, nil),
+		testpolicyregistry.NewClusterPolicyBindingRegistry(nil, nil),
+	)
+
+	tests := []struct {
+		name      string
+		scopes    []string
+		namespace string
+		expected  []authorizationapi.PolicyRule
+		err       string
+	}{
+		{
+			name:     "user scopes",
+			scopes:   []string{UserInfo, UserListProject},
+			expected: append(append([]authorizationapi.PolicyRule{}, userScopeRules[UserInfo]...), userScopeRules[UserListProject]...),
+		},
+		{
+			name:      "non escalating role",
+			scopes:    []string{"role:reader:mallet"},
+			namespace: "mallet",
+			expected: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), Resources: sets.NewString("pods")},
+				{Verbs: sets.NewString("get"), NonResourceURLs: sets.NewString("/healthz")},
+			},
+		},
+		{
+			name:      "escalating role",
+			scopes:    []string{"role:reader:*:!"},
+			namespace: "mallet",
+			expected:  roleRules,
+		},
+		{
+			name:      "role in another namespace",
+			scopes:    []string{"role:reader:adze"},
+			namespace: "mallet",
+			expected:  []authorizationapi.PolicyRule{},
+		},
+		{
+			name:      "missing role",
+			scopes:    []string{"role:missing:mallet", UserInfo},
+			namespace: "mallet",
+			expected:  userScopeRules[UserInfo],
+			err:       `role "missing" not found`,
+		},
+	}
+	for _, test := range tests {
+		rules, err := ScopesToRules(test.scopes, test.namespace, resolver)
+		if len(test.err) == 0 && err != nil || len(test.err) > 0 && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(rules, test.expected) {
+			t.Errorf("%s: unexpected rules: %v", test.name, rules)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package authorizer
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/auth/user"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+)
+
+func scopedUser(name string, groups []string, scopes ...string) user.Info {
+	return &authapi.DefaultScopedUserInfo{DefaultInfo: user.DefaultInfo{Name: name, Groups: groups}, Scopes: scopes}
+}
+
+func newScopeTest(namespace string, user user.Info, attributes *DefaultAuthorizationAttributes) *authorizeTest {
+	test := &authorizeTest{
+		context:    kapi.WithUser(kapi.WithNamespace(kapi.NewContext(), namespace), user),
+		attributes: attributes,
+	}
+	test.clusterPolicies = newDefaultClusterPolicies()
+	test.policies = append(newAdzePolicies(), newMalletPolicies()...)
+	test.clusterBindings = newDefaultClusterPolicyBindings()
+	test.bindings = append(newAdzeBindings(), newMalletBindings()...)
+	return test
+}
+
+func TestScopedRoleAllow(t *testing.T) {
+	test := newScopeTest("mallet", scopedUser("Matthew", nil, "role:view:mallet"), &DefaultAuthorizationAttributes{
+		Verb:     "get",
+		Resource: "pods",
+	})
+	test.expectedAllowed = true
+	test.expectedReason = "allowed by rule in mallet"
+	test.test(t)
+}
+
+func TestScopedRoleDeny(t *testing.T) {
+	test := newScopeTest("mallet", scopedUser("Matthew", nil, "role:view:mallet"), &DefaultAuthorizationAttributes{
+		Verb:     "update",
+		Resource: "pods",
+	})
+	test.expectedAllowed = false
+	test.expectedReason = `User "Matthew" cannot update pods in project "mallet" with a token restricted to the scopes role:view:mallet`
+	test.test(t)
+}
+
+func TestScopedRoleOtherNamespaceDeny(t *testing.T) {
+	test := newScopeTest("adze", scopedUser("root", []string{bootstrappolicy.ClusterAdminGroup}, "role:view:mallet"), &DefaultAuthorizationAttributes{
+		Verb:     "get",
+		Resource: "pods",
+	})
+	test.expectedAllowed = false
+	test.expectedReason = `User "root" cannot get pods in project "adze"`
+	test.test(t)
+}
+
+func TestScopedRoleNarrowsClusterRules(t *testing.T) {
+	test := newScopeTest("adze", scopedUser("root", []string{bootstrappolicy.ClusterAdminGroup}, "role:view:*"), &DefaultAuthorizationAttributes{
+		Verb:     "get",
+		Resource: "pods",
+	})
+	test.expectedAllowed = true
+	test.expectedReason = "allowed by cluster rule"
+	test.test(t)
+}
+
+func TestScopedRoleEscalatingResourceDeny(t *testing.T) {
+	test := newScopeTest("mallet", scopedUser("Matthew", nil, "role:admin:mallet"), &DefaultAuthorizationAttributes{
+		Verb:     "get",
+		Resource: "secrets",
+	})
+	test.expectedAllowed = false
+	test.expectedReason = `User "Matthew" cannot get secrets in project "mallet"`
+	test.test(t)
+}
+
+func TestScopedRoleEscalatingResourceAllow(t *testing.T) {
+	test := newScopeTest("mallet", scopedUser("Matthew", nil, "role:admin:mallet:!"), &DefaultAuthorizationAttributes{
+		Verb:     "get",
+		Resource: "secrets",
+	})
+	test.expectedAllowed = true
+	test.expectedReason = "allowed by rule in mallet"
+	test.test(t)
+}
+
+func TestScopedUserInfoAllow(t *testing.T) {
+	test := newScopeTest("", scopedUser("Matthew", []string{bootstrappolicy.AuthenticatedGroup}, "user:info"), &DefaultAuthorizationAttributes{
+		Verb:         "get",
+		Resource:     "users",
+		ResourceName: "~",
+	})
+	test.expectedAllowed = true
+	test.expectedReason = "allowed by cluster rule"
+	test.test(t)
+}
+
+func TestScopedUserInfoDeny(t *testing.T) {
+	test := newScopeTest("mallet", scopedUser("Matthew", []string{bootstrappolicy.AuthenticatedGroup}, "user:info"), &DefaultAuthorizationAttributes{
+		Verb:     "list",
+		Resource: "pods",
+	})
+	test.expectedAllowed = false
+	test.expectedReason = `User "Matthew" cannot list pods in project "mallet"`
+	test.test(t)
+}
+
+func TestScopedUserFullAllow(t *testing.T) {
+	test := newScopeTest("mallet", scopedUser("Matthew", nil, "user:full"), &DefaultAuthorizationAttributes{
+		Verb:     "update",
+		Resource: "pods",
+	})
+	test.expectedAllowed = true
+	test.expectedReason = "allowed by rule in mallet"
+	test.test(t)
+}
+
+func TestUnknownScopeDeny(t *testing.T) {
+	test := newScopeTest("mallet", scopedUser("Matthew", nil, "unknown"), &DefaultAuthorizationAttributes{
+		Verb:     "get",
+		Resource: "pods",
+	})
+	test.expectedAllowed = false
+	test.expectedError = `unknown scope "unknown"`
+	test.test(t)
+}
@@ -9,6 +9,7 @@ import (
 	"k8s.io/kubernetes/pkg/auth/user"
 	"k8s.io/kubernetes/pkg/runtime"
 
+	authapi "github.com/openshift/origin/pkg/auth/api"
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	authorizationvalidation "github.com/openshift/origin/pkg/authorization/api/validation"
 	"github.com/openshift/origin/pkg/authorization/authorizer"
@@ -60,10 +61,15 @@ func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 		userToCheck = ctxUser
 
 	} else {
-		userToCheck = &user.DefaultInfo{
+		info := user.DefaultInfo{
 			Name:   subjectAccessReview.User,
 			Groups: subjectAccessReview.Groups.List(),
 		}
+		if len(subjectAccessReview.Scopes) > 0 {
+			userToCheck = &authapi.DefaultScopedUserInfo{DefaultInfo: info, Scopes: subjectAccessReview.Scopes}
+		} else {
+			userToCheck = &info
+		}
 
 	}
 
@@ -0,0 +1,120 @@
+package rulevalidation
+
+import (
+	"reflect"
+	"strings"
+
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+// NarrowRules returns the rules that allow exactly the actions allowed by both a rule in rules and a rule in limits.  It is used
+// to restrict the rules of a user to the rules allowed by the scopes of the token the user authenticated with.
+func NarrowRules(rules, limits []authorizationapi.PolicyRule) []authorizationapi.PolicyRule {
+	narrowed := []authorizationapi.PolicyRule{}
+	for _, rule := range rules {
+		for _, limit := range limits {
+			if intersection, ok := intersectRules(rule, limit); ok {
+				narrowed = append(narrowed, intersection)
+			}
+		}
+	}
+	return narrowed
+}
+
+// intersectRules returns the rule that matches an action if and only if both given rules match it.  It returns false
+// if no action can match both rules.
+func intersectRules(a, b authorizationapi.PolicyRule) (authorizationapi.PolicyRule, bool) {
+	rule := authorizationapi.PolicyRule{}
+
+	rule.Verbs = intersectWithWildcard(a.Verbs, b.Verbs, authorizationapi.VerbAll)
+	if len(rule.Verbs) == 0 {
+		return rule, false
+	}
+
+	switch {
+	case a.AttributeRestrictions == nil:
+		rule.AttributeRestrictions = b.AttributeRestrictions
+	case b.AttributeRestrictions == nil, reflect.TypeOf(a.AttributeRestrictions) == reflect.TypeOf(b.AttributeRestrictions):
+		rule.AttributeRestrictions = a.AttributeRestrictions
+	default:
+		// we can't tell whether different restrictions can be satisfied at the same time
+		return rule, false
+	}
+
+	rule.NonResourceURLs = sets.NewString()
+	for url := range a.NonResourceURLs {
+		if nonResourceURLCovered(b.NonResourceURLs, url) {
+			rule.NonResourceURLs.Insert(url)
+		}
+	}
+	for url := range b.NonResourceURLs {
+		if nonResourceURLCovered(a.NonResourceURLs, url) {
+			rule.NonResourceURLs.Insert(url)
+		}
+	}
+
+	rule.APIGroups = intersectAPIGroups(a.APIGroups, b.APIGroups)
+	rule.Resources = intersectWithWildcard(authorizationapi.NormalizeResources(a.Resources), authorizationapi.NormalizeResources(b.Resources), authorizationapi.ResourceAll)
+	switch {
+	case len(a.ResourceNames) == 0:
+		rule.ResourceNames = b.ResourceNames
+	case len(b.ResourceNames) == 0:
+		rule.ResourceNames = a.ResourceNames
+	default:
+		rule.ResourceNames = a.ResourceNames.Intersection(b.ResourceNames)
+		if len(rule.ResourceNames) == 0 {
+			// an empty list of names would allow every name
+			rule.Resources = sets.NewString()
+		}
+	}
+	if len(rule.APIGroups) == 0 {
+		rule.Resources = sets.NewString()
+	}
+
+	if len(rule.Resources) == 0 && len(rule.NonResourceURLs) == 0 {
+		return rule, false
+	}
+	return rule, true
+}
+
+// intersectWithWildcard returns the values in both a and b, where the wildcard matches every value
+func intersectWithWildcard(a, b sets.String, wildcard string) sets.String {
+	switch {
+	case a.Has(wildcard):
+		return sets.NewString(b.List()...)
+	case b.Has(wildcard):
+		return sets.NewString(a.List()...)
+	}
+	return a.Intersection(b)
+}
+
+// intersectAPIGroups returns the API groups allowed by both a and b.  An empty list of API groups allows the default
+// API group only.
+func intersectAPIGroups(a, b []string) []string {
+	normalize := func(groups []string) sets.String {
+		if len(groups) == 0 {
+			return sets.NewString("")
+		}
+		ret := sets.NewString()
+		for _, group := range groups {
+			ret.Insert(strings.ToLower(group))
+		}
+		return ret
+	}
+	return intersectWithWildcard(normalize(a), normalize(b), authorizationapi.APIGroupAll).List()
+}
+
+// nonResourceURLCovered returns true if every URL matched by url is matched by one of the allowed URLs
+func nonResourceURLCovered(allowed sets.String, url string) bool {
+	for allowedURL := range allowed {
+		if allowedURL == url {
+			return true
+		}
+		if strings.HasSuffix(allowedURL, "*") && strings.HasPrefix(strings.TrimSuffix(url, "*"), allowedURL[:len(allowedURL)-1]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package rulevalidation
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+)
+
+func TestNarrowRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []authorizationapi.PolicyRule
+		limits   []authorizationapi.PolicyRule
+		expected []authorizationapi.PolicyRule
+	}{
+		{
+			name: "wildcards",
+			rules: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("*"), APIGroups: []string{"*"}, Resources: sets.NewString("*")},
+			},
+			limits: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), Resources: sets.NewString("users"), ResourceNames: sets.NewString("~")},
+			},
+			expected: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), APIGroups: []string{""}, Resources: sets.NewString("users"), ResourceNames: sets.NewString("~"), NonResourceURLs: sets.NewString()},
+			},
+		},
+		{
+			name: "verbs and resources",
+			rules: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get", "list", "update"), Resources: sets.NewString("pods", "services")},
+				{Verbs: sets.NewString("delete"), Resources: sets.NewString("pods")},
+			},
+			limits: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get", "delete"), Resources: sets.NewString("pods", "builds")},
+			},
+			expected: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), APIGroups: []string{""}, Resources: sets.NewString("pods"), NonResourceURLs: sets.NewString()},
+				{Verbs: sets.NewString("delete"), APIGroups: []string{""}, Resources: sets.NewString("pods"), NonResourceURLs: sets.NewString()},
+			},
+		},
+		{
+			name: "resource groups",
+			rules: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), Resources: sets.NewString(authorizationapi.BuildGroupName)},
+			},
+			limits: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), Resources: sets.NewString("builds", "pods")},
+			},
+			expected: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), APIGroups: []string{""}, Resources: sets.NewString("builds"), NonResourceURLs: sets.NewString()},
+			},
+		},
+		{
+			name: "disjoint",
+			rules: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), Resources: sets.NewString("pods")},
+				{Verbs: sets.NewString("list"), APIGroups: []string{"extensions"}, Resources: sets.NewString("jobs")},
+				{Verbs: sets.NewString("get"), Resources: sets.NewString("users"), ResourceNames: sets.NewString("other")},
+			},
+			limits: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("list"), Resources: sets.NewString("*")},
+				{Verbs: sets.NewString("get"), Resources: sets.NewString("users"), ResourceNames: sets.NewString("~")},
+			},
+			expected: []authorizationapi.PolicyRule{},
+		},
+		{
+			name: "attribute restrictions",
+			rules: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("create"), Resources: sets.NewString("subjectaccessreviews")},
+			},
+			limits: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("create"), Resources: sets.NewString("subjectaccessreviews"), AttributeRestrictions: &authorizationapi.IsPersonalSubjectAccessReview{}},
+			},
+			expected: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("create"), APIGroups: []string{""}, Resources: sets.NewString("subjectaccessreviews"), AttributeRestrictions: &authorizationapi.IsPersonalSubjectAccessReview{}, NonResourceURLs: sets.NewString()},
+			},
+		},
+		{
+			name: "non resource urls",
+			rules: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), NonResourceURLs: sets.NewString("/healthz/*", "/version")},
+			},
+			limits: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("*"), NonResourceURLs: sets.NewString("/healthz/ready", "/api*")},
+			},
+			expected: []authorizationapi.PolicyRule{
+				{Verbs: sets.NewString("get"), APIGroups: []string{""}, Resources: sets.NewString(), NonResourceURLs: sets.NewString("/healthz/ready")},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		actual := NarrowRules(test.rules, test.limits)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, actual)
+		}
+	}
+}
@@ -21,6 +21,7 @@ type Interface interface {
 	BuildConfigsNamespacer
 	BuildLogsNamespacer
 	ImagesInterfacer
+	ImageSignaturesInterfacer
 	ImageStreamsNamespacer
 	ImageStreamMappingsNamespacer
 	ImageStreamTagsNamespacer
@@ -46,7 +47,9 @@ type Interface interface {
 	LocalSubjectAccessReviewsNamespacer
 	TemplatesNamespacer
 	TemplateConfigsNamespacer
+	TemplateInstancesNamespacer
 	OAuthAccessTokensInterface
+	UserOAuthAccessTokensInterface
 	PoliciesNamespacer
 	PolicyBindingsNamespacer
 	RolesNamespacer
@@ -77,6 +80,11 @@ func (c *Client) Images() ImageInterface {
 	return newImages(c)
 }
 
+// ImageSignatures provides a REST client for ImageSignatures
+func (c *Client) ImageSignatures() ImageSignatureInterface {
+	return newImageSignatures(c)
+}
+
 // ImageStreamImages provides a REST client for retrieving image secrets in a namespace
 func (c *Client) ImageStreamSecrets(namespace string) ImageStreamSecretInterface {
 	return newImageStreamSecrets(c, namespace)
@@ -172,6 +180,11 @@ func (c *Client) Templates(namespace string) TemplateInterface {
 	return newTemplates(c, namespace)
 }
 
+// TemplateInstances provides a REST client for TemplateInstances
+func (c *Client) TemplateInstances(namespace string) TemplateInstanceInterface {
+	return newTemplateInstances(c, namespace)
+}
+
 // Policies provides a REST client for Policies
 func (c *Client) Policies(namespace string) PolicyInterface {
 	return newPolicies(c, namespace)
@@ -227,6 +240,11 @@ func (c *Client) OAuthAccessTokens() OAuthAccessTokenInterface {
 	return newOAuthAccessTokens(c)
 }
 
+// UserOAuthAccessTokens provides a REST client for the UserOAuthAccessTokens of the current user
+func (c *Client) UserOAuthAccessTokens() UserOAuthAccessTokenInterface {
+	return newUserOAuthAccessTokens(c)
+}
+
 func (c *Client) ClusterPolicies() ClusterPolicyInterface {
 	return newClusterPolicies(c)
 }
@@ -139,6 +139,11 @@ func (c *Fake) Images() client.ImageInterface {
 	return &FakeImages{Fake: c}
 }
 
+// ImageSignatures provides a fake REST client for ImageSignatures
+func (c *Fake) ImageSignatures() client.ImageSignatureInterface {
+	return &FakeImageSignatures{Fake: c}
+}
+
 // ImageStreams provides a fake REST client for ImageStreams
 func (c *Fake) ImageStreamSecrets(namespace string) client.ImageStreamSecretInterface {
 	return &FakeImageStreamSecrets{Fake: c, Namespace: namespace}
@@ -199,6 +204,11 @@ func (c *Fake) Templates(namespace string) client.TemplateInterface {
 	return &FakeTemplates{Fake: c, Namespace: namespace}
 }
 
+// TemplateInstances provides a fake REST client for TemplateInstances
+func (c *Fake) TemplateInstances(namespace string) client.TemplateInstanceInterface {
+	return &FakeTemplateInstances{Fake: c, Namespace: namespace}
+}
+
 // TemplateConfigs provides a fake REST client for TemplateConfigs
 func (c *Fake) TemplateConfigs(namespace string) client.TemplateConfigInterface {
 	return &FakeTemplateConfigs{Fake: c, Namespace: namespace}
@@ -279,6 +289,11 @@ func (c *Fake) OAuthAccessTokens() client.OAuthAccessTokenInterface {
 	return &FakeOAuthAccessTokens{Fake: c}
 }
 
+// UserOAuthAccessTokens provides a fake REST client for the UserOAuthAccessTokens of the current user
+func (c *Fake) UserOAuthAccessTokens() client.UserOAuthAccessTokenInterface {
+	return &FakeUserOAuthAccessTokens{Fake: c}
+}
+
 // LocalSubjectAccessReviews provides a fake REST client for SubjectAccessReviews
 func (c *Fake) LocalSubjectAccessReviews(namespace string) client.LocalSubjectAccessReviewInterface {
 	return &FakeLocalSubjectAccessReviews{Fake: c}
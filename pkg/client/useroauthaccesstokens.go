@@ -0,0 +1,54 @@
+package client
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+// UserOAuthAccessTokensInterface has methods to work with the UserOAuthAccessTokens resources of the current user
+type UserOAuthAccessTokensInterface interface {
+	UserOAuthAccessTokens() UserOAuthAccessTokenInterface
+}
+
+// UserOAuthAccessTokenInterface exposes methods on UserOAuthAccessTokens resources.
+type UserOAuthAccessTokenInterface interface {
+	List(opts kapi.ListOptions) (*oauthapi.UserOAuthAccessTokenList, error)
+	Get(name string) (*oauthapi.UserOAuthAccessToken, error)
+	Delete(name string) error
+}
+
+// userOAuthAccessTokens implements UserOAuthAccessTokenInterface interface
+type userOAuthAccessTokens struct {
+	r *Client
+}
+
+// newUserOAuthAccessTokens returns a userOAuthAccessTokens client
+func newUserOAuthAccessTokens(c *Client) *userOAuthAccessTokens {
+	return &userOAuthAccessTokens{
+		r: c,
+	}
+}
+
+// List returns the access tokens of the current user that match the label and field selectors.
+func (c *userOAuthAccessTokens) List(opts kapi.ListOptions) (result *oauthapi.UserOAuthAccessTokenList, err error) {
+	result = &oauthapi.UserOAuthAccessTokenList{}
+	err = c.r.Get().
+		Resource("userOAuthAccessTokens").
+		VersionedParams(&opts, kapi.Scheme).
+		Do().
+		Into(result)
+	return
+}
+
+// Get returns information about a particular access token of the current user or an error
+func (c *userOAuthAccessTokens) Get(name string) (result *oauthapi.UserOAuthAccessToken, err error) {
+	result = &oauthapi.UserOAuthAccessToken{}
+	err = c.r.Get().Resource("userOAuthAccessTokens").Name(name).Do().Into(result)
+	return
+}
+
+// Delete revokes an access token of the current user. Returns an error if one occurs.
+func (c *userOAuthAccessTokens) Delete(name string) (err error) {
+	return c.r.Delete().Resource("userOAuthAccessTokens").Name(name).Do().Error()
+}
@@ -34,6 +34,10 @@ const (
 By default, the prune operation performs a dry run making no changes to internal registry. A
 --confirm flag is needed for changes to be effective.
 
+Image streams with a retention policy keep the number of tag revisions set by their policy instead
+of --keep-tag-revisions, as well as the revisions their policy protects. The server also enforces
+retention policies periodically, leaving the pruned image data for the registry garbage collector.
+
 Only a user with a cluster role %s or higher who is logged-in will be able to actually delete the
 images.`
 
@@ -39,12 +39,13 @@ type DeployOptions struct {
 	deployLatest         bool
 	retryDeploy          bool
 	cancelDeploy         bool
+	continueDeploy       bool
 	enableTriggers       bool
 }
 
 const (
 	deployLong = `
-View, start, cancel, or retry a deployment
+View, start, cancel, continue, or retry a deployment
 
 This command allows you to control a deployment config. Each individual deployment is exposed
 as a new replication controller, and the deployment process manages scaling down old deployments
@@ -66,6 +67,9 @@ never successfully complete - in which case you can use the '--latest' flag to f
 When rolling back to a previous deployment, a new deployment will be created with an identical copy
 of your config at the latest position.
 
+A rolling deployment which sets a pause percentage waits after scaling up that share of the new
+pods until it is continued with the '--continue' flag, or cancelled with the '--cancel' flag.
+
 If no options are given, shows information about the latest deployment.`
 
 	deployExample = `  # Display the latest deployment for the 'database' deployment config
@@ -79,7 +83,10 @@ If no options are given, shows information about the latest deployment.`
   $ %[1]s deploy frontend --retry
 
   # Cancel the in-progress deployment based on 'frontend'
-  $ %[1]s deploy frontend --cancel`
+  $ %[1]s deploy frontend --cancel
+
+  # Continue the paused deployment based on 'frontend'
+  $ %[1]s deploy frontend --continue`
 )
 
 // NewCmdDeploy creates a new `deploy` command.
@@ -89,8 +96,8 @@ func NewCmdDeploy(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.C
 	}
 
 	cmd := &cobra.Command{
-		Use:        "deploy DEPLOYMENTCONFIG [--latest|--retry|--cancel|--enable-triggers]",
-		Short:      "View, start, cancel, or retry a deployment",
+		Use:        "deploy DEPLOYMENTCONFIG [--latest|--retry|--cancel|--continue|--enable-triggers]",
+		Short:      "View, start, cancel, continue, or retry a deployment",
 		Long:       deployLong,
 		Example:    fmt.Sprintf(deployExample, fullName),
 		SuggestFor: []string{"deployment"},
@@ -112,6 +119,7 @@ func NewCmdDeploy(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.C
 	cmd.Flags().BoolVar(&options.deployLatest, "latest", false, "Start a new deployment now.")
 	cmd.Flags().BoolVar(&options.retryDeploy, "retry", false, "Retry the latest failed deployment.")
 	cmd.Flags().BoolVar(&options.cancelDeploy, "cancel", false, "Cancel the in-progress deployment.")
+	cmd.Flags().BoolVar(&options.continueDeploy, "continue", false, "Continue the paused deployment.")
 	cmd.Flags().BoolVar(&options.enableTriggers, "enable-triggers", false, "Enables all image triggers for the deployment config.")
 
 	return cmd
@@ -158,11 +166,14 @@ func (o DeployOptions) Validate() error {
 	if o.cancelDeploy {
 		numOptions++
 	}
+	if o.continueDeploy {
+		numOptions++
+	}
 	if o.enableTriggers {
 		numOptions++
 	}
 	if numOptions > 1 {
-		return errors.New("only one of --latest, --retry, --cancel, or --enable-triggers is allowed.")
+		return errors.New("only one of --latest, --retry, --cancel, --continue, or --enable-triggers is allowed.")
 	}
 	return nil
 }
@@ -189,6 +200,8 @@ func (o DeployOptions) RunDeploy() error {
 		err = o.retry(config, o.out)
 	case o.cancelDeploy:
 		err = o.cancel(config, o.out)
+	case o.continueDeploy:
+		err = o.resume(config, o.out)
 	case o.enableTriggers:
 		err = o.reenableTriggers(config, o.out)
 	default:
@@ -328,6 +341,34 @@ func (o DeployOptions) cancel(config *deployapi.DeploymentConfig, out io.Writer)
 	return nil
 }
 
+// resume continues the latest deployment of config if it is paused. An error
+// is returned if the deployment is not currently paused.
+func (o DeployOptions) resume(config *deployapi.DeploymentConfig, out io.Writer) error {
+	if config.Status.LatestVersion == 0 {
+		return fmt.Errorf("no deployments found for %s/%s", config.Namespace, config.Name)
+	}
+	deploymentName := deployutil.LatestDeploymentNameForConfig(config)
+	deployment, err := o.kubeClient.ReplicationControllers(config.Namespace).Get(deploymentName)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return fmt.Errorf("unable to find the latest deployment (#%d).", config.Status.LatestVersion)
+		}
+		return err
+	}
+
+	if _, paused := deployment.Annotations[deployapi.DeploymentPausedAnnotation]; !paused {
+		status := deployutil.DeploymentStatusFor(deployment)
+		return fmt.Errorf("#%d is %s; only paused deployments can be continued.", config.Status.LatestVersion, strings.ToLower(string(status)))
+	}
+
+	delete(deployment.Annotations, deployapi.DeploymentPausedAnnotation)
+	_, err = o.kubeClient.ReplicationControllers(deployment.Namespace).Update(deployment)
+	if err == nil {
+		fmt.Fprintf(out, "Continued deployment #%d\n", config.Status.LatestVersion)
+	}
+	return err
+}
+
 // reenableTriggers enables all image triggers and then persists config.
 func (o DeployOptions) reenableTriggers(config *deployapi.DeploymentConfig, out io.Writer) error {
 	enabled := []string{}
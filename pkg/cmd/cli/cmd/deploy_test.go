@@ -203,6 +203,47 @@ func TestCmdDeploy_retryRejectNonFailed(t *testing.T) {
 	}
 }
 
+// TestCmdDeploy_continueOk ensures that a paused deployment has its pause
+// annotation removed.
+func TestCmdDeploy_continueOk(t *testing.T) {
+	config := deploytest.OkDeploymentConfig(1)
+	existingDeployment := deploymentFor(config, deployapi.DeploymentStatusRunning)
+	existingDeployment.Annotations[deployapi.DeploymentPausedAnnotation] = "true"
+
+	var updatedDeployment *kapi.ReplicationController
+	kubeClient := &ktc.Fake{}
+	kubeClient.AddReactor("get", "replicationcontrollers", func(action ktc.Action) (handled bool, ret runtime.Object, err error) {
+		return true, existingDeployment, nil
+	})
+	kubeClient.AddReactor("update", "replicationcontrollers", func(action ktc.Action) (handled bool, ret runtime.Object, err error) {
+		updatedDeployment = action.(ktc.UpdateAction).GetObject().(*kapi.ReplicationController)
+		return true, updatedDeployment, nil
+	})
+
+	o := &DeployOptions{kubeClient: kubeClient}
+	if err := o.resume(config, ioutil.Discard); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updatedDeployment == nil {
+		t.Fatalf("expected updated deployment")
+	}
+	if _, paused := updatedDeployment.Annotations[deployapi.DeploymentPausedAnnotation]; paused {
+		t.Fatalf("deployment should not have the paused annotation set anymore")
+	}
+}
+
+// TestCmdDeploy_continueRejectNotPaused ensures that attempts to continue a
+// deployment which isn't paused are rejected.
+func TestCmdDeploy_continueRejectNotPaused(t *testing.T) {
+	config := deploytest.OkDeploymentConfig(1)
+	existingDeployment := deploymentFor(config, deployapi.DeploymentStatusRunning)
+	kubeClient := ktc.NewSimpleFake(existingDeployment)
+	o := &DeployOptions{kubeClient: kubeClient}
+	if err := o.resume(config, ioutil.Discard); err == nil {
+		t.Fatalf("expected an error continuing a deployment which isn't paused")
+	}
+}
+
 // TestCmdDeploy_cancelOk ensures that attempts to cancel deployments
 // for a config result in cancelling all in-progress deployments
 // and none of the completed/faild ones.
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+
+	"github.com/openshift/origin/pkg/cmd/cli/describe"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+)
+
+const (
+	historyLong = `
+View the deployment history of a deployment config
+
+Every deployment of a deployment config is recorded as a numbered revision. Without any
+options, this command lists all revisions along with their status, when they were created,
+and what caused them (a config change or an image change).
+
+Pass '--revision' to view the pod template deployed by a single revision. Pass '--diff' to
+show the field level changes made to the pod template between two revisions; when
+'--revision' is omitted the comparison is made against the latest revision.`
+
+	historyExample = `  # List the deployment history of the 'frontend' deployment config
+  $ %[1]s history frontend
+
+  # View the details of revision 3
+  $ %[1]s history frontend --revision=3
+
+  # Show what changed in the pod template between revision 2 and revision 4
+  $ %[1]s history frontend --revision=4 --diff=2`
+)
+
+// HistoryOptions holds all the options for the `history` command
+type HistoryOptions struct {
+	Namespace            string
+	DeploymentConfigName string
+	Revision             int
+	DiffRevision         int
+
+	out       io.Writer
+	builder   *resource.Builder
+	describer *describe.DeploymentHistoryDescriber
+}
+
+// NewCmdHistory creates a new `history` command.
+func NewCmdHistory(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &HistoryOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "history DEPLOYMENTCONFIG [--revision=N] [--diff=M]",
+		Short:   "View the deployment history of a deployment config",
+		Long:    historyLong,
+		Example: fmt.Sprintf(historyExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args, out); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			if err := options.Validate(); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+
+			if err := options.RunHistory(); err != nil {
+				kcmdutil.CheckErr(err)
+			}
+		},
+	}
+
+	cmd.Flags().IntVar(&options.Revision, "revision", 0, "View the details of this revision.")
+	cmd.Flags().IntVar(&options.DiffRevision, "diff", 0, "Show the pod template changes from this revision to the revision given by --revision, or to the latest revision.")
+
+	return cmd
+}
+
+func (o *HistoryOptions) Complete(f *clientcmd.Factory, args []string, out io.Writer) error {
+	if len(args) > 1 {
+		return errors.New("only one deployment config name is supported as argument.")
+	}
+	if len(args) == 1 {
+		o.DeploymentConfigName = args[0]
+	}
+
+	namespace, _, err := f.DefaultNamespace()
+	if err != nil {
+		return err
+	}
+	o.Namespace = namespace
+
+	oClient, kClient, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.describer = describe.NewDeploymentHistoryDescriber(oClient, kClient)
+
+	mapper, typer := f.Object()
+	o.builder = resource.NewBuilder(mapper, typer, resource.ClientMapperFunc(f.ClientForMapping), kapi.Codecs.UniversalDecoder())
+
+	o.out = out
+	return nil
+}
+
+func (o HistoryOptions) Validate() error {
+	if len(o.DeploymentConfigName) == 0 {
+		return errors.New("a deployment config name is required.")
+	}
+	if o.Revision < 0 {
+		return errors.New("--revision must be a positive number.")
+	}
+	if o.DiffRevision < 0 {
+		return errors.New("--diff must be a positive number.")
+	}
+	return nil
+}
+
+func (o HistoryOptions) RunHistory() error {
+	resultObj, err := o.builder.
+		NamespaceParam(o.Namespace).
+		ResourceNames("deploymentconfigs", o.DeploymentConfigName).
+		SingleResourceType().
+		Do().
+		Object()
+	if err != nil {
+		return err
+	}
+	config, ok := resultObj.(*deployapi.DeploymentConfig)
+	if !ok {
+		return fmt.Errorf("%s is not a valid deployment config", o.DeploymentConfigName)
+	}
+
+	var desc string
+	switch {
+	case o.DiffRevision > 0:
+		to := o.Revision
+		if to == 0 {
+			to = config.Status.LatestVersion
+		}
+		desc, err = o.describer.DescribeDiff(config.Namespace, config.Name, o.DiffRevision, to)
+	case o.Revision > 0:
+		desc, err = o.describer.DescribeRevision(config.Namespace, config.Name, o.Revision)
+	default:
+		desc, err = o.describer.Describe(config.Namespace, config.Name)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(o.out, desc)
+	return nil
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/docker/distribution/digest"
+	"github.com/golang/glog"
+	"github.com/spf13/cobra"
+	gocontext "golang.org/x/net/context"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/importer"
+)
+
+// MirrorImageOptions contains all the necessary options for the cli mirror-image command.
+type MirrorImageOptions struct {
+	out      io.Writer
+	osClient client.Interface
+
+	insecure  bool
+	updateTag string
+	namespace string
+
+	source      imageapi.DockerImageReference
+	destination imageapi.DockerImageReference
+
+	// mirrorFn copies the source image to the destination and returns the digest of its manifest.
+	mirrorFn func(source, destination imageapi.DockerImageReference) (digest.Digest, error)
+}
+
+const (
+	mirrorImageLong = `
+Copy an image from one Docker registry to another
+
+The mirror-image command copies the manifest of an image and the layers it references
+directly between two Docker registries, without pulling the image to a Docker daemon.
+Layers that already exist in the destination repository are not copied again, and when
+both repositories are served by the same registry the layers are mounted from the source
+repository if the registry supports it. Multi-platform images are copied along with all
+of the images they reference.
+
+Credentials for the registries are read from your Docker configuration, as written by
+'docker login'. To push to the integrated registry, log in with your OpenShift token.
+Pass --insecure if one of the registries does not have a valid HTTPS certificate, or is
+only served over HTTP.
+
+Pass --update-tag to point a tag of an image stream at the mirrored image once the copy
+completes.`
+
+	mirrorImageExample = `  # Copy an image from the integrated registry to an external registry.
+  $ %[1]s mirror-image 172.30.1.1:5000/myproject/ruby:latest registry.example.com/team/ruby:latest
+
+  # Copy an image by digest and point the tag 'ruby:stable' of the current project at the copy.
+  $ %[1]s mirror-image registry.example.com/team/ruby@sha256:3f9e... registry.prod.example.com/team/ruby:1.0 --update-tag=ruby:stable`
+)
+
+// NewCmdMirrorImage implements the OpenShift cli mirror-image command.
+func NewCmdMirrorImage(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	opts := &MirrorImageOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "mirror-image SOURCE DESTINATION",
+		Short:   "Copy an image between Docker registries",
+		Long:    mirrorImageLong,
+		Example: fmt.Sprintf(mirrorImageExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(opts.Complete(f, cmd, args, out))
+			kcmdutil.CheckErr(opts.Validate())
+			kcmdutil.CheckErr(opts.RunMirrorImage())
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.insecure, "insecure", opts.insecure, "If true, allow accessing registries that have invalid HTTPS certificates or are hosted via HTTP")
+	cmd.Flags().StringVar(&opts.updateTag, "update-tag", opts.updateTag, "An image stream tag, in the form [NAMESPACE/]NAME:TAG, to point at the mirrored image")
+
+	return cmd
+}
+
+// Complete completes all the required options for the mirror-image command.
+func (o *MirrorImageOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command, args []string, out io.Writer) error {
+	if len(args) != 2 {
+		return kcmdutil.UsageError(cmd, "you must specify a source and a destination image")
+	}
+	o.out = out
+
+	source, err := imageapi.ParseDockerImageReference(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid SOURCE: %v", err)
+	}
+	destination, err := imageapi.ParseDockerImageReference(args[1])
+	if err != nil {
+		return fmt.Errorf("invalid DESTINATION: %v", err)
+	}
+	if len(source.Tag) == 0 && len(source.ID) == 0 {
+		source.Tag = imageapi.DefaultImageTag
+	}
+	if len(destination.Tag) == 0 && len(destination.ID) == 0 {
+		// an image copied by digest is only tagged when a destination tag is given
+		destination.Tag = source.Tag
+	}
+	o.source = source
+	o.destination = destination
+
+	if len(o.updateTag) > 0 {
+		o.osClient, _, err = f.Clients()
+		if err != nil {
+			return err
+		}
+		o.namespace, _, err = f.DefaultNamespace()
+		if err != nil {
+			return err
+		}
+	}
+
+	o.mirrorFn = o.mirror
+	return nil
+}
+
+// Validate validates all the required options for the mirror-image command.
+func (o MirrorImageOptions) Validate() error {
+	if o.out == nil {
+		return errors.New("a writer interface is required")
+	}
+	if len(o.destination.ID) > 0 {
+		return errors.New("DESTINATION may not reference an image by digest")
+	}
+	if o.source.DockerClientDefaults().Equal(o.destination.DockerClientDefaults()) {
+		return errors.New("SOURCE and DESTINATION must be different")
+	}
+	if len(o.updateTag) > 0 {
+		if o.osClient == nil {
+			return errors.New("a client is required to update an image stream tag")
+		}
+		_, nameAndTag, err := parseStreamName(o.namespace, o.updateTag)
+		if err != nil {
+			return err
+		}
+		if _, _, ok := imageapi.SplitImageStreamTag(nameAndTag); !ok {
+			return fmt.Errorf("--update-tag must be of the form [NAMESPACE/]NAME:TAG")
+		}
+	}
+	if o.mirrorFn == nil {
+		return errors.New("a mirror function is required")
+	}
+	return nil
+}
+
+// RunMirrorImage contains all the necessary functionality for the OpenShift cli mirror-image command.
+func (o MirrorImageOptions) RunMirrorImage() error {
+	dgst, err := o.mirrorFn(o.source, o.destination)
+	if err != nil {
+		return err
+	}
+	mirrored := o.destination
+	mirrored.Tag, mirrored.ID = "", dgst.String()
+	fmt.Fprintf(o.out, "Mirrored %s to %s\n", o.source.Exact(), mirrored.Exact())
+
+	if len(o.updateTag) == 0 {
+		return nil
+	}
+	destNamespace, destNameAndTag, err := parseStreamName(o.namespace, o.updateTag)
+	if err != nil {
+		return err
+	}
+	tag := TagOptions{
+		out:            o.out,
+		osClient:       o.osClient,
+		insecureTag:    o.insecure,
+		namespace:      o.namespace,
+		ref:            mirrored,
+		sourceKind:     "DockerImage",
+		destNamespace:  []string{destNamespace},
+		destNameAndTag: []string{destNameAndTag},
+	}
+	return tag.RunTag()
+}
+
+// mirror copies the source image to the destination through the Docker registry API, using the
+// credentials of the local Docker configuration.
+func (o MirrorImageOptions) mirror(source, destination imageapi.DockerImageReference) (digest.Digest, error) {
+	insecureTransport, err := kclient.TransportFor(&kclient.Config{Insecure: true})
+	if err != nil {
+		return "", err
+	}
+	context := importer.NewContext(http.DefaultTransport, insecureTransport)
+	credentials := importer.NewLocalCredentials()
+
+	ctx := gocontext.Background()
+	source = source.DockerClientDefaults()
+	destination = destination.DockerClientDefaults()
+	src, err := context.WithCredentials(credentials).Repository(ctx, source.RegistryURL(), source.RepositoryName(), o.insecure)
+	if err != nil {
+		return "", fmt.Errorf("unable to access %s: %v", source.Exact(), err)
+	}
+	dst, err := context.WithActions("pull", "push").WithCredentials(credentials).Repository(ctx, destination.RegistryURL(), destination.RepositoryName(), o.insecure)
+	if err != nil {
+		return "", fmt.Errorf("unable to access %s: %v", destination.Exact(), err)
+	}
+
+	reference := source.Tag
+	if len(source.ID) > 0 {
+		reference = source.ID
+	}
+	mountFrom := ""
+	if source.Registry == destination.Registry {
+		mountFrom = source.RepositoryName()
+	}
+	glog.V(3).Infof("Mirroring %s to %s", source.Exact(), destination.Exact())
+	return importer.Mirror(ctx, src, dst, reference, destination.Tag, mountFrom)
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/docker/distribution/digest"
+	ktc "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestRunMirrorImage(t *testing.T) {
+	source := imageapi.DockerImageReference{Registry: "172.30.1.1:5000", Namespace: "myproject", Name: "ruby", Tag: "latest"}
+	destination := imageapi.DockerImageReference{Registry: "registry.example.com", Namespace: "team", Name: "ruby", Tag: "latest"}
+	dgst := digest.Digest("sha256:3f9e4c8c0b5c6a5bca1da5ba84fa9baf8e3a5e4ec6f7bd78a2d1f0bba5ad8f5a")
+
+	streams := testData()
+	client := testclient.NewSimpleFake(streams[0])
+	out := &bytes.Buffer{}
+	opts := &MirrorImageOptions{
+		out:         out,
+		osClient:    client,
+		namespace:   "myproject",
+		updateTag:   "yourproject/rails:tip",
+		source:      source,
+		destination: destination,
+		mirrorFn: func(src, dst imageapi.DockerImageReference) (digest.Digest, error) {
+			if src != source || dst != destination {
+				t.Errorf("unexpected references: %#v %#v", src, dst)
+			}
+			return dgst, nil
+		},
+	}
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if err := opts.RunMirrorImage(); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "Mirrored 172.30.1.1:5000/myproject/ruby:latest to registry.example.com/team/ruby@" + dgst.String() + "\n"; !strings.HasPrefix(out.String(), expected) {
+		t.Errorf("unexpected output: %s", out.String())
+	}
+	actions := client.Actions()
+	if len(actions) != 2 || !actions[1].Matches("update", "imagestreams") {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+	stream := actions[1].(ktc.UpdateAction).GetObject().(*imageapi.ImageStream)
+	from := stream.Spec.Tags["tip"].From
+	if from == nil || from.Kind != "DockerImage" || from.Name != "registry.example.com/team/ruby@"+dgst.String() {
+		t.Errorf("unexpected tag reference: %#v", from)
+	}
+}
+
+func TestValidateMirrorImage(t *testing.T) {
+	source := imageapi.DockerImageReference{Namespace: "library", Name: "ruby", Tag: "latest"}
+	mirrorFn := func(src, dst imageapi.DockerImageReference) (digest.Digest, error) { return "", nil }
+
+	tests := []struct {
+		name        string
+		destination imageapi.DockerImageReference
+		updateTag   string
+		expectErr   bool
+	}{
+		{
+			name:        "valid",
+			destination: imageapi.DockerImageReference{Registry: "registry.example.com", Name: "ruby", Tag: "latest"},
+			updateTag:   "ruby:latest",
+		},
+		{
+			name:        "same image",
+			destination: imageapi.DockerImageReference{Registry: "docker.io", Namespace: "library", Name: "ruby", Tag: "latest"},
+			expectErr:   true,
+		},
+		{
+			name:        "destination digest",
+			destination: imageapi.DockerImageReference{Registry: "registry.example.com", Name: "ruby", ID: "sha256:3f9e"},
+			expectErr:   true,
+		},
+		{
+			name:        "update tag without a tag",
+			destination: imageapi.DockerImageReference{Registry: "registry.example.com", Name: "ruby", Tag: "latest"},
+			updateTag:   "ruby",
+			expectErr:   true,
+		},
+	}
+
+	for _, test := range tests {
+		opts := &MirrorImageOptions{
+			out:         &bytes.Buffer{},
+			osClient:    &testclient.Fake{},
+			namespace:   "myproject",
+			updateTag:   test.updateTag,
+			source:      source,
+			destination: test.destination,
+			mirrorFn:    mirrorFn,
+		}
+		err := opts.Validate()
+		if test.expectErr != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+	}
+}
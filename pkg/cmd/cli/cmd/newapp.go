@@ -36,6 +36,7 @@ import (
 	newapp "github.com/openshift/origin/pkg/generate/app"
 	newcmd "github.com/openshift/origin/pkg/generate/app/cmd"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	templatevalidation "github.com/openshift/origin/pkg/template/api/validation"
 	"github.com/openshift/origin/pkg/util"
 )
 
@@ -58,6 +59,11 @@ configuration, and a service will be connected to the first public port of the a
 components using the various existing flags or let new-app autodetect what kind of components
 you have provided.
 
+A docker-compose file (named docker-compose*.yml) may be provided to create a multi-container
+application. Every service in the file becomes a deployment configuration, a build configuration
+when the service is built from a directory in a git repository, and a service when it exposes
+ports. Keys of the compose file that can't be translated are reported as warnings.
+
 If you provide source code, a new build will be automatically triggered.
 You can use '%[1]s status' to check the progress.`
 
@@ -93,6 +99,12 @@ You can use '%[1]s status' to check the progress.`
   # Create an application based on a template file, explicitly setting a parameter value
   $ %[1]s new-app --file=./example/myapp/template.json --param=MYSQL_USER=admin
 
+  # Print a reusable template named "ruby-app" instead of creating the application
+  $ %[1]s new-app centos/ruby-22-centos7~https://github.com/openshift/ruby-hello-world.git --as-template=ruby-app
+
+  # Create an application from the services defined in a docker-compose file
+  $ %[1]s new-app ./docker-compose.yml
+
   # Search for "mysql" in all image repositories and stored templates
   $ %[1]s new-app --search mysql
 
@@ -155,6 +167,7 @@ func NewCmdNewApplication(fullName string, f *clientcmd.Factory, out io.Writer)
 	cmd.Flags().StringSliceVarP(&config.Environment, "env", "e", config.Environment, "Specify key value pairs of environment variables to set into each container.")
 	cmd.Flags().StringVar(&config.Name, "name", "", "Set name to use for generated application artifacts")
 	cmd.Flags().StringVar(&config.Strategy, "strategy", "", "Specify the build strategy to use if you don't want to detect (docker|source).")
+	cmd.Flags().String("detector-rules", os.Getenv("OPENSHIFT_DETECTOR_RULES"), "Path to a file of rules detecting additional source platforms. Defaults to the value of the OPENSHIFT_DETECTOR_RULES environment variable.")
 	cmd.Flags().StringP("labels", "l", "", "Label to set in all resources for this application.")
 	cmd.Flags().BoolVar(&config.InsecureRegistry, "insecure-registry", false, "If true, indicates that the referenced Docker images are on insecure registries and should bypass certificate checking")
 	cmd.Flags().BoolVarP(&config.AsList, "list", "L", false, "List all local templates and image streams that can be used to create.")
@@ -163,6 +176,7 @@ func NewCmdNewApplication(fullName string, f *clientcmd.Factory, out io.Writer)
 	cmd.Flags().BoolVar(&config.AllowSecretUse, "grant-install-rights", false, "If true, a component that requires access to your account may use your token to install software into your project. Only grant images you trust the right to run with your token.")
 	cmd.Flags().BoolVar(&config.SkipGeneration, "no-install", false, "Do not attempt to run images that describe themselves as being installable")
 	cmd.Flags().BoolVar(&config.DryRun, "dry-run", false, "If true, do not actually create resources.")
+	cmd.Flags().String("as-template", "", "If set, output a template with the given name that creates the application instead of creating it. Detected values like names, source and image locations and environment become template parameters.")
 
 	// TODO AddPrinterFlags disabled so that it doesn't conflict with our own "template" flag.
 	// Need a better solution.
@@ -178,6 +192,19 @@ func NewCmdNewApplication(fullName string, f *clientcmd.Factory, out io.Writer)
 // RunNewApplication contains all the necessary functionality for the OpenShift cli new-app command
 func RunNewApplication(fullName string, f *clientcmd.Factory, out io.Writer, c *cobra.Command, args []string, config *newcmd.AppConfig) error {
 	output := kcmdutil.GetFlagString(c, "output")
+	asTemplate := kcmdutil.GetFlagString(c, "as-template")
+	if len(asTemplate) > 0 {
+		switch {
+		case config.Querying():
+			return kcmdutil.UsageError(c, "--as-template can't be used with --list or --search")
+		case output == "name":
+			return kcmdutil.UsageError(c, "--as-template can't be used with --output=name")
+		case len(output) == 0:
+			// use YAML as the default format
+			output = "yaml"
+			c.Flags().Set("output", output)
+		}
+	}
 	shortOutput := output == "name"
 
 	if err := setupAppConfig(f, out, c, args, config); err != nil {
@@ -213,6 +240,10 @@ func RunNewApplication(fullName string, f *clientcmd.Factory, out io.Writer, c *
 		config.Labels = map[string]string{"app": result.Name}
 	}
 
+	if len(asTemplate) > 0 {
+		return printAsTemplate(f, c, out, asTemplate, config.Labels, result)
+	}
+
 	if err := setLabels(config.Labels, result); err != nil {
 		return err
 	}
@@ -444,6 +475,12 @@ func setupAppConfig(f *clientcmd.Factory, out io.Writer, c *cobra.Command, args
 	config.KubeClient = kclient
 	config.SetOpenShiftClient(osclient, namespace)
 
+	if rules := kcmdutil.GetFlagString(c, "detector-rules"); len(rules) > 0 {
+		if err := config.AddDetectorRules(rules); err != nil {
+			return err
+		}
+	}
+
 	// Only output="" should print descriptions of intermediate steps. Everything
 	// else should print only some specific output (json, yaml, go-template, ...)
 	output := kcmdutil.GetFlagString(c, "output")
@@ -480,6 +517,29 @@ func setupAppConfig(f *clientcmd.Factory, out io.Writer, c *cobra.Command, args
 	return nil
 }
 
+// printAsTemplate prints the objects of the result wrapped in a template named
+// name. The labels are applied by the template when it is processed.
+func printAsTemplate(f *clientcmd.Factory, c *cobra.Command, out io.Writer, name string, labels map[string]string, result *newcmd.AppResult) error {
+	if result.GeneratedJobs {
+		return fmt.Errorf("--as-template can't be used with images that install an application")
+	}
+	if err := setAnnotations(map[string]string{newcmd.GeneratedByNamespace: newcmd.GeneratedByNewApp}, result); err != nil {
+		return err
+	}
+	template, err := newapp.TemplateFromObjects(name, result.List.Items, labels)
+	if err != nil {
+		return err
+	}
+	if errs := templatevalidation.ValidateTemplate(template); len(errs) > 0 {
+		return fmt.Errorf("the generated template is invalid: %v", errs.ToAggregate())
+	}
+	template.Objects, err = ocmdutil.ConvertItemsForDisplayFromDefaultCommand(c, template.Objects)
+	if err != nil {
+		return err
+	}
+	return f.Factory.PrintObject(c, template, out)
+}
+
 func setAnnotations(annotations map[string]string, result *newcmd.AppResult) error {
 	for _, object := range result.List.Items {
 		err := util.AddObjectAnnotations(object, annotations)
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"reflect"
@@ -14,12 +15,16 @@ import (
 	"k8s.io/kubernetes/pkg/kubectl"
 	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
 	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/cmd/cli/describe"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 	"github.com/openshift/origin/pkg/template"
 	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/util/jsonmerge"
 )
 
 const (
@@ -31,7 +36,13 @@ update. Templates have "parameters", which may either be generated on creation o
 as well as metadata describing the template.
 
 The output of the process command is always a list of one or more resources. You may pipe the
-output to the create command over STDIN (using the '-f -' option) or redirect it to a file.`
+output to the create command over STDIN (using the '-f -' option) or redirect it to a file.
+
+With --diff, the processed resources are compared with the resources of the project instead of
+being printed. The fields that differ are shown for each existing resource, along with the resources
+that would be created and the resources labeled with the template labels that the template no
+longer contains. The fields set from parameters generated while processing are not compared,
+since their values are different every time the template is processed.`
 
 	processExample = `  # Convert template.json file into resource list and pass to create
   $ %[1]s process -f template.json | %[1]s create -f -
@@ -52,7 +63,10 @@ output to the create command over STDIN (using the '-f -' option) or redirect it
   $ cat template.json | %[1]s process -f -
 
   # Combine multiple templates into single resource list
-  $ cat template.json second_template.json | %[1]s process -f -`
+  $ cat template.json second_template.json | %[1]s process -f -
+
+  # Compare a newer version of a template with the resources it created in the project
+  $ %[1]s process -f template.json -l app=foo --diff`
 )
 
 // NewCmdProcess implements the OpenShift cli process command
@@ -71,6 +85,7 @@ func NewCmdProcess(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.
 	cmd.Flags().StringSliceP("value", "v", nil, "Specify a list of key-value pairs (eg. -v FOO=BAR,BAR=FOO) to set/override parameter values")
 	cmd.Flags().BoolP("parameters", "", false, "Do not process but only print available parameters")
 	cmd.Flags().StringP("labels", "l", "", "Label to set in all resources for this template")
+	cmd.Flags().Bool("diff", false, "If true, compare the processed resources with the resources of the project instead of printing them")
 
 	cmd.Flags().StringP("output", "o", "json", "Output format. One of: describe|json|yaml|name|template|templatefile.")
 	cmd.Flags().Bool("raw", false, "If true output the processed template instead of the template's objects. Implied by -o describe")
@@ -109,6 +124,14 @@ func RunProcess(f *clientcmd.Factory, out io.Writer, cmd *cobra.Command, args []
 			}
 		}
 	}
+	diff := kcmdutil.GetFlagBool(cmd, "diff")
+	if diff {
+		for _, flag := range []string{"parameters", "output", "output-version", "raw", "template"} {
+			if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
+				return kcmdutil.UsageError(cmd, "The --diff flag compares the processed template with the project, can't be used with --%v", flag)
+			}
+		}
+	}
 
 	namespace, explicit, err := f.DefaultNamespace()
 	if err != nil {
@@ -226,6 +249,16 @@ func RunProcess(f *clientcmd.Factory, out io.Writer, cmd *cobra.Command, args []
 			continue
 		}
 
+		if diff {
+			if len(infos) > 1 {
+				fmt.Fprintf(out, "\n%s:\n", obj.Name)
+			}
+			if err := diffTemplateObjects(f, out, namespace, resultObj, generatedValues(obj, resultObj)); err != nil {
+				fmt.Fprintf(cmd.Out(), "error comparing the template %q with the project: %v\n", obj.Name, err)
+			}
+			continue
+		}
+
 		if outputFormat == "describe" {
 			if s, err := (&describe.TemplateDescriber{
 				MetadataAccessor: meta.NewAccessor(),
@@ -241,9 +274,9 @@ func RunProcess(f *clientcmd.Factory, out io.Writer, cmd *cobra.Command, args []
 		objects = append(objects, resultObj.Objects...)
 	}
 
-	// Do not print the processed templates when asked to only show parameters or
-	// describe.
-	if kcmdutil.GetFlagBool(cmd, "parameters") || outputFormat == "describe" {
+	// Do not print the processed templates when asked to only show parameters,
+	// describe or diff.
+	if kcmdutil.GetFlagBool(cmd, "parameters") || outputFormat == "describe" || diff {
 		return nil
 	}
 
@@ -289,3 +322,197 @@ func injectUserVars(values []string, out io.Writer, t *api.Template) {
 		}
 	}
 }
+
+// generatedValues returns the values generated for the parameters of the processed template that
+// had no value in the template.
+func generatedValues(t, processed *api.Template) []string {
+	values := []string{}
+	for _, param := range processed.Parameters {
+		if len(param.Generate) == 0 || len(param.Value) == 0 {
+			continue
+		}
+		if original := template.GetParameterByName(t, param.Name); original != nil && len(original.Value) > 0 {
+			continue
+		}
+		values = append(values, param.Value)
+	}
+	return values
+}
+
+// diffTemplateObjects compares the objects of a processed template with the objects of the
+// namespace. It prints the fields that differ for each object that exists, except the fields set
+// to generated values, the objects that would be created, and the objects labeled with the template
+// object labels that the template no longer contains, looking only for the kinds of objects the
+// template contains.
+func diffTemplateObjects(f *clientcmd.Factory, out io.Writer, namespace string, template *api.Template, generated []string) error {
+	if errs := runtime.DecodeList(template.Objects, kapi.Codecs.UniversalDecoder()); len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+	mapper, typer := f.Object()
+	exporter := &defaultExporter{}
+
+	mappings := []*meta.RESTMapping{}
+	names := map[unversioned.GroupKind]sets.String{}
+	for _, obj := range template.Objects {
+		gvk, err := typer.ObjectKind(obj)
+		if err != nil {
+			return err
+		}
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return err
+		}
+		name, err := mapping.MetadataAccessor.Name(obj)
+		if err != nil {
+			return err
+		}
+		kind := mapping.GroupVersionKind.Kind
+		if _, ok := names[gvk.GroupKind()]; !ok {
+			names[gvk.GroupKind()] = sets.NewString()
+			mappings = append(mappings, mapping)
+		}
+		if len(name) == 0 {
+			fmt.Fprintf(out, "%s with a generated name would be created\n", kind)
+			continue
+		}
+		names[gvk.GroupKind()].Insert(name)
+
+		client, err := f.ClientForMapping(mapping)
+		if err != nil {
+			return err
+		}
+		live, err := resource.NewHelper(client, mapping).Get(namespace, name, false)
+		if errors.IsNotFound(err) {
+			fmt.Fprintf(out, "%s %q would be created\n", kind, name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		changes, err := objectChanges(exporter, mapping, live, obj)
+		if err != nil {
+			return err
+		}
+		changes = withoutGeneratedValues(changes, generated)
+		if len(changes) == 0 {
+			fmt.Fprintf(out, "%s %q is unchanged\n", kind, name)
+			continue
+		}
+		fmt.Fprintf(out, "%s %q would be updated:\n", kind, name)
+		for _, change := range changes {
+			fmt.Fprintf(out, "  %s\n", change)
+		}
+	}
+
+	if len(template.ObjectLabels) == 0 {
+		return nil
+	}
+	selector := labels.SelectorFromSet(template.ObjectLabels)
+	for _, mapping := range mappings {
+		client, err := f.ClientForMapping(mapping)
+		if err != nil {
+			return err
+		}
+		list, err := resource.NewHelper(client, mapping).List(namespace, mapping.GroupVersionKind.GroupVersion().String(), selector, false)
+		if err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			name, err := mapping.MetadataAccessor.Name(item)
+			if err != nil {
+				return err
+			}
+			if !names[mapping.GroupVersionKind.GroupKind()].Has(name) {
+				fmt.Fprintf(out, "%s %q would be orphaned\n", mapping.GroupVersionKind.Kind, name)
+			}
+		}
+	}
+	return nil
+}
+
+// objectChanges returns the fields of the live object that differ in the processed object, once
+// both are exported. The processed object is defaulted first, like the server does when it is
+// created, so the fields a template leaves to their default are not reported as removed.
+func objectChanges(exporter Exporter, mapping *meta.RESTMapping, live, processed runtime.Object) ([]jsonmerge.Change, error) {
+	defaulted, err := withDefaults(mapping, processed)
+	if err != nil {
+		return nil, err
+	}
+	from, err := diffableJSON(exporter, mapping, live)
+	if err != nil {
+		return nil, err
+	}
+	to, err := diffableJSON(exporter, mapping, defaulted)
+	if err != nil {
+		return nil, err
+	}
+	return jsonmerge.Changes(from, to)
+}
+
+// withoutGeneratedValues returns the changes of fields whose new value doesn't contain any of the
+// generated values. Those fields were set to other values generated when the live object was
+// created, and would show as changed every time.
+func withoutGeneratedValues(changes []jsonmerge.Change, generated []string) []jsonmerge.Change {
+	if len(generated) == 0 {
+		return changes
+	}
+	filtered := []jsonmerge.Change{}
+	for _, change := range changes {
+		if change.Operation == jsonmerge.Changed && containsAny(fmt.Sprintf("%v", change.To), generated) {
+			continue
+		}
+		filtered = append(filtered, change)
+	}
+	return filtered
+}
+
+// containsAny returns true if s contains any of the values.
+func containsAny(s string, values []string) bool {
+	for _, value := range values {
+		if strings.Contains(s, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// withDefaults returns a copy of obj with the defaults of its API version set, by converting it to
+// that version and back.
+func withDefaults(mapping *meta.RESTMapping, obj runtime.Object) (runtime.Object, error) {
+	data, err := runtime.Encode(kapi.Codecs.LegacyCodec(mapping.GroupVersionKind.GroupVersion()), obj)
+	if err != nil {
+		return nil, err
+	}
+	return runtime.Decode(kapi.Codecs.UniversalDecoder(), data)
+}
+
+// diffableJSON exports obj and encodes it without its status and the metadata set by the server,
+// which a template does not control.
+func diffableJSON(exporter Exporter, mapping *meta.RESTMapping, obj runtime.Object) ([]byte, error) {
+	if err := exporter.Export(obj, false); err != nil && err != ErrExportOmit {
+		return nil, err
+	}
+	data, err := runtime.Encode(kapi.Codecs.LegacyCodec(mapping.GroupVersionKind.GroupVersion()), obj)
+	if err != nil {
+		return nil, err
+	}
+	object := map[string]interface{}{}
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	delete(object, "status")
+	if metadata, ok := object["metadata"].(map[string]interface{}); ok {
+		for key := range metadata {
+			switch key {
+			case "name", "labels", "annotations":
+			default:
+				delete(metadata, key)
+			}
+		}
+	}
+	return json.Marshal(object)
+}
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apimachinery/registered"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/intstr"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	templateapi "github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/util/jsonmerge"
+)
+
+func TestObjectChanges(t *testing.T) {
+	serviceMapping, err := registered.GroupOrDie(kapi.GroupName).RESTMapper.RESTMapping(kapi.Kind("Service"), "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	configMapping, err := registered.GroupOrDie(kapi.GroupName).RESTMapper.RESTMapping(kapi.Kind("DeploymentConfig"), "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a live object as created by the server from a template, with its defaults and server metadata
+	serverCreated := func(mapping *meta.RESTMapping, obj runtime.Object) runtime.Object {
+		created, err := withDefaults(mapping, obj)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objectMeta, err := kapi.ObjectMetaFor(created)
+		if err != nil {
+			t.Fatal(err)
+		}
+		objectMeta.Namespace = "test"
+		objectMeta.ResourceVersion = "10"
+		objectMeta.UID = "uid"
+		objectMeta.CreationTimestamp = unversioned.Now()
+		return created
+	}
+
+	config := func(replicas int, image string) *deployapi.DeploymentConfig {
+		return &deployapi.DeploymentConfig{
+			ObjectMeta: kapi.ObjectMeta{Name: "web"},
+			Spec: deployapi.DeploymentConfigSpec{
+				Replicas: replicas,
+				Selector: map[string]string{"name": "web"},
+				Template: &kapi.PodTemplateSpec{
+					ObjectMeta: kapi.ObjectMeta{Labels: map[string]string{"name": "web"}},
+					Spec: kapi.PodSpec{
+						Containers: []kapi.Container{{Name: "web", Image: image}},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		mapping   *meta.RESTMapping
+		live      runtime.Object
+		processed runtime.Object
+		expected  []jsonmerge.Change
+	}{
+		{
+			name:    "service",
+			mapping: serviceMapping,
+			live: &kapi.Service{
+				ObjectMeta: kapi.ObjectMeta{
+					Name:              "web",
+					Namespace:         "test",
+					ResourceVersion:   "10",
+					UID:               "uid",
+					CreationTimestamp: unversioned.Now(),
+					Labels:            map[string]string{"template": "web", "tier": "frontend"},
+				},
+				Spec: kapi.ServiceSpec{
+					Type:            kapi.ServiceTypeClusterIP,
+					ClusterIP:       "172.30.0.1",
+					SessionAffinity: kapi.ServiceAffinityNone,
+					Ports:           []kapi.ServicePort{{Name: "http", Protocol: kapi.ProtocolTCP, Port: 80, TargetPort: intstr.FromInt(80)}},
+				},
+				Status: kapi.ServiceStatus{LoadBalancer: kapi.LoadBalancerStatus{Ingress: []kapi.LoadBalancerIngress{{IP: "1.2.3.4"}}}},
+			},
+			processed: &kapi.Service{
+				ObjectMeta: kapi.ObjectMeta{
+					Name:   "web",
+					Labels: map[string]string{"template": "web", "version": "2"},
+				},
+				Spec: kapi.ServiceSpec{
+					Ports: []kapi.ServicePort{{Name: "http", Port: 8080}},
+				},
+			},
+			expected: []jsonmerge.Change{
+				{Operation: jsonmerge.Removed, Path: "metadata.labels.tier", From: "frontend"},
+				{Operation: jsonmerge.Added, Path: "metadata.labels.version", To: "2"},
+				{Operation: jsonmerge.Changed, Path: "spec.ports[name=http].port", From: float64(80), To: float64(8080)},
+				{Operation: jsonmerge.Changed, Path: "spec.ports[name=http].targetPort", From: float64(80), To: float64(8080)},
+			},
+		},
+		{
+			name:      "unchanged deployment config",
+			mapping:   configMapping,
+			live:      serverCreated(configMapping, config(1, "web:1")),
+			processed: config(1, "web:1"),
+		},
+		{
+			name:      "updated deployment config",
+			mapping:   configMapping,
+			live:      serverCreated(configMapping, config(1, "web:1")),
+			processed: config(2, "web:2"),
+			expected: []jsonmerge.Change{
+				{Operation: jsonmerge.Changed, Path: "spec.replicas", From: float64(1), To: float64(2)},
+				{Operation: jsonmerge.Changed, Path: "spec.template.spec.containers[name=web].image", From: "web:1", To: "web:2"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		changes, err := objectChanges(&defaultExporter{}, tc.mapping, tc.live, tc.processed)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if len(tc.expected) == 0 && len(changes) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(tc.expected, changes) {
+			t.Errorf("%s: unexpected changes: %v", tc.name, changes)
+		}
+	}
+}
+
+func TestWithoutGeneratedValues(t *testing.T) {
+	template := &templateapi.Template{
+		Parameters: []templateapi.Parameter{
+			{Name: "PASSWORD", Generate: "expression", From: "[a-z]{8}"},
+			{Name: "SECRET", Generate: "expression", From: "[a-z]{8}", Value: "provided"},
+			{Name: "IMAGE", Value: "web:2"},
+		},
+	}
+	processed := &templateapi.Template{
+		Parameters: []templateapi.Parameter{
+			{Name: "PASSWORD", Generate: "expression", From: "[a-z]{8}", Value: "abcdefgh"},
+			{Name: "SECRET", Generate: "expression", From: "[a-z]{8}", Value: "provided"},
+			{Name: "IMAGE", Value: "web:2"},
+		},
+	}
+	generated := generatedValues(template, processed)
+	if !reflect.DeepEqual([]string{"abcdefgh"}, generated) {
+		t.Fatalf("unexpected generated values: %v", generated)
+	}
+
+	changes := []jsonmerge.Change{
+		{Operation: jsonmerge.Changed, Path: "spec.template.spec.containers[name=web].env[name=PASSWORD].value", From: "zyxwvuts", To: "abcdefgh"},
+		{Operation: jsonmerge.Changed, Path: "metadata.annotations.url", From: "http://u:zyxwvuts@db", To: "http://u:abcdefgh@db"},
+		{Operation: jsonmerge.Changed, Path: "spec.template.spec.containers[name=web].image", From: "web:1", To: "web:2"},
+		{Operation: jsonmerge.Added, Path: "spec.template.spec.containers[name=web].env[name=TOKEN]", To: map[string]interface{}{"name": "TOKEN", "value": "abcdefgh"}},
+	}
+	expected := []jsonmerge.Change{changes[2], changes[3]}
+	if actual := withoutGeneratedValues(changes, generated); !reflect.DeepEqual(expected, actual) {
+		t.Errorf("unexpected changes: %v", actual)
+	}
+}
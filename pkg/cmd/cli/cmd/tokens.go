@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	kerrors "k8s.io/kubernetes/pkg/util/errors"
+
+	"github.com/openshift/origin/pkg/client"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	TokensRecommendedCommandName       = "tokens"
+	ListTokensRecommendedCommandName   = "list"
+	RevokeTokensRecommendedCommandName = "revoke"
+)
+
+const (
+	tokensLong = `
+Manage the access tokens issued to you
+
+Every time you log in, or authorize an application to act on your behalf, the server issues
+an access token. These commands let you see the tokens that have not expired yet, and revoke
+the ones you no longer use.`
+
+	listTokensLong = `
+List the access tokens issued to you
+
+Shows the client each token was issued to, the scopes it is restricted to, and when it was
+created, expires and was last used.`
+
+	listTokensExample = `
+  # List your access tokens
+  $ %[1]s
+
+  # List your access tokens in YAML
+  $ %[1]s -o yaml`
+
+	revokeTokensLong = `
+Revoke access tokens issued to you
+
+A revoked token can no longer be used to access the server. Revoking the token of the
+current session logs you out, so you will have to log in again.`
+
+	revokeTokensExample = `
+  # Revoke a single access token
+  $ %[1]s <token name>
+
+  # Revoke all of your access tokens, including the one of the current session
+  $ %[1]s --all`
+)
+
+// NewCmdTokens implements the OpenShift cli tokens command
+func NewCmdTokens(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	cmds := &cobra.Command{
+		Use:   name,
+		Short: "Manage your access tokens",
+		Long:  tokensLong,
+		Run:   cmdutil.DefaultSubCommandRun(out),
+	}
+
+	cmds.AddCommand(NewCmdListTokens(ListTokensRecommendedCommandName, fullName+" "+ListTokensRecommendedCommandName, f, out))
+	cmds.AddCommand(NewCmdRevokeTokens(RevokeTokensRecommendedCommandName, fullName+" "+RevokeTokensRecommendedCommandName, f, out))
+	return cmds
+}
+
+// NewCmdListTokens implements the OpenShift cli tokens list command
+func NewCmdListTokens(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     name,
+		Short:   "List your access tokens",
+		Long:    listTokensLong,
+		Example: fmt.Sprintf(listTokensExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 0 {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, "no arguments are allowed"))
+			}
+			osClient, _, err := f.Clients()
+			kcmdutil.CheckErr(err)
+
+			list, err := osClient.UserOAuthAccessTokens().List(kapi.ListOptions{})
+			kcmdutil.CheckErr(err)
+			kcmdutil.CheckErr(f.PrintObject(cmd, list, out))
+		},
+	}
+	kcmdutil.AddPrinterFlags(cmd)
+	return cmd
+}
+
+// RevokeTokensOptions contains the information needed to revoke access tokens
+type RevokeTokensOptions struct {
+	Names []string
+	All   bool
+
+	Tokens client.UserOAuthAccessTokenInterface
+	Out    io.Writer
+}
+
+// NewCmdRevokeTokens implements the OpenShift cli tokens revoke command
+func NewCmdRevokeTokens(name, fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &RevokeTokensOptions{Out: out}
+
+	cmd := &cobra.Command{
+		Use:     name + " (NAME... | --all)",
+		Short:   "Revoke your access tokens",
+		Long:    revokeTokensLong,
+		Example: fmt.Sprintf(revokeTokensExample, fullName),
+		Run: func(cmd *cobra.Command, args []string) {
+			kcmdutil.CheckErr(options.Complete(f, args))
+			if err := options.Validate(); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+			kcmdutil.CheckErr(options.RevokeTokens())
+		},
+	}
+	cmd.Flags().BoolVar(&options.All, "all", options.All, "Revoke all of your access tokens")
+	return cmd
+}
+
+func (o *RevokeTokensOptions) Complete(f *clientcmd.Factory, args []string) error {
+	o.Names = args
+	osClient, _, err := f.Clients()
+	if err != nil {
+		return err
+	}
+	o.Tokens = osClient.UserOAuthAccessTokens()
+	return nil
+}
+
+func (o *RevokeTokensOptions) Validate() error {
+	if o.All && len(o.Names) > 0 {
+		return errors.New("token names may not be given with --all")
+	}
+	if !o.All && len(o.Names) == 0 {
+		return errors.New("at least one token name or --all is required")
+	}
+	return nil
+}
+
+// RevokeTokens revokes the named tokens, or all the tokens of the user.
+func (o *RevokeTokensOptions) RevokeTokens() error {
+	names := o.Names
+	if o.All {
+		list, err := o.Tokens.List(kapi.ListOptions{})
+		if err != nil {
+			return err
+		}
+		names = []string{}
+		for _, token := range list.Items {
+			names = append(names, token.Name)
+		}
+	}
+
+	errs := []error{}
+	for _, name := range names {
+		if err := o.Tokens.Delete(name); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		fmt.Fprintf(o.Out, "token %q revoked\n", name)
+	}
+	return kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/api"
+	ktc "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
+)
+
+func TestRevokeTokens(t *testing.T) {
+	tests := map[string]struct {
+		opts            *RevokeTokensOptions
+		expectedDeleted []string
+	}{
+		"named tokens": {
+			opts:            &RevokeTokensOptions{Names: []string{"first", "third"}},
+			expectedDeleted: []string{"first", "third"},
+		},
+		"all tokens": {
+			opts:            &RevokeTokensOptions{All: true},
+			expectedDeleted: []string{"first", "second", "third"},
+		},
+	}
+
+	for name, test := range tests {
+		client := &testclient.Fake{}
+		client.AddReactor("list", "useroauthaccesstokens", func(action ktc.Action) (handled bool, ret runtime.Object, err error) {
+			return true, &oauthapi.UserOAuthAccessTokenList{
+				Items: []oauthapi.UserOAuthAccessToken{
+					{ObjectMeta: api.ObjectMeta{Name: "first"}},
+					{ObjectMeta: api.ObjectMeta{Name: "second"}},
+					{ObjectMeta: api.ObjectMeta{Name: "third"}},
+				},
+			}, nil
+		})
+		out := &bytes.Buffer{}
+		test.opts.Tokens = client.UserOAuthAccessTokens()
+		test.opts.Out = out
+
+		if err := test.opts.Validate(); err != nil {
+			t.Errorf("%s: unexpected validation error: %v", name, err)
+			continue
+		}
+		if err := test.opts.RevokeTokens(); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+
+		deleted := []string{}
+		for _, action := range client.Actions() {
+			if action.GetVerb() == "delete" {
+				deleted = append(deleted, action.(ktc.DeleteAction).GetName())
+			}
+		}
+		if !reflect.DeepEqual(deleted, test.expectedDeleted) {
+			t.Errorf("%s: expected %v to be revoked, got %v", name, test.expectedDeleted, deleted)
+		}
+	}
+}
+
+func TestRevokeTokensValidate(t *testing.T) {
+	if err := (&RevokeTokensOptions{}).Validate(); err == nil {
+		t.Errorf("expected an error without token names")
+	}
+	if err := (&RevokeTokensOptions{Names: []string{"first"}, All: true}).Validate(); err == nil {
+		t.Errorf("expected an error with token names and --all")
+	}
+}
@@ -1,12 +1,14 @@
 package describe
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sort"
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/openshift/origin/pkg/api/graph"
 
@@ -15,6 +17,7 @@ import (
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	kctl "k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
 
 	kubegraph "github.com/openshift/origin/pkg/api/kubegraph/nodes"
 	"github.com/openshift/origin/pkg/client"
@@ -23,6 +26,7 @@ import (
 	deploygraph "github.com/openshift/origin/pkg/deploy/graph/nodes"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/util/jsonmerge"
 )
 
 // DeploymentConfigDescriber generates information about a DeploymentConfig
@@ -193,13 +197,20 @@ func printStrategy(strategy deployapi.DeploymentStrategy, w *tabwriter.Writer) {
 	case deployapi.DeploymentStrategyTypeRolling:
 		if strategy.RollingParams != nil {
 			pre := strategy.RollingParams.Pre
+			mid := strategy.RollingParams.Mid
 			post := strategy.RollingParams.Post
 			if pre != nil {
 				printHook("Pre-deployment", pre, w)
 			}
+			if mid != nil {
+				printHook("Mid-deployment", mid, w)
+			}
 			if post != nil {
 				printHook("Post-deployment", post, w)
 			}
+			if strategy.RollingParams.PauseAfterPercent != nil {
+				fmt.Fprintf(w, "\t  Pause After:\t%d%%\n", *strategy.RollingParams.PauseAfterPercent)
+			}
 		}
 	case deployapi.DeploymentStrategyTypeCustom:
 		fmt.Fprintf(w, "\t  Image:\t%s\n", strategy.CustomParams.Image)
@@ -221,6 +232,24 @@ func printHook(prefix string, hook *deployapi.LifecycleHook, w io.Writer) {
 		fmt.Fprintf(w, "\t    Command:\t%v\n", strings.Join(hook.ExecNewPod.Command, " "))
 		fmt.Fprintf(w, "\t    Env:\t%s\n", formatLabels(convertEnv(hook.ExecNewPod.Env)))
 	}
+	if len(hook.TagImages) > 0 {
+		fmt.Fprintf(w, "\t  %s hook (tag images, failure policy: %s):\n", prefix, hook.FailurePolicy)
+		for _, image := range hook.TagImages {
+			fmt.Fprintf(w, "\t    Tag:\tcontainer %s to %s %s\n", image.ContainerName, image.To.Kind, image.To.Name)
+		}
+	}
+	if hook.ExecInPods != nil {
+		fmt.Fprintf(w, "\t  %s hook (exec in pods, failure policy: %s):\n", prefix, hook.FailurePolicy)
+		container := hook.ExecInPods.ContainerName
+		if len(container) == 0 {
+			container = "<first container>"
+		}
+		fmt.Fprintf(w, "\t    Container:\t%s\n", container)
+		fmt.Fprintf(w, "\t    Command:\t%v\n", strings.Join(hook.ExecInPods.Command, " "))
+		if hook.ExecInPods.MaxPods > 0 {
+			fmt.Fprintf(w, "\t    Max Pods:\t%d\n", hook.ExecInPods.MaxPods)
+		}
+	}
 }
 
 func printTriggers(triggers []deployapi.DeploymentTriggerPolicy, w *tabwriter.Writer) {
@@ -280,7 +309,7 @@ func printDeploymentRc(deployment *kapi.ReplicationController, client deployment
 	}
 	timeAt := strings.ToLower(formatRelativeTime(deployment.CreationTimestamp.Time))
 	fmt.Fprintf(w, "\tCreated:\t%s ago\n", timeAt)
-	fmt.Fprintf(w, "\tStatus:\t%s\n", deployutil.DeploymentStatusFor(deployment))
+	fmt.Fprintf(w, "\tStatus:\t%s\n", deploymentStatusWithPause(deployment))
 	fmt.Fprintf(w, "\tReplicas:\t%d current / %d desired\n", deployment.Status.Replicas, deployment.Spec.Replicas)
 
 	if verbose {
@@ -402,3 +431,170 @@ func (s rcSorter) Less(i, j int) bool {
 func (s rcSorter) Swap(i, j int) {
 	s[i], s[j] = s[j], s[i]
 }
+
+// DeploymentHistoryDescriber generates information about the deployment
+// history of a DeploymentConfig
+type DeploymentHistoryDescriber struct {
+	client  deploymentDescriberClient
+	decoder runtime.Decoder
+}
+
+// NewDeploymentHistoryDescriber returns a new DeploymentHistoryDescriber
+func NewDeploymentHistoryDescriber(client client.Interface, kclient kclient.Interface) *DeploymentHistoryDescriber {
+	return &DeploymentHistoryDescriber{
+		decoder: kapi.Codecs.UniversalDecoder(),
+		client: &genericDeploymentDescriberClient{
+			getDeploymentConfigFunc: func(namespace, name string) (*deployapi.DeploymentConfig, error) {
+				return client.DeploymentConfigs(namespace).Get(name)
+			},
+			getDeploymentFunc: func(namespace, name string) (*kapi.ReplicationController, error) {
+				return kclient.ReplicationControllers(namespace).Get(name)
+			},
+			listDeploymentsFunc: func(namespace string, selector labels.Selector) (*kapi.ReplicationControllerList, error) {
+				return kclient.ReplicationControllers(namespace).List(kapi.ListOptions{LabelSelector: selector})
+			},
+			listPodsFunc: func(namespace string, selector labels.Selector) (*kapi.PodList, error) {
+				return kclient.Pods(namespace).List(kapi.ListOptions{LabelSelector: selector})
+			},
+			listEventsFunc: func(deploymentConfig *deployapi.DeploymentConfig) (*kapi.EventList, error) {
+				return kclient.Events(deploymentConfig.Namespace).Search(deploymentConfig)
+			},
+		},
+	}
+}
+
+// Describe returns every deployment revision of a config with its status,
+// creation time and cause
+func (d *DeploymentHistoryDescriber) Describe(namespace, name string) (string, error) {
+	list, err := d.client.listDeployments(namespace, deployutil.ConfigSelector(name))
+	if err != nil {
+		return "", err
+	}
+	deployments := list.Items
+	if len(deployments) == 0 {
+		return fmt.Sprintf("No deployments found for deploymentconfig %q\n", name), nil
+	}
+	sort.Sort(deployutil.ByLatestVersionAsc(deployments))
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		fmt.Fprintf(out, "REVISION\tSTATUS\tCREATED\tCAUSE\n")
+		for i := range deployments {
+			deployment := &deployments[i]
+			cause := "<unknown>"
+			if config, err := deployutil.DecodeDeploymentConfig(deployment, d.decoder); err == nil {
+				cause = formatDeploymentCause(config.Status.Details)
+			}
+			fmt.Fprintf(out, "%d\t%s\t%s ago\t%s\n",
+				deployutil.DeploymentVersionFor(deployment),
+				deploymentStatusWithPause(deployment),
+				strings.ToLower(formatRelativeTime(deployment.CreationTimestamp.Time)),
+				cause)
+		}
+		return nil
+	})
+}
+
+// DescribeRevision returns the details of a single deployment revision of a
+// config, including the pod template it deployed
+func (d *DeploymentHistoryDescriber) DescribeRevision(namespace, name string, revision int) (string, error) {
+	deployment, config, err := d.getRevision(namespace, name, revision)
+	if err != nil {
+		return "", err
+	}
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		fmt.Fprintf(out, "deploymentconfig %q revision %d\n", name, revision)
+		formatString(out, "Deployment", deployment.Name)
+		formatString(out, "Status", deploymentStatusWithPause(deployment))
+		if reason := deployutil.DeploymentStatusReasonFor(deployment); len(reason) > 0 {
+			formatString(out, "Status Reason", reason)
+		}
+		formatString(out, "Created", fmt.Sprintf("%s (%s ago)", deployment.CreationTimestamp.Time.Format(time.RFC1123Z), strings.ToLower(formatRelativeTime(deployment.CreationTimestamp.Time))))
+		formatString(out, "Cause", formatDeploymentCause(config.Status.Details))
+		fmt.Fprintln(out)
+		return printDeploymentConfigSpec(config.Spec, out)
+	})
+}
+
+// DescribeDiff returns the field level differences between the pod templates
+// deployed by two revisions of a config
+func (d *DeploymentHistoryDescriber) DescribeDiff(namespace, name string, from, to int) (string, error) {
+	_, fromConfig, err := d.getRevision(namespace, name, from)
+	if err != nil {
+		return "", err
+	}
+	_, toConfig, err := d.getRevision(namespace, name, to)
+	if err != nil {
+		return "", err
+	}
+	changes, err := jsonmerge.ObjectChanges(fromConfig.Spec.Template, toConfig.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+
+	buf := &bytes.Buffer{}
+	if len(changes) == 0 {
+		fmt.Fprintf(buf, "The pod templates of revisions %d and %d of deploymentconfig %q are identical\n", from, to, name)
+		return buf.String(), nil
+	}
+	fmt.Fprintf(buf, "Pod template changes from revision %d to revision %d of deploymentconfig %q:\n", from, to, name)
+	for _, change := range changes {
+		fmt.Fprintf(buf, "  %s\n", change)
+	}
+	return buf.String(), nil
+}
+
+// getRevision returns the deployment for the given revision of a config and
+// the config it was created from
+func (d *DeploymentHistoryDescriber) getRevision(namespace, name string, revision int) (*kapi.ReplicationController, *deployapi.DeploymentConfig, error) {
+	deployment, err := d.client.getDeployment(namespace, deployutil.DeploymentNameForConfigVersion(name, revision))
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("revision %d of deploymentconfig %q not found", revision, name)
+		}
+		return nil, nil, err
+	}
+	config, err := deployutil.DecodeDeploymentConfig(deployment, d.decoder)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't decode the deploymentconfig of revision %d: %v", revision, err)
+	}
+	return deployment, config, nil
+}
+
+func deploymentStatusWithPause(deployment *kapi.ReplicationController) string {
+	status := string(deployutil.DeploymentStatusFor(deployment))
+	if _, paused := deployment.Annotations[deployapi.DeploymentPausedAnnotation]; paused {
+		status += " (paused)"
+	}
+	return status
+}
+
+// formatDeploymentCause returns a human readable summary of why a deployment
+// was created
+func formatDeploymentCause(details *deployapi.DeploymentDetails) string {
+	if details == nil {
+		return "<unknown>"
+	}
+	causes := []string{}
+	for _, cause := range details.Causes {
+		switch cause.Type {
+		case deployapi.DeploymentTriggerOnImageChange:
+			if cause.ImageTrigger != nil {
+				causes = append(causes, fmt.Sprintf("image change (%s %s)", cause.ImageTrigger.From.Kind, cause.ImageTrigger.From.Name))
+			} else {
+				causes = append(causes, "image change")
+			}
+		case deployapi.DeploymentTriggerOnConfigChange:
+			causes = append(causes, "config change")
+		default:
+			causes = append(causes, strings.ToLower(string(cause.Type)))
+		}
+	}
+	if len(details.Message) > 0 {
+		causes = append(causes, details.Message)
+	}
+	if len(causes) == 0 {
+		return "<unknown>"
+	}
+	return strings.Join(causes, ", ")
+}
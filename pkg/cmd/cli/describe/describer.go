@@ -28,6 +28,7 @@ import (
 	"github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
+	oauthapi "github.com/openshift/origin/pkg/oauth/api"
 	projectapi "github.com/openshift/origin/pkg/project/api"
 	routeapi "github.com/openshift/origin/pkg/route/api"
 	templateapi "github.com/openshift/origin/pkg/template/api"
@@ -47,6 +48,7 @@ func describerMap(c *client.Client, kclient kclient.Interface, host string) map[
 		routeapi.Kind("Route"):                        &RouteDescriber{c, kclient},
 		projectapi.Kind("Project"):                    &ProjectDescriber{c, kclient},
 		templateapi.Kind("Template"):                  &TemplateDescriber{c, meta.NewAccessor(), kapi.Scheme, nil},
+		templateapi.Kind("TemplateInstance"):          &TemplateInstanceDescriber{c},
 		authorizationapi.Kind("Policy"):               &PolicyDescriber{c},
 		authorizationapi.Kind("PolicyBinding"):        &PolicyBindingDescriber{c},
 		authorizationapi.Kind("RoleBinding"):          &RoleBindingDescriber{c},
@@ -58,6 +60,7 @@ func describerMap(c *client.Client, kclient kclient.Interface, host string) map[
 		userapi.Kind("User"):                          &UserDescriber{c},
 		userapi.Kind("Group"):                         &GroupDescriber{c.Groups()},
 		userapi.Kind("UserIdentityMapping"):           &UserIdentityMappingDescriber{c},
+		oauthapi.Kind("UserOAuthAccessToken"):         &UserOAuthAccessTokenDescriber{c.UserOAuthAccessTokens()},
 	}
 	return m
 }
@@ -572,11 +575,33 @@ func (d *ImageStreamDescriber) Describe(namespace, name string) (string, error)
 	return tabbedString(func(out *tabwriter.Writer) error {
 		formatMeta(out, imageStream.ObjectMeta)
 		formatString(out, "Docker Pull Spec", imageStream.Status.DockerImageRepository)
+		if policy := imageStream.Spec.RetentionPolicy; policy != nil {
+			formatString(out, "Retention Policy", describeRetentionPolicy(policy))
+		}
+		if len(imageStream.Spec.Notifications) > 0 {
+			urls := []string{}
+			for _, notification := range imageStream.Spec.Notifications {
+				urls = append(urls, notification.URL)
+			}
+			formatString(out, "Notifications", strings.Join(urls, ", "))
+		}
 		formatImageStreamTags(out, imageStream)
 		return nil
 	})
 }
 
+// describeRetentionPolicy returns a human readable summary of the retention policy of an image stream.
+func describeRetentionPolicy(policy *imageapi.ImageStreamRetentionPolicy) string {
+	parts := []string{fmt.Sprintf("keep %d revisions per tag", policy.KeepTagRevisions)}
+	if policy.KeepYoungerThanSeconds > 0 {
+		parts = append(parts, fmt.Sprintf("revisions tagged less than %s ago", time.Duration(policy.KeepYoungerThanSeconds)*time.Second))
+	}
+	if len(policy.ProtectedTags) > 0 {
+		parts = append(parts, fmt.Sprintf("all revisions of %s", strings.Join(policy.ProtectedTags, ", ")))
+	}
+	return strings.Join(parts, ", ")
+}
+
 // RouteDescriber generates information about a Route
 type RouteDescriber struct {
 	client.Interface
@@ -778,6 +803,18 @@ func (d *TemplateDescriber) DescribeParameters(params []templateapi.Parameter, o
 			formatString(out, indent+"Description", p.Description)
 		}
 		formatString(out, indent+"Required", p.Required)
+		if len(p.Type) > 0 {
+			formatString(out, indent+"Type", p.Type)
+		}
+		if len(p.Pattern) > 0 {
+			formatString(out, indent+"Pattern", p.Pattern)
+		}
+		if len(p.AllowedValues) > 0 {
+			formatString(out, indent+"Allowed Values", strings.Join(p.AllowedValues, ", "))
+		}
+		if p.MinLength > 0 || p.MaxLength > 0 {
+			formatString(out, indent+"Length", describeParameterLength(p.MinLength, p.MaxLength))
+		}
 		if len(p.Generate) == 0 {
 			formatString(out, indent+"Value", p.Value)
 			continue
@@ -794,6 +831,20 @@ func (d *TemplateDescriber) DescribeParameters(params []templateapi.Parameter, o
 	}
 }
 
+// describeParameterLength returns a description of the length constraints of a parameter
+func describeParameterLength(min, max int) string {
+	switch {
+	case max == 0:
+		return fmt.Sprintf("at least %d characters", min)
+	case min == 0:
+		return fmt.Sprintf("at most %d characters", max)
+	case min == max:
+		return fmt.Sprintf("%d characters", min)
+	default:
+		return fmt.Sprintf("%d to %d characters", min, max)
+	}
+}
+
 // describeObjects prints out information about the objects of a template
 func (d *TemplateDescriber) describeObjects(objects []runtime.Object, out *tabwriter.Writer) {
 	formatString(out, "Objects", " ")
@@ -851,6 +902,50 @@ func (d *TemplateDescriber) DescribeTemplate(template *templateapi.Template) (st
 	})
 }
 
+// TemplateInstanceDescriber generates information about a template instance
+type TemplateInstanceDescriber struct {
+	client.Interface
+}
+
+// Describe returns the description of a template instance
+func (d *TemplateInstanceDescriber) Describe(namespace, name string) (string, error) {
+	templateInstance, err := d.TemplateInstances(namespace).Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		formatMeta(out, templateInstance.ObjectMeta)
+		formatString(out, "Template", templateInstance.Spec.Template.Name)
+		if templateInstance.Spec.Secret != nil {
+			formatString(out, "Parameters Secret", templateInstance.Spec.Secret.Name)
+		} else {
+			formatString(out, "Parameters Secret", "<none>")
+		}
+		if requester := templateInstance.Spec.Requester; requester != nil {
+			formatString(out, "Requester", requester.Username)
+		}
+		formatString(out, "Status", templateInstanceStatus(templateInstance))
+
+		if len(templateInstance.Status.Conditions) > 0 {
+			fmt.Fprintf(out, "Conditions:\n  Type\tStatus\tLast Transition\tReason\tMessage\n")
+			for _, c := range templateInstance.Status.Conditions {
+				fmt.Fprintf(out, "  %s\t%s\t%s\t%s\t%s\n", c.Type, c.Status, formatRelativeTime(c.LastTransitionTime.Time), c.Reason, c.Message)
+			}
+		}
+
+		if len(templateInstance.Status.Objects) == 0 {
+			formatString(out, "Objects", "<none>")
+			return nil
+		}
+		fmt.Fprintf(out, "Objects:\n")
+		for _, ref := range templateInstance.Status.Objects {
+			fmt.Fprintf(out, "  %s\t%s\n", ref.Kind, ref.Name)
+		}
+		return nil
+	})
+}
+
 // IdentityDescriber generates information about a user
 type IdentityDescriber struct {
 	client.Interface
@@ -1001,6 +1096,38 @@ func (d *GroupDescriber) Describe(namespace, name string) (string, error) {
 	})
 }
 
+// UserOAuthAccessTokenDescriber generates information about an access token of the current user
+type UserOAuthAccessTokenDescriber struct {
+	c client.UserOAuthAccessTokenInterface
+}
+
+// Describe returns the description of an access token of the current user
+func (d *UserOAuthAccessTokenDescriber) Describe(namespace, name string) (string, error) {
+	token, err := d.c.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		formatMeta(out, token.ObjectMeta)
+		formatString(out, "Client Name", token.ClientName)
+		if len(token.Scopes) == 0 {
+			formatString(out, "Scopes", "<none>")
+		} else {
+			formatString(out, "Scopes", strings.Join(token.Scopes, ", "))
+		}
+		expires := token.CreationTimestamp.Add(time.Duration(token.ExpiresIn) * time.Second)
+		formatString(out, "Expires", expires.Format(time.RFC1123Z))
+		if token.LastUsedTimestamp == nil {
+			formatString(out, "Last Used", "<never>")
+		} else {
+			formatTime(out, "Last Used", token.LastUsedTimestamp.Time)
+		}
+		formatString(out, "Redirect URI", token.RedirectURI)
+		return nil
+	})
+}
+
 // policy describers
 
 // PolicyDescriber generates information about a Project
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/kubectl"
@@ -54,6 +55,7 @@ var DescriberCoverageExceptions = []reflect.Type{
 	reflect.TypeOf(&oauthapi.OAuthAccessToken{}),                      // normal users don't ever look at these
 	reflect.TypeOf(&oauthapi.OAuthAuthorizeToken{}),                   // normal users don't ever look at these
 	reflect.TypeOf(&oauthapi.OAuthClientAuthorization{}),              // normal users don't ever look at these
+	reflect.TypeOf(&oauthapi.OAuthTOTPEnrollment{}),                   // normal users don't ever look at these
 	reflect.TypeOf(&projectapi.ProjectRequest{}),                      // normal users don't ever look at these
 	reflect.TypeOf(&authorizationapi.IsPersonalSubjectAccessReview{}), // not a top level resource
 
@@ -64,6 +66,8 @@ var DescriberCoverageExceptions = []reflect.Type{
 	reflect.TypeOf(&authorizationapi.ResourceAccessReview{}),
 	reflect.TypeOf(&authorizationapi.LocalSubjectAccessReview{}),
 	reflect.TypeOf(&authorizationapi.LocalResourceAccessReview{}),
+	// image signatures can only be created and deleted
+	reflect.TypeOf(&imageapi.ImageSignature{}),
 }
 
 // MissingDescriberCoverageExceptions is the list of types that were missing describer methods when I started
@@ -218,6 +222,83 @@ func TestDeploymentConfigDescriber(t *testing.T) {
 	describe()
 }
 
+func TestDeploymentHistoryDescriber(t *testing.T) {
+	config := deployapitest.OkDeploymentConfig(1)
+	config.Status.Details = &deployapi.DeploymentDetails{
+		Causes: []*deployapi.DeploymentCause{{Type: deployapi.DeploymentTriggerOnConfigChange}},
+	}
+	first, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	first.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusComplete)
+
+	config = deployapitest.OkDeploymentConfig(2)
+	config.Spec.Template.Spec.Containers[0].Image = "registry:8080/repo1:ref2"
+	config.Status.Details = &deployapi.DeploymentDetails{
+		Causes: []*deployapi.DeploymentCause{{
+			Type: deployapi.DeploymentTriggerOnImageChange,
+			ImageTrigger: &deployapi.DeploymentCauseImageTrigger{
+				From: kapi.ObjectReference{Kind: "ImageStreamTag", Name: "repo1:ref2"},
+			},
+		}},
+	}
+	second, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	second.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusRunning)
+	second.Annotations[deployapi.DeploymentPausedAnnotation] = "true"
+
+	deployments := map[string]*kapi.ReplicationController{first.Name: first, second.Name: second}
+	d := &DeploymentHistoryDescriber{
+		decoder: kapi.Codecs.UniversalDecoder(),
+		client: &genericDeploymentDescriberClient{
+			getDeploymentFunc: func(namespace, name string) (*kapi.ReplicationController, error) {
+				if deployment, ok := deployments[name]; ok {
+					return deployment, nil
+				}
+				return nil, kerrors.NewNotFound(kapi.Resource("replicationcontroller"), name)
+			},
+			listDeploymentsFunc: func(namespace string, selector labels.Selector) (*kapi.ReplicationControllerList, error) {
+				return &kapi.ReplicationControllerList{Items: []kapi.ReplicationController{*second, *first}}, nil
+			},
+		},
+	}
+
+	out, err := d.Describe("test", config.Name)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected a header and 2 revisions, got:\n%s", out)
+	}
+	if !strings.HasPrefix(lines[1], "1") || !strings.HasPrefix(lines[2], "2") {
+		t.Errorf("expected revisions in ascending order, got:\n%s", out)
+	}
+	if !strings.Contains(lines[1], "config change") {
+		t.Errorf("expected config change cause for revision 1, got %q", lines[1])
+	}
+	if !strings.Contains(lines[2], "Running (paused)") || !strings.Contains(lines[2], "image change (ImageStreamTag repo1:ref2)") {
+		t.Errorf("unexpected description of revision 2: %q", lines[2])
+	}
+
+	out, err = d.DescribeRevision("test", config.Name, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "registry:8080/repo1:ref2") {
+		t.Errorf("expected the revision's pod template to be described, got:\n%s", out)
+	}
+
+	out, err = d.DescribeDiff("test", config.Name, 1, 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `~ spec.containers[name=container1].image: "registry:8080/repo1:ref1" -> "registry:8080/repo1:ref2"`) {
+		t.Errorf("expected image change in diff, got:\n%s", out)
+	}
+
+	if _, err := d.DescribeRevision("test", config.Name, 3); err == nil {
+		t.Errorf("expected an error for a missing revision")
+	}
+}
+
 func TestDescribeBuildDuration(t *testing.T) {
 	type testBuild struct {
 		build  *buildapi.Build
@@ -173,6 +173,20 @@ func shortenImagePullSpec(spec string) string {
 	return spec
 }
 
+// describeImportFailure returns a short description of the reason of a failed tag import.
+func describeImportFailure(reason string) string {
+	switch reason {
+	case imageapi.ImportFailedUnauthorized:
+		return "import failed (unauthorized)"
+	case imageapi.ImportFailedNotFound:
+		return "import failed (not found)"
+	case imageapi.ImportFailedRegistryUnavailable:
+		return "import failed (registry unavailable)"
+	default:
+		return "import failed"
+	}
+}
+
 func formatImageStreamTags(out *tabwriter.Writer, stream *imageapi.ImageStream) {
 	if len(stream.Status.Tags) == 0 && len(stream.Spec.Tags) == 0 {
 		fmt.Fprintf(out, "Tags:\t<none>\n")
@@ -230,7 +244,7 @@ func formatImageStreamTags(out *tabwriter.Writer, stream *imageapi.ImageStream)
 					switch condition.Type {
 					case imageapi.ImportSuccess:
 						if condition.Status == api.ConditionFalse {
-							summary = append(summary, fmt.Sprintf("import failed: %s", condition.Message))
+							summary = append(summary, fmt.Sprintf("%s: %s", describeImportFailure(condition.Reason), condition.Message))
 						}
 					default:
 						summary = append(summary, string(condition.Type))
@@ -2,6 +2,7 @@ package describe
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 	"text/tabwriter"
 	"time"
@@ -79,3 +80,39 @@ func TestFormatImageStreamTags(t *testing.T) {
 	actual := string(buf.String())
 	t.Logf("\n%s", actual)
 }
+
+func TestFormatImageStreamTagsImportFailure(t *testing.T) {
+	tests := map[string]string{
+		imageapi.ImportFailedUnauthorized:        "import failed (unauthorized): ",
+		imageapi.ImportFailedNotFound:            "import failed (not found): ",
+		imageapi.ImportFailedRegistryUnavailable: "import failed (registry unavailable): ",
+		"InternalError":                          "import failed: ",
+	}
+	for reason, expected := range tests {
+		stream := imageapi.ImageStream{
+			Spec: imageapi.ImageStreamSpec{
+				Tags: map[string]imageapi.TagReference{
+					"latest": {From: &kapi.ObjectReference{Kind: "DockerImage", Name: "registry.example.com/app:latest"}},
+				},
+			},
+			Status: imageapi.ImageStreamStatus{
+				Tags: map[string]imageapi.TagEventList{
+					"latest": {
+						Conditions: []imageapi.TagEventCondition{
+							{Type: imageapi.ImportSuccess, Status: kapi.ConditionFalse, Reason: reason, Message: "failed"},
+						},
+					},
+				},
+			},
+		}
+
+		buf := &bytes.Buffer{}
+		out := new(tabwriter.Writer)
+		out.Init(buf, 0, 8, 1, '\t', 0)
+		formatImageStreamTags(out, &stream)
+		out.Flush()
+		if !strings.Contains(buf.String(), expected+"failed") {
+			t.Errorf("%s: expected %q in the output:\n%s", reason, expected, buf.String())
+		}
+	}
+}
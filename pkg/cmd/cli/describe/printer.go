@@ -9,6 +9,7 @@ import (
 	"text/tabwriter"
 	"time"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	kctl "k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/labels"
@@ -38,6 +39,7 @@ var (
 	deploymentColumns       = []string{"NAME", "STATUS", "CAUSE"}
 	deploymentConfigColumns = []string{"NAME", "REVISION", "REPLICAS", "TRIGGERED BY"}
 	templateColumns         = []string{"NAME", "DESCRIPTION", "PARAMETERS", "OBJECTS"}
+	templateInstanceColumns = []string{"NAME", "TEMPLATE", "STATUS", "OBJECTS"}
 	policyColumns           = []string{"NAME", "ROLES", "LAST MODIFIED"}
 	policyBindingColumns    = []string{"NAME", "ROLE BINDINGS", "LAST MODIFIED"}
 	roleBindingColumns      = []string{"NAME", "ROLE", "USERS", "GROUPS", "SERVICE ACCOUNTS", "SUBJECTS"}
@@ -47,6 +49,8 @@ var (
 	oauthClientAuthorizationColumns = []string{"NAME", "USER NAME", "CLIENT NAME", "SCOPES"}
 	oauthAccessTokenColumns         = []string{"NAME", "USER NAME", "CLIENT NAME", "CREATED", "EXPIRES", "REDIRECT URI", "SCOPES"}
 	oauthAuthorizeTokenColumns      = []string{"NAME", "USER NAME", "CLIENT NAME", "CREATED", "EXPIRES", "REDIRECT URI", "SCOPES"}
+	userOAuthAccessTokenColumns     = []string{"NAME", "CLIENT NAME", "CREATED", "EXPIRES", "LAST USED", "SCOPES"}
+	oauthTOTPEnrollmentColumns      = []string{"NAME", "USER UID", "CONFIRMED", "RECOVERY CODES", "AGE"}
 
 	userColumns                = []string{"NAME", "UID", "FULL NAME", "IDENTITIES"}
 	identityColumns            = []string{"NAME", "IDP NAME", "IDP USER NAME", "USER NAME", "USER UID"}
@@ -84,6 +88,8 @@ func NewHumanReadablePrinter(noHeaders, withNamespace, wide bool, showAll bool,
 	p.Handler(deploymentConfigColumns, printDeploymentConfigList)
 	p.Handler(templateColumns, printTemplate)
 	p.Handler(templateColumns, printTemplateList)
+	p.Handler(templateInstanceColumns, printTemplateInstance)
+	p.Handler(templateInstanceColumns, printTemplateInstanceList)
 
 	p.Handler(policyColumns, printPolicy)
 	p.Handler(policyColumns, printPolicyList)
@@ -111,6 +117,10 @@ func NewHumanReadablePrinter(noHeaders, withNamespace, wide bool, showAll bool,
 	p.Handler(oauthAccessTokenColumns, printOAuthAccessTokenList)
 	p.Handler(oauthAuthorizeTokenColumns, printOAuthAuthorizeToken)
 	p.Handler(oauthAuthorizeTokenColumns, printOAuthAuthorizeTokenList)
+	p.Handler(userOAuthAccessTokenColumns, printUserOAuthAccessToken)
+	p.Handler(userOAuthAccessTokenColumns, printUserOAuthAccessTokenList)
+	p.Handler(oauthTOTPEnrollmentColumns, printOAuthTOTPEnrollment)
+	p.Handler(oauthTOTPEnrollmentColumns, printOAuthTOTPEnrollmentList)
 
 	p.Handler(userColumns, printUser)
 	p.Handler(userColumns, printUserList)
@@ -199,6 +209,39 @@ func printTemplateList(list *templateapi.TemplateList, w io.Writer, opts kctl.Pr
 	return nil
 }
 
+func printTemplateInstance(t *templateapi.TemplateInstance, w io.Writer, opts kctl.PrintOptions) error {
+	if opts.WithNamespace {
+		if _, err := fmt.Fprintf(w, "%s\t", t.Namespace); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%d\n", t.Name, t.Spec.Template.Name, templateInstanceStatus(t), len(t.Status.Objects))
+	return err
+}
+
+// templateInstanceStatus summarizes the conditions of a template instance.
+func templateInstanceStatus(t *templateapi.TemplateInstance) string {
+	status := "Pending"
+	for _, c := range t.Status.Conditions {
+		switch {
+		case c.Type == templateapi.TemplateInstanceInstantiateFailure && c.Status == kapi.ConditionTrue:
+			return "Failed"
+		case c.Type == templateapi.TemplateInstanceReady && c.Status == kapi.ConditionTrue:
+			status = "Ready"
+		}
+	}
+	return status
+}
+
+func printTemplateInstanceList(list *templateapi.TemplateInstanceList, w io.Writer, opts kctl.PrintOptions) error {
+	for _, t := range list.Items {
+		if err := printTemplateInstance(&t, w, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func printBuild(build *buildapi.Build, w io.Writer, opts kctl.PrintOptions) error {
 	if opts.WithNamespace {
 		if _, err := fmt.Fprintf(w, "%s\t", build.Namespace); err != nil {
@@ -705,6 +748,41 @@ func printOAuthAuthorizeTokenList(list *oauthapi.OAuthAuthorizeTokenList, w io.W
 	return nil
 }
 
+func printUserOAuthAccessToken(token *oauthapi.UserOAuthAccessToken, w io.Writer, opts kctl.PrintOptions) error {
+	created := token.CreationTimestamp
+	expires := created.Add(time.Duration(token.ExpiresIn) * time.Second)
+	lastUsed := "<never>"
+	if token.LastUsedTimestamp != nil {
+		lastUsed = token.LastUsedTimestamp.String()
+	}
+	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", token.Name, token.ClientName, created, expires, lastUsed, strings.Join(token.Scopes, ","))
+	return err
+}
+
+func printUserOAuthAccessTokenList(list *oauthapi.UserOAuthAccessTokenList, w io.Writer, opts kctl.PrintOptions) error {
+	for _, item := range list.Items {
+		if err := printUserOAuthAccessToken(&item, w, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printOAuthTOTPEnrollment(enrollment *oauthapi.OAuthTOTPEnrollment, w io.Writer, opts kctl.PrintOptions) error {
+	age := formatRelativeTime(enrollment.CreationTimestamp.Time)
+	_, err := fmt.Fprintf(w, "%s\t%s\t%t\t%d\t%s\n", enrollment.Name, enrollment.UserUID, enrollment.Confirmed, len(enrollment.RecoveryCodeHashes), age)
+	return err
+}
+
+func printOAuthTOTPEnrollmentList(list *oauthapi.OAuthTOTPEnrollmentList, w io.Writer, opts kctl.PrintOptions) error {
+	for _, item := range list.Items {
+		if err := printOAuthTOTPEnrollment(&item, w, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func printUser(user *userapi.User, w io.Writer, opts kctl.PrintOptions) error {
 	_, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", user.Name, user.UID, user.FullName, strings.Join(user.Identities, ", "))
 	return err
@@ -20,6 +20,7 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/docker/distribution/registry/auth"
 	"github.com/docker/distribution/registry/handlers"
+	"github.com/docker/distribution/registry/storage/driver/factory"
 	"github.com/docker/distribution/uuid"
 	"github.com/docker/distribution/version"
 
@@ -52,6 +53,13 @@ func Execute(configFile io.Reader) {
 	// with uuid generation under low entropy.
 	uuid.Loggerf = context.GetLogger(ctx).Warnf
 
+	// the repository middleware refuses manifests while the garbage collector holds its lock in the storage
+	driver, err := factory.Create(config.Storage.Type(), config.Storage.Parameters())
+	if err != nil {
+		log.Fatalf("Error creating storage driver: %v", err)
+	}
+	server.SetStorageDriver(driver)
+
 	app := handlers.NewApp(ctx, config)
 
 	// TODO add https scheme
@@ -79,8 +87,52 @@ func Execute(configFile io.Reader) {
 		pruneAccessRecords,
 	)
 
+	// the metrics name the repositories of every project, so they are only served to authorized users
+	if os.Getenv("DOCKER_REGISTRY_METRICS") == "true" {
+		metricsAccessRecords := func(*http.Request) []auth.Access {
+			return []auth.Access{
+				{
+					Resource: auth.Resource{
+						Type: "metrics",
+					},
+					Action: "get",
+				},
+			}
+		}
+		app.RegisterRoute(
+			// GET /metrics
+			app.NewRoute().Path("/metrics").Methods("GET"),
+			// handler
+			server.MetricsDispatcher,
+			// repo name not required in url
+			handlers.NameNotRequired,
+			// custom access records
+			metricsAccessRecords,
+		)
+	}
+
 	app.RegisterHealthChecks()
-	handler := alive("/", app)
+
+	// the audit log records pushes and pulls, to a file or to the standard output with "-"
+	var audit *server.AuditLogger
+	if auditLog := os.Getenv("DOCKER_REGISTRY_AUDIT_LOG"); len(auditLog) > 0 {
+		out := io.Writer(os.Stdout)
+		if auditLog != "-" {
+			f, err := os.OpenFile(auditLog, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+			if err != nil {
+				log.Fatalf("Unable to open the audit log: %v", err)
+			}
+			defer f.Close()
+			out = f
+		}
+		if audit, err = server.NewAuditLogger(out); err != nil {
+			log.Fatalf("Unable to configure the audit log: %v", err)
+		}
+		context.GetLogger(app).Infof("writing the audit log to %s", auditLog)
+	}
+
+	handler := server.InstrumentHandler(app, audit)
+	handler = alive("/", handler)
 	// TODO: temporarily keep for backwards compatibility; remove in the future
 	handler = alive("/healthz", handler)
 	handler = health.Handler(handler)
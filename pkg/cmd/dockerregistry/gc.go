@@ -0,0 +1,63 @@
+package dockerregistry
+
+import (
+	"io"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+
+	"github.com/docker/distribution/configuration"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/factory"
+
+	"github.com/openshift/origin/pkg/dockerregistry/server"
+)
+
+// ExecuteGarbageCollect deletes the blobs of the registry storage that are not referenced by any
+// image. It may run while the registry serves requests: blobs uploaded less than gracePeriod ago
+// are never deleted, and the registry refuses manifests while the blobs are being deleted.
+func ExecuteGarbageCollect(configFile io.Reader, gracePeriod time.Duration, dryRun bool) {
+	config, err := configuration.Parse(configFile)
+	if err != nil {
+		log.Fatalf("Error parsing configuration file: %s", err)
+	}
+
+	ctx := context.Background()
+	ctx, err = configureLogging(ctx, config)
+	if err != nil {
+		log.Fatalf("error configuring logger: %v", err)
+	}
+
+	driver, err := factory.Create(config.Storage.Type(), config.Storage.Parameters())
+	if err != nil {
+		log.Fatalf("Error creating storage driver: %v", err)
+	}
+	registry, err := storage.NewRegistry(ctx, driver, storage.EnableDelete)
+	if err != nil {
+		log.Fatalf("Error creating registry: %v", err)
+	}
+	client, err := server.NewRegistryOpenShiftClient()
+	if err != nil {
+		log.Fatalf("Error creating OpenShift client: %v", err)
+	}
+
+	gc := &server.GarbageCollector{
+		Client:       client,
+		Registry:     registry,
+		Driver:       driver,
+		GracePeriod:  gracePeriod,
+		SettlePeriod: server.DefaultGCSettlePeriod,
+		LockDuration: server.DefaultGCLockDuration,
+		DryRun:       dryRun,
+	}
+	deleted, err := gc.Run(ctx)
+	if err != nil {
+		log.Fatalf("Error collecting garbage: %v", err)
+	}
+	if dryRun {
+		log.Infof("garbage collection would delete %d blobs", len(deleted))
+		return
+	}
+	log.Infof("garbage collection deleted %d blobs", len(deleted))
+}
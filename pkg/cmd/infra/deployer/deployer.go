@@ -11,6 +11,7 @@ import (
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/kubectl"
 
+	osclient "github.com/openshift/origin/pkg/client"
 	"github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
@@ -45,7 +46,7 @@ func NewCommandDeployer(name string) *cobra.Command {
 		Short: "Run the deployer",
 		Long:  deployerLong,
 		Run: func(c *cobra.Command, args []string) {
-			_, kClient, err := cfg.Config.Clients()
+			osClient, kClient, err := cfg.Config.Clients()
 			if err != nil {
 				glog.Fatal(err)
 			}
@@ -58,7 +59,7 @@ func NewCommandDeployer(name string) *cobra.Command {
 				glog.Fatal("namespace is required")
 			}
 
-			deployer := NewDeployer(kClient)
+			deployer := NewDeployer(kClient, osClient, cfg.Config.KubeConfig())
 			if err = deployer.Deploy(cfg.Namespace, cfg.DeploymentName); err != nil {
 				glog.Fatal(err)
 			}
@@ -75,8 +76,10 @@ func NewCommandDeployer(name string) *cobra.Command {
 	return cmd
 }
 
-// NewDeployer makes a new Deployer from a kube client.
-func NewDeployer(client kclient.Interface) *Deployer {
+// NewDeployer makes a new Deployer from a kube client, an OpenShift client
+// used by lifecycle hooks which tag images, and the kube client config used
+// by lifecycle hooks which execute commands in pods.
+func NewDeployer(client kclient.Interface, oclient osclient.Interface, kubeConfig *kclient.Config) *Deployer {
 	scaler, _ := kubectl.ScalerFor(kapi.Kind("ReplicationController"), client)
 	return &Deployer{
 		getDeployment: func(namespace, name string) (*kapi.ReplicationController, error) {
@@ -89,10 +92,10 @@ func NewDeployer(client kclient.Interface) *Deployer {
 		strategyFor: func(config *deployapi.DeploymentConfig) (strategy.DeploymentStrategy, error) {
 			switch config.Spec.Strategy.Type {
 			case deployapi.DeploymentStrategyTypeRecreate:
-				return recreate.NewRecreateDeploymentStrategy(client, kapi.Codecs.UniversalDecoder()), nil
+				return recreate.NewRecreateDeploymentStrategy(client, oclient, kubeConfig, kapi.Codecs.UniversalDecoder()), nil
 			case deployapi.DeploymentStrategyTypeRolling:
-				recreate := recreate.NewRecreateDeploymentStrategy(client, kapi.Codecs.UniversalDecoder())
-				return rolling.NewRollingDeploymentStrategy(config.Namespace, client, kapi.Codecs.UniversalDecoder(), recreate), nil
+				recreate := recreate.NewRecreateDeploymentStrategy(client, oclient, kubeConfig, kapi.Codecs.UniversalDecoder())
+				return rolling.NewRollingDeploymentStrategy(config.Namespace, client, oclient, kubeConfig, kapi.Codecs.UniversalDecoder(), recreate), nil
 			default:
 				return nil, fmt.Errorf("unsupported strategy type: %s", config.Spec.Strategy.Type)
 			}
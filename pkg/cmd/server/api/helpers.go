@@ -193,6 +193,12 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 			case (*OpenIDIdentityProvider):
 				refs = append(refs, &provider.CA)
 
+			case (*SAMLIdentityProvider):
+				refs = append(refs, &provider.Metadata)
+				refs = append(refs, &provider.CA)
+				refs = append(refs, &provider.SigningCert.CertFile)
+				refs = append(refs, &provider.SigningCert.KeyFile)
+
 			}
 		}
 
@@ -480,7 +486,8 @@ func IsIdentityProviderType(provider runtime.Object) bool {
 		(*OpenIDIdentityProvider),
 		(*GitHubIdentityProvider),
 		(*GitLabIdentityProvider),
-		(*GoogleIdentityProvider):
+		(*GoogleIdentityProvider),
+		(*SAMLIdentityProvider):
 
 		return true
 	}
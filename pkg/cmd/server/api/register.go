@@ -48,6 +48,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 		&GitLabIdentityProvider{},
 		&GoogleIdentityProvider{},
 		&OpenIDIdentityProvider{},
+		&SAMLIdentityProvider{},
 
 		&LDAPSyncConfig{},
 	)
@@ -56,6 +57,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 func (obj *LDAPSyncConfig) GetObjectKind() unversioned.ObjectKind { return &obj.TypeMeta }
 
 func (obj *OpenIDIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
+func (obj *SAMLIdentityProvider) GetObjectKind() unversioned.ObjectKind          { return &obj.TypeMeta }
 func (obj *GoogleIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *GitLabIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *GitHubIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
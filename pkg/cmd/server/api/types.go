@@ -575,6 +575,18 @@ type IdentityProvider struct {
 	MappingMethod string
 	// Provider contains the information about how to set up a specific identity provider
 	Provider runtime.Object
+	// TOTP enables a time-based one-time password second factor for a password identity provider
+	TOTP *TOTPConfig
+}
+
+// TOTPConfig holds the settings of the time-based one-time password (RFC 6238) step that follows
+// a successful password login
+type TOTPConfig struct {
+	// Required forces users that have not enrolled a device yet to enroll one the next time they log in
+	// with a browser. When false, users that have not enrolled are offered to enroll and may skip it.
+	Required bool
+	// Issuer is the name shown by authenticator apps next to the account
+	Issuer string
 }
 
 type BasicAuthPasswordIdentityProvider struct {
@@ -754,6 +766,53 @@ type OpenIDClaims struct {
 	Email []string
 }
 
+type SAMLIdentityProvider struct {
+	unversioned.TypeMeta
+
+	// EntityID identifies the master to the identity provider. If empty, the URL the master serves its
+	// SAML metadata at is used
+	EntityID string
+
+	// Metadata is a file containing the SAML metadata (an EntityDescriptor with an IDPSSODescriptor) of the
+	// identity provider. The single sign-on URL, issuer and signing certificates are read from it.
+	// If empty, SingleSignOnURL, Issuer and CA are required
+	Metadata string
+
+	// SingleSignOnURL is the URL of the identity provider accepting authentication requests through the
+	// HTTP-Redirect binding
+	SingleSignOnURL string
+	// Issuer is the entity ID of the identity provider
+	Issuer string
+	// CA is a file with the certificates the identity provider signs its responses and assertions with
+	CA string
+
+	// SigningCert is the certificate and key used to sign authentication requests.
+	// The certificate must be known to the identity provider
+	SigningCert CertInfo
+
+	// NameIDFormat is the format of the name identifier requested from the identity provider.
+	// If empty, the format is left to the identity provider
+	NameIDFormat string
+
+	// Attributes mappings
+	Attributes SAMLAttributes
+}
+
+type SAMLAttributes struct {
+	// ID is the list of attributes whose values should be used as the user ID.
+	// If unspecified, the name identifier of the assertion subject is used
+	ID []string
+	// PreferredUsername is the list of attributes whose values should be used as the preferred username.
+	// If unspecified, the preferred username is determined from the user ID
+	PreferredUsername []string
+	// Name is the list of attributes whose values should be used as the display name. Optional.
+	// If unspecified, no display name is set for the identity
+	Name []string
+	// Email is the list of attributes whose values should be used as the email address. Optional.
+	// If unspecified, no email is set for the identity
+	Email []string
+}
+
 type GrantConfig struct {
 	// Method: allow, deny, prompt
 	Method GrantHandlerType
@@ -150,6 +150,11 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 				obj.MappingMethod = "claim"
 			}
 		},
+		func(obj *TOTPConfig) {
+			if len(obj.Issuer) == 0 {
+				obj.Issuer = "OpenShift"
+			}
+		},
 	)
 	if err != nil {
 		// If one of the conversion functions is malformed, detect it immediately.
@@ -266,6 +271,9 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 			out.UseAsChallenger = in.UseAsChallenger
 			out.UseAsLogin = in.UseAsLogin
 			out.MappingMethod = in.MappingMethod
+			if err := s.Convert(&in.TOTP, &out.TOTP, 0); err != nil {
+				return err
+			}
 			return nil
 		},
 		func(in *IdentityProvider, out *internal.IdentityProvider, s conversion.Scope) error {
@@ -283,6 +291,9 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 			out.UseAsChallenger = in.UseAsChallenger
 			out.UseAsLogin = in.UseAsLogin
 			out.MappingMethod = in.MappingMethod
+			if err := s.Convert(&in.TOTP, &out.TOTP, 0); err != nil {
+				return err
+			}
 			return nil
 		},
 		func(in *internal.AdmissionPluginConfig, out *AdmissionPluginConfig, s conversion.Scope) error {
@@ -34,6 +34,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 		&GitLabIdentityProvider{},
 		&GoogleIdentityProvider{},
 		&OpenIDIdentityProvider{},
+		&SAMLIdentityProvider{},
 
 		&LDAPSyncConfig{},
 	)
@@ -42,6 +43,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 func (obj *LDAPSyncConfig) GetObjectKind() unversioned.ObjectKind { return &obj.TypeMeta }
 
 func (obj *OpenIDIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
+func (obj *SAMLIdentityProvider) GetObjectKind() unversioned.ObjectKind          { return &obj.TypeMeta }
 func (obj *GoogleIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *GitLabIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
 func (obj *GitHubIdentityProvider) GetObjectKind() unversioned.ObjectKind        { return &obj.TypeMeta }
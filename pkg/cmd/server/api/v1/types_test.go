@@ -310,6 +310,27 @@ oauthConfig:
         authorize: ""
         token: ""
         userInfo: ""
+  - challenge: false
+    login: false
+    mappingMethod: ""
+    name: ""
+    provider:
+      apiVersion: v1
+      attributes:
+        email: null
+        id: null
+        name: null
+        preferredUsername: null
+      ca: ""
+      entityID: ""
+      issuer: ""
+      kind: SAMLIdentityProvider
+      metadata: ""
+      nameIDFormat: ""
+      signingCert:
+        certFile: ""
+        keyFile: ""
+      singleSignOnURL: ""
   masterCA: null
   masterPublicURL: ""
   masterURL: ""
@@ -410,6 +431,7 @@ func TestMasterConfig(t *testing.T) {
 				{Provider: &internal.GitLabIdentityProvider{}},
 				{Provider: &internal.GoogleIdentityProvider{}},
 				{Provider: &internal.OpenIDIdentityProvider{}},
+				{Provider: &internal.SAMLIdentityProvider{}},
 			},
 			SessionConfig: &internal.SessionConfig{},
 			Templates:     &internal.OAuthTemplates{},
@@ -194,9 +194,32 @@ func ValidateIdentityProvider(identityProvider api.IdentityProvider, fldPath *fi
 		case (*api.OpenIDIdentityProvider):
 			validationResults.AddErrors(ValidateOpenIDIdentityProvider(provider, identityProvider)...)
 
+		case (*api.SAMLIdentityProvider):
+			validationResults.Append(ValidateSAMLIdentityProvider(provider, identityProvider))
+
 		}
 	}
 
+	if identityProvider.TOTP != nil {
+		validationResults.Append(ValidateTOTPConfig(identityProvider, fldPath.Child("totp")))
+	}
+
+	return validationResults
+}
+
+func ValidateTOTPConfig(identityProvider api.IdentityProvider, fldPath *field.Path) ValidationResults {
+	validationResults := ValidationResults{}
+
+	if !api.IsPasswordAuthenticator(identityProvider) {
+		validationResults.AddErrors(field.Invalid(fldPath, identityProvider.TOTP, "a second factor can only be configured for password identity providers"))
+	}
+	if len(identityProvider.TOTP.Issuer) == 0 {
+		validationResults.AddErrors(field.Required(fldPath.Child("issuer"), ""))
+	}
+	if identityProvider.TOTP.Required && !identityProvider.UseAsLogin {
+		validationResults.AddWarnings(field.Invalid(fldPath.Child("required"), identityProvider.TOTP.Required, "users can only enroll through the login page, so challenging clients will be refused until users enroll with a browser"))
+	}
+
 	return validationResults
 }
 
@@ -353,6 +376,46 @@ func ValidateOpenIDIdentityProvider(provider *api.OpenIDIdentityProvider, identi
 	return allErrs
 }
 
+func ValidateSAMLIdentityProvider(provider *api.SAMLIdentityProvider, identityProvider api.IdentityProvider) ValidationResults {
+	validationResults := ValidationResults{}
+
+	providerPath := field.NewPath("provider")
+
+	if len(provider.Metadata) != 0 {
+		validationResults.AddErrors(ValidateFile(provider.Metadata, providerPath.Child("metadata"))...)
+
+		// The identity provider is described either by its metadata or by the individual fields
+		if len(provider.SingleSignOnURL) != 0 {
+			validationResults.AddErrors(field.Invalid(providerPath.Child("singleSignOnURL"), provider.SingleSignOnURL, "may not be set when metadata is specified"))
+		}
+		if len(provider.Issuer) != 0 {
+			validationResults.AddErrors(field.Invalid(providerPath.Child("issuer"), provider.Issuer, "may not be set when metadata is specified"))
+		}
+		if len(provider.CA) != 0 {
+			validationResults.AddErrors(field.Invalid(providerPath.Child("ca"), provider.CA, "may not be set when metadata is specified"))
+		}
+	} else {
+		_, urlErrs := ValidateSecureURL(provider.SingleSignOnURL, providerPath.Child("singleSignOnURL"))
+		validationResults.AddErrors(urlErrs...)
+		if len(provider.Issuer) == 0 {
+			validationResults.AddErrors(field.Required(providerPath.Child("issuer"), ""))
+		}
+		validationResults.AddErrors(ValidateFile(provider.CA, providerPath.Child("ca"))...)
+	}
+
+	// Authentication requests are always signed
+	validationResults.AddErrors(ValidateCertInfo(provider.SigningCert, true, providerPath.Child("signingCert"))...)
+
+	if identityProvider.UseAsChallenger {
+		validationResults.AddErrors(field.Invalid(field.NewPath("challenge"), identityProvider.UseAsChallenger, "SAML providers cannot be used for challenges"))
+	}
+	if !identityProvider.UseAsLogin {
+		validationResults.AddWarnings(field.Invalid(field.NewPath("login"), identityProvider.UseAsLogin, "SAML providers can only be used to log in with a browser"))
+	}
+
+	return validationResults
+}
+
 func validateGrantConfig(config api.GrantConfig, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -169,6 +169,7 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 				{Verbs: sets.NewString("list"), Resources: sets.NewString("projectrequests")},
 				{Verbs: sets.NewString("list", "get"), Resources: sets.NewString("clusterroles")},
 				{Verbs: sets.NewString("list"), Resources: sets.NewString("projects")},
+				{Verbs: sets.NewString("list", "get", "delete"), Resources: sets.NewString("useroauthaccesstokens")},
 				{Verbs: sets.NewString("create"), Resources: sets.NewString("subjectaccessreviews", "localsubjectaccessreviews"), AttributeRestrictions: &authorizationapi.IsPersonalSubjectAccessReview{}},
 			},
 		},
@@ -261,6 +262,21 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 				},
 			},
 		},
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: ImageSignerRoleName,
+			},
+			Rules: []authorizationapi.PolicyRule{
+				{
+					Verbs:     sets.NewString("create", "delete"),
+					Resources: sets.NewString("imagesignatures"),
+				},
+				{
+					Verbs:     sets.NewString("get", "list"),
+					Resources: sets.NewString("images"),
+				},
+			},
+		},
 		{
 			ObjectMeta: kapi.ObjectMeta{
 				Name: DeployerRoleName,
@@ -289,6 +305,18 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 					Verbs:     sets.NewString("get"),
 					Resources: sets.NewString("pods/log"),
 				},
+				{
+					// RecreateDeploymentStrategy.hookExecutor
+					// RollingDeploymentStrategy.hookExecutor
+					Verbs:     sets.NewString("create", "get"),
+					Resources: sets.NewString("pods/exec"),
+				},
+				{
+					// RecreateDeploymentStrategy.hookExecutor
+					// RollingDeploymentStrategy.hookExecutor
+					Verbs:     sets.NewString("get", "create", "update"),
+					Resources: sets.NewString("imagestreams"),
+				},
 			},
 		},
 		{
@@ -331,7 +359,7 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 			},
 			Rules: []authorizationapi.PolicyRule{
 				{
-					Verbs:     sets.NewString("get", "delete"),
+					Verbs:     sets.NewString("get", "list", "delete"),
 					Resources: sets.NewString("images"),
 				},
 				{
@@ -346,6 +374,10 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 					Verbs:     sets.NewString("create"),
 					Resources: sets.NewString("imagestreammappings"),
 				},
+				{
+					Verbs:     sets.NewString("list"),
+					Resources: sets.NewString("imagestreams", "resourcequotas"),
+				},
 			},
 		},
 		{
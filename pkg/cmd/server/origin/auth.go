@@ -1,6 +1,7 @@
 package origin
 
 import (
+	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"errors"
@@ -20,12 +21,14 @@ import (
 	kerrs "k8s.io/kubernetes/pkg/api/errors"
 	kuser "k8s.io/kubernetes/pkg/auth/user"
 	"k8s.io/kubernetes/pkg/client/unversioned"
+	kutil "k8s.io/kubernetes/pkg/util"
 	knet "k8s.io/kubernetes/pkg/util/net"
 	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/auth/authenticator"
 	"github.com/openshift/origin/pkg/auth/authenticator/challenger/passwordchallenger"
 	"github.com/openshift/origin/pkg/auth/authenticator/challenger/placeholderchallenger"
+	"github.com/openshift/origin/pkg/auth/authenticator/challenger/totpchallenger"
 	"github.com/openshift/origin/pkg/auth/authenticator/password/allowanypassword"
 	"github.com/openshift/origin/pkg/auth/authenticator/password/basicauthpassword"
 	"github.com/openshift/origin/pkg/auth/authenticator/password/denypassword"
@@ -35,6 +38,7 @@ import (
 	"github.com/openshift/origin/pkg/auth/authenticator/redirector"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/basicauthrequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/headerrequest"
+	"github.com/openshift/origin/pkg/auth/authenticator/request/totprequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/unionrequest"
 	"github.com/openshift/origin/pkg/auth/authenticator/request/x509request"
 	"github.com/openshift/origin/pkg/auth/ldaputil"
@@ -45,12 +49,16 @@ import (
 	"github.com/openshift/origin/pkg/auth/oauth/external/openid"
 	"github.com/openshift/origin/pkg/auth/oauth/handlers"
 	"github.com/openshift/origin/pkg/auth/oauth/registry"
+	"github.com/openshift/origin/pkg/auth/saml"
 	"github.com/openshift/origin/pkg/auth/server/csrf"
 	"github.com/openshift/origin/pkg/auth/server/errorpage"
 	"github.com/openshift/origin/pkg/auth/server/grant"
+	"github.com/openshift/origin/pkg/auth/server/introspect"
 	"github.com/openshift/origin/pkg/auth/server/login"
 	"github.com/openshift/origin/pkg/auth/server/selectprovider"
 	"github.com/openshift/origin/pkg/auth/server/tokenrequest"
+	"github.com/openshift/origin/pkg/auth/server/totplogin"
+	"github.com/openshift/origin/pkg/auth/totp"
 	"github.com/openshift/origin/pkg/auth/userregistry/identitymapper"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
@@ -63,6 +71,8 @@ import (
 	clientetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclient/etcd"
 	clientauthregistry "github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization"
 	clientauthetcd "github.com/openshift/origin/pkg/oauth/registry/oauthclientauthorization/etcd"
+	totpenrollmentregistry "github.com/openshift/origin/pkg/oauth/registry/oauthtotpenrollment"
+	totpenrollmentetcd "github.com/openshift/origin/pkg/oauth/registry/oauthtotpenrollment/etcd"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver"
 	"github.com/openshift/origin/pkg/oauth/server/osinserver/registrystorage"
 )
@@ -122,7 +132,11 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 			handlers.NewAuthorizeAuthenticator(
 				authRequestHandler,
 				authHandler,
-				errorPageHandler,
+				handlers.AuthenticationErrorHandlers{
+					// clients requesting challenges are asked for a verification code when one is missing
+					totpchallenger.New("openshift", OpenShiftOAuthTokenRequestURL(c.Options.MasterPublicURL)),
+					errorPageHandler,
+				},
 			),
 			handlers.NewGrantCheck(
 				grantChecker,
@@ -163,6 +177,8 @@ func (c *AuthConfig) InstallAPI(container *restful.Container) []string {
 	tokenRequestEndpoints := tokenrequest.NewEndpoints(c.Options.MasterPublicURL, osOAuthClient)
 	tokenRequestEndpoints.Install(mux, OpenShiftOAuthAPIPrefix)
 
+	introspect.NewIntrospect(accessTokenRegistry, clientRegistry, c.UserRegistry).Install(mux, OpenShiftOAuthAPIPrefix)
+
 	// glog.Infof("oauth server configured as: %#v", server)
 	// glog.Infof("auth handler: %#v", authHandler)
 	// glog.Infof("auth request handler: %#v", authRequestHandler)
@@ -373,7 +389,25 @@ func (c *AuthConfig) getAuthenticationHandler(mux cmdutil.Mux, errorHandler hand
 				if c.SessionAuth == nil {
 					return nil, errors.New("SessionAuth is required for password-based login")
 				}
-				passwordSuccessHandler := handlers.AuthenticationSuccessHandlers{c.SessionAuth, redirectSuccessHandler{}}
+				passwordSuccessHandler := handlers.AuthenticationSuccessHandler(handlers.AuthenticationSuccessHandlers{c.SessionAuth, redirectSuccessHandler{}})
+
+				// With a second factor, the session is only saved once the verification code was given
+				if identityProvider.TOTP != nil {
+					totpLogin := totplogin.NewTOTPLogin(
+						OpenShiftLoginPrefix+"/totp",
+						OpenShiftLoginPrefix,
+						c.getCSRF(),
+						c.getTOTPVerifier(identityProvider),
+						identityProvider.TOTP.Required,
+						c.SessionStore,
+						c.Options.SessionConfig.SessionName+"-totp",
+						passwordSuccessHandler,
+						totplogin.DefaultFormRenderer,
+						kutil.RealClock{},
+					)
+					totpLogin.Install(mux)
+					passwordSuccessHandler = totpLogin
+				}
 
 				// Since we're redirecting to a local login page, we don't need to force absolute URL resolution
 				redirectors[identityProvider.Name] = redirector.NewRedirector(nil, OpenShiftLoginPrefix+"?then=${url}")
@@ -427,6 +461,35 @@ func (c *AuthConfig) getAuthenticationHandler(mux cmdutil.Mux, errorHandler hand
 			if identityProvider.UseAsChallenger {
 				return nil, errors.New("oauth identity providers cannot issue challenges")
 			}
+		} else if samlProvider, isSAML := identityProvider.Provider.(*configapi.SAMLIdentityProvider); isSAML {
+			// The identity provider posts responses to the callback path, and reads our metadata under it
+			callbackPath := path.Join(OpenShiftOAuthCallbackPrefix, identityProvider.Name)
+			metadataPath := path.Join(callbackPath, "metadata")
+			provider, err := c.getSAMLProvider(identityProvider.Name, samlProvider, c.Options.MasterPublicURL+callbackPath, c.Options.MasterPublicURL+metadataPath)
+			if err != nil {
+				return nil, err
+			}
+
+			// Default state builder, combining CSRF and return URL handling
+			state := external.CSRFRedirectingState(c.getCSRF())
+
+			// SAML auth requires
+			// 1. a session success handler (to remember you logged in)
+			// 2. a state success handler (to go back to the URL encoded in the relay state)
+			if c.SessionAuth == nil {
+				return nil, errors.New("SessionAuth is required for SAML-based login")
+			}
+			samlSuccessHandler := handlers.AuthenticationSuccessHandlers{c.SessionAuth, state}
+
+			samlHandler := saml.NewHandler(provider, state, samlSuccessHandler, errorHandler, identityMapper)
+			mux.Handle(callbackPath, samlHandler)
+			mux.HandleFunc(metadataPath, samlHandler.ServeMetadata)
+			if identityProvider.UseAsLogin {
+				redirectors[identityProvider.Name] = samlHandler
+			}
+			if identityProvider.UseAsChallenger {
+				return nil, errors.New("SAML identity providers cannot issue challenges")
+			}
 		} else if requestHeaderProvider, isRequestHeader := identityProvider.Provider.(*configapi.RequestHeaderIdentityProvider); isRequestHeader {
 			// We might be redirecting to an external site, we need to fully resolve the request URL to the public master
 			baseRequestURL, err := url.Parse(c.Options.MasterPublicURL + OpenShiftOAuthAPIPrefix + osinserver.AuthorizePath)
@@ -514,6 +577,61 @@ func (c *AuthConfig) getOAuthProvider(identityProvider configapi.IdentityProvide
 
 }
 
+func (c *AuthConfig) getSAMLProvider(providerName string, provider *configapi.SAMLIdentityProvider, callbackURL, metadataURL string) (*saml.Provider, error) {
+	signingCert, err := tls.LoadX509KeyPair(provider.SigningCert.CertFile, provider.SigningCert.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading SAML signing certificate: %v", err)
+	}
+	signingKey, ok := signingCert.PrivateKey.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("SAML signing key must be an RSA key")
+	}
+	cert, err := x509.ParseCertificate(signingCert.Certificate[0])
+	if err != nil {
+		return nil, err
+	}
+
+	config := saml.Config{
+		EntityID:                    provider.EntityID,
+		AssertionConsumerServiceURL: callbackURL,
+		SigningKey:                  signingKey,
+		SigningCert:                 cert,
+		NameIDFormat:                provider.NameIDFormat,
+
+		IDAttributes:                provider.Attributes.ID,
+		PreferredUsernameAttributes: provider.Attributes.PreferredUsername,
+		EmailAttributes:             provider.Attributes.Email,
+		NameAttributes:              provider.Attributes.Name,
+	}
+	if len(config.EntityID) == 0 {
+		config.EntityID = metadataURL
+	}
+
+	if len(provider.Metadata) > 0 {
+		data, err := ioutil.ReadFile(provider.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading %s: %v", provider.Metadata, err)
+		}
+		metadata, err := saml.ParseIdentityProviderMetadata(data)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading SAML metadata from %s: %v", provider.Metadata, err)
+		}
+		config.SingleSignOnURL = metadata.SingleSignOnURL
+		config.Issuer = metadata.EntityID
+		config.Certificates = metadata.Certificates
+	} else {
+		certs, err := cmdutil.CertificatesFromFile(provider.CA)
+		if err != nil {
+			return nil, err
+		}
+		config.SingleSignOnURL = provider.SingleSignOnURL
+		config.Issuer = provider.Issuer
+		config.Certificates = certs
+	}
+
+	return saml.NewProvider(providerName, config)
+}
+
 func (c *AuthConfig) getPasswordAuthenticator(identityProvider configapi.IdentityProvider) (authenticator.Password, error) {
 	identityMapper, err := identitymapper.NewIdentityUserMapper(c.IdentityRegistry, c.UserRegistry, identitymapper.MappingMethodType(identityProvider.MappingMethod))
 	if err != nil {
@@ -607,7 +725,13 @@ func (c *AuthConfig) getAuthenticationRequestHandler() (authenticator.Request, e
 			if err != nil {
 				return nil, err
 			}
-			authRequestHandlers = append(authRequestHandlers, basicauthrequest.NewBasicAuthAuthentication(passwordAuthenticator, true))
+			var authRequestHandler authenticator.Request = basicauthrequest.NewBasicAuthAuthentication(passwordAuthenticator, true)
+
+			// Require a verification code along with the basic credentials
+			if identityProvider.TOTP != nil {
+				authRequestHandler = totprequest.NewAuthenticator(authRequestHandler, c.getTOTPVerifier(identityProvider), identityProvider.TOTP.Required)
+			}
+			authRequestHandlers = append(authRequestHandlers, authRequestHandler)
 
 		} else {
 			switch provider := identityProvider.Provider.(type) {
@@ -643,6 +767,12 @@ func (c *AuthConfig) getAuthenticationRequestHandler() (authenticator.Request, e
 	return authRequestHandler, nil
 }
 
+// getTOTPVerifier returns the verifier of the second factor configured for a password identity provider
+func (c *AuthConfig) getTOTPVerifier(identityProvider configapi.IdentityProvider) *totp.Verifier {
+	enrollmentRegistry := totpenrollmentregistry.NewRegistry(totpenrollmentetcd.NewREST(c.EtcdHelper))
+	return totp.NewVerifier(enrollmentRegistry, identityProvider.TOTP.Issuer, kutil.RealClock{})
+}
+
 // callbackPasswordAuthenticator combines password auth, successful login callback,
 // and "then" param redirection
 type callbackPasswordAuthenticator struct {
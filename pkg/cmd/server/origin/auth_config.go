@@ -37,7 +37,9 @@ type AuthConfig struct {
 	UserRegistry     userregistry.Registry
 	IdentityRegistry identityregistry.Registry
 
-	SessionAuth *session.Authenticator
+	// SessionStore holds the cookie sessions of browser logins
+	SessionStore session.Store
+	SessionAuth  *session.Authenticator
 }
 
 func BuildAuthConfig(options configapi.MasterConfig) (*AuthConfig, error) {
@@ -67,14 +69,16 @@ func BuildAuthConfig(options configapi.MasterConfig) (*AuthConfig, error) {
 		etcdBackends = append(etcdBackends, backendEtcdHelper)
 	}
 
+	var sessionStore session.Store
 	var sessionAuth *session.Authenticator
 	if options.OAuthConfig.SessionConfig != nil {
 		secure := isHTTPS(options.OAuthConfig.MasterPublicURL)
-		auth, err := BuildSessionAuth(secure, options.OAuthConfig.SessionConfig)
+		store, err := BuildSessionStore(secure, options.OAuthConfig.SessionConfig)
 		if err != nil {
 			return nil, err
 		}
-		sessionAuth = auth
+		sessionStore = store
+		sessionAuth = session.NewAuthenticator(sessionStore, options.OAuthConfig.SessionConfig.SessionName)
 	}
 
 	// Build the list of valid redirect_uri prefixes for a login using the openshift-web-console client to redirect to
@@ -100,18 +104,26 @@ func BuildAuthConfig(options configapi.MasterConfig) (*AuthConfig, error) {
 		IdentityRegistry: identityRegistry,
 		UserRegistry:     userRegistry,
 
-		SessionAuth: sessionAuth,
+		SessionStore: sessionStore,
+		SessionAuth:  sessionAuth,
 	}
 
 	return ret, nil
 }
 
-func BuildSessionAuth(secure bool, config *configapi.SessionConfig) (*session.Authenticator, error) {
+func BuildSessionStore(secure bool, config *configapi.SessionConfig) (session.Store, error) {
 	secrets, err := getSessionSecrets(config.SessionSecretsFile)
 	if err != nil {
 		return nil, err
 	}
-	sessionStore := session.NewStore(secure, int(config.SessionMaxAgeSeconds), secrets...)
+	return session.NewStore(secure, int(config.SessionMaxAgeSeconds), secrets...), nil
+}
+
+func BuildSessionAuth(secure bool, config *configapi.SessionConfig) (*session.Authenticator, error) {
+	sessionStore, err := BuildSessionStore(secure, config)
+	if err != nil {
+		return nil, err
+	}
 	return session.NewAuthenticator(sessionStore, config.SessionName), nil
 }
 
@@ -548,6 +548,35 @@ func (c *MasterConfig) OriginNamespaceControllerClients() (*osclient.Client, *kc
 	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
 }
 
+// ImageQuotaControllerClients returns the image quota controller client objects
+// The openshift client object must have authority to read image streams and images in any namespace
+// The kubernetes client object must have authority to update the status of resource quotas in any namespace
+func (c *MasterConfig) ImageQuotaControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+}
+
+// ImageRetentionControllerClients returns the image retention controller client objects
+// The openshift client object must have authority to prune images and image streams and to read builds, build configs and deployment configs in any namespace
+// The kubernetes client object must have authority to read pods and replication controllers in any namespace
+func (c *MasterConfig) ImageRetentionControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+}
+
+// ImageNotificationControllerClients returns the image notification controller client objects
+// The openshift client object must have authority to list and watch image streams in any namespace
+// The kubernetes client object must have authority to read secrets in any namespace
+func (c *MasterConfig) ImageNotificationControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+}
+
+// TemplateInstanceControllerClients returns the template instance controller client objects
+// The clients must have authority to manage template instances and their parameter secrets, to
+// process templates, to review the access of the requesters of template instances and to manage
+// any object created from a template, in any namespace
+func (c *MasterConfig) TemplateInstanceControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+}
+
 // NewEtcdStorage returns a storage interface for the provided storage version.
 func NewEtcdStorage(client newetcdclient.Client, version unversioned.GroupVersion, prefix string) (oshelper storage.Interface, err error) {
 	return etcdstorage.NewEtcdStorage(client, kapi.Codecs.LegacyCodec(version), prefix), nil
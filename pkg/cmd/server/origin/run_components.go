@@ -4,20 +4,27 @@ import (
 	"io/ioutil"
 	"net"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
 
 	"k8s.io/kubernetes/pkg/admission"
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apimachinery/registered"
 	sacontroller "k8s.io/kubernetes/pkg/controller/serviceaccount"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
 	"k8s.io/kubernetes/pkg/registry/service/allocator"
 	etcdallocator "k8s.io/kubernetes/pkg/registry/service/allocator/etcd"
 	"k8s.io/kubernetes/pkg/serviceaccount"
 	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/sets"
 	serviceaccountadmission "k8s.io/kubernetes/plugin/pkg/admission/serviceaccount"
 
+	"github.com/openshift/origin/pkg/api/latest"
+	strategyrestrictions "github.com/openshift/origin/pkg/build/admission/strategyrestrictions"
 	buildclient "github.com/openshift/origin/pkg/build/client"
 	buildcontrollerfactory "github.com/openshift/origin/pkg/build/controller/factory"
 	buildstrategy "github.com/openshift/origin/pkg/build/controller/strategy"
@@ -30,13 +37,17 @@ import (
 	imagechangecontroller "github.com/openshift/origin/pkg/deploy/controller/imagechange"
 	"github.com/openshift/origin/pkg/dns"
 	imagecontroller "github.com/openshift/origin/pkg/image/controller"
+	imageprune "github.com/openshift/origin/pkg/image/prune"
 	projectcontroller "github.com/openshift/origin/pkg/project/controller"
+	imagequota "github.com/openshift/origin/pkg/quota/image"
 	securitycontroller "github.com/openshift/origin/pkg/security/controller"
 	"github.com/openshift/origin/pkg/security/mcs"
 	"github.com/openshift/origin/pkg/security/uid"
 	"github.com/openshift/origin/pkg/security/uidallocator"
+	templatecontroller "github.com/openshift/origin/pkg/template/controller"
 
 	"github.com/openshift/openshift-sdn/plugins/osdn/factory"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 	serviceaccountcontrollers "github.com/openshift/origin/pkg/serviceaccounts/controllers"
@@ -362,6 +373,98 @@ func (c *MasterConfig) RunImageImportController() {
 	}
 }
 
+// RunImageQuotaController starts the controller that reports the image storage usage of projects
+// in their resource quotas.
+func (c *MasterConfig) RunImageQuotaController() {
+	osclient, kclient := c.ImageQuotaControllerClients()
+	factory := imagequota.ImageQuotaControllerFactory{
+		Client:         osclient,
+		KubeClient:     kclient,
+		ResyncInterval: 5 * time.Minute,
+	}
+	controller := factory.Create()
+	controller.Run()
+}
+
+// RunImageRetentionController starts the controller that periodically prunes the images dropped
+// by the retention policies of image streams.
+func (c *MasterConfig) RunImageRetentionController() {
+	osclient, kclient := c.ImageRetentionControllerClients()
+	controller := imageprune.RetentionController{
+		Client:          osclient,
+		KubeClient:      kclient,
+		KeepYoungerThan: time.Hour,
+	}
+	controller.RunUntil(30*time.Minute, util.NeverStop)
+}
+
+// RunImageNotificationController starts the controller that notifies the endpoints of image streams
+// when their tags are updated.
+func (c *MasterConfig) RunImageNotificationController() {
+	osclient, kclient := c.ImageNotificationControllerClients()
+	// notifications are never sent to the pods and services of the cluster
+	blocked := []*net.IPNet{}
+	for _, cidr := range []string{c.Options.NetworkConfig.ClusterNetworkCIDR, c.Options.NetworkConfig.ServiceNetworkCIDR} {
+		cidr = strings.TrimSpace(cidr)
+		if len(cidr) == 0 {
+			continue
+		}
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			glog.Fatalf("Unable to parse the network %q: %v", cidr, err)
+		}
+		blocked = append(blocked, network)
+	}
+	controller := imagecontroller.NewNotificationController(osclient, kclient, imagecontroller.NotificationControllerOptions{
+		Resync:          10 * time.Minute,
+		Workers:         5,
+		BlockedNetworks: blocked,
+	})
+	controller.Run()
+}
+
+// RunTemplateInstanceController starts the controller that creates and manages the objects of
+// template instances.
+func (c *MasterConfig) RunTemplateInstanceController() {
+	osclient, kclient := c.TemplateInstanceControllerClients()
+
+	var restMapper meta.MultiRESTMapper
+	seenGroups := sets.String{}
+	for _, gv := range registered.EnabledVersions() {
+		if seenGroups.Has(gv.Group) {
+			continue
+		}
+		seenGroups.Insert(gv.Group)
+
+		groupMeta, err := registered.Group(gv.Group)
+		if err != nil {
+			continue
+		}
+		restMapper = meta.MultiRESTMapper(append(restMapper, groupMeta.RESTMapper))
+	}
+
+	// the objects are created by the controller, so the admission plugins which depend on the user
+	// are run by the controller as the requester of each template instance
+	scc := admission.NewFromPlugins(c.PrivilegedLoopbackKubernetesClient, []string{"SecurityContextConstraint"}, "")
+	buildByStrategy := strategyrestrictions.NewBuildByStrategy()
+	pluginInitializer := oadmission.PluginInitializer{OpenshiftClient: osclient}
+	pluginInitializer.Initialize([]admission.Interface{buildByStrategy})
+
+	controller := templatecontroller.NewTemplateInstanceController(osclient, kclient, templatecontroller.TemplateInstanceControllerOptions{
+		Resync:  time.Minute,
+		Workers: 5,
+		Mapper:  restMapper,
+		ClientForMapping: func(mapping *meta.RESTMapping) (resource.RESTClient, error) {
+			if latest.OriginKind(mapping.GroupVersionKind) {
+				return osclient, nil
+			}
+			return kclient, nil
+		},
+		Admission: admission.NewChainHandler(scc, buildByStrategy),
+	})
+	controller.Run()
+}
+
 // RunSecurityAllocationController starts the security allocation controller process.
 func (c *MasterConfig) RunSecurityAllocationController() {
 	alloc := c.Options.ProjectConfig.SecurityAllocator
@@ -21,12 +21,13 @@ var admissionPluginsNotUsedByKube = sets.NewString(
 	"DenyExecOnPrivileged",   // from kube (deprecated, see below), it denies exec to pods that have certain privileges.  This is superceded in origin by SCCExecRestrictions that checks against SCC rules.
 	"DenyEscalatingExec",     // from kube, it denies exec to pods that have certain privileges.  This is superceded in origin by SCCExecRestrictions that checks against SCC rules.
 
-	"BuildByStrategy",          // from origin, only needed for managing builds, not kubernetes resources
-	"BuildDefaults",            // from origin, only needed for managing builds, not kubernetes resources
-	"BuildOverrides",           // from origin, only needed for managing builds, not kubernetes resources
-	"OriginNamespaceLifecycle", // from origin, only needed for rejecting openshift resources, so not needed by kube
-	"ProjectRequestLimit",      // from origin, used for limiting project requests by user (online use case)
-	"RunOnceDuration",          // from origin, used for overriding the ActiveDeadlineSeconds for run-once pods
+	"BuildByStrategy",            // from origin, only needed for managing builds, not kubernetes resources
+	"BuildDefaults",              // from origin, only needed for managing builds, not kubernetes resources
+	"BuildOverrides",             // from origin, only needed for managing builds, not kubernetes resources
+	"ImageSignatureVerification", // from origin, used for refusing pods with images not signed by a trusted key
+	"OriginNamespaceLifecycle",   // from origin, only needed for rejecting openshift resources, so not needed by kube
+	"ProjectRequestLimit",        // from origin, used for limiting project requests by user (online use case)
+	"RunOnceDuration",            // from origin, used for overriding the ActiveDeadlineSeconds for run-once pods
 
 	"NamespaceExists",  // superceded by NamespaceLifecycle
 	"InitialResources", // do we want this? https://github.com/kubernetes/kubernetes/blob/master/docs/proposals/initial-resources.md
@@ -583,6 +583,10 @@ func startControllers(oc *origin.MasterConfig, kc *kubernetes.MasterConfig) erro
 	oc.RunDeploymentConfigChangeController()
 	oc.RunDeploymentImageChangeTriggerController()
 	oc.RunImageImportController()
+	oc.RunImageQuotaController()
+	oc.RunImageRetentionController()
+	oc.RunImageNotificationController()
+	oc.RunTemplateInstanceController()
 	oc.RunOriginNamespaceController()
 	oc.RunSDNController()
 
@@ -22,11 +22,17 @@ const CSRFTokenHeader = "X-CSRF-Token"
 // RequestToken uses the cmd arguments to locate an openshift oauth server and attempts to authenticate
 // it returns the access token if it gets one.  An error if it does not
 func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername string, defaultPassword string) (string, error) {
-	challengeHandler := &BasicChallengeHandler{
-		Host:     clientCfg.Host,
-		Reader:   reader,
-		Username: defaultUsername,
-		Password: defaultPassword,
+	challengeHandlers := []ChallengeHandler{
+		&BasicChallengeHandler{
+			Host:     clientCfg.Host,
+			Reader:   reader,
+			Username: defaultUsername,
+			Password: defaultPassword,
+		},
+		// a verification code is asked for once the basic credentials were accepted
+		&TOTPChallengeHandler{
+			Reader: reader,
+		},
 	}
 
 	rt, err := kclient.TransportFor(clientCfg)
@@ -52,7 +58,8 @@ func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername s
 
 		if resp.StatusCode == http.StatusUnauthorized {
 			if resp.Header.Get("WWW-Authenticate") != "" {
-				if !challengeHandler.CanHandle(resp.Header) {
+				challengeHandler := findChallengeHandler(challengeHandlers, resp.Header)
+				if challengeHandler == nil {
 					return "", apierrs.NewUnauthorized("unhandled challenge")
 				}
 				// Handle a challenge
@@ -67,8 +74,10 @@ func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername s
 				// Reset request set/list. Since we're setting different headers, it is legitimate to request the same urls
 				requestedURLSet = sets.NewString()
 				requestedURLList = []string{}
-				// Use the response to the challenge as the new headers
-				requestHeaders = newRequestHeaders
+				// Add the response to the challenge to the headers, so a second challenge keeps the credentials given to the first one
+				for k, v := range newRequestHeaders {
+					requestHeaders[k] = v
+				}
 				continue
 			}
 
@@ -115,6 +124,23 @@ func RequestToken(clientCfg *kclient.Config, reader io.Reader, defaultUsername s
 	}
 }
 
+// ChallengeHandler responds to the WWW-Authenticate challenges it recognizes
+type ChallengeHandler interface {
+	// CanHandle returns true if the handler recognizes a challenge in the headers
+	CanHandle(headers http.Header) bool
+	// HandleChallenge returns the headers to retry the request with, and whether the request should be retried
+	HandleChallenge(headers http.Header) (http.Header, bool, error)
+}
+
+func findChallengeHandler(challengeHandlers []ChallengeHandler, headers http.Header) ChallengeHandler {
+	for _, challengeHandler := range challengeHandlers {
+		if challengeHandler.CanHandle(headers) {
+			return challengeHandler
+		}
+	}
+	return nil
+}
+
 func oauthAuthorizeResult(location string) (string, error) {
 	u, err := url.Parse(location)
 	if err != nil {
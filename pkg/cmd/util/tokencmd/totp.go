@@ -0,0 +1,70 @@
+package tokencmd
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/golang/glog"
+
+	"github.com/openshift/origin/pkg/cmd/util"
+)
+
+// TOTPCodeHeader holds the verification code given along with the basic credentials
+// Corresponds to the header expected by TOTP challenging authenticators
+const TOTPCodeHeader = "X-TOTP-Code"
+
+type TOTPChallengeHandler struct {
+	// Reader is used to prompt for a verification code. If nil, no prompting is done
+	Reader io.Reader
+	// Writer is used to output prompts. If nil, stdout is used
+	Writer io.Writer
+
+	// Code is the verification code to use when challenged. If empty, a prompt is issued to a non-nil Reader
+	Code string
+
+	// handled tracks whether this handler has already handled a challenge.
+	handled bool
+}
+
+func (c *TOTPChallengeHandler) CanHandle(headers http.Header) bool {
+	for _, challengeHeader := range headers[http.CanonicalHeaderKey("WWW-Authenticate")] {
+		if totpRegex.MatchString(challengeHeader) {
+			return true
+		}
+	}
+	return false
+}
+func (c *TOTPChallengeHandler) HandleChallenge(headers http.Header) (http.Header, bool, error) {
+	if c.handled {
+		// the server challenged again, so the code we gave was rejected
+		return nil, false, errors.New("the verification code was not accepted")
+	}
+
+	code := c.Code
+	if len(code) == 0 && c.Reader != nil {
+		w := c.Writer
+		if w == nil {
+			w = os.Stdout
+		}
+		code = util.PromptForString(c.Reader, w, "Verification code: ")
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == 0 {
+		glog.V(2).Info("no verification code available")
+		return nil, false, errors.New("a verification code is required")
+	}
+
+	responseHeaders := http.Header{}
+	responseHeaders.Set(TOTPCodeHeader, code)
+	// remember so we don't re-prompt
+	c.handled = true
+	return responseHeaders, true, nil
+}
+
+// if this matches a WWW-Authenticate header, it is a TOTP challenge
+var totpRegex = regexp.MustCompile(`(?i)^\s*totp(?:\s+|$)`)
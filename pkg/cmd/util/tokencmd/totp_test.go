@@ -0,0 +1,105 @@
+package tokencmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestTOTPHandleChallenge(t *testing.T) {
+	totpChallenge := http.Header{WWW_AUTHENTICATE: []string{`TOTP realm="openshift"`}}
+	basicChallenge := http.Header{WWW_AUTHENTICATE: []string{`Basic realm="openshift"`}}
+	codeHeaders := func(code string) http.Header {
+		return http.Header{http.CanonicalHeaderKey(TOTPCodeHeader): []string{code}}
+	}
+
+	testCases := map[string]struct {
+		Handler    *TOTPChallengeHandler
+		Challenges []Challenge
+	}{
+		"basic challenge": {
+			Handler: &TOTPChallengeHandler{},
+			Challenges: []Challenge{
+				{
+					Headers:           basicChallenge,
+					ExpectedCanHandle: false,
+				},
+			},
+		},
+
+		"non-interactive with no code": {
+			Handler: &TOTPChallengeHandler{},
+			Challenges: []Challenge{
+				{
+					Headers:           totpChallenge,
+					ExpectedCanHandle: true,
+					ExpectedHeaders:   nil,
+					ExpectedHandled:   false,
+					ExpectedErr:       errors.New("a verification code is required"),
+				},
+			},
+		},
+
+		"non-interactive with code": {
+			Handler: &TOTPChallengeHandler{Code: "123456"},
+			Challenges: []Challenge{
+				{
+					Headers:           totpChallenge,
+					ExpectedCanHandle: true,
+					ExpectedHeaders:   codeHeaders("123456"),
+					ExpectedHandled:   true,
+				},
+			},
+		},
+
+		"interactive": {
+			Handler: &TOTPChallengeHandler{Reader: bytes.NewBufferString(" 123456 \n")},
+			Challenges: []Challenge{
+				{
+					Headers:           totpChallenge,
+					ExpectedCanHandle: true,
+					ExpectedHeaders:   codeHeaders("123456"),
+					ExpectedHandled:   true,
+					ExpectedPrompt:    "Verification code: ",
+				},
+				{
+					Headers:           totpChallenge,
+					ExpectedCanHandle: true,
+					ExpectedHeaders:   nil,
+					ExpectedHandled:   false,
+					ExpectedErr:       errors.New("the verification code was not accepted"),
+				},
+			},
+		},
+	}
+
+	for k, tc := range testCases {
+		for i, challenge := range tc.Challenges {
+			out := &bytes.Buffer{}
+			tc.Handler.Writer = out
+
+			canHandle := tc.Handler.CanHandle(challenge.Headers)
+			if canHandle != challenge.ExpectedCanHandle {
+				t.Errorf("%s: %d: Expected CanHandle=%v, got %v", k, i, challenge.ExpectedCanHandle, canHandle)
+			}
+
+			if canHandle {
+				headers, handled, err := tc.Handler.HandleChallenge(challenge.Headers)
+				if !reflect.DeepEqual(headers, challenge.ExpectedHeaders) {
+					t.Errorf("%s: %d: Expected headers\n\t%#v\ngot\n\t%#v", k, i, challenge.ExpectedHeaders, headers)
+				}
+				if handled != challenge.ExpectedHandled {
+					t.Errorf("%s: %d: Expected handled=%v, got %v", k, i, challenge.ExpectedHandled, handled)
+				}
+				if ((err == nil) != (challenge.ExpectedErr == nil)) || (err != nil && err.Error() != challenge.ExpectedErr.Error()) {
+					t.Errorf("%s: %d: Expected err=%v, got %v", k, i, challenge.ExpectedErr, err)
+				}
+				if out.String() != challenge.ExpectedPrompt {
+					t.Errorf("%s: %d: Expected prompt %q, got %q", k, i, challenge.ExpectedPrompt, out.String())
+				}
+			}
+		}
+	}
+}
@@ -88,6 +88,11 @@ type LifecycleHook struct {
 	FailurePolicy LifecycleHookFailurePolicy
 	// ExecNewPod specifies the options for a lifecycle hook backed by a pod.
 	ExecNewPod *ExecNewPodHook
+	// TagImages instructs the deployer to tag the current image referenced under a container onto an image stream tag.
+	TagImages []TagImageHook
+	// ExecInPods specifies the options for a lifecycle hook which runs a command in a sample of the running
+	// pods of the new deployment. It is only supported on post hooks.
+	ExecInPods *ExecInPodsHook
 }
 
 // LifecycleHookFailurePolicy describes possibles actions to take if a hook fails.
@@ -118,6 +123,27 @@ type ExecNewPodHook struct {
 	Volumes []string
 }
 
+// TagImageHook is a request to tag the image in a particular container onto an ImageStreamTag.
+type TagImageHook struct {
+	// ContainerName is the name of a container in the deployment config whose image value will be used as the source of the tag
+	ContainerName string
+	// To is the target ImageStreamTag to set the image of
+	To kapi.ObjectReference
+}
+
+// ExecInPodsHook is a hook implementation which runs a command in the
+// running pods of the new deployment.
+type ExecInPodsHook struct {
+	// Command is the action command and its arguments.
+	Command []string
+	// ContainerName is the name of the container in each pod the command is
+	// executed in. If empty, the first container of the pod is used.
+	ContainerName string
+	// MaxPods is the maximum number of running pods the command is executed
+	// in. If zero, the command is executed in every running pod.
+	MaxPods int
+}
+
 // RollingDeploymentStrategyParams are the input to the Rolling deployment
 // strategy.
 type RollingDeploymentStrategyParams struct {
@@ -160,10 +186,24 @@ type RollingDeploymentStrategyParams struct {
 	// Pre is a lifecycle hook which is executed before the deployment process
 	// begins. All LifecycleHookFailurePolicy values are supported.
 	Pre *LifecycleHook
+	// Mid is a lifecycle hook which is executed after each batch of new pods
+	// has been scaled up and before old pods are scaled down. A failure aborts
+	// the deployment unless the LifecycleHookFailurePolicyIgnore policy is
+	// used. The hook is not executed for the first deployment of a config.
+	Mid *LifecycleHook
 	// Post is a lifecycle hook which is executed after the strategy has
 	// finished all deployment logic. The LifecycleHookFailurePolicyAbort policy
 	// is NOT supported.
 	Post *LifecycleHook
+	// PauseAfterPercent pauses the deployment once the new deployment has been
+	// scaled up to at least this percentage, between 1 and 99, of the desired
+	// replicas. A paused deployment waits until it is continued by removing
+	// the DeploymentPausedAnnotation (e.g. with `oc deploy --continue`). If
+	// nil, the deployment is never paused. The first deployment of a config
+	// is never paused. The deployer pod is stopped
+	// MaxDeploymentDurationSeconds after it starts, so a deployment which is
+	// not continued within that duration of its creation fails.
+	PauseAfterPercent *int
 }
 
 const (
@@ -224,6 +264,10 @@ const (
 	// DeploymentReplicasAnnotation is for internal use only and is for
 	// detecting external modifications to deployment replica counts.
 	DeploymentReplicasAnnotation = "openshift.io/deployment.replicas"
+	// DeploymentPausedAnnotation is set on a deployment (a ReplicationController)
+	// by the deployer while a rolling deployment is paused. Removing the
+	// annotation continues the deployment.
+	DeploymentPausedAnnotation = "openshift.io/deployment.paused"
 )
 
 // These constants represent the various reasons for cancelling a deployment
@@ -54,11 +54,18 @@ func convert_v1_RollingDeploymentStrategyParams_To_api_RollingDeploymentStrategy
 	out.TimeoutSeconds = in.TimeoutSeconds
 	out.UpdatePercent = in.UpdatePercent
 
+	out.PauseAfterPercent = in.PauseAfterPercent
+
 	if in.Pre != nil {
 		if err := s.Convert(&in.Pre, &out.Pre, 0); err != nil {
 			return err
 		}
 	}
+	if in.Mid != nil {
+		if err := s.Convert(&in.Mid, &out.Mid, 0); err != nil {
+			return err
+		}
+	}
 	if in.Post != nil {
 		if err := s.Convert(&in.Post, &out.Post, 0); err != nil {
 			return err
@@ -89,11 +96,18 @@ func convert_api_RollingDeploymentStrategyParams_To_v1_RollingDeploymentStrategy
 	out.TimeoutSeconds = in.TimeoutSeconds
 	out.UpdatePercent = in.UpdatePercent
 
+	out.PauseAfterPercent = in.PauseAfterPercent
+
 	if in.Pre != nil {
 		if err := s.Convert(&in.Pre, &out.Pre, 0); err != nil {
 			return err
 		}
 	}
+	if in.Mid != nil {
+		if err := s.Convert(&in.Mid, &out.Mid, 0); err != nil {
+			return err
+		}
+	}
 	if in.Post != nil {
 		if err := s.Convert(&in.Post, &out.Post, 0); err != nil {
 			return err
@@ -88,6 +88,11 @@ type LifecycleHook struct {
 	FailurePolicy LifecycleHookFailurePolicy `json:"failurePolicy" description:"what action to take if the hook fails"`
 	// ExecNewPod specifies the options for a lifecycle hook backed by a pod.
 	ExecNewPod *ExecNewPodHook `json:"execNewPod,omitempty" description:"options for an ExecNewPodHook"`
+	// TagImages instructs the deployer to tag the current image referenced under a container onto an image stream tag.
+	TagImages []TagImageHook `json:"tagImages,omitempty" description:"a list of image stream tags to update with the images deployed by a container"`
+	// ExecInPods specifies the options for a lifecycle hook which runs a command in a sample of the running
+	// pods of the new deployment. It is only supported on post hooks.
+	ExecInPods *ExecInPodsHook `json:"execInPods,omitempty" description:"options for an ExecInPodsHook"`
 }
 
 // LifecycleHookFailurePolicy describes possibles actions to take if a hook fails.
@@ -119,6 +124,27 @@ type ExecNewPodHook struct {
 	Volumes []string `json:"volumes,omitempty" description:"the names of volumes from the pod template which should be included in the hook pod; an empty list means no volumes will be copied, and names not found in the pod spec will be ignored"`
 }
 
+// TagImageHook is a request to tag the image in a particular container onto an ImageStreamTag.
+type TagImageHook struct {
+	// ContainerName is the name of a container in the deployment config whose image value will be used as the source of the tag
+	ContainerName string `json:"containerName" description:"the name of a container from the pod template whose image will be tagged"`
+	// To is the target ImageStreamTag to set the image of
+	To kapi.ObjectReference `json:"to" description:"the image stream tag to set to the image of the container"`
+}
+
+// ExecInPodsHook is a hook implementation which runs a command in the
+// running pods of the new deployment.
+type ExecInPodsHook struct {
+	// Command is the action command and its arguments.
+	Command []string `json:"command" description:"the hook command and its arguments"`
+	// ContainerName is the name of the container in each pod the command is
+	// executed in. If empty, the first container of the pod is used.
+	ContainerName string `json:"containerName,omitempty" description:"the name of the container in each pod to execute the command in; defaults to the first container"`
+	// MaxPods is the maximum number of running pods the command is executed
+	// in. If zero, the command is executed in every running pod.
+	MaxPods int `json:"maxPods,omitempty" description:"the maximum number of running pods to execute the command in; zero means every running pod"`
+}
+
 // RollingDeploymentStrategyParams are the input to the Rolling deployment
 // strategy.
 type RollingDeploymentStrategyParams struct {
@@ -165,10 +191,24 @@ type RollingDeploymentStrategyParams struct {
 	// Pre is a lifecycle hook which is executed before the deployment process
 	// begins. All LifecycleHookFailurePolicy values are supported.
 	Pre *LifecycleHook `json:"pre,omitempty" description:"a hook executed before the strategy starts the deployment"`
+	// Mid is a lifecycle hook which is executed after each batch of new pods
+	// has been scaled up and before old pods are scaled down. A failure aborts
+	// the deployment unless the LifecycleHookFailurePolicyIgnore policy is
+	// used. The hook is not executed for the first deployment of a config.
+	Mid *LifecycleHook `json:"mid,omitempty" description:"a hook executed after each batch of new pods is scaled up"`
 	// Post is a lifecycle hook which is executed after the strategy has
 	// finished all deployment logic. The LifecycleHookFailurePolicyAbort policy
 	// is NOT supported.
 	Post *LifecycleHook `json:"post,omitempty" description:"a hook executed after the strategy finishes the deployment"`
+	// PauseAfterPercent pauses the deployment once the new deployment has been
+	// scaled up to at least this percentage, between 1 and 99, of the desired
+	// replicas. A paused deployment waits until it is continued by removing
+	// the DeploymentPausedAnnotation (e.g. with `oc deploy --continue`). If
+	// nil, the deployment is never paused. The first deployment of a config
+	// is never paused. The deployer pod is stopped
+	// MaxDeploymentDurationSeconds after it starts, so a deployment which is
+	// not continued within that duration of its creation fails.
+	PauseAfterPercent *int `json:"pauseAfterPercent,omitempty" description:"pause the deployment once the new deployment is scaled up to at least this percentage, between 1 and 99, of the desired replicas; the deployment fails if it is not continued within the maximum deployment duration"`
 }
 
 // These constants represent keys used for correlating objects related to deployments.
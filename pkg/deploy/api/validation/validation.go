@@ -127,10 +127,10 @@ func validateRecreateParams(params *deployapi.RecreateDeploymentStrategyParams,
 	errs := field.ErrorList{}
 
 	if params.Pre != nil {
-		errs = append(errs, validateLifecycleHook(params.Pre, fldPath.Child("pre"))...)
+		errs = append(errs, validatePreOrMidHook(params.Pre, fldPath.Child("pre"))...)
 	}
 	if params.Mid != nil {
-		errs = append(errs, validateLifecycleHook(params.Mid, fldPath.Child("mid"))...)
+		errs = append(errs, validatePreOrMidHook(params.Mid, fldPath.Child("mid"))...)
 	}
 	if params.Post != nil {
 		errs = append(errs, validateLifecycleHook(params.Post, fldPath.Child("post"))...)
@@ -146,15 +146,40 @@ func validateLifecycleHook(hook *deployapi.LifecycleHook, fldPath *field.Path) f
 		errs = append(errs, field.Required(fldPath.Child("failurePolicy"), ""))
 	}
 
-	if hook.ExecNewPod == nil {
-		errs = append(errs, field.Required(fldPath.Child("execNewPod"), ""))
-	} else {
+	actions := 0
+	if hook.ExecNewPod != nil {
+		actions++
 		errs = append(errs, validateExecNewPod(hook.ExecNewPod, fldPath.Child("execNewPod"))...)
 	}
+	if len(hook.TagImages) > 0 {
+		actions++
+		errs = append(errs, validateTagImages(hook.TagImages, fldPath.Child("tagImages"))...)
+	}
+	if hook.ExecInPods != nil {
+		actions++
+		errs = append(errs, validateExecInPods(hook.ExecInPods, fldPath.Child("execInPods"))...)
+	}
+
+	switch {
+	case actions == 0:
+		errs = append(errs, field.Required(fldPath.Child("execNewPod"), "must specify one of execNewPod, tagImages or execInPods"))
+	case actions > 1:
+		errs = append(errs, field.Invalid(fldPath, "", "only one of execNewPod, tagImages or execInPods may be specified"))
+	}
 
 	return errs
 }
 
+// validatePreOrMidHook validates a hook which runs before the new deployment
+// has any pods, and therefore cannot execute commands in them.
+func validatePreOrMidHook(hook *deployapi.LifecycleHook, fldPath *field.Path) field.ErrorList {
+	errs := validateLifecycleHook(hook, fldPath)
+	if hook.ExecInPods != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("execInPods"), "", "only supported by post hooks"))
+	}
+	return errs
+}
+
 func validateExecNewPod(hook *deployapi.ExecNewPodHook, fldPath *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 
@@ -175,6 +200,45 @@ func validateExecNewPod(hook *deployapi.ExecNewPodHook, fldPath *field.Path) fie
 	return errs
 }
 
+func validateTagImages(tagImages []deployapi.TagImageHook, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	for i, image := range tagImages {
+		idxPath := fldPath.Index(i)
+		if len(image.ContainerName) == 0 {
+			errs = append(errs, field.Required(idxPath.Child("containerName"), ""))
+		}
+		toPath := idxPath.Child("to")
+		if len(image.To.Kind) > 0 && image.To.Kind != "ImageStreamTag" {
+			errs = append(errs, field.Invalid(toPath.Child("kind"), image.To.Kind, "kind must be an ImageStreamTag"))
+		}
+		if len(image.To.Name) == 0 {
+			errs = append(errs, field.Required(toPath.Child("name"), ""))
+		} else if err := validateImageStreamTagName(image.To.Name); err != nil {
+			errs = append(errs, field.Invalid(toPath.Child("name"), image.To.Name, err.Error()))
+		}
+		if len(image.To.Namespace) != 0 && !kvalidation.IsDNS1123Subdomain(image.To.Namespace) {
+			errs = append(errs, field.Invalid(toPath.Child("namespace"), image.To.Namespace, "namespace must be a valid subdomain"))
+		}
+	}
+
+	return errs
+}
+
+func validateExecInPods(hook *deployapi.ExecInPodsHook, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if len(hook.Command) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("command"), ""))
+	}
+
+	if hook.MaxPods < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("maxPods"), hook.MaxPods, "must be >=0"))
+	}
+
+	return errs
+}
+
 func validateEnv(vars []kapi.EnvVar, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -238,12 +302,23 @@ func validateRollingParams(params *deployapi.RollingDeploymentStrategyParams, fl
 	errs = append(errs, IsNotMoreThan100Percent(params.MaxUnavailable, fldPath.Child("maxUnavailable"))...)
 
 	if params.Pre != nil {
-		errs = append(errs, validateLifecycleHook(params.Pre, fldPath.Child("pre"))...)
+		errs = append(errs, validatePreOrMidHook(params.Pre, fldPath.Child("pre"))...)
+	}
+	if params.Mid != nil {
+		errs = append(errs, validateLifecycleHook(params.Mid, fldPath.Child("mid"))...)
 	}
 	if params.Post != nil {
 		errs = append(errs, validateLifecycleHook(params.Post, fldPath.Child("post"))...)
 	}
 
+	if params.PauseAfterPercent != nil {
+		p := *params.PauseAfterPercent
+		// the deployment is complete once scaled up to 100%, too late to pause it
+		if p < 1 || p > 99 {
+			errs = append(errs, field.Invalid(fldPath.Child("pauseAfterPercent"), p, "must be between 1 and 99 (inclusive)"))
+		}
+	}
+
 	return errs
 }
 
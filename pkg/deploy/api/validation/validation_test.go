@@ -371,6 +371,157 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 			field.ErrorTypeInvalid,
 			"spec.strategy.recreateParams.pre.execNewPod.volumes[1]",
 		},
+		"missing spec.strategy.recreateParams.post.tagImages[0].containerName": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyIgnore,
+								TagImages: []api.TagImageHook{
+									{
+										To: kapi.ObjectReference{Kind: "ImageStreamTag", Name: "stream:prod"},
+									},
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.strategy.recreateParams.post.tagImages[0].containerName",
+		},
+		"invalid spec.strategy.recreateParams.post.tagImages[0].to.name": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyIgnore,
+								TagImages: []api.TagImageHook{
+									{
+										ContainerName: "container",
+										To:            kapi.ObjectReference{Kind: "ImageStreamTag", Name: "stream"},
+									},
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.recreateParams.post.tagImages[0].to.name",
+		},
+		"invalid spec.strategy.recreateParams.post.tagImages[0].to.kind": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyIgnore,
+								TagImages: []api.TagImageHook{
+									{
+										ContainerName: "container",
+										To:            kapi.ObjectReference{Kind: "DockerImage", Name: "stream:prod"},
+									},
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.recreateParams.post.tagImages[0].to.kind",
+		},
+		"missing spec.strategy.recreateParams.post.execInPods.command": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyIgnore,
+								ExecInPods:    &api.ExecInPodsHook{},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.strategy.recreateParams.post.execInPods.command",
+		},
+		"invalid spec.strategy.recreateParams.mid.execInPods": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Mid: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyAbort,
+								ExecInPods: &api.ExecInPodsHook{
+									Command: []string{"cmd"},
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.recreateParams.mid.execInPods",
+		},
+		"invalid spec.strategy.recreateParams.pre with multiple actions": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Pre: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyAbort,
+								ExecNewPod: &api.ExecNewPodHook{
+									Command:       []string{"cmd"},
+									ContainerName: "container",
+								},
+								TagImages: []api.TagImageHook{
+									{
+										ContainerName: "container",
+										To:            kapi.ObjectReference{Kind: "ImageStreamTag", Name: "stream:prod"},
+									},
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.recreateParams.pre",
+		},
 		"invalid spec.strategy.rollingParams.intervalSeconds": {
 			rollingConfig(-20, 1, 1),
 			field.ErrorTypeInvalid,
@@ -413,6 +564,55 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 			field.ErrorTypeRequired,
 			"spec.strategy.rollingParams.pre.failurePolicy",
 		},
+		"missing spec.strategy.rollingParams.mid.failurePolicy": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRolling,
+						RollingParams: &api.RollingDeploymentStrategyParams{
+							IntervalSeconds:     mkint64p(1),
+							UpdatePeriodSeconds: mkint64p(1),
+							TimeoutSeconds:      mkint64p(20),
+							MaxSurge:            intstr.FromInt(1),
+							Mid: &api.LifecycleHook{
+								ExecInPods: &api.ExecInPodsHook{
+									Command:       []string{"cmd"},
+									ContainerName: "container",
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.strategy.rollingParams.mid.failurePolicy",
+		},
+		"100 spec.strategy.rollingParams.pauseAfterPercent": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRolling,
+						RollingParams: &api.RollingDeploymentStrategyParams{
+							IntervalSeconds:     mkint64p(1),
+							UpdatePeriodSeconds: mkint64p(1),
+							TimeoutSeconds:      mkint64p(20),
+							MaxSurge:            intstr.FromInt(1),
+							PauseAfterPercent:   mkintp(100),
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.rollingParams.pauseAfterPercent",
+		},
 		"both maxSurge and maxUnavailable 0 spec.strategy.rollingParams.maxUnavailable": {
 			rollingConfigMax(intstr.FromInt(0), intstr.FromInt(0)),
 			field.ErrorTypeInvalid,
@@ -479,6 +679,29 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 	}
 }
 
+func TestValidateRollingParamsPauseAfterPercent(t *testing.T) {
+	tests := []struct {
+		percent int
+		valid   bool
+	}{
+		{percent: 0},
+		{percent: 1, valid: true},
+		{percent: 99, valid: true},
+		{percent: 100},
+		{percent: 101},
+	}
+	for _, test := range tests {
+		params := &api.RollingDeploymentStrategyParams{
+			MaxSurge:          intstr.FromInt(1),
+			PauseAfterPercent: mkintp(test.percent),
+		}
+		errs := validateRollingParams(params, field.NewPath("rollingParams"))
+		if test.valid != (len(errs) == 0) {
+			t.Errorf("%d: expected valid=%t, got %v", test.percent, test.valid, errs)
+		}
+	}
+}
+
 func TestValidateDeploymentConfigUpdate(t *testing.T) {
 	oldConfig := &api.DeploymentConfig{
 		ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar", ResourceVersion: "1"},
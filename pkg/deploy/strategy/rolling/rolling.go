@@ -15,6 +15,7 @@ import (
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/wait"
 
+	"github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	strat "github.com/openshift/origin/pkg/deploy/strategy"
 	stratsupport "github.com/openshift/origin/pkg/deploy/strategy/support"
@@ -28,6 +29,10 @@ const sourceIdAnnotation = "kubectl.kubernetes.io/update-source-id"
 const DefaultApiRetryPeriod = 1 * time.Second
 const DefaultApiRetryTimeout = 10 * time.Second
 
+// DefaultPausePollPeriod is how often a paused deployment is checked for
+// continuation.
+const DefaultPausePollPeriod = 5 * time.Second
+
 // RollingDeploymentStrategy is a Strategy which implements rolling
 // deployments using the upstream Kubernetes RollingUpdater.
 //
@@ -60,6 +65,12 @@ type RollingDeploymentStrategy struct {
 	apiRetryPeriod time.Duration
 	// apiRetryTimeout is how long to retry API calls before giving up.
 	apiRetryTimeout time.Duration
+	// pausePollPeriod is how often a paused deployment is checked for
+	// continuation.
+	pausePollPeriod time.Duration
+	// maxDeploymentDuration is how long after its creation a deployment may
+	// run before its deployer pod is stopped.
+	maxDeploymentDuration time.Duration
 }
 
 // acceptingDeploymentStrategy is a DeploymentStrategy which accepts an
@@ -76,18 +87,20 @@ type acceptingDeploymentStrategy interface {
 const AcceptorInterval = 1 * time.Second
 
 // NewRollingDeploymentStrategy makes a new RollingDeploymentStrategy.
-func NewRollingDeploymentStrategy(namespace string, client kclient.Interface, decoder runtime.Decoder, initialStrategy acceptingDeploymentStrategy) *RollingDeploymentStrategy {
+func NewRollingDeploymentStrategy(namespace string, client kclient.Interface, tags client.ImageStreamsNamespacer, kubeConfig *kclient.Config, decoder runtime.Decoder, initialStrategy acceptingDeploymentStrategy) *RollingDeploymentStrategy {
 	return &RollingDeploymentStrategy{
-		decoder:         decoder,
-		initialStrategy: initialStrategy,
-		client:          client,
-		apiRetryPeriod:  DefaultApiRetryPeriod,
-		apiRetryTimeout: DefaultApiRetryTimeout,
+		decoder:               decoder,
+		initialStrategy:       initialStrategy,
+		client:                client,
+		apiRetryPeriod:        DefaultApiRetryPeriod,
+		apiRetryTimeout:       DefaultApiRetryTimeout,
+		pausePollPeriod:       DefaultPausePollPeriod,
+		maxDeploymentDuration: time.Duration(deployapi.MaxDeploymentDurationSeconds) * time.Second,
 		rollingUpdate: func(config *kubectl.RollingUpdaterConfig) error {
 			updater := kubectl.NewRollingUpdater(namespace, client)
 			return updater.Update(config)
 		},
-		hookExecutor: stratsupport.NewHookExecutor(client, os.Stdout, decoder),
+		hookExecutor: stratsupport.NewHookExecutor(client, tags, kubeConfig, os.Stdout, decoder),
 		getUpdateAcceptor: func(timeout time.Duration) strat.UpdateAcceptor {
 			return stratsupport.NewAcceptNewlyObservedReadyPods(client, timeout, AcceptorInterval)
 		},
@@ -207,6 +220,9 @@ func (s *RollingDeploymentStrategy) Deploy(from *kapi.ReplicationController, to
 		MaxSurge:       params.MaxSurge,
 		MaxUnavailable: params.MaxUnavailable,
 	}
+	if params.Mid != nil || params.PauseAfterPercent != nil {
+		rollingConfig.OnProgress = s.onProgress(params, updateAcceptor)
+	}
 	err = s.rollingUpdate(rollingConfig)
 	if err != nil {
 		return err
@@ -225,6 +241,93 @@ func (s *RollingDeploymentStrategy) Deploy(from *kapi.ReplicationController, to
 	return nil
 }
 
+// onProgress returns a function to be invoked by the rolling updater after
+// each scale up of the new deployment. Once the pods of the batch are ready,
+// the function executes any mid-hook and pauses the deployment the first time
+// it reaches the configured percentage.
+func (s *RollingDeploymentStrategy) onProgress(params *deployapi.RollingDeploymentStrategyParams, updateAcceptor strat.UpdateAcceptor) func(oldRc, newRc *kapi.ReplicationController, percentage int) error {
+	batch := 0
+	paused := false
+	return func(oldRc, newRc *kapi.ReplicationController, percentage int) error {
+		batch++
+		if err := updateAcceptor.Accept(newRc); err != nil {
+			return err
+		}
+
+		// Execute any mid-hook.
+		if params.Mid != nil {
+			err := s.hookExecutor.Execute(params.Mid, newRc, fmt.Sprintf("midhook-%d", batch))
+			if err != nil {
+				return fmt.Errorf("mid hook failed: %s", err)
+			}
+			glog.Infof("Mid hook finished for batch %d", batch)
+		}
+
+		if params.PauseAfterPercent != nil && !paused && percentage >= *params.PauseAfterPercent && percentage < 100 {
+			paused = true
+			return s.pause(newRc, percentage)
+		}
+		return nil
+	}
+}
+
+// pause marks the deployment as paused and blocks until the pause annotation
+// is removed. An error is returned if the deployment is cancelled while
+// paused, or if it is not continued before the deployer pod reaches its
+// active deadline. The deadline is counted from the creation of the
+// deployment, which precedes the start of the deployer pod, so the failure
+// is reported before the pod is stopped.
+func (s *RollingDeploymentStrategy) pause(deployment *kapi.ReplicationController, percentage int) error {
+	deadline := deployment.CreationTimestamp.Add(s.maxDeploymentDuration)
+	timeoutErr := fmt.Errorf("deployment %s was not continued within the maximum deployment duration of %v", deployutil.LabelForDeployment(deployment), s.maxDeploymentDuration)
+	timeout := deadline.Sub(time.Now())
+	if timeout <= 0 {
+		return timeoutErr
+	}
+
+	err := kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+		existing, err := s.client.ReplicationControllers(deployment.Namespace).Get(deployment.Name)
+		if err != nil {
+			return err
+		}
+		if existing.Annotations == nil {
+			existing.Annotations = make(map[string]string)
+		}
+		existing.Annotations[deployapi.DeploymentPausedAnnotation] = "true"
+		_, err = s.client.ReplicationControllers(existing.Namespace).Update(existing)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("couldn't pause deployment %s: %v", deployutil.LabelForDeployment(deployment), err)
+	}
+	glog.Infof("Deployment %s paused at %d%%; waiting to be continued until %s", deployutil.LabelForDeployment(deployment), percentage, deadline.Format(time.RFC3339))
+
+	err = wait.Poll(s.pausePollPeriod, timeout, func() (bool, error) {
+		existing, err := s.client.ReplicationControllers(deployment.Namespace).Get(deployment.Name)
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, err
+			}
+			// Try again.
+			glog.Infof("couldn't look up deployment %s: %v", deployutil.LabelForDeployment(deployment), err)
+			return false, nil
+		}
+		if deployutil.IsDeploymentCancelled(existing) {
+			return false, fmt.Errorf("deployment %s was cancelled while paused", deployutil.LabelForDeployment(deployment))
+		}
+		_, stillPaused := existing.Annotations[deployapi.DeploymentPausedAnnotation]
+		return !stillPaused, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return timeoutErr
+	}
+	if err != nil {
+		return err
+	}
+	glog.Infof("Deployment %s continued", deployutil.LabelForDeployment(deployment))
+	return nil
+}
+
 // rollingUpdaterWriter is an io.Writer that delegates to glog.
 type rollingUpdaterWriter struct{}
 
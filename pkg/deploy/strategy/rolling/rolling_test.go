@@ -2,10 +2,12 @@ package rolling
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/apimachinery/registered"
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/kubectl"
@@ -213,6 +215,196 @@ func TestRolling_deployRollingHooks(t *testing.T) {
 	}
 }
 
+func TestRolling_deployRollingMidHook(t *testing.T) {
+	config := deploytest.OkDeploymentConfig(1)
+	config.Spec.Strategy = deploytest.OkRollingStrategy()
+	latest, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(registered.GroupOrDie(kapi.GroupName).GroupVersions[0]))
+
+	config = deploytest.OkDeploymentConfig(2)
+	config.Spec.Strategy.RollingParams = rollingParams("", "")
+	config.Spec.Strategy.RollingParams.Mid = &deployapi.LifecycleHook{
+		FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+		ExecNewPod:    &deployapi.ExecNewPodHook{},
+	}
+	deployment, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(registered.GroupOrDie(kapi.GroupName).GroupVersions[0]))
+
+	deployments := map[string]*kapi.ReplicationController{
+		latest.Name:     latest,
+		deployment.Name: deployment,
+	}
+	fake := &ktestclient.Fake{}
+	fake.AddReactor("get", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		name := action.(ktestclient.GetAction).GetName()
+		return true, deployments[name], nil
+	})
+	fake.AddReactor("update", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		updated := action.(ktestclient.UpdateAction).GetObject().(*kapi.ReplicationController)
+		return true, updated, nil
+	})
+
+	var hookError error
+	labels := []string{}
+	accepted := 0
+	strategy := &RollingDeploymentStrategy{
+		decoder: kapi.Codecs.UniversalDecoder(),
+		client:  fake,
+		rollingUpdate: func(config *kubectl.RollingUpdaterConfig) error {
+			if config.OnProgress == nil {
+				t.Fatalf("expected OnProgress to be set")
+			}
+			for _, percentage := range []int{50, 100} {
+				if err := config.OnProgress(config.OldRc, config.NewRc, percentage); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		hookExecutor: &hookExecutorImpl{
+			executeFunc: func(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, label string) error {
+				labels = append(labels, label)
+				return hookError
+			},
+		},
+		getUpdateAcceptor: func(timeout time.Duration) strat.UpdateAcceptor {
+			return &testAcceptor{
+				acceptFn: func(deployment *kapi.ReplicationController) error {
+					accepted++
+					return nil
+				},
+			}
+		},
+		apiRetryPeriod:  1 * time.Millisecond,
+		apiRetryTimeout: 10 * time.Millisecond,
+	}
+
+	if err := strategy.Deploy(latest, deployment, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []string{"midhook-1", "midhook-2"}, labels; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected hook labels %v, got %v", e, a)
+	}
+	if e, a := 2, accepted; e != a {
+		t.Errorf("expected %d batches to be accepted, got %d", e, a)
+	}
+
+	labels = []string{}
+	hookError = fmt.Errorf("hook failure")
+	if err := strategy.Deploy(latest, deployment, 2); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if e, a := []string{"midhook-1"}, labels; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected hook labels %v, got %v", e, a)
+	}
+}
+
+func TestRolling_deployRollingPause(t *testing.T) {
+	config := deploytest.OkDeploymentConfig(1)
+	config.Spec.Strategy = deploytest.OkRollingStrategy()
+	latest, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(registered.GroupOrDie(kapi.GroupName).GroupVersions[0]))
+
+	config = deploytest.OkDeploymentConfig(2)
+	config.Spec.Strategy.RollingParams = rollingParams("", "")
+	pauseAfter := 50
+	config.Spec.Strategy.RollingParams.PauseAfterPercent = &pauseAfter
+
+	cases := []struct {
+		name     string
+		act      func(*kapi.ReplicationController)
+		created  time.Duration
+		expected bool
+		paused   int
+	}{
+		{
+			name: "continued",
+			act: func(rc *kapi.ReplicationController) {
+				delete(rc.Annotations, deployapi.DeploymentPausedAnnotation)
+			},
+			expected: true,
+			paused:   1,
+		},
+		{
+			name: "cancelled",
+			act: func(rc *kapi.ReplicationController) {
+				rc.Annotations[deployapi.DeploymentCancelledAnnotation] = deployapi.DeploymentCancelledAnnotationValue
+			},
+			expected: false,
+			paused:   1,
+		},
+		{
+			name:     "not continued before the deadline",
+			act:      func(rc *kapi.ReplicationController) {},
+			created:  time.Minute - 50*time.Millisecond,
+			expected: false,
+			paused:   1,
+		},
+		{
+			name:     "deadline already passed",
+			act:      func(rc *kapi.ReplicationController) {},
+			created:  2 * time.Minute,
+			expected: false,
+			paused:   0,
+		},
+	}
+
+	for _, tc := range cases {
+		deployment, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(registered.GroupOrDie(kapi.GroupName).GroupVersions[0]))
+		deployment.CreationTimestamp = unversioned.NewTime(time.Now().Add(-tc.created))
+		deployments := map[string]*kapi.ReplicationController{
+			latest.Name:     latest,
+			deployment.Name: deployment,
+		}
+
+		paused := 0
+		fake := &ktestclient.Fake{}
+		fake.AddReactor("get", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			name := action.(ktestclient.GetAction).GetName()
+			rc := deployments[name]
+			if _, ok := rc.Annotations[deployapi.DeploymentPausedAnnotation]; ok {
+				// Simulate the user acting on the paused deployment.
+				tc.act(rc)
+			}
+			return true, rc, nil
+		})
+		fake.AddReactor("update", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			updated := action.(ktestclient.UpdateAction).GetObject().(*kapi.ReplicationController)
+			if _, ok := updated.Annotations[deployapi.DeploymentPausedAnnotation]; ok {
+				paused++
+			}
+			deployments[updated.Name] = updated
+			return true, updated, nil
+		})
+
+		strategy := &RollingDeploymentStrategy{
+			decoder: kapi.Codecs.UniversalDecoder(),
+			client:  fake,
+			rollingUpdate: func(config *kubectl.RollingUpdaterConfig) error {
+				for _, percentage := range []int{25, 50, 75, 100} {
+					if err := config.OnProgress(config.OldRc, config.NewRc, percentage); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			getUpdateAcceptor:     getUpdateAcceptor,
+			apiRetryPeriod:        1 * time.Millisecond,
+			apiRetryTimeout:       10 * time.Millisecond,
+			pausePollPeriod:       1 * time.Millisecond,
+			maxDeploymentDuration: time.Minute,
+		}
+
+		err := strategy.Deploy(latest, deployment, 2)
+		if tc.expected && err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+		}
+		if !tc.expected && err == nil {
+			t.Errorf("%s: expected an error", tc.name)
+		}
+		if e, a := tc.paused, paused; e != a {
+			t.Errorf("%s: expected deployment to be paused %d times, got %d", tc.name, e, a)
+		}
+	}
+}
+
 // TestRolling_deployInitialHooks can go away once the rolling strategy
 // supports initial deployments.
 func TestRolling_deployInitialHooks(t *testing.T) {
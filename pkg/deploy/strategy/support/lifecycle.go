@@ -3,6 +3,7 @@ package support
 import (
 	"fmt"
 	"io"
+	"sort"
 	"sync"
 	"time"
 
@@ -12,15 +13,19 @@ import (
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/client/cache"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
 	"k8s.io/kubernetes/pkg/fields"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
 	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/wait"
 	"k8s.io/kubernetes/pkg/watch"
 
+	"github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
+	imageapi "github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/util"
 	namer "github.com/openshift/origin/pkg/util/namer"
 )
@@ -31,6 +36,8 @@ const HookContainerName = "lifecycle"
 type HookExecutor struct {
 	// podClient provides access to pods.
 	podClient HookExecutorPodClient
+	// tags provides access to image streams for TagImages hooks.
+	tags client.ImageStreamsNamespacer
 	// podLogDestination is where hook pod logs should be written to.
 	podLogDestination io.Writer
 	// podLogStream provides a reader for a pod's logs.
@@ -39,8 +46,9 @@ type HookExecutor struct {
 	decoder runtime.Decoder
 }
 
-// NewHookExecutor makes a HookExecutor from a client.
-func NewHookExecutor(client kclient.Interface, podLogDestination io.Writer, decoder runtime.Decoder) *HookExecutor {
+// NewHookExecutor makes a HookExecutor from a client. The kubeConfig is used
+// to execute commands in running pods.
+func NewHookExecutor(client kclient.Interface, tags client.ImageStreamsNamespacer, kubeConfig *kclient.Config, podLogDestination io.Writer, decoder runtime.Decoder) *HookExecutor {
 	return &HookExecutor{
 		podClient: &HookExecutorPodClientImpl{
 			CreatePodFunc: func(namespace string, pod *kapi.Pod) (*kapi.Pod, error) {
@@ -49,7 +57,14 @@ func NewHookExecutor(client kclient.Interface, podLogDestination io.Writer, deco
 			PodWatchFunc: func(namespace, name, resourceVersion string, stopChannel chan struct{}) func() *kapi.Pod {
 				return NewPodWatch(client, namespace, name, resourceVersion, stopChannel)
 			},
+			ListPodsFunc: func(namespace string, selector labels.Selector) (*kapi.PodList, error) {
+				return client.Pods(namespace).List(kapi.ListOptions{LabelSelector: selector})
+			},
+			ExecPodFunc: func(pod *kapi.Pod, container string, command []string, out io.Writer) error {
+				return ExecInPod(kubeConfig, pod, container, command, out)
+			},
 		},
+		tags: tags,
 		podLogStream: func(namespace, name string, opts *kapi.PodLogOptions) (io.ReadCloser, error) {
 			return client.Pods(namespace).GetLogs(name, opts).Stream()
 		},
@@ -65,6 +80,10 @@ func (e *HookExecutor) Execute(hook *deployapi.LifecycleHook, deployment *kapi.R
 	switch {
 	case hook.ExecNewPod != nil:
 		err = e.executeExecNewPod(hook, deployment, label)
+	case len(hook.TagImages) > 0:
+		err = e.tagImages(hook, deployment, label)
+	case hook.ExecInPods != nil:
+		err = e.executeExecInPods(hook, deployment, label)
 	}
 
 	if err == nil {
@@ -144,6 +163,149 @@ waitLoop:
 	return nil
 }
 
+// tagImages tags the image of each referenced container of the deployment
+// into the target ImageStreamTag by updating the spec tag of the image
+// stream, creating the stream if it does not exist yet.
+func (e *HookExecutor) tagImages(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, label string) error {
+	var errs []error
+	for _, action := range hook.TagImages {
+		image, ok := findContainerImage(deployment, action.ContainerName)
+		if !ok {
+			errs = append(errs, fmt.Errorf("unable to find image for container %q, container could not be found", action.ContainerName))
+			continue
+		}
+		namespace := action.To.Namespace
+		if len(namespace) == 0 {
+			namespace = deployment.Namespace
+		}
+		name, tag, ok := imageapi.SplitImageStreamTag(action.To.Name)
+		if !ok {
+			errs = append(errs, fmt.Errorf("invalid ImageStreamTag: %s", action.To.Name))
+			continue
+		}
+		err := kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+			isc := e.tags.ImageStreams(namespace)
+			stream, err := isc.Get(name)
+			if err != nil {
+				if !kerrors.IsNotFound(err) {
+					return err
+				}
+				stream = &imageapi.ImageStream{ObjectMeta: kapi.ObjectMeta{Name: name}}
+			}
+			if stream.Spec.Tags == nil {
+				stream.Spec.Tags = make(map[string]imageapi.TagReference)
+			}
+			ref := stream.Spec.Tags[tag]
+			ref.From = &kapi.ObjectReference{Kind: "DockerImage", Name: image}
+			stream.Spec.Tags[tag] = ref
+			if len(stream.ResourceVersion) == 0 {
+				_, err = isc.Create(stream)
+			} else {
+				_, err = isc.Update(stream)
+			}
+			return err
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("couldn't tag %q into %s/%s: %v", image, namespace, action.To.Name, err))
+			continue
+		}
+		glog.V(0).Infof("Tagged %q into %s/%s for %s hook of deployment %s", image, namespace, action.To.Name, label, deployutil.LabelForDeployment(deployment))
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// findContainerImage returns the image of the named container in the
+// deployment's pod template.
+func findContainerImage(deployment *kapi.ReplicationController, containerName string) (string, bool) {
+	if deployment.Spec.Template == nil {
+		return "", false
+	}
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == containerName {
+			return container.Image, true
+		}
+	}
+	return "", false
+}
+
+// executeExecInPods executes an ExecInPods hook by running the hook command
+// in the running and ready pods of the deployment. Pods are sorted by name
+// and at most MaxPods of them are used. The command output is written to
+// podLogDestination, and an error is returned if the command fails in any of
+// the pods.
+func (e *HookExecutor) executeExecInPods(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, label string) error {
+	exec := hook.ExecInPods
+	// The deployment selector only matches pods of the new deployment.
+	selector := labels.Set(deployment.Spec.Selector).AsSelector()
+	list, err := e.podClient.ListPods(deployment.Namespace, selector)
+	if err != nil {
+		return fmt.Errorf("couldn't list pods for %s: %v", deployutil.LabelForDeployment(deployment), err)
+	}
+
+	pods := []*kapi.Pod{}
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if pod.Status.Phase != kapi.PodRunning || !kapi.IsPodReady(pod) {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("no running pods found for %s", deployutil.LabelForDeployment(deployment))
+	}
+	sort.Sort(podsByName(pods))
+	if exec.MaxPods > 0 && len(pods) > exec.MaxPods {
+		pods = pods[:exec.MaxPods]
+	}
+
+	var errs []error
+	for _, pod := range pods {
+		container := exec.ContainerName
+		if len(container) == 0 {
+			container = pod.Spec.Containers[0].Name
+		}
+		glog.V(0).Infof("Executing %s hook for deployment %s in pod %s/%s", label, deployutil.LabelForDeployment(deployment), pod.Namespace, pod.Name)
+		if err := e.podClient.ExecPod(pod, container, exec.Command, e.podLogDestination); err != nil {
+			errs = append(errs, fmt.Errorf("command failed in pod %s/%s: %v", pod.Namespace, pod.Name, err))
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// podsByName sorts pods by their name.
+type podsByName []*kapi.Pod
+
+func (p podsByName) Len() int           { return len(p) }
+func (p podsByName) Less(i, j int) bool { return p[i].Name < p[j].Name }
+func (p podsByName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+
+// ExecInPod runs command in the named container of a running pod and writes
+// the combined output to out.
+func ExecInPod(config *kclient.Config, pod *kapi.Pod, container string, command []string, out io.Writer) error {
+	client, err := kclient.New(config)
+	if err != nil {
+		return err
+	}
+	req := client.RESTClient.Post().
+		Resource("pods").
+		Name(pod.Name).
+		Namespace(pod.Namespace).
+		SubResource("exec").
+		Param("container", container)
+	req.VersionedParams(&kapi.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	}, kapi.Scheme)
+
+	executor, err := remotecommand.NewExecutor(config, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	return executor.Stream(nil, out, out, false)
+}
+
 // readPodLogs streams logs from pod to podLogDestination. It signals wg when
 // done.
 func (e *HookExecutor) readPodLogs(pod *kapi.Pod, wg *sync.WaitGroup) {
@@ -284,12 +446,16 @@ func makeHookPod(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationCont
 type HookExecutorPodClient interface {
 	CreatePod(namespace string, pod *kapi.Pod) (*kapi.Pod, error)
 	PodWatch(namespace, name, resourceVersion string, stopChannel chan struct{}) func() *kapi.Pod
+	ListPods(namespace string, selector labels.Selector) (*kapi.PodList, error)
+	ExecPod(pod *kapi.Pod, container string, command []string, out io.Writer) error
 }
 
 // HookExecutorPodClientImpl is a pluggable HookExecutorPodClient.
 type HookExecutorPodClientImpl struct {
 	CreatePodFunc func(namespace string, pod *kapi.Pod) (*kapi.Pod, error)
 	PodWatchFunc  func(namespace, name, resourceVersion string, stopChannel chan struct{}) func() *kapi.Pod
+	ListPodsFunc  func(namespace string, selector labels.Selector) (*kapi.PodList, error)
+	ExecPodFunc   func(pod *kapi.Pod, container string, command []string, out io.Writer) error
 }
 
 func (i *HookExecutorPodClientImpl) CreatePod(namespace string, pod *kapi.Pod) (*kapi.Pod, error) {
@@ -300,6 +466,14 @@ func (i *HookExecutorPodClientImpl) PodWatch(namespace, name, resourceVersion st
 	return i.PodWatchFunc(namespace, name, resourceVersion, stopChannel)
 }
 
+func (i *HookExecutorPodClientImpl) ListPods(namespace string, selector labels.Selector) (*kapi.PodList, error) {
+	return i.ListPodsFunc(namespace, selector)
+}
+
+func (i *HookExecutorPodClientImpl) ExecPod(pod *kapi.Pod, container string, command []string, out io.Writer) error {
+	return i.ExecPodFunc(pod, container, command, out)
+}
+
 // NewPodWatch creates a pod watching function which is backed by a
 // FIFO/reflector pair. This avoids managing watches directly.
 // A stop channel to close the watch's reflector is also returned.
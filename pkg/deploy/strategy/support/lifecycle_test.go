@@ -12,14 +12,20 @@ import (
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/client/cache"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
 	kutil "k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	"github.com/openshift/origin/pkg/client/testclient"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
+	imageapi "github.com/openshift/origin/pkg/image/api"
 	namer "github.com/openshift/origin/pkg/util/namer"
 
 	_ "github.com/openshift/origin/pkg/api/install"
@@ -147,6 +153,186 @@ func TestHookExecutor_executeExecNewPodFailed(t *testing.T) {
 	t.Logf("got expected error: %s", err)
 }
 
+func TestHookExecutor_tagImages(t *testing.T) {
+	hook := &deployapi.LifecycleHook{
+		FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+		TagImages: []deployapi.TagImageHook{
+			{
+				ContainerName: "container1",
+				To:            kapi.ObjectReference{Kind: "ImageStreamTag", Name: "stream:prod"},
+			},
+			{
+				ContainerName: "container2",
+				To:            kapi.ObjectReference{Kind: "ImageStreamTag", Name: "other:prod", Namespace: "other"},
+			},
+		},
+	}
+
+	deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+
+	fake := &testclient.Fake{}
+	fake.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if action.GetNamespace() == deployment.Namespace && name == "stream" {
+			return true, &imageapi.ImageStream{ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: deployment.Namespace, ResourceVersion: "1"}}, nil
+		}
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("imagestreams"), name)
+	})
+	fake.AddReactor("*", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, nil, nil
+	})
+	executor := &HookExecutor{
+		tags:    fake,
+		decoder: kapi.Codecs.UniversalDecoder(),
+	}
+
+	if err := executor.tagImages(hook, deployment, "hook"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tagged := map[string]string{}
+	for _, action := range fake.Actions() {
+		var stream *imageapi.ImageStream
+		switch a := action.(type) {
+		case ktestclient.UpdateAction:
+			stream = a.GetObject().(*imageapi.ImageStream)
+		case ktestclient.CreateAction:
+			stream = a.GetObject().(*imageapi.ImageStream)
+		default:
+			continue
+		}
+		tagged[action.GetVerb()+" "+action.GetNamespace()+"/"+stream.Name] = stream.Spec.Tags["prod"].From.Name
+	}
+	expected := map[string]string{
+		"update " + deployment.Namespace + "/stream": "registry:8080/repo1:ref1",
+		"create other/other":                         "registry:8080/repo1:ref2",
+	}
+	if !reflect.DeepEqual(expected, tagged) {
+		t.Fatalf("expected tagged images %v, got %v", expected, tagged)
+	}
+}
+
+func TestHookExecutor_tagImagesInvalidContainerRef(t *testing.T) {
+	hook := &deployapi.LifecycleHook{
+		FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+		TagImages: []deployapi.TagImageHook{
+			{
+				ContainerName: "undefined",
+				To:            kapi.ObjectReference{Kind: "ImageStreamTag", Name: "stream:prod"},
+			},
+		},
+	}
+
+	deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+
+	fake := testclient.NewSimpleFake()
+	executor := &HookExecutor{
+		tags:    fake,
+		decoder: kapi.Codecs.UniversalDecoder(),
+	}
+
+	if err := executor.tagImages(hook, deployment, "hook"); err == nil {
+		t.Fatalf("expected an error")
+	}
+	if len(fake.Actions()) != 0 {
+		t.Fatalf("unexpected actions: %v", fake.Actions())
+	}
+}
+
+func TestHookExecutor_executeExecInPods(t *testing.T) {
+	deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+
+	pod := func(name string, phase kapi.PodPhase, ready bool) kapi.Pod {
+		status := kapi.ConditionFalse
+		if ready {
+			status = kapi.ConditionTrue
+		}
+		return kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: deployment.Namespace},
+			Spec: kapi.PodSpec{
+				Containers: []kapi.Container{{Name: "container1"}, {Name: "container2"}},
+			},
+			Status: kapi.PodStatus{
+				Phase:      phase,
+				Conditions: []kapi.PodCondition{{Type: kapi.PodReady, Status: status}},
+			},
+		}
+	}
+	pods := &kapi.PodList{
+		Items: []kapi.Pod{
+			pod("pod-c", kapi.PodRunning, true),
+			pod("pod-a", kapi.PodRunning, true),
+			pod("pod-b", kapi.PodPending, false),
+			pod("pod-d", kapi.PodRunning, false),
+			pod("pod-e", kapi.PodRunning, true),
+		},
+	}
+
+	tests := []struct {
+		name         string
+		hook         *deployapi.ExecInPodsHook
+		execErr      error
+		expectedPods []string
+		expectErr    bool
+	}{
+		{
+			name:         "all running pods",
+			hook:         &deployapi.ExecInPodsHook{Command: []string{"warmup"}},
+			expectedPods: []string{"pod-a/container1", "pod-c/container1", "pod-e/container1"},
+		},
+		{
+			name:         "sampled pods",
+			hook:         &deployapi.ExecInPodsHook{Command: []string{"warmup"}, ContainerName: "container2", MaxPods: 2},
+			expectedPods: []string{"pod-a/container2", "pod-c/container2"},
+		},
+		{
+			name:         "command failure",
+			hook:         &deployapi.ExecInPodsHook{Command: []string{"warmup"}, MaxPods: 1},
+			execErr:      fmt.Errorf("exit status 1"),
+			expectedPods: []string{"pod-a/container1"},
+			expectErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		executed := []string{}
+		executor := &HookExecutor{
+			podClient: &HookExecutorPodClientImpl{
+				ListPodsFunc: func(namespace string, selector labels.Selector) (*kapi.PodList, error) {
+					if !selector.Matches(labels.Set(deployment.Spec.Selector)) {
+						t.Errorf("%s: unexpected selector %s", test.name, selector)
+					}
+					return pods, nil
+				},
+				ExecPodFunc: func(pod *kapi.Pod, container string, command []string, out io.Writer) error {
+					if !reflect.DeepEqual(test.hook.Command, command) {
+						t.Errorf("%s: expected command %v, got %v", test.name, test.hook.Command, command)
+					}
+					executed = append(executed, pod.Name+"/"+container)
+					return test.execErr
+				},
+			},
+			podLogDestination: ioutil.Discard,
+			decoder:           kapi.Codecs.UniversalDecoder(),
+		}
+
+		hook := &deployapi.LifecycleHook{
+			FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+			ExecInPods:    test.hook,
+		}
+		err := executor.executeExecInPods(hook, deployment, "hook")
+		if test.expectErr && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+		if !test.expectErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(test.expectedPods, executed) {
+			t.Errorf("%s: expected command to run in %v, got %v", test.name, test.expectedPods, executed)
+		}
+	}
+}
+
 func TestHookExecutor_makeHookPodInvalidContainerRef(t *testing.T) {
 	hook := &deployapi.LifecycleHook{
 		FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
@@ -0,0 +1,98 @@
+package server
+
+import (
+	"crypto/sha256"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	"github.com/docker/distribution/context"
+	"github.com/hashicorp/golang-lru"
+)
+
+// AuditLogger writes a structured record of the images pushed, pulled and deleted in the registry,
+// with the OpenShift user that made each request.
+type AuditLogger struct {
+	logger *log.Logger
+	// users caches the user names resolved from bearer tokens, keyed by a hash of the token.
+	users *lru.Cache
+	// userFn resolves the name of the user owning a bearer token.
+	userFn func(token string) (string, error)
+}
+
+// NewAuditLogger returns an AuditLogger writing JSON records to out.
+func NewAuditLogger(out io.Writer) (*AuditLogger, error) {
+	users, err := lru.New(256)
+	if err != nil {
+		return nil, err
+	}
+	logger := log.New()
+	logger.Out = out
+	logger.Formatter = &log.JSONFormatter{TimestampFormat: time.RFC3339Nano}
+	return &AuditLogger{
+		logger: logger,
+		users:  users,
+		userFn: userForToken,
+	}, nil
+}
+
+// Log records the event of a registry API request once it has been served. Only requests on manifests
+// are recorded: reading a manifest is a pull, writing one is a push.
+func (a *AuditLogger) Log(req *http.Request, operation, repository, reference string, status int) {
+	if operation != "manifest" {
+		return
+	}
+	event := ""
+	switch req.Method {
+	case "GET":
+		event = "pull"
+	case "PUT":
+		event = "push"
+	case "DELETE":
+		event = "delete"
+	default:
+		return
+	}
+	a.logger.WithFields(log.Fields{
+		"event":      event,
+		"repository": repository,
+		"reference":  reference,
+		"user":       a.user(req),
+		"remoteAddr": req.RemoteAddr,
+		"status":     status,
+	}).Info("registry audit")
+}
+
+// user returns the name of the user whose token authenticated the request, or an empty string if it
+// cannot be determined.
+func (a *AuditLogger) user(req *http.Request) string {
+	token, err := getToken(context.Background(), req)
+	if err != nil {
+		return ""
+	}
+	key := sha256.Sum256([]byte(token))
+	if name, ok := a.users.Get(key); ok {
+		return name.(string)
+	}
+	name, err := a.userFn(token)
+	if err != nil {
+		log.Debugf("Unable to resolve the user of a registry request: %v", err)
+		return ""
+	}
+	a.users.Add(key, name)
+	return name
+}
+
+// userForToken returns the name of the OpenShift user owning token.
+func userForToken(token string) (string, error) {
+	client, err := NewUserOpenShiftClient(token)
+	if err != nil {
+		return "", err
+	}
+	user, err := client.Users().Get("~")
+	if err != nil {
+		return "", err
+	}
+	return user.Name, nil
+}
@@ -88,6 +88,7 @@ func (ac *authChallenge) SetHeaders(w http.ResponseWriter) {
 
 // wrapErr wraps errors related to authorization in an authChallenge error that will present a WWW-Authenticate challenge response
 func (ac *AccessController) wrapErr(err error) error {
+	authFailureCounter.WithLabelValues(authFailureReason(err)).Inc()
 	switch err {
 	case ErrTokenRequired, ErrTokenInvalid, ErrOpenShiftTokenRequired, ErrOpenShiftAccessDenied:
 		// Challenge for errors that involve tokens or access denied
@@ -183,6 +184,15 @@ func (ac *AccessController) Authorized(ctx context.Context, accessRecords ...reg
 			default:
 				return nil, ac.wrapErr(ErrUnsupportedAction)
 			}
+		case "metrics":
+			switch access.Action {
+			case "get":
+				if err := verifyMetricsAccess(ctx, client); err != nil {
+					return nil, ac.wrapErr(err)
+				}
+			default:
+				return nil, ac.wrapErr(ErrUnsupportedAction)
+			}
 		default:
 			return nil, ac.wrapErr(ErrUnsupportedResource)
 		}
@@ -268,10 +278,20 @@ func verifyImageStreamAccess(ctx context.Context, namespace, imageRepo, verb str
 }
 
 func verifyPruneAccess(ctx context.Context, client *client.Client) error {
+	return verifyClusterAccess(ctx, "delete", "images", client)
+}
+
+// verifyMetricsAccess allows the users who may list all the images of the cluster to read the metrics,
+// which name the repositories of every project.
+func verifyMetricsAccess(ctx context.Context, client *client.Client) error {
+	return verifyClusterAccess(ctx, "list", "images", client)
+}
+
+func verifyClusterAccess(ctx context.Context, verb, resource string, client *client.Client) error {
 	sar := authorizationapi.SubjectAccessReview{
 		Action: authorizationapi.AuthorizationAttributes{
-			Verb:     "delete",
-			Resource: "images",
+			Verb:     verb,
+			Resource: resource,
 		},
 	}
 	response, err := client.SubjectAccessReviews().Create(&sar)
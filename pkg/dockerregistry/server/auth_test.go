@@ -265,6 +265,16 @@ func TestAccessController(t *testing.T) {
 				"POST /oapi/v1/subjectaccessreviews",
 			},
 		},
+		"metrics": {
+			access:     []auth.Access{{Resource: auth.Resource{Type: "metrics"}, Action: "get"}},
+			basicToken: "b3BlbnNoaWZ0OmF3ZXNvbWU=",
+			openshiftResponses: []response{
+				{200, runtime.EncodeOrDie(kapi.Codecs.LegacyCodec(registered.GroupOrDie(kapi.GroupName).GroupVersions[0]), &api.SubjectAccessReviewResponse{Allowed: false, Reason: "no!"})},
+			},
+			expectedError:     ErrOpenShiftAccessDenied,
+			expectedChallenge: true,
+			expectedActions:   []string{"POST /oapi/v1/subjectaccessreviews"},
+		},
 	}
 
 	for k, test := range tests {
@@ -34,8 +34,11 @@ func (c digestToRepositoryCache) RememberDigest(dgst digest.Digest, repo string)
 	value, ok := c.Get(key)
 	if !ok {
 		value = &repositoryBucket{}
-		if ok, _ := c.ContainsOrAdd(key, value); !ok {
-			return
+		if found, _ := c.ContainsOrAdd(key, value); found {
+			// another caller added a bucket for the digest first
+			if value, ok = c.Get(key); !ok {
+				return
+			}
 		}
 	}
 	repos := value.(*repositoryBucket)
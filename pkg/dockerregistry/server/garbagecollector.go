@@ -0,0 +1,253 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/registry/storage"
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	// DefaultGCGracePeriod is the minimum age of a blob before the garbage collector deletes it when it
+	// is not referenced by any image.
+	DefaultGCGracePeriod = time.Hour
+	// DefaultGCSettlePeriod is how long the garbage collector waits for the pushes accepted before it
+	// took its lock to complete.
+	DefaultGCSettlePeriod = time.Minute
+	// DefaultGCLockDuration is how long the lock of the garbage collector is held at most.
+	DefaultGCLockDuration = time.Hour
+)
+
+// gcLockPath is the path of the lock the garbage collector holds in the registry storage while it
+// deletes blobs. The registry refuses manifests while the lock is held, so that no new image starts
+// referencing a blob that is about to be deleted.
+const gcLockPath = "/openshift/gc.lock"
+
+// GarbageCollector deletes the blobs of the registry storage that are not referenced by any image
+// known to OpenShift. It runs next to a registry serving requests: blobs uploaded less than GracePeriod
+// ago are kept, since the image referencing them may not have been created yet. Before deleting
+// blobs, the collector takes a lock which makes the registry refuse manifests, waits for the pushes
+// in progress to complete and marks the blobs referenced by images again.
+type GarbageCollector struct {
+	// Client is used to list images and image streams.
+	Client osclient.Interface
+	// Registry gives access to the blobs and the manifest signatures of the storage. Deletion must be
+	// enabled on it.
+	Registry distribution.Namespace
+	// Driver is the storage driver of the registry.
+	Driver storagedriver.StorageDriver
+	// GracePeriod is the minimum age of the blobs that may be deleted.
+	GracePeriod time.Duration
+	// SettlePeriod is how long the collector waits after taking its lock, so that the images of the
+	// manifests accepted before are created.
+	SettlePeriod time.Duration
+	// LockDuration is how long the lock is held at most. The registry accepts manifests again once it
+	// expires, even if the collector did not release it, and the collector stops deleting blobs.
+	LockDuration time.Duration
+	// DryRun reports the blobs that would be deleted without deleting them.
+	DryRun bool
+}
+
+// Run marks the blobs referenced by images and sweeps the others. It returns the digests of the
+// blobs that were deleted, or would be deleted in dry run mode.
+func (gc *GarbageCollector) Run(ctx context.Context) ([]digest.Digest, error) {
+	start := time.Now()
+	marked, err := gc.mark(ctx)
+	if err != nil {
+		return nil, err
+	}
+	context.GetLogger(ctx).Infof("garbage collector: %d blobs are referenced by images", marked.Len())
+
+	enumerator, err := storage.RegistryBlobEnumerator(gc.Registry)
+	if err != nil {
+		return nil, err
+	}
+	candidates := []digest.Digest{}
+	err = enumerator.Enumerate(ctx, func(dgst digest.Digest) error {
+		if marked.Has(dgst.String()) {
+			return nil
+		}
+		recent, err := gc.uploadedSince(ctx, dgst, start.Add(-gc.GracePeriod))
+		if err != nil {
+			return err
+		}
+		if recent {
+			context.GetLogger(ctx).Debugf("garbage collector: keeping recently uploaded blob %s", dgst)
+			return nil
+		}
+		candidates = append(candidates, dgst)
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	// Images may have been pushed since the first mark, and they may reference old blobs. Once the
+	// lock is held no image referencing a blob is created anymore, so the second mark is final.
+	var expires time.Time
+	if !gc.DryRun {
+		expires = time.Now().Add(gc.LockDuration)
+		if err := acquireGCLock(ctx, gc.Driver, expires); err != nil {
+			return nil, fmt.Errorf("error taking the garbage collector lock: %v", err)
+		}
+		defer func() {
+			if err := releaseGCLock(ctx, gc.Driver); err != nil {
+				context.GetLogger(ctx).Errorf("garbage collector: error releasing the lock: %v", err)
+			}
+		}()
+		context.GetLogger(ctx).Infof("garbage collector: refusing manifests until %s", expires.Format(time.RFC3339))
+		time.Sleep(gc.SettlePeriod)
+	}
+	marked, err = gc.mark(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	deleter, err := storage.RegistryBlobDeleter(gc.Registry)
+	if err != nil {
+		return nil, err
+	}
+	deleted := []digest.Digest{}
+	for _, dgst := range candidates {
+		if marked.Has(dgst.String()) {
+			continue
+		}
+		if gc.DryRun {
+			context.GetLogger(ctx).Infof("garbage collector: would delete blob %s", dgst)
+			deleted = append(deleted, dgst)
+			continue
+		}
+		if !time.Now().Before(expires) {
+			return deleted, fmt.Errorf("the garbage collector lock expired before all blobs were deleted")
+		}
+		if err := deleter.Delete(ctx, dgst); err != nil {
+			if _, notFound := err.(storagedriver.PathNotFoundError); notFound {
+				continue
+			}
+			return deleted, fmt.Errorf("error deleting blob %s: %v", dgst, err)
+		}
+		context.GetLogger(ctx).Infof("garbage collector: deleted blob %s", dgst)
+		deleted = append(deleted, dgst)
+	}
+	return deleted, nil
+}
+
+// mark returns the digests of all blobs referenced by images: their manifests, layers and
+// configurations, as well as the signatures of the schema 1 manifests stored in the repositories
+// of the image streams.
+func (gc *GarbageCollector) mark(ctx context.Context) (sets.String, error) {
+	images, err := gc.Client.Images().List(kapi.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing images: %v", err)
+	}
+	marked := sets.NewString()
+	for i := range images.Items {
+		image := &images.Items[i]
+		marked.Insert(image.Name)
+		if err := imageapi.ImageWithMetadata(image); err != nil {
+			context.GetLogger(ctx).Warnf("garbage collector: unable to read the manifest of image %s: %v", image.Name, err)
+		}
+		for _, layer := range image.DockerImageLayers {
+			marked.Insert(layer.Name)
+		}
+		if image.DockerImageManifestMediaType == imageapi.DockerManifestSchema2MediaType && len(image.DockerImageMetadata.ID) > 0 {
+			marked.Insert(image.DockerImageMetadata.ID)
+		}
+	}
+
+	streams, err := gc.Client.ImageStreams(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error listing image streams: %v", err)
+	}
+	for _, stream := range streams.Items {
+		repository, err := gc.Registry.Repository(ctx, fmt.Sprintf("%s/%s", stream.Namespace, stream.Name))
+		if err != nil {
+			return nil, err
+		}
+		for _, history := range stream.Status.Tags {
+			for _, event := range history.Items {
+				dgst, err := digest.ParseDigest(event.Image)
+				if err != nil {
+					continue
+				}
+				signatures, err := repository.Signatures().Get(dgst)
+				if err != nil {
+					if _, notFound := err.(storagedriver.PathNotFoundError); notFound {
+						continue
+					}
+					return nil, fmt.Errorf("error reading the signatures of image %s in %s/%s: %v", dgst, stream.Namespace, stream.Name, err)
+				}
+				for _, signature := range signatures {
+					sigdgst, err := digest.FromBytes(signature)
+					if err != nil {
+						return nil, err
+					}
+					marked.Insert(sigdgst.String())
+				}
+			}
+		}
+	}
+	return marked, nil
+}
+
+// uploadedSince returns true if the blob was written to the storage after the given time.
+func (gc *GarbageCollector) uploadedSince(ctx context.Context, dgst digest.Digest, since time.Time) (bool, error) {
+	blobPath, err := storage.BlobDataPath(dgst)
+	if err != nil {
+		return false, err
+	}
+	info, err := gc.Driver.Stat(ctx, blobPath)
+	if err != nil {
+		if _, notFound := err.(storagedriver.PathNotFoundError); notFound {
+			// the blob is already gone
+			return true, nil
+		}
+		return false, err
+	}
+	return info.ModTime().After(since), nil
+}
+
+// acquireGCLock takes the lock of the garbage collector until it expires.
+func acquireGCLock(ctx context.Context, driver storagedriver.StorageDriver, expires time.Time) error {
+	return driver.PutContent(ctx, gcLockPath, []byte(expires.UTC().Format(time.RFC3339Nano)))
+}
+
+// releaseGCLock releases the lock of the garbage collector.
+func releaseGCLock(ctx context.Context, driver storagedriver.StorageDriver) error {
+	err := driver.Delete(ctx, gcLockPath)
+	if _, notFound := err.(storagedriver.PathNotFoundError); notFound {
+		return nil
+	}
+	return err
+}
+
+// gcLocked returns true if the garbage collector holds its lock. The lock of a collector which did not
+// complete is ignored once it expires.
+func gcLocked(ctx context.Context, driver storagedriver.StorageDriver, now time.Time) (bool, error) {
+	content, err := driver.GetContent(ctx, gcLockPath)
+	if err != nil {
+		if _, notFound := err.(storagedriver.PathNotFoundError); notFound {
+			return false, nil
+		}
+		return false, err
+	}
+	expires, err := time.Parse(time.RFC3339Nano, string(content))
+	if err != nil {
+		return false, fmt.Errorf("invalid garbage collector lock: %v", err)
+	}
+	return now.Before(expires), nil
+}
@@ -0,0 +1,179 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestGarbageCollector(t *testing.T) {
+	ctx := context.Background()
+	driver := inmemory.New()
+	registry, err := storage.NewRegistry(ctx, driver, storage.EnableDelete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobs := registry.Blobs().(distribution.BlobIngester)
+	put := func(content string) digest.Digest {
+		desc, err := blobs.Put(ctx, "application/octet-stream", []byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc.Digest
+	}
+	manifest := put("manifest")
+	layer := put("layer")
+	orphan := put("orphan")
+
+	repository, err := registry.Repository(ctx, "test/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repository.Signatures().Put(manifest, []byte("signature")); err != nil {
+		t.Fatal(err)
+	}
+	signature, _ := digest.FromBytes([]byte("signature"))
+
+	var pushed []imageapi.Image
+	client := &testclient.Fake{}
+	client.AddReactor("list", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		images := &imageapi.ImageList{Items: []imageapi.Image{{
+			ObjectMeta:        kapi.ObjectMeta{Name: manifest.String()},
+			DockerImageLayers: []imageapi.ImageLayer{{Name: layer.String()}},
+		}}}
+		images.Items = append(images.Items, pushed...)
+		return true, images, nil
+	})
+	client.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		stream := imageapi.ImageStream{ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "app"}}
+		stream.Status.Tags = map[string]imageapi.TagEventList{"latest": {Items: []imageapi.TagEvent{{Image: manifest.String()}}}}
+		return true, &imageapi.ImageStreamList{Items: []imageapi.ImageStream{stream}}, nil
+	})
+
+	gc := &GarbageCollector{Client: client, Registry: registry, Driver: driver, GracePeriod: time.Hour, LockDuration: time.Minute}
+	deleted, err := gc.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected recently uploaded blobs to be kept, got %v", deleted)
+	}
+
+	gc.GracePeriod = 0
+	gc.DryRun = true
+	deleted, err = gc.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 1 || deleted[0] != orphan {
+		t.Errorf("expected %s to be reported, got %v", orphan, deleted)
+	}
+	if _, err := registry.Blobs().Stat(ctx, orphan); err != nil {
+		t.Errorf("expected the blob to be kept in dry run mode: %v", err)
+	}
+
+	// an image referencing the orphan blob is pushed while the collector runs, before it takes its lock
+	var locked []bool
+	client.PrependReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		held, err := gcLocked(ctx, driver, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		locked = append(locked, held)
+		if !held {
+			pushed = []imageapi.Image{{ObjectMeta: kapi.ObjectMeta{Name: orphan.String()}}}
+		}
+		return false, nil, nil
+	})
+	gc.DryRun = false
+	deleted, err = gc.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deleted) != 0 {
+		t.Errorf("expected blobs referenced by new images to be kept, got %v", deleted)
+	}
+	if !reflect.DeepEqual(locked, []bool{false, true}) {
+		t.Errorf("expected the lock to be held during the second mark only, got %v", locked)
+	}
+	if held, err := gcLocked(ctx, driver, time.Now()); err != nil || held {
+		t.Errorf("expected the lock to be released, got %t: %v", held, err)
+	}
+
+	pushed = nil
+	client = &testclient.Fake{}
+	client.AddReactor("list", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageList{Items: []imageapi.Image{{ObjectMeta: kapi.ObjectMeta{Name: manifest.String()}}}}, nil
+	})
+	client.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamList{}, nil
+	})
+	gc.Client = client
+	deleted, err = gc.Run(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[digest.Digest]bool{layer: true, orphan: true, signature: true}
+	if len(deleted) != len(expected) {
+		t.Fatalf("unexpected deleted blobs: %v", deleted)
+	}
+	for _, dgst := range deleted {
+		if !expected[dgst] {
+			t.Errorf("unexpected deleted blob: %s", dgst)
+		}
+		if _, err := registry.Blobs().Stat(ctx, dgst); err != distribution.ErrBlobUnknown {
+			t.Errorf("expected blob %s to be deleted, got %v", dgst, err)
+		}
+	}
+	if _, err := registry.Blobs().Stat(ctx, manifest); err != nil {
+		t.Errorf("expected the manifest to be kept: %v", err)
+	}
+}
+
+func TestGarbageCollectorLock(t *testing.T) {
+	ctx := context.Background()
+	driver := inmemory.New()
+	r := &repository{ctx: ctx}
+
+	defer SetStorageDriver(nil)
+	SetStorageDriver(driver)
+
+	if err := r.checkGarbageCollecting(); err != nil {
+		t.Fatalf("unexpected error without lock: %v", err)
+	}
+	if err := acquireGCLock(ctx, driver, time.Now().Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.checkGarbageCollecting(); err != ErrorCodeGarbageCollecting {
+		t.Errorf("expected manifests to be refused while locked, got %v", err)
+	}
+	if err := r.Put(&schema1.SignedManifest{}); err != ErrorCodeGarbageCollecting {
+		t.Errorf("expected the manifest to be refused, got %v", err)
+	}
+	if err := acquireGCLock(ctx, driver, time.Now().Add(-time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.checkGarbageCollecting(); err != nil {
+		t.Errorf("expected an expired lock to be ignored, got %v", err)
+	}
+	if err := releaseGCLock(ctx, driver); err != nil {
+		t.Fatal(err)
+	}
+	if err := releaseGCLock(ctx, driver); err != nil {
+		t.Errorf("unexpected error releasing a released lock: %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/docker/distribution/registry/api/v2"
+	"github.com/docker/distribution/registry/handlers"
+	gorillahandlers "github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	requestCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_registry_request_count",
+			Help: "Counter of registry requests broken out for each method, operation, repository, and HTTP response code.",
+		},
+		[]string{"method", "operation", "repository", "code"},
+	)
+	requestLatencies = prometheus.NewSummaryVec(
+		prometheus.SummaryOpts{
+			Name: "openshift_registry_request_latencies_summary",
+			Help: "Response latency summary in microseconds for each method, operation, and repository.",
+			// Make the sliding window of 1h.
+			MaxAge: time.Hour,
+		},
+		[]string{"method", "operation", "repository"},
+	)
+	pullthroughBlobCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_registry_pullthrough_blob_count",
+			Help: "Counter of blobs looked up in remote repositories, broken out by whether they were found.",
+		},
+		[]string{"result"},
+	)
+	authFailureCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "openshift_registry_auth_failure_count",
+			Help: "Counter of requests refused by the registry access controller, broken out by reason.",
+		},
+		[]string{"reason"},
+	)
+
+	// router names the registry API operations of requests.
+	router = v2.Router()
+)
+
+func init() {
+	prometheus.MustRegister(requestCounter)
+	prometheus.MustRegister(requestLatencies)
+	prometheus.MustRegister(pullthroughBlobCounter)
+	prometheus.MustRegister(authFailureCounter)
+}
+
+// MetricsDispatcher serves the Prometheus metrics of the registry.
+func MetricsDispatcher(ctx *handlers.Context, r *http.Request) http.Handler {
+	return gorillahandlers.MethodHandler{
+		"GET": prometheus.Handler(),
+	}
+}
+
+// authFailureReason returns the metric label of an error returned by the access controller.
+func authFailureReason(err error) string {
+	switch err {
+	case ErrTokenRequired:
+		return "token_required"
+	case ErrTokenInvalid, ErrOpenShiftTokenRequired:
+		return "token_invalid"
+	case ErrOpenShiftAccessDenied:
+		return "access_denied"
+	case ErrNamespaceRequired, ErrUnsupportedAction, ErrUnsupportedResource:
+		return "bad_request"
+	default:
+		return "error"
+	}
+}
+
+// InstrumentHandler records the count and latency of the registry API requests served by handler, and
+// reports the push and pull events to audit if it is not nil. The repository is only recorded in the
+// metrics of successful requests.
+func InstrumentHandler(handler http.Handler, audit *AuditLogger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var match mux.RouteMatch
+		if !router.Match(req, &match) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+		operation := match.Route.GetName()
+		repository := match.Vars["name"]
+
+		start := time.Now()
+		rw := &statusResponseWriter{ResponseWriter: w, status: http.StatusOK}
+		handler.ServeHTTP(rw, req)
+
+		if rw.status >= http.StatusBadRequest {
+			// refused requests may name any repository; do not let them grow the number of series
+			repository = ""
+		}
+		requestCounter.WithLabelValues(req.Method, operation, repository, strconv.Itoa(rw.status)).Inc()
+		requestLatencies.WithLabelValues(req.Method, operation, repository).Observe(float64(time.Since(start) / time.Microsecond))
+		if audit != nil {
+			audit.Log(req, operation, match.Vars["name"], match.Vars["reference"], rw.status)
+		}
+	})
+}
+
+// statusResponseWriter records the status code of a response.
+type statusResponseWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusResponseWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Flush implements http.Flusher when the underlying writer does.
+func (w *statusResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
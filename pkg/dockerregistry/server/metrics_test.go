@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestInstrumentHandler(t *testing.T) {
+	out := &bytes.Buffer{}
+	audit, err := NewAuditLogger(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookups := 0
+	audit.userFn = func(token string) (string, error) {
+		lookups++
+		if token != "secret" {
+			return "", fmt.Errorf("unknown token")
+		}
+		return "alice", nil
+	}
+	handler := InstrumentHandler(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == "PUT":
+			w.WriteHeader(http.StatusCreated)
+		case strings.HasPrefix(req.URL.Path, "/v2/unknown/"):
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}), audit)
+
+	tests := []struct {
+		method, path, token string
+		event, user         string
+	}{
+		{method: "GET", path: "/v2/test/app/manifests/latest", token: "secret", event: "pull", user: "alice"},
+		{method: "PUT", path: "/v2/test/app/manifests/latest", token: "secret", event: "push", user: "alice"},
+		{method: "GET", path: "/v2/test/app/manifests/latest", token: "other", event: "pull"},
+		{method: "GET", path: "/v2/test/app/blobs/sha256:b4ca4c215f483111b64ec6919f1659ff475d7080a649d6acd78a6ade562a4a63", token: "secret"},
+		{method: "GET", path: "/healthz"},
+	}
+	for _, test := range tests {
+		req, _ := http.NewRequest(test.method, "http://localhost"+test.path, nil)
+		if len(test.token) > 0 {
+			req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("user:"+test.token)))
+		}
+		out.Reset()
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		if len(test.event) == 0 {
+			if out.Len() != 0 {
+				t.Errorf("%s %s: unexpected audit record: %s", test.method, test.path, out.String())
+			}
+			continue
+		}
+		record := map[string]interface{}{}
+		if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+			t.Errorf("%s %s: invalid audit record %q: %v", test.method, test.path, out.String(), err)
+			continue
+		}
+		if record["event"] != test.event || record["repository"] != "test/app" || record["reference"] != "latest" || record["user"] != test.user {
+			t.Errorf("%s %s: unexpected audit record: %v", test.method, test.path, record)
+		}
+	}
+	if lookups != 2 {
+		t.Errorf("expected the user of each token to be looked up once, got %d lookups", lookups)
+	}
+
+	metric := &dto.Metric{}
+	if err := requestCounter.WithLabelValues("PUT", "manifest", "test/app", "201").Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if metric.GetCounter().GetValue() != 1 {
+		t.Errorf("unexpected request count: %v", metric)
+	}
+	if err := requestCounter.WithLabelValues("GET", "blob", "test/app", "200").Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if metric.GetCounter().GetValue() != 1 {
+		t.Errorf("unexpected request count: %v", metric)
+	}
+
+	// failed requests are counted without their repository
+	for _, name := range []string{"unknown/a", "unknown/b"} {
+		req, _ := http.NewRequest("GET", "http://localhost/v2/"+name+"/manifests/latest", nil)
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	if err := requestCounter.WithLabelValues("GET", "manifest", "", "404").Write(metric); err != nil {
+		t.Fatal(err)
+	}
+	if metric.GetCounter().GetValue() != 2 {
+		t.Errorf("unexpected request count: %v", metric)
+	}
+}
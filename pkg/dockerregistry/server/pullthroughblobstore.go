@@ -65,6 +65,7 @@ func (r *pullthroughBlobStore) Stat(ctx context.Context, dgst digest.Digest) (di
 	// look at the first level of tagged repositories first
 	search := identifyCandidateRepositories(is, localRegistry, true)
 	if desc, err := r.findCandidateRepository(ctx, search, cached, dgst, retriever); err == nil {
+		pullthroughBlobCounter.WithLabelValues("hit").Inc()
 		return desc, nil
 	}
 
@@ -74,9 +75,11 @@ func (r *pullthroughBlobStore) Stat(ctx context.Context, dgst digest.Digest) (di
 		delete(secondary, k)
 	}
 	if desc, err := r.findCandidateRepository(ctx, secondary, cached, dgst, retriever); err == nil {
+		pullthroughBlobCounter.WithLabelValues("hit").Inc()
 		return desc, nil
 	}
 
+	pullthroughBlobCounter.WithLabelValues("miss").Inc()
 	return distribution.Descriptor{}, distribution.ErrBlobUnknown
 }
 
@@ -120,9 +123,18 @@ func (r *pullthroughBlobStore) ServeBlob(ctx context.Context, w http.ResponseWri
 		context.GetLogger(r.repo.ctx).Errorf("Failure to open remote store %q: %v", dgst.String(), err)
 		return err
 	}
+	defer remoteReader.Close()
 
 	setResponseHeaders(w, desc.Size, desc.MediaType, dgst)
 
+	if r.repo.mirrorpullthrough {
+		local, err := r.BlobStore.Create(ctx)
+		if err == nil {
+			return r.serveAndStore(ctx, w, remoteReader, local, desc, dgst)
+		}
+		context.GetLogger(r.repo.ctx).Errorf("Unable to store blob %q locally: %v", dgst.String(), err)
+	}
+
 	context.GetLogger(r.repo.ctx).Infof("Copying %d bytes of type %q for %q", desc.Size, desc.MediaType, dgst.String())
 	if _, err := io.CopyN(w, remoteReader, desc.Size); err != nil {
 		context.GetLogger(r.repo.ctx).Errorf("Failed copying content from remote store %q: %v", dgst.String(), err)
@@ -131,6 +143,51 @@ func (r *pullthroughBlobStore) ServeBlob(ctx context.Context, w http.ResponseWri
 	return nil
 }
 
+// serveAndStore copies the remote content onto w and into the local blob writer at the same time. Once
+// the blob is committed, it is linked to the repository and later requests are served from the local
+// storage. Failing to store the blob does not fail the request.
+func (r *pullthroughBlobStore) serveAndStore(ctx context.Context, w http.ResponseWriter, remoteReader io.Reader, local distribution.BlobWriter, desc distribution.Descriptor, dgst digest.Digest) error {
+	mirror := &bestEffortWriter{w: local}
+	context.GetLogger(r.repo.ctx).Infof("Copying and storing %d bytes of type %q for %q", desc.Size, desc.MediaType, dgst.String())
+	if _, err := io.CopyN(io.MultiWriter(w, mirror), remoteReader, desc.Size); err != nil {
+		context.GetLogger(r.repo.ctx).Errorf("Failed copying content from remote store %q: %v", dgst.String(), err)
+		local.Cancel(ctx)
+		return err
+	}
+	if mirror.err != nil {
+		context.GetLogger(r.repo.ctx).Errorf("Unable to store blob %q locally: %v", dgst.String(), mirror.err)
+		local.Cancel(ctx)
+		return nil
+	}
+
+	desc.Digest = dgst
+	if len(desc.MediaType) == 0 {
+		desc.MediaType = "application/octet-stream"
+	}
+	if _, err := local.Commit(ctx, desc); err != nil {
+		context.GetLogger(r.repo.ctx).Errorf("Unable to store blob %q locally: %v", dgst.String(), err)
+		local.Cancel(ctx)
+		return nil
+	}
+	r.repo.cachedLayers.RememberDigest(dgst, r.repo.namespace+"/"+r.repo.name)
+	context.GetLogger(r.repo.ctx).Infof("Stored blob %q in %s/%s", dgst.String(), r.repo.namespace, r.repo.name)
+	return nil
+}
+
+// bestEffortWriter writes to w until the first error, which it records. It never fails, so that
+// writing to it does not interrupt the other writers of an io.MultiWriter.
+type bestEffortWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (b *bestEffortWriter) Write(p []byte) (int, error) {
+	if b.err == nil {
+		_, b.err = b.w.Write(p)
+	}
+	return len(p), nil
+}
+
 // findCandidateRepository looks in search for a particular blob, referring to previously cached items
 func (r *pullthroughBlobStore) findCandidateRepository(ctx context.Context, search map[string]*imageapi.DockerImageReference, cachedLayers []string, dgst digest.Digest, retriever importer.RepositoryRetriever) (distribution.Descriptor, error) {
 	// no possible remote locations to search, exit early
@@ -0,0 +1,72 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestPullthroughServeBlob(t *testing.T) {
+	ctx := context.Background()
+	content := []byte("remote layer")
+	dgst, err := digest.FromBytes(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote := &fakeBlobStore{blobs: map[digest.Digest][]byte{dgst: content}}
+	client := &testclient.Fake{}
+	client.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("imagestreams"), "app")
+	})
+
+	for _, mirror := range []bool{false, true} {
+		registry, err := storage.NewRegistry(ctx, inmemory.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		local, err := registry.Repository(ctx, "test/app")
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo := newTestRepository(client, nil)
+		repo.Repository = local
+		repo.pullthrough = true
+		repo.mirrorpullthrough = mirror
+
+		bs := repo.Blobs(ctx).(*pullthroughBlobStore)
+		bs.digestToStore[dgst.String()] = remote
+		w := httptest.NewRecorder()
+		if err := bs.ServeBlob(ctx, w, &http.Request{}, dgst); err != nil {
+			t.Fatalf("mirror=%t: unexpected error: %v", mirror, err)
+		}
+		if w.Body.String() != string(content) || w.Header().Get("Docker-Content-Digest") != dgst.String() {
+			t.Errorf("mirror=%t: unexpected response: %#v", mirror, w)
+		}
+
+		_, err = repo.Blobs(ctx).Stat(ctx, dgst)
+		switch {
+		case mirror && err != nil:
+			t.Errorf("expected the blob to be stored locally: %v", err)
+		case !mirror && err != distribution.ErrBlobUnknown:
+			t.Errorf("expected the blob not to be stored locally, got %v", err)
+		}
+		repos := sets.NewString(repo.cachedLayers.RepositoriesForDigest(dgst)...)
+		if repos.Has("test/app") != mirror {
+			t.Errorf("mirror=%t: unexpected repositories cached for the blob: %v", mirror, repos.List())
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"time"
+
+	"github.com/hashicorp/golang-lru"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kcache "k8s.io/kubernetes/pkg/client/cache"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	// defaultProjectCacheTTL is how long the resource quotas and image streams of a project are
+	// cached when admitting pushed images.
+	defaultProjectCacheTTL = 30 * time.Second
+	// defaultImageCacheSize is the number of images kept to compute the image storage used by the
+	// projects.
+	defaultImageCacheSize = 4096
+)
+
+// projectCache caches the objects a pushed image is admitted against: the resource quotas and the
+// image streams of the project for a short time, and the images referenced by the streams, which
+// never change the layers they are computed from. The images pushed to a project while its image
+// streams are cached are not counted until they expire, so a project may exceed its quota by the
+// images pushed during that time; the image quota controller of the master reports the actual
+// usage. Thread safe and shared by all middleware layers.
+type projectCache struct {
+	quotas  kcache.Store
+	streams kcache.Store
+	images  *lru.Cache
+}
+
+// projectList is the list of objects of a project kept in a projectCache.
+type projectList struct {
+	namespace string
+	items     interface{}
+}
+
+func projectListKey(obj interface{}) (string, error) {
+	return obj.(*projectList).namespace, nil
+}
+
+// newProjectCache returns a projectCache keeping the lists of a project for ttl and imageCacheSize
+// images.
+func newProjectCache(ttl time.Duration, imageCacheSize int) (*projectCache, error) {
+	images, err := lru.New(imageCacheSize)
+	if err != nil {
+		return nil, err
+	}
+	return &projectCache{
+		quotas:  kcache.NewTTLStore(projectListKey, ttl),
+		streams: kcache.NewTTLStore(projectListKey, ttl),
+		images:  images,
+	}, nil
+}
+
+// ResourceQuotas returns the resource quotas of namespace, calling list if they are not cached.
+func (c *projectCache) ResourceQuotas(namespace string, list func() (*kapi.ResourceQuotaList, error)) ([]kapi.ResourceQuota, error) {
+	if obj, exists, err := c.quotas.GetByKey(namespace); err == nil && exists {
+		return obj.(*projectList).items.([]kapi.ResourceQuota), nil
+	}
+	quotas, err := list()
+	if err != nil {
+		return nil, err
+	}
+	c.quotas.Add(&projectList{namespace: namespace, items: quotas.Items})
+	return quotas.Items, nil
+}
+
+// ImageStreams returns the image streams of namespace, calling list if they are not cached.
+func (c *projectCache) ImageStreams(namespace string, list func() (*imageapi.ImageStreamList, error)) ([]imageapi.ImageStream, error) {
+	if obj, exists, err := c.streams.GetByKey(namespace); err == nil && exists {
+		return obj.(*projectList).items.([]imageapi.ImageStream), nil
+	}
+	streams, err := list()
+	if err != nil {
+		return nil, err
+	}
+	c.streams.Add(&projectList{namespace: namespace, items: streams.Items})
+	return streams.Items, nil
+}
+
+// Image returns the named image, calling get if it is not cached.
+func (c *projectCache) Image(name string, get func() (*imageapi.Image, error)) (*imageapi.Image, error) {
+	if obj, ok := c.images.Get(name); ok {
+		return obj.(*imageapi.Image), nil
+	}
+	image, err := get()
+	if err != nil {
+		return nil, err
+	}
+	c.images.Add(name, image)
+	return image, nil
+}
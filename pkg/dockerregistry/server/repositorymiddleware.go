@@ -7,12 +7,16 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/docker/distribution"
 	"github.com/docker/distribution/context"
 	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/registry/api/errcode"
+	"github.com/docker/distribution/registry/api/v2"
 	repomw "github.com/docker/distribution/registry/middleware/repository"
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
 	"github.com/docker/libtrust"
 
 	kapi "k8s.io/kubernetes/pkg/api"
@@ -22,26 +26,63 @@ import (
 	"github.com/openshift/origin/pkg/client"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/image/importer"
+	imagequota "github.com/openshift/origin/pkg/quota/image"
 )
 
+// ErrorCodeQuotaExceeded is returned when storing a pushed image would exceed the image storage quota
+// of the project.
+var ErrorCodeQuotaExceeded = errcode.Register("openshift", errcode.ErrorDescriptor{
+	Value:   "QUOTA_EXCEEDED",
+	Message: "image storage quota exceeded",
+	Description: `The image cannot be stored because it exceeds a resource
+	quota of the project.`,
+	HTTPStatusCode: http.StatusForbidden,
+})
+
+// ErrorCodeGarbageCollecting is returned for the manifests pushed while the garbage collector deletes
+// blobs.
+var ErrorCodeGarbageCollecting = errcode.Register("openshift", errcode.ErrorDescriptor{
+	Value:   "GARBAGE_COLLECTING",
+	Message: "the registry is deleting unused blobs, retry later",
+	Description: `The manifest cannot be stored while the garbage collector
+	deletes the blobs of the registry that are not referenced by any image.`,
+	HTTPStatusCode: http.StatusServiceUnavailable,
+})
+
 var (
 	// cachedLayers is a shared cache of blob digests to remote repositories that have previously
 	// been identified as containing that blob. Thread safe and reused by all middleware layers.
 	cachedLayers digestToRepositoryCache
+	// cachedProjects is a shared cache of the objects pushed images are admitted against. Thread safe
+	// and reused by all middleware layers.
+	cachedProjects *projectCache
 	// secureTransport is the transport pool used for pullthrough to remote registries marked as
 	// secure.
 	secureTransport http.RoundTripper
 	// insecureTransport is the transport pool that does not verify remote TLS certificates for use
 	// during pullthrough against registries marked as insecure.
 	insecureTransport http.RoundTripper
+	// storageDriver is the storage driver of the registry, used to find out whether the garbage
+	// collector is deleting blobs. Unset when the middleware runs outside of the registry.
+	storageDriver storagedriver.StorageDriver
 )
 
+// SetStorageDriver sets the storage driver of the registry, which the middleware checks for the lock of the
+// garbage collector before accepting manifests.
+func SetStorageDriver(driver storagedriver.StorageDriver) {
+	storageDriver = driver
+}
+
 func init() {
 	cache, err := newDigestToRepositoryCache(1024)
 	if err != nil {
 		panic(err)
 	}
 	cachedLayers = cache
+	cachedProjects, err = newProjectCache(defaultProjectCacheTTL, defaultImageCacheSize)
+	if err != nil {
+		panic(err)
+	}
 	repomw.Register("openshift", repomw.InitFunc(newRepository))
 
 	secureTransport = http.DefaultTransport
@@ -58,6 +99,7 @@ type repository struct {
 
 	ctx            context.Context
 	registryClient client.Interface
+	quotaClient    kclient.ResourceQuotasNamespacer
 	registryAddr   string
 	namespace      string
 	name           string
@@ -65,13 +107,29 @@ type repository struct {
 	// if true, the repository will check remote references in the image stream to support pulling "through"
 	// from a remote repository
 	pullthrough bool
+	// if true, blobs served from remote repositories are also stored in the local storage of the
+	// repository, so that later requests for them are served locally
+	mirrorpullthrough bool
+	// if true, the repository accepts schema 2 manifests and manifest lists on push. Clients pushing to a
+	// repository that does not accept them fall back to schema 1.
+	acceptschema2 bool
+	// if true, pushed images are refused if storing them would exceed the image storage limits of a
+	// resource quota of the project.
+	enforcequota bool
 	// cachedLayers remembers a mapping of layer digest to repositories recently seen with that image to avoid
 	// having to check every potential upstream repository when a blob request is made. The cache is useful only
 	// when session affinity is on for the registry, but in practice the first pull will fill the cache.
 	cachedLayers digestToRepositoryCache
+	// cachedProjects keeps the resource quotas and image streams of the project and the images they
+	// reference for a short time, so that admitting a pushed image doesn't list all of them.
+	cachedProjects *projectCache
+	// signingKey signs the schema 1 manifests converted from schema 2 manifests for the clients that
+	// don't accept schema 2 manifests.
+	signingKey libtrust.PrivateKey
 }
 
 var _ distribution.ManifestService = &repository{}
+var _ distribution.RawManifestService = &repository{}
 
 // newRepository returns a new repository middleware.
 func newRepository(ctx context.Context, repo distribution.Repository, options map[string]interface{}) (distribution.Repository, error) {
@@ -87,10 +145,46 @@ func newRepository(ctx context.Context, repo distribution.Repository, options ma
 		}
 	}
 
+	mirrorpullthrough := false
+	if value, ok := options["mirrorpullthrough"]; ok {
+		if b, ok := value.(bool); ok {
+			mirrorpullthrough = b
+		}
+	}
+
+	acceptschema2 := true
+	if value, ok := options["acceptschema2"]; ok {
+		if b, ok := value.(bool); ok {
+			acceptschema2 = b
+		}
+	}
+
+	enforcequota := true
+	if value, ok := options["enforcequota"]; ok {
+		if b, ok := value.(bool); ok {
+			enforcequota = b
+		}
+	}
+
+	signingKeyFile := ""
+	if value, ok := options["signingkeyfile"]; ok {
+		if s, ok := value.(string); ok {
+			signingKeyFile = s
+		}
+	}
+	signingKey, err := loadSigningKey(signingKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the key to sign schema 1 manifests with: %v", err)
+	}
+
 	registryClient, err := NewRegistryOpenShiftClient()
 	if err != nil {
 		return nil, err
 	}
+	quotaClient, err := NewRegistryKubernetesClient()
+	if err != nil {
+		return nil, err
+	}
 
 	nameParts := strings.SplitN(repo.Name(), "/", 2)
 	if len(nameParts) != 2 {
@@ -100,13 +194,19 @@ func newRepository(ctx context.Context, repo distribution.Repository, options ma
 	return &repository{
 		Repository: repo,
 
-		ctx:            ctx,
-		registryClient: registryClient,
-		registryAddr:   registryAddr,
-		namespace:      nameParts[0],
-		name:           nameParts[1],
-		pullthrough:    pullthrough,
-		cachedLayers:   cachedLayers,
+		ctx:               ctx,
+		registryClient:    registryClient,
+		quotaClient:       quotaClient,
+		registryAddr:      registryAddr,
+		namespace:         nameParts[0],
+		name:              nameParts[1],
+		pullthrough:       pullthrough,
+		mirrorpullthrough: mirrorpullthrough,
+		acceptschema2:     acceptschema2,
+		enforcequota:      enforcequota,
+		cachedLayers:      cachedLayers,
+		cachedProjects:    cachedProjects,
+		signingKey:        signingKey,
 	}, nil
 }
 
@@ -183,7 +283,7 @@ func (r *repository) Get(dgst digest.Digest) (*schema1.SignedManifest, error) {
 	}
 
 	ref := imageapi.DockerImageReference{Namespace: r.namespace, Name: r.name, Registry: r.registryAddr}
-	return r.manifestFromImageWithCachedLayers(image, ref.DockerClientDefaults().Exact())
+	return r.manifestFromImageWithCachedLayers(image, "", ref.DockerClientDefaults().Exact())
 }
 
 // Enumerate retrieves digests of manifest revisions in particular repository
@@ -219,7 +319,7 @@ func (r *repository) GetByTag(tag string, options ...distribution.ManifestServic
 
 	// if we have a local manifest, use it
 	if len(image.DockerImageManifest) > 0 {
-		return r.manifestFromImageWithCachedLayers(image, cacheName)
+		return r.manifestFromImageWithCachedLayers(image, tag, cacheName)
 	}
 
 	dgst, err := digest.ParseDigest(imageStreamTag.Image.Name)
@@ -237,7 +337,7 @@ func (r *repository) GetByTag(tag string, options ...distribution.ManifestServic
 	} else {
 		// if we have a local manifest, use it
 		if len(localImage.DockerImageManifest) > 0 {
-			return r.manifestFromImageWithCachedLayers(localImage, cacheName)
+			return r.manifestFromImageWithCachedLayers(localImage, tag, cacheName)
 		}
 	}
 
@@ -303,6 +403,10 @@ func (r *repository) pullthroughGetByTag(image *imageapi.Image, ref imageapi.Doc
 
 // Put creates or updates the named manifest.
 func (r *repository) Put(manifest *schema1.SignedManifest) error {
+	if err := r.checkGarbageCollecting(); err != nil {
+		return err
+	}
+
 	// Resolve the payload in the manifest.
 	payload, err := manifest.Payload()
 	if err != nil {
@@ -334,7 +438,34 @@ func (r *repository) Put(manifest *schema1.SignedManifest) error {
 		},
 	}
 
-	if err := r.registryClient.ImageStreamMappings(r.namespace).Create(&ism); err != nil {
+	if err := r.createImageStreamMapping(&ism); err != nil {
+		return err
+	}
+
+	// Grab each json signature and store them.
+	signatures, err := manifest.Signatures()
+	if err != nil {
+		return err
+	}
+
+	for _, signature := range signatures {
+		if err := r.Signatures().Put(dgst, signature); err != nil {
+			context.GetLogger(r.ctx).Errorf("Error storing signature: %s", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// createImageStreamMapping uploads the image in ism to OpenShift, auto provisioning the image stream if
+// it does not exist yet.
+func (r *repository) createImageStreamMapping(ism *imageapi.ImageStreamMapping) error {
+	if err := r.admitImage(&ism.Image); err != nil {
+		return err
+	}
+
+	if err := r.registryClient.ImageStreamMappings(r.namespace).Create(ism); err != nil {
 		// if the error was that the image stream wasn't found, try to auto provision it
 		statusErr, ok := err.(*kerrors.StatusError)
 		if !ok {
@@ -366,25 +497,292 @@ func (r *repository) Put(manifest *schema1.SignedManifest) error {
 		}
 
 		// try to create the ISM again
-		if err := r.registryClient.ImageStreamMappings(r.namespace).Create(&ism); err != nil {
+		if err := r.registryClient.ImageStreamMappings(r.namespace).Create(ism); err != nil {
 			context.GetLogger(r.ctx).Errorf("Error creating image stream mapping: %s", err)
 			return err
 		}
 	}
+	return nil
+}
 
-	// Grab each json signature and store them.
-	signatures, err := manifest.Signatures()
+// admitImage refuses the image if tagging it into the repository would exceed the image storage
+// limits of a resource quota of the project. The quotas, image streams and images are read from
+// cachedProjects.
+func (r *repository) admitImage(image *imageapi.Image) error {
+	if !r.enforcequota {
+		return nil
+	}
+	quotas, err := r.cachedProjects.ResourceQuotas(r.namespace, func() (*kapi.ResourceQuotaList, error) {
+		return r.quotaClient.ResourceQuotas(r.namespace).List(kapi.ListOptions{})
+	})
 	if err != nil {
+		context.GetLogger(r.ctx).Errorf("Error listing resource quotas of %s: %v", r.namespace, err)
 		return err
 	}
+	limited := false
+	for _, quota := range quotas {
+		limited = limited || imagequota.HasImageResources(quota.Spec.Hard)
+	}
+	if !limited {
+		return nil
+	}
 
-	for _, signature := range signatures {
-		if err := r.Signatures().Put(dgst, signature); err != nil {
-			context.GetLogger(r.ctx).Errorf("Error storing signature: %s", err)
-			return err
+	streams, err := r.cachedProjects.ImageStreams(r.namespace, func() (*imageapi.ImageStreamList, error) {
+		return r.registryClient.ImageStreams(r.namespace).List(kapi.ListOptions{})
+	})
+	if err != nil {
+		context.GetLogger(r.ctx).Errorf("Error listing ImageStreams of %s: %v", r.namespace, err)
+		return err
+	}
+	err = imagequota.AdmitImage(quotas, image, streams, r.name, func(name string) (*imageapi.Image, error) {
+		return r.cachedProjects.Image(name, func() (*imageapi.Image, error) {
+			return r.registryClient.Images().Get(name)
+		})
+	})
+	if _, exceeded := err.(*imagequota.QuotaExceededError); exceeded {
+		return ErrorCodeQuotaExceeded.WithDetail(err.Error())
+	}
+	return err
+}
+
+// GetRaw retrieves the manifest identified by a tag or a digest. Images pushed or imported as schema 2
+// manifests or manifest lists are returned as such if the client accepts their media type, other images are
+// returned as signed schema 1 manifests. Schema 2 manifests are converted to schema 1 manifests signed with
+// the key of the registry for the clients that don't accept them.
+func (r *repository) GetRaw(reference string, accept []string) ([]byte, string, error) {
+	var image *imageapi.Image
+	tag := ""
+	ref := imageapi.DockerImageReference{Namespace: r.namespace, Name: r.name, Registry: r.registryAddr}
+	cacheName := ref.DockerClientDefaults().Exact()
+
+	if dgst, err := digest.ParseDigest(reference); err == nil {
+		image, err = r.getStreamImage(dgst)
+		if err != nil {
+			context.GetLogger(r.ctx).Errorf("Error retrieving image %s/%s@%s: %v", r.namespace, r.name, dgst.String(), err)
+			return nil, "", err
 		}
+	} else {
+		tag = reference
+		imageStreamTag, err := r.getImageStreamTag(reference)
+		if err != nil {
+			context.GetLogger(r.ctx).Errorf("Error getting ImageStreamTag %q: %v", reference, err)
+			return nil, "", err
+		}
+		image = &imageStreamTag.Image
+
+		ref, referenceErr := imageapi.ParseDockerImageReference(image.DockerImageReference)
+		if referenceErr == nil {
+			ref.Namespace = r.namespace
+			ref.Name = r.name
+			ref.Registry = r.registryAddr
+		}
+		cacheName = ref.DockerClientDefaults().AsRepository().Exact()
+
+		if len(image.DockerImageManifest) == 0 {
+			dgst, err := digest.ParseDigest(image.Name)
+			if err != nil {
+				context.GetLogger(r.ctx).Errorf("Error parsing digest %q: %v", image.Name, err)
+				return nil, "", err
+			}
+			localImage, err := r.getImage(dgst)
+			if err != nil {
+				// if the image is managed by OpenShift and we cannot load the image, report an error
+				if image.Annotations[imageapi.ManagedByOpenShiftAnnotation] == "true" {
+					context.GetLogger(r.ctx).Errorf("Error getting image %q: %v", dgst.String(), err)
+					return nil, "", err
+				}
+			} else if len(localImage.DockerImageManifest) > 0 {
+				image = localImage
+			}
+		}
+
+		if len(image.DockerImageManifest) == 0 {
+			// allow pullthrough to be disabled
+			if !r.pullthrough {
+				return nil, "", distribution.ErrManifestBlobUnknown{Digest: digest.Digest(image.Name)}
+			}
+			// check the previous error here
+			if referenceErr != nil {
+				context.GetLogger(r.ctx).Errorf("Error parsing image %q: %v", image.DockerImageReference, referenceErr)
+				return nil, "", referenceErr
+			}
+			return r.pullthroughGetRaw(image, ref, cacheName, accept)
+		}
+	}
+
+	switch image.DockerImageManifestMediaType {
+	case imageapi.DockerManifestSchema2MediaType:
+		if acceptsMediaType(accept, image.DockerImageManifestMediaType) {
+			r.rememberSchema2Layers([]byte(image.DockerImageManifest), cacheName)
+			return []byte(image.DockerImageManifest), image.DockerImageManifestMediaType, nil
+		}
+	case imageapi.DockerManifestListMediaType:
+		if !acceptsMediaType(accept, image.DockerImageManifestMediaType) {
+			return nil, "", fmt.Errorf("the manifest of %s is of type %s, which the client does not accept", image.Name, image.DockerImageManifestMediaType)
+		}
+		r.rememberSchema2Layers([]byte(image.DockerImageManifest), cacheName)
+		return []byte(image.DockerImageManifest), image.DockerImageManifestMediaType, nil
 	}
 
+	manifest, err := r.manifestFromImageWithCachedLayers(image, tag, cacheName)
+	if err != nil {
+		return nil, "", err
+	}
+	return manifest.Raw, imageapi.DockerManifestSchema1MediaType, nil
+}
+
+// pullthroughGetRaw attempts to load the raw manifest of the given image from the remote server defined by
+// ref, using cacheName to store any cached layers.
+func (r *repository) pullthroughGetRaw(image *imageapi.Image, ref imageapi.DockerImageReference, cacheName string, accept []string) ([]byte, string, error) {
+	defaultRef := ref.DockerClientDefaults()
+
+	retriever := r.importContext()
+
+	repo, err := retriever.Repository(r.ctx, defaultRef.RegistryURL(), defaultRef.RepositoryName(), false)
+	if err != nil {
+		context.GetLogger(r.ctx).Errorf("Error getting remote repository for image %q: %v", image.DockerImageReference, err)
+		return nil, "", err
+	}
+
+	manifests, err := repo.Manifests(r.ctx)
+	if err != nil {
+		context.GetLogger(r.ctx).Errorf("Error getting manifests for image %q: %v", image.DockerImageReference, err)
+		return nil, "", err
+	}
+
+	rms, ok := manifests.(distribution.RawManifestService)
+	if !ok {
+		manifest, err := r.pullthroughGetByTag(image, ref, cacheName)
+		if err != nil {
+			return nil, "", err
+		}
+		return manifest.Raw, imageapi.DockerManifestSchema1MediaType, nil
+	}
+
+	reference := ref.Tag
+	if len(ref.ID) > 0 {
+		reference = ref.ID
+	}
+	payload, mediaType, err := rms.GetRaw(reference, accept)
+	if err != nil {
+		context.GetLogger(r.ctx).Errorf("Error getting manifest from remote server for image %q: %v", image.DockerImageReference, err)
+		return nil, "", err
+	}
+
+	r.rememberSchema2Layers(payload, cacheName)
+	return payload, mediaType, nil
+}
+
+// checkGarbageCollecting refuses manifests while the garbage collector holds its lock, since they
+// may reference blobs that are about to be deleted.
+func (r *repository) checkGarbageCollecting() error {
+	if storageDriver == nil {
+		return nil
+	}
+	locked, err := gcLocked(r.ctx, storageDriver, time.Now())
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrorCodeGarbageCollecting
+	}
+	return nil
+}
+
+// PutRaw creates or updates the image of a schema 2 manifest or a manifest list through an image stream
+// mapping. Images pushed with a tag are mapped into the image stream. Images pushed by digest only, as the
+// platform specific images of a manifest list are, are mapped without a tag, which creates the image
+// without tagging it, and become part of the image stream once a manifest list referencing them is tagged.
+func (r *repository) PutRaw(payload []byte, mediaType, tag string) (digest.Digest, error) {
+	if !r.acceptschema2 {
+		return "", v2.ErrorCodeManifestInvalid.WithDetail("schema 2 manifests are not accepted by this registry")
+	}
+	if err := r.checkGarbageCollecting(); err != nil {
+		return "", err
+	}
+
+	dgst, err := digest.FromBytes(payload)
+	if err != nil {
+		return "", err
+	}
+
+	image := imageapi.Image{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: dgst.String(),
+			Annotations: map[string]string{
+				imageapi.ManagedByOpenShiftAnnotation: "true",
+			},
+		},
+		DockerImageReference:         fmt.Sprintf("%s/%s/%s@%s", r.registryAddr, r.namespace, r.name, dgst.String()),
+		DockerImageManifest:          string(payload),
+		DockerImageManifestMediaType: mediaType,
+	}
+
+	switch mediaType {
+	case imageapi.DockerManifestSchema2MediaType:
+		manifest := imageapi.DockerImageManifest{}
+		if err := json.Unmarshal(payload, &manifest); err != nil {
+			return "", v2.ErrorCodeManifestInvalid.WithDetail(err)
+		}
+		if err := r.verifySchema2Blobs(&manifest); err != nil {
+			return "", err
+		}
+		config, err := r.Repository.Blobs(r.ctx).Get(r.ctx, manifest.Config.Digest)
+		if err != nil {
+			context.GetLogger(r.ctx).Errorf("Error reading image config %s: %v", manifest.Config.Digest, err)
+			return "", err
+		}
+		image.DockerImageConfig = string(config)
+
+	case imageapi.DockerManifestListMediaType:
+		list := imageapi.DockerManifestList{}
+		if err := json.Unmarshal(payload, &list); err != nil {
+			return "", v2.ErrorCodeManifestInvalid.WithDetail(err)
+		}
+		var errs distribution.ErrManifestVerification
+		for _, m := range list.Manifests {
+			if _, err := r.getImage(m.Digest); err != nil {
+				errs = append(errs, distribution.ErrManifestBlobUnknown{Digest: m.Digest})
+			}
+		}
+		if len(errs) > 0 {
+			return "", errs
+		}
+
+	default:
+		return "", v2.ErrorCodeManifestInvalid.WithDetail(fmt.Sprintf("unsupported manifest media type %q", mediaType))
+	}
+
+	ism := imageapi.ImageStreamMapping{
+		ObjectMeta: kapi.ObjectMeta{
+			Namespace: r.namespace,
+			Name:      r.name,
+		},
+		Tag:   tag,
+		Image: image,
+	}
+	if err := r.createImageStreamMapping(&ism); err != nil {
+		return "", err
+	}
+	return dgst, nil
+}
+
+// verifySchema2Blobs ensures the layers and the configuration referenced by manifest have been uploaded.
+func (r *repository) verifySchema2Blobs(manifest *imageapi.DockerImageManifest) error {
+	blobs := r.Repository.Blobs(r.ctx)
+	var errs distribution.ErrManifestVerification
+	for _, desc := range append([]distribution.Descriptor{manifest.Config}, manifest.Layers...) {
+		if _, err := blobs.Stat(r.ctx, desc.Digest); err != nil {
+			if err != distribution.ErrBlobUnknown {
+				errs = append(errs, err)
+				continue
+			}
+			errs = append(errs, distribution.ErrManifestBlobUnknown{Digest: desc.Digest})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
 	return nil
 }
 
@@ -427,6 +825,49 @@ func (r *repository) getImageStreamTag(tag string) (*imageapi.ImageStreamTag, er
 	return r.registryClient.ImageStreamTags(r.namespace).Get(r.name, tag)
 }
 
+// getStreamImage retrieves the Image with digest `dgst` if it belongs to the ImageStream associated with r,
+// either directly or as a platform specific image of a manifest list tagged in the ImageStream.
+func (r *repository) getStreamImage(dgst digest.Digest) (*imageapi.Image, error) {
+	if _, err := r.getImageStreamImage(dgst); err != nil {
+		if !kerrors.IsNotFound(err) || !r.referencedByManifestList(dgst) {
+			return nil, err
+		}
+	}
+	return r.getImage(dgst)
+}
+
+// referencedByManifestList returns true if the latest image of any tag of the ImageStream associated with r
+// is a manifest list referencing the image with digest `dgst`.
+func (r *repository) referencedByManifestList(dgst digest.Digest) bool {
+	imageStream, err := r.getImageStream()
+	if err != nil {
+		return false
+	}
+	for _, history := range imageStream.Status.Tags {
+		if len(history.Items) == 0 {
+			continue
+		}
+		listDigest, err := digest.ParseDigest(history.Items[0].Image)
+		if err != nil {
+			continue
+		}
+		image, err := r.getImage(listDigest)
+		if err != nil || image.DockerImageManifestMediaType != imageapi.DockerManifestListMediaType {
+			continue
+		}
+		list := imageapi.DockerManifestList{}
+		if err := json.Unmarshal([]byte(image.DockerImageManifest), &list); err != nil {
+			continue
+		}
+		for _, m := range list.Manifests {
+			if m.Digest == dgst {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // getImageStreamImage retrieves the Image with digest `dgst` for the ImageStream
 // associated with r. This ensures the image belongs to the image stream.
 func (r *repository) getImageStreamImage(dgst digest.Digest) (*imageapi.ImageStreamImage, error) {
@@ -444,9 +885,36 @@ func (r *repository) rememberLayers(manifest *schema1.SignedManifest, cacheName
 	}
 }
 
+// rememberSchema2Layers caches the layers and the configuration of a schema 2 manifest
+func (r *repository) rememberSchema2Layers(payload []byte, cacheName string) {
+	if !r.pullthrough {
+		return
+	}
+	manifest := imageapi.DockerImageManifest{}
+	if err := json.Unmarshal(payload, &manifest); err != nil || manifest.SchemaVersion != 2 {
+		return
+	}
+	if len(manifest.Config.Digest) > 0 {
+		r.cachedLayers.RememberDigest(manifest.Config.Digest, cacheName)
+	}
+	for _, layer := range manifest.Layers {
+		r.cachedLayers.RememberDigest(layer.Digest, cacheName)
+	}
+}
+
+// acceptsMediaType returns true if mediaType is one of the accepted media types.
+func acceptsMediaType(accept []string, mediaType string) bool {
+	for _, t := range accept {
+		if t == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
 // manifestFromImageWithCachedLayers loads the image and then caches any located layers
-func (r *repository) manifestFromImageWithCachedLayers(image *imageapi.Image, cacheName string) (*schema1.SignedManifest, error) {
-	manifest, err := r.manifestFromImage(image)
+func (r *repository) manifestFromImageWithCachedLayers(image *imageapi.Image, tag, cacheName string) (*schema1.SignedManifest, error) {
+	manifest, err := r.manifestFromImage(image, tag)
 	if err != nil {
 		return nil, err
 	}
@@ -454,8 +922,16 @@ func (r *repository) manifestFromImageWithCachedLayers(image *imageapi.Image, ca
 	return manifest, nil
 }
 
-// manifestFromImage converts an Image to a SignedManifest.
-func (r *repository) manifestFromImage(image *imageapi.Image) (*schema1.SignedManifest, error) {
+// manifestFromImage converts an Image to a SignedManifest. Schema 2 manifests are converted to schema 1
+// manifests for tag, which is empty if the image is requested by digest.
+func (r *repository) manifestFromImage(image *imageapi.Image, tag string) (*schema1.SignedManifest, error) {
+	switch image.DockerImageManifestMediaType {
+	case imageapi.DockerManifestSchema2MediaType:
+		return r.convertSchema2Manifest(image, tag)
+	case imageapi.DockerManifestListMediaType:
+		return nil, fmt.Errorf("the manifest of %s is of type %s and cannot be served to clients that only accept schema 1 manifests", image.Name, image.DockerImageManifestMediaType)
+	}
+
 	dgst, err := digest.ParseDigest(image.Name)
 	if err != nil {
 		return nil, err
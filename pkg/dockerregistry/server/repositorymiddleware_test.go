@@ -0,0 +1,434 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/registry/api/errcode"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const testConfigDigest = "sha256:2d2fd6a9e2ea5b3cc22e3e2c9b0ef2c4e8b8cfc1b0f7f2b0f4fbd3ff1b2e7a58"
+const testLayerDigest = "sha256:b4ca4c215f483111b64ec6919f1659ff475d7080a649d6acd78a6ade562a4a63"
+
+const testSchema2Manifest = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+   "config": {
+      "mediaType": "application/vnd.docker.container.image.v1+json",
+      "size": 37,
+      "digest": "` + testConfigDigest + `"
+   },
+   "layers": [
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 2191,
+         "digest": "` + testLayerDigest + `"
+      }
+   ]
+}`
+
+const testSchema2Config = `{"architecture":"amd64","os":"linux"}`
+
+type fakeBlobStore struct {
+	distribution.BlobStore
+
+	blobs map[digest.Digest][]byte
+}
+
+func (s *fakeBlobStore) Stat(ctx context.Context, dgst digest.Digest) (distribution.Descriptor, error) {
+	blob, ok := s.blobs[dgst]
+	if !ok {
+		return distribution.Descriptor{}, distribution.ErrBlobUnknown
+	}
+	return distribution.Descriptor{Digest: dgst, Size: int64(len(blob))}, nil
+}
+
+func (s *fakeBlobStore) Get(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	blob, ok := s.blobs[dgst]
+	if !ok {
+		return nil, distribution.ErrBlobUnknown
+	}
+	return blob, nil
+}
+
+func (s *fakeBlobStore) Put(ctx context.Context, mediaType string, p []byte) (distribution.Descriptor, error) {
+	dgst, err := digest.FromBytes(p)
+	if err != nil {
+		return distribution.Descriptor{}, err
+	}
+	s.blobs[dgst] = p
+	return distribution.Descriptor{MediaType: mediaType, Digest: dgst, Size: int64(len(p))}, nil
+}
+
+func (s *fakeBlobStore) Open(ctx context.Context, dgst digest.Digest) (distribution.ReadSeekCloser, error) {
+	blob, ok := s.blobs[dgst]
+	if !ok {
+		return nil, distribution.ErrBlobUnknown
+	}
+	return nopReadSeekCloser{bytes.NewReader(blob)}, nil
+}
+
+type nopReadSeekCloser struct {
+	*bytes.Reader
+}
+
+func (nopReadSeekCloser) Close() error { return nil }
+
+type fakeRepository struct {
+	distribution.Repository
+
+	blobs *fakeBlobStore
+}
+
+func (r *fakeRepository) Blobs(ctx context.Context) distribution.BlobStore { return r.blobs }
+
+func newTestRepository(client *testclient.Fake, blobs map[digest.Digest][]byte) *repository {
+	projects, err := newProjectCache(time.Minute, 16)
+	if err != nil {
+		panic(err)
+	}
+	signingKey, err := loadSigningKey("")
+	if err != nil {
+		panic(err)
+	}
+	if blobs == nil {
+		blobs = map[digest.Digest][]byte{}
+	}
+	return &repository{
+		Repository:     &fakeRepository{blobs: &fakeBlobStore{blobs: blobs}},
+		ctx:            context.Background(),
+		registryClient: client,
+		quotaClient:    &ktestclient.Fake{},
+		registryAddr:   "localhost:5000",
+		namespace:      "test",
+		name:           "app",
+		acceptschema2:  true,
+		enforcequota:   true,
+		cachedLayers:   cachedLayers,
+		cachedProjects: projects,
+		signingKey:     signingKey,
+	}
+}
+
+func TestRepositoryPutRaw(t *testing.T) {
+	dgst, err := digest.FromBytes([]byte(testSchema2Manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	allBlobs := map[digest.Digest][]byte{
+		testConfigDigest: []byte(testSchema2Config),
+		testLayerDigest:  []byte("layer"),
+	}
+
+	testCases := []struct {
+		name          string
+		acceptschema2 bool
+		blobs         map[digest.Digest][]byte
+		tag           string
+		expectErr     string
+		expectAction  string
+	}{
+		{
+			name:          "tagged",
+			acceptschema2: true,
+			blobs:         allBlobs,
+			tag:           "latest",
+			expectAction:  "imagestreammappings",
+		},
+		{
+			name:          "untagged",
+			acceptschema2: true,
+			blobs:         allBlobs,
+			expectAction:  "imagestreammappings",
+		},
+		{
+			name:          "disabled",
+			acceptschema2: false,
+			blobs:         allBlobs,
+			tag:           "latest",
+			expectErr:     "manifest invalid: manifest invalid",
+		},
+		{
+			name:          "missing layer",
+			acceptschema2: true,
+			blobs:         map[digest.Digest][]byte{testConfigDigest: []byte(testSchema2Config)},
+			tag:           "latest",
+			expectErr:     "errors verifying manifest: unknown blob " + testLayerDigest + " on manifest",
+		},
+	}
+
+	for _, tc := range testCases {
+		var created *imageapi.Image
+		client := &testclient.Fake{}
+		client.AddReactor("create", "*", func(action ktestclient.Action) (bool, runtime.Object, error) {
+			if ism, ok := action.(ktestclient.CreateAction).GetObject().(*imageapi.ImageStreamMapping); ok {
+				if ism.Tag != tc.tag {
+					t.Errorf("%s: unexpected tag: %s", tc.name, ism.Tag)
+				}
+				created = &ism.Image
+			}
+			return true, action.(ktestclient.CreateAction).GetObject(), nil
+		})
+
+		r := newTestRepository(client, tc.blobs)
+		r.acceptschema2 = tc.acceptschema2
+		actual, err := r.PutRaw([]byte(testSchema2Manifest), imageapi.DockerManifestSchema2MediaType, tc.tag)
+		if len(tc.expectErr) > 0 {
+			if err == nil || err.Error() != tc.expectErr {
+				t.Errorf("%s: expected error %q, got %v", tc.name, tc.expectErr, err)
+			}
+			if len(client.Actions()) != 0 {
+				t.Errorf("%s: unexpected actions: %#v", tc.name, client.Actions())
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if actual != dgst {
+			t.Errorf("%s: unexpected digest: %s", tc.name, actual)
+		}
+		if actions := client.Actions(); len(actions) != 1 || actions[0].GetResource() != tc.expectAction {
+			t.Errorf("%s: unexpected actions: %#v", tc.name, actions)
+			continue
+		}
+		if created.Name != dgst.String() || created.DockerImageManifest != testSchema2Manifest || created.DockerImageManifestMediaType != imageapi.DockerManifestSchema2MediaType || created.DockerImageConfig != testSchema2Config {
+			t.Errorf("%s: unexpected image: %#v", tc.name, created)
+		}
+	}
+}
+
+func TestRepositoryPutRawQuotaExceeded(t *testing.T) {
+	client := &testclient.Fake{}
+	client.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamList{}, nil
+	})
+	quotaClient := &ktestclient.Fake{}
+	quotaClient.AddReactor("list", "resourcequotas", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		quota := kapi.ResourceQuota{ObjectMeta: kapi.ObjectMeta{Name: "images", Namespace: "test"}}
+		quota.Spec.Hard = kapi.ResourceList{imageapi.ResourceImageSize: resource.MustParse("1Ki")}
+		return true, &kapi.ResourceQuotaList{Items: []kapi.ResourceQuota{quota}}, nil
+	})
+
+	r := newTestRepository(client, map[digest.Digest][]byte{
+		testConfigDigest: []byte(testSchema2Config),
+		testLayerDigest:  []byte("layer"),
+	})
+	r.quotaClient = quotaClient
+	_, err := r.PutRaw([]byte(testSchema2Manifest), imageapi.DockerManifestSchema2MediaType, "latest")
+	quotaErr, ok := err.(errcode.Error)
+	if !ok || quotaErr.Code != ErrorCodeQuotaExceeded {
+		t.Fatalf("expected a quota exceeded error, got %v", err)
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "create" {
+			t.Errorf("unexpected action: %#v", action)
+		}
+	}
+
+	r.enforcequota = false
+	client.ClearActions()
+	if _, err := r.PutRaw([]byte(testSchema2Manifest), imageapi.DockerManifestSchema2MediaType, "latest"); err != nil {
+		t.Errorf("unexpected error with quota enforcement disabled: %v", err)
+	}
+}
+
+func TestRepositoryPutRawQuotaCached(t *testing.T) {
+	client := &testclient.Fake{}
+	client.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		stream := imageapi.ImageStream{ObjectMeta: kapi.ObjectMeta{Name: "other", Namespace: "test"}}
+		stream.Status.Tags = map[string]imageapi.TagEventList{"latest": {Items: []imageapi.TagEvent{{Image: "sha256:other"}}}}
+		return true, &imageapi.ImageStreamList{Items: []imageapi.ImageStream{stream}}, nil
+	})
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.Image{
+			ObjectMeta:        kapi.ObjectMeta{Name: "sha256:other", Annotations: map[string]string{imageapi.ManagedByOpenShiftAnnotation: "true"}},
+			DockerImageLayers: []imageapi.ImageLayer{{Name: "sha256:otherlayer", Size: 1024}},
+		}, nil
+	})
+	client.AddReactor("create", "imagestreammappings", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, action.(ktestclient.CreateAction).GetObject(), nil
+	})
+	quotaClient := &ktestclient.Fake{}
+	quotaClient.AddReactor("list", "resourcequotas", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		quota := kapi.ResourceQuota{ObjectMeta: kapi.ObjectMeta{Name: "images", Namespace: "test"}}
+		quota.Spec.Hard = kapi.ResourceList{imageapi.ResourceProjectImagesSize: resource.MustParse("1Gi")}
+		return true, &kapi.ResourceQuotaList{Items: []kapi.ResourceQuota{quota}}, nil
+	})
+
+	r := newTestRepository(client, map[digest.Digest][]byte{
+		testConfigDigest: []byte(testSchema2Config),
+		testLayerDigest:  []byte("layer"),
+	})
+	r.quotaClient = quotaClient
+	for i := 0; i < 2; i++ {
+		if _, err := r.PutRaw([]byte(testSchema2Manifest), imageapi.DockerManifestSchema2MediaType, "latest"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	// the quotas, streams and images of the project are read once for both pushes
+	if actions := quotaClient.Actions(); len(actions) != 1 {
+		t.Errorf("unexpected quota actions: %#v", actions)
+	}
+	reads := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() != "create" {
+			reads++
+		}
+	}
+	if reads != 2 {
+		t.Errorf("expected the image streams to be listed and the image to be read once, got %#v", client.Actions())
+	}
+}
+
+func TestRepositoryPutRawManifestList(t *testing.T) {
+	list := fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"manifests":[{"digest":%q,"platform":{"architecture":"amd64","os":"linux"}}]}`, imageapi.DockerManifestListMediaType, "sha256:missing")
+
+	client := &testclient.Fake{}
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("images"), action.(ktestclient.GetAction).GetName())
+	})
+	r := newTestRepository(client, nil)
+	_, err := r.PutRaw([]byte(list), imageapi.DockerManifestListMediaType, "latest")
+	if err == nil || err.Error() != "errors verifying manifest: unknown blob sha256:missing on manifest" {
+		t.Errorf("expected the missing platform image to be reported, got %v", err)
+	}
+}
+
+func TestRepositoryGetRaw(t *testing.T) {
+	dgst, err := digest.FromBytes([]byte(testSchema2Manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	image := &imageapi.Image{
+		ObjectMeta:                   kapi.ObjectMeta{Name: dgst.String()},
+		DockerImageReference:         "localhost:5000/test/app@" + dgst.String(),
+		DockerImageManifest:          testSchema2Manifest,
+		DockerImageManifestMediaType: imageapi.DockerManifestSchema2MediaType,
+		DockerImageConfig:            `{"architecture":"amd64","os":"linux","config":{"Cmd":["/bin/sh"]},"rootfs":{"type":"layers","diff_ids":["sha256:5f70bf18a086007016e948b04aed3b82103a36bea41755b6cddfaf10ace3c6ef"]},"history":[{"created":"2016-06-01T00:00:00Z","created_by":"ADD file in /"},{"created":"2016-06-01T00:00:01Z","created_by":"CMD [\"/bin/sh\"]","empty_layer":true}]}`,
+	}
+	list := fmt.Sprintf(`{"schemaVersion":2,"mediaType":%q,"manifests":[{"digest":%q,"platform":{"architecture":"amd64","os":"linux"}}]}`, imageapi.DockerManifestListMediaType, dgst)
+	listDigest, err := digest.FromBytes([]byte(list))
+	if err != nil {
+		t.Fatal(err)
+	}
+	listImage := &imageapi.Image{
+		ObjectMeta:                   kapi.ObjectMeta{Name: listDigest.String()},
+		DockerImageManifest:          list,
+		DockerImageManifestMediaType: imageapi.DockerManifestListMediaType,
+	}
+
+	client := &testclient.Fake{}
+	client.AddReactor("get", "imagestreamtags", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		tagged := *image
+		tagged.DockerImageManifest = ""
+		return true, &imageapi.ImageStreamTag{Image: tagged}, nil
+	})
+	client.AddReactor("get", "imagestreamimages", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("imagestreamimages"), action.(ktestclient.GetAction).GetName())
+	})
+	client.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStream{
+			Status: imageapi.ImageStreamStatus{
+				Tags: map[string]imageapi.TagEventList{
+					"multi": {Items: []imageapi.TagEvent{{Image: listDigest.String()}}},
+				},
+			},
+		}, nil
+	})
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		switch action.(ktestclient.GetAction).GetName() {
+		case image.Name:
+			return true, image, nil
+		case listImage.Name:
+			return true, listImage, nil
+		}
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("images"), action.(ktestclient.GetAction).GetName())
+	})
+
+	r := newTestRepository(client, nil)
+	schema2 := []string{imageapi.DockerManifestSchema2MediaType, imageapi.DockerManifestSchema1MediaType}
+
+	payload, mediaType, err := r.GetRaw("latest", schema2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(payload) != testSchema2Manifest || mediaType != imageapi.DockerManifestSchema2MediaType {
+		t.Errorf("unexpected manifest %s of type %s", payload, mediaType)
+	}
+
+	// clients not accepting schema 2 manifests get a signed schema 1 conversion
+	payload, mediaType, err = r.GetRaw("latest", []string{imageapi.DockerManifestSchema1MediaType})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mediaType != imageapi.DockerManifestSchema1MediaType {
+		t.Errorf("unexpected media type %s", mediaType)
+	}
+	converted := &schema1.SignedManifest{}
+	if err := json.Unmarshal(payload, converted); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := schema1.Verify(converted); err != nil {
+		t.Errorf("unable to verify the converted manifest: %v", err)
+	}
+	if converted.Name != "test/app" || converted.Tag != "latest" || converted.Architecture != "amd64" {
+		t.Errorf("unexpected converted manifest %s", payload)
+	}
+	expectedLayers := []schema1.FSLayer{{BlobSum: digestSHA256GzippedEmptyTar}, {BlobSum: testLayerDigest}}
+	if !reflect.DeepEqual(converted.FSLayers, expectedLayers) || len(converted.History) != 2 {
+		t.Errorf("unexpected converted layers %s", payload)
+	}
+	top := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(converted.History[0].V1Compatibility), &top); err != nil {
+		t.Fatal(err)
+	}
+	if top["throwaway"] != true || top["parent"] == nil || top["rootfs"] != nil || top["history"] != nil {
+		t.Errorf("unexpected top layer %s", converted.History[0].V1Compatibility)
+	}
+	if _, ok := r.Repository.Blobs(r.ctx).(*fakeBlobStore).blobs[digestSHA256GzippedEmptyTar]; !ok {
+		t.Errorf("expected the empty layer to be stored in the repository")
+	}
+
+	// the platform specific image of a tagged manifest list is served by digest
+	payload, _, err = r.GetRaw(dgst.String(), schema2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(payload) != testSchema2Manifest {
+		t.Errorf("unexpected manifest %s", payload)
+	}
+	if _, _, err := r.GetRaw(testLayerDigest, schema2); !kerrors.IsNotFound(err) {
+		t.Errorf("expected not found for an image outside of the stream, got %v", err)
+	}
+
+	manifest, err := r.manifestFromImage(image, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Tag != "" || !reflect.DeepEqual(manifest.FSLayers, expectedLayers) {
+		t.Errorf("unexpected manifest of an image requested by digest: %#v", manifest)
+	}
+	if _, err := r.manifestFromImage(listImage, ""); err == nil {
+		t.Errorf("expected an error converting a manifest list to schema 1")
+	}
+}
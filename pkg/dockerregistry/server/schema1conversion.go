@@ -0,0 +1,222 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/libtrust"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// gzippedEmptyTar is a gzip-compressed empty tar file (1024 NULL bytes), the layer of the schema 1
+// history entries which don't change the file system.
+var gzippedEmptyTar = []byte{
+	31, 139, 8, 0, 0, 9, 110, 136, 0, 255, 98, 24, 5, 163, 96, 20, 140, 88,
+	0, 8, 0, 0, 255, 255, 46, 175, 181, 239, 0, 4, 0, 0,
+}
+
+// digestSHA256GzippedEmptyTar is the digest of gzippedEmptyTar.
+const digestSHA256GzippedEmptyTar = digest.Digest("sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4")
+
+var (
+	signingKeyOnce sync.Once
+	signingKey     libtrust.PrivateKey
+	signingKeyErr  error
+)
+
+// loadSigningKey returns the key the schema 1 manifests converted from schema 2 manifests are signed
+// with. The key is read from path, or generated if path is empty, the first time it is called. All
+// the instances of a registry must share the key file for a converted manifest to have the same
+// digest whichever instance serves it.
+func loadSigningKey(path string) (libtrust.PrivateKey, error) {
+	signingKeyOnce.Do(func() {
+		if len(path) > 0 {
+			signingKey, signingKeyErr = libtrust.LoadKeyFile(path)
+			return
+		}
+		signingKey, signingKeyErr = libtrust.GenerateECP256PrivateKey()
+	})
+	return signingKey, signingKeyErr
+}
+
+// schema2ImageConfig is the part of the configuration of a schema 2 image needed to describe its
+// layers in a schema 1 manifest.
+type schema2ImageConfig struct {
+	Architecture string `json:"architecture"`
+	RootFS       *struct {
+		DiffIDs []digest.Digest `json:"diff_ids"`
+	} `json:"rootfs"`
+	History []struct {
+		Created    time.Time `json:"created"`
+		Author     string    `json:"author,omitempty"`
+		CreatedBy  string    `json:"created_by,omitempty"`
+		Comment    string    `json:"comment,omitempty"`
+		EmptyLayer bool      `json:"empty_layer,omitempty"`
+	} `json:"history"`
+}
+
+// schema1V1Compatibility is the v1 compatibility information of the layers of a converted schema 1
+// manifest, except the top one.
+type schema1V1Compatibility struct {
+	ID              string    `json:"id"`
+	Parent          string    `json:"parent,omitempty"`
+	Comment         string    `json:"comment,omitempty"`
+	Created         time.Time `json:"created"`
+	ContainerConfig struct {
+		Cmd []string
+	} `json:"container_config,omitempty"`
+	Author    string `json:"author,omitempty"`
+	ThrowAway bool   `json:"throwaway,omitempty"`
+}
+
+// convertSchema2Manifest returns a schema 1 manifest describing the image of a schema 2 manifest,
+// signed with the key of the registry, for the clients which don't accept schema 2 manifests. Each
+// entry of the history of the image becomes a layer, the entries which don't change the file
+// system pointing to an empty layer, which is stored in the repository if needed.
+func (r *repository) convertSchema2Manifest(image *imageapi.Image, tag string) (*schema1.SignedManifest, error) {
+	if r.signingKey == nil {
+		return nil, fmt.Errorf("the manifest of %s is of type %s and cannot be served to clients that only accept schema 1 manifests", image.Name, image.DockerImageManifestMediaType)
+	}
+	m := imageapi.DockerImageManifest{}
+	if err := json.Unmarshal([]byte(image.DockerImageManifest), &m); err != nil {
+		return nil, err
+	}
+	config := []byte(image.DockerImageConfig)
+	if len(config) == 0 {
+		blob, err := r.Blobs(r.ctx).Get(r.ctx, m.Config.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the configuration of %s: %v", image.Name, err)
+		}
+		config = blob
+	}
+	img := schema2ImageConfig{}
+	if err := json.Unmarshal(config, &img); err != nil {
+		return nil, err
+	}
+	if len(img.History) == 0 {
+		return nil, fmt.Errorf("the configuration of %s has no history to describe its layers with", image.Name)
+	}
+	if img.RootFS == nil || len(img.RootFS.DiffIDs) != len(m.Layers) {
+		return nil, fmt.Errorf("the configuration of %s doesn't describe the layers of its manifest", image.Name)
+	}
+
+	fsLayers := make([]schema1.FSLayer, len(img.History))
+	history := make([]schema1.History, len(img.History))
+	parent := ""
+	layer := 0
+	for i, h := range img.History {
+		var blobSum digest.Digest
+		if h.EmptyLayer {
+			emptyTar, err := r.emptyTar()
+			if err != nil {
+				return nil, err
+			}
+			blobSum = emptyTar
+		} else {
+			if layer >= len(m.Layers) {
+				return nil, errors.New("the history of the image has more non-empty entries than the image has layers")
+			}
+			blobSum = m.Layers[layer].Digest
+			layer++
+		}
+
+		// schema 1 manifests list the top layer first
+		index := len(img.History) - i - 1
+		fsLayers[index] = schema1.FSLayer{BlobSum: blobSum}
+
+		if i == len(img.History)-1 {
+			// the top layer is described by the configuration of the image
+			id, err := digest.FromBytes([]byte(blobSum.Hex() + " " + parent + " " + string(config)))
+			if err != nil {
+				return nil, err
+			}
+			v1Config, err := v1ConfigFromConfig(config, id.Hex(), parent, h.EmptyLayer)
+			if err != nil {
+				return nil, err
+			}
+			history[index] = schema1.History{V1Compatibility: string(v1Config)}
+			break
+		}
+
+		id, err := digest.FromBytes([]byte(blobSum.Hex() + " " + parent))
+		if err != nil {
+			return nil, err
+		}
+		v1Compatibility := schema1V1Compatibility{
+			ID:        id.Hex(),
+			Parent:    parent,
+			Comment:   h.Comment,
+			Created:   h.Created,
+			Author:    h.Author,
+			ThrowAway: h.EmptyLayer,
+		}
+		v1Compatibility.ContainerConfig.Cmd = []string{h.CreatedBy}
+		data, err := json.Marshal(&v1Compatibility)
+		if err != nil {
+			return nil, err
+		}
+		history[index] = schema1.History{V1Compatibility: string(data)}
+		parent = id.Hex()
+	}
+
+	return schema1.Sign(&schema1.Manifest{
+		Versioned:    schema1.SchemaVersion,
+		Name:         r.namespace + "/" + r.name,
+		Tag:          tag,
+		Architecture: img.Architecture,
+		FSLayers:     fsLayers,
+		History:      history,
+	}, r.signingKey)
+}
+
+// emptyTar stores an empty layer in the repository if it doesn't have one, and returns its digest.
+func (r *repository) emptyTar() (digest.Digest, error) {
+	blobs := r.Repository.Blobs(r.ctx)
+	_, err := blobs.Stat(r.ctx, digestSHA256GzippedEmptyTar)
+	switch err {
+	case nil:
+		return digestSHA256GzippedEmptyTar, nil
+	case distribution.ErrBlobUnknown:
+	default:
+		return "", err
+	}
+	desc, err := blobs.Put(r.ctx, "", gzippedEmptyTar)
+	if err != nil {
+		return "", err
+	}
+	return desc.Digest, nil
+}
+
+// v1ConfigFromConfig turns the configuration of a schema 2 image into the v1 compatibility
+// information of the top layer of a schema 1 manifest.
+func v1ConfigFromConfig(config []byte, id, parent string, throwaway bool) ([]byte, error) {
+	fields := map[string]*json.RawMessage{}
+	if err := json.Unmarshal(config, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "rootfs")
+	delete(fields, "history")
+	fields["id"] = rawJSON(id)
+	if len(parent) > 0 {
+		fields["parent"] = rawJSON(parent)
+	}
+	if throwaway {
+		fields["throwaway"] = rawJSON(true)
+	}
+	return json.Marshal(fields)
+}
+
+func rawJSON(value interface{}) *json.RawMessage {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return (*json.RawMessage)(&data)
+}
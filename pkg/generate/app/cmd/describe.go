@@ -220,6 +220,43 @@ func describeBuildPipelineWithImage(out io.Writer, ref app.ComponentReference, p
 	fmt.Fprintln(out)
 }
 
+func describeComposePipeline(out io.Writer, path, service string, pipeline *app.Pipeline) {
+	fmt.Fprintf(out, "--> Found service %q in docker-compose file %q\n", service, path)
+	if pipeline.Build == nil {
+		if trackedImage := extractFirstImageStreamTag(true, pipeline.Image); len(trackedImage) > 0 {
+			fmt.Fprintf(out, "    * An image stream will be created as %q that will track the image %q\n", trackedImage, pipeline.From)
+		}
+	} else {
+		fmt.Fprintf(out, "    * A Docker build using source code from %s will be created\n", pipeline.Build.Source.URL)
+		if len(pipeline.Build.Source.ContextDir) > 0 {
+			fmt.Fprintf(out, "      * The build will use the context directory %q\n", pipeline.Build.Source.ContextDir)
+		}
+		if buildOut, err := pipeline.Build.Output.BuildOutput(); err == nil && buildOut != nil && buildOut.To != nil {
+			fmt.Fprintf(out, "      * The resulting image will be pushed to %s %q\n", buildOut.To.Kind, buildOut.To.Name)
+		}
+	}
+	if pipeline.Deployment != nil {
+		fmt.Fprintf(out, "    * This image will be deployed in deployment config %q\n", pipeline.Deployment.Name)
+		ports := sets.NewString()
+		for port := range pipeline.Image.Info.Config.ExposedPorts {
+			ports.Insert(port)
+		}
+		switch ports.Len() {
+		case 0:
+			fmt.Fprintf(out, "    * The service does not expose any ports, no service will be created for it\n")
+		case 1:
+			fmt.Fprintf(out, "    * Port %s will be load balanced by service %q\n", ports.List()[0], pipeline.Deployment.Name)
+		default:
+			fmt.Fprintf(out, "    * Ports %s will be load balanced by service %q\n", strings.Join(ports.List(), ", "), pipeline.Deployment.Name)
+		}
+		if hasEmptyDir(pipeline.Image.Info) {
+			fmt.Fprintf(out, "    * The service declares volumes and will default to use non-persistent, host-local storage.\n")
+			fmt.Fprintf(out, "      You can add persistent volumes later by running 'volume dc/%s --add ...'\n", pipeline.Deployment.Name)
+		}
+	}
+	fmt.Fprintln(out)
+}
+
 func hasRootUser(image *imageapi.DockerImage) bool {
 	if image.Config == nil {
 		return false
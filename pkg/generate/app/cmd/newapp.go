@@ -53,6 +53,7 @@ type AppConfig struct {
 	DockerImages  []string
 	Templates     []string
 	TemplateFiles []string
+	ComposeFiles  []string
 
 	TemplateParameters []string
 	Groups             []string
@@ -201,6 +202,20 @@ func (c *AppConfig) SetDockerClient(dockerclient *docker.Client) {
 	}
 }
 
+// AddDetectorRules loads the source detectors defined by a detector rules
+// file. They are tried before the default source detectors.
+func (c *AppConfig) AddDetectorRules(filename string) error {
+	detectors, err := source.LoadDetectorRules(filename)
+	if err != nil {
+		return err
+	}
+	c.detector = app.SourceRepositoryEnumerator{
+		Detectors: append(detectors, source.DefaultDetectors...),
+		Tester:    dockerfile.NewTester(),
+	}
+	return nil
+}
+
 // SetOpenShiftClient sets the passed OpenShift client in the application configuration
 func (c *AppConfig) SetOpenShiftClient(osclient client.Interface, originNamespace string) {
 	c.osclient = osclient
@@ -240,6 +255,8 @@ func (c *AppConfig) AddArguments(args []string) []string {
 		switch {
 		case cmdutil.IsEnvironmentArgument(s):
 			c.Environment = append(c.Environment, s)
+		case app.IsPossibleComposeFile(s):
+			c.ComposeFiles = append(c.ComposeFiles, s)
 		case app.IsPossibleSourceRepository(s):
 			c.SourceRepositories = append(c.SourceRepositories, s)
 		case app.IsComponentReference(s):
@@ -714,6 +731,76 @@ func (c *AppConfig) buildPipelines(components app.ComponentReferences, environme
 	return pipelines, nil
 }
 
+// buildComposePipelines converts the services of the docker-compose files into
+// pipelines. Images that are not built are looked up to find the ports they
+// expose, so that services are created for them even when the compose file
+// doesn't list any ports.
+func (c *AppConfig) buildComposePipelines(environment app.Environment) (app.PipelineGroup, error) {
+	pipelines := app.PipelineGroup{}
+	for _, path := range c.ComposeFiles {
+		compose, err := app.ReadComposeFile(path)
+		if err != nil {
+			return nil, err
+		}
+		group, err := compose.Pipelines(c.OutputDocker)
+		if err != nil {
+			return nil, fmt.Errorf("can't use %q: %v", path, err)
+		}
+		for _, warning := range compose.Warnings {
+			fmt.Fprintf(c.ErrOut, "--> WARNING: %s: %s\n", path, warning)
+		}
+		byName := map[string]*app.Pipeline{}
+		for i, pipeline := range group {
+			service := compose.Services[i]
+			byName[service.Name] = pipeline
+			if pipeline.Build != nil {
+				pipeline.Build.Env = c.GetBuildEnvironment(environment)
+			} else {
+				c.addComposeImageInfo(pipeline.Image)
+			}
+			if c.Deploy {
+				if err := pipeline.NeedsDeployment(app.NewEnvironment(service.Environment, environment), c.Labels, c.AsTestDeployment); err != nil {
+					return nil, fmt.Errorf("can't set up a deployment for %q: %v", service.Name, err)
+				}
+			}
+			if c.NoOutput && pipeline.Build != nil {
+				pipeline.Build.Output = nil
+			}
+			describeComposePipeline(c.Out, path, service.Name, pipeline)
+		}
+		for _, service := range compose.Services {
+			for _, link := range service.Links {
+				if len(byName[link].Image.Info.Config.ExposedPorts) == 0 {
+					fmt.Fprintf(c.ErrOut, "--> WARNING: %s: service %q links to %q, which exposes no ports and will not be reachable\n", path, service.Name, link)
+				}
+			}
+		}
+		pipelines = append(pipelines, group...)
+	}
+	return pipelines, nil
+}
+
+// addComposeImageInfo adds the ports and volumes declared by the image of a
+// compose service to the ones set in the compose file.
+func (c *AppConfig) addComposeImageInfo(ref *app.ImageRef) {
+	if c.dockerSearcher == nil {
+		return
+	}
+	from := ref.Reference.String()
+	matches, errs := c.dockerSearcher.Search(true, from)
+	if len(errs) > 0 || len(matches.Exact()) == 0 || matches.Exact()[0].Image == nil || matches.Exact()[0].Image.Config == nil {
+		glog.V(2).Infof("unable to find the metadata of image %q: %v", from, errs)
+		return
+	}
+	config := matches.Exact()[0].Image.Config
+	for port := range config.ExposedPorts {
+		ref.Info.Config.ExposedPorts[port] = struct{}{}
+	}
+	for volume := range config.Volumes {
+		ref.Info.Config.Volumes[volume] = struct{}{}
+	}
+}
+
 // buildTemplates converts a set of resolved, valid references into references to template objects.
 func (c *AppConfig) buildTemplates(components app.ComponentReferences, environment app.Environment) ([]runtime.Object, error) {
 	objects := []runtime.Object{}
@@ -1030,10 +1117,14 @@ func (c *AppConfig) run(acceptors app.Acceptors) (*AppResult, error) {
 	glog.V(4).Infof("Code [%v]", repositories)
 	glog.V(4).Infof("Components [%v]", components)
 
-	if len(repositories) == 0 && len(components) == 0 {
+	if len(repositories) == 0 && len(components) == 0 && len(c.ComposeFiles) == 0 {
 		return nil, ErrNoInputs
 	}
 
+	if len(c.ComposeFiles) > 0 && (len(c.Name) > 0 || len(c.To) > 0) {
+		return nil, fmt.Errorf("--name and --to can't be used with docker-compose files")
+	}
+
 	if len(c.Name) > 0 {
 		if err := validateEnforcedName(c.Name); err != nil {
 			return nil, err
@@ -1080,6 +1171,11 @@ func (c *AppConfig) run(acceptors app.Acceptors) (*AppResult, error) {
 		}
 		return nil, err
 	}
+	composePipelines, err := c.buildComposePipelines(env)
+	if err != nil {
+		return nil, err
+	}
+	pipelines = append(pipelines, composePipelines...)
 
 	objects := app.Objects{}
 	accept := app.NewAcceptFirst()
@@ -1134,7 +1230,8 @@ func (c *AppConfig) HasArguments() bool {
 		len(c.ImageStreams) > 0 ||
 		len(c.DockerImages) > 0 ||
 		len(c.Templates) > 0 ||
-		len(c.TemplateFiles) > 0
+		len(c.TemplateFiles) > 0 ||
+		len(c.ComposeFiles) > 0
 }
 
 func (c *AppConfig) GetBuildEnvironment(environment app.Environment) app.Environment {
@@ -1651,6 +1651,97 @@ func TestBuildPipelinesWithUnresolvedImage(t *testing.T) {
 	}
 }
 
+func TestBuildComposePipelines(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	composeFile := filepath.Join(dir, "docker-compose.yml")
+	compose := "web:\n  image: nginx\n  links:\n  - db\n  environment:\n    MODE: compose\n  ports:\n  - 8080\ndb:\n  image: postgres\n"
+	if err := ioutil.WriteFile(composeFile, []byte(compose), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	a := AppConfig{
+		Deploy: true,
+		dockerSearcher: app.DockerClientSearcher{
+			Client: &dockertools.FakeDockerClient{
+				Images: []docker.APIImages{{RepoTags: []string{"nginx", "postgres"}}},
+				Image: &docker.Image{
+					ID: "postgres",
+					Config: &docker.Config{
+						ExposedPorts: map[docker.Port]struct{}{"5432/tcp": {}},
+					},
+				},
+			},
+			RegistrySearcher: &ExactMatchDockerSearcher{},
+		},
+	}
+	a.Out, a.ErrOut = &bytes.Buffer{}, &bytes.Buffer{}
+	if unknown := a.AddArguments([]string{composeFile}); len(unknown) > 0 || len(a.ComposeFiles) != 1 {
+		t.Fatalf("the compose file was not recognized: %v", unknown)
+	}
+
+	group, err := a.buildComposePipelines(app.Environment{"MODE": "override"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group) != 2 {
+		t.Fatalf("expected a pipeline for each service, got %#v", group)
+	}
+	objects := app.Objects{}
+	for _, p := range group {
+		accepted, err := p.Objects(app.NewAcceptFirst(), app.Acceptors{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects = append(objects, accepted...)
+	}
+	objects = app.AddServices(objects, false)
+
+	services := sets.NewString()
+	for _, obj := range objects {
+		switch o := obj.(type) {
+		case *kapi.Service:
+			services.Insert(o.Name)
+		case *deployapi.DeploymentConfig:
+			if o.Name == "web" {
+				if env := o.Spec.Template.Spec.Containers[0].Env; len(env) != 1 || env[0].Value != "override" {
+					t.Errorf("expected the command line environment to override the compose file, got %#v", env)
+				}
+			}
+		}
+	}
+	if e, a := []string{"db", "web"}, services.List(); !reflect.DeepEqual(e, a) {
+		t.Errorf("expected services %v, got %v", e, a)
+	}
+}
+
+func TestBuildComposePipelinesWithoutDocker(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	composeFile := filepath.Join(dir, "docker-compose.yml")
+	if err := ioutil.WriteFile(composeFile, []byte("db:\n  image: postgres\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// without a Docker daemon, the metadata of the images of the services can't be looked up
+	a := AppConfig{Deploy: true}
+	a.Out, a.ErrOut = &bytes.Buffer{}, &bytes.Buffer{}
+	a.ComposeFiles = []string{composeFile}
+	group, err := a.buildComposePipelines(app.Environment{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group) != 1 {
+		t.Fatalf("expected a pipeline for the service, got %#v", group)
+	}
+}
+
 func builderImageStream() *imageapi.ImageStream {
 	return &imageapi.ImageStream{
 		ObjectMeta: kapi.ObjectMeta{
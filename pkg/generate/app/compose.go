@@ -0,0 +1,513 @@
+package app
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	image "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/util/docker/dockerfile"
+)
+
+// IsPossibleComposeFile returns true if the argument is an existing file that
+// follows the docker-compose file naming convention (docker-compose*.yml).
+func IsPossibleComposeFile(value string) bool {
+	if !isFile(value) {
+		return false
+	}
+	base := filepath.Base(value)
+	ext := filepath.Ext(base)
+	return strings.HasPrefix(base, "docker-compose") && (ext == ".yml" || ext == ".yaml")
+}
+
+// ComposeFile holds the services of a docker-compose file that can be
+// translated into application components.
+type ComposeFile struct {
+	// Path is the location of the compose file. Build contexts and environment
+	// files are resolved relative to its directory.
+	Path string
+	// Version is the compose file format version, "1" or "2".
+	Version string
+	// Services are the services defined in the file, sorted by name.
+	Services []ComposeService
+	// Warnings describes the keys of the file that are not supported and were
+	// ignored or only partially translated.
+	Warnings []string
+}
+
+// ComposeService is a service defined in a docker-compose file.
+type ComposeService struct {
+	Name string
+	// Image is the image the service runs. It is ignored when Build is set.
+	Image string
+	// Build is the absolute path of the build context of the service.
+	Build string
+	// Ports are the container ports of the service, as <port>/<protocol>.
+	Ports []string
+	// Volumes are the container paths of the volumes of the service.
+	Volumes []string
+	// Environment holds the variables set with environment and env_file.
+	Environment Environment
+	// Links are the names of the services this service links to or depends on.
+	Links []string
+}
+
+// ReadComposeFile reads and validates a docker-compose file in the version 1 or
+// version 2 format.
+func ReadComposeFile(path string) (*ComposeFile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseComposeFile(path, data)
+}
+
+func parseComposeFile(path string, data []byte) (*ComposeFile, error) {
+	raw := map[string]interface{}{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("unable to parse compose file %s: %v", path, err)
+	}
+
+	f := &ComposeFile{Path: path, Version: "1"}
+	services := raw
+	if version, ok := raw["version"]; ok {
+		f.Version = composeScalar(version)
+		if f.Version != "2" && !strings.HasPrefix(f.Version, "2.") {
+			return nil, fmt.Errorf("compose file %s: version %q is not supported", path, f.Version)
+		}
+		if services, ok = raw["services"].(map[string]interface{}); !ok {
+			return nil, fmt.Errorf("compose file %s: services must be a map", path)
+		}
+		for _, key := range sortedComposeKeys(raw) {
+			switch key {
+			case "version", "services":
+			case "volumes":
+				volumes, _ := raw[key].(map[string]interface{})
+				for _, name := range sortedComposeKeys(volumes) {
+					if options, ok := volumes[name].(map[string]interface{}); ok && len(options) > 0 {
+						f.warnf("volume %q: volume drivers and options are not supported, the volume will use non-persistent storage", name)
+					}
+				}
+			default:
+				f.warnf("the top level key %q is not supported", key)
+			}
+		}
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("compose file %s does not define any services", path)
+	}
+
+	dir := filepath.Dir(path)
+	names := sets.NewString()
+	for _, name := range sortedComposeKeys(services) {
+		def, ok := services[name].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("compose file %s: service %q must be a map", path, name)
+		}
+		service, err := f.parseService(dir, name, def)
+		if err != nil {
+			return nil, fmt.Errorf("compose file %s: service %q: %v", path, name, err)
+		}
+		f.Services = append(f.Services, *service)
+		names.Insert(name)
+	}
+	for _, service := range f.Services {
+		for _, link := range service.Links {
+			if !names.Has(link) {
+				return nil, fmt.Errorf("compose file %s: service %q refers to the undefined service %q", path, service.Name, link)
+			}
+		}
+	}
+	return f, nil
+}
+
+// parseService translates the definition of a single compose service. Keys
+// that have no equivalent are reported as warnings.
+func (f *ComposeFile) parseService(dir, name string, def map[string]interface{}) (*ComposeService, error) {
+	service := &ComposeService{Name: name, Environment: Environment{}}
+	// values from env_file are overridden by environment, so process them first
+	if value, ok := def["env_file"]; ok {
+		files, err := composeStrings(value)
+		if err != nil {
+			return nil, fmt.Errorf("env_file: %v", err)
+		}
+		for _, file := range files {
+			if err := f.readEnvFile(service, resolveComposePath(dir, file)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, key := range sortedComposeKeys(def) {
+		value := def[key]
+		switch key {
+		case "env_file":
+		case "image":
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("image must be a string")
+			}
+			service.Image = s
+		case "build":
+			if err := f.parseBuild(service, dir, value); err != nil {
+				return nil, err
+			}
+		case "dockerfile":
+			if value != "Dockerfile" {
+				f.warnf("service %q: alternate Dockerfiles are not supported, the Dockerfile of the build context will be used", name)
+			}
+		case "ports", "expose":
+			specs, err := composeStrings(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", key, err)
+			}
+			for _, spec := range specs {
+				ports, hostPort, err := parseComposePort(spec)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %v", key, err)
+				}
+				if hostPort {
+					f.warnf("service %q: host port mappings are not supported, only the container port of %q will be exposed", name, spec)
+				}
+				service.Ports = append(service.Ports, ports...)
+			}
+		case "volumes":
+			specs, err := composeStrings(value)
+			if err != nil {
+				return nil, fmt.Errorf("volumes: %v", err)
+			}
+			for _, spec := range specs {
+				source, path := parseComposeVolume(spec)
+				if !filepath.IsAbs(path) {
+					return nil, fmt.Errorf("volumes: the container path of %q must be absolute", spec)
+				}
+				if strings.HasPrefix(source, ".") || strings.HasPrefix(source, "/") || strings.HasPrefix(source, "~") {
+					f.warnf("service %q: host directories can not be mounted, the volume %q was ignored", name, spec)
+					continue
+				}
+				service.Volumes = append(service.Volumes, path)
+			}
+		case "environment":
+			if err := f.parseEnvironment(service, value); err != nil {
+				return nil, err
+			}
+		case "links", "depends_on":
+			links, err := composeStrings(value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", key, err)
+			}
+			for _, link := range links {
+				segs := strings.SplitN(link, ":", 2)
+				if len(segs) == 2 && segs[0] != segs[1] {
+					f.warnf("service %q: link aliases are not supported, use the hostname %q instead of %q", name, segs[0], segs[1])
+				}
+				service.Links = append(service.Links, segs[0])
+			}
+		default:
+			f.warnf("service %q: the key %q is not supported", name, key)
+		}
+	}
+
+	if len(service.Image) == 0 && len(service.Build) == 0 {
+		return nil, fmt.Errorf("an image or a build context must be specified")
+	}
+	if len(service.Image) > 0 && len(service.Build) > 0 {
+		f.warnf("service %q: the image %q is ignored because the service is built from source", name, service.Image)
+	}
+	sort.Strings(service.Ports)
+	sort.Strings(service.Volumes)
+	return service, nil
+}
+
+// parseBuild handles both the version 1 build context string and the version 2
+// build map.
+func (f *ComposeFile) parseBuild(service *ComposeService, dir string, value interface{}) error {
+	switch t := value.(type) {
+	case string:
+		service.Build = resolveComposePath(dir, t)
+	case map[string]interface{}:
+		for _, key := range sortedComposeKeys(t) {
+			switch key {
+			case "context":
+				context, ok := t[key].(string)
+				if !ok {
+					return fmt.Errorf("build context must be a string")
+				}
+				service.Build = resolveComposePath(dir, context)
+			case "dockerfile":
+				if t[key] != "Dockerfile" {
+					f.warnf("service %q: alternate Dockerfiles are not supported, the Dockerfile of the build context will be used", service.Name)
+				}
+			default:
+				f.warnf("service %q: the build key %q is not supported", service.Name, key)
+			}
+		}
+		if len(service.Build) == 0 {
+			return fmt.Errorf("build context must be specified")
+		}
+	default:
+		return fmt.Errorf("build must be a string or a map")
+	}
+	return nil
+}
+
+// parseEnvironment handles both the list (KEY=value) and the map form of the
+// environment key.
+func (f *ComposeFile) parseEnvironment(service *ComposeService, value interface{}) error {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		for _, key := range sortedComposeKeys(t) {
+			if t[key] == nil {
+				f.setFromShell(service, key)
+				continue
+			}
+			service.Environment[key] = composeScalar(t[key])
+		}
+	default:
+		vars, err := composeStrings(value)
+		if err != nil {
+			return fmt.Errorf("environment: %v", err)
+		}
+		for _, v := range vars {
+			f.setEnvironment(service, v)
+		}
+	}
+	return nil
+}
+
+// readEnvFile reads the KEY=value lines of an env_file.
+func (f *ComposeFile) readEnvFile(service *ComposeService, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("env_file: %v", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		f.setEnvironment(service, line)
+	}
+	return scanner.Err()
+}
+
+func (f *ComposeFile) setEnvironment(service *ComposeService, v string) {
+	segs := strings.SplitN(v, "=", 2)
+	if len(segs) == 1 {
+		f.setFromShell(service, segs[0])
+		return
+	}
+	service.Environment[segs[0]] = segs[1]
+}
+
+// setFromShell sets a variable that has no value in the compose file to its
+// value in the current environment, as docker-compose does.
+func (f *ComposeFile) setFromShell(service *ComposeService, key string) {
+	value, ok := os.LookupEnv(key)
+	if !ok {
+		f.warnf("service %q: the environment variable %q has no value and is not set locally, it was ignored", service.Name, key)
+		return
+	}
+	service.Environment[key] = value
+}
+
+func (f *ComposeFile) warnf(format string, args ...interface{}) {
+	f.Warnings = append(f.Warnings, fmt.Sprintf(format, args...))
+}
+
+// Pipelines returns a pipeline for each service of the compose file, in the
+// order of Services. Services with a build context are built with the Docker
+// strategy from the git repository that contains the context, all other
+// services deploy their image through an image stream. No deployments are set
+// up; callers should invoke NeedsDeployment with the service environment.
+func (f *ComposeFile) Pipelines(outputDocker bool) (PipelineGroup, error) {
+	return f.pipelines(NewSourceRefGenerator(), outputDocker)
+}
+
+func (f *ComposeFile) pipelines(sourceRefs *SourceRefGenerator, outputDocker bool) (PipelineGroup, error) {
+	pipelines := PipelineGroup{}
+	names := sets.NewString()
+	for _, service := range f.Services {
+		name, _ := makeValidServiceName(service.Name)
+		if len(name) == 0 {
+			return nil, fmt.Errorf("service %q: unable to derive a valid name", service.Name)
+		}
+		if names.Has(name) {
+			return nil, fmt.Errorf("service %q: another service is already named %q", service.Name, name)
+		}
+		names.Insert(name)
+		if name != service.Name {
+			f.warnf("service %q will be named %q, other services must use that name as the hostname", service.Name, name)
+		}
+
+		info := &image.DockerImage{
+			Config: &image.DockerConfig{
+				ExposedPorts: map[string]struct{}{},
+				Volumes:      map[string]struct{}{},
+			},
+		}
+		for _, port := range service.Ports {
+			info.Config.ExposedPorts[port] = struct{}{}
+		}
+		for _, volume := range service.Volumes {
+			info.Config.Volumes[volume] = struct{}{}
+		}
+
+		if len(service.Build) == 0 {
+			input, err := NewImageRefGenerator().FromName(service.Image)
+			if err != nil {
+				return nil, fmt.Errorf("service %q: %v", service.Name, err)
+			}
+			input.AsImageStream = true
+			input.ObjectName = name
+			input.Info = info
+			pipelines = append(pipelines, &Pipeline{
+				Name:  name,
+				From:  service.Image,
+				Image: input,
+			})
+			continue
+		}
+
+		source, err := sourceRefs.FromDirectory(service.Build)
+		if err != nil {
+			return nil, fmt.Errorf("service %q: %v", service.Name, err)
+		}
+		contextDir, err := filepath.Rel(source.Dir, service.Build)
+		if err != nil || strings.HasPrefix(contextDir, "..") {
+			return nil, fmt.Errorf("service %q: the build context %s is not part of the repository %s", service.Name, service.Build, source.Dir)
+		}
+		if contextDir == "." {
+			contextDir = ""
+		}
+		source.ContextDir = contextDir
+		source.Name = name
+
+		// the ports exposed by the Dockerfile are reachable by the other services
+		if d, err := NewDockerfileFromFile(filepath.Join(service.Build, "Dockerfile")); err == nil {
+			for _, port := range dockerfile.LastExposedPorts(d.AST()) {
+				info.Config.ExposedPorts[port] = struct{}{}
+			}
+		}
+
+		output := &ImageRef{
+			Reference: image.DockerImageReference{
+				Name: name,
+				Tag:  image.DefaultImageTag,
+			},
+			OutputImage:   true,
+			AsImageStream: !outputDocker,
+			Info:          info,
+		}
+		pipelines = append(pipelines, &Pipeline{
+			Name:  name,
+			From:  service.Build,
+			Image: output,
+			Build: &BuildRef{
+				Source:   source,
+				Strategy: &BuildStrategyRef{IsDockerBuild: true},
+				Output:   output,
+			},
+		})
+	}
+	return pipelines, nil
+}
+
+// parseComposePort returns the container ports of a compose port mapping of the
+// form [[ip:]host:]container[-end][/protocol], and whether a host port was
+// requested.
+func parseComposePort(spec string) ([]string, bool, error) {
+	protocol := "tcp"
+	if i := strings.Index(spec, "/"); i != -1 {
+		spec, protocol = spec[:i], strings.ToLower(spec[i+1:])
+	}
+	segs := strings.Split(spec, ":")
+	container := segs[len(segs)-1]
+	start, end := container, container
+	if i := strings.Index(container, "-"); i != -1 {
+		start, end = container[:i], container[i+1:]
+	}
+	from, err := strconv.Atoi(start)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid port %q", spec)
+	}
+	to, err := strconv.Atoi(end)
+	if err != nil || from < 1 || to < from || to > 65535 {
+		return nil, false, fmt.Errorf("invalid port %q", spec)
+	}
+	ports := []string{}
+	for port := from; port <= to; port++ {
+		ports = append(ports, fmt.Sprintf("%d/%s", port, protocol))
+	}
+	return ports, len(segs) > 1, nil
+}
+
+// parseComposeVolume splits a compose volume of the form
+// [source:]container[:mode] into its source and container path.
+func parseComposeVolume(spec string) (string, string) {
+	segs := strings.Split(spec, ":")
+	if len(segs) == 1 {
+		return "", segs[0]
+	}
+	return segs[0], segs[1]
+}
+
+// composeStrings converts a compose value that is either a single string or a
+// list of scalars into a list of strings.
+func composeStrings(value interface{}) ([]string, error) {
+	switch t := value.(type) {
+	case string:
+		return []string{t}, nil
+	case []interface{}:
+		values := []string{}
+		for _, v := range t {
+			switch v.(type) {
+			case string, float64, bool:
+				values = append(values, composeScalar(v))
+			default:
+				return nil, fmt.Errorf("%v must be a string", v)
+			}
+		}
+		return values, nil
+	}
+	return nil, fmt.Errorf("must be a string or a list")
+}
+
+// composeScalar formats a scalar of a compose file. YAML numbers are decoded as float64 and are
+// formatted without exponent, so that 1000000 isn't turned into 1e+06.
+func composeScalar(value interface{}) string {
+	if f, ok := value.(float64); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+func resolveComposePath(dir, path string) string {
+	if filepath.IsAbs(path) {
+		return filepath.Clean(path)
+	}
+	return filepath.Join(dir, path)
+}
+
+func sortedComposeKeys(m map[string]interface{}) []string {
+	keys := []string{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,217 @@
+package app
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/openshift/origin/pkg/generate/app/test"
+)
+
+const composeV1 = `
+web:
+  build: ./web
+  ports:
+  - "8080:80"
+  links:
+  - db:database
+  environment:
+  - DEBUG=1
+  env_file: web.env
+  volumes:
+  - ./src:/src
+  - /cache
+  restart: always
+db:
+  image: postgres:9.5
+  expose:
+  - 5432
+  environment:
+    POSTGRES_USER: user
+    MAX_CONNECTIONS: 1000000
+    RATIO: 0.5
+`
+
+const composeV2 = `
+version: "2"
+services:
+  frontend:
+    build:
+      context: .
+      dockerfile: Dockerfile.dev
+    ports:
+    - "3000-3001"
+    depends_on:
+    - cache_db
+  cache_db:
+    image: redis
+    volumes:
+    - data:/data
+volumes:
+  data: {}
+networks:
+  back: {}
+`
+
+func TestParseComposeFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "compose")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "web.env"), []byte("# comment\nDEBUG=0\nSECRET=abc\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := parseComposeFile(filepath.Join(dir, "docker-compose.yml"), []byte(composeV1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []ComposeService{
+		{
+			Name:        "db",
+			Image:       "postgres:9.5",
+			Ports:       []string{"5432/tcp"},
+			Environment: Environment{"POSTGRES_USER": "user", "MAX_CONNECTIONS": "1000000", "RATIO": "0.5"},
+		},
+		{
+			Name:        "web",
+			Build:       filepath.Join(dir, "web"),
+			Ports:       []string{"80/tcp"},
+			Volumes:     []string{"/cache"},
+			Environment: Environment{"DEBUG": "1", "SECRET": "abc"},
+			Links:       []string{"db"},
+		},
+	}
+	if f.Version != "1" {
+		t.Errorf("unexpected version %q", f.Version)
+	}
+	if !reflect.DeepEqual(f.Services, expected) {
+		t.Errorf("unexpected services:\n%#v", f.Services)
+	}
+	if len(f.Warnings) != 4 {
+		t.Errorf("expected a warning for the link alias, host port, host volume and restart key, got %v", f.Warnings)
+	}
+
+	f, err = parseComposeFile(filepath.Join(dir, "docker-compose.yml"), []byte(composeV2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected = []ComposeService{
+		{
+			Name:        "cache_db",
+			Image:       "redis",
+			Volumes:     []string{"/data"},
+			Environment: Environment{},
+		},
+		{
+			Name:        "frontend",
+			Build:       dir,
+			Ports:       []string{"3000/tcp", "3001/tcp"},
+			Environment: Environment{},
+			Links:       []string{"cache_db"},
+		},
+	}
+	if f.Version != "2" {
+		t.Errorf("unexpected version %q", f.Version)
+	}
+	if !reflect.DeepEqual(f.Services, expected) {
+		t.Errorf("unexpected services:\n%#v", f.Services)
+	}
+	if len(f.Warnings) != 2 {
+		t.Errorf("expected a warning for the Dockerfile and the networks key, got %v", f.Warnings)
+	}
+}
+
+func TestParseComposeFileErrors(t *testing.T) {
+	tests := map[string]string{
+		"no services":       `version: "2"`,
+		"unknown version":   "version: \"3\"\nservices:\n  web:\n    image: nginx\n",
+		"no image or build": "web:\n  ports:\n  - 80\n",
+		"undefined link":    "web:\n  image: nginx\n  links:\n  - db\n",
+		"invalid port":      "web:\n  image: nginx\n  ports:\n  - http\n",
+		"relative volume":   "web:\n  image: nginx\n  volumes:\n  - data\n",
+	}
+	for name, data := range tests {
+		if _, err := parseComposeFile("docker-compose.yml", []byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestComposePipelines(t *testing.T) {
+	f := &ComposeFile{
+		Services: []ComposeService{
+			{Name: "cache_db", Image: "redis", Volumes: []string{"/data"}},
+			{Name: "web", Build: "/repo/web", Ports: []string{"8080/tcp"}},
+		},
+	}
+	sourceRefs := &SourceRefGenerator{&test.FakeGit{RootDir: "/repo", GitURL: "https://github.com/openshift/ruby-hello-world.git", Ref: "master"}}
+	pipelines, err := f.pipelines(sourceRefs, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pipelines) != 2 {
+		t.Fatalf("unexpected pipelines: %#v", pipelines)
+	}
+
+	db := pipelines[0]
+	if db.Name != "cachedb" || db.Build != nil || !db.Image.AsImageStream || db.Image.ObjectName != "cachedb" {
+		t.Errorf("unexpected image pipeline: %#v", db)
+	}
+	if _, ok := db.Image.Info.Config.Volumes["/data"]; !ok {
+		t.Errorf("expected a volume for /data: %#v", db.Image.Info.Config)
+	}
+	if len(f.Warnings) != 1 {
+		t.Errorf("expected a warning for the renamed service, got %v", f.Warnings)
+	}
+
+	web := pipelines[1]
+	if web.Name != "web" || web.Build == nil || !web.Build.Strategy.IsDockerBuild {
+		t.Fatalf("unexpected build pipeline: %#v", web)
+	}
+	if web.Build.Source.ContextDir != "web" || web.Build.Source.URL.String() != "https://github.com/openshift/ruby-hello-world.git" {
+		t.Errorf("unexpected build source: %#v", web.Build.Source)
+	}
+	if _, ok := web.Image.Info.Config.ExposedPorts["8080/tcp"]; !ok {
+		t.Errorf("expected port 8080 to be exposed: %#v", web.Image.Info.Config)
+	}
+
+	if err := web.NeedsDeployment(nil, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	objects, err := web.Objects(NewAcceptFirst(), Acceptors{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	objects = AddServices(objects, false)
+	if len(objects) != 4 {
+		t.Errorf("expected an image stream, build config, deployment config and service: %#v", objects)
+	}
+}
+
+func TestParseComposePort(t *testing.T) {
+	tests := []struct {
+		spec     string
+		ports    []string
+		hostPort bool
+	}{
+		{spec: "80", ports: []string{"80/tcp"}},
+		{spec: "53/udp", ports: []string{"53/udp"}},
+		{spec: "8080:80", ports: []string{"80/tcp"}, hostPort: true},
+		{spec: "127.0.0.1:8080:80", ports: []string{"80/tcp"}, hostPort: true},
+		{spec: "9000-9002", ports: []string{"9000/tcp", "9001/tcp", "9002/tcp"}},
+	}
+	for _, test := range tests {
+		ports, hostPort, err := parseComposePort(test.spec)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.spec, err)
+			continue
+		}
+		if !reflect.DeepEqual(ports, test.ports) || hostPort != test.hostPort {
+			t.Errorf("%s: unexpected ports %v (host port %t)", test.spec, ports, hostPort)
+		}
+	}
+}
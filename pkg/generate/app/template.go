@@ -0,0 +1,255 @@
+package app
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kmeta "k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	templateapi "github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/util/stringreplace"
+)
+
+// webhookSecretExpression generates the webhook secrets of the build configs
+// of a template created with TemplateFromObjects.
+const webhookSecretExpression = "[a-zA-Z0-9]{40}"
+
+var invalidParameterChars = regexp.MustCompile("[^A-Z0-9_]")
+
+// TemplateFromObjects wraps the objects generated for an application in a
+// template with the given name. The values that were detected or generated
+// are lifted into parameters, so that the template can be instantiated again
+// with different values:
+//
+//   NAME                    the name of the application components
+//   SOURCE_REPOSITORY_URL   the git repository of a build
+//   SOURCE_REPOSITORY_REF   the git reference of a build
+//   CONTEXT_DIR             the context directory of a build
+//   IMAGE                   the Docker image imported by an image stream
+//   GITHUB_WEBHOOK_SECRET   the secret of the GitHub webhook of a build, generated
+//   GENERIC_WEBHOOK_SECRET  the secret of the generic webhook of a build, generated
+//   <VARIABLE>              the value of an environment variable
+//
+// When the objects contain several components, builds or image streams, the
+// parameter names are prefixed with the original name of the object. The
+// labels are removed from the objects and set as the object labels of the
+// template, which applies them when the template is processed.
+func TemplateFromObjects(name string, objects Objects, labels map[string]string) (*templateapi.Template, error) {
+	template := &templateapi.Template{
+		ObjectMeta:   kapi.ObjectMeta{Name: name},
+		ObjectLabels: labels,
+	}
+	params := &templateParameters{}
+
+	names := sets.NewString()
+	builds, imports := 0, 0
+	for _, obj := range objects {
+		switch t := obj.(type) {
+		case *deployapi.DeploymentConfig:
+			names.Insert(t.Name)
+		case *buildapi.BuildConfig:
+			names.Insert(t.Name)
+			builds++
+		case *kapi.Service:
+			names.Insert(t.Name)
+		case *imageapi.ImageStream:
+			if len(t.Spec.DockerImageRepository) > 0 || len(t.Spec.Tags) > 0 {
+				imports++
+			}
+		}
+	}
+
+	for _, obj := range objects {
+		if err := removeObjectLabels(obj, labels); err != nil {
+			return nil, err
+		}
+		switch t := obj.(type) {
+		case *deployapi.DeploymentConfig:
+			if t.Spec.Template == nil {
+				continue
+			}
+			for i := range t.Spec.Template.Spec.Containers {
+				params.environment(t.Name, t.Spec.Template.Spec.Containers[i].Env)
+			}
+		case *buildapi.BuildConfig:
+			prefix := parameterPrefix(t.Name, builds)
+			if git := t.Spec.Source.Git; git != nil {
+				git.URI = params.add(prefix+"SOURCE_REPOSITORY_URL", "The URL of the repository with the source code of the application.", git.URI)
+				if len(git.Ref) > 0 {
+					git.Ref = params.add(prefix+"SOURCE_REPOSITORY_REF", "The git reference of the source code to build, e.g. a branch, tag or commit.", git.Ref)
+				}
+			}
+			if len(t.Spec.Source.ContextDir) > 0 {
+				t.Spec.Source.ContextDir = params.add(prefix+"CONTEXT_DIR", "The directory of the source repository to build.", t.Spec.Source.ContextDir)
+			}
+			for _, trigger := range t.Spec.Triggers {
+				switch {
+				case trigger.GitHubWebHook != nil:
+					trigger.GitHubWebHook.Secret = params.generate(prefix+"GITHUB_WEBHOOK_SECRET", "The secret of the GitHub webhook that triggers a build.", webhookSecretExpression)
+				case trigger.GenericWebHook != nil:
+					trigger.GenericWebHook.Secret = params.generate(prefix+"GENERIC_WEBHOOK_SECRET", "The secret of the generic webhook that triggers a build.", webhookSecretExpression)
+				}
+			}
+			switch strategy := t.Spec.Strategy; {
+			case strategy.SourceStrategy != nil:
+				params.environment(t.Name, strategy.SourceStrategy.Env)
+			case strategy.DockerStrategy != nil:
+				params.environment(t.Name, strategy.DockerStrategy.Env)
+			case strategy.CustomStrategy != nil:
+				params.environment(t.Name, strategy.CustomStrategy.Env)
+			}
+		case *imageapi.ImageStream:
+			param := parameterPrefix(t.Name, imports) + "IMAGE"
+			description := fmt.Sprintf("The Docker image imported by the image stream %s.", t.Name)
+			if len(t.Spec.DockerImageRepository) > 0 {
+				t.Spec.DockerImageRepository = params.add(param, description, t.Spec.DockerImageRepository)
+			}
+			for tag, ref := range t.Spec.Tags {
+				if ref.From == nil || ref.From.Kind != "DockerImage" {
+					continue
+				}
+				image := ref.From.Name
+				ref.From.Name = params.add(param, description, image)
+				if ref.Annotations["openshift.io/imported-from"] == image {
+					ref.Annotations["openshift.io/imported-from"] = ref.From.Name
+				}
+				t.Spec.Tags[tag] = ref
+			}
+		}
+	}
+
+	// the names are replaced last, so that the values already lifted into
+	// parameters are left alone
+	for _, name := range names.List() {
+		param := params.add(parameterPrefix(name, len(names))+"NAME", fmt.Sprintf("The name of the %s component of the application.", name), name)
+		for _, obj := range objects {
+			stringreplace.VisitObjectStrings(obj, func(in string) string {
+				switch {
+				case in == name:
+					return param
+				case strings.HasPrefix(in, name+":"):
+					// an image stream tag of the component
+					return param + in[len(name):]
+				}
+				return in
+			})
+		}
+	}
+
+	template.Parameters = params.parameters
+	template.Objects = objects
+	return template, nil
+}
+
+// templateParameters collects the parameters of a template.
+type templateParameters struct {
+	parameters []templateapi.Parameter
+}
+
+// add returns a reference to a parameter with the given name and value,
+// reusing an existing parameter with the same name and value. If a parameter
+// with that name has a different value, a suffix is added to the name.
+func (p *templateParameters) add(name, description, value string) string {
+	name = invalidParameterChars.ReplaceAllString(strings.ToUpper(name), "_")
+	unique := name
+	for i := 2; ; i++ {
+		existing := p.find(unique)
+		if existing == nil {
+			break
+		}
+		if existing.Value == value && len(existing.Generate) == 0 {
+			return fmt.Sprintf("${%s}", unique)
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+	p.parameters = append(p.parameters, templateapi.Parameter{
+		Name:        unique,
+		Description: description,
+		Value:       value,
+	})
+	return fmt.Sprintf("${%s}", unique)
+}
+
+// generate returns a reference to a parameter whose value is generated from
+// the given expression.
+func (p *templateParameters) generate(name, description, expression string) string {
+	if existing := p.find(name); existing != nil && existing.From == expression {
+		return fmt.Sprintf("${%s}", name)
+	}
+	p.parameters = append(p.parameters, templateapi.Parameter{
+		Name:        name,
+		Description: description,
+		Generate:    "expression",
+		From:        expression,
+	})
+	return fmt.Sprintf("${%s}", name)
+}
+
+// environment lifts the values of the environment variables of the named
+// object into parameters named after the variables. Variables with the same
+// name but a different value in another object are prefixed with the object
+// name.
+func (p *templateParameters) environment(object string, env []kapi.EnvVar) {
+	for i := range env {
+		if env[i].ValueFrom != nil {
+			continue
+		}
+		name := invalidParameterChars.ReplaceAllString(strings.ToUpper(env[i].Name), "_")
+		if existing := p.find(name); existing != nil && existing.Value != env[i].Value {
+			name = parameterPrefix(object, 2) + name
+		}
+		env[i].Value = p.add(name, fmt.Sprintf("The value of the %s environment variable.", env[i].Name), env[i].Value)
+	}
+}
+
+func (p *templateParameters) find(name string) *templateapi.Parameter {
+	for i := range p.parameters {
+		if p.parameters[i].Name == name {
+			return &p.parameters[i]
+		}
+	}
+	return nil
+}
+
+// parameterPrefix returns the prefix of the parameters of an object when there
+// are count objects of its kind.
+func parameterPrefix(name string, count int) string {
+	if count < 2 {
+		return ""
+	}
+	return invalidParameterChars.ReplaceAllString(strings.ToUpper(name), "_") + "_"
+}
+
+// removeObjectLabels removes the given labels from the labels and selectors of
+// an object, so that they can be set by the template instead.
+func removeObjectLabels(obj interface{}, labels map[string]string) error {
+	if len(labels) == 0 {
+		return nil
+	}
+	accessor, err := kmeta.Accessor(obj)
+	if err != nil {
+		return err
+	}
+	maps := []map[string]string{accessor.GetLabels()}
+	switch t := obj.(type) {
+	case *deployapi.DeploymentConfig:
+		maps = append(maps, t.Spec.Selector)
+		if t.Spec.Template != nil {
+			maps = append(maps, t.Spec.Template.Labels)
+		}
+	case *kapi.Service:
+		maps = append(maps, t.Spec.Selector)
+	}
+	for _, m := range maps {
+		for k := range labels {
+			delete(m, k)
+		}
+	}
+	return nil
+}
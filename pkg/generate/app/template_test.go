@@ -0,0 +1,128 @@
+package app
+
+import (
+	"math/rand"
+	"net/url"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	image "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/template"
+	"github.com/openshift/origin/pkg/template/generator"
+)
+
+func TestTemplateFromObjects(t *testing.T) {
+	sourceURL, _ := url.Parse("https://github.com/openshift/ruby-hello-world.git")
+	input, err := NewImageRefGenerator().FromName("centos/ruby-22-centos7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	input.AsImageStream = true
+	output := &ImageRef{
+		Reference:     image.DockerImageReference{Name: "ruby-hello-world", Tag: "latest"},
+		OutputImage:   true,
+		AsImageStream: true,
+		Info: &image.DockerImage{
+			Config: &image.DockerConfig{ExposedPorts: map[string]struct{}{"8080/tcp": {}}},
+		},
+	}
+	pipeline := &Pipeline{
+		Name:       "ruby-hello-world",
+		InputImage: input,
+		Image:      output,
+		Build: &BuildRef{
+			Source:   &SourceRef{URL: sourceURL, Ref: "beta4", Name: "ruby-hello-world"},
+			Input:    input,
+			Strategy: &BuildStrategyRef{Base: input},
+			Output:   output,
+			Env:      Environment{"RACK_ENV": "production"},
+		},
+	}
+	labels := map[string]string{"app": "ruby-hello-world"}
+	if err := pipeline.NeedsDeployment(Environment{"RACK_ENV": "production", "PORT": "8080"}, labels, false); err != nil {
+		t.Fatal(err)
+	}
+	objects, err := pipeline.Objects(NewAcceptFirst(), Acceptors{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects = AddServices(objects, false)
+
+	result, err := TemplateFromObjects("ruby", objects, labels)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "ruby" || result.ObjectLabels["app"] != "ruby-hello-world" {
+		t.Errorf("unexpected template: %#v", result.ObjectMeta)
+	}
+	expected := map[string]string{
+		"SOURCE_REPOSITORY_URL":  "https://github.com/openshift/ruby-hello-world.git",
+		"SOURCE_REPOSITORY_REF":  "beta4",
+		"GITHUB_WEBHOOK_SECRET":  "",
+		"GENERIC_WEBHOOK_SECRET": "",
+		"IMAGE":                  "centos/ruby-22-centos7",
+		"RACK_ENV":               "production",
+		"PORT":                   "8080",
+		"NAME":                   "ruby-hello-world",
+	}
+	if len(result.Parameters) != len(expected) {
+		t.Errorf("unexpected parameters: %#v", result.Parameters)
+	}
+	for _, p := range result.Parameters {
+		value, ok := expected[p.Name]
+		if !ok || p.Value != value {
+			t.Errorf("unexpected parameter %#v", p)
+		}
+		if (p.Generate == "expression") != (len(value) == 0) {
+			t.Errorf("only the webhook secrets should be generated: %#v", p)
+		}
+	}
+
+	for i := range result.Parameters {
+		if result.Parameters[i].Name == "NAME" {
+			result.Parameters[i].Value = "other"
+		}
+	}
+	processor := template.NewProcessor(map[string]generator.Generator{
+		"expression": generator.NewExpressionValueGenerator(rand.New(rand.NewSource(1))),
+	})
+	if errs := processor.Process(result); len(errs) > 0 {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	for _, obj := range result.Objects {
+		switch o := obj.(type) {
+		case *image.ImageStream:
+			if o.Name == "other" && len(o.Spec.DockerImageRepository) > 0 {
+				t.Errorf("the output image stream should not import an image: %#v", o)
+			}
+			if o.Name != "other" && o.Spec.DockerImageRepository != "centos/ruby-22-centos7" {
+				t.Errorf("unexpected input image stream: %#v", o)
+			}
+		case *buildapi.BuildConfig:
+			if o.Name != "other" || o.Spec.Output.To.Name != "other:latest" || o.Labels["app"] != "ruby-hello-world" {
+				t.Errorf("unexpected build config: %#v", o.ObjectMeta)
+			}
+			if len(o.Spec.Triggers[0].GitHubWebHook.Secret) != 40 {
+				t.Errorf("expected a generated webhook secret: %#v", o.Spec.Triggers[0])
+			}
+		case *deployapi.DeploymentConfig:
+			if o.Name != "other" || o.Spec.Selector["deploymentconfig"] != "other" || o.Spec.Template.Labels["app"] != "ruby-hello-world" {
+				t.Errorf("unexpected deployment config: %#v", o)
+			}
+			for _, trigger := range o.Spec.Triggers {
+				if trigger.ImageChangeParams != nil && trigger.ImageChangeParams.From.Name != "other:latest" {
+					t.Errorf("unexpected image change trigger: %#v", trigger.ImageChangeParams)
+				}
+			}
+		case *kapi.Service:
+			if o.Name != "other" || o.Spec.Selector["deploymentconfig"] != "other" {
+				t.Errorf("unexpected service: %#v", o)
+			}
+		default:
+			t.Errorf("unexpected object %#v", obj)
+		}
+	}
+}
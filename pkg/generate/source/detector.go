@@ -1,7 +1,6 @@
 package source
 
 import (
-	"os"
 	"path/filepath"
 )
 
@@ -19,7 +18,9 @@ type DetectorFunc func(dir string) (*Info, bool)
 // language/platform for a given source directory
 type Detectors []DetectorFunc
 
-// DefafultDetectors is a default set of Detector functions
+// DefafultDetectors is a default set of Detector functions. Static HTML is
+// detected last so that the sites of the other platforms are not mistaken for
+// static sites.
 var DefaultDetectors = Detectors{
 	DetectRuby,
 	DetectJava,
@@ -28,6 +29,9 @@ var DefaultDetectors = Detectors{
 	DetectPython,
 	DetectPerl,
 	DetectScala,
+	DetectGolang,
+	DetectDotNet,
+	DetectStaticHTML,
 }
 
 type sourceDetector struct {
@@ -45,29 +49,48 @@ func (s Detectors) DetectSource(dir string) (*Info, bool) {
 	return nil, false
 }
 
-// DetectRuby detects Ruby source
+// DetectRuby detects Ruby source and the Ruby version from .ruby-version or
+// the Gemfile
 func DetectRuby(dir string) (*Info, bool) {
-	return detect("ruby", dir, "Gemfile", "Rakefile", "config.ru")
+	return detectWithVersion("ruby", dir, []string{"Gemfile", "Rakefile", "config.ru"},
+		fileVersion(".ruby-version"),
+		patternVersion("Gemfile", gemfileRubyExp),
+	)
 }
 
-// DetectJava detects Java source
+// DetectJava detects Java source built with Maven or Gradle and the Java
+// version the source is compiled for
 func DetectJava(dir string) (*Info, bool) {
-	return detect("jee", dir, "pom.xml")
+	return detectWithVersion("jee", dir, []string{"pom.xml", "build.gradle", "build.gradle.kts"},
+		patternVersion("pom.xml", pomJavaVersionExp),
+		patternVersion("build.gradle", gradleJavaVersionExp),
+		patternVersion("build.gradle.kts", gradleJavaVersionExp),
+	)
 }
 
-// DetectNodeJS detects NodeJS source
+// DetectNodeJS detects NodeJS source and the NodeJS version from the engines
+// of package.json or .nvmrc
 func DetectNodeJS(dir string) (*Info, bool) {
-	return detect("nodejs", dir, "app.json", "package.json")
+	return detectWithVersion("nodejs", dir, []string{"app.json", "package.json"},
+		jsonVersion("package.json", "engines", "node"),
+		fileVersion(".nvmrc"),
+	)
 }
 
-// DetectPHP detects PHP source
+// DetectPHP detects PHP source and the PHP version required by composer.json
 func DetectPHP(dir string) (*Info, bool) {
-	return detect("php", dir, "index.php", "composer.json")
+	return detectWithVersion("php", dir, []string{"index.php", "composer.json"},
+		jsonVersion("composer.json", "require", "php"),
+	)
 }
 
-// DetectPython detects Python source
+// DetectPython detects Python source and the Python version from runtime.txt
+// or .python-version
 func DetectPython(dir string) (*Info, bool) {
-	return detect("python", dir, "requirements.txt", "setup.py")
+	return detectWithVersion("python", dir, []string{"requirements.txt", "setup.py"},
+		fileVersion("runtime.txt"),
+		fileVersion(".python-version"),
+	)
 }
 
 // DetectPerl detects Perl source
@@ -80,6 +103,27 @@ func DetectScala(dir string) (*Info, bool) {
 	return detect("scala", dir, "build.sbt")
 }
 
+// DetectGolang detects Go source and the Go version from Godeps or .go-version
+func DetectGolang(dir string) (*Info, bool) {
+	return detectWithVersion("golang", dir, []string{"Godeps/Godeps.json", "glide.yaml", "*.go"},
+		jsonVersion("Godeps/Godeps.json", "GoVersion"),
+		fileVersion(".go-version"),
+	)
+}
+
+// DetectDotNet detects .NET source and the .NET Core SDK version from
+// global.json
+func DetectDotNet(dir string) (*Info, bool) {
+	return detectWithVersion("dotnet", dir, []string{"project.json", "global.json", "*.csproj", "*.sln"},
+		jsonVersion("global.json", "sdk", "version"),
+	)
+}
+
+// DetectStaticHTML detects a static web site
+func DetectStaticHTML(dir string) (*Info, bool) {
+	return detect("httpd", dir, "index.html", "index.htm")
+}
+
 // detect returns an Info object with the given platform if the source at dir contains any of the argument files
 func detect(platform string, dir string, files ...string) (*Info, bool) {
 	if filesPresent(dir, files) {
@@ -90,10 +134,29 @@ func detect(platform string, dir string, files ...string) (*Info, bool) {
 	return nil, false
 }
 
+// detectWithVersion returns an Info object with the given platform if the
+// source at dir contains any of the files, and the version returned by the
+// first VersionFunc that finds one
+func detectWithVersion(platform string, dir string, files []string, versions ...VersionFunc) (*Info, bool) {
+	info, found := detect(platform, dir, files...)
+	if !found {
+		return nil, false
+	}
+	for _, version := range versions {
+		if v := version(dir); len(v) > 0 {
+			info.Version = v
+			break
+		}
+	}
+	return info, true
+}
+
+// filesPresent returns true if the source at dir contains any of the files,
+// which may be glob patterns
 func filesPresent(dir string, files []string) bool {
 	for _, f := range files {
-		_, err := os.Stat(filepath.Join(dir, f))
-		if err == nil {
+		matches, err := filepath.Glob(filepath.Join(dir, f))
+		if err == nil && len(matches) > 0 {
 			return true
 		}
 	}
@@ -1,6 +1,9 @@
 package source
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -44,3 +47,122 @@ func fake2(dir string) (*Info, bool) {
 	return nil, false
 
 }
+
+// sourceDir creates a temporary source directory containing files.
+func sourceDir(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "source")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestDefaultDetectors(t *testing.T) {
+	tests := map[string]struct {
+		files    map[string]string
+		platform string
+		version  string
+	}{
+		"ruby version file": {
+			files:    map[string]string{"Gemfile": "source 'https://rubygems.org'", ".ruby-version": "ruby-2.2.3\n"},
+			platform: "ruby",
+			version:  "2.2",
+		},
+		"ruby Gemfile version": {
+			files:    map[string]string{"Gemfile": "source 'https://rubygems.org'\nruby '2.0.0'\n"},
+			platform: "ruby",
+			version:  "2.0",
+		},
+		"maven": {
+			files:    map[string]string{"pom.xml": "<properties><maven.compiler.source>1.8</maven.compiler.source></properties>"},
+			platform: "jee",
+			version:  "1.8",
+		},
+		"gradle": {
+			files:    map[string]string{"build.gradle": "apply plugin: 'java'\nsourceCompatibility = 1.7\n"},
+			platform: "jee",
+			version:  "1.7",
+		},
+		"nodejs engines": {
+			files:    map[string]string{"package.json": `{"name": "app", "engines": {"node": ">=0.10.0"}}`},
+			platform: "nodejs",
+			version:  "0.10",
+		},
+		"nodejs without version": {
+			files:    map[string]string{"package.json": `{"name": "app"}`},
+			platform: "nodejs",
+		},
+		"php": {
+			files:    map[string]string{"composer.json": `{"require": {"php": ">=5.5.9"}}`},
+			platform: "php",
+			version:  "5.5",
+		},
+		"python": {
+			files:    map[string]string{"requirements.txt": "django", "runtime.txt": "python-3.4.3"},
+			platform: "python",
+			version:  "3.4",
+		},
+		"go with Godeps": {
+			files:    map[string]string{"Godeps/Godeps.json": `{"ImportPath": "example.com/app", "GoVersion": "go1.6"}`},
+			platform: "golang",
+			version:  "1.6",
+		},
+		"go sources": {
+			files:    map[string]string{"main.go": "package main"},
+			platform: "golang",
+		},
+		".NET": {
+			files:    map[string]string{"app.csproj": "<Project/>", "global.json": `{"sdk": {"version": "1.0.0-preview2-003121"}}`},
+			platform: "dotnet",
+			version:  "1.0",
+		},
+		"static site": {
+			files:    map[string]string{"index.html": "<html></html>"},
+			platform: "httpd",
+		},
+		"static site of another platform": {
+			files:    map[string]string{"index.html": "<html></html>", "index.php": "<?php"},
+			platform: "php",
+		},
+	}
+
+	for name, test := range tests {
+		dir := sourceDir(t, test.files)
+		defer os.RemoveAll(dir)
+		info, ok := DefaultDetectors.DetectSource(dir)
+		if !ok {
+			t.Errorf("%s: unable to detect source", name)
+			continue
+		}
+		if info.Platform != test.platform || info.Version != test.version {
+			t.Errorf("%s: expected %s %s, got %s %s", name, test.platform, test.version, info.Platform, info.Version)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := map[string]string{
+		"2.2.3":      "2.2",
+		"ruby-2.2.3": "2.2",
+		">=0.10.0":   "0.10",
+		"~4":         "4",
+		"go1.6":      "1.6",
+		"1.8\n":      "1.8",
+		"latest":     "",
+		" v4.2.1 \n": "4.2",
+	}
+	for value, expected := range tests {
+		if version := ParseVersion(value); version != expected {
+			t.Errorf("%q: expected %q, got %q", value, expected, version)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"k8s.io/kubernetes/pkg/util/yaml"
+)
+
+// DetectorRules is the content of a detector rules file, which lets
+// administrators detect platforms without recompiling. For example:
+//
+//   detectors:
+//   - platform: elixir
+//     files:
+//     - mix.exs
+//     versionFile: .tool-versions
+//     versionPattern: 'elixir\s+(\S+)'
+type DetectorRules struct {
+	// Detectors are the rules detecting each platform.
+	Detectors []DetectorRule `json:"detectors"`
+}
+
+// DetectorRule detects a platform from the files present in a source
+// directory.
+type DetectorRule struct {
+	// Platform is the detected platform, which is searched for in the
+	// supports annotation of builder images.
+	Platform string `json:"platform"`
+	// Files are the names or glob patterns of the files identifying the
+	// platform. The platform is detected if any of them is present.
+	Files []string `json:"files"`
+	// VersionFile is the file the platform version is read from. Optional.
+	VersionFile string `json:"versionFile,omitempty"`
+	// VersionPattern is a regular expression whose first group matches the
+	// version in VersionFile. Defaults to the first version number of the
+	// file.
+	VersionPattern string `json:"versionPattern,omitempty"`
+}
+
+// Detector returns the DetectorFunc of the rule, or an error if the rule is
+// invalid.
+func (r DetectorRule) Detector() (DetectorFunc, error) {
+	if len(r.Platform) == 0 {
+		return nil, fmt.Errorf("a platform is required")
+	}
+	if len(r.Files) == 0 {
+		return nil, fmt.Errorf("at least one file is required to detect %s", r.Platform)
+	}
+	var versions []VersionFunc
+	switch {
+	case len(r.VersionFile) == 0 && len(r.VersionPattern) > 0:
+		return nil, fmt.Errorf("a versionFile is required with a versionPattern to detect %s", r.Platform)
+	case len(r.VersionPattern) > 0:
+		exp, err := regexp.Compile(r.VersionPattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid versionPattern to detect %s: %v", r.Platform, err)
+		}
+		if exp.NumSubexp() == 0 {
+			return nil, fmt.Errorf("the versionPattern to detect %s must have a group matching the version", r.Platform)
+		}
+		versions = append(versions, patternVersion(r.VersionFile, exp))
+	case len(r.VersionFile) > 0:
+		versions = append(versions, fileVersion(r.VersionFile))
+	}
+	return func(dir string) (*Info, bool) {
+		return detectWithVersion(r.Platform, dir, r.Files, versions...)
+	}, nil
+}
+
+// LoadDetectorRules reads the detector rules of a YAML or JSON file and
+// returns their detectors, in the order of the file.
+func LoadDetectorRules(filename string) (Detectors, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	data, err = yaml.ToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse the detector rules in %s: %v", filename, err)
+	}
+	rules := &DetectorRules{}
+	if err := json.Unmarshal(data, rules); err != nil {
+		return nil, fmt.Errorf("unable to parse the detector rules in %s: %v", filename, err)
+	}
+	detectors := Detectors{}
+	for i, rule := range rules.Detectors {
+		detector, err := rule.Detector()
+		if err != nil {
+			return nil, fmt.Errorf("invalid detector rule %d in %s: %v", i, filename, err)
+		}
+		detectors = append(detectors, detector)
+	}
+	return detectors, nil
+}
@@ -0,0 +1,89 @@
+package source
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDetectorRules(t *testing.T) {
+	rules := `
+detectors:
+- platform: elixir
+  files:
+  - mix.exs
+  versionFile: .tool-versions
+  versionPattern: 'elixir\s+(\S+)'
+- platform: rust
+  files:
+  - Cargo.toml
+  versionFile: rust-toolchain
+`
+	dir := sourceDir(t, map[string]string{"rules.yaml": rules})
+	defer os.RemoveAll(dir)
+	detectors, err := LoadDetectorRules(filepath.Join(dir, "rules.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(detectors) != 2 {
+		t.Fatalf("expected 2 detectors, got %d", len(detectors))
+	}
+
+	tests := map[string]struct {
+		files    map[string]string
+		platform string
+		version  string
+	}{
+		"version pattern": {
+			files:    map[string]string{"mix.exs": "", ".tool-versions": "erlang 18.3\nelixir 1.2.5\n"},
+			platform: "elixir",
+			version:  "1.2",
+		},
+		"version file": {
+			files:    map[string]string{"Cargo.toml": "", "rust-toolchain": "1.10.0"},
+			platform: "rust",
+			version:  "1.10",
+		},
+	}
+	for name, test := range tests {
+		dir := sourceDir(t, test.files)
+		defer os.RemoveAll(dir)
+		info, ok := detectors.DetectSource(dir)
+		if !ok {
+			t.Errorf("%s: unable to detect source", name)
+			continue
+		}
+		if info.Platform != test.platform || info.Version != test.version {
+			t.Errorf("%s: expected %s %s, got %s %s", name, test.platform, test.version, info.Platform, info.Version)
+		}
+	}
+}
+
+func TestInvalidDetectorRules(t *testing.T) {
+	tests := map[string]DetectorRule{
+		"no platform":             {Files: []string{"mix.exs"}},
+		"no files":                {Platform: "elixir"},
+		"pattern without file":    {Platform: "elixir", Files: []string{"mix.exs"}, VersionPattern: `(\d+)`},
+		"invalid pattern":         {Platform: "elixir", Files: []string{"mix.exs"}, VersionFile: "version", VersionPattern: `(`},
+		"pattern without a group": {Platform: "elixir", Files: []string{"mix.exs"}, VersionFile: "version", VersionPattern: `\d+`},
+	}
+	for name, rule := range tests {
+		if _, err := rule.Detector(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	dir, err := ioutil.TempDir("", "rules")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "rules.json")
+	if err := ioutil.WriteFile(filename, []byte(`{"detectors": [{"platform": "elixir"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDetectorRules(filename); err == nil {
+		t.Errorf("expected an error loading an invalid rule")
+	}
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	// versionExp matches a version number, capturing its major and minor parts
+	versionExp = regexp.MustCompile(`(\d+)(?:\.(\d+))?`)
+
+	gemfileRubyExp       = regexp.MustCompile(`(?m)^\s*ruby\s+['"]([^'"]+)['"]`)
+	pomJavaVersionExp    = regexp.MustCompile(`<(?:maven\.compiler\.source|java\.version)>\s*([^<\s]+)\s*<`)
+	gradleJavaVersionExp = regexp.MustCompile(`sourceCompatibility\s*=\s*['"]?(?:JavaVersion\.VERSION_)?([\d._]+)`)
+)
+
+// VersionFunc returns the version of the platform of the source at dir, or an
+// empty string if the version cannot be determined.
+type VersionFunc func(dir string) string
+
+// fileVersion returns a VersionFunc reading the version from a file that
+// contains only the version, such as .ruby-version.
+func fileVersion(name string) VersionFunc {
+	return func(dir string) string {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		return ParseVersion(string(data))
+	}
+}
+
+// patternVersion returns a VersionFunc reading the version from the first
+// group of exp matched against a file.
+func patternVersion(name string, exp *regexp.Regexp) VersionFunc {
+	return func(dir string) string {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		match := exp.FindSubmatch(data)
+		if len(match) < 2 {
+			return ""
+		}
+		return ParseVersion(string(match[1]))
+	}
+}
+
+// jsonVersion returns a VersionFunc reading the version from the string found
+// by following keys in a JSON file.
+func jsonVersion(name string, keys ...string) VersionFunc {
+	return func(dir string) string {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return ""
+		}
+		var value interface{}
+		if err := json.Unmarshal(data, &value); err != nil {
+			return ""
+		}
+		for _, key := range keys {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				return ""
+			}
+			value = m[key]
+		}
+		s, ok := value.(string)
+		if !ok {
+			return ""
+		}
+		return ParseVersion(s)
+	}
+}
+
+// ParseVersion returns the major and minor parts of the first version number
+// found in s, such as 2.2 for "ruby-2.2.3" or 0.10 for ">=0.10.0", which is
+// how builder images declare the versions they support.
+func ParseVersion(s string) string {
+	match := versionExp.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return ""
+	}
+	if len(match[2]) == 0 {
+		return match[1]
+	}
+	return match[1] + "." + match[2]
+}
@@ -0,0 +1,200 @@
+package signatureverification
+
+import (
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/labels"
+
+	"github.com/openshift/origin/pkg/client"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
+	configlatest "github.com/openshift/origin/pkg/cmd/server/api/latest"
+	"github.com/openshift/origin/pkg/image/admission/signatureverification/api"
+	"github.com/openshift/origin/pkg/image/admission/signatureverification/api/validation"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/signature"
+	projectcache "github.com/openshift/origin/pkg/project/cache"
+)
+
+func init() {
+	admission.RegisterPlugin("ImageSignatureVerification", func(client kclient.Interface, config io.Reader) (admission.Interface, error) {
+		pluginConfig, err := readConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		return NewImageSignatureVerification(pluginConfig)
+	})
+}
+
+func readConfig(reader io.Reader) (*api.ImageSignatureVerificationConfig, error) {
+	config := &api.ImageSignatureVerificationConfig{}
+	if reader == nil || reflect.ValueOf(reader).IsNil() {
+		return config, nil
+	}
+	configBytes, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	err = configlatest.ReadYAML(configBytes, config)
+	if err != nil {
+		return nil, err
+	}
+	errs := validation.ValidateImageSignatureVerificationConfig(config)
+	if len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return config, nil
+}
+
+// errNotIntegratedRegistry is returned for images referenced by a tag which is not served by
+// the integrated registry.
+var errNotIntegratedRegistry = errors.New("images must be referenced by digest or by a tag of an image stream in the integrated registry")
+
+// policy is an ImageSignaturePolicy with a parsed selector and loaded keys.
+type policy struct {
+	selector labels.Selector
+	keys     []crypto.PublicKey
+}
+
+// NewImageSignatureVerification returns an admission plugin that refuses pods whose images
+// are not signed by a key trusted for the project of the pod. The trusted keys are read
+// when the plugin is created.
+func NewImageSignatureVerification(config *api.ImageSignatureVerificationConfig) (admission.Interface, error) {
+	policies := []policy{}
+	for _, p := range config.Policies {
+		keys, err := signature.LoadTrustedKeys(p.TrustedKeys)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy{
+			selector: labels.Set(p.ProjectSelector).AsSelector(),
+			keys:     keys,
+		})
+	}
+	return &imageSignatureVerification{
+		Handler:  admission.NewHandler(admission.Create, admission.Update),
+		policies: policies,
+	}, nil
+}
+
+type imageSignatureVerification struct {
+	*admission.Handler
+	policies []policy
+	client   client.Interface
+	cache    *projectcache.ProjectCache
+}
+
+// ensure that the required Openshift admission interfaces are implemented
+var _ = oadmission.WantsProjectCache(&imageSignatureVerification{})
+var _ = oadmission.WantsOpenshiftClient(&imageSignatureVerification{})
+var _ = oadmission.Validator(&imageSignatureVerification{})
+
+// Admit refuses pods with an image that is not signed by one of the keys trusted for the
+// project of the pod. Images must be referenced by digest or by a tag of an image stream in
+// the integrated registry.
+func (a *imageSignatureVerification) Admit(attributes admission.Attributes) error {
+	if len(a.policies) == 0 ||
+		attributes.GetResource() != kapi.Resource("pods") ||
+		len(attributes.GetSubresource()) > 0 {
+		return nil
+	}
+	pod, ok := attributes.GetObject().(*kapi.Pod)
+	if !ok {
+		return admission.NewForbidden(attributes, fmt.Errorf("unexpected object: %#v", attributes.GetObject()))
+	}
+
+	keys, err := a.trustedKeys(attributes.GetNamespace())
+	if err != nil {
+		return admission.NewForbidden(attributes, err)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for _, container := range pod.Spec.Containers {
+		image, err := a.resolveImage(container.Image)
+		if err != nil {
+			return admission.NewForbidden(attributes, fmt.Errorf("unable to verify the signature of image %q of container %q: %v", container.Image, container.Name, err))
+		}
+		if err := signature.Verify(image, keys); err != nil {
+			return admission.NewForbidden(attributes, fmt.Errorf("image %q of container %q: %v", container.Image, container.Name, err))
+		}
+	}
+	return nil
+}
+
+// trustedKeys returns the keys of the first policy that selects the project.
+func (a *imageSignatureVerification) trustedKeys(namespace string) ([]crypto.PublicKey, error) {
+	ns, err := a.cache.GetNamespace(namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error looking up pod namespace: %v", err)
+	}
+	projectLabels := labels.Set(ns.Labels)
+	for _, p := range a.policies {
+		if p.selector.Matches(projectLabels) {
+			return p.keys, nil
+		}
+	}
+	return nil, nil
+}
+
+// resolveImage returns the image a container pull spec refers to. Pull specs referencing an
+// image by digest are looked up directly, since the digest identifies the content which is
+// pulled whatever the registry. Pull specs referencing a tag are resolved through the image
+// stream tag only if they point to the repository of the image stream in the integrated
+// registry, which serves the image the image stream tag references. A tag of any other
+// registry may reference any content.
+func (a *imageSignatureVerification) resolveImage(pullSpec string) (*imageapi.Image, error) {
+	ref, err := imageapi.ParseDockerImageReference(pullSpec)
+	if err != nil {
+		return nil, err
+	}
+	if len(ref.ID) > 0 {
+		return a.client.Images().Get(ref.ID)
+	}
+	if len(ref.Registry) == 0 || len(ref.Namespace) == 0 {
+		return nil, errNotIntegratedRegistry
+	}
+	stream, err := a.client.ImageStreams(ref.Namespace).Get(ref.Name)
+	if err != nil {
+		return nil, err
+	}
+	repository, err := imageapi.ParseDockerImageReference(stream.Status.DockerImageRepository)
+	if err != nil || repository.Registry != ref.Registry || repository.Namespace != ref.Namespace || repository.Name != ref.Name {
+		return nil, errNotIntegratedRegistry
+	}
+	tag := ref.Tag
+	if len(tag) == 0 {
+		tag = imageapi.DefaultImageTag
+	}
+	istag, err := a.client.ImageStreamTags(ref.Namespace).Get(ref.Name, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &istag.Image, nil
+}
+
+func (a *imageSignatureVerification) SetOpenshiftClient(c client.Interface) {
+	a.client = c
+}
+
+func (a *imageSignatureVerification) SetProjectCache(cache *projectcache.ProjectCache) {
+	a.cache = cache
+}
+
+func (a *imageSignatureVerification) Validate() error {
+	if a.client == nil {
+		return errors.New("ImageSignatureVerification plugin requires an Openshift client")
+	}
+	if a.cache == nil {
+		return errors.New("ImageSignatureVerification plugin requires a project cache")
+	}
+	return nil
+}
@@ -0,0 +1,262 @@
+package signatureverification
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
+	"github.com/openshift/origin/pkg/image/admission/signatureverification/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/signature"
+	projectcache "github.com/openshift/origin/pkg/project/cache"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+)
+
+const (
+	registry      = "172.30.1.1:5000"
+	signedImage   = "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238"
+	unsignedImage = "sha256:b8f1e2d9a03b8c3c4f5e9e57e6bd0dd5a7b4b9e0a8c1d8f1d0d9c2b1e4f3a2c1"
+)
+
+func testCache(projectLabels map[string]string) *projectcache.ProjectCache {
+	kclient := &ktestclient.Fake{}
+	pCache := projectcache.NewFake(kclient.Namespaces(), projectcache.NewCacheStore(cache.MetaNamespaceKeyFunc), "")
+	ns := &kapi.Namespace{}
+	ns.Name = "default"
+	ns.Labels = projectLabels
+	pCache.Store.Add(ns)
+	return pCache
+}
+
+func testClient(t *testing.T, key *ecdsa.PrivateKey) *testclient.Fake {
+	content, err := signature.Sign(key, signedImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	images := map[string]*imageapi.Image{
+		signedImage: {
+			ObjectMeta: kapi.ObjectMeta{Name: signedImage},
+			Signatures: []imageapi.ImageSignature{
+				{
+					ObjectMeta: kapi.ObjectMeta{Name: imageapi.JoinImageSignatureName(signedImage, "signer")},
+					Type:       imageapi.ImageSignatureTypeX509,
+					Content:    content,
+				},
+			},
+		},
+		unsignedImage: {
+			ObjectMeta: kapi.ObjectMeta{Name: unsignedImage},
+		},
+	}
+	tags := map[string]string{
+		"default/signed:latest":   signedImage,
+		"default/unsigned:latest": unsignedImage,
+		"other/signed:v1":         signedImage,
+	}
+
+	client := &testclient.Fake{}
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if image, ok := images[name]; ok {
+			return true, image, nil
+		}
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("images"), name)
+	})
+	client.AddReactor("get", "imagestreamtags", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if image, ok := tags[action.GetNamespace()+"/"+name]; ok {
+			return true, &imageapi.ImageStreamTag{Image: *images[image]}, nil
+		}
+		return true, nil, kerrors.NewNotFound(imageapi.Resource("imagestreamtags"), name)
+	})
+	client.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		stream := &imageapi.ImageStream{}
+		stream.Status.DockerImageRepository = registry + "/" + action.GetNamespace() + "/" + name
+		return true, stream, nil
+	})
+	return client
+}
+
+func testPod(images ...string) *kapi.Pod {
+	pod := &kapi.Pod{}
+	for i, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, kapi.Container{Name: fmt.Sprintf("c%d", i), Image: image})
+	}
+	return pod
+}
+
+func writeTrustedKey(t *testing.T, dir string, key *ecdsa.PrivateKey) string {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "signer.pem")
+	if err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return file
+}
+
+func TestImageSignatureVerificationAdmit(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir, err := ioutil.TempDir("", "signatureverification")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	keyFile := writeTrustedKey(t, dir, key)
+
+	config := &api.ImageSignatureVerificationConfig{
+		Policies: []api.ImageSignaturePolicy{
+			{ProjectSelector: map[string]string{"signed": "false"}},
+			{ProjectSelector: map[string]string{"signed": "true"}, TrustedKeys: []string{keyFile}},
+		},
+	}
+
+	tests := []struct {
+		name          string
+		pod           *kapi.Pod
+		operation     admission.Operation
+		projectLabels map[string]string
+		expectedErr   string
+	}{
+		{
+			name:          "signed image stream tag",
+			pod:           testPod(registry + "/default/signed"),
+			projectLabels: map[string]string{"signed": "true"},
+		},
+		{
+			name:          "signed image by digest",
+			pod:           testPod("registry:5000/default/signed@" + signedImage),
+			projectLabels: map[string]string{"signed": "true"},
+		},
+		{
+			name:          "signed image stream tag in another namespace",
+			pod:           testPod(registry + "/other/signed:v1"),
+			projectLabels: map[string]string{"signed": "true"},
+		},
+		{
+			name:          "unsigned image stream tag",
+			pod:           testPod(registry+"/default/signed", registry+"/default/unsigned"),
+			projectLabels: map[string]string{"signed": "true"},
+			expectedErr:   `image "172.30.1.1:5000/default/unsigned" of container "c1": the image has no signature from a trusted key`,
+		},
+		{
+			name:          "unsigned image stream tag on update",
+			pod:           testPod(registry + "/default/unsigned"),
+			operation:     admission.Update,
+			projectLabels: map[string]string{"signed": "true"},
+			expectedErr:   "the image has no signature from a trusted key",
+		},
+		{
+			name:          "tag of another registry",
+			pod:           testPod("evil.example.com/default/signed:latest"),
+			projectLabels: map[string]string{"signed": "true"},
+			expectedErr:   "images must be referenced by digest or by a tag of an image stream in the integrated registry",
+		},
+		{
+			name:          "tag without registry",
+			pod:           testPod("default/signed"),
+			projectLabels: map[string]string{"signed": "true"},
+			expectedErr:   "images must be referenced by digest or by a tag of an image stream in the integrated registry",
+		},
+		{
+			name:          "unresolvable image",
+			pod:           testPod("docker.io/library/busybox"),
+			projectLabels: map[string]string{"signed": "true"},
+			expectedErr:   `unable to verify the signature of image "docker.io/library/busybox"`,
+		},
+		{
+			name:          "unknown digest",
+			pod:           testPod("busybox@sha256:0000000000000000000000000000000000000000000000000000000000000000"),
+			projectLabels: map[string]string{"signed": "true"},
+			expectedErr:   "unable to verify the signature",
+		},
+		{
+			name:          "policy without trusted keys",
+			pod:           testPod("unsigned"),
+			projectLabels: map[string]string{"signed": "false"},
+		},
+		{
+			name: "project without policy",
+			pod:  testPod("docker.io/library/busybox"),
+		},
+	}
+
+	for _, tc := range tests {
+		plugin, err := NewImageSignatureVerification(config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		plugin.(oadmission.WantsProjectCache).SetProjectCache(testCache(tc.projectLabels))
+		plugin.(oadmission.WantsOpenshiftClient).SetOpenshiftClient(testClient(t, key))
+		if err := plugin.(oadmission.Validator).Validate(); err != nil {
+			t.Fatal(err)
+		}
+		operation := tc.operation
+		if len(operation) == 0 {
+			operation = admission.Create
+		}
+		attrs := admission.NewAttributesRecord(tc.pod, kapi.Kind("Pod"), "default", "test", kapi.Resource("pods"), "", operation, nil)
+		err = plugin.Admit(attrs)
+		if len(tc.expectedErr) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+			t.Errorf("%s: expected error %q, got %v", tc.name, tc.expectedErr, err)
+			continue
+		}
+		if !kerrors.IsForbidden(err) {
+			t.Errorf("%s: expected a forbidden error, got %v", tc.name, err)
+		}
+	}
+}
+
+func TestReadConfig(t *testing.T) {
+	configText := `
+apiVersion: v1
+kind: ImageSignatureVerificationConfig
+policies:
+- projectSelector:
+    signed: "true"
+  trustedKeys:
+  - /etc/origin/master/signer.crt
+`
+	config, err := readConfig(bytes.NewBufferString(configText))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(config.Policies) != 1 || config.Policies[0].ProjectSelector["signed"] != "true" || config.Policies[0].TrustedKeys[0] != "/etc/origin/master/signer.crt" {
+		t.Errorf("unexpected config: %#v", config)
+	}
+
+	if _, err := NewImageSignatureVerification(config); err == nil {
+		t.Errorf("expected an error for a missing trusted key file")
+	}
+}
@@ -0,0 +1,26 @@
+package v1
+
+import (
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// ImageSignatureVerificationConfig is the configuration for the ImageSignatureVerification
+// plugin. It contains an ordered list of verification policies based on project label
+// selectors. Selectors will be checked in order and the first one that matches the project
+// of a pod will be used to verify the images of the pod.
+type ImageSignatureVerificationConfig struct {
+	unversioned.TypeMeta
+
+	Policies []ImageSignaturePolicy `json:"policies" description:"ordered list of image signature policies"`
+}
+
+// ImageSignaturePolicy specifies the keys trusted to sign the images of pods in the projects
+// selected by a project label selector.
+type ImageSignaturePolicy struct {
+	// ProjectSelector is a project label selector. An empty selector selects everything.
+	ProjectSelector map[string]string `json:"projectSelector" description:"project label selector"`
+	// TrustedKeys is a list of files holding PEM encoded x509 certificates or public keys.
+	// Every image of a pod must have a signature made by one of the keys. If no keys are
+	// given the images of pods in the selected projects are not verified.
+	TrustedKeys []string `json:"trustedKeys" description:"files holding the certificates or public keys trusted to sign images"`
+}
@@ -0,0 +1,29 @@
+package validation
+
+import (
+	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/image/admission/signatureverification/api"
+)
+
+// ValidateImageSignatureVerificationConfig validates the ImageSignatureVerification plugin configuration.
+func ValidateImageSignatureVerificationConfig(config *api.ImageSignatureVerificationConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, policy := range config.Policies {
+		allErrs = append(allErrs, ValidateImageSignaturePolicy(policy, field.NewPath("policies").Index(i))...)
+	}
+	return allErrs
+}
+
+// ValidateImageSignaturePolicy validates a single image signature policy.
+func ValidateImageSignaturePolicy(policy api.ImageSignaturePolicy, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validation.ValidateLabels(policy.ProjectSelector, path.Child("projectSelector"))...)
+	for i, file := range policy.TrustedKeys {
+		if len(file) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("trustedKeys").Index(i), ""))
+		}
+	}
+	return allErrs
+}
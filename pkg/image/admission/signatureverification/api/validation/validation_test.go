@@ -0,0 +1,36 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/openshift/origin/pkg/image/admission/signatureverification/api"
+)
+
+func TestImageSignatureVerificationConfigValidation(t *testing.T) {
+	validConfig := &api.ImageSignatureVerificationConfig{
+		Policies: []api.ImageSignaturePolicy{
+			{
+				ProjectSelector: map[string]string{"signed": "true"},
+				TrustedKeys:     []string{"/etc/origin/master/signer.crt"},
+			},
+			{},
+		},
+	}
+	if errs := ValidateImageSignatureVerificationConfig(validConfig); len(errs) > 0 {
+		t.Errorf("Unexpected error on valid config: %v", errs)
+	}
+
+	invalidConfigs := map[string]*api.ImageSignatureVerificationConfig{
+		"invalid selector": {
+			Policies: []api.ImageSignaturePolicy{{ProjectSelector: map[string]string{"-": "true"}}},
+		},
+		"empty trusted key": {
+			Policies: []api.ImageSignaturePolicy{{TrustedKeys: []string{""}}},
+		},
+	}
+	for name, config := range invalidConfigs {
+		if errs := ValidateImageSignatureVerificationConfig(config); len(errs) == 0 {
+			t.Errorf("%s: did not get expected error", name)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Package signatureverification contains the ImageSignatureVerification admission
+control plugin. The plugin refuses pods in selected projects unless every image
+of the pod has a signature made by one of the keys trusted for the project.
+Pods are verified when they are created and when they are updated. Images must
+be referenced by digest, or by a tag of an image stream in the integrated
+registry, so that the image which is pulled is the one whose signatures are
+looked up. Only x509 signatures are verified.
+
+
+Configuration
+
+The plugin is configured via an ImageSignatureVerificationConfig object. The
+first policy whose projectSelector matches the labels of the project of a pod
+applies; pods in projects matched by no policy, or by a policy without trusted
+keys, are admitted:
+
+ apiVersion: v1
+ kind: ImageSignatureVerificationConfig
+ policies:
+ - projectSelector:
+     signed-images: "true"
+   trustedKeys:
+   - /etc/origin/master/image-signer.crt
+*/
+
+package signatureverification
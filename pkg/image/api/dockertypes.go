@@ -6,6 +6,19 @@ import (
 	"github.com/docker/distribution"
 )
 
+const (
+	// DockerManifestSchema1MediaType is the media type of a signed schema 1 manifest.
+	DockerManifestSchema1MediaType = "application/vnd.docker.distribution.manifest.v1+prettyjws"
+	// DockerManifestSchema2MediaType is the media type of a schema 2 manifest.
+	DockerManifestSchema2MediaType = "application/vnd.docker.distribution.manifest.v2+json"
+	// DockerManifestListMediaType is the media type of a manifest list, which references
+	// a schema 2 manifest per platform.
+	DockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+	// DockerImageConfigMediaType is the media type of the image configuration blob
+	// referenced by a schema 2 manifest.
+	DockerImageConfigMediaType = "application/vnd.docker.container.image.v1+json"
+)
+
 // DockerImage is the type representing a docker image and its various properties when
 // retrieved from the Docker client API.
 type DockerImage struct {
@@ -72,6 +85,64 @@ type DockerImageManifest struct {
 	Config distribution.Descriptor   `json:"config"`
 }
 
+// DockerManifestList references the platform specific manifests of a
+// multi-platform image.
+type DockerManifestList struct {
+	SchemaVersion int                          `json:"schemaVersion"`
+	MediaType     string                       `json:"mediaType,omitempty"`
+	Manifests     []DockerManifestListManifest `json:"manifests"`
+}
+
+// DockerManifestListManifest is a single manifest referenced by a manifest list.
+type DockerManifestListManifest struct {
+	distribution.Descriptor
+	Platform DockerPlatform `json:"platform"`
+}
+
+// DockerPlatform describes the platform a manifest in a manifest list is built for.
+type DockerPlatform struct {
+	Architecture string   `json:"architecture"`
+	OS           string   `json:"os"`
+	OSVersion    string   `json:"os.version,omitempty"`
+	OSFeatures   []string `json:"os.features,omitempty"`
+	Variant      string   `json:"variant,omitempty"`
+	Features     []string `json:"features,omitempty"`
+}
+
+// DockerImageConfig is the image configuration blob referenced by a schema 2
+// manifest.
+type DockerImageConfig struct {
+	ID              string                `json:"id,omitempty"`
+	Parent          string                `json:"parent,omitempty"`
+	Comment         string                `json:"comment,omitempty"`
+	Created         unversioned.Time      `json:"created"`
+	Container       string                `json:"container,omitempty"`
+	ContainerConfig DockerConfig          `json:"container_config,omitempty"`
+	DockerVersion   string                `json:"docker_version,omitempty"`
+	Author          string                `json:"author,omitempty"`
+	Config          *DockerConfig         `json:"config,omitempty"`
+	Architecture    string                `json:"architecture,omitempty"`
+	Size            int64                 `json:"size,omitempty"`
+	RootFS          *DockerConfigRootFS   `json:"rootfs,omitempty"`
+	History         []DockerConfigHistory `json:"history,omitempty"`
+	OS              string                `json:"os,omitempty"`
+}
+
+// DockerConfigHistory stores build commands that were used to create an image
+type DockerConfigHistory struct {
+	Created    unversioned.Time `json:"created"`
+	Author     string           `json:"author,omitempty"`
+	CreatedBy  string           `json:"created_by,omitempty"`
+	Comment    string           `json:"comment,omitempty"`
+	EmptyLayer bool             `json:"empty_layer,omitempty"`
+}
+
+// DockerConfigRootFS describes images root filesystem
+type DockerConfigRootFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diff_ids,omitempty"`
+}
+
 // DockerFSLayer is a container struct for BlobSums defined in an image manifest
 type DockerFSLayer struct {
 	// DockerBlobSum is the tarsum of the referenced filesystem image layer
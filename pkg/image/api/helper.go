@@ -289,6 +289,21 @@ func JoinImageStreamTag(name, tag string) string {
 	return fmt.Sprintf("%s:%s", name, tag)
 }
 
+// SplitImageSignatureName splits the name of an ImageSignature into the name of the signed
+// image and the name of the signature. It returns false if either part is missing.
+func SplitImageSignatureName(imageSignatureName string) (imageName, signatureName string, ok bool) {
+	parts := strings.Split(imageSignatureName, "@")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// JoinImageSignatureName turns an image name and a signature name into the name of an ImageSignature.
+func JoinImageSignatureName(imageName, signatureName string) string {
+	return fmt.Sprintf("%s@%s", imageName, signatureName)
+}
+
 // NormalizeImageStreamTag normalizes an image stream tag by defaulting to 'latest'
 // if no tag has been specified.
 func NormalizeImageStreamTag(name string) string {
@@ -309,10 +324,16 @@ func ManifestMatchesImage(image *Image, newManifest []byte) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	sm := schema1.SignedManifest{Raw: newManifest}
-	raw, err := sm.Payload()
-	if err != nil {
-		return false, err
+	raw := newManifest
+	switch image.DockerImageManifestMediaType {
+	case DockerManifestSchema2MediaType, DockerManifestListMediaType:
+		// schema 2 manifests are not signed, the digest covers the whole payload
+	default:
+		sm := schema1.SignedManifest{Raw: newManifest}
+		raw, err = sm.Payload()
+		if err != nil {
+			return false, err
+		}
 	}
 	if _, err := v.Write(raw); err != nil {
 		return false, err
@@ -390,8 +411,44 @@ func ImageWithMetadata(image *Image) error {
 			image.DockerImageMetadata.Size = v1Metadata.Size
 		}
 	case 2:
-		// TODO: need to prepare for this
-		return fmt.Errorf("unrecognized Docker image manifest schema %d for %q (%s)", manifest.SchemaVersion, image.Name, image.DockerImageReference)
+		if manifest.MediaType == DockerManifestListMediaType {
+			// manifest lists carry no layers or configuration of their own
+			return nil
+		}
+		if len(image.DockerImageConfig) == 0 {
+			return fmt.Errorf("schema 2 Docker image manifest for %q (%s) is missing the image configuration", image.Name, image.DockerImageReference)
+		}
+
+		config := DockerImageConfig{}
+		if err := json.Unmarshal([]byte(image.DockerImageConfig), &config); err != nil {
+			return err
+		}
+
+		// schema 2 layers are already ordered from lowest to highest
+		image.DockerImageLayers = make([]ImageLayer, len(manifest.Layers))
+		for i, layer := range manifest.Layers {
+			image.DockerImageLayers[i].Name = layer.Digest.String()
+			image.DockerImageLayers[i].Size = layer.Size
+		}
+		if len(image.DockerImageManifestMediaType) == 0 {
+			image.DockerImageManifestMediaType = DockerManifestSchema2MediaType
+		}
+
+		image.DockerImageMetadata.ID = manifest.Config.Digest.String()
+		image.DockerImageMetadata.Parent = config.Parent
+		image.DockerImageMetadata.Comment = config.Comment
+		image.DockerImageMetadata.Created = config.Created
+		image.DockerImageMetadata.Container = config.Container
+		image.DockerImageMetadata.ContainerConfig = config.ContainerConfig
+		image.DockerImageMetadata.DockerVersion = config.DockerVersion
+		image.DockerImageMetadata.Author = config.Author
+		image.DockerImageMetadata.Config = config.Config
+		image.DockerImageMetadata.Architecture = config.Architecture
+		size := int64(0)
+		for _, layer := range image.DockerImageLayers {
+			size += layer.Size
+		}
+		image.DockerImageMetadata.Size = size
 	default:
 		return fmt.Errorf("unrecognized Docker image manifest schema %d for %q (%s)", manifest.SchemaVersion, image.Name, image.DockerImageReference)
 	}
@@ -459,6 +459,42 @@ func TestDockerImageReferenceString(t *testing.T) {
 	}
 }
 
+const validSchema2ImageManifest = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+   "config": {
+      "mediaType": "application/vnd.docker.container.image.v1+json",
+      "size": 1442,
+      "digest": "sha256:2d2fd6a9e2ea5b3cc22e3e2c9b0ef2c4e8b8cfc1b0f7f2b0f4fbd3ff1b2e7a58"
+   },
+   "layers": [
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 2191,
+         "digest": "sha256:b4ca4c215f483111b64ec6919f1659ff475d7080a649d6acd78a6ade562a4a63"
+      },
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 32,
+         "digest": "sha256:4e0ba9fd56b8e3c2b9e5d6ae1f8b9c95a3aa5a6c6e1e9f3e6c8e3a5e5d7c2b1a"
+      }
+   ]
+}`
+
+const validSchema2ImageConfig = `{
+   "architecture": "amd64",
+   "config": {
+      "Env": ["PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"],
+      "Cmd": ["/hello"]
+   },
+   "container": "f2b4b5a4d9e0",
+   "created": "2016-03-08T18:18:46Z",
+   "docker_version": "1.10.2",
+   "history": [{"created": "2016-03-08T18:18:45Z", "created_by": "/bin/sh -c #(nop) COPY file:hello in /"}],
+   "os": "linux",
+   "rootfs": {"type": "layers", "diff_ids": ["sha256:a02596fdd012f22b03af6ad7d11fa590c57507558357b079c3e8cebceb4262d7"]}
+}`
+
 func validImageWithManifestData() Image {
 	return Image{
 		ObjectMeta: kapi.ObjectMeta{
@@ -632,6 +668,51 @@ func TestImageWithMetadata(t *testing.T) {
 				},
 			},
 		},
+		"schema 2 without config": {
+			image: Image{
+				DockerImageManifest: validSchema2ImageManifest,
+			},
+			expectError: true,
+		},
+		"schema 2 manifest list": {
+			image: Image{
+				DockerImageManifest:          `{"schemaVersion": 2, "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json", "manifests": []}`,
+				DockerImageManifestMediaType: DockerManifestListMediaType,
+			},
+			expectedImage: Image{
+				DockerImageManifest:          `{"schemaVersion": 2, "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json", "manifests": []}`,
+				DockerImageManifestMediaType: DockerManifestListMediaType,
+			},
+		},
+		"schema 2 happy path": {
+			image: Image{
+				ObjectMeta:          kapi.ObjectMeta{Name: "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238"},
+				DockerImageManifest: validSchema2ImageManifest,
+				DockerImageConfig:   validSchema2ImageConfig,
+			},
+			expectedImage: Image{
+				ObjectMeta:                   kapi.ObjectMeta{Name: "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238"},
+				DockerImageManifest:          validSchema2ImageManifest,
+				DockerImageManifestMediaType: DockerManifestSchema2MediaType,
+				DockerImageConfig:            validSchema2ImageConfig,
+				DockerImageLayers: []ImageLayer{
+					{Name: "sha256:b4ca4c215f483111b64ec6919f1659ff475d7080a649d6acd78a6ade562a4a63", Size: 2191},
+					{Name: "sha256:4e0ba9fd56b8e3c2b9e5d6ae1f8b9c95a3aa5a6c6e1e9f3e6c8e3a5e5d7c2b1a", Size: 32},
+				},
+				DockerImageMetadata: DockerImage{
+					ID:            "sha256:2d2fd6a9e2ea5b3cc22e3e2c9b0ef2c4e8b8cfc1b0f7f2b0f4fbd3ff1b2e7a58",
+					Created:       unversioned.Date(2016, 3, 8, 18, 18, 46, 0, time.UTC),
+					Container:     "f2b4b5a4d9e0",
+					DockerVersion: "1.10.2",
+					Config: &DockerConfig{
+						Env: []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"},
+						Cmd: []string{"/hello"},
+					},
+					Architecture: "amd64",
+					Size:         2223,
+				},
+			},
+		},
 	}
 
 	for name, test := range tests {
@@ -1046,6 +1127,30 @@ func TestJoinImageStreamTag(t *testing.T) {
 	}
 }
 
+func TestSplitImageSignatureName(t *testing.T) {
+	tests := map[string]struct {
+		name          string
+		imageName     string
+		signatureName string
+		ok            bool
+	}{
+		"valid":             {name: "sha256:abc@sig", imageName: "sha256:abc", signatureName: "sig", ok: true},
+		"missing signature": {name: "sha256:abc@"},
+		"missing image":     {name: "@sig"},
+		"no separator":      {name: "sha256:abc"},
+		"too many parts":    {name: "a@b@c"},
+	}
+	for name, test := range tests {
+		imageName, signatureName, ok := SplitImageSignatureName(test.name)
+		if imageName != test.imageName || signatureName != test.signatureName || ok != test.ok {
+			t.Errorf("%s: unexpected result: %q %q %t", name, imageName, signatureName, ok)
+		}
+	}
+	if e, a := "sha256:abc@sig", JoinImageSignatureName("sha256:abc", "sig"); e != a {
+		t.Errorf("Unexpected value: %s", a)
+	}
+}
+
 func TestResolveImageID(t *testing.T) {
 	tests := map[string]struct {
 		tags     map[string]TagEventList
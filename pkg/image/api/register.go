@@ -38,6 +38,7 @@ func addKnownTypes(scheme *runtime.Scheme) {
 		&ImageStreamTagList{},
 		&ImageStreamImage{},
 		&ImageStreamImport{},
+		&ImageSignature{},
 	)
 }
 
@@ -51,3 +52,4 @@ func (obj *ImageStreamTag) GetObjectKind() unversioned.ObjectKind     { return &
 func (obj *ImageStreamTagList) GetObjectKind() unversioned.ObjectKind { return &obj.TypeMeta }
 func (obj *ImageStreamImage) GetObjectKind() unversioned.ObjectKind   { return &obj.TypeMeta }
 func (obj *ImageStreamImport) GetObjectKind() unversioned.ObjectKind  { return &obj.TypeMeta }
+func (obj *ImageSignature) GetObjectKind() unversioned.ObjectKind     { return &obj.TypeMeta }
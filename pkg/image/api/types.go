@@ -28,10 +28,26 @@ const (
 	// ExcludeImageSecretAnnotation indicates that a secret should not be returned by imagestream/secrets.
 	ExcludeImageSecretAnnotation = "openshift.io/image.excludeSecret"
 
+	// ScheduledImportIntervalAnnotation may be set on an image stream to the minimum number of seconds
+	// between two scheduled imports of its tags. Intervals shorter than the minimum configured on the
+	// server are ignored.
+	ScheduledImportIntervalAnnotation = "openshift.io/image.scheduledImportIntervalSeconds"
+
 	// DefaultImageTag is used when an image tag is needed and the configuration does not specify a tag to use.
 	DefaultImageTag = "latest"
 )
 
+const (
+	// ResourceImageSize is the maximum size of a single image pushed to the integrated registry.
+	ResourceImageSize kapi.ResourceName = "openshift.io/image-size"
+	// ResourceImageStreamSize is the maximum storage used by the images of a single image stream
+	// in the integrated registry.
+	ResourceImageStreamSize kapi.ResourceName = "openshift.io/imagestream-size"
+	// ResourceProjectImagesSize is the maximum storage used by all images of the image streams of
+	// a project in the integrated registry.
+	ResourceProjectImagesSize kapi.ResourceName = "openshift.io/project-images-size"
+)
+
 // Image is an immutable representation of a Docker image and metadata at a point in time.
 type Image struct {
 	unversioned.TypeMeta
@@ -45,8 +61,33 @@ type Image struct {
 	DockerImageMetadataVersion string
 	// The raw JSON of the manifest
 	DockerImageManifest string
+	// DockerImageManifestMediaType specifies the mediaType of the manifest. Empty for schema 1 manifests.
+	DockerImageManifestMediaType string
+	// DockerImageConfig is the raw JSON of the image configuration blob referenced by a schema 2 manifest.
+	DockerImageConfig string
 	// DockerImageLayers represents the layers in the image. May not be set if the image does not define that data.
 	DockerImageLayers []ImageLayer
+	// Signatures holds all signatures of the image.
+	Signatures []ImageSignature
+}
+
+const (
+	// ImageSignatureTypeX509 is the type of signatures made with the private key of an x509 certificate
+	// or public key. The content of such a signature is the PKCS #1 v1.5 (RSA) or ASN.1 (ECDSA) signature
+	// of the SHA-256 hash of the image name.
+	ImageSignatureTypeX509 = "x509"
+)
+
+// ImageSignature holds a detached signature of an image. The name of a signature has the form
+// <image name>@<signature name>.
+type ImageSignature struct {
+	unversioned.TypeMeta
+	kapi.ObjectMeta
+
+	// Type describes the format of the signature.
+	Type string
+	// Content is the opaque signature of the image.
+	Content []byte
 }
 
 // ImageLayer represents a single layer of the image. Some images may have multiple layers. Some may have none.
@@ -84,6 +125,40 @@ type ImageStreamSpec struct {
 	DockerImageRepository string
 	// Tags map arbitrary string values to specific image locators
 	Tags map[string]TagReference
+	// RetentionPolicy, if set, controls which images referenced by this stream are pruned.
+	RetentionPolicy *ImageStreamRetentionPolicy
+	// Notifications are the endpoints that are notified when a tag of this stream is updated.
+	Notifications []ImageStreamNotification
+}
+
+// ImageStreamNotification is an endpoint that receives an HTTP POST every time a tag of an image
+// stream points to a new image. The body of the request is signed with a key kept in a secret.
+type ImageStreamNotification struct {
+	// URL is the http or https URL the notifications are sent to.
+	URL string
+	// SecretName is the name of the secret, in the namespace of the image stream, whose
+	// ImageStreamNotificationSecretKey entry is used to sign the body of the notifications with
+	// HMAC-SHA256.
+	SecretName string
+}
+
+// ImageStreamNotificationSecretKey is the key of the secret data used to sign the notifications of an
+// image stream.
+const ImageStreamNotificationSecretKey = "secret"
+
+// ImageStreamRetentionPolicy controls which images referenced by an image stream are removed from
+// the stream and deleted when images are pruned, either by an administrator or periodically by the
+// server.
+type ImageStreamRetentionPolicy struct {
+	// KeepTagRevisions is the number of most recent revisions of each tag that are kept. The current
+	// revision of a tag is always kept.
+	KeepTagRevisions int
+	// KeepYoungerThanSeconds keeps older revisions of a tag that were tagged less than this number of
+	// seconds ago. Images pushed to the stream that no tag references anymore are deleted once they are
+	// older than this.
+	KeepYoungerThanSeconds int64
+	// ProtectedTags are the tags whose revisions are never pruned.
+	ProtectedTags []string
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to
@@ -155,6 +230,18 @@ const (
 	ImportSuccess TagEventConditionType = "ImportSuccess"
 )
 
+// These are reasons of an ImportSuccess condition with status False. Other reasons may be reported when
+// the import fails for a different cause.
+const (
+	// ImportFailedUnauthorized means the registry required credentials to import the tag, or refused the
+	// credentials it was given.
+	ImportFailedUnauthorized = "Unauthorized"
+	// ImportFailedNotFound means the repository or the tag does not exist in the registry.
+	ImportFailedNotFound = "NotFound"
+	// ImportFailedRegistryUnavailable means the registry could not be reached or reported a server error.
+	ImportFailedRegistryUnavailable = "RegistryUnavailable"
+)
+
 // TagEventCondition contains condition information for a tag event.
 type TagEventCondition struct {
 	// Type of tag event condition, currently only ImportSuccess
@@ -186,6 +273,8 @@ type ImageStreamMapping struct {
 	// A Docker image.
 	Image Image
 	// A string value this image can be located with inside the repository.
+	// If empty, the image must have a schema 2 manifest or be a manifest list,
+	// and it is created without being tagged.
 	Tag string
 }
 
@@ -22,6 +22,8 @@ func convert_api_Image_To_v1_Image(in *newer.Image, out *Image, s conversion.Sco
 
 	out.DockerImageReference = in.DockerImageReference
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 
 	gvString := in.DockerImageMetadataVersion
 	if len(gvString) == 0 {
@@ -53,6 +55,17 @@ func convert_api_Image_To_v1_Image(in *newer.Image, out *Image, s conversion.Sco
 		out.DockerImageLayers = nil
 	}
 
+	if in.Signatures != nil {
+		out.Signatures = make([]ImageSignature, len(in.Signatures))
+		for i := range in.Signatures {
+			if err := s.Convert(&in.Signatures[i], &out.Signatures[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Signatures = nil
+	}
+
 	return nil
 }
 
@@ -63,6 +76,8 @@ func convert_v1_Image_To_api_Image(in *Image, out *newer.Image, s conversion.Sco
 
 	out.DockerImageReference = in.DockerImageReference
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 
 	version := in.DockerImageMetadataVersion
 	if len(version) == 0 {
@@ -94,12 +109,29 @@ func convert_v1_Image_To_api_Image(in *Image, out *newer.Image, s conversion.Sco
 		out.DockerImageLayers = nil
 	}
 
+	if in.Signatures != nil {
+		out.Signatures = make([]newer.ImageSignature, len(in.Signatures))
+		for i := range in.Signatures {
+			if err := s.Convert(&in.Signatures[i], &out.Signatures[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Signatures = nil
+	}
+
 	return nil
 }
 
 func convert_v1_ImageStreamSpec_To_api_ImageStreamSpec(in *ImageStreamSpec, out *newer.ImageStreamSpec, s conversion.Scope) error {
 	out.DockerImageRepository = in.DockerImageRepository
 	out.Tags = make(map[string]newer.TagReference)
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.Notifications, &out.Notifications, 0); err != nil {
+		return err
+	}
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
 
@@ -115,6 +147,12 @@ func convert_api_ImageStreamSpec_To_v1_ImageStreamSpec(in *newer.ImageStreamSpec
 		}
 	}
 	out.Tags = make([]TagReference, 0, 0)
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.Notifications, &out.Notifications, 0); err != nil {
+		return err
+	}
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
 
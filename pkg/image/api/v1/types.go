@@ -28,8 +28,26 @@ type Image struct {
 	DockerImageMetadataVersion string `json:"dockerImageMetadataVersion,omitempty" description:"conveys version of the object, if empty defaults to '1.0'"`
 	// DockerImageManifest is the raw JSON of the manifest
 	DockerImageManifest string `json:"dockerImageManifest,omitempty" description:"raw JSON of the manifest"`
+	// DockerImageManifestMediaType specifies the mediaType of the manifest. Empty for schema 1 manifests.
+	DockerImageManifestMediaType string `json:"dockerImageManifestMediaType,omitempty" description:"media type of the manifest, empty for schema 1 manifests"`
+	// DockerImageConfig is the raw JSON of the image configuration blob referenced by a schema 2 manifest.
+	DockerImageConfig string `json:"dockerImageConfig,omitempty" description:"raw JSON of the image configuration referenced by a schema 2 manifest"`
 	// DockerImageLayers represents the layers in the image. May not be set if the image does not define that data.
 	DockerImageLayers []ImageLayer `json:"dockerImageLayers" description:"a list of the image layers from lowest to highest"`
+	// Signatures holds all signatures of the image.
+	Signatures []ImageSignature `json:"signatures,omitempty" description:"all signatures of the image"`
+}
+
+// ImageSignature holds a detached signature of an image. The name of a signature has the form
+// <image name>@<signature name>.
+type ImageSignature struct {
+	unversioned.TypeMeta `json:",inline"`
+	kapi.ObjectMeta      `json:"metadata,omitempty"`
+
+	// Type describes the format of the signature.
+	Type string `json:"type" description:"format of the signature, for example x509"`
+	// Content is the opaque signature of the image.
+	Content []byte `json:"content" description:"opaque signature of the image"`
 }
 
 // ImageLayer represents a single layer of the image. Some images may have multiple layers. Some may have none.
@@ -68,6 +86,29 @@ type ImageStreamSpec struct {
 	DockerImageRepository string `json:"dockerImageRepository,omitempty" description:"optional field if specified this stream is backed by a Docker repository on this server"`
 	// Tags map arbitrary string values to specific image locators
 	Tags []TagReference `json:"tags,omitempty" description:"map arbitrary string values to specific image locators"`
+	// RetentionPolicy, if set, controls which images referenced by this stream are pruned
+	RetentionPolicy *ImageStreamRetentionPolicy `json:"retentionPolicy,omitempty" description:"controls which images referenced by this stream are pruned"`
+	// Notifications are the endpoints that are notified when a tag of this stream is updated
+	Notifications []ImageStreamNotification `json:"notifications,omitempty" description:"endpoints that are notified when a tag of this stream is updated"`
+}
+
+// ImageStreamNotification is an endpoint that receives an HTTP POST every time a tag of an image stream points to a new image.
+type ImageStreamNotification struct {
+	// URL is the http or https URL the notifications are sent to
+	URL string `json:"url" description:"http or https URL the notifications are sent to"`
+	// SecretName is the name of the secret in the namespace of the image stream whose "secret" entry is used to sign the body of the notifications with HMAC-SHA256
+	SecretName string `json:"secretName" description:"name of the secret in the namespace of the image stream whose secret entry is used to sign the body of the notifications with HMAC-SHA256"`
+}
+
+// ImageStreamRetentionPolicy controls which images referenced by an image stream are pruned.
+type ImageStreamRetentionPolicy struct {
+	// KeepTagRevisions is the number of most recent revisions of each tag that are kept
+	KeepTagRevisions int `json:"keepTagRevisions" description:"number of most recent revisions of each tag that are kept; the current revision of a tag is always kept"`
+	// KeepYoungerThanSeconds keeps older revisions of a tag that were tagged less than this number of seconds ago.
+	// Images pushed to the stream that no tag references anymore are deleted once they are older than this.
+	KeepYoungerThanSeconds int64 `json:"keepYoungerThanSeconds,omitempty" description:"older revisions of a tag that were tagged less than this number of seconds ago are kept; images pushed to the stream that no tag references are deleted once older than this"`
+	// ProtectedTags are the tags whose revisions are never pruned
+	ProtectedTags []string `json:"protectedTags,omitempty" description:"tags whose revisions are never pruned"`
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to an ImageStreamTag, ImageStreamImage, or DockerImage this tag should track.
@@ -157,7 +198,9 @@ type ImageStreamMapping struct {
 	// Image is a Docker image.
 	Image Image `json:"image" description:"a Docker image"`
 	// Tag is a string value this image can be located with inside the stream.
-	Tag string `json:"tag" description:"string value this image can be located with inside the stream"`
+	// If empty, the image must have a schema 2 manifest or be a manifest list,
+	// and it is created without being tagged.
+	Tag string `json:"tag" description:"string value this image can be located with inside the stream; if empty, an image with a schema 2 manifest or a manifest list is created without being tagged"`
 }
 
 // ImageStreamTag represents an Image that is retrieved by tag name from an ImageStream.
@@ -21,6 +21,8 @@ func convert_api_Image_To_v1beta3_Image(in *newer.Image, out *Image, s conversio
 
 	out.DockerImageReference = in.DockerImageReference
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 
 	gvString := in.DockerImageMetadataVersion
 	if len(gvString) == 0 {
@@ -51,6 +53,8 @@ func convert_v1beta3_Image_To_api_Image(in *Image, out *newer.Image, s conversio
 
 	out.DockerImageReference = in.DockerImageReference
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 
 	version := in.DockerImageMetadataVersion
 	if len(version) == 0 {
@@ -86,12 +90,24 @@ func convert_v1beta3_ImageStreamSpec_To_api_ImageStreamSpec(in *ImageStreamSpec,
 		}
 	}
 	out.Tags = make(map[string]newer.TagReference)
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.Notifications, &out.Notifications, 0); err != nil {
+		return err
+	}
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
 
 func convert_api_ImageStreamSpec_To_v1beta3_ImageStreamSpec(in *newer.ImageStreamSpec, out *ImageStreamSpec, s conversion.Scope) error {
 	out.DockerImageRepository = in.DockerImageRepository
 	out.Tags = make([]TagReference, 0, 0)
+	if err := s.Convert(&in.RetentionPolicy, &out.RetentionPolicy, 0); err != nil {
+		return err
+	}
+	if err := s.Convert(&in.Notifications, &out.Notifications, 0); err != nil {
+		return err
+	}
 	return s.Convert(&in.Tags, &out.Tags, 0)
 }
 
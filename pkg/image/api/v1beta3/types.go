@@ -27,6 +27,10 @@ type Image struct {
 	DockerImageMetadataVersion string `json:"dockerImageMetadataVersion,omitempty"`
 	// The raw JSON of the manifest
 	DockerImageManifest string `json:"dockerImageManifest,omitempty"`
+	// DockerImageManifestMediaType specifies the mediaType of the manifest. Empty for schema 1 manifests.
+	DockerImageManifestMediaType string `json:"dockerImageManifestMediaType,omitempty" description:"media type of the manifest, empty for schema 1 manifests"`
+	// DockerImageConfig is the raw JSON of the image configuration blob referenced by a schema 2 manifest.
+	DockerImageConfig string `json:"dockerImageConfig,omitempty" description:"raw JSON of the image configuration referenced by a schema 2 manifest"`
 	// DockerImageLayers represents the layers in the image. May not be set if the image does not define that data.
 	DockerImageLayers []ImageLayer `json:"dockerImageLayers" description:"a list of the image layers from lowest to highest"`
 }
@@ -66,6 +70,29 @@ type ImageStreamSpec struct {
 	DockerImageRepository string `json:"dockerImageRepository,omitempty"`
 	// Tags map arbitrary string values to specific image locators
 	Tags []TagReference `json:"tags,omitempty"`
+	// RetentionPolicy, if set, controls which images referenced by this stream are pruned
+	RetentionPolicy *ImageStreamRetentionPolicy `json:"retentionPolicy,omitempty"`
+	// Notifications are the endpoints that are notified when a tag of this stream is updated
+	Notifications []ImageStreamNotification `json:"notifications,omitempty"`
+}
+
+// ImageStreamNotification is an endpoint that receives an HTTP POST every time a tag of an image stream points to a new image.
+type ImageStreamNotification struct {
+	// URL is the http or https URL the notifications are sent to
+	URL string `json:"url"`
+	// SecretName is the name of the secret in the namespace of the image stream whose "secret" entry is used to sign the body of the notifications with HMAC-SHA256
+	SecretName string `json:"secretName"`
+}
+
+// ImageStreamRetentionPolicy controls which images referenced by an image stream are pruned.
+type ImageStreamRetentionPolicy struct {
+	// KeepTagRevisions is the number of most recent revisions of each tag that are kept
+	KeepTagRevisions int `json:"keepTagRevisions"`
+	// KeepYoungerThanSeconds keeps older revisions of a tag that were tagged less than this number of seconds ago.
+	// Images pushed to the stream that no tag references anymore are deleted once they are older than this.
+	KeepYoungerThanSeconds int64 `json:"keepYoungerThanSeconds,omitempty"`
+	// ProtectedTags are the tags whose revisions are never pruned
+	ProtectedTags []string `json:"protectedTags,omitempty"`
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to an ImageStreamTag, ImageStreamImage, or DockerImage this tag should track.
@@ -152,6 +179,8 @@ type ImageStreamMapping struct {
 	// A Docker image.
 	Image Image `json:"image"`
 	// A string value this image can be located with inside the repository.
+	// If empty, the image must have a schema 2 manifest or be a manifest list,
+	// and it is created without being tagged.
 	Tag string `json:"tag"`
 }
 
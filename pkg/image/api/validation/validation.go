@@ -2,11 +2,14 @@ package validation
 
 import (
 	"fmt"
+	"net/url"
 	"regexp"
+	"strconv"
 
 	"github.com/docker/distribution/reference"
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
+	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	oapi "github.com/openshift/origin/pkg/api"
@@ -56,6 +59,41 @@ func validateImage(image *api.Image, fldPath *field.Path) field.ErrorList {
 		}
 	}
 
+	names := sets.NewString()
+	for i := range image.Signatures {
+		signature := &image.Signatures[i]
+		signaturePath := fldPath.Child("signatures").Index(i)
+		result = append(result, validateImageSignature(signature, signaturePath)...)
+		if imageName, _, ok := api.SplitImageSignatureName(signature.Name); ok && imageName != image.Name {
+			result = append(result, field.Invalid(signaturePath.Child("metadata", "name"), signature.Name, fmt.Sprintf("must be prefixed with the image name %q", image.Name+"@")))
+		}
+		if names.Has(signature.Name) {
+			result = append(result, field.Duplicate(signaturePath.Child("metadata", "name"), signature.Name))
+		}
+		names.Insert(signature.Name)
+	}
+
+	return result
+}
+
+// ValidateImageSignature tests required fields for an ImageSignature.
+func ValidateImageSignature(signature *api.ImageSignature) field.ErrorList {
+	return validateImageSignature(signature, nil)
+}
+
+func validateImageSignature(signature *api.ImageSignature, fldPath *field.Path) field.ErrorList {
+	result := validation.ValidateObjectMeta(&signature.ObjectMeta, false, oapi.MinimalNameRequirements, fldPath.Child("metadata"))
+	if len(signature.Name) > 0 {
+		if _, _, ok := api.SplitImageSignatureName(signature.Name); !ok {
+			result = append(result, field.Invalid(fldPath.Child("metadata", "name"), signature.Name, "must be of the form <image name>@<signature name>"))
+		}
+	}
+	if len(signature.Type) == 0 {
+		result = append(result, field.Required(fldPath.Child("type"), ""))
+	}
+	if len(signature.Content) == 0 {
+		result = append(result, field.Required(fldPath.Child("content"), ""))
+	}
 	return result
 }
 
@@ -66,6 +104,49 @@ func ValidateImageUpdate(newImage, oldImage *api.Image) field.ErrorList {
 	return result
 }
 
+// validateRetentionPolicy validates the retention policy of an image stream.
+func validateRetentionPolicy(policy *api.ImageStreamRetentionPolicy, fldPath *field.Path) field.ErrorList {
+	result := field.ErrorList{}
+	if policy.KeepTagRevisions < 1 {
+		result = append(result, field.Invalid(fldPath.Child("keepTagRevisions"), policy.KeepTagRevisions, "must be at least 1"))
+	}
+	if policy.KeepYoungerThanSeconds < 0 {
+		result = append(result, field.Invalid(fldPath.Child("keepYoungerThanSeconds"), policy.KeepYoungerThanSeconds, "must be a non-negative number of seconds"))
+	}
+	tags := sets.NewString()
+	for i, tag := range policy.ProtectedTags {
+		switch {
+		case len(tag) == 0:
+			result = append(result, field.Required(fldPath.Child("protectedTags").Index(i), ""))
+		case tags.Has(tag):
+			result = append(result, field.Duplicate(fldPath.Child("protectedTags").Index(i), tag))
+		}
+		tags.Insert(tag)
+	}
+	return result
+}
+
+// validateNotifications validates the notification endpoints of an image stream.
+func validateNotifications(notifications []api.ImageStreamNotification, fldPath *field.Path) field.ErrorList {
+	result := field.ErrorList{}
+	for i, notification := range notifications {
+		idxPath := fldPath.Index(i)
+		if len(notification.URL) == 0 {
+			result = append(result, field.Required(idxPath.Child("url"), ""))
+		} else if u, err := url.Parse(notification.URL); err != nil {
+			result = append(result, field.Invalid(idxPath.Child("url"), notification.URL, err.Error()))
+		} else if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+			result = append(result, field.Invalid(idxPath.Child("url"), notification.URL, "must be an absolute http or https URL"))
+		}
+		if len(notification.SecretName) == 0 {
+			result = append(result, field.Required(idxPath.Child("secretName"), ""))
+		} else if ok, msg := validation.ValidateSecretName(notification.SecretName, false); !ok {
+			result = append(result, field.Invalid(idxPath.Child("secretName"), notification.SecretName, msg))
+		}
+	}
+	return result
+}
+
 // ValidateImageStream tests required fields for an ImageStream.
 func ValidateImageStream(stream *api.ImageStream) field.ErrorList {
 	result := validation.ValidateObjectMeta(&stream.ObjectMeta, true, ValidateImageStreamName, field.NewPath("metadata"))
@@ -104,6 +185,15 @@ func ValidateImageStream(stream *api.ImageStream) field.ErrorList {
 			}
 		}
 	}
+	if stream.Spec.RetentionPolicy != nil {
+		result = append(result, validateRetentionPolicy(stream.Spec.RetentionPolicy, field.NewPath("spec", "retentionPolicy"))...)
+	}
+	result = append(result, validateNotifications(stream.Spec.Notifications, field.NewPath("spec", "notifications"))...)
+	if value, ok := stream.Annotations[api.ScheduledImportIntervalAnnotation]; ok {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err != nil || seconds <= 0 {
+			result = append(result, field.Invalid(field.NewPath("metadata", "annotations").Key(api.ScheduledImportIntervalAnnotation), value, "must be a positive number of seconds"))
+		}
+	}
 	for tag, history := range stream.Status.Tags {
 		for i, tagEvent := range history.Items {
 			if len(tagEvent.DockerImageReference) == 0 {
@@ -148,7 +238,9 @@ func ValidateImageStreamMapping(mapping *api.ImageStreamMapping) field.ErrorList
 	if ok, msg := validation.ValidateNamespaceName(mapping.Namespace, false); !ok {
 		result = append(result, field.Invalid(field.NewPath("metadata", "namespace"), mapping.Namespace, msg))
 	}
-	if len(mapping.Tag) == 0 {
+	// only images which schema 1 clients can not reference by tag, like the images of a manifest
+	// list, may be mapped without a tag
+	if len(mapping.Tag) == 0 && len(mapping.Image.DockerImageManifestMediaType) == 0 {
 		result = append(result, field.Required(field.NewPath("tag"), ""))
 	}
 	if errs := validateImage(&mapping.Image, field.NewPath("image")); len(errs) != 0 {
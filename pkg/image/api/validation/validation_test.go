@@ -7,6 +7,7 @@ import (
 
 	"github.com/openshift/origin/pkg/image/api"
 	kapi "k8s.io/kubernetes/pkg/api"
+	kvalidation "k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/util"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 )
@@ -47,6 +48,45 @@ func TestValidateImageMissingFields(t *testing.T) {
 			field.ErrorTypeRequired,
 			"dockerImageReference",
 		},
+		"signature of another image": {
+			api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo"},
+				DockerImageReference: "ref",
+				Signatures:           []api.ImageSignature{{ObjectMeta: kapi.ObjectMeta{Name: "bar@sig"}, Type: api.ImageSignatureTypeX509, Content: []byte("data")}},
+			},
+			field.ErrorTypeInvalid,
+			"signatures[0].metadata.name",
+		},
+		"signature without a signature name": {
+			api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo"},
+				DockerImageReference: "ref",
+				Signatures:           []api.ImageSignature{{ObjectMeta: kapi.ObjectMeta{Name: "foo"}, Type: api.ImageSignatureTypeX509, Content: []byte("data")}},
+			},
+			field.ErrorTypeInvalid,
+			"signatures[0].metadata.name",
+		},
+		"signature without content": {
+			api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo"},
+				DockerImageReference: "ref",
+				Signatures:           []api.ImageSignature{{ObjectMeta: kapi.ObjectMeta{Name: "foo@sig"}, Type: api.ImageSignatureTypeX509}},
+			},
+			field.ErrorTypeRequired,
+			"signatures[0].content",
+		},
+		"duplicate signature": {
+			api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo"},
+				DockerImageReference: "ref",
+				Signatures: []api.ImageSignature{
+					{ObjectMeta: kapi.ObjectMeta{Name: "foo@sig"}, Type: api.ImageSignatureTypeX509, Content: []byte("data")},
+					{ObjectMeta: kapi.ObjectMeta{Name: "foo@sig"}, Type: api.ImageSignatureTypeX509, Content: []byte("data")},
+				},
+			},
+			field.ErrorTypeDuplicate,
+			"signatures[1].metadata.name",
+		},
 	}
 
 	for k, v := range errorCases {
@@ -193,6 +233,9 @@ func TestValidateImageStream(t *testing.T) {
 		dockerImageRepository string
 		specTags              map[string]api.TagReference
 		statusTags            map[string]api.TagEventList
+		retentionPolicy       *api.ImageStreamRetentionPolicy
+		notifications         []api.ImageStreamNotification
+		annotations           map[string]string
 		expected              field.ErrorList
 	}{
 		"missing name": {
@@ -357,6 +400,69 @@ func TestValidateImageStream(t *testing.T) {
 			},
 			expected: field.ErrorList{},
 		},
+		"valid retention policy": {
+			namespace: "namespace",
+			name:      "foo",
+			retentionPolicy: &api.ImageStreamRetentionPolicy{
+				KeepTagRevisions:       3,
+				KeepYoungerThanSeconds: 3600,
+				ProtectedTags:          []string{"stable"},
+			},
+			expected: field.ErrorList{},
+		},
+		"invalid retention policy": {
+			namespace: "namespace",
+			name:      "foo",
+			retentionPolicy: &api.ImageStreamRetentionPolicy{
+				KeepTagRevisions:       0,
+				KeepYoungerThanSeconds: -1,
+				ProtectedTags:          []string{"stable", "", "stable"},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "retentionPolicy", "keepTagRevisions"), 0, "must be at least 1"),
+				field.Invalid(field.NewPath("spec", "retentionPolicy", "keepYoungerThanSeconds"), int64(-1), "must be a non-negative number of seconds"),
+				field.Required(field.NewPath("spec", "retentionPolicy", "protectedTags").Index(1), ""),
+				field.Duplicate(field.NewPath("spec", "retentionPolicy", "protectedTags").Index(2), "stable"),
+			},
+		},
+		"valid notifications": {
+			namespace: "namespace",
+			name:      "foo",
+			notifications: []api.ImageStreamNotification{
+				{URL: "https://ci.example.com/hooks/images", SecretName: "hooks"},
+			},
+			expected: field.ErrorList{},
+		},
+		"invalid notifications": {
+			namespace: "namespace",
+			name:      "foo",
+			notifications: []api.ImageStreamNotification{
+				{SecretName: "hooks"},
+				{URL: "ftp://ci.example.com/hooks", SecretName: "hooks"},
+				{URL: "https://ci.example.com/hooks"},
+				{URL: "https://ci.example.com/hooks", SecretName: "Hooks"},
+			},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "notifications").Index(0).Child("url"), ""),
+				field.Invalid(field.NewPath("spec", "notifications").Index(1).Child("url"), "ftp://ci.example.com/hooks", "must be an absolute http or https URL"),
+				field.Required(field.NewPath("spec", "notifications").Index(2).Child("secretName"), ""),
+				field.Invalid(field.NewPath("spec", "notifications").Index(3).Child("secretName"), "Hooks", kvalidation.DNSSubdomainErrorMsg),
+			},
+		},
+		"valid scheduled import interval": {
+			namespace:   "namespace",
+			name:        "foo",
+			annotations: map[string]string{api.ScheduledImportIntervalAnnotation: "3600"},
+			expected:    field.ErrorList{},
+		},
+		"invalid scheduled import interval": {
+			namespace:   "namespace",
+			name:        "foo",
+			annotations: map[string]string{api.ScheduledImportIntervalAnnotation: "1h"},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("metadata", "annotations").Key(api.ScheduledImportIntervalAnnotation), "1h", "must be a positive number of seconds"),
+			},
+		},
 		"shortest name components": {
 			namespace: "f",
 			name:      "g",
@@ -384,12 +490,15 @@ func TestValidateImageStream(t *testing.T) {
 	for name, test := range tests {
 		stream := api.ImageStream{
 			ObjectMeta: kapi.ObjectMeta{
-				Namespace: test.namespace,
-				Name:      test.name,
+				Namespace:   test.namespace,
+				Name:        test.name,
+				Annotations: test.annotations,
 			},
 			Spec: api.ImageStreamSpec{
 				DockerImageRepository: test.dockerImageRepository,
 				Tags: test.specTags,
+				RetentionPolicy:       test.retentionPolicy,
+				Notifications:         test.notifications,
 			},
 			Status: api.ImageStreamStatus{
 				Tags: test.statusTags,
@@ -0,0 +1,89 @@
+package controller
+
+import (
+	"time"
+
+	"k8s.io/kubernetes/pkg/util/wait"
+
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+// registryBackoff delays the scheduled imports from registries that could not be reached. Each
+// consecutive failure doubles the delay up to a maximum, and a random jitter is added to the delay so
+// that the streams importing from a registry do not all retry at once when it comes back.
+type registryBackoff struct {
+	initial time.Duration
+	max     time.Duration
+	jitter  float64
+
+	registries map[string]*registryFailure
+}
+
+// registryFailure records the consecutive failures to reach a registry.
+type registryFailure struct {
+	count   int
+	retryAt time.Time
+}
+
+// newRegistryBackoff returns a registryBackoff delaying imports by initial after the first failure,
+// and by at most max.
+func newRegistryBackoff(initial, max time.Duration) *registryBackoff {
+	if max < initial {
+		max = initial
+	}
+	return &registryBackoff{
+		initial:    initial,
+		max:        max,
+		jitter:     0.2,
+		registries: make(map[string]*registryFailure),
+	}
+}
+
+// delayed returns true if imports from registry should not be attempted at now.
+func (b *registryBackoff) delayed(registry string, now time.Time) bool {
+	failure, ok := b.registries[registry]
+	return ok && now.Before(failure.retryAt)
+}
+
+// failed records a failure to reach registry at now and returns how long imports from it are delayed.
+func (b *registryBackoff) failed(registry string, now time.Time) time.Duration {
+	failure, ok := b.registries[registry]
+	if !ok {
+		failure = &registryFailure{}
+		b.registries[registry] = failure
+	}
+	failure.count++
+
+	delay := b.initial
+	for i := 1; i < failure.count && delay < b.max; i++ {
+		delay *= 2
+	}
+	if delay > b.max {
+		delay = b.max
+	}
+	delay = wait.Jitter(delay, b.jitter)
+	failure.retryAt = now.Add(delay)
+	return delay
+}
+
+// succeeded clears the failures recorded for registry.
+func (b *registryBackoff) succeeded(registry string) {
+	delete(b.registries, registry)
+}
+
+// tagRegistry returns the registry a tag is imported from.
+func tagRegistry(tagRef api.TagReference) string {
+	if tagRef.From == nil {
+		return ""
+	}
+	return imageRegistry(tagRef.From.Name)
+}
+
+// imageRegistry returns the registry of a Docker pull spec, or the pull spec itself if it is invalid.
+func imageRegistry(pullSpec string) string {
+	ref, err := api.ParseDockerImageReference(pullSpec)
+	if err != nil {
+		return pullSpec
+	}
+	return ref.DockerClientDefaults().Registry
+}
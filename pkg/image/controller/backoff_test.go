@@ -0,0 +1,49 @@
+package controller
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRegistryBackoff(t *testing.T) {
+	b := newRegistryBackoff(time.Minute, 5*time.Minute)
+	now := time.Now()
+
+	for i, expected := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute} {
+		delay := b.failed("registry", now)
+		if delay < expected || delay > expected+expected/5 {
+			t.Errorf("%d: expected a delay between %s and %s, got %s", i, expected, expected+expected/5, delay)
+		}
+		if !b.delayed("registry", now.Add(expected-time.Second)) {
+			t.Errorf("%d: expected the registry to be delayed", i)
+		}
+		if b.delayed("registry", now.Add(delay)) {
+			t.Errorf("%d: expected the delay to expire", i)
+		}
+	}
+	if b.delayed("other", now) {
+		t.Errorf("expected other registries not to be delayed")
+	}
+
+	b.succeeded("registry")
+	if b.delayed("registry", now) {
+		t.Errorf("expected the registry not to be delayed after a success")
+	}
+	if delay := b.failed("registry", now); delay > time.Minute+time.Minute/5 {
+		t.Errorf("expected the backoff to be reset, got %s", delay)
+	}
+}
+
+func TestImageRegistry(t *testing.T) {
+	tests := map[string]string{
+		"mysql":                             "docker.io",
+		"openshift/origin:latest":           "docker.io",
+		"registry.example.com:5000/app/web": "registry.example.com:5000",
+		"@invalid":                          "@invalid",
+	}
+	for pullSpec, expected := range tests {
+		if registry := imageRegistry(pullSpec); registry != expected {
+			t.Errorf("%s: expected %s, got %s", pullSpec, expected, registry)
+		}
+	}
+}
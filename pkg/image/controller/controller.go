@@ -90,15 +90,23 @@ func needsScheduling(stream *api.ImageStream) bool {
 	return false
 }
 
-// resetScheduledTags artificially increments the generation on the tags that should be imported.
-func resetScheduledTags(stream *api.ImageStream) {
+// resetScheduledTags artificially increments the generation on the tags that should be imported. If
+// include is not nil, only the scheduled tags it returns true for are reset. Returns true if any tag was
+// reset.
+func resetScheduledTags(stream *api.ImageStream, include func(api.TagReference) bool) bool {
 	next := stream.Generation + 1
+	reset := false
 	for tag, tagRef := range stream.Spec.Tags {
 		if tagImportable(tagRef) && tagRef.ImportPolicy.Scheduled {
+			if include != nil && !include(tagRef) {
+				continue
+			}
 			tagRef.Generation = &next
 			stream.Spec.Tags[tag] = tagRef
+			reset = true
 		}
 	}
+	return reset
 }
 
 // retryCount is the number of times to retry on a conflict when updating an image stream
@@ -125,9 +133,17 @@ const retryCount = 2
 //
 // Notifier, if passed, will be invoked if the stream is going to be imported.
 func (c *ImportController) Next(stream *api.ImageStream, notifier Notifier) error {
+	_, err := c.next(stream, notifier, nil)
+	return err
+}
+
+// next imports the stream as described by Next and returns the result of the import, which is nil if the
+// stream did not need to be imported. If include is not nil, only the tags it returns true for are
+// imported.
+func (c *ImportController) next(stream *api.ImageStream, notifier Notifier, include func(api.TagReference) bool) (*api.ImageStreamImport, error) {
 	ok, partial := needsImport(stream)
 	if !ok {
-		return nil
+		return nil, nil
 	}
 	glog.V(3).Infof("Importing stream %s/%s partial=%t...", stream.Namespace, stream.Name, partial)
 
@@ -148,6 +164,9 @@ func (c *ImportController) Next(stream *api.ImageStream, notifier Notifier) erro
 		if !(partial && tagImportable(tagRef)) && !tagNeedsImport(stream, tag, tagRef, true) {
 			continue
 		}
+		if include != nil && !include(tagRef) {
+			continue
+		}
 		isi.Spec.Images = append(isi.Spec.Images, api.ImageImportSpec{
 			From:         kapi.ObjectReference{Kind: "DockerImage", Name: tagRef.From.Name},
 			To:           &kapi.LocalObjectReference{Name: tag},
@@ -161,16 +180,19 @@ func (c *ImportController) Next(stream *api.ImageStream, notifier Notifier) erro
 			ImportPolicy: api.TagImportPolicy{Insecure: insecure},
 		}
 	}
+	if include != nil && len(isi.Spec.Images) == 0 && isi.Spec.Repository == nil {
+		return nil, nil
+	}
 	result, err := c.streams.ImageStreams(stream.Namespace).Import(isi)
 	if err != nil {
 		if apierrs.IsNotFound(err) && client.IsStatusErrorKind(err, "imageStream") {
-			return ErrNotImportable
+			return nil, ErrNotImportable
 		}
 		glog.V(4).Infof("Import stream %s/%s partial=%t error: %v", stream.Namespace, stream.Name, partial, err)
-	} else {
-		glog.V(5).Infof("Import stream %s/%s partial=%t import: %#v", stream.Namespace, stream.Name, partial, result.Status.Import)
+		return nil, err
 	}
-	return err
+	glog.V(5).Infof("Import stream %s/%s partial=%t import: %#v", stream.Namespace, stream.Name, partial, result.Status.Import)
+	return result, nil
 }
 
 func (c *ImportController) NextTimedByName(namespace, name string) error {
@@ -185,12 +207,21 @@ func (c *ImportController) NextTimedByName(namespace, name string) error {
 }
 
 func (c *ImportController) NextTimed(stream *api.ImageStream) error {
+	_, err := c.nextTimed(stream, nil)
+	return err
+}
+
+// nextTimed imports the scheduled tags of the stream that include returns true for, or all the scheduled
+// tags if include is nil, and returns the result of the import.
+func (c *ImportController) nextTimed(stream *api.ImageStream, include func(api.TagReference) bool) (*api.ImageStreamImport, error) {
 	if !needsScheduling(stream) {
-		return ErrNotImportable
+		return nil, ErrNotImportable
+	}
+	if !resetScheduledTags(stream, include) {
+		return nil, nil
 	}
-	resetScheduledTags(stream)
 
 	glog.V(3).Infof("Scheduled import of stream %s/%s...", stream.Namespace, stream.Name)
 
-	return c.Next(stream, nil)
+	return c.next(stream, nil, include)
 }
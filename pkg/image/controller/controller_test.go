@@ -2,12 +2,16 @@ package controller
 
 import (
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	apierrs "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
 
 	client "github.com/openshift/origin/pkg/client/testclient"
@@ -330,7 +334,7 @@ func TestControllerExternalRepo(t *testing.T) {
 
 func TestScheduledImport(t *testing.T) {
 	fake := &client.Fake{}
-	b := newScheduled(true, fake, 1, nil, nil)
+	b := newScheduled(true, fake, 1, nil, nil, time.Minute)
 
 	one := int64(1)
 	stream := &api.ImageStream{
@@ -424,3 +428,102 @@ func TestScheduledImport(t *testing.T) {
 		t.Fatalf("should have left scheduled: %#v", b.scheduler)
 	}
 }
+
+func TestScheduledImportBackoff(t *testing.T) {
+	one := int64(1)
+	stream := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: "test", Namespace: "other", UID: "1", ResourceVersion: "1",
+			Annotations: map[string]string{api.DockerImageRepositoryCheckAnnotation: "done"},
+			Generation:  1,
+		},
+		Spec: api.ImageStreamSpec{
+			Tags: map[string]api.TagReference{
+				"up": {
+					From:         &kapi.ObjectReference{Kind: "DockerImage", Name: "mysql:latest"},
+					Generation:   &one,
+					ImportPolicy: api.TagImportPolicy{Scheduled: true},
+				},
+				"down": {
+					From:         &kapi.ObjectReference{Kind: "DockerImage", Name: "down.example.com/mysql:latest"},
+					Generation:   &one,
+					ImportPolicy: api.TagImportPolicy{Scheduled: true},
+				},
+			},
+		},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{
+				"up":   {Items: []api.TagEvent{{Generation: 1}}},
+				"down": {Items: []api.TagEvent{{Generation: 1}}},
+			},
+		},
+	}
+
+	var imported []string
+	fake := &client.Fake{}
+	fake.AddReactor("get", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		copied, err := kapi.Scheme.DeepCopy(stream)
+		return true, copied.(runtime.Object), err
+	})
+	fake.AddReactor("create", "imagestreamimports", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		isi := action.(ktestclient.CreateAction).GetObject().(*api.ImageStreamImport)
+		imported = []string{}
+		for _, image := range isi.Spec.Images {
+			imported = append(imported, image.From.Name)
+			status := api.ImageImportStatus{Status: unversioned.Status{Status: unversioned.StatusSuccess}, Image: &api.Image{}}
+			if image.From.Name == "down.example.com/mysql:latest" {
+				status = api.ImageImportStatus{Status: unversioned.Status{Status: unversioned.StatusFailure, Reason: api.ImportFailedRegistryUnavailable}}
+			}
+			isi.Status.Images = append(isi.Status.Images, status)
+		}
+		return true, isi, nil
+	})
+
+	now := time.Now()
+	b := newScheduled(true, fake, 1, nil, nil, time.Minute)
+	b.now = func() time.Time { return now }
+	key := "other/test"
+
+	// both registries are imported from the first time
+	b.HandleTimed(key, nil)
+	sort.Strings(imported)
+	if !reflect.DeepEqual(imported, []string{"down.example.com/mysql:latest", "mysql:latest"}) {
+		t.Fatalf("unexpected imports: %v", imported)
+	}
+
+	// the unavailable registry is backed off
+	now = now.Add(time.Minute / 2)
+	b.HandleTimed(key, nil)
+	if !reflect.DeepEqual(imported, []string{"mysql:latest"}) {
+		t.Fatalf("unexpected imports: %v", imported)
+	}
+
+	// and retried once the backoff expires, which doubles it
+	now = now.Add(time.Minute)
+	b.HandleTimed(key, nil)
+	if len(imported) != 2 {
+		t.Fatalf("unexpected imports: %v", imported)
+	}
+	if !b.backoff.delayed("down.example.com", now.Add(time.Minute+time.Second)) {
+		t.Fatalf("expected the backoff to double: %#v", b.backoff.registries["down.example.com"])
+	}
+
+	// a stream with a longer interval is not imported until the interval elapses
+	stream.Annotations[api.ScheduledImportIntervalAnnotation] = "3600"
+	now = now.Add(3 * time.Minute)
+	b.HandleTimed(key, nil)
+	if len(imported) != 2 {
+		t.Fatalf("unexpected imports: %v", imported)
+	}
+	imported = nil
+	now = now.Add(30 * time.Minute)
+	b.HandleTimed(key, nil)
+	if imported != nil {
+		t.Fatalf("should not have imported before the interval elapsed: %v", imported)
+	}
+	now = now.Add(time.Hour)
+	b.HandleTimed(key, nil)
+	if len(imported) != 2 {
+		t.Fatalf("unexpected imports: %v", imported)
+	}
+}
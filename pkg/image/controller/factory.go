@@ -1,14 +1,17 @@
 package controller
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/golang/glog"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	apierrs "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/client/cache"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/util/wait"
 	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/openshift/origin/pkg/client"
@@ -50,7 +53,7 @@ func (f *ImportControllerFactory) Create() (controller.RunnableController, contr
 	bucketQPS := 1.0 / float32(seconds) * float32(buckets)
 
 	limiter := util.NewTokenBucketRateLimiter(bucketQPS, 1)
-	b := newScheduled(f.ScheduleEnabled, f.Client, buckets, limiter, f.ImportRateLimiter)
+	b := newScheduled(f.ScheduleEnabled, f.Client, buckets, limiter, f.ImportRateLimiter, f.MinimumCheckInterval)
 
 	// instantiate an importer for changes that happen to the image stream
 	changed := &controller.RetryController{
@@ -75,6 +78,10 @@ type uniqueItem struct {
 	resourceVersion string
 }
 
+// maxScheduledImportBackoff is the longest time scheduled imports from an unreachable registry are
+// delayed, unless the minimum check interval is longer.
+const maxScheduledImportBackoff = 2 * time.Hour
+
 // scheduled watches for changes to image streams and adds them to the list of streams to be
 // periodically imported (later) or directly imported (now).
 type scheduled struct {
@@ -82,16 +89,34 @@ type scheduled struct {
 	scheduler   *controller.Scheduler
 	rateLimiter util.RateLimiter
 	controller  *ImportController
+
+	// minimumInterval is the interval at which the scheduler checks every stream.
+	minimumInterval time.Duration
+	// nextImport is the earliest time the streams that set an import interval longer than the minimum
+	// may be imported again. It is only accessed by HandleTimed.
+	nextImport map[string]time.Time
+	// backoff delays the imports from the registries that could not be reached. It is only accessed by
+	// HandleTimed.
+	backoff *registryBackoff
+	now     func() time.Time
 }
 
 // newScheduled initializes a scheduled import object and sets its scheduler. Limiter is optional.
-func newScheduled(enabled bool, client client.ImageStreamsNamespacer, buckets int, bucketLimiter, importLimiter util.RateLimiter) *scheduled {
+func newScheduled(enabled bool, client client.ImageStreamsNamespacer, buckets int, bucketLimiter, importLimiter util.RateLimiter, minimumInterval time.Duration) *scheduled {
+	maxBackoff := maxScheduledImportBackoff
+	if minimumInterval > maxBackoff {
+		maxBackoff = minimumInterval
+	}
 	b := &scheduled{
 		enabled:     enabled,
 		rateLimiter: importLimiter,
 		controller: &ImportController{
 			streams: client,
 		},
+		minimumInterval: minimumInterval,
+		nextImport:      make(map[string]time.Time),
+		backoff:         newRegistryBackoff(minimumInterval, maxBackoff),
+		now:             time.Now,
 	}
 	b.scheduler = controller.NewScheduler(buckets, bucketLimiter, b.HandleTimed)
 	return b
@@ -114,22 +139,80 @@ func (b *scheduled) HandleTimed(key, value interface{}) {
 		return
 	}
 	glog.V(5).Infof("DEBUG: checking %s", key)
+	now := b.now()
+	if next, ok := b.nextImport[key.(string)]; ok && now.Before(next) {
+		glog.V(5).Infof("DEBUG: %s is not due for import until %s", key, next)
+		return
+	}
 	if b.rateLimiter != nil && !b.rateLimiter.TryAccept() {
 		glog.V(5).Infof("DEBUG: check of %s exceeded rate limit, will retry later", key)
 		return
 	}
 	namespace, name, _ := cache.SplitMetaNamespaceKey(key.(string))
-	if err := b.controller.NextTimedByName(namespace, name); err != nil {
-		// the stream cannot be imported
-		if err == ErrNotImportable {
-			// value must match to be removed, so we avoid races against creation by ensuring that we only
-			// remove the stream if the uid and resource version in the scheduler are exactly the same.
-			b.scheduler.Remove(key, value)
+	stream, err := b.controller.streams.ImageStreams(namespace).Get(name)
+	if err == nil {
+		var result *api.ImageStreamImport
+		result, err = b.controller.nextTimed(stream, func(tagRef api.TagReference) bool {
+			return !b.backoff.delayed(tagRegistry(tagRef), now)
+		})
+		if err == nil {
+			b.imported(key.(string), stream, result, now)
 			return
 		}
-		util.HandleError(err)
+	} else if apierrs.IsNotFound(err) {
+		err = ErrNotImportable
+	}
+	// the stream cannot be imported
+	if err == ErrNotImportable {
+		// value must match to be removed, so we avoid races against creation by ensuring that we only
+		// remove the stream if the uid and resource version in the scheduler are exactly the same.
+		if b.scheduler.Remove(key, value) {
+			delete(b.nextImport, key.(string))
+		}
+		return
+	}
+	util.HandleError(err)
+}
+
+// imported records the result of a scheduled import of a stream: the stream is not imported again before
+// its import interval elapses, and the registries that could not be reached are backed off.
+func (b *scheduled) imported(key string, stream *api.ImageStream, result *api.ImageStreamImport, now time.Time) {
+	if result == nil {
+		// all the registries of the stream are backed off
 		return
 	}
+	if interval := scheduledImportInterval(stream); interval > b.minimumInterval {
+		b.nextImport[key] = now.Add(wait.Jitter(interval, 0.1))
+	} else {
+		delete(b.nextImport, key)
+	}
+
+	unavailable := make(map[string]bool)
+	for i, image := range result.Spec.Images {
+		if i >= len(result.Status.Images) {
+			break
+		}
+		registry := imageRegistry(image.From.Name)
+		unavailable[registry] = unavailable[registry] || result.Status.Images[i].Status.Reason == api.ImportFailedRegistryUnavailable
+	}
+	for registry, failed := range unavailable {
+		if !failed {
+			b.backoff.succeeded(registry)
+			continue
+		}
+		delay := b.backoff.failed(registry, now)
+		glog.V(4).Infof("Registry %s is unavailable, scheduled imports from it are delayed by %s", registry, delay)
+	}
+}
+
+// scheduledImportInterval returns the import interval requested by the annotation of the stream, or zero
+// if none is set.
+func scheduledImportInterval(stream *api.ImageStream) time.Duration {
+	seconds, err := strconv.ParseInt(stream.Annotations[api.ScheduledImportIntervalAnnotation], 10, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
 }
 
 // Importing is invoked when the controller decides to import a stream in order to push back
@@ -0,0 +1,433 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/controller"
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	// NotificationSignatureHeader is the header carrying the HMAC-SHA256 signature of the body of a
+	// notification, computed with the secret of the endpoint and formatted as "sha256=<hex digest>".
+	NotificationSignatureHeader = "X-OpenShift-Signature"
+
+	// TagUpdatedNotificationKind is the kind of the notifications sent when a tag points to a new image.
+	TagUpdatedNotificationKind = "ImageStreamTagUpdated"
+)
+
+// TagUpdatedNotification is the body of the request sent to the notification endpoints of an image
+// stream when one of its tags points to a new image.
+type TagUpdatedNotification struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Tag       string `json:"tag"`
+	// Image is the digest of the image the tag points to.
+	Image string `json:"image,omitempty"`
+	// PreviousImage is the digest of the image the tag pointed to before, if any.
+	PreviousImage        string           `json:"previousImage,omitempty"`
+	DockerImageReference string           `json:"dockerImageReference"`
+	Created              unversioned.Time `json:"created"`
+}
+
+// NotificationControllerOptions contains options for the NotificationController.
+type NotificationControllerOptions struct {
+	// Resync is the interval at which image streams are fully re-listed.
+	Resync time.Duration
+	// Workers is the number of notifications delivered concurrently.
+	Workers int
+	// MaxRetries is the number of times a failed delivery is retried. Defaults to 5.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry of a failed delivery. The delay doubles with
+	// every failure of the delivery, up to MaxBackoff. Defaults to one second.
+	InitialBackoff time.Duration
+	// MaxBackoff is the longest delay between two retries of a delivery. Defaults to one minute.
+	MaxBackoff time.Duration
+	// BlockedNetworks are networks, like the cluster and service networks, the notifications are never
+	// sent to. Loopback, link-local, multicast and unspecified addresses are always refused.
+	BlockedNetworks []*net.IPNet
+	// Client sends the notifications. If nil, a client with a short timeout that refuses the blocked
+	// addresses and doesn't follow redirects is used.
+	Client *http.Client
+}
+
+// NotificationController notifies the endpoints listed in the spec of image streams every time a tag
+// of the stream points to a new image, whether the image was pushed, imported or tagged. Only the
+// changes observed while the controller runs are notified. The notifications are queued without
+// blocking the watch of image streams, and are signed with a key read from a secret of the namespace
+// of the stream when they are sent. Pending notifications, including the ones waiting to be retried,
+// are only kept in memory and are lost when the controller restarts.
+type NotificationController struct {
+	stopChan chan struct{}
+
+	client  *http.Client
+	secrets kclient.SecretsNamespacer
+
+	queue    *cache.FIFO
+	handlers []*controller.RetryController
+
+	streamController *framework.Controller
+}
+
+// notificationDelivery is a notification waiting to be sent to an endpoint.
+type notificationDelivery struct {
+	namespace    string
+	endpoint     api.ImageStreamNotification
+	notification TagUpdatedNotification
+}
+
+// notificationDeliveryKey identifies a delivery in the queue of the controller.
+func notificationDeliveryKey(obj interface{}) (string, error) {
+	delivery := obj.(*notificationDelivery)
+	n := delivery.notification
+	return fmt.Sprintf("%s/%s:%s@%s %s", n.Namespace, n.Name, n.Tag, n.Image, delivery.endpoint.URL), nil
+}
+
+// fatalError is an error which can't be retried.
+type fatalError string
+
+func (e fatalError) Error() string {
+	return string(e)
+}
+
+// NewNotificationController returns a new *NotificationController.
+func NewNotificationController(streams client.ImageStreamsNamespacer, secrets kclient.SecretsNamespacer, options NotificationControllerOptions) *NotificationController {
+	c := &NotificationController{
+		client:  options.Client,
+		secrets: secrets,
+		queue:   cache.NewFIFO(notificationDeliveryKey),
+	}
+	if c.client == nil {
+		c.client = newNotificationClient(options.BlockedNetworks)
+	}
+	maxRetries := options.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = 5
+	}
+	initialBackoff, maxBackoff := options.InitialBackoff, options.MaxBackoff
+	if initialBackoff == 0 {
+		initialBackoff = time.Second
+	}
+	if maxBackoff == 0 {
+		maxBackoff = time.Minute
+	}
+	retries := &backoffRetryManager{
+		queue: c.queue,
+		retryFunc: func(obj interface{}, err error, retries controller.Retry) bool {
+			if _, isFatal := err.(fatalError); isFatal || retries.Count >= maxRetries {
+				util.HandleError(err)
+				return false
+			}
+			glog.V(4).Infof("Will retry: %v", err)
+			return true
+		},
+		backoff: util.NewBackOff(initialBackoff, maxBackoff),
+		retries: make(map[string]controller.Retry),
+	}
+	workers := options.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	for i := 0; i < workers; i++ {
+		c.handlers = append(c.handlers, &controller.RetryController{
+			Queue:        c.queue,
+			RetryManager: retries,
+			Handle: func(obj interface{}) error {
+				return c.send(obj.(*notificationDelivery))
+			},
+		})
+	}
+
+	_, c.streamController = framework.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts kapi.ListOptions) (runtime.Object, error) {
+				return streams.ImageStreams(kapi.NamespaceAll).List(opts)
+			},
+			WatchFunc: func(opts kapi.ListOptions) (watch.Interface, error) {
+				return streams.ImageStreams(kapi.NamespaceAll).Watch(opts)
+			},
+		},
+		&api.ImageStream{},
+		options.Resync,
+		framework.ResourceEventHandlerFuncs{
+			UpdateFunc: c.streamUpdated,
+		},
+	)
+	return c
+}
+
+// backoffRetryManager requeues a failed delivery after a delay that grows exponentially with the
+// failures of that delivery, so that a slow or unreachable endpoint doesn't delay the deliveries to
+// the other endpoints. It is safe for concurrent use by the workers.
+type backoffRetryManager struct {
+	lock      sync.Mutex
+	queue     controller.ReQueue
+	retryFunc controller.RetryFunc
+	backoff   *util.Backoff
+	retries   map[string]controller.Retry
+}
+
+func (m *backoffRetryManager) Retry(resource interface{}, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	id, _ := notificationDeliveryKey(resource)
+	tries, exists := m.retries[id]
+	if !exists {
+		tries = controller.Retry{StartTimestamp: unversioned.Now()}
+	}
+	if !m.retryFunc(resource, err, tries) {
+		m.forget(id)
+		return
+	}
+	tries.Count++
+	m.retries[id] = tries
+	m.backoff.Next(id, m.backoff.Clock.Now())
+	time.AfterFunc(m.backoff.Get(id), func() {
+		// AddIfNotPresent keeps a newer notification for the same delivery queued meanwhile.
+		m.queue.AddIfNotPresent(resource)
+	})
+}
+
+func (m *backoffRetryManager) Forget(resource interface{}) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	id, _ := notificationDeliveryKey(resource)
+	m.forget(id)
+}
+
+func (m *backoffRetryManager) forget(id string) {
+	delete(m.retries, id)
+	m.backoff.Reset(id)
+}
+
+// newNotificationClient returns a client that refuses to connect to the loopback, link-local,
+// multicast and unspecified addresses or to the blocked networks, once the host of the endpoint is
+// resolved, and that doesn't follow redirects. Users choose the endpoints, which must not be used to
+// reach the services of the master or of the cluster.
+func newNotificationClient(blocked []*net.IPNet) *http.Client {
+	dialer := &notificationDialer{
+		lookupIP: net.LookupIP,
+		blocked:  blocked,
+		dialer:   &net.Dialer{Timeout: 10 * time.Second},
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			// a proxy would connect to the endpoints without the checks of the dialer
+			Proxy:               nil,
+			Dial:                dialer.Dial,
+			TLSHandshakeTimeout: 10 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return fatalError(fmt.Sprintf("redirected to %s", req.URL))
+		},
+		Timeout: 10 * time.Second,
+	}
+}
+
+// notificationDialer dials the endpoints of notifications at an address it checked, so that the host
+// of an endpoint can't resolve to an allowed address when checked and to a refused one when dialed.
+type notificationDialer struct {
+	lookupIP func(host string) ([]net.IP, error)
+	blocked  []*net.IPNet
+	dialer   *net.Dialer
+}
+
+func (d *notificationDialer) Dial(network, addr string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	ips, err := d.lookupIP(host)
+	if err != nil {
+		return nil, err
+	}
+	if len(ips) == 0 {
+		return nil, fmt.Errorf("no address found for %s", host)
+	}
+	// refuse the host if any of its addresses is refused rather than picking an allowed one
+	for _, ip := range ips {
+		if err := d.allowed(ip); err != nil {
+			return nil, err
+		}
+	}
+	return d.dialer.Dial(network, net.JoinHostPort(ips[0].String(), port))
+}
+
+// allowed returns a fatalError if notifications must not be sent to ip.
+func (d *notificationDialer) allowed(ip net.IP) error {
+	if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return fatalError(fmt.Sprintf("notifications can't be sent to %s", ip))
+	}
+	for _, network := range d.blocked {
+		if network.Contains(ip) {
+			return fatalError(fmt.Sprintf("notifications can't be sent to %s, in the network %s", ip, network))
+		}
+	}
+	return nil
+}
+
+// Run starts the controller loops and returns immediately.
+func (c *NotificationController) Run() {
+	if c.stopChan == nil {
+		c.stopChan = make(chan struct{})
+		go c.streamController.Run(c.stopChan)
+		for _, handler := range c.handlers {
+			handler.RunUntil(c.stopChan)
+		}
+	}
+}
+
+// Stop gracefully shuts down this controller.
+func (c *NotificationController) Stop() {
+	if c.stopChan != nil {
+		close(c.stopChan)
+		c.stopChan = nil
+	}
+}
+
+// streamUpdated queues a notification to every endpoint of the stream for each tag that points to a
+// new image.
+func (c *NotificationController) streamUpdated(oldObj, newObj interface{}) {
+	oldStream := oldObj.(*api.ImageStream)
+	newStream := newObj.(*api.ImageStream)
+	if len(newStream.Spec.Notifications) == 0 {
+		return
+	}
+	for _, notification := range tagUpdatedNotifications(oldStream, newStream) {
+		for _, endpoint := range newStream.Spec.Notifications {
+			c.queue.Add(&notificationDelivery{namespace: newStream.Namespace, endpoint: endpoint, notification: notification})
+		}
+	}
+}
+
+// tagUpdatedNotifications returns a notification for each tag whose most recent tag event differs
+// between the old and new versions of a stream.
+func tagUpdatedNotifications(oldStream, newStream *api.ImageStream) []TagUpdatedNotification {
+	notifications := []TagUpdatedNotification{}
+	for tag, history := range newStream.Status.Tags {
+		if len(history.Items) == 0 {
+			continue
+		}
+		latest := history.Items[0]
+		if !api.DifferentTagEvent(oldStream, tag, latest) {
+			continue
+		}
+		notification := TagUpdatedNotification{
+			Kind:                 TagUpdatedNotificationKind,
+			Namespace:            newStream.Namespace,
+			Name:                 newStream.Name,
+			Tag:                  tag,
+			Image:                latest.Image,
+			DockerImageReference: latest.DockerImageReference,
+			Created:              latest.Created,
+		}
+		if previous := api.LatestTaggedImage(oldStream, tag); previous != nil {
+			notification.PreviousImage = previous.Image
+		}
+		notifications = append(notifications, notification)
+	}
+	return notifications
+}
+
+// send posts a notification to its endpoint once. Failing to reach the endpoint or a server error is
+// retried later; a notification rejected by the endpoint or that cannot be signed is not.
+func (c *NotificationController) send(delivery *notificationDelivery) error {
+	n := delivery.notification
+	key, err := c.signingKey(delivery)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(n)
+	if err != nil {
+		return fatalError(err.Error())
+	}
+
+	req, err := http.NewRequest("POST", delivery.endpoint.URL, bytes.NewReader(body))
+	if err != nil {
+		return fatalError(err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(NotificationSignatureHeader, "sha256="+signNotification(body, key))
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		if cause, isFatal := fatalCause(err); isFatal {
+			return fatalError(fmt.Sprintf("unable to notify %s of the update of %s/%s:%s: %v", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag, cause))
+		}
+		return fmt.Errorf("unable to notify %s of the update of %s/%s:%s: %v", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag, err)
+	}
+	resp.Body.Close()
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		glog.V(4).Infof("Notified %s of the update of %s/%s:%s", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag)
+		return nil
+	case resp.StatusCode == kapierrors.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("unable to notify %s of the update of %s/%s:%s: the endpoint responded with %s", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag, resp.Status)
+	default:
+		return fatalError(fmt.Sprintf("unable to notify %s of the update of %s/%s:%s: the endpoint rejected the notification with %s", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag, resp.Status))
+	}
+}
+
+// fatalCause returns the fatalError wrapped by an error of the client, if any.
+func fatalCause(err error) (fatalError, bool) {
+	for {
+		switch t := err.(type) {
+		case fatalError:
+			return t, true
+		case *url.Error:
+			err = t.Err
+		case *net.OpError:
+			err = t.Err
+		default:
+			return "", false
+		}
+	}
+}
+
+// signingKey returns the key the notification is signed with, read from the secret of the endpoint.
+func (c *NotificationController) signingKey(delivery *notificationDelivery) (string, error) {
+	n := delivery.notification
+	secret, err := c.secrets.Secrets(delivery.namespace).Get(delivery.endpoint.SecretName)
+	switch {
+	case kapierrors.IsNotFound(err):
+		return "", fatalError(fmt.Sprintf("unable to notify %s of the update of %s/%s:%s: secret %s not found", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag, delivery.endpoint.SecretName))
+	case err != nil:
+		return "", fmt.Errorf("unable to notify %s of the update of %s/%s:%s: %v", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag, err)
+	}
+	key := secret.Data[api.ImageStreamNotificationSecretKey]
+	if len(key) == 0 {
+		return "", fatalError(fmt.Sprintf("unable to notify %s of the update of %s/%s:%s: secret %s has no %q key", delivery.endpoint.URL, n.Namespace, n.Name, n.Tag, delivery.endpoint.SecretName, api.ImageStreamNotificationSecretKey))
+	}
+	return string(key), nil
+}
+
+// signNotification returns the hex encoded HMAC-SHA256 of body keyed with secret.
+func signNotification(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
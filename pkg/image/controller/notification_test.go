@@ -0,0 +1,321 @@
+package controller
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+func TestTagUpdatedNotifications(t *testing.T) {
+	stream := func(tags map[string]api.TagEventList) *api.ImageStream {
+		return &api.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"},
+			Status:     api.ImageStreamStatus{Tags: tags},
+		}
+	}
+	event := func(image string) api.TagEvent {
+		return api.TagEvent{DockerImageReference: "registry/ns/app@" + image, Image: image}
+	}
+
+	tests := []struct {
+		name     string
+		old, new *api.ImageStream
+		expected []TagUpdatedNotification
+	}{
+		{
+			name:     "unchanged",
+			old:      stream(map[string]api.TagEventList{"latest": {Items: []api.TagEvent{event("sha256:1")}}}),
+			new:      stream(map[string]api.TagEventList{"latest": {Items: []api.TagEvent{event("sha256:1")}}}),
+			expected: []TagUpdatedNotification{},
+		},
+		{
+			name: "new tag",
+			old:  stream(nil),
+			new:  stream(map[string]api.TagEventList{"latest": {Items: []api.TagEvent{event("sha256:1")}}}),
+			expected: []TagUpdatedNotification{
+				{Kind: TagUpdatedNotificationKind, Namespace: "ns", Name: "app", Tag: "latest", Image: "sha256:1", DockerImageReference: "registry/ns/app@sha256:1"},
+			},
+		},
+		{
+			name: "updated tag",
+			old:  stream(map[string]api.TagEventList{"latest": {Items: []api.TagEvent{event("sha256:1")}}}),
+			new:  stream(map[string]api.TagEventList{"latest": {Items: []api.TagEvent{event("sha256:2"), event("sha256:1")}}}),
+			expected: []TagUpdatedNotification{
+				{Kind: TagUpdatedNotificationKind, Namespace: "ns", Name: "app", Tag: "latest", Image: "sha256:2", PreviousImage: "sha256:1", DockerImageReference: "registry/ns/app@sha256:2"},
+			},
+		},
+		{
+			name:     "removed tag",
+			old:      stream(map[string]api.TagEventList{"latest": {Items: []api.TagEvent{event("sha256:1")}}}),
+			new:      stream(map[string]api.TagEventList{}),
+			expected: []TagUpdatedNotification{},
+		},
+	}
+
+	for _, test := range tests {
+		if actual := tagUpdatedNotifications(test.old, test.new); !reflect.DeepEqual(test.expected, actual) {
+			t.Errorf("%s: unexpected notifications: %#v", test.name, actual)
+		}
+	}
+}
+
+func TestSendNotification(t *testing.T) {
+	notification := TagUpdatedNotification{Kind: TagUpdatedNotificationKind, Namespace: "ns", Name: "app", Tag: "latest", Image: "sha256:2", PreviousImage: "sha256:1"}
+	secret := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "hooks"},
+		Data:       map[string][]byte{api.ImageStreamNotificationSecretKey: []byte("secret")},
+	}
+	secrets := &ktestclient.Fake{}
+	secrets.AddReactor("get", "secrets", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		switch name := action.(ktestclient.GetAction).GetName(); name {
+		case "hooks":
+			return true, secret, nil
+		case "empty":
+			return true, &kapi.Secret{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "empty"}}, nil
+		default:
+			return true, nil, kapierrors.NewNotFound(kapi.Resource("secrets"), name)
+		}
+	})
+
+	tests := []struct {
+		name       string
+		secretName string
+		response   int
+		attempted  bool
+		expectErr  bool
+		fatal      bool
+	}{
+		{
+			name:       "delivered",
+			secretName: "hooks",
+			response:   http.StatusOK,
+			attempted:  true,
+		},
+		{
+			name:       "server error",
+			secretName: "hooks",
+			response:   http.StatusServiceUnavailable,
+			attempted:  true,
+			expectErr:  true,
+		},
+		{
+			name:       "rejected",
+			secretName: "hooks",
+			response:   http.StatusBadRequest,
+			attempted:  true,
+			expectErr:  true,
+			fatal:      true,
+		},
+		{
+			name:       "missing secret",
+			secretName: "missing",
+			expectErr:  true,
+			fatal:      true,
+		},
+		{
+			name:       "secret without key",
+			secretName: "empty",
+			expectErr:  true,
+			fatal:      true,
+		},
+	}
+
+	for _, test := range tests {
+		attempted := false
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			attempted = true
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signature := req.Header.Get(NotificationSignatureHeader); signature != "sha256="+signNotification(body, "secret") {
+				t.Errorf("%s: unexpected signature: %s", test.name, signature)
+			}
+			received := TagUpdatedNotification{}
+			if err := json.Unmarshal(body, &received); err != nil {
+				t.Errorf("%s: unable to decode the notification: %v", test.name, err)
+			}
+			if !reflect.DeepEqual(notification, received) {
+				t.Errorf("%s: unexpected notification: %#v", test.name, received)
+			}
+			w.WriteHeader(test.response)
+		}))
+
+		c := &NotificationController{
+			client:  http.DefaultClient,
+			secrets: secrets,
+		}
+		err := c.send(&notificationDelivery{
+			namespace:    "ns",
+			endpoint:     api.ImageStreamNotification{URL: server.URL, SecretName: test.secretName},
+			notification: notification,
+		})
+		server.Close()
+
+		if test.expectErr != (err != nil) {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if _, fatal := err.(fatalError); fatal != test.fatal {
+			t.Errorf("%s: expected fatal=%t, got %v", test.name, test.fatal, err)
+		}
+		if attempted != test.attempted {
+			t.Errorf("%s: expected attempted=%t", test.name, test.attempted)
+		}
+	}
+}
+
+func TestNotificationRetries(t *testing.T) {
+	secret := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "hooks"},
+		Data:       map[string][]byte{api.ImageStreamNotificationSecretKey: []byte("secret")},
+	}
+	responses := []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}
+	attempts := make(chan int, len(responses))
+	count := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(responses[count])
+		count++
+		attempts <- count
+	}))
+	defer server.Close()
+
+	streams := testclient.NewSimpleFake()
+	streams.AddWatchReactor("*", ktestclient.DefaultWatchReactor(watch.NewFake(), nil))
+	c := NewNotificationController(streams, ktestclient.NewSimpleFake(secret), NotificationControllerOptions{
+		InitialBackoff: time.Millisecond,
+		// the default client refuses the loopback address of the test server
+		Client: http.DefaultClient,
+	})
+	old := &api.ImageStream{ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"}}
+	updated := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"},
+		Spec: api.ImageStreamSpec{
+			Notifications: []api.ImageStreamNotification{{URL: server.URL, SecretName: "hooks"}},
+		},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{"latest": {Items: []api.TagEvent{{Image: "sha256:1"}}}},
+		},
+	}
+	// queuing notifications never waits for them to be delivered
+	c.streamUpdated(old, updated)
+
+	c.Run()
+	defer c.Stop()
+	for {
+		select {
+		case n := <-attempts:
+			if n == len(responses) {
+				return
+			}
+		case <-time.After(util.ForeverTestTimeout):
+			t.Fatalf("expected %d attempts", len(responses))
+		}
+	}
+}
+
+func TestNotificationDialer(t *testing.T) {
+	_, serviceNetwork, _ := net.ParseCIDR("172.30.0.0/16")
+	d := &notificationDialer{blocked: []*net.IPNet{serviceNetwork}}
+
+	tests := []struct {
+		ip      string
+		allowed bool
+	}{
+		{ip: "8.8.8.8", allowed: true},
+		{ip: "2001:4860:4860::8888", allowed: true},
+		{ip: "10.1.2.3", allowed: true},
+		{ip: "127.0.0.1"},
+		{ip: "::1"},
+		{ip: "169.254.169.254"},
+		{ip: "fe80::1"},
+		{ip: "0.0.0.0"},
+		{ip: "224.0.0.1"},
+		{ip: "172.30.0.1"},
+	}
+	for _, test := range tests {
+		err := d.allowed(net.ParseIP(test.ip))
+		if test.allowed != (err == nil) {
+			t.Errorf("%s: expected allowed=%t, got %v", test.ip, test.allowed, err)
+		}
+		if _, fatal := err.(fatalError); err != nil && !fatal {
+			t.Errorf("%s: expected a fatal error, got %v", test.ip, err)
+		}
+	}
+
+	// a host is refused when any of its addresses is
+	d.lookupIP = func(host string) ([]net.IP, error) {
+		return []net.IP{net.ParseIP("8.8.8.8"), net.ParseIP("169.254.169.254")}, nil
+	}
+	if _, err := d.Dial("tcp", "metadata.example.com:80"); err == nil {
+		t.Errorf("expected the host to be refused")
+	}
+}
+
+func TestSendNotificationRefused(t *testing.T) {
+	secret := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "hooks"},
+		Data:       map[string][]byte{api.ImageStreamNotificationSecretKey: []byte("secret")},
+	}
+	attempted := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		attempted = true
+	}))
+	defer target.Close()
+	redirect := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer redirect.Close()
+
+	// the test servers listen on a loopback address
+	loopback := newNotificationClient(nil)
+	redirects := newNotificationClient(nil)
+	redirects.Transport.(*http.Transport).Dial = (&net.Dialer{}).Dial
+
+	tests := []struct {
+		name   string
+		client *http.Client
+		url    string
+	}{
+		{
+			name:   "loopback",
+			client: loopback,
+			url:    target.URL,
+		},
+		{
+			name:   "redirect",
+			client: redirects,
+			url:    redirect.URL,
+		},
+	}
+	for _, test := range tests {
+		attempted = false
+		c := &NotificationController{
+			client:  test.client,
+			secrets: ktestclient.NewSimpleFake(secret),
+		}
+		err := c.send(&notificationDelivery{
+			namespace:    "ns",
+			endpoint:     api.ImageStreamNotification{URL: test.url, SecretName: "hooks"},
+			notification: TagUpdatedNotification{Kind: TagUpdatedNotificationKind, Namespace: "ns", Name: "app", Tag: "latest"},
+		})
+		if _, fatal := err.(fatalError); !fatal {
+			t.Errorf("%s: expected a fatal error, got %v", test.name, err)
+		}
+		if attempted {
+			t.Errorf("%s: unexpected request to %s", test.name, target.URL)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package importer
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"path"
@@ -392,7 +393,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			continue
 		}
 		limiter.Accept()
-		m, err := s.Get(d)
+		image, err := getImage(ctx, repo, s, d.String(), d)
 		if err != nil {
 			glog.V(5).Infof("unable to access digest %q for repository %#v: %#v", d, repository, err)
 			switch {
@@ -408,11 +409,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			importDigest.Err = err
 			continue
 		}
-		importDigest.Image, err = schema1ToImage(m, d)
-		if err != nil {
-			importDigest.Err = err
-			continue
-		}
+		importDigest.Image = image
 		if err := api.ImageWithMetadata(importDigest.Image); err != nil {
 			importDigest.Err = err
 			continue
@@ -425,7 +422,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			continue
 		}
 		limiter.Accept()
-		m, err := s.GetByTag(importTag.Name)
+		image, err := getImage(ctx, repo, s, importTag.Name, "")
 		if err != nil {
 			glog.V(5).Infof("unable to access tag %q for repository %#v: %#v", importTag.Name, repository, err)
 			switch {
@@ -441,11 +438,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			importTag.Err = err
 			continue
 		}
-		importTag.Image, err = schema1ToImage(m, "")
-		if err != nil {
-			importTag.Err = err
-			continue
-		}
+		importTag.Image = image
 		if err := api.ImageWithMetadata(importTag.Image); err != nil {
 			importTag.Err = err
 			continue
@@ -453,6 +446,99 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 	}
 }
 
+// acceptedManifestTypes are the manifest media types requested from registries that support retrieving raw
+// manifests, in order of preference.
+var acceptedManifestTypes = []string{
+	api.DockerManifestSchema2MediaType,
+	api.DockerManifestListMediaType,
+	api.DockerManifestSchema1MediaType,
+}
+
+// getImage retrieves the manifest identified by reference (a tag or a digest) from the repository and converts
+// it to an image. If d is set, it is used as the name of the image. Manifest services that can return raw
+// manifests are asked for schema 2 manifests and manifest lists in addition to schema 1 manifests, other
+// services are limited to schema 1.
+func getImage(ctx gocontext.Context, repo distribution.Repository, s distribution.ManifestService, reference string, d digest.Digest) (*api.Image, error) {
+	rs, ok := s.(distribution.RawManifestService)
+	if !ok {
+		var m *schema1.SignedManifest
+		var err error
+		if len(d) > 0 {
+			m, err = s.Get(d)
+		} else {
+			m, err = s.GetByTag(reference)
+		}
+		if err != nil {
+			return nil, err
+		}
+		return schema1ToImage(m, d)
+	}
+
+	payload, _, err := rs.GetRaw(reference, acceptedManifestTypes)
+	if err != nil {
+		return nil, err
+	}
+	manifest := api.DockerImageManifest{}
+	if err := json.Unmarshal(payload, &manifest); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case manifest.SchemaVersion == 1:
+		m := &schema1.SignedManifest{}
+		if err := json.Unmarshal(payload, m); err != nil {
+			return nil, err
+		}
+		return schema1ToImage(m, d)
+	case manifest.SchemaVersion == 2 && manifest.MediaType == api.DockerManifestListMediaType:
+		list := api.DockerManifestList{}
+		if err := json.Unmarshal(payload, &list); err != nil {
+			return nil, err
+		}
+		child, err := selectPlatformManifest(&list)
+		if err != nil {
+			return nil, err
+		}
+		glog.V(5).Infof("manifest list %s resolved to %s manifest %s", reference, child.Platform.Architecture, child.Digest)
+		payload, _, err := rs.GetRaw(child.Digest.String(), acceptedManifestTypes)
+		if err != nil {
+			return nil, err
+		}
+		manifest := api.DockerImageManifest{}
+		if err := json.Unmarshal(payload, &manifest); err != nil {
+			return nil, err
+		}
+		if manifest.SchemaVersion != 2 || manifest.MediaType != api.DockerManifestSchema2MediaType {
+			return nil, fmt.Errorf("manifest list %s references an unsupported manifest %s of type %q", reference, child.Digest, manifest.MediaType)
+		}
+		return fetchSchema2Image(ctx, repo, &manifest, payload, child.Digest)
+	case manifest.SchemaVersion == 2:
+		return fetchSchema2Image(ctx, repo, &manifest, payload, d)
+	default:
+		return nil, fmt.Errorf("unrecognized Docker image manifest schema %d for %s", manifest.SchemaVersion, reference)
+	}
+}
+
+// selectPlatformManifest returns the manifest for linux/amd64 from the manifest list.
+func selectPlatformManifest(list *api.DockerManifestList) (*api.DockerManifestListManifest, error) {
+	for i := range list.Manifests {
+		m := &list.Manifests[i]
+		if m.Platform.OS == "linux" && m.Platform.Architecture == "amd64" {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("the manifest list does not reference an image for linux/amd64")
+}
+
+// fetchSchema2Image retrieves the configuration blob of a schema 2 manifest and converts both to an image.
+func fetchSchema2Image(ctx gocontext.Context, repo distribution.Repository, manifest *api.DockerImageManifest, payload []byte, d digest.Digest) (*api.Image, error) {
+	config, err := repo.Blobs(context.Context(ctx)).Get(context.Context(ctx), manifest.Config.Digest)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve the image configuration %s: %v", manifest.Config.Digest, err)
+	}
+	return schema2ToImage(payload, config, d)
+}
+
 func importRepositoryFromDockerV1(ctx gocontext.Context, repository *importRepository, limiter util.RateLimiter) {
 	value := ctx.Value(ContextKeyV1RegistryClient)
 	if value == nil {
@@ -594,6 +680,14 @@ func imageImportStatus(err error, kind, position string) unversioned.Status {
 	case *field.Error:
 		return kapierrors.NewInvalid(api.Kind(kind), position, field.ErrorList{t}).(kapierrors.APIStatus).Status()
 	default:
+		switch {
+		case isRegistryUnavailable(err):
+			status := kapierrors.NewServiceUnavailable(err.Error()).(kapierrors.APIStatus).Status()
+			status.Reason = api.ImportFailedRegistryUnavailable
+			return status
+		case isDockerError(err, errcode.ErrorCodeDenied):
+			return kapierrors.NewUnauthorized(err.Error()).(kapierrors.APIStatus).Status()
+		}
 		return kapierrors.NewInternalError(err).(kapierrors.APIStatus).Status()
 	}
 }
@@ -619,6 +713,15 @@ type Context struct {
 	Transport         http.RoundTripper
 	InsecureTransport http.RoundTripper
 	Challenges        auth.ChallengeManager
+	// Actions are the repository actions requested from token based registries. Defaults to pull.
+	Actions []string
+}
+
+// WithActions returns a context whose RepositoryRetrievers request the given actions, for instance pull
+// and push, on the repositories they access.
+func (c Context) WithActions(actions ...string) Context {
+	c.Actions = actions
+	return c
 }
 
 func (c Context) WithCredentials(credentials auth.CredentialStore) RepositoryRetriever {
@@ -665,13 +768,17 @@ func (r *repositoryRetriever) Repository(ctx gocontext.Context, registry *url.UR
 		}
 	}
 
+	actions := r.context.Actions
+	if len(actions) == 0 {
+		actions = []string{"pull"}
+	}
 	rt := transport.NewTransport(
 		t,
 		// TODO: slightly smarter authorizer that retries unauthenticated requests
 		// TODO: make multiple attempts if the first credential fails
 		auth.NewAuthorizer(
 			r.context.Challenges,
-			auth.NewTokenHandler(t, r.credentials, repoName, "pull"),
+			auth.NewTokenHandler(t, r.credentials, repoName, actions...),
 			auth.NewBasicHandler(r.credentials),
 		),
 	)
@@ -752,6 +859,27 @@ func schema1ToImage(manifest *schema1.SignedManifest, d digest.Digest) (*api.Ima
 	return image, nil
 }
 
+func schema2ToImage(payload, config []byte, d digest.Digest) (*api.Image, error) {
+	if len(d) == 0 {
+		var err error
+		d, err = digest.FromBytes(payload)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create digest from image payload: %v", err)
+		}
+	}
+	image := &api.Image{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: d.String(),
+		},
+		DockerImageManifest:          string(payload),
+		DockerImageManifestMediaType: api.DockerManifestSchema2MediaType,
+		DockerImageConfig:            string(config),
+		DockerImageMetadataVersion:   "1.0",
+	}
+
+	return image, nil
+}
+
 func schema0ToImage(dockerImage *dockerregistry.Image, id string) (*api.Image, error) {
 	var baseImage api.DockerImage
 	if err := kapi.Scheme.Convert(&dockerImage.Image, &baseImage); err != nil {
@@ -800,3 +928,17 @@ func isDockerError(err error, code errcode.ErrorCode) bool {
 	}
 	return false
 }
+
+// isRegistryUnavailable returns true if err means the registry could not be reached, or failed to serve
+// the request because of a server side error.
+func isRegistryUnavailable(err error) bool {
+	switch t := err.(type) {
+	case *url.Error:
+		return isRegistryUnavailable(t.Err)
+	case net.Error:
+		return true
+	case *registryclient.UnexpectedHTTPStatusError:
+		return strings.HasPrefix(t.Status, "5")
+	}
+	return isDockerError(err, errcode.ErrorCodeUnavailable)
+}
@@ -3,6 +3,7 @@ package importer
 import (
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -17,6 +18,8 @@ import (
 	"github.com/docker/distribution/context"
 	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/registry/api/errcode"
+	registryclient "github.com/docker/distribution/registry/client"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
@@ -71,6 +74,50 @@ func (r *mockRepository) GetByTag(tag string, options ...distribution.ManifestSe
 	return r.manifest, r.getByTagErr
 }
 
+type mockRawRepository struct {
+	mockRepository
+
+	manifests map[string]string
+	blobs     map[digest.Digest][]byte
+	accepted  []string
+}
+
+func (r *mockRawRepository) Manifests(ctx context.Context, options ...distribution.ManifestServiceOption) (distribution.ManifestService, error) {
+	return r, r.repoErr
+}
+func (r *mockRawRepository) Blobs(ctx context.Context) distribution.BlobStore {
+	return &mockBlobStore{blobs: r.blobs}
+}
+func (r *mockRawRepository) GetRaw(reference string, accept []string) ([]byte, string, error) {
+	r.accepted = accept
+	payload, ok := r.manifests[reference]
+	if !ok {
+		return nil, "", fmt.Errorf("no such manifest %s", reference)
+	}
+	m := api.DockerImageManifest{}
+	if err := json.Unmarshal([]byte(payload), &m); err != nil {
+		return nil, "", err
+	}
+	return []byte(payload), m.MediaType, nil
+}
+func (r *mockRawRepository) PutRaw(payload []byte, mediaType, tag string) (digest.Digest, error) {
+	return "", fmt.Errorf("not implemented")
+}
+
+type mockBlobStore struct {
+	distribution.BlobStore
+
+	blobs map[digest.Digest][]byte
+}
+
+func (s *mockBlobStore) Get(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	blob, ok := s.blobs[dgst]
+	if !ok {
+		return nil, distribution.ErrBlobUnknown
+	}
+	return blob, nil
+}
+
 func TestImportNothing(t *testing.T) {
 	ctx := NewContext(http.DefaultTransport, http.DefaultTransport).WithCredentials(NoCredentials)
 	isi := &api.ImageStreamImport{}
@@ -291,6 +338,73 @@ const etcdManifest = `
    ]
 }`
 
+const schema2Manifest = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+   "config": {
+      "mediaType": "application/vnd.docker.container.image.v1+json",
+      "size": 1442,
+      "digest": "sha256:2d2fd6a9e2ea5b3cc22e3e2c9b0ef2c4e8b8cfc1b0f7f2b0f4fbd3ff1b2e7a58"
+   },
+   "layers": [
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 2191,
+         "digest": "sha256:b4ca4c215f483111b64ec6919f1659ff475d7080a649d6acd78a6ade562a4a63"
+      },
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 32,
+         "digest": "sha256:4e0ba9fd56b8e3c2b9e5d6ae1f8b9c95a3aa5a6c6e1e9f3e6c8e3a5e5d7c2b1a"
+      }
+   ]
+}`
+
+const schema2Config = `{
+   "architecture": "amd64",
+   "config": {
+      "Env": ["PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"],
+      "Cmd": ["/hello"]
+   },
+   "created": "2016-03-08T18:18:46Z",
+   "docker_version": "1.10.2",
+   "os": "linux",
+   "rootfs": {"type": "layers", "diff_ids": ["sha256:a02596fdd012f22b03af6ad7d11fa590c57507558357b079c3e8cebceb4262d7"]}
+}`
+
+// manifestList references an arm image and the amd64 image whose digest must be substituted.
+const manifestList = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json",
+   "manifests": [
+      {
+         "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+         "size": 424,
+         "digest": "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f",
+         "platform": {"architecture": "arm", "os": "linux", "variant": "v7"}
+      },
+      {
+         "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+         "size": 743,
+         "digest": "%s",
+         "platform": {"architecture": "amd64", "os": "linux"}
+      }
+   ]
+}`
+
+const armManifestList = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json",
+   "manifests": [
+      {
+         "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+         "size": 424,
+         "digest": "sha256:e692418e4cbaf90ca69d05a66403747baa33ee08806650b51fab815ad7fc331f",
+         "platform": {"architecture": "arm", "os": "linux", "variant": "v7"}
+      }
+   ]
+}`
+
 func TestSchema1ToImage(t *testing.T) {
 	m := &schema1.SignedManifest{}
 	if err := json.Unmarshal([]byte(etcdManifest), m); err != nil {
@@ -305,6 +419,61 @@ func TestSchema1ToImage(t *testing.T) {
 	}
 }
 
+func TestImportSchema2(t *testing.T) {
+	manifestDigest, err := digest.FromBytes([]byte(schema2Manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &mockRawRepository{
+		manifests: map[string]string{
+			"latest":                schema2Manifest,
+			"multi":                 fmt.Sprintf(manifestList, manifestDigest),
+			"arm":                   armManifestList,
+			manifestDigest.String(): schema2Manifest,
+		},
+		blobs: map[digest.Digest][]byte{
+			"sha256:2d2fd6a9e2ea5b3cc22e3e2c9b0ef2c4e8b8cfc1b0f7f2b0f4fbd3ff1b2e7a58": []byte(schema2Config),
+		},
+	}
+	isi := &api.ImageStreamImport{
+		Spec: api.ImageStreamImportSpec{
+			Images: []api.ImageImportSpec{
+				{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test:latest"}},
+				{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test:multi"}},
+				{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test:arm"}},
+				{From: kapi.ObjectReference{Kind: "DockerImage", Name: "test@" + manifestDigest.String()}},
+			},
+		},
+	}
+	im := NewImageStreamImporter(&mockRetriever{repo: repo}, 5, nil)
+	if err := im.Import(nil, isi); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(repo.accepted, acceptedManifestTypes) {
+		t.Errorf("unexpected accepted manifest types: %v", repo.accepted)
+	}
+
+	for i, name := range map[int]string{0: "latest", 1: "multi", 3: "digest"} {
+		image := isi.Status.Images[i].Image
+		if image == nil {
+			t.Errorf("%s: expected an image: %#v", name, isi.Status.Images[i].Status)
+			continue
+		}
+		if image.Name != manifestDigest.String() {
+			t.Errorf("%s: unexpected image name: %s", name, image.Name)
+		}
+		if image.DockerImageManifest != schema2Manifest || image.DockerImageConfig != schema2Config || image.DockerImageManifestMediaType != api.DockerManifestSchema2MediaType {
+			t.Errorf("%s: unexpected image manifest: %#v", name, image)
+		}
+		if image.DockerImageMetadata.Architecture != "amd64" || image.DockerImageMetadata.Size != 2223 || len(image.DockerImageLayers) != 2 {
+			t.Errorf("%s: unexpected image metadata: %#v", name, image)
+		}
+	}
+	if status := isi.Status.Images[2].Status; !expectStatusError(status, "Internal error occurred: the manifest list does not reference an image for linux/amd64") {
+		t.Errorf("unexpected status for a manifest list without a linux/amd64 image: %#v", status)
+	}
+}
+
 func TestDockerV1Fallback(t *testing.T) {
 	var uri *url.URL
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -352,3 +521,47 @@ func TestDockerV1Fallback(t *testing.T) {
 		t.Errorf("unexpected images: %#v", images)
 	}
 }
+
+func TestImageImportStatusReason(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		reason unversioned.StatusReason
+	}{
+		{
+			name:   "connection refused",
+			err:    &url.Error{Op: "Get", URL: "https://registry.example.com/v2/", Err: &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connection refused")}},
+			reason: api.ImportFailedRegistryUnavailable,
+		},
+		{
+			name:   "server error",
+			err:    &registryclient.UnexpectedHTTPStatusError{Status: "503 Service Unavailable"},
+			reason: api.ImportFailedRegistryUnavailable,
+		},
+		{
+			name:   "unavailable error code",
+			err:    errcode.Errors{errcode.ErrorCodeUnavailable.WithDetail("down for maintenance")},
+			reason: api.ImportFailedRegistryUnavailable,
+		},
+		{
+			name:   "denied",
+			err:    errcode.ErrorCodeDenied.WithDetail("requested access to the resource is denied"),
+			reason: api.ImportFailedUnauthorized,
+		},
+		{
+			name:   "unexpected client error",
+			err:    &registryclient.UnexpectedHTTPStatusError{Status: "418 I'm a teapot"},
+			reason: unversioned.StatusReasonInternalError,
+		},
+		{
+			name:   "certificate error",
+			err:    &url.Error{Op: "Get", URL: "https://registry.example.com/v2/", Err: fmt.Errorf("x509: certificate signed by unknown authority")},
+			reason: unversioned.StatusReasonInternalError,
+		},
+	}
+	for _, test := range tests {
+		if status := imageImportStatus(test.err, "", ""); status.Reason != test.reason {
+			t.Errorf("%s: expected reason %s, got %s", test.name, test.reason, status.Reason)
+		}
+	}
+}
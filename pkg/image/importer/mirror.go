@@ -0,0 +1,186 @@
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/golang/glog"
+	gocontext "golang.org/x/net/context"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema1"
+
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+// Mirror copies the manifest identified by reference (a tag or a digest) and the blobs it references from
+// the source repository to the destination repository, and tags it as tag in the destination when tag is
+// not empty. Manifest lists are copied along with all of the manifests they reference. Blobs that already
+// exist in the destination are skipped. When mountFrom is set, it names the source repository on the
+// destination registry and blobs are mounted from it instead of being uploaded, if the registry allows
+// it. Mirror returns the digest of the copied manifest.
+func Mirror(ctx gocontext.Context, src, dst distribution.Repository, reference, tag, mountFrom string) (digest.Digest, error) {
+	srcManifests, err := src.Manifests(context.Context(ctx))
+	if err != nil {
+		return "", err
+	}
+	dstManifests, err := dst.Manifests(context.Context(ctx))
+	if err != nil {
+		return "", err
+	}
+	rsrc, ok := srcManifests.(distribution.RawManifestService)
+	if !ok {
+		return "", fmt.Errorf("the manifests of %s cannot be read in their original form", src.Name())
+	}
+	rdst, ok := dstManifests.(distribution.RawManifestService)
+	if !ok {
+		return "", fmt.Errorf("the manifests of %s cannot be written in their original form", dst.Name())
+	}
+	m := &mirrorer{ctx: context.Context(ctx), src: src, dst: dst, srcManifests: rsrc, dstManifests: rdst, mountFrom: mountFrom}
+	return m.mirrorManifest(reference, tag)
+}
+
+// mirrorer copies manifests and blobs between two repositories.
+type mirrorer struct {
+	ctx          context.Context
+	src, dst     distribution.Repository
+	srcManifests distribution.RawManifestService
+	dstManifests distribution.RawManifestService
+	mountFrom    string
+}
+
+// mirrorManifest copies the blobs referenced by a manifest, and for manifest lists the referenced
+// manifests, before copying the manifest itself.
+func (m *mirrorer) mirrorManifest(reference, tag string) (digest.Digest, error) {
+	payload, mediaType, err := m.srcManifests.GetRaw(reference, acceptedManifestTypes)
+	if err != nil {
+		return "", err
+	}
+	manifest := api.DockerImageManifest{}
+	if err := json.Unmarshal(payload, &manifest); err != nil {
+		return "", err
+	}
+
+	dgst, err := digest.FromBytes(payload)
+	if err != nil {
+		return "", err
+	}
+	switch {
+	case manifest.SchemaVersion == 1:
+		// the digest of a signed manifest is computed without its signatures
+		signed := &schema1.SignedManifest{}
+		if err := json.Unmarshal(payload, signed); err != nil {
+			return "", err
+		}
+		unsigned, err := signed.Payload()
+		if err != nil {
+			return "", err
+		}
+		if dgst, err = digest.FromBytes(unsigned); err != nil {
+			return "", err
+		}
+		if len(mediaType) == 0 {
+			mediaType = api.DockerManifestSchema1MediaType
+		}
+		for _, layer := range manifest.FSLayers {
+			layerDigest, err := digest.ParseDigest(layer.DockerBlobSum)
+			if err != nil {
+				return "", err
+			}
+			if err := m.mirrorBlob(distribution.Descriptor{Digest: layerDigest}); err != nil {
+				return "", err
+			}
+		}
+	case manifest.SchemaVersion == 2 && manifest.MediaType == api.DockerManifestListMediaType:
+		list := api.DockerManifestList{}
+		if err := json.Unmarshal(payload, &list); err != nil {
+			return "", err
+		}
+		for _, child := range list.Manifests {
+			if _, err := m.mirrorManifest(child.Digest.String(), ""); err != nil {
+				return "", fmt.Errorf("unable to mirror the %s/%s manifest %s: %v", child.Platform.OS, child.Platform.Architecture, child.Digest, err)
+			}
+		}
+		mediaType = manifest.MediaType
+	case manifest.SchemaVersion == 2:
+		if err := m.mirrorBlob(manifest.Config); err != nil {
+			return "", err
+		}
+		for _, layer := range manifest.Layers {
+			if err := m.mirrorBlob(layer); err != nil {
+				return "", err
+			}
+		}
+		mediaType = manifest.MediaType
+	default:
+		return "", fmt.Errorf("unrecognized Docker image manifest schema %d for %s", manifest.SchemaVersion, reference)
+	}
+
+	glog.V(4).Infof("Pushing manifest %s to %s", dgst, m.dst.Name())
+	if _, err := m.dstManifests.PutRaw(payload, mediaType, tag); err != nil {
+		return "", err
+	}
+	return dgst, nil
+}
+
+// mirrorBlob ensures the blob described by desc exists in the destination repository, mounting it from
+// the source repository when possible and uploading it otherwise.
+func (m *mirrorer) mirrorBlob(desc distribution.Descriptor) error {
+	blobs := m.dst.Blobs(m.ctx)
+	if _, err := blobs.Stat(m.ctx, desc.Digest); err == nil {
+		glog.V(5).Infof("Blob %s already exists in %s", desc.Digest, m.dst.Name())
+		return nil
+	} else if err != distribution.ErrBlobUnknown {
+		return err
+	}
+
+	var w distribution.BlobWriter
+	if mounter, ok := blobs.(distribution.BlobMounter); ok && len(m.mountFrom) > 0 {
+		_, writer, err := mounter.Mount(m.ctx, m.mountFrom, desc.Digest)
+		if err != nil {
+			return err
+		}
+		if writer == nil {
+			glog.V(4).Infof("Mounted blob %s from %s into %s", desc.Digest, m.mountFrom, m.dst.Name())
+			return nil
+		}
+		w = writer
+	} else {
+		writer, err := blobs.Create(m.ctx)
+		if err != nil {
+			return err
+		}
+		w = writer
+	}
+	if err := m.uploadBlob(w, desc); err != nil {
+		w.Cancel(m.ctx)
+		return err
+	}
+	return nil
+}
+
+// uploadBlob copies the content of a blob from the source repository to the writer and commits it.
+func (m *mirrorer) uploadBlob(w distribution.BlobWriter, desc distribution.Descriptor) error {
+	r, err := m.src.Blobs(m.ctx).Open(m.ctx, desc.Digest)
+	if err != nil {
+		return fmt.Errorf("unable to open blob %s: %v", desc.Digest, err)
+	}
+	defer r.Close()
+
+	glog.V(4).Infof("Uploading blob %s to %s", desc.Digest, m.dst.Name())
+	n, err := io.Copy(w, r)
+	if err != nil {
+		return fmt.Errorf("unable to upload blob %s: %v", desc.Digest, err)
+	}
+	desc.Size = n
+	if len(desc.MediaType) == 0 {
+		desc.MediaType = "application/octet-stream"
+	}
+	if _, err := w.Commit(m.ctx, desc); err != nil {
+		return fmt.Errorf("unable to commit blob %s: %v", desc.Digest, err)
+	}
+	return nil
+}
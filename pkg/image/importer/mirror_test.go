@@ -0,0 +1,207 @@
+package importer
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+)
+
+// memoryRepository stores manifests and blobs in memory and records how blobs were added.
+type memoryRepository struct {
+	mockRawRepository
+
+	name      string
+	mountable bool
+	tagged    map[string]string
+	mounted   []digest.Digest
+	uploaded  []digest.Digest
+}
+
+func newMemoryRepository(name string, manifests map[string]string, blobs map[digest.Digest][]byte) *memoryRepository {
+	return &memoryRepository{
+		mockRawRepository: mockRawRepository{manifests: manifests, blobs: blobs},
+		name:              name,
+		tagged:            make(map[string]string),
+	}
+}
+
+func (r *memoryRepository) Name() string { return r.name }
+func (r *memoryRepository) Manifests(ctx context.Context, options ...distribution.ManifestServiceOption) (distribution.ManifestService, error) {
+	return r, nil
+}
+func (r *memoryRepository) Blobs(ctx context.Context) distribution.BlobStore {
+	return &memoryBlobStore{repo: r}
+}
+func (r *memoryRepository) PutRaw(payload []byte, mediaType, tag string) (digest.Digest, error) {
+	dgst, err := digest.FromBytes(payload)
+	if err != nil {
+		return "", err
+	}
+	r.manifests[dgst.String()] = string(payload)
+	if len(tag) > 0 {
+		r.manifests[tag] = string(payload)
+		r.tagged[tag] = mediaType
+	}
+	return dgst, nil
+}
+
+type memoryBlobStore struct {
+	distribution.BlobStore
+
+	repo *memoryRepository
+}
+
+func (s *memoryBlobStore) Stat(ctx context.Context, dgst digest.Digest) (distribution.Descriptor, error) {
+	blob, ok := s.repo.blobs[dgst]
+	if !ok {
+		return distribution.Descriptor{}, distribution.ErrBlobUnknown
+	}
+	return distribution.Descriptor{Digest: dgst, Size: int64(len(blob))}, nil
+}
+
+func (s *memoryBlobStore) Open(ctx context.Context, dgst digest.Digest) (distribution.ReadSeekCloser, error) {
+	blob, ok := s.repo.blobs[dgst]
+	if !ok {
+		return nil, distribution.ErrBlobUnknown
+	}
+	return &memoryBlobReader{bytes.NewReader(blob)}, nil
+}
+
+func (s *memoryBlobStore) Create(ctx context.Context) (distribution.BlobWriter, error) {
+	return &memoryBlobWriter{repo: s.repo}, nil
+}
+
+func (s *memoryBlobStore) Mount(ctx context.Context, sourceRepo string, dgst digest.Digest) (distribution.Descriptor, distribution.BlobWriter, error) {
+	if !s.repo.mountable {
+		return distribution.Descriptor{}, &memoryBlobWriter{repo: s.repo}, nil
+	}
+	s.repo.blobs[dgst] = []byte(sourceRepo)
+	s.repo.mounted = append(s.repo.mounted, dgst)
+	return distribution.Descriptor{Digest: dgst}, nil, nil
+}
+
+type memoryBlobReader struct {
+	*bytes.Reader
+}
+
+func (r *memoryBlobReader) Close() error { return nil }
+
+type memoryBlobWriter struct {
+	distribution.BlobWriter
+
+	repo *memoryRepository
+	buf  bytes.Buffer
+}
+
+func (w *memoryBlobWriter) Write(p []byte) (int, error) { return w.buf.Write(p) }
+func (w *memoryBlobWriter) Commit(ctx context.Context, desc distribution.Descriptor) (distribution.Descriptor, error) {
+	if desc.Size != int64(w.buf.Len()) {
+		return distribution.Descriptor{}, fmt.Errorf("unexpected size %d for %s", desc.Size, desc.Digest)
+	}
+	w.repo.blobs[desc.Digest] = w.buf.Bytes()
+	w.repo.uploaded = append(w.repo.uploaded, desc.Digest)
+	return desc, nil
+}
+
+func TestMirror(t *testing.T) {
+	manifestDigest, err := digest.FromBytes([]byte(schema2Manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := digest.Digest("sha256:2d2fd6a9e2ea5b3cc22e3e2c9b0ef2c4e8b8cfc1b0f7f2b0f4fbd3ff1b2e7a58")
+	layer1 := digest.Digest("sha256:b4ca4c215f483111b64ec6919f1659ff475d7080a649d6acd78a6ade562a4a63")
+	layer2 := digest.Digest("sha256:4e0ba9fd56b8e3c2b9e5d6ae1f8b9c95a3aa5a6c6e1e9f3e6c8e3a5e5d7c2b1a")
+	newSource := func() *memoryRepository {
+		return newMemoryRepository("source/app", map[string]string{
+			"latest":                schema2Manifest,
+			"multi":                 fmt.Sprintf(manifestList, manifestDigest),
+			manifestDigest.String(): schema2Manifest,
+		}, map[digest.Digest][]byte{
+			config: []byte(schema2Config),
+			layer1: []byte("layer1"),
+			layer2: []byte("layer2"),
+		})
+	}
+
+	tests := []struct {
+		name      string
+		reference string
+		mountFrom string
+		mountable bool
+		existing  []digest.Digest
+		uploaded  []digest.Digest
+		mounted   []digest.Digest
+		expectErr bool
+	}{
+		{
+			name:      "upload all blobs",
+			reference: "latest",
+			uploaded:  []digest.Digest{config, layer1, layer2},
+		},
+		{
+			name:      "skip existing blobs",
+			reference: "latest",
+			existing:  []digest.Digest{layer1},
+			uploaded:  []digest.Digest{config, layer2},
+		},
+		{
+			name:      "mount blobs",
+			reference: "latest",
+			mountFrom: "source/app",
+			mountable: true,
+			mounted:   []digest.Digest{config, layer1, layer2},
+		},
+		{
+			name:      "upload when the registry cannot mount",
+			reference: "latest",
+			mountFrom: "source/app",
+			uploaded:  []digest.Digest{config, layer1, layer2},
+		},
+		{
+			name:      "manifest list referencing a missing manifest",
+			reference: "multi",
+			expectErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		src := newSource()
+		dst := newMemoryRepository("mirror/app", map[string]string{}, map[digest.Digest][]byte{})
+		dst.mountable = test.mountable
+		for _, dgst := range test.existing {
+			dst.blobs[dgst] = src.blobs[dgst]
+		}
+
+		dgst, err := Mirror(nil, src, dst, test.reference, "v1", test.mountFrom)
+		if test.expectErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if dgst != manifestDigest {
+			t.Errorf("%s: unexpected digest: %s", test.name, dgst)
+		}
+		if dst.manifests["v1"] != schema2Manifest || dst.tagged["v1"] != "application/vnd.docker.distribution.manifest.v2+json" {
+			t.Errorf("%s: expected the manifest to be tagged: %#v", test.name, dst.tagged)
+		}
+		if !reflect.DeepEqual(dst.uploaded, test.uploaded) {
+			t.Errorf("%s: unexpected uploaded blobs: %v", test.name, dst.uploaded)
+		}
+		if !reflect.DeepEqual(dst.mounted, test.mounted) {
+			t.Errorf("%s: unexpected mounted blobs: %v", test.name, dst.mounted)
+		}
+		if len(test.mounted) == 0 && string(dst.blobs[layer1]) != "layer1" {
+			t.Errorf("%s: unexpected blob content: %q", test.name, dst.blobs[layer1])
+		}
+	}
+}
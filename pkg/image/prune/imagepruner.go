@@ -46,8 +46,12 @@ const (
 // pruneAlgorithm contains the various settings to use when evaluating images
 // and layers for pruning.
 type pruneAlgorithm struct {
-	keepYoungerThan  time.Duration
-	keepTagRevisions int
+	keepYoungerThan       time.Duration
+	keepTagRevisions      int
+	retentionPoliciesOnly bool
+	// retentionPolicies are the retention policies of image streams, keyed
+	// by namespace/name.
+	retentionPolicies map[string]*imageapi.ImageStreamRetentionPolicy
 }
 
 // ImagePruner knows how to delete images from OpenShift.
@@ -115,6 +119,17 @@ type ImageRegistryPrunerOptions struct {
 	// DCs is the entire list of deployment configs across all namespaces in the
 	// cluster.
 	DCs *deployapi.DeploymentConfigList
+	// RetentionPoliciesOnly indicates that only the images dropped by the
+	// retention policies of image streams are candidates for pruning. Images
+	// referenced by image streams without a retention policy are preserved, as
+	// are images not referenced by any image stream unless they were pushed to
+	// an image stream whose retention policy no longer keeps them because of
+	// their age.
+	RetentionPoliciesOnly bool
+	// SkipRegistry indicates that the layers, blobs, and manifests of pruned
+	// images are left in the registry, to be reclaimed by the registry garbage
+	// collector. The registry is not contacted.
+	SkipRegistry bool
 	// DryRun indicates that no changes will be made to the cluster and nothing
 	// will be removed.
 	DryRun bool
@@ -140,6 +155,7 @@ type imageRegistryPruner struct {
 	registryPinger registryPinger
 	registryClient *http.Client
 	registryURL    string
+	skipRegistry   bool
 }
 
 var _ ImageRegistryPruner = &imageRegistryPruner{}
@@ -224,6 +240,11 @@ minutes ago and is *not* currently referenced by:
 - any builds
 - the n most recent tag revisions in an image stream's status.tags
 
+An image stream with a retention policy overrides keepTagRevisions with its own
+number of revisions to keep, also preserves older revisions tagged less than
+the policy's keepYoungerThanSeconds ago, and preserves every revision of its
+protected tags.
+
 When removing an image, remove all references to the image from all
 ImageStreams having a reference to the image in `status.tags`.
 
@@ -236,8 +257,16 @@ func NewImageRegistryPruner(options ImageRegistryPrunerOptions) ImageRegistryPru
 	glog.V(1).Infof("Creating image pruner with keepYoungerThan=%v, keepTagRevisions=%d", options.KeepYoungerThan, options.KeepTagRevisions)
 
 	algorithm := pruneAlgorithm{
-		keepYoungerThan:  options.KeepYoungerThan,
-		keepTagRevisions: options.KeepTagRevisions,
+		keepYoungerThan:       options.KeepYoungerThan,
+		keepTagRevisions:      options.KeepTagRevisions,
+		retentionPoliciesOnly: options.RetentionPoliciesOnly,
+		retentionPolicies:     make(map[string]*imageapi.ImageStreamRetentionPolicy),
+	}
+	for i := range options.Streams.Items {
+		stream := &options.Streams.Items[i]
+		if stream.Spec.RetentionPolicy != nil {
+			algorithm.retentionPolicies[stream.Namespace+"/"+stream.Name] = stream.Spec.RetentionPolicy
+		}
 	}
 
 	addImagesToGraph(g, options.Images, algorithm)
@@ -249,7 +278,7 @@ func NewImageRegistryPruner(options ImageRegistryPrunerOptions) ImageRegistryPru
 	addDeploymentConfigsToGraph(g, options.DCs)
 
 	var rp registryPinger
-	if options.DryRun {
+	if options.DryRun || options.SkipRegistry {
 		rp = &dryRunRegistryPinger{}
 	} else {
 		rp = &defaultRegistryPinger{options.RegistryClient}
@@ -261,6 +290,7 @@ func NewImageRegistryPruner(options ImageRegistryPrunerOptions) ImageRegistryPru
 		registryPinger: rp,
 		registryClient: options.RegistryClient,
 		registryURL:    options.RegistryURL,
+		skipRegistry:   options.SkipRegistry,
 	}
 }
 
@@ -303,14 +333,26 @@ func addImagesToGraph(g graph.Graph, images *imageapi.ImageList, algorithm prune
 			layerNode := imagegraph.EnsureImageLayerNode(g, layer.DockerBlobSum)
 			g.AddEdge(imageNode, layerNode, ReferencedImageLayerEdgeKind)
 		}
+		for _, layer := range manifest.Layers {
+			glog.V(4).Infof("Adding image layer %q to graph", layer.Digest)
+			layerNode := imagegraph.EnsureImageLayerNode(g, layer.Digest.String())
+			g.AddEdge(imageNode, layerNode, ReferencedImageLayerEdgeKind)
+		}
+		// the configuration of a schema 2 image is stored as a blob too
+		if len(manifest.Config.Digest) > 0 {
+			glog.V(4).Infof("Adding image config %q to graph", manifest.Config.Digest)
+			configNode := imagegraph.EnsureImageLayerNode(g, manifest.Config.Digest.String())
+			g.AddEdge(imageNode, configNode, ReferencedImageLayerEdgeKind)
+		}
 	}
 }
 
 // addImageStreamsToGraph adds all the streams to the graph. The most recent n
 // image revisions for a tag will be preserved, where n is specified by the
-// algorithm's keepTagRevisions. Image revisions older than n are candidates
-// for pruning.  if the image stream's age is at least as old as the minimum
-// threshold in algorithm.  Otherwise, if the image stream is younger than the
+// stream's retention policy or, without one, by the algorithm's
+// keepTagRevisions. Image revisions older than n are candidates for pruning
+// if the image stream's age is at least as old as the minimum threshold in
+// algorithm, unless the retention policy protects them.  Otherwise, if the image stream is younger than the
 // threshold, all image revisions for that stream are ineligible for pruning.
 //
 // addImageStreamsToGraph also adds references from each stream to all the
@@ -331,6 +373,20 @@ func addImageStreamsToGraph(g graph.Graph, streams *imageapi.ImageStreamList, al
 			oldImageRevisionReferenceKind = ReferencedImageEdgeKind
 		}
 
+		keepTagRevisions := algorithm.keepTagRevisions
+		keepRevisionsYoungerThan := time.Duration(0)
+		protectedTags := sets.NewString()
+		switch policy := stream.Spec.RetentionPolicy; {
+		case policy != nil:
+			glog.V(4).Infof("Stream %s/%s has a retention policy: %#v", stream.Namespace, stream.Name, policy)
+			keepTagRevisions = policy.KeepTagRevisions
+			keepRevisionsYoungerThan = time.Duration(policy.KeepYoungerThanSeconds) * time.Second
+			protectedTags.Insert(policy.ProtectedTags...)
+		case algorithm.retentionPoliciesOnly:
+			glog.V(4).Infof("Stream %s/%s has no retention policy - none of its images are eligible for pruning", stream.Namespace, stream.Name)
+			oldImageRevisionReferenceKind = ReferencedImageEdgeKind
+		}
+
 		glog.V(4).Infof("Adding ImageStream %s/%s to graph", stream.Namespace, stream.Name)
 		isNode := imagegraph.EnsureImageStreamNode(g, stream)
 		imageStreamNode := isNode.(*imagegraph.ImageStreamNode)
@@ -346,7 +402,11 @@ func addImageStreamsToGraph(g graph.Graph, streams *imageapi.ImageStreamList, al
 
 				var kind string
 				switch {
-				case i < algorithm.keepTagRevisions:
+				case i < keepTagRevisions:
+					kind = ReferencedImageEdgeKind
+				case protectedTags.Has(tag):
+					kind = ReferencedImageEdgeKind
+				case unversioned.Now().Sub(history.Items[i].Created.Time) < keepRevisionsYoungerThan:
 					kind = ReferencedImageEdgeKind
 				default:
 					kind = oldImageRevisionReferenceKind
@@ -575,14 +635,27 @@ func imageIsPrunable(g graph.Graph, imageNode *imagegraph.ImageNode) bool {
 }
 
 // calculatePrunableImages returns the list of prunable images and a
-// graph.NodeSet containing the image node IDs.
-func calculatePrunableImages(g graph.Graph, imageNodes []*imagegraph.ImageNode) ([]*imagegraph.ImageNode, graph.NodeSet) {
+// graph.NodeSet containing the image node IDs. When the algorithm only
+// enforces retention policies, an image without any predecessor is only
+// prunable if it was pushed to an image stream with a retention policy and is
+// older than the policy's keepYoungerThanSeconds. Such images are left behind
+// when their tags are removed, or when deleting them failed after they were
+// removed from their image stream.
+func calculatePrunableImages(g graph.Graph, imageNodes []*imagegraph.ImageNode, algorithm pruneAlgorithm) ([]*imagegraph.ImageNode, graph.NodeSet) {
 	prunable := []*imagegraph.ImageNode{}
 	ids := make(graph.NodeSet)
 
 	for _, imageNode := range imageNodes {
 		glog.V(4).Infof("Examining image %q", imageNode.Image.Name)
 
+		if algorithm.retentionPoliciesOnly && len(g.To(imageNode)) == 0 {
+			if !unreferencedImageIsExpired(imageNode.Image, algorithm) {
+				glog.V(4).Infof("Image %q is not referenced by any image stream and not expired by a retention policy - skipping", imageNode.Image.Name)
+				continue
+			}
+			glog.V(4).Infof("Image %q is not referenced by any image stream and expired by a retention policy", imageNode.Image.Name)
+		}
+
 		if imageIsPrunable(g, imageNode) {
 			glog.V(4).Infof("Image %q is prunable", imageNode.Image.Name)
 			prunable = append(prunable, imageNode)
@@ -593,6 +666,21 @@ func calculatePrunableImages(g graph.Graph, imageNodes []*imagegraph.ImageNode)
 	return prunable, ids
 }
 
+// unreferencedImageIsExpired returns true if the image was pushed to an image
+// stream with a retention policy and is older than the policy allows.
+func unreferencedImageIsExpired(image *imageapi.Image, algorithm pruneAlgorithm) bool {
+	ref, err := imageapi.ParseDockerImageReference(image.DockerImageReference)
+	if err != nil || len(ref.Namespace) == 0 {
+		return false
+	}
+	policy, ok := algorithm.retentionPolicies[ref.Namespace+"/"+ref.Name]
+	if !ok {
+		return false
+	}
+	age := unversioned.Now().Sub(image.CreationTimestamp.Time)
+	return age >= time.Duration(policy.KeepYoungerThanSeconds)*time.Second
+}
+
 // subgraphWithoutPrunableImages creates a subgraph from g with prunable image
 // nodes excluded.
 func subgraphWithoutPrunableImages(g graph.Graph, prunableImageIDs graph.NodeSet) graph.Graph {
@@ -735,26 +823,33 @@ func (p *imageRegistryPruner) Prune(imagePruner ImagePruner, streamPruner ImageS
 		return nil
 	}
 
-	registryURL, err := p.determineRegistry(imageNodes)
-	if err != nil {
-		return fmt.Errorf("unable to determine registry: %v", err)
-	}
-	glog.V(1).Infof("Using registry: %s", registryURL)
+	var registryURL string
+	if !p.skipRegistry {
+		var err error
+		registryURL, err = p.determineRegistry(imageNodes)
+		if err != nil {
+			return fmt.Errorf("unable to determine registry: %v", err)
+		}
+		glog.V(1).Infof("Using registry: %s", registryURL)
 
-	if err := p.registryPinger.ping(registryURL); err != nil {
-		return fmt.Errorf("error communicating with registry: %v", err)
+		if err := p.registryPinger.ping(registryURL); err != nil {
+			return fmt.Errorf("error communicating with registry: %v", err)
+		}
 	}
 
-	prunableImageNodes, prunableImageIDs := calculatePrunableImages(p.g, imageNodes)
-	graphWithoutPrunableImages := subgraphWithoutPrunableImages(p.g, prunableImageIDs)
-	prunableLayers := calculatePrunableLayers(graphWithoutPrunableImages)
+	prunableImageNodes, prunableImageIDs := calculatePrunableImages(p.g, imageNodes, p.algorithm)
 
 	errs := []error{}
 
 	errs = append(errs, pruneStreams(p.g, prunableImageNodes, streamPruner)...)
-	errs = append(errs, pruneLayers(p.g, p.registryClient, registryURL, prunableLayers, layerPruner)...)
-	errs = append(errs, pruneBlobs(p.g, p.registryClient, registryURL, prunableLayers, blobPruner)...)
-	errs = append(errs, pruneManifests(p.g, p.registryClient, registryURL, prunableImageNodes, manifestPruner)...)
+	if !p.skipRegistry {
+		graphWithoutPrunableImages := subgraphWithoutPrunableImages(p.g, prunableImageIDs)
+		prunableLayers := calculatePrunableLayers(graphWithoutPrunableImages)
+
+		errs = append(errs, pruneLayers(p.g, p.registryClient, registryURL, prunableLayers, layerPruner)...)
+		errs = append(errs, pruneBlobs(p.g, p.registryClient, registryURL, prunableLayers, blobPruner)...)
+		errs = append(errs, pruneManifests(p.g, p.registryClient, registryURL, prunableImageNodes, manifestPruner)...)
+	}
 
 	if len(errs) > 0 {
 		// If we had any errors removing image references from image streams or deleting
@@ -16,6 +16,9 @@ import (
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util/sets"
 
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/digest"
+
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/client/testclient"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
@@ -87,6 +90,30 @@ func imageWithLayers(id, ref string, layers ...string) imageapi.Image {
 	return image
 }
 
+func schema2ImageWithLayers(id, ref, config string, layers ...string) imageapi.Image {
+	image := imageWithLayers(id, ref)
+
+	manifest := imageapi.DockerImageManifest{
+		SchemaVersion: 2,
+		MediaType:     imageapi.DockerManifestSchema2MediaType,
+		Config:        distribution.Descriptor{Digest: digest.Digest(config)},
+	}
+
+	for _, layer := range layers {
+		manifest.Layers = append(manifest.Layers, distribution.Descriptor{Digest: digest.Digest(layer)})
+	}
+
+	manifestBytes, err := json.Marshal(&manifest)
+	if err != nil {
+		panic(err)
+	}
+
+	image.DockerImageManifest = string(manifestBytes)
+	image.DockerImageManifestMediaType = imageapi.DockerManifestSchema2MediaType
+
+	return image
+}
+
 func unmanagedImage(id, ref string, hasAnnotations bool, annotation, value string) imageapi.Image {
 	image := imageWithLayers(id, ref)
 	if !hasAnnotations {
@@ -778,6 +805,36 @@ func TestRegistryPruning(t *testing.T) {
 				"registry1|foo/bar|id1",
 			),
 		},
+		"schema 2 layers and config unique to id1 pruned": {
+			images: imageList(
+				schema2ImageWithLayers("id1", "registry1/foo/bar@id1", "config1", "layer1", "layer2", "layer3"),
+				schema2ImageWithLayers("id2", "registry1/foo/bar@id2", "config2", "layer2", "layer3", "layer4"),
+			),
+			streams: streamList(
+				stream("registry1", "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id2", "registry1/foo/bar@id2"),
+						tagEvent("id1", "registry1/foo/bar@id1"),
+					),
+				)),
+				stream("registry1", "foo", "other", tags(
+					tag("latest",
+						tagEvent("id2", "registry1/foo/other@id2"),
+					),
+				)),
+			),
+			expectedLayerDeletions: sets.NewString(
+				"registry1|foo/bar|config1",
+				"registry1|foo/bar|layer1",
+			),
+			expectedBlobDeletions: sets.NewString(
+				"registry1|config1",
+				"registry1|layer1",
+			),
+			expectedManifestDeletions: sets.NewString(
+				"registry1|foo/bar|id1",
+			),
+		},
 		"no pruning when no images are pruned": {
 			images: imageList(
 				imageWithLayers("id1", "registry1/foo/bar@id1", "layer1", "layer2", "layer3", "layer4"),
@@ -875,3 +932,203 @@ func TestRegistryPruning(t *testing.T) {
 		}
 	}
 }
+
+func withRetentionPolicy(stream imageapi.ImageStream, keepTagRevisions int, keepYoungerThanSeconds int64, protectedTags ...string) imageapi.ImageStream {
+	stream.Spec.RetentionPolicy = &imageapi.ImageStreamRetentionPolicy{
+		KeepTagRevisions:       keepTagRevisions,
+		KeepYoungerThanSeconds: keepYoungerThanSeconds,
+		ProtectedTags:          protectedTags,
+	}
+	return stream
+}
+
+func agedTagEvent(id, ref string, ageInMinutes int64) imageapi.TagEvent {
+	event := tagEvent(id, ref)
+	event.Created = unversioned.NewTime(unversioned.Now().Add(time.Duration(-1*ageInMinutes) * time.Minute))
+	return event
+}
+
+func TestImagePruningRetentionPolicies(t *testing.T) {
+	registryURL := "registry"
+
+	tests := map[string]struct {
+		images                 imageapi.ImageList
+		streams                imageapi.ImageStreamList
+		retentionPoliciesOnly  bool
+		expectedDeletions      []string
+		expectedUpdatedStreams []string
+	}{
+		"policy overrides the number of revisions to keep": {
+			images: imageList(
+				image("id", registryURL+"/foo/bar@id"),
+				image("id2", registryURL+"/foo/bar@id2"),
+				image("id3", registryURL+"/foo/bar@id3"),
+			),
+			streams: streamList(
+				withRetentionPolicy(stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id", registryURL+"/foo/bar@id"),
+						tagEvent("id2", registryURL+"/foo/bar@id2"),
+						tagEvent("id3", registryURL+"/foo/bar@id3"),
+					),
+				)), 1, 0),
+			),
+			expectedDeletions:      []string{"id2", "id3"},
+			expectedUpdatedStreams: []string{"foo/bar|id2", "foo/bar|id3"},
+		},
+		"revisions of protected tags are kept": {
+			images: imageList(
+				image("id", registryURL+"/foo/bar@id"),
+				image("id2", registryURL+"/foo/bar@id2"),
+				image("id3", registryURL+"/foo/bar@id3"),
+				image("id4", registryURL+"/foo/bar@id4"),
+			),
+			streams: streamList(
+				withRetentionPolicy(stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id", registryURL+"/foo/bar@id"),
+						tagEvent("id2", registryURL+"/foo/bar@id2"),
+					),
+					tag("stable",
+						tagEvent("id3", registryURL+"/foo/bar@id3"),
+						tagEvent("id4", registryURL+"/foo/bar@id4"),
+					),
+				)), 1, 0, "stable"),
+			),
+			expectedDeletions:      []string{"id2"},
+			expectedUpdatedStreams: []string{"foo/bar|id2"},
+		},
+		"recently tagged revisions are kept": {
+			images: imageList(
+				image("id", registryURL+"/foo/bar@id"),
+				image("id2", registryURL+"/foo/bar@id2"),
+				image("id3", registryURL+"/foo/bar@id3"),
+			),
+			streams: streamList(
+				withRetentionPolicy(stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						agedTagEvent("id", registryURL+"/foo/bar@id", 5),
+						agedTagEvent("id2", registryURL+"/foo/bar@id2", 10),
+						agedTagEvent("id3", registryURL+"/foo/bar@id3", 120),
+					),
+				)), 1, 3600),
+			),
+			expectedDeletions:      []string{"id3"},
+			expectedUpdatedStreams: []string{"foo/bar|id3"},
+		},
+		"only images dropped by retention policies are pruned": {
+			images: imageList(
+				image("id", registryURL+"/foo/bar@id"),
+				image("id2", registryURL+"/foo/bar@id2"),
+				image("id3", registryURL+"/foo/baz@id3"),
+				image("id4", registryURL+"/foo/baz@id4"),
+				image("orphan", registryURL+"/foo/bar@orphan"),
+				image("orphan2", registryURL+"/foo/baz@orphan2"),
+			),
+			streams: streamList(
+				withRetentionPolicy(stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id", registryURL+"/foo/bar@id"),
+						tagEvent("id2", registryURL+"/foo/bar@id2"),
+					),
+				)), 1, 0),
+				stream(registryURL, "foo", "baz", tags(
+					tag("latest",
+						tagEvent("id3", registryURL+"/foo/baz@id3"),
+						tagEvent("id4", registryURL+"/foo/baz@id4"),
+					),
+				)),
+			),
+			retentionPoliciesOnly:  true,
+			expectedDeletions:      []string{"id2", "orphan"},
+			expectedUpdatedStreams: []string{"foo/bar|id2"},
+		},
+		"unreferenced images are pruned by the age of the policy": {
+			images: imageList(
+				image("id", registryURL+"/foo/bar@id"),
+				agedImage("young", registryURL+"/foo/bar@young", 90),
+				agedImage("old", registryURL+"/foo/bar@old", 180),
+			),
+			streams: streamList(
+				withRetentionPolicy(stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id", registryURL+"/foo/bar@id"),
+					),
+				)), 1, 7200),
+			),
+			retentionPoliciesOnly:  true,
+			expectedDeletions:      []string{"old"},
+			expectedUpdatedStreams: []string{},
+		},
+		"image dropped by a policy but kept by a stream without policy": {
+			images: imageList(
+				image("id", registryURL+"/foo/bar@id"),
+				image("id2", registryURL+"/foo/bar@id2"),
+			),
+			streams: streamList(
+				withRetentionPolicy(stream(registryURL, "foo", "bar", tags(
+					tag("latest",
+						tagEvent("id", registryURL+"/foo/bar@id"),
+						tagEvent("id2", registryURL+"/foo/bar@id2"),
+					),
+				)), 1, 0),
+				stream(registryURL, "foo", "baz", tags(
+					tag("latest",
+						tagEvent("id", registryURL+"/foo/bar@id"),
+						tagEvent("id2", registryURL+"/foo/bar@id2"),
+					),
+				)),
+			),
+			retentionPoliciesOnly:  true,
+			expectedDeletions:      []string{},
+			expectedUpdatedStreams: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		options := ImageRegistryPrunerOptions{
+			KeepYoungerThan:       60 * time.Minute,
+			KeepTagRevisions:      3,
+			Images:                &test.images,
+			Streams:               &test.streams,
+			Pods:                  &kapi.PodList{},
+			RCs:                   &kapi.ReplicationControllerList{},
+			BCs:                   &buildapi.BuildConfigList{},
+			Builds:                &buildapi.BuildList{},
+			DCs:                   &deployapi.DeploymentConfigList{},
+			RetentionPoliciesOnly: test.retentionPoliciesOnly,
+			SkipRegistry:          test.retentionPoliciesOnly,
+		}
+		p := NewImageRegistryPruner(options)
+		pinger := &fakeRegistryPinger{}
+		if !test.retentionPoliciesOnly {
+			p.(*imageRegistryPruner).registryPinger = pinger
+		}
+
+		imagePruner := &fakeImagePruner{invocations: sets.NewString()}
+		streamPruner := &fakeImageStreamPruner{invocations: sets.NewString()}
+		layerPruner := &fakeLayerPruner{invocations: sets.NewString()}
+		blobPruner := &fakeBlobPruner{invocations: sets.NewString()}
+		manifestPruner := &fakeManifestPruner{invocations: sets.NewString()}
+
+		if err := p.Prune(imagePruner, streamPruner, layerPruner, blobPruner, manifestPruner); err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		expectedDeletions := sets.NewString(test.expectedDeletions...)
+		if !reflect.DeepEqual(expectedDeletions, imagePruner.invocations) {
+			t.Errorf("%s: expected image deletions %q, got %q", name, expectedDeletions.List(), imagePruner.invocations.List())
+		}
+
+		expectedUpdatedStreams := sets.NewString(test.expectedUpdatedStreams...)
+		if !reflect.DeepEqual(expectedUpdatedStreams, streamPruner.invocations) {
+			t.Errorf("%s: expected stream updates %q, got %q", name, expectedUpdatedStreams.List(), streamPruner.invocations.List())
+		}
+
+		if test.retentionPoliciesOnly {
+			if len(layerPruner.invocations)+len(blobPruner.invocations)+len(manifestPruner.invocations) != 0 {
+				t.Errorf("%s: expected the registry to be left alone, got layers %q, blobs %q, manifests %q", name, layerPruner.invocations.List(), blobPruner.invocations.List(), manifestPruner.invocations.List())
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package prune
+
+import (
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/util"
+
+	"github.com/openshift/origin/pkg/client"
+	oserrors "github.com/openshift/origin/pkg/util/errors"
+)
+
+// RetentionController periodically enforces the retention policies of image streams. The images
+// dropped by the policies are removed from the image streams and deleted, unless something else in
+// the cluster still references them. Their layers and manifests are left in the registry, to be
+// reclaimed by the registry garbage collector.
+type RetentionController struct {
+	// Client is an OpenShift client.
+	Client client.Interface
+	// KubeClient is a Kubernetes client.
+	KubeClient kclient.Interface
+	// KeepYoungerThan is the minimum age of the images and image streams that may be pruned.
+	KeepYoungerThan time.Duration
+}
+
+// RunUntil enforces the retention policies every interval until stopCh is closed.
+func (c *RetentionController) RunUntil(interval time.Duration, stopCh <-chan struct{}) {
+	go util.Until(func() {
+		if err := c.Prune(); err != nil {
+			util.HandleError(err)
+		}
+	}, interval, stopCh)
+}
+
+// Prune enforces the retention policies of all image streams once, reusing the algorithm of the
+// image registry pruner so that images used by pods, controllers, builds, and deployments are
+// preserved.
+func (c *RetentionController) Prune() error {
+	streams, err := c.Client.ImageStreams(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+	withPolicy := 0
+	for i := range streams.Items {
+		if streams.Items[i].Spec.RetentionPolicy != nil {
+			withPolicy++
+		}
+	}
+	if withPolicy == 0 {
+		return nil
+	}
+	glog.V(4).Infof("Enforcing the retention policies of %d image streams", withPolicy)
+
+	images, err := c.Client.Images().List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+	pods, err := c.KubeClient.Pods(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+	rcs, err := c.KubeClient.ReplicationControllers(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+	// build configs and builds may be disabled
+	bcs, err := c.Client.BuildConfigs(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err := oserrors.TolerateNotFoundError(err); err != nil {
+		return err
+	}
+	builds, err := c.Client.Builds(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err := oserrors.TolerateNotFoundError(err); err != nil {
+		return err
+	}
+	dcs, err := c.Client.DeploymentConfigs(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	pruner := NewImageRegistryPruner(ImageRegistryPrunerOptions{
+		KeepYoungerThan:       c.KeepYoungerThan,
+		Images:                images,
+		Streams:               streams,
+		Pods:                  pods,
+		RCs:                   rcs,
+		BCs:                   bcs,
+		Builds:                builds,
+		DCs:                   dcs,
+		RetentionPoliciesOnly: true,
+		SkipRegistry:          true,
+	})
+	return pruner.Prune(NewDeletingImagePruner(c.Client.Images()), NewDeletingImageStreamPruner(c.Client), nil, nil, nil)
+}
@@ -0,0 +1,68 @@
+package prune
+
+import (
+	"testing"
+	"time"
+
+	ktc "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestRetentionControllerPrune(t *testing.T) {
+	registryURL := "registry"
+	images := imageList(
+		agedImage("id", registryURL+"/foo/bar@id", 120),
+		agedImage("id2", registryURL+"/foo/bar@id2", 120),
+	)
+	barStream := stream(registryURL, "foo", "bar", tags(
+		tag("latest",
+			tagEvent("id", registryURL+"/foo/bar@id"),
+			tagEvent("id2", registryURL+"/foo/bar@id2"),
+		),
+	))
+
+	for _, withPolicy := range []bool{false, true} {
+		streams := streamList(barStream)
+		if withPolicy {
+			streams = streamList(withRetentionPolicy(barStream, 1, 0))
+		}
+		client := &testclient.Fake{}
+		client.AddReactor("list", "imagestreams", func(action ktc.Action) (bool, runtime.Object, error) {
+			return true, &streams, nil
+		})
+		client.AddReactor("list", "images", func(action ktc.Action) (bool, runtime.Object, error) {
+			return true, &images, nil
+		})
+
+		controller := &RetentionController{Client: client, KubeClient: &ktc.Fake{}, KeepYoungerThan: time.Hour}
+		if err := controller.Prune(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		deleted := []string{}
+		updated := []string{}
+		for _, action := range client.Actions() {
+			switch {
+			case action.Matches("delete", "images"):
+				deleted = append(deleted, action.(ktc.DeleteAction).GetName())
+			case action.Matches("update", "imagestreams"):
+				updated = append(updated, action.(ktc.UpdateAction).GetObject().(*imageapi.ImageStream).Name)
+			}
+		}
+		if !withPolicy {
+			if len(client.Actions()) != 1 {
+				t.Errorf("expected only image streams to be listed without retention policies, got %#v", client.Actions())
+			}
+			continue
+		}
+		if len(deleted) != 1 || deleted[0] != "id2" {
+			t.Errorf("expected image id2 to be deleted, got %v", deleted)
+		}
+		if len(updated) != 1 || updated[0] != "bar" {
+			t.Errorf("expected stream bar to be updated, got %v", updated)
+		}
+	}
+}
@@ -18,8 +18,14 @@ type REST struct {
 	*etcdgeneric.Etcd
 }
 
-// NewREST returns a new REST.
-func NewREST(s storage.Interface) *REST {
+// SignaturesREST implements a RESTStorage for images against etcd that only changes the signatures
+// of images on update. It backs the imagesignatures resource.
+type SignaturesREST struct {
+	*etcdgeneric.Etcd
+}
+
+// NewREST returns a new REST and a SignaturesREST sharing its storage.
+func NewREST(s storage.Interface) (*REST, *SignaturesREST) {
 	prefix := "/images"
 
 	store := &etcdgeneric.Etcd{
@@ -59,5 +65,9 @@ func NewREST(s storage.Interface) *REST {
 
 		Storage: s,
 	}
-	return &REST{store}
+
+	signaturesStore := *store
+	signaturesStore.UpdateStrategy = image.SignaturesStrategy
+
+	return &REST{store}, &SignaturesREST{&signaturesStore}
 }
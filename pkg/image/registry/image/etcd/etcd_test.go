@@ -19,7 +19,7 @@ import (
 
 func newStorage(t *testing.T) (*REST, *etcdtesting.EtcdTestServer) {
 	etcdStorage, server := registrytest.NewEtcdStorage(t, latest.Version.Group)
-	storage := NewREST(etcdStorage)
+	storage, _ := NewREST(etcdStorage)
 	return storage, server
 }
 
@@ -404,3 +404,39 @@ func TestUpdateResetsMetadata(t *testing.T) {
 		}
 	}
 }
+
+func TestUpdateSignatures(t *testing.T) {
+	etcdStorage, server := registrytest.NewEtcdStorage(t, latest.Version.Group)
+	defer server.Terminate(t)
+	storage, signaturesStorage := NewREST(etcdStorage)
+	signature := api.ImageSignature{ObjectMeta: kapi.ObjectMeta{Name: "foo@first"}, Type: "x509", Content: []byte("content")}
+
+	created, err := storage.Create(kapi.NewDefaultContext(), validImage())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// signatures can't be changed by an update of the image
+	updated := created.(*api.Image)
+	updated.Signatures = []api.ImageSignature{signature}
+	updated.Labels = map[string]string{"a": "b"}
+	obj, _, err := storage.Update(kapi.NewDefaultContext(), updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := obj.(*api.Image); len(image.Signatures) != 0 || image.Labels["a"] != "b" {
+		t.Fatalf("unexpected image: %#v", image)
+	}
+
+	// only signatures are changed through the signatures storage
+	updated = obj.(*api.Image)
+	updated.Signatures = []api.ImageSignature{signature}
+	updated.DockerImageReference = "openshift/other"
+	obj, _, err = signaturesStorage.Update(kapi.NewDefaultContext(), updated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if image := obj.(*api.Image); len(image.Signatures) != 1 || image.DockerImageReference != "openshift/origin" {
+		t.Fatalf("unexpected image: %#v", image)
+	}
+}
@@ -16,6 +16,8 @@ type Registry interface {
 	GetImage(ctx kapi.Context, id string) (*api.Image, error)
 	// CreateImage creates a new image.
 	CreateImage(ctx kapi.Context, image *api.Image) error
+	// UpdateImage updates given image.
+	UpdateImage(ctx kapi.Context, image *api.Image) (*api.Image, error)
 	// DeleteImage deletes an image.
 	DeleteImage(ctx kapi.Context, id string) error
 	// WatchImages watches for new or deleted images.
@@ -28,6 +30,7 @@ type Storage interface {
 	rest.Lister
 	rest.Getter
 	rest.Watcher
+	rest.Updater
 
 	Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error)
 }
@@ -64,6 +67,14 @@ func (s *storage) CreateImage(ctx kapi.Context, image *api.Image) error {
 	return err
 }
 
+func (s *storage) UpdateImage(ctx kapi.Context, image *api.Image) (*api.Image, error) {
+	obj, _, err := s.Update(ctx, image)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*api.Image), nil
+}
+
 func (s *storage) DeleteImage(ctx kapi.Context, imageID string) error {
 	_, err := s.Delete(ctx, imageID, nil)
 	return err
@@ -72,6 +72,10 @@ func (imageStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	newImage.DockerImageMetadata = oldImage.DockerImageMetadata
 	newImage.DockerImageMetadataVersion = oldImage.DockerImageMetadataVersion
 	newImage.DockerImageLayers = oldImage.DockerImageLayers
+	newImage.DockerImageManifestMediaType = oldImage.DockerImageManifestMediaType
+	newImage.DockerImageConfig = oldImage.DockerImageConfig
+	// signatures are only added and removed through the imagesignatures resource
+	newImage.Signatures = oldImage.Signatures
 
 	// allow an image update that results in the manifest matching the digest (the name)
 	newManifest := newImage.DockerImageManifest
@@ -95,6 +99,26 @@ func (imageStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) f
 	return validation.ValidateImageUpdate(old.(*api.Image), obj.(*api.Image))
 }
 
+// signaturesStrategy implements behavior for the updates of the signatures of Images.
+type signaturesStrategy struct {
+	imageStrategy
+}
+
+// SignaturesStrategy is the logic that applies when the signatures of Image objects are added or
+// removed through the imagesignatures resource.
+var SignaturesStrategy = signaturesStrategy{Strategy}
+
+// PrepareForUpdate keeps everything but the metadata and the signatures of the old image.
+func (signaturesStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	newImage := obj.(*api.Image)
+	oldImage := old.(*api.Image)
+
+	meta, signatures := newImage.ObjectMeta, newImage.Signatures
+	*newImage = *oldImage
+	newImage.ObjectMeta = meta
+	newImage.Signatures = signatures
+}
+
 // MatchImage returns a generic matcher for a given label and field selector.
 func MatchImage(label labels.Selector, field fields.Selector) generic.Matcher {
 	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
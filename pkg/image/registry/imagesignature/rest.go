@@ -0,0 +1,124 @@
+package imagesignature
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/rest"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/image/api"
+	"github.com/openshift/origin/pkg/image/api/validation"
+	"github.com/openshift/origin/pkg/image/registry/image"
+)
+
+// REST implements the RESTStorage interface in terms of an image registry. It
+// supports the Create and Delete methods and is used to add and remove detached
+// signatures of an Image. Signatures are stored as part of the signed Image.
+type REST struct {
+	imageRegistry image.Registry
+}
+
+// NewREST returns a new REST.
+func NewREST(imageRegistry image.Registry) *REST {
+	return &REST{imageRegistry: imageRegistry}
+}
+
+// imageSignatureStrategy implements behavior for image signatures.
+type imageSignatureStrategy struct {
+	runtime.ObjectTyper
+	kapi.NameGenerator
+}
+
+// Strategy is the default logic that applies when creating ImageSignature
+// objects via the REST API.
+var Strategy = imageSignatureStrategy{kapi.Scheme, kapi.SimpleNameGenerator}
+
+// New returns a new ImageSignature for use with Create.
+func (r *REST) New() runtime.Object {
+	return &api.ImageSignature{}
+}
+
+// NamespaceScoped is false for image signatures.
+func (s imageSignatureStrategy) NamespaceScoped() bool {
+	return false
+}
+
+// PrepareForCreate clears fields that are not allowed to be set by end users on creation.
+func (s imageSignatureStrategy) PrepareForCreate(obj runtime.Object) {
+}
+
+// Canonicalize normalizes the object after validation.
+func (s imageSignatureStrategy) Canonicalize(obj runtime.Object) {
+}
+
+// Validate validates a new ImageSignature.
+func (s imageSignatureStrategy) Validate(ctx kapi.Context, obj runtime.Object) field.ErrorList {
+	return validation.ValidateImageSignature(obj.(*api.ImageSignature))
+}
+
+// Create adds the signature to the image it is named after. An error is
+// returned if the image already has a signature with the same name.
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error) {
+	if err := rest.BeforeCreate(Strategy, ctx, obj); err != nil {
+		return nil, err
+	}
+
+	signature := obj.(*api.ImageSignature)
+	imageName, _, _ := api.SplitImageSignatureName(signature.Name)
+
+	err := kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+		image, err := r.imageRegistry.GetImage(ctx, imageName)
+		if err != nil {
+			return err
+		}
+		if indexOfSignature(image, signature.Name) >= 0 {
+			return errors.NewAlreadyExists(api.Resource("imagesignatures"), signature.Name)
+		}
+		image.Signatures = append(image.Signatures, *signature)
+		_, err = r.imageRegistry.UpdateImage(ctx, image)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return signature, nil
+}
+
+// Delete removes the named signature from its image.
+func (r *REST) Delete(ctx kapi.Context, name string) (runtime.Object, error) {
+	imageName, _, ok := api.SplitImageSignatureName(name)
+	if !ok {
+		return nil, errors.NewBadRequest("ImageSignatures must be referenced with <image name>@<signature name>")
+	}
+
+	err := kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+		image, err := r.imageRegistry.GetImage(ctx, imageName)
+		if err != nil {
+			return err
+		}
+		i := indexOfSignature(image, name)
+		if i < 0 {
+			return errors.NewNotFound(api.Resource("imagesignatures"), name)
+		}
+		image.Signatures = append(image.Signatures[:i], image.Signatures[i+1:]...)
+		_, err = r.imageRegistry.UpdateImage(ctx, image)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+}
+
+// indexOfSignature returns the index of the named signature in the image or -1.
+func indexOfSignature(image *api.Image, name string) int {
+	for i := range image.Signatures {
+		if image.Signatures[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
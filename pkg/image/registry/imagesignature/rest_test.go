@@ -0,0 +1,108 @@
+package imagesignature
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/image/api"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+)
+
+type fakeImageRegistry struct {
+	image   *api.Image
+	updates int
+}
+
+func (f *fakeImageRegistry) ListImages(ctx kapi.Context, options *kapi.ListOptions) (*api.ImageList, error) {
+	return nil, nil
+}
+func (f *fakeImageRegistry) GetImage(ctx kapi.Context, id string) (*api.Image, error) {
+	if f.image == nil || f.image.Name != id {
+		return nil, errors.NewNotFound(api.Resource("images"), id)
+	}
+	copied, err := kapi.Scheme.DeepCopy(f.image)
+	if err != nil {
+		return nil, err
+	}
+	return copied.(*api.Image), nil
+}
+func (f *fakeImageRegistry) CreateImage(ctx kapi.Context, image *api.Image) error {
+	return nil
+}
+func (f *fakeImageRegistry) UpdateImage(ctx kapi.Context, image *api.Image) (*api.Image, error) {
+	f.updates++
+	f.image = image
+	return image, nil
+}
+func (f *fakeImageRegistry) DeleteImage(ctx kapi.Context, id string) error {
+	return nil
+}
+func (f *fakeImageRegistry) WatchImages(ctx kapi.Context, options *kapi.ListOptions) (watch.Interface, error) {
+	return nil, nil
+}
+
+func TestCreateAndDelete(t *testing.T) {
+	registry := &fakeImageRegistry{
+		image: &api.Image{
+			ObjectMeta:           kapi.ObjectMeta{Name: "sha256:abc"},
+			DockerImageReference: "openshift/origin@sha256:abc",
+		},
+	}
+	storage := NewREST(registry)
+	ctx := kapi.NewContext()
+
+	signature := &api.ImageSignature{
+		ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc@first"},
+		Type:       api.ImageSignatureTypeX509,
+		Content:    []byte("signature"),
+	}
+	if _, err := storage.Create(ctx, signature); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(registry.image.Signatures) != 1 || registry.image.Signatures[0].Name != "sha256:abc@first" {
+		t.Fatalf("unexpected signatures: %#v", registry.image.Signatures)
+	}
+
+	duplicate := &api.ImageSignature{
+		ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc@first"},
+		Type:       api.ImageSignatureTypeX509,
+		Content:    []byte("other"),
+	}
+	if _, err := storage.Create(ctx, duplicate); !errors.IsAlreadyExists(err) {
+		t.Errorf("expected already exists error, got %v", err)
+	}
+
+	missing := &api.ImageSignature{
+		ObjectMeta: kapi.ObjectMeta{Name: "sha256:unknown@first"},
+		Type:       api.ImageSignatureTypeX509,
+		Content:    []byte("signature"),
+	}
+	if _, err := storage.Create(ctx, missing); !errors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+
+	invalid := &api.ImageSignature{ObjectMeta: kapi.ObjectMeta{Name: "sha256:abc"}}
+	if _, err := storage.Create(ctx, invalid); !errors.IsInvalid(err) {
+		t.Errorf("expected invalid error, got %v", err)
+	}
+
+	if _, err := storage.Delete(ctx, "sha256:abc@second"); !errors.IsNotFound(err) {
+		t.Errorf("expected not found error, got %v", err)
+	}
+	if _, err := storage.Delete(ctx, "sha256:abc"); !errors.IsBadRequest(err) {
+		t.Errorf("expected bad request error, got %v", err)
+	}
+	if _, err := storage.Delete(ctx, "sha256:abc@first"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(registry.image.Signatures) != 0 {
+		t.Errorf("unexpected signatures: %#v", registry.image.Signatures)
+	}
+	if registry.updates != 2 {
+		t.Errorf("unexpected number of image updates: %d", registry.updates)
+	}
+}
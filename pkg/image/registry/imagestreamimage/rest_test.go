@@ -39,7 +39,7 @@ func setup(t *testing.T) (*goetcd.Client, *etcdtesting.EtcdTestServer, *REST) {
 	etcdStorage, server := registrytest.NewEtcdStorage(t, "")
 	etcdClient := goetcd.NewClient(server.ClientURLs.StringSlice())
 
-	imageStorage := imageetcd.NewREST(etcdStorage)
+	imageStorage, _ := imageetcd.NewREST(etcdStorage)
 	imageStreamStorage, imageStreamStatus, internalStorage := imagestreametcd.NewREST(etcdStorage, testDefaultRegistry, &fakeSubjectAccessReviewRegistry{})
 
 	imageRegistry := image.NewRegistry(imageStorage)
@@ -88,14 +88,16 @@ func (s *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 
 	image := mapping.Image
 	tag := mapping.Tag
-	if len(tag) == 0 {
-		tag = api.DefaultImageTag
-	}
 
 	if err := s.imageRegistry.CreateImage(ctx, &image); err != nil && !errors.IsAlreadyExists(err) {
 		return nil, err
 	}
 
+	// images mapped without a tag, like the images of a manifest list pushed by digest, are only created
+	if len(tag) == 0 {
+		return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+	}
+
 	next := api.TagEvent{
 		Created:              unversioned.Now(),
 		DockerImageReference: image.DockerImageReference,
@@ -46,7 +46,7 @@ func setup(t *testing.T) (*etcd.Client, *etcdtesting.EtcdTestServer, *REST) {
 	etcdStorage, server := registrytest.NewEtcdStorage(t, "")
 	etcdClient := etcd.NewClient(server.ClientURLs.StringSlice())
 
-	imageStorage := imageetcd.NewREST(etcdStorage)
+	imageStorage, _ := imageetcd.NewREST(etcdStorage)
 	imageStreamStorage, imageStreamStatus, internalStorage := imagestreametcd.NewREST(etcdStorage, testDefaultRegistry, &fakeSubjectAccessReviewRegistry{})
 
 	imageRegistry := image.NewRegistry(imageStorage)
@@ -176,6 +176,52 @@ func TestCreateSuccessWithName(t *testing.T) {
 	}
 }
 
+func TestCreateUntaggedSuccess(t *testing.T) {
+	client, server, storage := setup(t)
+	defer server.Terminate(t)
+
+	initialRepo := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "default", Name: "somerepo"},
+	}
+
+	_, err := client.Create(etcdtest.AddPrefix("/imagestreams/default/somerepo"), runtime.EncodeOrDie(kapi.Codecs.LegacyCodec(v1.SchemeGroupVersion), initialRepo), 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	mapping := validNewMappingWithName()
+	mapping.Tag = ""
+	mapping.Image.DockerImageManifestMediaType = api.DockerManifestSchema2MediaType
+	_, err = storage.Create(kapi.NewDefaultContext(), mapping)
+	if err != nil {
+		t.Fatalf("Unexpected error creating mapping: %#v", err)
+	}
+
+	if _, err := storage.imageRegistry.GetImage(kapi.NewDefaultContext(), "imageID1"); err != nil {
+		t.Errorf("Unexpected error retrieving image: %#v", err)
+	}
+
+	repo, err := storage.imageStreamRegistry.GetImageStream(kapi.NewDefaultContext(), "somerepo")
+	if err != nil {
+		t.Errorf("Unexpected non-nil err: %#v", err)
+	}
+	if len(repo.Status.Tags) != 0 {
+		t.Errorf("Expected the image not to be tagged, got %#v", repo.Status.Tags)
+	}
+}
+
+func TestCreateUntaggedSchema1Invalid(t *testing.T) {
+	_, server, storage := setup(t)
+	defer server.Terminate(t)
+
+	mapping := validNewMappingWithName()
+	mapping.Tag = ""
+	_, err := storage.Create(kapi.NewDefaultContext(), mapping)
+	if !errors.IsInvalid(err) {
+		t.Fatalf("Expected an invalid error, got %#v", err)
+	}
+}
+
 func TestAddExistingImageWithNewTag(t *testing.T) {
 	imageID := "8d812da98d6dd61620343f1a5bf6585b34ad6ed16e5c5f7c7216a525d6aeb772"
 	existingRepo := &api.ImageStream{
@@ -551,6 +597,7 @@ type fakeImageRegistry struct {
 	listImages  func(ctx kapi.Context, options *kapi.ListOptions) (*api.ImageList, error)
 	getImage    func(ctx kapi.Context, id string) (*api.Image, error)
 	createImage func(ctx kapi.Context, image *api.Image) error
+	updateImage func(ctx kapi.Context, image *api.Image) (*api.Image, error)
 	deleteImage func(ctx kapi.Context, id string) error
 	watchImages func(ctx kapi.Context, options *kapi.ListOptions) (watch.Interface, error)
 }
@@ -564,6 +611,9 @@ func (f *fakeImageRegistry) GetImage(ctx kapi.Context, id string) (*api.Image, e
 func (f *fakeImageRegistry) CreateImage(ctx kapi.Context, image *api.Image) error {
 	return f.createImage(ctx, image)
 }
+func (f *fakeImageRegistry) UpdateImage(ctx kapi.Context, image *api.Image) (*api.Image, error) {
+	return f.updateImage(ctx, image)
+}
 func (f *fakeImageRegistry) DeleteImage(ctx kapi.Context, id string) error {
 	return f.deleteImage(ctx, id)
 }
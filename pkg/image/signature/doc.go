@@ -0,0 +1,3 @@
+// Package signature signs images and verifies detached image signatures against
+// trusted x509 certificates and public keys.
+package signature
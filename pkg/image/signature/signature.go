@@ -0,0 +1,145 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"time"
+
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+// ErrNoTrustedSignature is returned when none of the signatures of an image
+// was made by one of the trusted keys.
+var ErrNoTrustedSignature = errors.New("the image has no signature from a trusted key")
+
+// ecdsaSignature is the ASN.1 structure of an ECDSA signature.
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// digestImageName returns the hash that is signed for the named image.
+func digestImageName(imageName string) []byte {
+	sum := sha256.Sum256([]byte(imageName))
+	return sum[:]
+}
+
+// Sign returns the content of an x509 signature of the named image made with the
+// given RSA or ECDSA private key.
+func Sign(signer crypto.Signer, imageName string) ([]byte, error) {
+	switch signer.Public().(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("unsupported private key type %T", signer)
+	}
+	return signer.Sign(rand.Reader, digestImageName(imageName), crypto.SHA256)
+}
+
+// VerifySignature checks that the content of the signature was made for the named
+// image with the private key matching the given public key.
+func VerifySignature(imageName string, signature *api.ImageSignature, key crypto.PublicKey) error {
+	if signature.Type != api.ImageSignatureTypeX509 {
+		return fmt.Errorf("unsupported signature type %q", signature.Type)
+	}
+	hashed := digestImageName(imageName)
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hashed, signature.Content)
+	case *ecdsa.PublicKey:
+		sig := &ecdsaSignature{}
+		rest, err := asn1.Unmarshal(signature.Content, sig)
+		if err != nil {
+			return err
+		}
+		if len(rest) != 0 || sig.R == nil || sig.S == nil {
+			return errors.New("malformed ECDSA signature")
+		}
+		if !ecdsa.Verify(k, hashed, sig.R, sig.S) {
+			return errors.New("ECDSA verification failure")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+// Verify returns nil if at least one signature of the image was made by one of the
+// trusted keys and ErrNoTrustedSignature otherwise. Signatures that belong to
+// another image are ignored.
+func Verify(image *api.Image, keys []crypto.PublicKey) error {
+	for i := range image.Signatures {
+		signature := &image.Signatures[i]
+		if imageName, _, ok := api.SplitImageSignatureName(signature.Name); !ok || imageName != image.Name {
+			continue
+		}
+		for _, key := range keys {
+			if err := VerifySignature(image.Name, signature, key); err == nil {
+				return nil
+			}
+		}
+	}
+	return ErrNoTrustedSignature
+}
+
+// LoadTrustedKeys reads the trusted public keys from the given PEM files.
+func LoadTrustedKeys(files []string) ([]crypto.PublicKey, error) {
+	keys := []crypto.PublicKey{}
+	for _, file := range files {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		fileKeys, err := ParseTrustedKeys(data, time.Now())
+		if err != nil {
+			return nil, fmt.Errorf("error reading trusted keys from %s: %v", file, err)
+		}
+		keys = append(keys, fileKeys...)
+	}
+	return keys, nil
+}
+
+// ParseTrustedKeys returns the public keys of the PEM encoded certificates and
+// public keys in data. Certificates that are not valid at the given time are
+// refused.
+func ParseTrustedKeys(data []byte, now time.Time) ([]crypto.PublicKey, error) {
+	keys := []crypto.PublicKey{}
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		switch block.Type {
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+				return nil, fmt.Errorf("the certificate of %q is only valid from %v to %v", cert.Subject.CommonName, cert.NotBefore, cert.NotAfter)
+			}
+			keys = append(keys, cert.PublicKey)
+		case "PUBLIC KEY":
+			key, err := x509.ParsePKIXPublicKey(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		default:
+			return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("no certificates or public keys found")
+	}
+	return keys, nil
+}
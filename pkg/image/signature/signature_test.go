@@ -0,0 +1,178 @@
+package signature
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+const testImageName = "sha256:958608f8ecc1dc62c93b6c610f3a834dae4220c9642e6e8b4e0f2b3ad7cbd238"
+
+func newCertificate(t *testing.T, key crypto.Signer, notBefore, notAfter time.Time) []byte {
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "signer"},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func newPublicKey(t *testing.T, key crypto.Signer) []byte {
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func newSignedImage(t *testing.T, signer crypto.Signer) *api.Image {
+	content, err := Sign(signer, testImageName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &api.Image{
+		ObjectMeta: kapi.ObjectMeta{Name: testImageName},
+		Signatures: []api.ImageSignature{
+			{
+				ObjectMeta: kapi.ObjectMeta{Name: api.JoinImageSignatureName(testImageName, "signer")},
+				Type:       api.ImageSignatureTypeX509,
+				Content:    content,
+			},
+		},
+	}
+}
+
+func TestSignAndVerify(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	tests := map[string]struct {
+		signer  crypto.Signer
+		trusted []byte
+		err     bool
+	}{
+		"rsa certificate": {
+			signer:  rsaKey,
+			trusted: newCertificate(t, rsaKey, now.Add(-time.Hour), now.Add(time.Hour)),
+		},
+		"ecdsa certificate": {
+			signer:  ecdsaKey,
+			trusted: newCertificate(t, ecdsaKey, now.Add(-time.Hour), now.Add(time.Hour)),
+		},
+		"rsa public key": {
+			signer:  rsaKey,
+			trusted: newPublicKey(t, rsaKey),
+		},
+		"ecdsa public key among others": {
+			signer:  ecdsaKey,
+			trusted: append(newPublicKey(t, rsaKey), newPublicKey(t, ecdsaKey)...),
+		},
+		"untrusted key": {
+			signer:  otherKey,
+			trusted: append(newPublicKey(t, rsaKey), newPublicKey(t, ecdsaKey)...),
+			err:     true,
+		},
+	}
+
+	for name, test := range tests {
+		keys, err := ParseTrustedKeys(test.trusted, now)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		image := newSignedImage(t, test.signer)
+		err = Verify(image, keys)
+		if test.err {
+			if err != ErrNoTrustedSignature {
+				t.Errorf("%s: expected untrusted signature, got %v", name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+
+		// a signature is only valid for the image it was made for
+		image.Name = "sha256:other"
+		image.Signatures[0].Name = api.JoinImageSignatureName(image.Name, "signer")
+		if err := Verify(image, keys); err != ErrNoTrustedSignature {
+			t.Errorf("%s: expected the signature of another image to be refused, got %v", name, err)
+		}
+	}
+}
+
+func TestParseTrustedKeys(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+
+	tests := map[string]struct {
+		data []byte
+		err  string
+	}{
+		"expired certificate": {
+			data: newCertificate(t, key, now.Add(-2*time.Hour), now.Add(-time.Hour)),
+			err:  "the certificate of \"signer\" is only valid",
+		},
+		"future certificate": {
+			data: newCertificate(t, key, now.Add(time.Hour), now.Add(2*time.Hour)),
+			err:  "the certificate of \"signer\" is only valid",
+		},
+		"private key": {
+			data: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: []byte("key")}),
+			err:  "unsupported PEM block type",
+		},
+		"empty": {
+			data: []byte("not pem"),
+			err:  "no certificates or public keys found",
+		},
+	}
+	for name, test := range tests {
+		if _, err := ParseTrustedKeys(test.data, now); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error %q, got %v", name, test.err, err)
+		}
+	}
+}
+
+func TestVerifySignatureType(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	image := newSignedImage(t, key)
+	image.Signatures[0].Type = "gpg"
+	if err := VerifySignature(image.Name, &image.Signatures[0], key.Public()); err == nil {
+		t.Errorf("expected an error for an unsupported signature type")
+	}
+}
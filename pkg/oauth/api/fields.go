@@ -39,3 +39,12 @@ func OAuthClientAuthorizationToSelectableFields(obj *OAuthClientAuthorization) f
 		"userUID":       obj.UserUID,
 	}
 }
+
+// OAuthTOTPEnrollmentToSelectableFields returns a label set that represents the object
+func OAuthTOTPEnrollmentToSelectableFields(obj *OAuthTOTPEnrollment) fields.Set {
+	return fields.Set{
+		"metadata.name": obj.Name,
+		"userName":      obj.UserName,
+		"userUID":       obj.UserUID,
+	}
+}
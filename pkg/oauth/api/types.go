@@ -32,8 +32,16 @@ type OAuthAccessToken struct {
 
 	// RefreshToken is the value by which this token can be renewed. Can be blank.
 	RefreshToken string
+
+	// LastUsedTimestamp is the approximate time the token was last used to authenticate. It is
+	// nil if the token was never used.
+	LastUsedTimestamp *unversioned.Time
 }
 
+// UserOAuthAccessToken is a virtual resource that mirrors the OAuthAccessTokens of the current
+// user, so that users can list and revoke their own tokens.
+type UserOAuthAccessToken OAuthAccessToken
+
 type OAuthAuthorizeToken struct {
 	unversioned.TypeMeta
 	kapi.ObjectMeta
@@ -93,12 +101,53 @@ type OAuthClientAuthorization struct {
 	Scopes []string
 }
 
+// OAuthTOTPEnrollment holds the time-based one-time password secret of a user. It is named after
+// the user it belongs to.
+type OAuthTOTPEnrollment struct {
+	unversioned.TypeMeta
+	kapi.ObjectMeta
+
+	// UserName is the user name that enrolled
+	UserName string
+
+	// UserUID is the unique UID associated with this enrollment. UserUID and UserName
+	// must both match for this enrollment to be valid.
+	UserUID string
+
+	// Secret is the base32 encoded key shared with the authenticator app of the user
+	Secret string
+
+	// Confirmed is true once the user proved that the authenticator app generates valid codes.
+	// Unconfirmed enrollments are not used to authenticate.
+	Confirmed bool
+
+	// RecoveryCodeHashes are the hashes of the single use codes the user may give instead of a
+	// generated code. A hash is removed when its code is used.
+	RecoveryCodeHashes []string
+
+	// LastUsedTimeStep is the time step of the last accepted code, so that a code can't be used twice
+	LastUsedTimeStep int64
+
+	// FailedAttempts is the number of invalid codes given since the last accepted code or lockout
+	FailedAttempts int
+
+	// LockedUntil is set when too many invalid codes were given in a row. No code is accepted
+	// before that time.
+	LockedUntil *unversioned.Time
+}
+
 type OAuthAccessTokenList struct {
 	unversioned.TypeMeta
 	unversioned.ListMeta
 	Items []OAuthAccessToken
 }
 
+type UserOAuthAccessTokenList struct {
+	unversioned.TypeMeta
+	unversioned.ListMeta
+	Items []UserOAuthAccessToken
+}
+
 type OAuthAuthorizeTokenList struct {
 	unversioned.TypeMeta
 	unversioned.ListMeta
@@ -116,3 +165,9 @@ type OAuthClientAuthorizationList struct {
 	unversioned.ListMeta
 	Items []OAuthClientAuthorization
 }
+
+type OAuthTOTPEnrollmentList struct {
+	unversioned.TypeMeta
+	unversioned.ListMeta
+	Items []OAuthTOTPEnrollment
+}
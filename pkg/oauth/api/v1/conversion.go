@@ -14,6 +14,12 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 		panic(err)
 	}
 
+	if err := scheme.AddFieldLabelConversionFunc("v1", "UserOAuthAccessToken",
+		oapi.GetFieldLabelConversionFunc(api.OAuthAccessTokenToSelectableFields(&api.OAuthAccessToken{}), nil),
+	); err != nil {
+		panic(err)
+	}
+
 	if err := scheme.AddFieldLabelConversionFunc("v1", "OAuthAuthorizeToken",
 		oapi.GetFieldLabelConversionFunc(api.OAuthAuthorizeTokenToSelectableFields(&api.OAuthAuthorizeToken{}), nil),
 	); err != nil {
@@ -31,4 +37,10 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 	); err != nil {
 		panic(err)
 	}
+
+	if err := scheme.AddFieldLabelConversionFunc("v1", "OAuthTOTPEnrollment",
+		oapi.GetFieldLabelConversionFunc(api.OAuthTOTPEnrollmentToSelectableFields(&api.OAuthTOTPEnrollment{}), nil),
+	); err != nil {
+		panic(err)
+	}
 }
@@ -36,4 +36,18 @@ func TestFieldSelectorConversions(t *testing.T) {
 		// Ensure previously supported labels have conversions. DO NOT REMOVE THINGS FROM THIS LIST
 		"clientName", "userName", "userUID",
 	)
+
+	testutil.CheckFieldLabelConversions(t, "v1", "OAuthTOTPEnrollment",
+		// Ensure all currently returned labels are supported
+		api.OAuthTOTPEnrollmentToSelectableFields(&api.OAuthTOTPEnrollment{}),
+		// Ensure previously supported labels have conversions. DO NOT REMOVE THINGS FROM THIS LIST
+		"userName", "userUID",
+	)
+
+	testutil.CheckFieldLabelConversions(t, "v1", "UserOAuthAccessToken",
+		// Ensure all currently returned labels are supported
+		api.OAuthAccessTokenToSelectableFields(&api.OAuthAccessToken{}),
+		// Ensure previously supported labels have conversions. DO NOT REMOVE THINGS FROM THIS LIST
+		"clientName", "userName", "userUID", "authorizeToken",
+	)
 }
@@ -20,12 +20,16 @@ func addKnownTypes(scheme *runtime.Scheme) {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&OAuthAccessToken{},
 		&OAuthAccessTokenList{},
+		&UserOAuthAccessToken{},
+		&UserOAuthAccessTokenList{},
 		&OAuthAuthorizeToken{},
 		&OAuthAuthorizeTokenList{},
 		&OAuthClient{},
 		&OAuthClientList{},
 		&OAuthClientAuthorization{},
 		&OAuthClientAuthorizationList{},
+		&OAuthTOTPEnrollment{},
+		&OAuthTOTPEnrollmentList{},
 	)
 }
 
@@ -37,3 +41,7 @@ func (obj *OAuthAuthorizeTokenList) GetObjectKind() unversioned.ObjectKind
 func (obj *OAuthAuthorizeToken) GetObjectKind() unversioned.ObjectKind          { return &obj.TypeMeta }
 func (obj *OAuthAccessTokenList) GetObjectKind() unversioned.ObjectKind         { return &obj.TypeMeta }
 func (obj *OAuthAccessToken) GetObjectKind() unversioned.ObjectKind             { return &obj.TypeMeta }
+func (obj *UserOAuthAccessTokenList) GetObjectKind() unversioned.ObjectKind     { return &obj.TypeMeta }
+func (obj *UserOAuthAccessToken) GetObjectKind() unversioned.ObjectKind         { return &obj.TypeMeta }
+func (obj *OAuthTOTPEnrollmentList) GetObjectKind() unversioned.ObjectKind      { return &obj.TypeMeta }
+func (obj *OAuthTOTPEnrollment) GetObjectKind() unversioned.ObjectKind          { return &obj.TypeMeta }
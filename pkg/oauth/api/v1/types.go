@@ -32,8 +32,16 @@ type OAuthAccessToken struct {
 
 	// RefreshToken is the value by which this token can be renewed. Can be blank.
 	RefreshToken string `json:"refreshToken,omitempty" description:"optional value by which this token can be renewed"`
+
+	// LastUsedTimestamp is the approximate time the token was last used to authenticate. It is
+	// nil if the token was never used.
+	LastUsedTimestamp *unversioned.Time `json:"lastUsedTimestamp,omitempty" description:"approximate time the token was last used to authenticate"`
 }
 
+// UserOAuthAccessToken is a virtual resource that mirrors the OAuthAccessTokens of the current
+// user, so that users can list and revoke their own tokens.
+type UserOAuthAccessToken OAuthAccessToken
+
 type OAuthAuthorizeToken struct {
 	unversioned.TypeMeta `json:",inline"`
 	kapi.ObjectMeta      `json:"metadata,omitempty"`
@@ -93,12 +101,53 @@ type OAuthClientAuthorization struct {
 	Scopes []string `json:"scopes,omitempty" description:"list of granted scopes"`
 }
 
+// OAuthTOTPEnrollment holds the time-based one-time password secret of a user. It is named after
+// the user it belongs to.
+type OAuthTOTPEnrollment struct {
+	unversioned.TypeMeta `json:",inline"`
+	kapi.ObjectMeta      `json:"metadata,omitempty"`
+
+	// UserName is the user name that enrolled
+	UserName string `json:"userName,omitempty" description:"user name that enrolled"`
+
+	// UserUID is the unique UID associated with this enrollment. UserUID and UserName
+	// must both match for this enrollment to be valid.
+	UserUID string `json:"userUID,omitempty" description:"unique UID associated with this enrollment. userUID and userName must both match for this enrollment to be valid"`
+
+	// Secret is the base32 encoded key shared with the authenticator app of the user
+	Secret string `json:"secret,omitempty" description:"base32 encoded key shared with the authenticator app of the user"`
+
+	// Confirmed is true once the user proved that the authenticator app generates valid codes.
+	// Unconfirmed enrollments are not used to authenticate.
+	Confirmed bool `json:"confirmed,omitempty" description:"true once the user proved that the authenticator app generates valid codes"`
+
+	// RecoveryCodeHashes are the hashes of the single use codes the user may give instead of a
+	// generated code. A hash is removed when its code is used.
+	RecoveryCodeHashes []string `json:"recoveryCodeHashes,omitempty" description:"hashes of the unused single use recovery codes"`
+
+	// LastUsedTimeStep is the time step of the last accepted code, so that a code can't be used twice
+	LastUsedTimeStep int64 `json:"lastUsedTimeStep,omitempty" description:"time step of the last accepted code"`
+
+	// FailedAttempts is the number of invalid codes given since the last accepted code or lockout
+	FailedAttempts int `json:"failedAttempts,omitempty" description:"number of invalid codes given since the last accepted code or lockout"`
+
+	// LockedUntil is set when too many invalid codes were given in a row. No code is accepted
+	// before that time.
+	LockedUntil *unversioned.Time `json:"lockedUntil,omitempty" description:"time before which no code is accepted, set when too many invalid codes were given in a row"`
+}
+
 type OAuthAccessTokenList struct {
 	unversioned.TypeMeta `json:",inline"`
 	unversioned.ListMeta `json:"metadata,omitempty"`
 	Items                []OAuthAccessToken `json:"items" description:"list of oauth access tokens"`
 }
 
+type UserOAuthAccessTokenList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+	Items                []UserOAuthAccessToken `json:"items" description:"list of oauth access tokens of the current user"`
+}
+
 type OAuthAuthorizeTokenList struct {
 	unversioned.TypeMeta `json:",inline"`
 	unversioned.ListMeta `json:"metadata,omitempty"`
@@ -116,3 +165,9 @@ type OAuthClientAuthorizationList struct {
 	unversioned.ListMeta `json:"metadata,omitempty"`
 	Items                []OAuthClientAuthorization `json:"items" description:"list of oauth client authorizations"`
 }
+
+type OAuthTOTPEnrollmentList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+	Items                []OAuthTOTPEnrollment `json:"items" description:"list of time-based one-time password enrollments"`
+}
@@ -32,6 +32,10 @@ type OAuthAccessToken struct {
 
 	// RefreshToken is the value by which this token can be renewed. Can be blank.
 	RefreshToken string `json:"refreshToken,omitempty"`
+
+	// LastUsedTimestamp is the approximate time the token was last used to authenticate. It is
+	// nil if the token was never used.
+	LastUsedTimestamp *unversioned.Time `json:"lastUsedTimestamp,omitempty"`
 }
 
 type OAuthAuthorizeToken struct {
@@ -1,14 +1,17 @@
 package validation
 
 import (
+	"encoding/base32"
 	"fmt"
 	"net/url"
 	"strings"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	oapi "github.com/openshift/origin/pkg/api"
+	"github.com/openshift/origin/pkg/authorization/authorizer/scope"
 	"github.com/openshift/origin/pkg/oauth/api"
 	uservalidation "github.com/openshift/origin/pkg/user/api/validation"
 )
@@ -60,10 +63,26 @@ func ValidateAccessToken(accessToken *api.OAuthAccessToken) field.ErrorList {
 	if ok, msg := ValidateRedirectURI(accessToken.RedirectURI); !ok {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("redirectURI"), accessToken.RedirectURI, msg))
 	}
+	allErrs = append(allErrs, ValidateScopes(accessToken.Scopes, field.NewPath("scopes"))...)
 
 	return allErrs
 }
 
+// ValidateAccessTokenUpdate validates an update of an access token. Only the time the token was
+// last used may change.
+func ValidateAccessTokenUpdate(newToken, oldToken *api.OAuthAccessToken) field.ErrorList {
+	allErrs := ValidateAccessToken(newToken)
+	allErrs = append(allErrs, validation.ValidateObjectMetaUpdate(&newToken.ObjectMeta, &oldToken.ObjectMeta, field.NewPath("metadata"))...)
+
+	copied := *oldToken
+	copied.ObjectMeta = newToken.ObjectMeta
+	copied.LastUsedTimestamp = newToken.LastUsedTimestamp
+	if !kapi.Semantic.DeepEqual(&copied, newToken) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath(""), "only lastUsedTimestamp may be updated"))
+	}
+	return allErrs
+}
+
 func ValidateAuthorizeToken(authorizeToken *api.OAuthAuthorizeToken) field.ErrorList {
 	allErrs := validation.ValidateObjectMeta(&authorizeToken.ObjectMeta, false, ValidateTokenName, field.NewPath("metadata"))
 	allErrs = append(allErrs, ValidateClientNameField(authorizeToken.ClientName, field.NewPath("clientName"))...)
@@ -75,6 +94,7 @@ func ValidateAuthorizeToken(authorizeToken *api.OAuthAuthorizeToken) field.Error
 	if ok, msg := ValidateRedirectURI(authorizeToken.RedirectURI); !ok {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("redirectURI"), authorizeToken.RedirectURI, msg))
 	}
+	allErrs = append(allErrs, ValidateScopes(authorizeToken.Scopes, field.NewPath("scopes"))...)
 
 	return allErrs
 }
@@ -136,6 +156,7 @@ func ValidateClientAuthorization(clientAuthorization *api.OAuthClientAuthorizati
 	if len(clientAuthorization.UserUID) == 0 {
 		allErrs = append(allErrs, field.Required(field.NewPath("useruid"), ""))
 	}
+	allErrs = append(allErrs, ValidateScopes(clientAuthorization.Scopes, field.NewPath("scopes"))...)
 
 	return allErrs
 }
@@ -158,6 +179,75 @@ func ValidateClientAuthorizationUpdate(newAuth *api.OAuthClientAuthorization, ol
 	return allErrs
 }
 
+// MinTOTPSecretLength is the minimum number of bytes of a TOTP secret, as recommended by RFC 4226
+const MinTOTPSecretLength = 16
+
+func ValidateTOTPEnrollment(enrollment *api.OAuthTOTPEnrollment) field.ErrorList {
+	allErrs := validation.ValidateObjectMeta(&enrollment.ObjectMeta, false, uservalidation.ValidateUserName, field.NewPath("metadata"))
+	allErrs = append(allErrs, ValidateUserNameField(enrollment.UserName, field.NewPath("userName"))...)
+	if len(enrollment.UserName) > 0 && enrollment.Name != enrollment.UserName {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("metadata", "name"), enrollment.Name, "must match userName"))
+	}
+
+	if len(enrollment.UserUID) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("userUID"), ""))
+	}
+
+	if len(enrollment.Secret) == 0 {
+		allErrs = append(allErrs, field.Required(field.NewPath("secret"), ""))
+	} else if key, err := base32.StdEncoding.DecodeString(enrollment.Secret); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("secret"), "", "must be base32 encoded"))
+	} else if len(key) < MinTOTPSecretLength {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("secret"), "", fmt.Sprintf("must be at least %d bytes long", MinTOTPSecretLength)))
+	}
+
+	for i, hash := range enrollment.RecoveryCodeHashes {
+		if len(hash) == 0 {
+			allErrs = append(allErrs, field.Required(field.NewPath("recoveryCodeHashes").Index(i), ""))
+		}
+	}
+	if enrollment.LastUsedTimeStep < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("lastUsedTimeStep"), enrollment.LastUsedTimeStep, "must be greater than or equal to 0"))
+	}
+	if enrollment.FailedAttempts < 0 {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("failedAttempts"), enrollment.FailedAttempts, "must be greater than or equal to 0"))
+	}
+
+	return allErrs
+}
+
+func ValidateTOTPEnrollmentUpdate(newEnrollment *api.OAuthTOTPEnrollment, oldEnrollment *api.OAuthTOTPEnrollment) field.ErrorList {
+	allErrs := ValidateTOTPEnrollment(newEnrollment)
+
+	allErrs = append(allErrs, validation.ValidateObjectMetaUpdate(&newEnrollment.ObjectMeta, &oldEnrollment.ObjectMeta, field.NewPath("metadata"))...)
+
+	if oldEnrollment.UserName != newEnrollment.UserName {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("userName"), newEnrollment.UserName, "userName is not a mutable field"))
+	}
+	if oldEnrollment.UserUID != newEnrollment.UserUID {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("userUID"), newEnrollment.UserUID, "userUID is not a mutable field"))
+	}
+	if oldEnrollment.Confirmed && oldEnrollment.Secret != newEnrollment.Secret {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("secret"), "the secret of a confirmed enrollment may not be changed"))
+	}
+	if newEnrollment.LastUsedTimeStep < oldEnrollment.LastUsedTimeStep {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("lastUsedTimeStep"), newEnrollment.LastUsedTimeStep, "may not decrease"))
+	}
+
+	return allErrs
+}
+
+// ValidateScopes returns an error for every scope that is not part of the scope language of the authorizer.
+func ValidateScopes(scopes []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, s := range scopes {
+		if err := scope.ValidateScopes([]string{s}); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), s, err.Error()))
+		}
+	}
+	return allErrs
+}
+
 func ValidateClientNameField(value string, fldPath *field.Path) field.ErrorList {
 	if len(value) == 0 {
 		return field.ErrorList{field.Required(fldPath, "")}
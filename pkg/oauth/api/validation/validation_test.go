@@ -2,8 +2,10 @@ package validation
 
 import (
 	"testing"
+	"time"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	oapi "github.com/openshift/origin/pkg/oauth/api"
@@ -196,6 +198,7 @@ func TestValidateAccessTokens(t *testing.T) {
 		ClientName: "myclient",
 		UserName:   "myusername",
 		UserUID:    "myuseruid",
+		Scopes:     []string{"user:info", "role:view:myproject"},
 	})
 	if len(errs) != 0 {
 		t.Errorf("expected success: %v", errs)
@@ -225,6 +228,17 @@ func TestValidateAccessTokens(t *testing.T) {
 			T: field.ErrorTypeForbidden,
 			F: "metadata.namespace",
 		},
+		"invalid scope": {
+			Token: oapi.OAuthAccessToken{
+				ObjectMeta: api.ObjectMeta{Name: "accessTokenNameWithMinimumLength"},
+				ClientName: "myclient",
+				UserName:   "myusername",
+				UserUID:    "myuseruid",
+				Scopes:     []string{"user:info", "unknown"},
+			},
+			T: field.ErrorTypeInvalid,
+			F: "scopes[1]",
+		},
 	}
 	for k, v := range errorCases {
 		errs := ValidateAccessToken(&v.Token)
@@ -243,6 +257,36 @@ func TestValidateAccessTokens(t *testing.T) {
 	}
 }
 
+func TestValidateAccessTokenUpdate(t *testing.T) {
+	valid := &oapi.OAuthAccessToken{
+		ObjectMeta: api.ObjectMeta{Name: "accessTokenNameWithMinimumLength", ResourceVersion: "1"},
+		ClientName: "myclient",
+		UserName:   "myusername",
+		UserUID:    "myuseruid",
+		Scopes:     []string{"user:info"},
+	}
+
+	used := *valid
+	used.LastUsedTimestamp = &unversioned.Time{Time: time.Now()}
+	if errs := ValidateAccessTokenUpdate(&used, valid); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	errorCases := map[string]func(token *oapi.OAuthAccessToken){
+		"changed user":   func(token *oapi.OAuthAccessToken) { token.UserName = "otheruser" },
+		"changed scopes": func(token *oapi.OAuthAccessToken) { token.Scopes = []string{"user:full"} },
+		"changed expiry": func(token *oapi.OAuthAccessToken) { token.ExpiresIn = 1000 },
+	}
+	for k, modify := range errorCases {
+		token := *valid
+		modify(&token)
+		errs := ValidateAccessTokenUpdate(&token, valid)
+		if len(errs) != 1 || errs[0].Type != field.ErrorTypeForbidden {
+			t.Errorf("%s: expected a forbidden error, got %v", k, errs)
+		}
+	}
+}
+
 func TestValidateAuthorizeTokens(t *testing.T) {
 	errs := ValidateAuthorizeToken(&oapi.OAuthAuthorizeToken{
 		ObjectMeta: api.ObjectMeta{Name: "authorizeTokenNameWithMinimumLength"},
@@ -322,3 +366,84 @@ func TestValidateAuthorizeTokens(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateTOTPEnrollment(t *testing.T) {
+	valid := &oapi.OAuthTOTPEnrollment{
+		ObjectMeta: api.ObjectMeta{Name: "myusername", ResourceVersion: "1"},
+		UserName:   "myusername",
+		UserUID:    "myuseruid",
+		Secret:     "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+	}
+	if errs := ValidateTOTPEnrollment(valid); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	errorCases := map[string]struct {
+		Enrollment oapi.OAuthTOTPEnrollment
+		T          field.ErrorType
+		F          string
+	}{
+		"name differs from user": {
+			Enrollment: oapi.OAuthTOTPEnrollment{ObjectMeta: api.ObjectMeta{Name: "other"}, UserName: "myusername", UserUID: "myuseruid", Secret: valid.Secret},
+			T:          field.ErrorTypeInvalid,
+			F:          "metadata.name",
+		},
+		"missing user uid": {
+			Enrollment: oapi.OAuthTOTPEnrollment{ObjectMeta: api.ObjectMeta{Name: "myusername"}, UserName: "myusername", Secret: valid.Secret},
+			T:          field.ErrorTypeRequired,
+			F:          "userUID",
+		},
+		"missing secret": {
+			Enrollment: oapi.OAuthTOTPEnrollment{ObjectMeta: api.ObjectMeta{Name: "myusername"}, UserName: "myusername", UserUID: "myuseruid"},
+			T:          field.ErrorTypeRequired,
+			F:          "secret",
+		},
+		"secret not base32": {
+			Enrollment: oapi.OAuthTOTPEnrollment{ObjectMeta: api.ObjectMeta{Name: "myusername"}, UserName: "myusername", UserUID: "myuseruid", Secret: "not base32!"},
+			T:          field.ErrorTypeInvalid,
+			F:          "secret",
+		},
+		"short secret": {
+			Enrollment: oapi.OAuthTOTPEnrollment{ObjectMeta: api.ObjectMeta{Name: "myusername"}, UserName: "myusername", UserUID: "myuseruid", Secret: "JBSWY3DPEHPK3PXP"},
+			T:          field.ErrorTypeInvalid,
+			F:          "secret",
+		},
+		"negative failed attempts": {
+			Enrollment: oapi.OAuthTOTPEnrollment{ObjectMeta: api.ObjectMeta{Name: "myusername"}, UserName: "myusername", UserUID: "myuseruid", Secret: valid.Secret, FailedAttempts: -1},
+			T:          field.ErrorTypeInvalid,
+			F:          "failedAttempts",
+		},
+	}
+	for k, v := range errorCases {
+		errs := ValidateTOTPEnrollment(&v.Enrollment)
+		if len(errs) != 1 {
+			t.Errorf("%s: expected one error, got %v", k, errs)
+			continue
+		}
+		if errs[0].Type != v.T || errs[0].Field != v.F {
+			t.Errorf("%s: expected %s on %s, got %v", k, v.T, v.F, errs[0])
+		}
+	}
+
+	confirmed := *valid
+	confirmed.Confirmed = true
+	used := confirmed
+	used.LastUsedTimeStep = 100
+	used.RecoveryCodeHashes = []string{"hash"}
+	if errs := ValidateTOTPEnrollmentUpdate(&used, &confirmed); len(errs) != 0 {
+		t.Errorf("expected success: %v", errs)
+	}
+
+	updateErrorCases := map[string]func(enrollment *oapi.OAuthTOTPEnrollment){
+		"changed user uid":         func(enrollment *oapi.OAuthTOTPEnrollment) { enrollment.UserUID = "otheruid" },
+		"changed confirmed secret": func(enrollment *oapi.OAuthTOTPEnrollment) { enrollment.Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" },
+		"reused time step":         func(enrollment *oapi.OAuthTOTPEnrollment) { enrollment.LastUsedTimeStep = 50 },
+	}
+	for k, modify := range updateErrorCases {
+		enrollment := used
+		modify(&enrollment)
+		if errs := ValidateTOTPEnrollmentUpdate(&enrollment, &used); len(errs) != 1 {
+			t.Errorf("%s: expected one error, got %v", k, errs)
+		}
+	}
+}
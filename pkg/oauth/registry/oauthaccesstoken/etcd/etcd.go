@@ -20,7 +20,7 @@ import (
 
 // rest implements a RESTStorage for access tokens against etcd
 type REST struct {
-	// Cannot inline because we don't want the Update function
+	// Cannot inline because we don't want the Watch function
 	store *etcdgeneric.Etcd
 }
 
@@ -44,6 +44,10 @@ func NewREST(s storage.Interface, backends ...storage.Interface) *REST {
 			return oauthaccesstoken.Matcher(label, field)
 		},
 		TTLFunc: func(obj runtime.Object, existing uint64, update bool) (uint64, error) {
+			// updates must not extend the lifetime of the token
+			if update {
+				return existing, nil
+			}
 			token := obj.(*api.OAuthAccessToken)
 			expires := uint64(token.ExpiresIn)
 			return expires, nil
@@ -54,6 +58,7 @@ func NewREST(s storage.Interface, backends ...storage.Interface) *REST {
 	}
 
 	store.CreateStrategy = oauthaccesstoken.Strategy
+	store.UpdateStrategy = oauthaccesstoken.Strategy
 
 	if len(backends) > 0 {
 		// Build identical stores that talk to a single etcd, so we can verify the token is distributed after creation
@@ -94,6 +99,10 @@ func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 	return r.store.Create(ctx, obj)
 }
 
+func (r *REST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
+	return r.store.Update(ctx, obj)
+}
+
 func (r *REST) Delete(ctx kapi.Context, name string, options *kapi.DeleteOptions) (runtime.Object, error) {
 	return r.store.Delete(ctx, name, options)
 }
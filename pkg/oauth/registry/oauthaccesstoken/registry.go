@@ -15,6 +15,8 @@ type Registry interface {
 	GetAccessToken(ctx kapi.Context, name string) (*api.OAuthAccessToken, error)
 	// CreateAccessToken creates a new access token.
 	CreateAccessToken(ctx kapi.Context, token *api.OAuthAccessToken) (*api.OAuthAccessToken, error)
+	// UpdateAccessToken updates an access token.
+	UpdateAccessToken(ctx kapi.Context, token *api.OAuthAccessToken) (*api.OAuthAccessToken, error)
 	// DeleteAccessToken deletes an access token.
 	DeleteAccessToken(ctx kapi.Context, name string) error
 }
@@ -24,6 +26,7 @@ type Storage interface {
 	rest.Getter
 	rest.Lister
 	rest.Creater
+	rest.Updater
 	rest.GracefulDeleter
 }
 
@@ -62,6 +65,14 @@ func (s *storage) CreateAccessToken(ctx kapi.Context, token *api.OAuthAccessToke
 	return obj.(*api.OAuthAccessToken), nil
 }
 
+func (s *storage) UpdateAccessToken(ctx kapi.Context, token *api.OAuthAccessToken) (*api.OAuthAccessToken, error) {
+	obj, _, err := s.Update(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*api.OAuthAccessToken), nil
+}
+
 func (s *storage) DeleteAccessToken(ctx kapi.Context, name string) error {
 	_, err := s.Delete(ctx, name, nil)
 	if err != nil {
@@ -42,6 +42,11 @@ func (strategy) Validate(ctx kapi.Context, obj runtime.Object) field.ErrorList {
 	return validation.ValidateAccessToken(token)
 }
 
+// ValidateUpdate validates an update of a token
+func (strategy) ValidateUpdate(ctx kapi.Context, obj runtime.Object, old runtime.Object) field.ErrorList {
+	return validation.ValidateAccessTokenUpdate(obj.(*api.OAuthAccessToken), old.(*api.OAuthAccessToken))
+}
+
 // AllowCreateOnUpdate is false for OAuth objects
 func (strategy) AllowCreateOnUpdate() bool {
 	return false
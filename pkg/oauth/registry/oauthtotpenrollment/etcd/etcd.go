@@ -0,0 +1,50 @@
+package etcd
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthtotpenrollment"
+	"github.com/openshift/origin/pkg/util"
+)
+
+// REST implements a RESTStorage for TOTP enrollments against etcd
+type REST struct {
+	etcdgeneric.Etcd
+}
+
+const EtcdPrefix = "/oauth/totpenrollments"
+
+// NewREST returns a RESTStorage object that will work against TOTP enrollments
+func NewREST(s storage.Interface) *REST {
+	store := &etcdgeneric.Etcd{
+		NewFunc:     func() runtime.Object { return &api.OAuthTOTPEnrollment{} },
+		NewListFunc: func() runtime.Object { return &api.OAuthTOTPEnrollmentList{} },
+		KeyRootFunc: func(ctx kapi.Context) string {
+			return EtcdPrefix
+		},
+		KeyFunc: func(ctx kapi.Context, name string) (string, error) {
+			return util.NoNamespaceKeyFunc(ctx, EtcdPrefix, name)
+		},
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return obj.(*api.OAuthTOTPEnrollment).Name, nil
+		},
+		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
+			return oauthtotpenrollment.Matcher(label, field)
+		},
+		QualifiedResource: api.Resource("oauthtotpenrollments"),
+
+		Storage: s,
+	}
+
+	store.CreateStrategy = oauthtotpenrollment.Strategy
+	store.UpdateStrategy = oauthtotpenrollment.Strategy
+
+	return &REST{*store}
+}
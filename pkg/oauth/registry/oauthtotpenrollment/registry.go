@@ -0,0 +1,63 @@
+package oauthtotpenrollment
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/rest"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// Registry is an interface for things that know how to store OAuthTOTPEnrollment objects.
+type Registry interface {
+	// GetTOTPEnrollment retrieves the enrollment of the named user.
+	GetTOTPEnrollment(ctx kapi.Context, name string) (*api.OAuthTOTPEnrollment, error)
+	// CreateTOTPEnrollment creates a new enrollment.
+	CreateTOTPEnrollment(ctx kapi.Context, enrollment *api.OAuthTOTPEnrollment) (*api.OAuthTOTPEnrollment, error)
+	// UpdateTOTPEnrollment updates an enrollment.
+	UpdateTOTPEnrollment(ctx kapi.Context, enrollment *api.OAuthTOTPEnrollment) (*api.OAuthTOTPEnrollment, error)
+	// DeleteTOTPEnrollment deletes an enrollment.
+	DeleteTOTPEnrollment(ctx kapi.Context, name string) error
+}
+
+// storage puts strong typing around storage calls
+type storage struct {
+	rest.StandardStorage
+}
+
+// NewRegistry returns a new Registry interface for the given Storage. Any mismatched
+// types will panic.
+func NewRegistry(s rest.StandardStorage) Registry {
+	return &storage{s}
+}
+
+func (s *storage) GetTOTPEnrollment(ctx kapi.Context, name string) (*api.OAuthTOTPEnrollment, error) {
+	obj, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*api.OAuthTOTPEnrollment), nil
+}
+
+func (s *storage) CreateTOTPEnrollment(ctx kapi.Context, enrollment *api.OAuthTOTPEnrollment) (*api.OAuthTOTPEnrollment, error) {
+	obj, err := s.Create(ctx, enrollment)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*api.OAuthTOTPEnrollment), nil
+}
+
+func (s *storage) UpdateTOTPEnrollment(ctx kapi.Context, enrollment *api.OAuthTOTPEnrollment) (*api.OAuthTOTPEnrollment, error) {
+	obj, _, err := s.Update(ctx, enrollment)
+	if err != nil {
+		return nil, err
+	}
+	return obj.(*api.OAuthTOTPEnrollment), nil
+}
+
+func (s *storage) DeleteTOTPEnrollment(ctx kapi.Context, name string) error {
+	_, err := s.Delete(ctx, name, nil)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package oauthtotpenrollment
+
+import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/api/validation"
+)
+
+// strategy implements behavior for OAuthTOTPEnrollment objects
+type strategy struct {
+	runtime.ObjectTyper
+}
+
+// Strategy is the default logic that applies when creating or updating OAuthTOTPEnrollment
+// objects via the REST API.
+var Strategy = strategy{kapi.Scheme}
+
+func (strategy) PrepareForUpdate(obj, old runtime.Object) {}
+
+// NamespaceScoped is false for OAuth objects
+func (strategy) NamespaceScoped() bool {
+	return false
+}
+
+func (strategy) GenerateName(base string) string {
+	return base
+}
+
+func (strategy) PrepareForCreate(obj runtime.Object) {
+	enrollment := obj.(*api.OAuthTOTPEnrollment)
+	enrollment.Name = enrollment.UserName
+}
+
+// Canonicalize normalizes the object after validation.
+func (strategy) Canonicalize(obj runtime.Object) {
+}
+
+// Validate validates a new enrollment
+func (strategy) Validate(ctx kapi.Context, obj runtime.Object) field.ErrorList {
+	enrollment := obj.(*api.OAuthTOTPEnrollment)
+	return validation.ValidateTOTPEnrollment(enrollment)
+}
+
+// ValidateUpdate validates an enrollment update
+func (strategy) ValidateUpdate(ctx kapi.Context, obj runtime.Object, old runtime.Object) field.ErrorList {
+	enrollment := obj.(*api.OAuthTOTPEnrollment)
+	oldEnrollment := old.(*api.OAuthTOTPEnrollment)
+	return validation.ValidateTOTPEnrollmentUpdate(enrollment, oldEnrollment)
+}
+
+func (strategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+// AllowUnconditionalUpdate is false so that a code can't be accepted twice by concurrent logins
+func (strategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
+		enrollment, ok := obj.(*api.OAuthTOTPEnrollment)
+		if !ok {
+			return false, fmt.Errorf("not a TOTP enrollment")
+		}
+		fields := api.OAuthTOTPEnrollmentToSelectableFields(enrollment)
+		return label.Matches(labels.Set(enrollment.Labels)) && field.Matches(fields), nil
+	})
+}
@@ -10,6 +10,7 @@ type AccessTokenRegistry struct {
 	Err                    error
 	AccessTokens           *api.OAuthAccessTokenList
 	AccessToken            *api.OAuthAccessToken
+	UpdatedAccessToken     *api.OAuthAccessToken
 	DeletedAccessTokenName string
 }
 
@@ -25,6 +26,11 @@ func (r *AccessTokenRegistry) CreateAccessToken(ctx kapi.Context, token *api.OAu
 	return r.AccessToken, r.Err
 }
 
+func (r *AccessTokenRegistry) UpdateAccessToken(ctx kapi.Context, token *api.OAuthAccessToken) (*api.OAuthAccessToken, error) {
+	r.UpdatedAccessToken = token
+	return token, r.Err
+}
+
 func (r *AccessTokenRegistry) DeleteAccessToken(ctx kapi.Context, name string) error {
 	r.DeletedAccessTokenName = name
 	return r.Err
@@ -0,0 +1,56 @@
+package test
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+)
+
+// TOTPEnrollmentRegistry remembers the last enrollment it was given, so that it can be used
+// across several steps of an enrollment.
+type TOTPEnrollmentRegistry struct {
+	Err                   error
+	Enrollment            *api.OAuthTOTPEnrollment
+	CreatedEnrollment     *api.OAuthTOTPEnrollment
+	UpdatedEnrollment     *api.OAuthTOTPEnrollment
+	DeletedEnrollmentName string
+}
+
+func (r *TOTPEnrollmentRegistry) GetTOTPEnrollment(ctx kapi.Context, name string) (*api.OAuthTOTPEnrollment, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if r.Enrollment == nil || r.Enrollment.Name != name {
+		return nil, kerrors.NewNotFound(api.Resource("oauthtotpenrollments"), name)
+	}
+	enrollment := *r.Enrollment
+	return &enrollment, nil
+}
+
+func (r *TOTPEnrollmentRegistry) CreateTOTPEnrollment(ctx kapi.Context, enrollment *api.OAuthTOTPEnrollment) (*api.OAuthTOTPEnrollment, error) {
+	r.CreatedEnrollment = enrollment
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	r.Enrollment = enrollment
+	return enrollment, nil
+}
+
+func (r *TOTPEnrollmentRegistry) UpdateTOTPEnrollment(ctx kapi.Context, enrollment *api.OAuthTOTPEnrollment) (*api.OAuthTOTPEnrollment, error) {
+	r.UpdatedEnrollment = enrollment
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	r.Enrollment = enrollment
+	return enrollment, nil
+}
+
+func (r *TOTPEnrollmentRegistry) DeleteTOTPEnrollment(ctx kapi.Context, name string) error {
+	r.DeletedEnrollmentName = name
+	if r.Err != nil {
+		return r.Err
+	}
+	r.Enrollment = nil
+	return nil
+}
@@ -0,0 +1,129 @@
+package useroauthaccesstoken
+
+import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
+)
+
+// REST implements the RESTStorage interface for UserOAuthAccessTokens.  It lets users list, get and revoke the access
+// tokens issued to them, without being allowed to see the tokens of anyone else.
+type REST struct {
+	tokens oauthaccesstoken.Registry
+}
+
+// NewREST returns a new REST.
+func NewREST(tokens oauthaccesstoken.Registry) *REST {
+	return &REST{tokens: tokens}
+}
+
+// New returns a new UserOAuthAccessToken.
+func (r *REST) New() runtime.Object {
+	return &api.UserOAuthAccessToken{}
+}
+
+// NewList returns a new UserOAuthAccessTokenList.
+func (r *REST) NewList() runtime.Object {
+	return &api.UserOAuthAccessTokenList{}
+}
+
+// List returns the access tokens of the current user that match the options.
+func (r *REST) List(ctx kapi.Context, options *kapi.ListOptions) (runtime.Object, error) {
+	u, err := userFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the field selector of the caller is applied below, so only the tokens of the user are requested
+	listOptions := &kapi.ListOptions{FieldSelector: fields.OneTermEqualSelector("userName", u.GetName())}
+	var fieldSelector fields.Selector
+	if options != nil {
+		listOptions.LabelSelector = options.LabelSelector
+		listOptions.ResourceVersion = options.ResourceVersion
+		fieldSelector = options.FieldSelector
+	}
+
+	tokens, err := r.tokens.ListAccessTokens(ctx, listOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &api.UserOAuthAccessTokenList{ListMeta: tokens.ListMeta, Items: []api.UserOAuthAccessToken{}}
+	for i := range tokens.Items {
+		token := &tokens.Items[i]
+		if !ownedBy(token, u) {
+			continue
+		}
+		if fieldSelector != nil && !fieldSelector.Matches(api.OAuthAccessTokenToSelectableFields(token)) {
+			continue
+		}
+		list.Items = append(list.Items, api.UserOAuthAccessToken(*token))
+	}
+	return list, nil
+}
+
+// Get returns the named access token if it belongs to the current user.
+func (r *REST) Get(ctx kapi.Context, name string) (runtime.Object, error) {
+	token, err := r.getOwnToken(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	userToken := api.UserOAuthAccessToken(*token)
+	return &userToken, nil
+}
+
+// Delete revokes the named access token if it belongs to the current user.
+func (r *REST) Delete(ctx kapi.Context, name string) (runtime.Object, error) {
+	if _, err := r.getOwnToken(ctx, name); err != nil {
+		return nil, err
+	}
+	if err := r.tokens.DeleteAccessToken(ctx, name); err != nil {
+		return nil, err
+	}
+	return &unversioned.Status{Status: unversioned.StatusSuccess}, nil
+}
+
+// getOwnToken returns the named access token.  The tokens of other users are reported as not found, so their
+// existence is not revealed.
+func (r *REST) getOwnToken(ctx kapi.Context, name string) (*api.OAuthAccessToken, error) {
+	u, err := userFrom(ctx)
+	if err != nil {
+		return nil, err
+	}
+	token, err := r.tokens.GetAccessToken(ctx, name)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, kerrors.NewNotFound(api.Resource("useroauthaccesstokens"), name)
+		}
+		return nil, err
+	}
+	if !ownedBy(token, u) {
+		return nil, kerrors.NewNotFound(api.Resource("useroauthaccesstokens"), name)
+	}
+	return token, nil
+}
+
+func userFrom(ctx kapi.Context) (user.Info, error) {
+	u, ok := kapi.UserFrom(ctx)
+	if !ok || len(u.GetName()) == 0 {
+		return nil, kerrors.NewForbidden(api.Resource("useroauthaccesstokens"), "", fmt.Errorf("unable to access tokens without a user on the context"))
+	}
+	return u, nil
+}
+
+// ownedBy returns true if the token was issued to the user.  The UID is compared when the user has one, so the tokens
+// of a deleted user are not given to a new user with the same name.
+func ownedBy(token *api.OAuthAccessToken, u user.Info) bool {
+	if token.UserName != u.GetName() {
+		return false
+	}
+	return len(u.GetUID()) == 0 || token.UserUID == u.GetUID()
+}
@@ -0,0 +1,111 @@
+package useroauthaccesstoken
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/fields"
+
+	"github.com/openshift/origin/pkg/oauth/api"
+	"github.com/openshift/origin/pkg/oauth/registry/test"
+)
+
+func newToken(name, userName, userUID, clientName string) api.OAuthAccessToken {
+	return api.OAuthAccessToken{
+		ObjectMeta: kapi.ObjectMeta{Name: name},
+		ClientName: clientName,
+		UserName:   userName,
+		UserUID:    userUID,
+	}
+}
+
+func userContext(name, uid string) kapi.Context {
+	return kapi.WithUser(kapi.NewContext(), &user.DefaultInfo{Name: name, UID: uid})
+}
+
+func TestList(t *testing.T) {
+	registry := &test.AccessTokenRegistry{
+		AccessTokens: &api.OAuthAccessTokenList{
+			Items: []api.OAuthAccessToken{
+				newToken("mine", "bob", "1", "openshift-browser-client"),
+				newToken("mine-cli", "bob", "1", "openshift-challenging-client"),
+				newToken("previous-bob", "bob", "0", "openshift-browser-client"),
+				newToken("alice", "alice", "2", "openshift-browser-client"),
+			},
+		},
+	}
+	storage := NewREST(registry)
+
+	obj, err := storage.List(userContext("bob", "1"), &kapi.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list := obj.(*api.UserOAuthAccessTokenList)
+	if len(list.Items) != 2 || list.Items[0].Name != "mine" || list.Items[1].Name != "mine-cli" {
+		t.Errorf("expected only the tokens of the user, got %#v", list.Items)
+	}
+
+	obj, err = storage.List(userContext("bob", "1"), &kapi.ListOptions{FieldSelector: fields.OneTermEqualSelector("clientName", "openshift-challenging-client")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	list = obj.(*api.UserOAuthAccessTokenList)
+	if len(list.Items) != 1 || list.Items[0].Name != "mine-cli" {
+		t.Errorf("expected the field selector to be applied, got %#v", list.Items)
+	}
+
+	if _, err := storage.List(kapi.NewContext(), &kapi.ListOptions{}); !kerrors.IsForbidden(err) {
+		t.Errorf("expected a forbidden error without a user, got %v", err)
+	}
+}
+
+func TestGetAndDelete(t *testing.T) {
+	tests := map[string]struct {
+		token         api.OAuthAccessToken
+		ctx           kapi.Context
+		expectedFound bool
+	}{
+		"own token": {
+			token:         newToken("token", "bob", "1", "openshift-browser-client"),
+			ctx:           userContext("bob", "1"),
+			expectedFound: true,
+		},
+		"token of another user": {
+			token: newToken("token", "alice", "2", "openshift-browser-client"),
+			ctx:   userContext("bob", "1"),
+		},
+		"token of a previous user with the same name": {
+			token: newToken("token", "bob", "0", "openshift-browser-client"),
+			ctx:   userContext("bob", "1"),
+		},
+	}
+	for name, tc := range tests {
+		token := tc.token
+		registry := &test.AccessTokenRegistry{AccessToken: &token}
+		storage := NewREST(registry)
+
+		obj, err := storage.Get(tc.ctx, "token")
+		if tc.expectedFound {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", name, err)
+			} else if obj.(*api.UserOAuthAccessToken).Name != "token" {
+				t.Errorf("%s: unexpected token: %#v", name, obj)
+			}
+		} else if !kerrors.IsNotFound(err) {
+			t.Errorf("%s: expected a not found error, got %v", name, err)
+		}
+
+		_, err = storage.Delete(tc.ctx, "token")
+		if tc.expectedFound {
+			if err != nil || registry.DeletedAccessTokenName != "token" {
+				t.Errorf("%s: expected the token to be deleted: %v", name, err)
+			}
+			continue
+		}
+		if !kerrors.IsNotFound(err) || len(registry.DeletedAccessTokenName) != 0 {
+			t.Errorf("%s: expected the token not to be deleted: %v", name, err)
+		}
+	}
+}
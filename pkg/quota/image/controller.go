@@ -0,0 +1,73 @@
+package image
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// ImageQuotaController reports the storage used by the images of a project in the integrated
+// registry in the status of the resource quotas limiting it. The usage of all other resources
+// is left to the Kubernetes resource quota controller.
+// Use the ImageQuotaControllerFactory to create this controller.
+type ImageQuotaController struct {
+	// Client is an OpenShift client.
+	Client osclient.Interface
+	// KubeClient is a Kubernetes client.
+	KubeClient kclient.Interface
+}
+
+// Handle computes the image storage usage of the project of the quota and updates the quota
+// status if it changed.
+func (c *ImageQuotaController) Handle(quota *kapi.ResourceQuota) error {
+	if !HasImageResources(quota.Spec.Hard) {
+		return nil
+	}
+
+	streams, err := c.Client.ImageStreams(quota.Namespace).List(kapi.ListOptions{})
+	if err != nil {
+		return err
+	}
+	images := map[string]*imageapi.Image{}
+	usage, err := ProjectUsage(streams.Items, func(name string) (*imageapi.Image, error) {
+		if image, ok := images[name]; ok {
+			return image, nil
+		}
+		image, err := c.Client.Images().Get(name)
+		if err != nil {
+			return nil, err
+		}
+		images[name] = image
+		return image, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	used := usage.ResourceList(quota.Spec.Hard)
+	dirty := false
+	updated := *quota
+	updated.Status = kapi.ResourceQuotaStatus{Hard: kapi.ResourceList{}, Used: kapi.ResourceList{}}
+	for name, value := range quota.Spec.Hard {
+		updated.Status.Hard[name] = *value.Copy()
+		if previous, ok := quota.Status.Hard[name]; !ok || previous.Cmp(value) != 0 {
+			dirty = true
+		}
+	}
+	for name, value := range quota.Status.Used {
+		updated.Status.Used[name] = *value.Copy()
+	}
+	for name, value := range used {
+		if previous, ok := quota.Status.Used[name]; !ok || previous.Cmp(value) != 0 {
+			dirty = true
+		}
+		updated.Status.Used[name] = value
+	}
+	if !dirty {
+		return nil
+	}
+	_, err = c.KubeClient.ResourceQuotas(quota.Namespace).UpdateStatus(&updated)
+	return err
+}
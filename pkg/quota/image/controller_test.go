@@ -0,0 +1,76 @@
+package image
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestHandleImageQuota(t *testing.T) {
+	getImage := testGetter(
+		testImage("base", true, map[string]int64{"layer1": 100}),
+		testImage("app", true, map[string]int64{"layer1": 100, "layer2": 50}),
+	)
+	client := &testclient.Fake{}
+	client.AddReactor("list", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &imageapi.ImageStreamList{Items: []imageapi.ImageStream{testStream("app", "base", "app")}}, nil
+	})
+	client.AddReactor("get", "images", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		image, err := getImage(action.(ktestclient.GetAction).GetName())
+		return true, image, err
+	})
+
+	tests := []struct {
+		name    string
+		quota   kapi.ResourceQuota
+		updated bool
+	}{
+		{
+			name:  "no image resources",
+			quota: testQuota(map[kapi.ResourceName]string{kapi.ResourcePods: "1"}, nil),
+		},
+		{
+			name:    "usage changed",
+			quota:   testQuota(map[kapi.ResourceName]string{kapi.ResourcePods: "1", imageapi.ResourceProjectImagesSize: "1Gi"}, map[kapi.ResourceName]string{kapi.ResourcePods: "1"}),
+			updated: true,
+		},
+		{
+			name:  "usage unchanged",
+			quota: testQuota(map[kapi.ResourceName]string{imageapi.ResourceProjectImagesSize: "1Gi"}, map[kapi.ResourceName]string{imageapi.ResourceProjectImagesSize: "150"}),
+		},
+	}
+
+	for _, test := range tests {
+		test.quota.Status.Hard = test.quota.Spec.Hard
+		kubeClient := &ktestclient.Fake{}
+		controller := &ImageQuotaController{Client: client, KubeClient: kubeClient}
+		if err := controller.Handle(&test.quota); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		actions := kubeClient.Actions()
+		if !test.updated {
+			if len(actions) != 0 {
+				t.Errorf("%s: unexpected actions: %#v", test.name, actions)
+			}
+			continue
+		}
+		if len(actions) != 1 || !actions[0].Matches("update", "resourcequotas") || actions[0].GetSubresource() != "status" {
+			t.Errorf("%s: expected a status update, got %#v", test.name, actions)
+			continue
+		}
+		updated := actions[0].(ktestclient.UpdateAction).GetObject().(*kapi.ResourceQuota)
+		if used := updated.Status.Used[imageapi.ResourceProjectImagesSize]; used.Value() != 150 {
+			t.Errorf("%s: unexpected project usage: %s", test.name, used.String())
+		}
+		if used := updated.Status.Used[kapi.ResourcePods]; used.Value() != 1 {
+			t.Errorf("%s: expected the usage of other resources to be preserved, got %#v", test.name, updated.Status.Used)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+// Package image computes the storage used by the images of a project in the integrated registry
+// and checks it against the openshift.io/image-size, openshift.io/imagestream-size and
+// openshift.io/project-images-size resources of resource quotas.
+package image
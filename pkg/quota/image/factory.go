@@ -0,0 +1,61 @@
+package image
+
+import (
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	kutil "k8s.io/kubernetes/pkg/util"
+	"k8s.io/kubernetes/pkg/watch"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	controller "github.com/openshift/origin/pkg/controller"
+)
+
+// ImageQuotaControllerFactory can create an ImageQuotaController.
+type ImageQuotaControllerFactory struct {
+	// Client is an OpenShift client.
+	Client osclient.Interface
+	// KubeClient is a Kubernetes client.
+	KubeClient kclient.Interface
+	// ResyncInterval is how often the usage of every quota is recomputed.
+	ResyncInterval time.Duration
+}
+
+// Create creates an ImageQuotaController.
+func (factory *ImageQuotaControllerFactory) Create() controller.RunnableController {
+	quotaLW := &cache.ListWatch{
+		ListFunc: func(options kapi.ListOptions) (runtime.Object, error) {
+			return factory.KubeClient.ResourceQuotas(kapi.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options kapi.ListOptions) (watch.Interface, error) {
+			return factory.KubeClient.ResourceQuotas(kapi.NamespaceAll).Watch(options)
+		},
+	}
+	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(quotaLW, &kapi.ResourceQuota{}, queue, factory.ResyncInterval).Run()
+
+	quotaController := &ImageQuotaController{
+		Client:     factory.Client,
+		KubeClient: factory.KubeClient,
+	}
+
+	return &controller.RetryController{
+		Queue: queue,
+		RetryManager: controller.NewQueueRetryManager(
+			queue,
+			cache.MetaNamespaceKeyFunc,
+			func(obj interface{}, err error, retries controller.Retry) bool {
+				kutil.HandleError(err)
+				return retries.Count < 5
+			},
+			kutil.NewTokenBucketRateLimiter(1, 10),
+		),
+		Handle: func(obj interface{}) error {
+			quota := obj.(*kapi.ResourceQuota)
+			return quotaController.Handle(quota)
+		},
+	}
+}
@@ -0,0 +1,227 @@
+package image
+
+import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// imageResources are the quota resources computed from the images stored in the integrated registry.
+var imageResources = []kapi.ResourceName{
+	imageapi.ResourceImageSize,
+	imageapi.ResourceImageStreamSize,
+	imageapi.ResourceProjectImagesSize,
+}
+
+// HasImageResources returns true if any of the image storage resources is limited by hard.
+func HasImageResources(hard kapi.ResourceList) bool {
+	for _, name := range imageResources {
+		if _, ok := hard[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ImageGetter returns the image with the given name.
+type ImageGetter func(name string) (*imageapi.Image, error)
+
+// layerSet maps the digests of unique layers to their sizes.
+type layerSet map[string]int64
+
+// add records the layers of the image and returns the size of the layers that were not known yet.
+func (s layerSet) add(image *imageapi.Image) int64 {
+	added := int64(0)
+	for _, layer := range image.DockerImageLayers {
+		if _, ok := s[layer.Name]; ok {
+			continue
+		}
+		s[layer.Name] = layer.Size
+		added += layer.Size
+	}
+	return added
+}
+
+// size returns the total size of the layers in the set.
+func (s layerSet) size() int64 {
+	size := int64(0)
+	for _, layerSize := range s {
+		size += layerSize
+	}
+	return size
+}
+
+// ImageSize returns the size of the unique layers of the image.
+func ImageSize(image *imageapi.Image) int64 {
+	return layerSet{}.add(image)
+}
+
+// isStoredInRegistry returns true for images pushed to the integrated registry. Images imported
+// from other registries do not use any storage of the integrated registry.
+func isStoredInRegistry(image *imageapi.Image) bool {
+	return image.Annotations[imageapi.ManagedByOpenShiftAnnotation] == "true"
+}
+
+// streamLayers adds the layers of the images of the stream stored in the integrated registry to
+// layers and returns the size of the largest of those images. Images that no longer exist are ignored.
+func streamLayers(stream *imageapi.ImageStream, getImage ImageGetter, layers layerSet) (int64, error) {
+	largest := int64(0)
+	seen := map[string]bool{}
+	for _, history := range stream.Status.Tags {
+		for _, event := range history.Items {
+			if seen[event.Image] {
+				continue
+			}
+			seen[event.Image] = true
+
+			image, err := getImage(event.Image)
+			if err != nil {
+				if kerrors.IsNotFound(err) {
+					continue
+				}
+				return 0, err
+			}
+			if !isStoredInRegistry(image) {
+				continue
+			}
+			layers.add(image)
+			if size := ImageSize(image); size > largest {
+				largest = size
+			}
+		}
+	}
+	return largest, nil
+}
+
+// Usage is the storage used by the images of a project in the integrated registry.
+type Usage struct {
+	// LargestImage is the size of the largest image.
+	LargestImage int64
+	// LargestImageStream is the storage used by the image stream with the largest images.
+	LargestImageStream int64
+	// Total is the storage used by all images. Layers shared by several images are counted once.
+	Total int64
+}
+
+// ProjectUsage computes the storage used by the images referenced by the image streams of a project.
+func ProjectUsage(streams []imageapi.ImageStream, getImage ImageGetter) (*Usage, error) {
+	usage := &Usage{}
+	projectLayers := layerSet{}
+	for i := range streams {
+		layers := layerSet{}
+		largest, err := streamLayers(&streams[i], getImage, layers)
+		if err != nil {
+			return nil, err
+		}
+		if largest > usage.LargestImage {
+			usage.LargestImage = largest
+		}
+		if size := layers.size(); size > usage.LargestImageStream {
+			usage.LargestImageStream = size
+		}
+		for name, size := range layers {
+			projectLayers[name] = size
+		}
+	}
+	usage.Total = projectLayers.size()
+	return usage, nil
+}
+
+// ResourceList returns the usage of the image storage resources limited by hard.
+func (u *Usage) ResourceList(hard kapi.ResourceList) kapi.ResourceList {
+	values := map[kapi.ResourceName]int64{
+		imageapi.ResourceImageSize:         u.LargestImage,
+		imageapi.ResourceImageStreamSize:   u.LargestImageStream,
+		imageapi.ResourceProjectImagesSize: u.Total,
+	}
+	used := kapi.ResourceList{}
+	for name, value := range values {
+		if _, ok := hard[name]; ok {
+			used[name] = *resource.NewQuantity(value, resource.BinarySI)
+		}
+	}
+	return used
+}
+
+// QuotaExceededError is returned when storing an image would exceed a quota.
+type QuotaExceededError struct {
+	// Quota is the name of the exceeded quota.
+	Quota string
+	// Resource is the exceeded resource.
+	Resource kapi.ResourceName
+	// Requested is the usage of the resource after storing the image.
+	Requested int64
+	// Limit is the hard limit of the resource.
+	Limit int64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("exceeded quota %s: %s requested %s, limited to %s", e.Quota, e.Resource,
+		resource.NewQuantity(e.Requested, resource.BinarySI), resource.NewQuantity(e.Limit, resource.BinarySI))
+}
+
+// AdmitImage returns a QuotaExceededError if tagging the image into the stream named streamName would
+// exceed any of the image storage limits of the quotas. streams are the image streams of the project,
+// which may not include the stream yet. The usage of the project is computed from the streams rather
+// than taken from the status of the quotas, which the quota controller only refreshes periodically,
+// so that images stored since the last refresh are counted.
+func AdmitImage(quotas []kapi.ResourceQuota, image *imageapi.Image, streams []imageapi.ImageStream, streamName string, getImage ImageGetter) error {
+	withMetadata := *image
+	if err := imageapi.ImageWithMetadata(&withMetadata); err != nil {
+		return err
+	}
+	imageSize := ImageSize(&withMetadata)
+
+	// images are often shared by several streams, look them up once
+	images := map[string]*imageapi.Image{}
+	cachedGetImage := func(name string) (*imageapi.Image, error) {
+		if image, ok := images[name]; ok {
+			return image, nil
+		}
+		image, err := getImage(name)
+		if err != nil {
+			return nil, err
+		}
+		images[name] = image
+		return image, nil
+	}
+
+	projectLayers := layerSet{}
+	layers := layerSet{}
+	for i := range streams {
+		streamLayerSet := layerSet{}
+		if _, err := streamLayers(&streams[i], cachedGetImage, streamLayerSet); err != nil {
+			return err
+		}
+		if streams[i].Name == streamName {
+			layers = streamLayerSet
+		}
+		for name, size := range streamLayerSet {
+			projectLayers[name] = size
+		}
+	}
+	layers.add(&withMetadata)
+	projectLayers.add(&withMetadata)
+
+	requested := map[kapi.ResourceName]int64{
+		imageapi.ResourceImageSize:         imageSize,
+		imageapi.ResourceImageStreamSize:   layers.size(),
+		imageapi.ResourceProjectImagesSize: projectLayers.size(),
+	}
+	for _, quota := range quotas {
+		for _, name := range imageResources {
+			limit, ok := quota.Spec.Hard[name]
+			if !ok {
+				continue
+			}
+			if requested[name] > limit.Value() {
+				return &QuotaExceededError{Quota: quota.Name, Resource: name, Requested: requested[name], Limit: limit.Value()}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package image
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/resource"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func testImage(name string, managed bool, layers map[string]int64) *imageapi.Image {
+	image := &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: name}}
+	if managed {
+		image.Annotations = map[string]string{imageapi.ManagedByOpenShiftAnnotation: "true"}
+	}
+	for layer, size := range layers {
+		image.DockerImageLayers = append(image.DockerImageLayers, imageapi.ImageLayer{Name: layer, Size: size})
+	}
+	return image
+}
+
+func testStream(name string, images ...string) imageapi.ImageStream {
+	stream := imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: "test"},
+		Status:     imageapi.ImageStreamStatus{Tags: map[string]imageapi.TagEventList{}},
+	}
+	for _, image := range images {
+		history := stream.Status.Tags["latest"]
+		history.Items = append(history.Items, imageapi.TagEvent{Image: image})
+		stream.Status.Tags["latest"] = history
+	}
+	return stream
+}
+
+func testGetter(images ...*imageapi.Image) ImageGetter {
+	return func(name string) (*imageapi.Image, error) {
+		for _, image := range images {
+			if image.Name == name {
+				return image, nil
+			}
+		}
+		return nil, kerrors.NewNotFound(imageapi.Resource("images"), name)
+	}
+}
+
+func testQuota(hard, used map[kapi.ResourceName]string) kapi.ResourceQuota {
+	quota := kapi.ResourceQuota{ObjectMeta: kapi.ObjectMeta{Name: "images", Namespace: "test"}}
+	quota.Spec.Hard = kapi.ResourceList{}
+	for name, value := range hard {
+		quota.Spec.Hard[name] = resource.MustParse(value)
+	}
+	quota.Status.Used = kapi.ResourceList{}
+	for name, value := range used {
+		quota.Status.Used[name] = resource.MustParse(value)
+	}
+	return quota
+}
+
+func TestProjectUsage(t *testing.T) {
+	getImage := testGetter(
+		testImage("base", true, map[string]int64{"layer1": 100}),
+		testImage("app", true, map[string]int64{"layer1": 100, "layer2": 50}),
+		testImage("other", true, map[string]int64{"layer3": 120}),
+		testImage("imported", false, map[string]int64{"layer4": 1000}),
+	)
+	streams := []imageapi.ImageStream{
+		testStream("app", "base", "app", "deleted"),
+		testStream("other", "other", "app", "imported"),
+	}
+
+	usage, err := ProjectUsage(streams, getImage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Usage{LargestImage: 150, LargestImageStream: 270, Total: 270}
+	if *usage != expected {
+		t.Errorf("unexpected usage: %#v", usage)
+	}
+
+	used := usage.ResourceList(kapi.ResourceList{imageapi.ResourceProjectImagesSize: resource.MustParse("1Gi")})
+	if len(used) != 1 {
+		t.Fatalf("unexpected resources: %#v", used)
+	}
+	if value := used[imageapi.ResourceProjectImagesSize]; value.Value() != 270 {
+		t.Errorf("unexpected project usage: %s", value.String())
+	}
+}
+
+func TestAdmitImage(t *testing.T) {
+	existing := testImage("existing", true, map[string]int64{"layer1": 100})
+	other := testImage("other", true, map[string]int64{"layer3": 850})
+	getImage := testGetter(existing, other)
+	streams := []imageapi.ImageStream{testStream("app", "existing"), testStream("other", "other")}
+	pushed := testImage("pushed", true, map[string]int64{"layer1": 100, "layer2": 50})
+
+	tests := []struct {
+		name     string
+		quota    kapi.ResourceQuota
+		streams  []imageapi.ImageStream
+		image    *imageapi.Image
+		resource kapi.ResourceName
+	}{
+		{
+			name:  "no image resources",
+			quota: testQuota(map[kapi.ResourceName]string{kapi.ResourcePods: "1"}, nil),
+			image: pushed,
+		},
+		{
+			name:    "within all limits",
+			quota:   testQuota(map[kapi.ResourceName]string{imageapi.ResourceImageSize: "150", imageapi.ResourceImageStreamSize: "150", imageapi.ResourceProjectImagesSize: "1000"}, nil),
+			streams: streams,
+			image:   pushed,
+		},
+		{
+			name:     "image too large",
+			quota:    testQuota(map[kapi.ResourceName]string{imageapi.ResourceImageSize: "149"}, nil),
+			image:    pushed,
+			resource: imageapi.ResourceImageSize,
+		},
+		{
+			name:     "stream too large",
+			quota:    testQuota(map[kapi.ResourceName]string{imageapi.ResourceImageStreamSize: "149"}, nil),
+			streams:  streams,
+			image:    pushed,
+			resource: imageapi.ResourceImageStreamSize,
+		},
+		{
+			name:     "project too large",
+			quota:    testQuota(map[kapi.ResourceName]string{imageapi.ResourceProjectImagesSize: "999"}, nil),
+			streams:  streams,
+			image:    pushed,
+			resource: imageapi.ResourceProjectImagesSize,
+		},
+		{
+			// the usage in the status of the quota is not refreshed yet
+			name:     "project too large with outdated usage",
+			quota:    testQuota(map[kapi.ResourceName]string{imageapi.ResourceProjectImagesSize: "999"}, map[kapi.ResourceName]string{imageapi.ResourceProjectImagesSize: "0"}),
+			streams:  streams,
+			image:    pushed,
+			resource: imageapi.ResourceProjectImagesSize,
+		},
+		{
+			name:    "retagging an image of the stream",
+			quota:   testQuota(map[kapi.ResourceName]string{imageapi.ResourceImageStreamSize: "100", imageapi.ResourceProjectImagesSize: "950"}, nil),
+			streams: streams,
+			image:   existing,
+		},
+	}
+
+	for _, test := range tests {
+		err := AdmitImage([]kapi.ResourceQuota{test.quota}, test.image, test.streams, "app", getImage)
+		if len(test.resource) == 0 {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", test.name, err)
+			}
+			continue
+		}
+		exceeded, ok := err.(*QuotaExceededError)
+		if !ok {
+			t.Errorf("%s: expected a quota exceeded error, got %v", test.name, err)
+			continue
+		}
+		if exceeded.Resource != test.resource || exceeded.Quota != "images" {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+	}
+}
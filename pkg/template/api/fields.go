@@ -9,3 +9,10 @@ func TemplateToSelectableFields(template *Template) fields.Set {
 		"metadata.name": template.Name,
 	}
 }
+
+// TemplateInstanceToSelectableFields returns a label set that represents the object
+func TemplateInstanceToSelectableFields(templateInstance *TemplateInstance) fields.Set {
+	return fields.Set{
+		"metadata.name": templateInstance.Name,
+	}
+}
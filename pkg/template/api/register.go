@@ -30,8 +30,12 @@ func addKnownTypes(scheme *runtime.Scheme) {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&Template{},
 		&TemplateList{},
+		&TemplateInstance{},
+		&TemplateInstanceList{},
 	)
 }
 
-func (obj *Template) GetObjectKind() unversioned.ObjectKind     { return &obj.TypeMeta }
-func (obj *TemplateList) GetObjectKind() unversioned.ObjectKind { return &obj.TypeMeta }
+func (obj *Template) GetObjectKind() unversioned.ObjectKind             { return &obj.TypeMeta }
+func (obj *TemplateList) GetObjectKind() unversioned.ObjectKind         { return &obj.TypeMeta }
+func (obj *TemplateInstance) GetObjectKind() unversioned.ObjectKind     { return &obj.TypeMeta }
+func (obj *TemplateInstanceList) GetObjectKind() unversioned.ObjectKind { return &obj.TypeMeta }
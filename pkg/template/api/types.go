@@ -45,18 +45,144 @@ type Parameter struct {
 
 	// Optional: Value holds the Parameter data. If specified, the generator
 	// will be ignored. The value replaces all occurrences of the Parameter
-	// ${Name} expression during the Template to Config transformation.
+	// ${Name} expression during the Template to Config transformation. Fields
+	// whose value is exactly ${{Name}} are replaced by the value parsed as JSON.
 	Value string
 
 	// Optional: Generate specifies the generator to be used to generate
 	// random string from an input value specified by From field. The result
 	// string is stored into Value field. If empty, no generator is being
-	// used, leaving the result Value untouched.
+	// used, leaving the result Value untouched. One of expression, uuid, rsa,
+	// ecdsa, publickey, certificate, bcrypt, htpasswd or base64. At most 5
+	// parameters of a template may use the rsa, bcrypt or htpasswd generators.
 	Generate string
 
-	// Optional: From is an input value for the generator.
+	// Optional: From is an input value for the generator. The ${Name}
+	// expressions in From are replaced by the values of the referenced
+	// parameters before the value is generated.
 	From string
 
 	// Optional: Indicates the parameter must have a value.  Defaults to false.
 	Required bool
+
+	// Optional: Type is the type of the value of the parameter. One of string, int, bool or base64.
+	// Defaults to string.
+	Type ParameterType
+
+	// Optional: Pattern is a regular expression the whole value of the parameter must match.
+	Pattern string
+
+	// Optional: AllowedValues lists the only values the parameter may have.
+	AllowedValues []string
+
+	// Optional: MinLength is the minimum number of characters of the value of the parameter.
+	MinLength int
+
+	// Optional: MaxLength is the maximum number of characters of the value of the parameter. No
+	// limit is applied if zero.
+	MaxLength int
+}
+
+// ParameterType is the type of the value of a template parameter.
+type ParameterType string
+
+const (
+	// ParameterTypeString accepts any value.
+	ParameterTypeString ParameterType = "string"
+	// ParameterTypeInt accepts base 10 integers.
+	ParameterTypeInt ParameterType = "int"
+	// ParameterTypeBool accepts the values true and false.
+	ParameterTypeBool ParameterType = "bool"
+	// ParameterTypeBase64 accepts standard base64 encoded data.
+	ParameterTypeBase64 ParameterType = "base64"
+)
+
+// TemplateInstance is an instantiation of a Template in a namespace. The objects processed from the
+// template are created, kept up to date when the parameters change and deleted along with the
+// instance by the template instance controller.
+type TemplateInstance struct {
+	unversioned.TypeMeta
+	kapi.ObjectMeta
+
+	// Spec describes the template to instantiate and the values of its parameters.
+	Spec TemplateInstanceSpec
+
+	// Status reports the objects created from the template and whether they are ready.
+	Status TemplateInstanceStatus
+}
+
+// TemplateInstanceSpec describes the desired state of a TemplateInstance.
+type TemplateInstanceSpec struct {
+	// Required: Template is the template to instantiate.
+	Template Template
+
+	// Optional: Secret references a secret in the namespace of the instance whose keys are
+	// parameter names and whose values override the values of the parameters of the template.
+	// The values of generated parameters are stored in the secret so that they are kept when the
+	// instance is re-applied.
+	Secret *kapi.LocalObjectReference
+
+	// Requester is the user who last created or updated the instance. The objects of the template
+	// are only created or updated if the requester is allowed to. It is set by the server.
+	Requester *TemplateInstanceRequester
+}
+
+// TemplateInstanceRequester identifies the user on whose behalf the objects of a TemplateInstance are
+// managed.
+type TemplateInstanceRequester struct {
+	// Username is the name of the user.
+	Username string
+
+	// Groups are the groups the user belongs to.
+	Groups []string
+
+	// Scopes are the scopes of the OAuth access token the user is restricted to, if any.
+	Scopes []string
+}
+
+// TemplateInstanceStatus describes the observed state of a TemplateInstance.
+type TemplateInstanceStatus struct {
+	// ObservedGeneration is the generation of the spec last applied.
+	ObservedGeneration int64
+
+	// ObservedSecretResourceVersion is the resource version of the parameter secret last applied.
+	ObservedSecretResourceVersion string
+
+	// Conditions report whether the instance was applied and whether its objects are ready.
+	Conditions []TemplateInstanceCondition
+
+	// Objects references the objects created from the template.
+	Objects []kapi.ObjectReference
+}
+
+// TemplateInstanceConditionType is the type of a condition of a TemplateInstance.
+type TemplateInstanceConditionType string
+
+const (
+	// TemplateInstanceReady is true when every object of the instance was created and is ready.
+	TemplateInstanceReady TemplateInstanceConditionType = "Ready"
+	// TemplateInstanceInstantiateFailure is true when the template could not be processed or some of
+	// its objects could not be created, updated or deleted.
+	TemplateInstanceInstantiateFailure TemplateInstanceConditionType = "InstantiateFailure"
+)
+
+// TemplateInstanceCondition describes the state of a TemplateInstance at a point in time.
+type TemplateInstanceCondition struct {
+	// Type of the condition.
+	Type TemplateInstanceConditionType
+	// Status of the condition, one of True, False or Unknown.
+	Status kapi.ConditionStatus
+	// LastTransitionTime is the last time the condition changed status.
+	LastTransitionTime unversioned.Time
+	// Reason is a brief machine readable explanation of the status.
+	Reason string
+	// Message is a human readable description of the status.
+	Message string
+}
+
+// TemplateInstanceList is a list of TemplateInstance objects.
+type TemplateInstanceList struct {
+	unversioned.TypeMeta
+	unversioned.ListMeta
+	Items []TemplateInstance
 }
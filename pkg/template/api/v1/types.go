@@ -47,18 +47,157 @@ type Parameter struct {
 
 	// Value holds the Parameter data. If specified, the generator will be
 	// ignored. The value replaces all occurrences of the Parameter ${Name}
-	// expression during the Template to Config transformation. Optional.
-	Value string `json:"value,omitempty" description:"optional: holds the parameter data.  if specified, the generator is ignored.  the value replaces all occurrences of the parameter ${Name} expression during template to config transformation"`
+	// expression during the Template to Config transformation. Fields whose
+	// value is exactly ${{Name}} are replaced by the value parsed as JSON.
+	// Optional.
+	Value string `json:"value,omitempty" description:"optional: holds the parameter data.  if specified, the generator is ignored.  the value replaces all occurrences of the parameter ${Name} expression during template to config transformation, and fields whose value is exactly ${{Name}} are replaced by the value parsed as JSON"`
 
 	// Generate specifies the generator to be used to generate random string
 	// from an input value specified by From field. The result string is
 	// stored into Value field. If empty, no generator is being used, leaving
-	// the result Value untouched. Optional.
-	Generate string `json:"generate,omitempty" description:"optional: generate specifies the generator to be used to generate random string from an input value specified by the from field.  the result string is stored in the value field. if not specified, the value field is untouched"`
+	// the result Value untouched. One of expression, uuid, rsa, ecdsa,
+	// publickey, certificate, bcrypt, htpasswd or base64. At most 5
+	// parameters of a template may use the rsa, bcrypt or htpasswd
+	// generators. Optional.
+	Generate string `json:"generate,omitempty" description:"optional: generate specifies the generator to be used to generate random string from an input value specified by the from field.  the result string is stored in the value field. if not specified, the value field is untouched; one of expression, uuid, rsa, ecdsa, publickey, certificate, bcrypt, htpasswd or base64; at most 5 parameters may use the rsa, bcrypt or htpasswd generators"`
 
-	// From is an input value for the generator. Optional.
-	From string `json:"from,omitempty" description:"input value for the generator"`
+	// From is an input value for the generator. The ${Name} expressions in
+	// From are replaced by the values of the referenced parameters before
+	// the value is generated. Optional.
+	From string `json:"from,omitempty" description:"input value for the generator; ${Name} expressions are replaced by the values of the referenced parameters"`
 
 	// Optional: Indicates the parameter must have a value.  Defaults to false.
 	Required bool `json:"required,omitempty" description:"indicates the parameter must have a non-empty value or be generated"`
+
+	// Type is the type of the value of the parameter. One of string, int,
+	// bool or base64. Defaults to string. Optional.
+	Type ParameterType `json:"type,omitempty" description:"type of the value of the parameter: string, int, bool or base64; defaults to string"`
+
+	// Pattern is a regular expression the whole value of the parameter must
+	// match. Optional.
+	Pattern string `json:"pattern,omitempty" description:"regular expression the whole value of the parameter must match"`
+
+	// AllowedValues lists the only values the parameter may have. Optional.
+	AllowedValues []string `json:"allowedValues,omitempty" description:"the only values the parameter may have"`
+
+	// MinLength is the minimum number of characters of the value of the
+	// parameter. Optional.
+	MinLength int `json:"minLength,omitempty" description:"minimum number of characters of the value of the parameter"`
+
+	// MaxLength is the maximum number of characters of the value of the
+	// parameter. No limit is applied if zero. Optional.
+	MaxLength int `json:"maxLength,omitempty" description:"maximum number of characters of the value of the parameter; no limit if zero"`
+}
+
+// ParameterType is the type of the value of a template parameter.
+type ParameterType string
+
+const (
+	// ParameterTypeString accepts any value.
+	ParameterTypeString ParameterType = "string"
+	// ParameterTypeInt accepts base 10 integers.
+	ParameterTypeInt ParameterType = "int"
+	// ParameterTypeBool accepts the values true and false.
+	ParameterTypeBool ParameterType = "bool"
+	// ParameterTypeBase64 accepts standard base64 encoded data.
+	ParameterTypeBase64 ParameterType = "base64"
+)
+
+// TemplateInstance is an instantiation of a Template in a namespace. The objects processed from the
+// template are created, kept up to date when the parameters change and deleted along with the
+// instance by the template instance controller.
+type TemplateInstance struct {
+	unversioned.TypeMeta `json:",inline"`
+	kapi.ObjectMeta      `json:"metadata,omitempty"`
+
+	// Spec describes the template to instantiate and the values of its parameters.
+	Spec TemplateInstanceSpec `json:"spec" description:"the template to instantiate and the values of its parameters"`
+
+	// Status reports the objects created from the template and whether they are ready.
+	Status TemplateInstanceStatus `json:"status,omitempty" description:"the objects created from the template and whether they are ready"`
+}
+
+// TemplateInstanceSpec describes the desired state of a TemplateInstance.
+type TemplateInstanceSpec struct {
+	// Template is the template to instantiate. Required.
+	Template Template `json:"template" description:"the template to instantiate"`
+
+	// Secret references a secret in the namespace of the instance whose keys
+	// are parameter names and whose values override the values of the
+	// parameters of the template. The values of generated parameters are
+	// stored in the secret so that they are kept when the instance is
+	// re-applied. Optional.
+	Secret *kapi.LocalObjectReference `json:"secret,omitempty" description:"optional: secret holding parameter values keyed by parameter name; generated values are stored in it"`
+
+	// Requester is the user who last created or updated the instance. The
+	// objects of the template are only created or updated if the requester
+	// is allowed to. It is set by the server.
+	Requester *TemplateInstanceRequester `json:"requester,omitempty" description:"the user who last created or updated the instance, set by the server"`
+}
+
+// TemplateInstanceRequester identifies the user on whose behalf the objects
+// of a TemplateInstance are managed.
+type TemplateInstanceRequester struct {
+	// Username is the name of the user.
+	Username string `json:"username" description:"name of the user"`
+
+	// Groups are the groups the user belongs to.
+	Groups []string `json:"groups,omitempty" description:"groups the user belongs to"`
+
+	// Scopes are the scopes of the OAuth access token the user is restricted to, if any.
+	Scopes []string `json:"scopes,omitempty" description:"scopes of the OAuth access token the user is restricted to"`
+}
+
+// TemplateInstanceStatus describes the observed state of a TemplateInstance.
+type TemplateInstanceStatus struct {
+	// ObservedGeneration is the generation of the spec last applied.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty" description:"generation of the spec last applied"`
+
+	// ObservedSecretResourceVersion is the resource version of the parameter
+	// secret last applied.
+	ObservedSecretResourceVersion string `json:"observedSecretResourceVersion,omitempty" description:"resource version of the parameter secret last applied"`
+
+	// Conditions report whether the instance was applied and whether its
+	// objects are ready.
+	Conditions []TemplateInstanceCondition `json:"conditions,omitempty" description:"whether the instance was applied and whether its objects are ready"`
+
+	// Objects references the objects created from the template.
+	Objects []kapi.ObjectReference `json:"objects,omitempty" description:"references to the objects created from the template"`
+}
+
+// TemplateInstanceConditionType is the type of a condition of a TemplateInstance.
+type TemplateInstanceConditionType string
+
+const (
+	// TemplateInstanceReady is true when every object of the instance was
+	// created and is ready.
+	TemplateInstanceReady TemplateInstanceConditionType = "Ready"
+	// TemplateInstanceInstantiateFailure is true when the template could not
+	// be processed or some of its objects could not be created, updated or
+	// deleted.
+	TemplateInstanceInstantiateFailure TemplateInstanceConditionType = "InstantiateFailure"
+)
+
+// TemplateInstanceCondition describes the state of a TemplateInstance at a
+// point in time.
+type TemplateInstanceCondition struct {
+	// Type of the condition.
+	Type TemplateInstanceConditionType `json:"type" description:"type of the condition, one of Ready or InstantiateFailure"`
+	// Status of the condition, one of True, False or Unknown.
+	Status kapi.ConditionStatus `json:"status" description:"status of the condition, one of True, False or Unknown"`
+	// LastTransitionTime is the last time the condition changed status.
+	LastTransitionTime unversioned.Time `json:"lastTransitionTime,omitempty" description:"last time the condition changed status"`
+	// Reason is a brief machine readable explanation of the status.
+	Reason string `json:"reason,omitempty" description:"brief machine readable explanation of the status"`
+	// Message is a human readable description of the status.
+	Message string `json:"message,omitempty" description:"human readable description of the status"`
+}
+
+// TemplateInstanceList is a list of TemplateInstance objects.
+type TemplateInstanceList struct {
+	unversioned.TypeMeta `json:",inline"`
+	unversioned.ListMeta `json:"metadata,omitempty"`
+
+	// Items is a list of template instances
+	Items []TemplateInstance `json:"items" description:"list of template instances"`
 }
@@ -45,18 +45,54 @@ type Parameter struct {
 
 	// Optional: Value holds the Parameter data. If specified, the generator
 	// will be ignored. The value replaces all occurrences of the Parameter
-	// ${Name} expression during the Template to Config transformation.
+	// ${Name} expression during the Template to Config transformation. Fields
+	// whose value is exactly ${{Name}} are replaced by the value parsed as JSON.
 	Value string `json:"value,omitempty"`
 
 	// Optional: Generate specifies the generator to be used to generate
 	// random string from an input value specified by From field. The result
 	// string is stored into Value field. If empty, no generator is being
-	// used, leaving the result Value untouched.
+	// used, leaving the result Value untouched. One of expression, uuid, rsa,
+	// ecdsa, publickey, certificate, bcrypt, htpasswd or base64. At most 5
+	// parameters of a template may use the rsa, bcrypt or htpasswd generators.
 	Generate string `json:"generate,omitempty"`
 
-	// Optional: From is an input value for the generator.
+	// Optional: From is an input value for the generator. The ${Name}
+	// expressions in From are replaced by the values of the referenced
+	// parameters before the value is generated.
 	From string `json:"from,omitempty"`
 
 	// Optional: Indicates the parameter must have a value.  Defaults to false.
 	Required bool `json:"required,omitempty" description:"indicates the parameter must have a non-empty value or be generated"`
+
+	// Optional: Type is the type of the value of the parameter. One of string, int, bool or base64.
+	// Defaults to string.
+	Type ParameterType `json:"type,omitempty"`
+
+	// Optional: Pattern is a regular expression the whole value of the parameter must match.
+	Pattern string `json:"pattern,omitempty"`
+
+	// Optional: AllowedValues lists the only values the parameter may have.
+	AllowedValues []string `json:"allowedValues,omitempty"`
+
+	// Optional: MinLength is the minimum number of characters of the value of the parameter.
+	MinLength int `json:"minLength,omitempty"`
+
+	// Optional: MaxLength is the maximum number of characters of the value of the parameter. No
+	// limit is applied if zero.
+	MaxLength int `json:"maxLength,omitempty"`
 }
+
+// ParameterType is the type of the value of a template parameter.
+type ParameterType string
+
+const (
+	// ParameterTypeString accepts any value.
+	ParameterTypeString ParameterType = "string"
+	// ParameterTypeInt accepts base 10 integers.
+	ParameterTypeInt ParameterType = "int"
+	// ParameterTypeBool accepts the values true and false.
+	ParameterTypeBool ParameterType = "bool"
+	// ParameterTypeBase64 accepts standard base64 encoded data.
+	ParameterTypeBase64 ParameterType = "base64"
+)
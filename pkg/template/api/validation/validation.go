@@ -1,9 +1,13 @@
 package validation
 
 import (
+	"encoding/base64"
 	"fmt"
 	"regexp"
+	"strconv"
+	"unicode/utf8"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
@@ -13,6 +17,13 @@ import (
 
 var parameterNameExp = regexp.MustCompile(`^[a-zA-Z0-9\_]+$`)
 
+var parameterTypes = []string{
+	string(api.ParameterTypeString),
+	string(api.ParameterTypeInt),
+	string(api.ParameterTypeBool),
+	string(api.ParameterTypeBase64),
+}
+
 // ValidateParameter tests if required fields in the Parameter are set.
 func ValidateParameter(param *api.Parameter, fldPath *field.Path) (allErrs field.ErrorList) {
 	if len(param.Name) == 0 {
@@ -22,9 +33,96 @@ func ValidateParameter(param *api.Parameter, fldPath *field.Path) (allErrs field
 	if !parameterNameExp.MatchString(param.Name) {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), param.Name, fmt.Sprintf("does not match %v", parameterNameExp)))
 	}
+	allErrs = append(allErrs, validateParameterConstraints(param, fldPath)...)
+	if len(allErrs) == 0 {
+		allErrs = append(allErrs, ValidateParameterValue(param, fldPath)...)
+	}
+	return
+}
+
+// validateParameterConstraints tests if the constraints of the Parameter are valid.
+func validateParameterConstraints(param *api.Parameter, fldPath *field.Path) (allErrs field.ErrorList) {
+	switch param.Type {
+	case "", api.ParameterTypeString, api.ParameterTypeInt, api.ParameterTypeBool, api.ParameterTypeBase64:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("type"), param.Type, parameterTypes))
+	}
+	if len(param.Pattern) > 0 {
+		if _, err := parameterPattern(param.Pattern); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pattern"), param.Pattern, err.Error()))
+		}
+	}
+	if param.MinLength < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minLength"), param.MinLength, "must be greater than or equal to 0"))
+	}
+	if param.MaxLength < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxLength"), param.MaxLength, "must be greater than or equal to 0"))
+	}
+	if param.MaxLength > 0 && param.MinLength > param.MaxLength {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("minLength"), param.MinLength, "must be less than or equal to maxLength"))
+	}
+	return
+}
+
+// ValidateParameterValue tests if the value of the Parameter satisfies its type, pattern, allowed
+// values and length constraints. Empty values are not checked, use Required to forbid them.
+func ValidateParameterValue(param *api.Parameter, fldPath *field.Path) (allErrs field.ErrorList) {
+	value := param.Value
+	if len(value) == 0 {
+		return
+	}
+	valuePath := fldPath.Child("value")
+
+	switch param.Type {
+	case api.ParameterTypeInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			allErrs = append(allErrs, field.Invalid(valuePath, value, "must be an integer"))
+		}
+	case api.ParameterTypeBool:
+		if value != "true" && value != "false" {
+			allErrs = append(allErrs, field.Invalid(valuePath, value, "must be true or false"))
+		}
+	case api.ParameterTypeBase64:
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(valuePath, value, "must be base64 encoded"))
+		}
+	}
+	if len(param.Pattern) > 0 {
+		pattern, err := parameterPattern(param.Pattern)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pattern"), param.Pattern, err.Error()))
+		} else if !pattern.MatchString(value) {
+			allErrs = append(allErrs, field.Invalid(valuePath, value, fmt.Sprintf("must match %s", param.Pattern)))
+		}
+	}
+	if len(param.AllowedValues) > 0 {
+		allowed := false
+		for _, v := range param.AllowedValues {
+			if v == value {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			allErrs = append(allErrs, field.NotSupported(valuePath, value, param.AllowedValues))
+		}
+	}
+	if length := utf8.RuneCountInString(value); length < param.MinLength {
+		allErrs = append(allErrs, field.Invalid(valuePath, value, fmt.Sprintf("must be at least %d characters long", param.MinLength)))
+	} else if param.MaxLength > 0 && length > param.MaxLength {
+		allErrs = append(allErrs, field.TooLong(valuePath, value, param.MaxLength))
+	}
 	return
 }
 
+// parameterPattern compiles the pattern of a parameter so that it matches whole values only.
+func parameterPattern(pattern string) (*regexp.Regexp, error) {
+	if _, err := regexp.Compile(pattern); err != nil {
+		return nil, err
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
 // ValidateProcessedTemplate tests if required fields in the Template are set for processing
 func ValidateProcessedTemplate(template *api.Template) field.ErrorList {
 	return validateTemplateBody(template)
@@ -43,10 +141,74 @@ func ValidateTemplateUpdate(template, oldTemplate *api.Template) field.ErrorList
 }
 
 // validateTemplateBody checks the body of a template.
-func validateTemplateBody(template *api.Template) (allErrs field.ErrorList) {
+func validateTemplateBody(template *api.Template) field.ErrorList {
+	return validateTemplateBodyPath(template, nil)
+}
+
+// validateTemplateBodyPath checks the body of a template found at fldPath.
+func validateTemplateBodyPath(template *api.Template, fldPath *field.Path) (allErrs field.ErrorList) {
 	for i := range template.Parameters {
-		allErrs = append(allErrs, ValidateParameter(&template.Parameters[i], field.NewPath("parameters").Index(i))...)
+		allErrs = append(allErrs, ValidateParameter(&template.Parameters[i], fldPath.Child("parameters").Index(i))...)
+	}
+	allErrs = append(allErrs, validation.ValidateLabels(template.ObjectLabels, fldPath.Child("labels"))...)
+	return
+}
+
+// ValidateTemplateInstance tests if required fields in the TemplateInstance are set.
+func ValidateTemplateInstance(templateInstance *api.TemplateInstance) (allErrs field.ErrorList) {
+	allErrs = validation.ValidateObjectMeta(&templateInstance.ObjectMeta, true, oapi.GetNameValidationFunc(validation.ValidatePodName), field.NewPath("metadata"))
+	allErrs = append(allErrs, validateTemplateInstanceSpec(&templateInstance.Spec, field.NewPath("spec"))...)
+	return
+}
+
+// ValidateTemplateInstanceUpdate tests if required fields in the TemplateInstance are set during an update
+func ValidateTemplateInstanceUpdate(templateInstance, oldTemplateInstance *api.TemplateInstance) (allErrs field.ErrorList) {
+	allErrs = validation.ValidateObjectMetaUpdate(&templateInstance.ObjectMeta, &oldTemplateInstance.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateTemplateInstanceSpec(&templateInstance.Spec, field.NewPath("spec"))...)
+	return
+}
+
+// ValidateTemplateInstanceStatusUpdate tests if the status of the TemplateInstance is valid during an update
+func ValidateTemplateInstanceStatusUpdate(templateInstance, oldTemplateInstance *api.TemplateInstance) (allErrs field.ErrorList) {
+	allErrs = validation.ValidateObjectMetaUpdate(&templateInstance.ObjectMeta, &oldTemplateInstance.ObjectMeta, field.NewPath("metadata"))
+	statusPath := field.NewPath("status")
+	for i, ref := range templateInstance.Status.Objects {
+		refPath := statusPath.Child("objects").Index(i)
+		if len(ref.Name) == 0 {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		}
+		if ref.Namespace != templateInstance.Namespace {
+			allErrs = append(allErrs, field.Invalid(refPath.Child("namespace"), ref.Namespace, "must be the namespace of the template instance"))
+		}
+	}
+	for i, condition := range templateInstance.Status.Conditions {
+		conditionPath := statusPath.Child("conditions").Index(i)
+		switch condition.Type {
+		case api.TemplateInstanceReady, api.TemplateInstanceInstantiateFailure:
+		default:
+			allErrs = append(allErrs, field.NotSupported(conditionPath.Child("type"), condition.Type, []string{string(api.TemplateInstanceReady), string(api.TemplateInstanceInstantiateFailure)}))
+		}
+		switch condition.Status {
+		case kapi.ConditionTrue, kapi.ConditionFalse, kapi.ConditionUnknown:
+		default:
+			allErrs = append(allErrs, field.NotSupported(conditionPath.Child("status"), condition.Status, []string{string(kapi.ConditionTrue), string(kapi.ConditionFalse), string(kapi.ConditionUnknown)}))
+		}
+	}
+	return
+}
+
+// validateTemplateInstanceSpec checks the spec of a template instance.
+func validateTemplateInstanceSpec(spec *api.TemplateInstanceSpec, fldPath *field.Path) (allErrs field.ErrorList) {
+	allErrs = append(allErrs, validateTemplateBodyPath(&spec.Template, fldPath.Child("template"))...)
+	if len(spec.Template.Objects) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("template", "objects"), ""))
+	}
+	if spec.Secret != nil {
+		if len(spec.Secret.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("secret", "name"), ""))
+		} else if ok, msg := validation.ValidateSecretName(spec.Secret.Name, false); !ok {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("secret", "name"), spec.Secret.Name, msg))
+		}
 	}
-	allErrs = append(allErrs, validation.ValidateLabels(template.ObjectLabels, field.NewPath("labels"))...)
 	return
 }
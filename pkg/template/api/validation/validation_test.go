@@ -5,6 +5,7 @@ import (
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	"github.com/openshift/origin/pkg/template/api"
 )
@@ -43,6 +44,66 @@ func TestValidateParameter(t *testing.T) {
 	}
 }
 
+func TestValidateParameterConstraints(t *testing.T) {
+	var tests = []struct {
+		name      string
+		param     api.Parameter
+		expectErr bool
+	}{
+		{name: "no constraints", param: api.Parameter{Name: "A"}},
+		{name: "all constraints", param: api.Parameter{Name: "A", Type: api.ParameterTypeInt, Pattern: "[0-9]+", AllowedValues: []string{"1", "10"}, MinLength: 1, MaxLength: 2}},
+		{name: "unknown type", param: api.Parameter{Name: "A", Type: "float"}, expectErr: true},
+		{name: "invalid pattern", param: api.Parameter{Name: "A", Pattern: "[a-"}, expectErr: true},
+		{name: "negative length", param: api.Parameter{Name: "A", MinLength: -1}, expectErr: true},
+		{name: "min length above max length", param: api.Parameter{Name: "A", MinLength: 3, MaxLength: 2}, expectErr: true},
+		{name: "valid default value", param: api.Parameter{Name: "A", Type: api.ParameterTypeBool, Value: "true"}},
+		{name: "invalid default value", param: api.Parameter{Name: "A", Type: api.ParameterTypeBool, Value: "yes"}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		errs := ValidateParameter(&test.param, nil)
+		if test.expectErr != (len(errs) > 0) {
+			t.Errorf("%s: unexpected errors: %v", test.name, errs)
+		}
+	}
+}
+
+func TestValidateParameterValue(t *testing.T) {
+	var tests = []struct {
+		name      string
+		param     api.Parameter
+		expectErr bool
+	}{
+		{name: "empty value", param: api.Parameter{Type: api.ParameterTypeInt, MinLength: 2}},
+		{name: "string", param: api.Parameter{Value: "any value"}},
+		{name: "int", param: api.Parameter{Type: api.ParameterTypeInt, Value: "-42"}},
+		{name: "invalid int", param: api.Parameter{Type: api.ParameterTypeInt, Value: "4.2"}, expectErr: true},
+		{name: "bool", param: api.Parameter{Type: api.ParameterTypeBool, Value: "false"}},
+		{name: "invalid bool", param: api.Parameter{Type: api.ParameterTypeBool, Value: "1"}, expectErr: true},
+		{name: "base64", param: api.Parameter{Type: api.ParameterTypeBase64, Value: "aGVsbG8="}},
+		{name: "invalid base64", param: api.Parameter{Type: api.ParameterTypeBase64, Value: "hello!"}, expectErr: true},
+		{name: "matching pattern", param: api.Parameter{Pattern: "[a-z]+", Value: "abc"}},
+		{name: "partially matching pattern", param: api.Parameter{Pattern: "[a-z]+", Value: "abc1"}, expectErr: true},
+		{name: "allowed value", param: api.Parameter{AllowedValues: []string{"small", "large"}, Value: "large"}},
+		{name: "value not allowed", param: api.Parameter{AllowedValues: []string{"small", "large"}, Value: "medium"}, expectErr: true},
+		{name: "length in range", param: api.Parameter{MinLength: 2, MaxLength: 3, Value: "äbc"}},
+		{name: "too short", param: api.Parameter{MinLength: 2, Value: "a"}, expectErr: true},
+		{name: "too long", param: api.Parameter{MaxLength: 2, Value: "abc"}, expectErr: true},
+	}
+
+	for _, test := range tests {
+		errs := ValidateParameterValue(&test.param, field.NewPath("parameters").Index(0))
+		if test.expectErr != (len(errs) > 0) {
+			t.Errorf("%s: unexpected errors: %v", test.name, errs)
+		}
+		for _, err := range errs {
+			if err.Field != "parameters[0].value" {
+				t.Errorf("%s: unexpected error field: %s", test.name, err.Field)
+			}
+		}
+	}
+}
+
 func TestValidateProcessTemplate(t *testing.T) {
 	var tests = []struct {
 		template        *api.Template
@@ -189,3 +250,87 @@ func TestValidateTemplate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateTemplateInstance(t *testing.T) {
+	objects := []runtime.Object{&kapi.Service{ObjectMeta: kapi.ObjectMeta{Name: "svc"}}}
+	meta := kapi.ObjectMeta{Name: "instance", Namespace: kapi.NamespaceDefault}
+
+	tests := map[string]struct {
+		templateInstance *api.TemplateInstance
+		isValidExpected  bool
+	}{
+		"valid": {
+			templateInstance: &api.TemplateInstance{ObjectMeta: meta, Spec: api.TemplateInstanceSpec{Template: api.Template{Objects: objects}}},
+			isValidExpected:  true,
+		},
+		"valid with a secret": {
+			templateInstance: &api.TemplateInstance{ObjectMeta: meta, Spec: api.TemplateInstanceSpec{Template: api.Template{Objects: objects}, Secret: &kapi.LocalObjectReference{Name: "params"}}},
+			isValidExpected:  true,
+		},
+		"missing namespace": {
+			templateInstance: &api.TemplateInstance{ObjectMeta: kapi.ObjectMeta{Name: "instance"}, Spec: api.TemplateInstanceSpec{Template: api.Template{Objects: objects}}},
+		},
+		"no objects": {
+			templateInstance: &api.TemplateInstance{ObjectMeta: meta},
+		},
+		"invalid parameter": {
+			templateInstance: &api.TemplateInstance{ObjectMeta: meta, Spec: api.TemplateInstanceSpec{Template: api.Template{Objects: objects, Parameters: []api.Parameter{*makeParameter("", "1")}}}},
+		},
+		"empty secret name": {
+			templateInstance: &api.TemplateInstance{ObjectMeta: meta, Spec: api.TemplateInstanceSpec{Template: api.Template{Objects: objects}, Secret: &kapi.LocalObjectReference{}}},
+		},
+		"invalid secret name": {
+			templateInstance: &api.TemplateInstance{ObjectMeta: meta, Spec: api.TemplateInstanceSpec{Template: api.Template{Objects: objects}, Secret: &kapi.LocalObjectReference{Name: "Params"}}},
+		},
+	}
+
+	for name, test := range tests {
+		errs := ValidateTemplateInstance(test.templateInstance)
+		if len(errs) != 0 && test.isValidExpected {
+			t.Errorf("%s: Unexpected non-empty error list: %v", name, errs.ToAggregate())
+		}
+		if len(errs) == 0 && !test.isValidExpected {
+			t.Errorf("%s: Unexpected empty error list", name)
+		}
+	}
+}
+
+func TestValidateTemplateInstanceStatusUpdate(t *testing.T) {
+	old := &api.TemplateInstance{ObjectMeta: kapi.ObjectMeta{Name: "instance", Namespace: kapi.NamespaceDefault, ResourceVersion: "1"}}
+
+	tests := map[string]struct {
+		conditions      []api.TemplateInstanceCondition
+		objects         []kapi.ObjectReference
+		isValidExpected bool
+	}{
+		"no conditions": {isValidExpected: true},
+		"objects in the namespace": {
+			objects:         []kapi.ObjectReference{{Kind: "Service", APIVersion: "v1", Namespace: kapi.NamespaceDefault, Name: "svc"}},
+			isValidExpected: true,
+		},
+		"object in another namespace": {objects: []kapi.ObjectReference{{Kind: "Service", APIVersion: "v1", Namespace: "other", Name: "svc"}}},
+		"object without name":         {objects: []kapi.ObjectReference{{Kind: "Service", APIVersion: "v1", Namespace: kapi.NamespaceDefault}}},
+		"valid conditions": {
+			conditions: []api.TemplateInstanceCondition{
+				{Type: api.TemplateInstanceReady, Status: kapi.ConditionTrue},
+				{Type: api.TemplateInstanceInstantiateFailure, Status: kapi.ConditionFalse},
+			},
+			isValidExpected: true,
+		},
+		"unknown type":   {conditions: []api.TemplateInstanceCondition{{Type: "Other", Status: kapi.ConditionTrue}}},
+		"invalid status": {conditions: []api.TemplateInstanceCondition{{Type: api.TemplateInstanceReady, Status: "Yes"}}},
+	}
+
+	for name, test := range tests {
+		templateInstance := *old
+		templateInstance.Status.Conditions = test.conditions
+		templateInstance.Status.Objects = test.objects
+		errs := ValidateTemplateInstanceStatusUpdate(&templateInstance, old)
+		if len(errs) != 0 && test.isValidExpected {
+			t.Errorf("%s: Unexpected non-empty error list: %v", name, errs.ToAggregate())
+		}
+		if len(errs) == 0 && !test.isValidExpected {
+			t.Errorf("%s: Unexpected empty error list", name)
+		}
+	}
+}
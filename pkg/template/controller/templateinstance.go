@@ -0,0 +1,717 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+
+	kadmission "k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kapierrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/controller/framework"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util"
+	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/util/workqueue"
+	"k8s.io/kubernetes/pkg/watch"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+	"github.com/openshift/origin/pkg/template/api"
+)
+
+// TemplateInstanceControllerOptions contains options for the TemplateInstanceController.
+type TemplateInstanceControllerOptions struct {
+	// Resync is the interval at which every template instance is checked again. Instances that
+	// failed to be applied are retried, and the readiness of their objects is refreshed.
+	Resync time.Duration
+	// Workers is the number of template instances synchronized concurrently.
+	Workers int
+	// Mapper maps the kinds of the objects of templates to their resources.
+	Mapper meta.RESTMapper
+	// ClientForMapping returns the client used to manage the objects of a resource.
+	ClientForMapping func(mapping *meta.RESTMapping) (resource.RESTClient, error)
+	// Admission is run as the requester of a template instance on every object created or updated,
+	// and on a pod made of the pod template of the objects that have one. The objects are created with
+	// the privileges of the controller, so the admission plugins that depend on the requesting user,
+	// such as security context constraints, must be run here. If nil, no admission is run.
+	Admission kadmission.Interface
+}
+
+// TemplateInstanceController processes the template of every template instance and creates the
+// resulting objects on behalf of the requester of the instance, as long as the requester is allowed
+// to and the objects pass admission as the requester. Only namespaced objects are created, in the
+// namespace of the instance. The objects are applied again when
+// the template or the parameter secret of the instance changes, the objects removed from the
+// template are deleted, and every object of the instance is deleted before the deleted instance is
+// removed. The readiness of the objects is reported in the conditions of the instance.
+type TemplateInstanceController struct {
+	stopChan chan struct{}
+	workers  int
+
+	client    client.Interface
+	kclient   kclient.Interface
+	mapper    *resource.Mapper
+	admission kadmission.Interface
+	now       func() unversioned.Time
+
+	// queue holds the keys of the template instances to synchronize, and the template instances
+	// whose objects must be deleted.
+	queue      *workqueue.Type
+	store      cache.Store
+	controller *framework.Controller
+}
+
+// NewTemplateInstanceController returns a new *TemplateInstanceController.
+func NewTemplateInstanceController(oc client.Interface, kc kclient.Interface, options TemplateInstanceControllerOptions) *TemplateInstanceController {
+	c := &TemplateInstanceController{
+		workers: options.Workers,
+		client:  oc,
+		kclient: kc,
+		mapper: &resource.Mapper{
+			ObjectTyper:  kapi.Scheme,
+			RESTMapper:   options.Mapper,
+			ClientMapper: resource.ClientMapperFunc(options.ClientForMapping),
+		},
+		admission: options.Admission,
+		now:       unversioned.Now,
+		queue:     workqueue.New(),
+	}
+	if c.workers < 1 {
+		c.workers = 1
+	}
+
+	c.store, c.controller = framework.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(opts kapi.ListOptions) (runtime.Object, error) {
+				return oc.TemplateInstances(kapi.NamespaceAll).List(opts)
+			},
+			WatchFunc: func(opts kapi.ListOptions) (watch.Interface, error) {
+				return oc.TemplateInstances(kapi.NamespaceAll).Watch(opts)
+			},
+		},
+		&api.TemplateInstance{},
+		options.Resync,
+		framework.ResourceEventHandlerFuncs{
+			AddFunc: c.enqueue,
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				c.enqueue(newObj)
+			},
+			DeleteFunc: c.templateInstanceDeleted,
+		},
+	)
+	return c
+}
+
+// Run starts the controller loops and returns immediately.
+func (c *TemplateInstanceController) Run() {
+	if c.stopChan == nil {
+		c.stopChan = make(chan struct{})
+		go c.controller.Run(c.stopChan)
+		for i := 0; i < c.workers; i++ {
+			go util.Until(c.work, time.Second, c.stopChan)
+		}
+	}
+}
+
+// Stop gracefully shuts down this controller. It cannot be run again.
+func (c *TemplateInstanceController) Stop() {
+	if c.stopChan != nil {
+		close(c.stopChan)
+		c.stopChan = nil
+		c.queue.ShutDown()
+	}
+}
+
+func (c *TemplateInstanceController) enqueue(obj interface{}) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		util.HandleError(err)
+		return
+	}
+	c.queue.Add(key)
+}
+
+// templateInstanceDeleted queues the deletion of the objects of a template instance removed before the
+// controller deleted them, such as an instance deleted with a grace period of zero.
+func (c *TemplateInstanceController) templateInstanceDeleted(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	templateInstance, ok := obj.(*api.TemplateInstance)
+	if !ok {
+		util.HandleError(fmt.Errorf("unexpected object in the template instance store: %#v", obj))
+		return
+	}
+	c.queue.Add(templateInstance)
+}
+
+// work processes the queue until it is shut down.
+func (c *TemplateInstanceController) work() {
+	for {
+		item, quit := c.queue.Get()
+		if quit {
+			return
+		}
+		var err error
+		switch t := item.(type) {
+		case string:
+			err = c.sync(t)
+		case *api.TemplateInstance:
+			err = c.deleteObjects(t)
+		}
+		if err != nil {
+			util.HandleError(err)
+		}
+		c.queue.Done(item)
+	}
+}
+
+// sync applies the template instance stored under key if it changed or previously failed, and
+// updates its status.
+func (c *TemplateInstanceController) sync(key string) error {
+	obj, exists, err := c.store.GetByKey(key)
+	if err != nil || !exists {
+		return err
+	}
+	copied, err := kapi.Scheme.DeepCopy(obj)
+	if err != nil {
+		return err
+	}
+	templateInstance := copied.(*api.TemplateInstance)
+	if templateInstance.DeletionTimestamp != nil {
+		return c.finalize(templateInstance)
+	}
+	status := templateInstance.Status
+	status.Conditions = append([]api.TemplateInstanceCondition(nil), status.Conditions...)
+
+	secret, err := c.parameterSecret(templateInstance)
+	if err != nil {
+		c.setCondition(&status, api.TemplateInstanceInstantiateFailure, kapi.ConditionTrue, "SecretUnavailable", err.Error())
+		c.setCondition(&status, api.TemplateInstanceReady, kapi.ConditionFalse, "InstantiateFailure", "the parameter secret is unavailable")
+		return c.updateStatus(templateInstance, status)
+	}
+
+	if needsApply(templateInstance, secret) {
+		objects, secretVersion, err := c.apply(templateInstance, secret)
+		if err != nil {
+			status.Objects = mergeObjectReferences(status.Objects, objects)
+			c.setCondition(&status, api.TemplateInstanceInstantiateFailure, kapi.ConditionTrue, "Failed", err.Error())
+			c.setCondition(&status, api.TemplateInstanceReady, kapi.ConditionFalse, "InstantiateFailure", "the template could not be instantiated")
+			return c.updateStatus(templateInstance, status)
+		}
+		status.Objects = objects
+		status.ObservedGeneration = templateInstance.Generation
+		status.ObservedSecretResourceVersion = secretVersion
+		c.setCondition(&status, api.TemplateInstanceInstantiateFailure, kapi.ConditionFalse, "Applied", "")
+	}
+
+	if message := c.notReady(status.Objects); len(message) > 0 {
+		c.setCondition(&status, api.TemplateInstanceReady, kapi.ConditionFalse, "ObjectsNotReady", message)
+	} else {
+		c.setCondition(&status, api.TemplateInstanceReady, kapi.ConditionTrue, "ObjectsReady", "")
+	}
+	return c.updateStatus(templateInstance, status)
+}
+
+// needsApply returns true if the objects of the template instance must be applied because its spec or
+// parameter secret changed since they were last applied, or because they could not be applied.
+func needsApply(templateInstance *api.TemplateInstance, secret *kapi.Secret) bool {
+	if templateInstance.Status.ObservedGeneration != templateInstance.Generation {
+		return true
+	}
+	if secret != nil && secret.ResourceVersion != templateInstance.Status.ObservedSecretResourceVersion {
+		return true
+	}
+	condition := findCondition(templateInstance.Status.Conditions, api.TemplateInstanceInstantiateFailure)
+	return condition != nil && condition.Status == kapi.ConditionTrue
+}
+
+// updateStatus updates the status of the template instance if it changed.
+func (c *TemplateInstanceController) updateStatus(templateInstance *api.TemplateInstance, status api.TemplateInstanceStatus) error {
+	if kapi.Semantic.DeepEqual(templateInstance.Status, status) {
+		return nil
+	}
+	templateInstance.Status = status
+	_, err := c.client.TemplateInstances(templateInstance.Namespace).UpdateStatus(templateInstance)
+	if kapierrors.IsNotFound(err) || kapierrors.IsConflict(err) {
+		// the instance was deleted or changed, its new version is synchronized next
+		return nil
+	}
+	return err
+}
+
+// parameterSecret returns the secret holding the parameter values of the template instance, or nil if
+// the instance has none. The requester of the instance must be allowed to get the secret, since its
+// values may end up in the objects of the instance.
+func (c *TemplateInstanceController) parameterSecret(templateInstance *api.TemplateInstance) (*kapi.Secret, error) {
+	if templateInstance.Spec.Secret == nil {
+		return nil, nil
+	}
+	if err := c.authorize(templateInstance, "get", "secrets", templateInstance.Spec.Secret.Name); err != nil {
+		return nil, err
+	}
+	secret, err := c.kclient.Secrets(templateInstance.Namespace).Get(templateInstance.Spec.Secret.Name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the parameter secret %q: %v", templateInstance.Spec.Secret.Name, err)
+	}
+	return secret, nil
+}
+
+// apply processes the template of the instance and creates or updates the resulting objects, then
+// deletes the objects of the previous version of the instance that are no longer in the template.
+// It returns references to the objects applied, even if an error occurred, and the resource version
+// of the parameter secret they were applied with.
+func (c *TemplateInstanceController) apply(templateInstance *api.TemplateInstance, secret *kapi.Secret) ([]kapi.ObjectReference, string, error) {
+	namespace := templateInstance.Namespace
+	if templateInstance.Spec.Requester == nil {
+		return nil, "", fmt.Errorf("the template instance has no requester")
+	}
+
+	copied, err := kapi.Scheme.DeepCopy(&templateInstance.Spec.Template)
+	if err != nil {
+		return nil, "", err
+	}
+	template := copied.(*api.Template)
+	setParameterValues(template, secret)
+	processed, err := c.client.TemplateConfigs(namespace).Create(template)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to process the template: %v", err)
+	}
+	if err := utilerrors.NewAggregate(runtime.DecodeList(processed.Objects, kapi.Codecs.UniversalDecoder())); err != nil {
+		return nil, "", fmt.Errorf("unable to decode the objects of the template: %v", err)
+	}
+
+	secretVersion := ""
+	if secret != nil {
+		if secret, err = c.storeGeneratedValues(templateInstance, template, processed, secret); err != nil {
+			return nil, "", err
+		}
+		secretVersion = secret.ResourceVersion
+	}
+
+	applied := []kapi.ObjectReference{}
+	for _, obj := range processed.Objects {
+		ref, err := c.applyObject(templateInstance, obj)
+		if err != nil {
+			return applied, "", err
+		}
+		applied = append(applied, *ref)
+	}
+
+	for _, ref := range templateInstance.Status.Objects {
+		if containsObjectReference(applied, ref) {
+			continue
+		}
+		if err := c.deleteObject(templateInstance, ref); err != nil {
+			return mergeObjectReferences(applied, []kapi.ObjectReference{ref}), "", err
+		}
+	}
+	return applied, secretVersion, nil
+}
+
+// setParameterValues overrides the values of the parameters of template with the values found in the
+// parameter secret.
+func setParameterValues(template *api.Template, secret *kapi.Secret) {
+	if secret == nil {
+		return
+	}
+	for i := range template.Parameters {
+		if value, ok := secret.Data[template.Parameters[i].Name]; ok {
+			template.Parameters[i].Value = string(value)
+		}
+	}
+}
+
+// storeGeneratedValues adds the values generated while processing template to the parameter secret,
+// so that the same values are used when the instance is applied again, if the requester of the
+// instance is allowed to update the secret. It returns the secret as updated.
+func (c *TemplateInstanceController) storeGeneratedValues(templateInstance *api.TemplateInstance, template, processed *api.Template, secret *kapi.Secret) (*kapi.Secret, error) {
+	generated := map[string][]byte{}
+	for i, param := range template.Parameters {
+		if len(param.Generate) == 0 || len(param.Value) > 0 || i >= len(processed.Parameters) {
+			continue
+		}
+		generated[param.Name] = []byte(processed.Parameters[i].Value)
+	}
+	if len(generated) == 0 {
+		return secret, nil
+	}
+	if err := c.authorize(templateInstance, "update", "secrets", secret.Name); err != nil {
+		return nil, err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	for name, value := range generated {
+		secret.Data[name] = value
+	}
+	updated, err := c.kclient.Secrets(secret.Namespace).Update(secret)
+	if err != nil {
+		return nil, fmt.Errorf("unable to store the generated parameter values in the secret %q: %v", secret.Name, err)
+	}
+	return updated, nil
+}
+
+// applyObject creates obj in the namespace of the template instance, or updates the existing object,
+// if the requester of the instance is allowed to.
+func (c *TemplateInstanceController) applyObject(templateInstance *api.TemplateInstance, obj runtime.Object) (*kapi.ObjectReference, error) {
+	namespace := templateInstance.Namespace
+	info, err := c.mapper.InfoForObject(obj)
+	if err != nil {
+		return nil, err
+	}
+	if info.Mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return nil, fmt.Errorf("%s are not namespaced and cannot be created by a template instance", info.Mapping.Resource)
+	}
+	if len(info.Name) == 0 {
+		return nil, fmt.Errorf("the %s objects of a template instance must have a name", info.Mapping.Resource)
+	}
+	if err := info.Mapping.MetadataAccessor.SetNamespace(obj, namespace); err != nil {
+		return nil, err
+	}
+	helper := resource.NewHelper(info.Client, info.Mapping)
+
+	var result runtime.Object
+	_, err = helper.Get(namespace, info.Name, false)
+	switch {
+	case kapierrors.IsNotFound(err):
+		if err := c.authorize(templateInstance, "create", info.Mapping.Resource, info.Name); err != nil {
+			return nil, err
+		}
+		if err := c.admit(templateInstance, kadmission.Create, info.Mapping, info.Name, obj); err != nil {
+			return nil, err
+		}
+		result, err = helper.Create(namespace, false, obj)
+	case err == nil:
+		if err := c.authorize(templateInstance, "update", info.Mapping.Resource, info.Name); err != nil {
+			return nil, err
+		}
+		if err := c.admit(templateInstance, kadmission.Update, info.Mapping, info.Name, obj); err != nil {
+			return nil, err
+		}
+		var patch []byte
+		if patch, err = mergePatch(obj, info.Mapping); err == nil {
+			result, err = helper.Patch(namespace, info.Name, kapi.MergePatchType, patch)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to apply %s %q: %v", info.Mapping.Resource, info.Name, err)
+	}
+	glog.V(4).Infof("Applied %s %s/%s of template instance %s", info.Mapping.Resource, namespace, info.Name, templateInstance.Name)
+
+	uid, _ := info.Mapping.MetadataAccessor.UID(result)
+	return &kapi.ObjectReference{
+		Kind:       info.Mapping.GroupVersionKind.Kind,
+		APIVersion: info.Mapping.GroupVersionKind.GroupVersion().String(),
+		Namespace:  namespace,
+		Name:       info.Name,
+		UID:        uid,
+	}, nil
+}
+
+// mergePatch returns a JSON merge patch setting every field of obj but its status and the metadata
+// managed by the server. Labels and annotations are added to the existing ones.
+func mergePatch(obj runtime.Object, mapping *meta.RESTMapping) ([]byte, error) {
+	data, err := runtime.Encode(kapi.Codecs.LegacyCodec(mapping.GroupVersionKind.GroupVersion()), obj)
+	if err != nil {
+		return nil, err
+	}
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(data, &patch); err != nil {
+		return nil, err
+	}
+	delete(patch, "status")
+	if metadata, ok := patch["metadata"].(map[string]interface{}); ok {
+		applied := map[string]interface{}{}
+		for _, key := range []string{"labels", "annotations"} {
+			if value, ok := metadata[key]; ok && value != nil {
+				applied[key] = value
+			}
+		}
+		patch["metadata"] = applied
+	}
+	return json.Marshal(patch)
+}
+
+// finalize deletes the objects of a template instance being deleted, then removes the instance. The
+// instance is kept until all of its objects are deleted, so that none are left behind if the controller
+// was not running when the instance was deleted.
+func (c *TemplateInstanceController) finalize(templateInstance *api.TemplateInstance) error {
+	if err := c.deleteObjects(templateInstance); err != nil {
+		return err
+	}
+	client := c.client.TemplateInstances(templateInstance.Namespace)
+	if len(templateInstance.Status.Objects) > 0 {
+		templateInstance.Status.Objects = nil
+		_, err := client.UpdateStatus(templateInstance)
+		if kapierrors.IsNotFound(err) || kapierrors.IsConflict(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := client.Delete(templateInstance.Name, kapi.NewDeleteOptions(0)); err != nil && !kapierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// deleteObjects deletes the objects of a deleted template instance.
+func (c *TemplateInstanceController) deleteObjects(templateInstance *api.TemplateInstance) error {
+	errs := []error{}
+	for _, ref := range templateInstance.Status.Objects {
+		if err := c.deleteObject(templateInstance, ref); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// deleteObject deletes the object of a template instance referenced by ref, if the requester of the
+// instance is allowed to. Only objects in the namespace of the instance are deleted.
+func (c *TemplateInstanceController) deleteObject(templateInstance *api.TemplateInstance, ref kapi.ObjectReference) error {
+	namespace := templateInstance.Namespace
+	if ref.Namespace != namespace {
+		return fmt.Errorf("%s %s/%s is not in the namespace of template instance %s/%s", ref.Kind, ref.Namespace, ref.Name, namespace, templateInstance.Name)
+	}
+	mapping, helper, err := c.helperFor(ref)
+	if err != nil {
+		return err
+	}
+	if err := c.authorize(templateInstance, "delete", mapping.Resource, ref.Name); err != nil {
+		return err
+	}
+	if err := helper.Delete(namespace, ref.Name); err != nil && !kapierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to delete %s %q of template instance %s/%s: %v", mapping.Resource, ref.Name, namespace, templateInstance.Name, err)
+	}
+	glog.V(4).Infof("Deleted %s %s/%s of template instance %s", mapping.Resource, namespace, ref.Name, templateInstance.Name)
+	return nil
+}
+
+// helperFor returns the mapping and a helper for the resource of the object referenced by ref.
+func (c *TemplateInstanceController) helperFor(ref kapi.ObjectReference) (*meta.RESTMapping, *resource.Helper, error) {
+	gv, err := unversioned.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+	mapping, err := c.mapper.RESTMapping(unversioned.GroupKind{Group: gv.Group, Kind: ref.Kind}, gv.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := c.mapper.ClientForMapping(mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+	return mapping, resource.NewHelper(client, mapping), nil
+}
+
+// authorize returns an error if the requester of the template instance is not allowed to perform verb
+// on the named object of resource in the namespace of the instance.
+func (c *TemplateInstanceController) authorize(templateInstance *api.TemplateInstance, verb, resource, name string) error {
+	requester := templateInstance.Spec.Requester
+	if requester == nil {
+		return fmt.Errorf("the template instance has no requester")
+	}
+	review := &authorizationapi.LocalSubjectAccessReview{
+		Action: authorizationapi.AuthorizationAttributes{
+			Verb:         verb,
+			Resource:     resource,
+			ResourceName: name,
+		},
+		User:   requester.Username,
+		Groups: sets.NewString(requester.Groups...),
+		Scopes: requester.Scopes,
+	}
+	response, err := c.client.LocalSubjectAccessReviews(templateInstance.Namespace).Create(review)
+	if err != nil {
+		return err
+	}
+	if !response.Allowed {
+		return fmt.Errorf("%s is not allowed to %s %s %q: %s", requester.Username, verb, resource, name, response.Reason)
+	}
+	return nil
+}
+
+// admit runs the admission of the controller as the requester of the template instance on obj, about to
+// be created or updated, and on a pod made of its pod template if it has one, since the pods of the
+// object are created later by controllers which do not act as the requester. obj is not modified.
+func (c *TemplateInstanceController) admit(templateInstance *api.TemplateInstance, operation kadmission.Operation, mapping *meta.RESTMapping, name string, obj runtime.Object) error {
+	if c.admission == nil {
+		return nil
+	}
+	requester := templateInstance.Spec.Requester
+	info := user.DefaultInfo{Name: requester.Username, Groups: requester.Groups}
+	var userInfo user.Info = &info
+	if len(requester.Scopes) > 0 {
+		userInfo = &authapi.DefaultScopedUserInfo{DefaultInfo: info, Scopes: requester.Scopes}
+	}
+	namespace := templateInstance.Namespace
+
+	copied, err := kapi.Scheme.DeepCopy(obj)
+	if err != nil {
+		return err
+	}
+	gvk := mapping.GroupVersionKind
+	resource := unversioned.GroupResource{Group: gvk.Group, Resource: mapping.Resource}
+	attributes := kadmission.NewAttributesRecord(copied.(runtime.Object), gvk.GroupKind(), namespace, name, resource, "", operation, userInfo)
+	if err := c.admission.Admit(attributes); err != nil {
+		return fmt.Errorf("%s %q of %s was not admitted: %v", mapping.Resource, name, requester.Username, err)
+	}
+
+	template := podTemplateFor(obj)
+	if template == nil {
+		return nil
+	}
+	pod := &kapi.Pod{ObjectMeta: template.ObjectMeta, Spec: template.Spec}
+	copied, err = kapi.Scheme.DeepCopy(pod)
+	if err != nil {
+		return err
+	}
+	pod = copied.(*kapi.Pod)
+	pod.Namespace = namespace
+	pod.Name = name
+	attributes = kadmission.NewAttributesRecord(pod, kapi.Kind("Pod"), namespace, name, kapi.Resource("pods"), "", kadmission.Create, userInfo)
+	if err := c.admission.Admit(attributes); err != nil {
+		return fmt.Errorf("the pods of %s %q of %s were not admitted: %v", mapping.Resource, name, requester.Username, err)
+	}
+	return nil
+}
+
+// podTemplateFor returns the template of the pods created from obj, or nil if obj does not create pods.
+func podTemplateFor(obj runtime.Object) *kapi.PodTemplateSpec {
+	switch t := obj.(type) {
+	case *kapi.PodTemplate:
+		return &t.Template
+	case *kapi.ReplicationController:
+		return t.Spec.Template
+	case *deployapi.DeploymentConfig:
+		return t.Spec.Template
+	case *extensions.Deployment:
+		return &t.Spec.Template
+	case *extensions.DaemonSet:
+		return t.Spec.Template
+	case *extensions.Job:
+		return &t.Spec.Template
+	case *extensions.ReplicaSet:
+		return t.Spec.Template
+	}
+	return nil
+}
+
+// notReady returns a message listing the objects that are not ready, or an empty string if every object
+// is ready.
+func (c *TemplateInstanceController) notReady(refs []kapi.ObjectReference) string {
+	notReady := []string{}
+	for _, ref := range refs {
+		mapping, helper, err := c.helperFor(ref)
+		if err != nil {
+			notReady = append(notReady, fmt.Sprintf("%s %q", strings.ToLower(ref.Kind), ref.Name))
+			continue
+		}
+		obj, err := helper.Get(ref.Namespace, ref.Name, false)
+		if err != nil || !c.objectReady(obj) {
+			notReady = append(notReady, fmt.Sprintf("%s %q", mapping.Resource, ref.Name))
+		}
+	}
+	if len(notReady) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("waiting for %s", strings.Join(notReady, ", "))
+}
+
+// objectReady returns true if obj finished starting. Builds must be complete, deployment configs must
+// have completed their latest deployment, replication controllers must have all their replicas, and
+// pods must be ready or have succeeded. Other objects are ready once they exist.
+func (c *TemplateInstanceController) objectReady(obj runtime.Object) bool {
+	switch t := obj.(type) {
+	case *buildapi.Build:
+		return t.Status.Phase == buildapi.BuildPhaseComplete
+	case *deployapi.DeploymentConfig:
+		if t.Status.LatestVersion == 0 {
+			return false
+		}
+		deployment, err := c.kclient.ReplicationControllers(t.Namespace).Get(deployutil.LatestDeploymentNameForConfig(t))
+		if err != nil {
+			return false
+		}
+		return deployutil.DeploymentStatusFor(deployment) == deployapi.DeploymentStatusComplete
+	case *kapi.ReplicationController:
+		return t.Status.ObservedGeneration >= t.Generation && t.Status.Replicas == t.Spec.Replicas
+	case *kapi.Pod:
+		if t.Status.Phase == kapi.PodSucceeded {
+			return true
+		}
+		for _, condition := range t.Status.Conditions {
+			if condition.Type == kapi.PodReady {
+				return condition.Status == kapi.ConditionTrue
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// setCondition sets the condition of type conditionType in status, updating its transition time if its
+// status changes.
+func (c *TemplateInstanceController) setCondition(status *api.TemplateInstanceStatus, conditionType api.TemplateInstanceConditionType, conditionStatus kapi.ConditionStatus, reason, message string) {
+	condition := findCondition(status.Conditions, conditionType)
+	if condition == nil {
+		status.Conditions = append(status.Conditions, api.TemplateInstanceCondition{Type: conditionType})
+		condition = &status.Conditions[len(status.Conditions)-1]
+	}
+	if condition.Status != conditionStatus {
+		condition.Status = conditionStatus
+		condition.LastTransitionTime = c.now()
+	}
+	condition.Reason = reason
+	condition.Message = message
+}
+
+// findCondition returns the condition of type conditionType, or nil if there is none.
+func findCondition(conditions []api.TemplateInstanceCondition, conditionType api.TemplateInstanceConditionType) *api.TemplateInstanceCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// containsObjectReference returns true if refs contains a reference to the same object as ref.
+func containsObjectReference(refs []kapi.ObjectReference, ref kapi.ObjectReference) bool {
+	for _, r := range refs {
+		if r.Kind == ref.Kind && r.APIVersion == ref.APIVersion && r.Namespace == ref.Namespace && r.Name == ref.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeObjectReferences returns the references of refs followed by the references of added that refer
+// to other objects.
+func mergeObjectReferences(refs, added []kapi.ObjectReference) []kapi.ObjectReference {
+	merged := append([]kapi.ObjectReference{}, refs...)
+	for _, ref := range added {
+		if !containsObjectReference(merged, ref) {
+			merged = append(merged, ref)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,374 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	kadmission "k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/meta"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apimachinery/registered"
+	"k8s.io/kubernetes/pkg/client/cache"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/template/api"
+)
+
+func TestNeedsApply(t *testing.T) {
+	failed := []api.TemplateInstanceCondition{{Type: api.TemplateInstanceInstantiateFailure, Status: kapi.ConditionTrue}}
+	applied := []api.TemplateInstanceCondition{{Type: api.TemplateInstanceInstantiateFailure, Status: kapi.ConditionFalse}}
+	secret := &kapi.Secret{ObjectMeta: kapi.ObjectMeta{ResourceVersion: "2"}}
+
+	tests := []struct {
+		name     string
+		status   api.TemplateInstanceStatus
+		secret   *kapi.Secret
+		expected bool
+	}{
+		{name: "new", status: api.TemplateInstanceStatus{}, expected: true},
+		{name: "applied", status: api.TemplateInstanceStatus{ObservedGeneration: 1, Conditions: applied}},
+		{name: "spec changed", status: api.TemplateInstanceStatus{ObservedGeneration: 0, Conditions: applied}, expected: true},
+		{name: "failed", status: api.TemplateInstanceStatus{ObservedGeneration: 1, Conditions: failed}, expected: true},
+		{name: "secret unchanged", status: api.TemplateInstanceStatus{ObservedGeneration: 1, ObservedSecretResourceVersion: "2", Conditions: applied}, secret: secret},
+		{name: "secret changed", status: api.TemplateInstanceStatus{ObservedGeneration: 1, ObservedSecretResourceVersion: "1", Conditions: applied}, secret: secret, expected: true},
+	}
+
+	for _, test := range tests {
+		templateInstance := &api.TemplateInstance{ObjectMeta: kapi.ObjectMeta{Generation: 1}, Status: test.status}
+		if actual := needsApply(templateInstance, test.secret); actual != test.expected {
+			t.Errorf("%s: expected %t, got %t", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestParameterSecret(t *testing.T) {
+	secret := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "params", ResourceVersion: "1"},
+		Data:       map[string][]byte{"USER": []byte("admin")},
+	}
+	kc := ktestclient.NewSimpleFake(secret)
+	oc := testclient.NewSimpleFake()
+	allowed := map[string]bool{}
+	oc.PrependReactor("create", "localsubjectaccessreviews", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		review := action.(ktestclient.CreateAction).GetObject().(*authorizationapi.LocalSubjectAccessReview)
+		if review.User != "user" || review.Action.Resource != "secrets" || review.Action.ResourceName != "params" {
+			t.Errorf("unexpected review: %#v", review)
+		}
+		return true, &authorizationapi.SubjectAccessReviewResponse{Allowed: allowed[review.Action.Verb]}, nil
+	})
+	c := &TemplateInstanceController{client: oc, kclient: kc}
+	templateInstance := &api.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"},
+		Spec: api.TemplateInstanceSpec{
+			Secret:    &kapi.LocalObjectReference{Name: "params"},
+			Requester: &api.TemplateInstanceRequester{Username: "user"},
+		},
+	}
+
+	if _, err := c.parameterSecret(templateInstance); err == nil {
+		t.Fatalf("expected the requester not to be allowed to get the secret")
+	}
+	if actions := kc.Actions(); len(actions) != 0 {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+	allowed["get"] = true
+	if got, err := c.parameterSecret(templateInstance); err != nil || got.Name != "params" {
+		t.Fatalf("unexpected secret %#v: %v", got, err)
+	}
+	kc.ClearActions()
+
+	template := &api.Template{
+		Parameters: []api.Parameter{
+			{Name: "USER", Value: "guest"},
+			{Name: "PASSWORD", Generate: "expression", From: "[a-z]{8}"},
+			{Name: "PORT", Value: "8080"},
+		},
+	}
+	setParameterValues(template, secret)
+	if template.Parameters[0].Value != "admin" || template.Parameters[2].Value != "8080" {
+		t.Fatalf("unexpected parameters: %#v", template.Parameters)
+	}
+
+	processed := &api.Template{
+		Parameters: []api.Parameter{
+			{Name: "USER", Value: "admin"},
+			{Name: "PASSWORD", Value: "generated"},
+			{Name: "PORT", Value: "8080"},
+		},
+	}
+	if _, err := c.storeGeneratedValues(templateInstance, template, processed, secret); err == nil {
+		t.Fatalf("expected the requester not to be allowed to update the secret")
+	}
+	if actions := kc.Actions(); len(actions) != 0 {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+	allowed["update"] = true
+	if _, err := c.storeGeneratedValues(templateInstance, template, processed, secret); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actions := kc.Actions()
+	if len(actions) != 1 || !actions[0].Matches("update", "secrets") {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+	updated := actions[0].(ktestclient.UpdateAction).GetObject().(*kapi.Secret)
+	expected := map[string][]byte{"USER": []byte("admin"), "PASSWORD": []byte("generated")}
+	if !reflect.DeepEqual(expected, updated.Data) {
+		t.Errorf("unexpected secret data: %v", updated.Data)
+	}
+}
+
+func TestObjectReady(t *testing.T) {
+	deployment := func(name string, status deployapi.DeploymentStatus) *kapi.ReplicationController {
+		return &kapi.ReplicationController{
+			ObjectMeta: kapi.ObjectMeta{
+				Namespace:   "ns",
+				Name:        name,
+				Annotations: map[string]string{deployapi.DeploymentStatusAnnotation: string(status)},
+			},
+		}
+	}
+	config := func(version int) *deployapi.DeploymentConfig {
+		return &deployapi.DeploymentConfig{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"},
+			Status:     deployapi.DeploymentConfigStatus{LatestVersion: version},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		obj        runtime.Object
+		deployment *kapi.ReplicationController
+		expected   bool
+	}{
+		{name: "service", obj: &kapi.Service{}, expected: true},
+		{name: "running build", obj: &buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseRunning}}},
+		{name: "complete build", obj: &buildapi.Build{Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseComplete}}, expected: true},
+		{name: "deployment config never deployed", obj: config(0)},
+		{name: "deployment config with a running deployment", obj: config(3), deployment: deployment("app-3", deployapi.DeploymentStatusRunning)},
+		{name: "deployment config with a complete deployment", obj: config(2), deployment: deployment("app-2", deployapi.DeploymentStatusComplete), expected: true},
+		{name: "deployment config with a missing deployment", obj: config(2)},
+		{name: "scaling replication controller", obj: &kapi.ReplicationController{Spec: kapi.ReplicationControllerSpec{Replicas: 2}, Status: kapi.ReplicationControllerStatus{Replicas: 1}}},
+		{name: "scaled replication controller", obj: &kapi.ReplicationController{Spec: kapi.ReplicationControllerSpec{Replicas: 2}, Status: kapi.ReplicationControllerStatus{Replicas: 2}}, expected: true},
+		{name: "pending pod", obj: &kapi.Pod{Status: kapi.PodStatus{Phase: kapi.PodPending}}},
+		{name: "ready pod", obj: &kapi.Pod{Status: kapi.PodStatus{Phase: kapi.PodRunning, Conditions: []kapi.PodCondition{{Type: kapi.PodReady, Status: kapi.ConditionTrue}}}}, expected: true},
+		{name: "succeeded pod", obj: &kapi.Pod{Status: kapi.PodStatus{Phase: kapi.PodSucceeded}}, expected: true},
+	}
+
+	for _, test := range tests {
+		objects := []runtime.Object{}
+		if test.deployment != nil {
+			objects = append(objects, test.deployment)
+		}
+		c := &TemplateInstanceController{kclient: ktestclient.NewSimpleFake(objects...)}
+		if actual := c.objectReady(test.obj); actual != test.expected {
+			t.Errorf("%s: expected %t, got %t", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestSyncWithoutRequester(t *testing.T) {
+	templateInstance := &api.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app", Generation: 1},
+	}
+	oc := testclient.NewSimpleFake()
+	now := unversioned.Now()
+	c := &TemplateInstanceController{
+		client:  oc,
+		kclient: ktestclient.NewSimpleFake(),
+		store:   cache.NewStore(cache.MetaNamespaceKeyFunc),
+		now:     func() unversioned.Time { return now },
+	}
+	c.store.Add(templateInstance)
+
+	if err := c.sync("ns/app"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actions := oc.Actions()
+	if len(actions) != 1 || !actions[0].Matches("update", "templateinstances") || actions[0].GetSubresource() != "status" {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+	updated := actions[0].(ktestclient.CreateAction).GetObject().(*api.TemplateInstance)
+	expected := []api.TemplateInstanceCondition{
+		{Type: api.TemplateInstanceInstantiateFailure, Status: kapi.ConditionTrue, LastTransitionTime: now, Reason: "Failed", Message: "the template instance has no requester"},
+		{Type: api.TemplateInstanceReady, Status: kapi.ConditionFalse, LastTransitionTime: now, Reason: "InstantiateFailure", Message: "the template could not be instantiated"},
+	}
+	if !reflect.DeepEqual(expected, updated.Status.Conditions) {
+		t.Errorf("unexpected conditions: %#v", updated.Status.Conditions)
+	}
+	if updated.Status.ObservedGeneration != 0 {
+		t.Errorf("expected the generation not to be observed")
+	}
+	if len(templateInstance.Status.Conditions) != 0 {
+		t.Errorf("expected the stored template instance not to be modified")
+	}
+}
+
+func TestMergeObjectReferences(t *testing.T) {
+	a := kapi.ObjectReference{Kind: "Service", APIVersion: "v1", Namespace: "ns", Name: "a"}
+	b := kapi.ObjectReference{Kind: "Service", APIVersion: "v1", Namespace: "ns", Name: "b"}
+	bWithUID := b
+	bWithUID.UID = "uid"
+	c := kapi.ObjectReference{Kind: "Route", APIVersion: "v1", Namespace: "ns", Name: "a"}
+
+	merged := mergeObjectReferences([]kapi.ObjectReference{a, bWithUID}, []kapi.ObjectReference{b, c})
+	if expected := []kapi.ObjectReference{a, bWithUID, c}; !reflect.DeepEqual(expected, merged) {
+		t.Errorf("unexpected references: %#v", merged)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	mapping, err := registered.GroupOrDie(kapi.GroupName).RESTMapper.RESTMapping(kapi.Kind("Service"), "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &kapi.Service{
+		ObjectMeta: kapi.ObjectMeta{Name: "svc", ResourceVersion: "3", Labels: map[string]string{"app": "web"}},
+		Spec:       kapi.ServiceSpec{Ports: []kapi.ServicePort{{Port: 80}}},
+		Status:     kapi.ServiceStatus{LoadBalancer: kapi.LoadBalancerStatus{Ingress: []kapi.LoadBalancerIngress{{IP: "1.2.3.4"}}}},
+	}
+	patch, err := mergePatch(service, mapping)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"apiVersion":"v1","kind":"Service","metadata":{"labels":{"app":"web"}},"spec":{"ports":[{"port":80,"targetPort":0}]}}`
+	if string(patch) != expected {
+		t.Errorf("unexpected patch: %s", patch)
+	}
+}
+
+func TestApplyClusterScopedObject(t *testing.T) {
+	oc := testclient.NewSimpleFake()
+	c := &TemplateInstanceController{
+		client: oc,
+		mapper: &resource.Mapper{
+			ObjectTyper: kapi.Scheme,
+			RESTMapper:  registered.GroupOrDie(kapi.GroupName).RESTMapper,
+			ClientMapper: resource.ClientMapperFunc(func(mapping *meta.RESTMapping) (resource.RESTClient, error) {
+				return nil, nil
+			}),
+		},
+	}
+	templateInstance := &api.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"},
+		Spec:       api.TemplateInstanceSpec{Requester: &api.TemplateInstanceRequester{Username: "user"}},
+	}
+
+	_, err := c.applyObject(templateInstance, &kapi.Namespace{ObjectMeta: kapi.ObjectMeta{Name: "other"}})
+	if err == nil || !strings.Contains(err.Error(), "not namespaced") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if actions := oc.Actions(); len(actions) != 0 {
+		t.Errorf("unexpected actions: %#v", actions)
+	}
+}
+
+type fakeAdmission struct {
+	*kadmission.Handler
+	attributes []kadmission.Attributes
+	err        error
+}
+
+func (a *fakeAdmission) Admit(attributes kadmission.Attributes) error {
+	a.attributes = append(a.attributes, attributes)
+	return a.err
+}
+
+func TestAdmit(t *testing.T) {
+	mapping, err := registered.GroupOrDie(kapi.GroupName).RESTMapper.RESTMapping(deployapi.Kind("DeploymentConfig"), "v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	templateInstance := &api.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app"},
+		Spec:       api.TemplateInstanceSpec{Requester: &api.TemplateInstanceRequester{Username: "user", Groups: []string{"group"}}},
+	}
+	config := &deployapi.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "web"},
+		Spec: deployapi.DeploymentConfigSpec{
+			Template: &kapi.PodTemplateSpec{
+				Spec: kapi.PodSpec{Containers: []kapi.Container{{Name: "web", Image: "web"}}},
+			},
+		},
+	}
+
+	admission := &fakeAdmission{}
+	c := &TemplateInstanceController{admission: admission}
+	if err := c.admit(templateInstance, kadmission.Create, mapping, "web", config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(admission.attributes) != 2 {
+		t.Fatalf("expected the config and its pod to be admitted: %#v", admission.attributes)
+	}
+	for i, resource := range []unversioned.GroupResource{deployapi.Resource("deploymentconfigs"), kapi.Resource("pods")} {
+		attributes := admission.attributes[i]
+		if attributes.GetResource() != resource || attributes.GetNamespace() != "ns" || attributes.GetOperation() != kadmission.Create {
+			t.Errorf("unexpected attributes: %#v", attributes)
+		}
+		if userInfo := attributes.GetUserInfo(); userInfo.GetName() != "user" || !reflect.DeepEqual(userInfo.GetGroups(), []string{"group"}) {
+			t.Errorf("expected admission as the requester: %#v", userInfo)
+		}
+		if attributes.GetObject() == config {
+			t.Errorf("expected a copy of the object to be admitted")
+		}
+	}
+	if _, ok := admission.attributes[1].GetObject().(*kapi.Pod); !ok {
+		t.Errorf("expected a pod to be admitted: %#v", admission.attributes[1].GetObject())
+	}
+
+	admission.err = fmt.Errorf("forbidden")
+	if err := c.admit(templateInstance, kadmission.Create, mapping, "web", config); err == nil {
+		t.Errorf("expected the config not to be admitted")
+	}
+}
+
+func TestFinalize(t *testing.T) {
+	now := unversioned.Now()
+	templateInstance := &api.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "ns", Name: "app", DeletionTimestamp: &now},
+		Spec:       api.TemplateInstanceSpec{Requester: &api.TemplateInstanceRequester{Username: "user"}},
+		Status: api.TemplateInstanceStatus{
+			Objects: []kapi.ObjectReference{{Kind: "Service", APIVersion: "v1", Namespace: "other", Name: "svc"}},
+		},
+	}
+	oc := testclient.NewSimpleFake()
+	c := &TemplateInstanceController{
+		client: oc,
+		mapper: &resource.Mapper{
+			ObjectTyper: kapi.Scheme,
+			RESTMapper:  registered.GroupOrDie(kapi.GroupName).RESTMapper,
+			ClientMapper: resource.ClientMapperFunc(func(mapping *meta.RESTMapping) (resource.RESTClient, error) {
+				return nil, nil
+			}),
+		},
+		store: cache.NewStore(cache.MetaNamespaceKeyFunc),
+	}
+	c.store.Add(templateInstance)
+
+	// objects outside the namespace of the instance are never deleted
+	if err := c.sync("ns/app"); err == nil || !strings.Contains(err.Error(), "not in the namespace") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actions := oc.Actions(); len(actions) != 0 {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+
+	// once its objects are deleted, the instance is removed
+	templateInstance.Status.Objects = nil
+	if err := c.sync("ns/app"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	actions := oc.Actions()
+	if len(actions) != 1 || !actions[0].Matches("delete", "templateinstances") {
+		t.Fatalf("unexpected actions: %#v", actions)
+	}
+}
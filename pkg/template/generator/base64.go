@@ -0,0 +1,24 @@
+package generator
+
+import (
+	"encoding/base64"
+)
+
+// Base64Generator implements Generator interface. It encodes the input
+// expression with the standard base64 encoding. The expression usually
+// references another parameter, in which case the value of that parameter
+// is encoded.
+//
+// Example:
+//   - "${PASSWORD}"
+type Base64Generator struct{}
+
+// NewBase64Generator creates new Base64Generator.
+func NewBase64Generator() Base64Generator {
+	return Base64Generator{}
+}
+
+// GenerateValue returns the base64 encoding of the input expression.
+func (g Base64Generator) GenerateValue(expression string) (interface{}, error) {
+	return base64.StdEncoding.EncodeToString([]byte(expression)), nil
+}
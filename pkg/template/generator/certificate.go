@@ -0,0 +1,76 @@
+package generator
+
+import (
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+// DefaultCertificateLifetime is how long the certificates generated by
+// CertificateGenerator are valid.
+const DefaultCertificateLifetime = 2 * 365 * 24 * time.Hour
+
+// CertificateGenerator implements Generator interface. It generates a PEM
+// encoded self-signed certificate from an input expression of the form
+// "<common name> <private key>". The certificate is issued for the common
+// name, which is also added as a DNS name or IP address subject alternative
+// name, and is signed by the PEM encoded RSA or ECDSA private key, usually a
+// reference to a parameter generated by the rsa or ecdsa generator.
+//
+// Example:
+//   - "${HOSTNAME} ${TLS_KEY}"
+type CertificateGenerator struct {
+	lifetime time.Duration
+	now      func() time.Time
+}
+
+// NewCertificateGenerator creates new CertificateGenerator.
+func NewCertificateGenerator() CertificateGenerator {
+	return CertificateGenerator{lifetime: DefaultCertificateLifetime, now: time.Now}
+}
+
+// GenerateValue generates a PEM encoded self-signed certificate.
+func (g CertificateGenerator) GenerateValue(expression string) (interface{}, error) {
+	parts := strings.SplitN(strings.TrimSpace(expression), " ", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return "", errors.New("the expression must be of the form <common name> <private key>")
+	}
+	commonName := parts[0]
+	key, err := parsePrivateKey(parts[1])
+	if err != nil {
+		return "", err
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(math.MaxInt64))
+	if err != nil {
+		return "", err
+	}
+	now := g.now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now.Add(-time.Minute),
+		NotAfter:              now.Add(g.lifetime),
+		KeyUsage:              x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	if ip := net.ParseIP(commonName); ip != nil {
+		template.IPAddresses = []net.IP{ip}
+	} else {
+		template.DNSNames = []string{commonName}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})), nil
+}
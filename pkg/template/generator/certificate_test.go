@@ -0,0 +1,63 @@
+package generator
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+)
+
+func TestCertificateGenerator(t *testing.T) {
+	key, err := NewECDSAKeyGenerator().GenerateValue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2016, 1, 1, 0, 0, 0, 0, time.UTC)
+	g := CertificateGenerator{lifetime: time.Hour, now: func() time.Time { return now }}
+
+	tests := map[string]struct {
+		expression string
+		dnsName    string
+		ipAddress  string
+	}{
+		"host name":   {expression: "www.example.com " + key.(string), dnsName: "www.example.com"},
+		"ip address":  {expression: "10.0.0.1 " + key.(string), ipAddress: "10.0.0.1"},
+		"missing key": {expression: "www.example.com"},
+		"invalid key": {expression: "www.example.com key"},
+	}
+	for name, test := range tests {
+		value, err := g.GenerateValue(test.expression)
+		if len(test.dnsName) == 0 && len(test.ipAddress) == 0 {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		block, _ := pem.Decode([]byte(value.(string)))
+		if block == nil || block.Type != "CERTIFICATE" {
+			t.Errorf("%s: unexpected certificate: %s", name, value)
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+			t.Errorf("%s: expected a self-signed certificate: %v", name, err)
+		}
+		if !cert.NotAfter.Equal(now.Add(time.Hour)) {
+			t.Errorf("%s: unexpected expiry %s", name, cert.NotAfter)
+		}
+		if len(test.dnsName) > 0 && (cert.Subject.CommonName != test.dnsName || len(cert.DNSNames) != 1 || cert.DNSNames[0] != test.dnsName) {
+			t.Errorf("%s: unexpected names %s %v", name, cert.Subject.CommonName, cert.DNSNames)
+		}
+		if len(test.ipAddress) > 0 && (len(cert.IPAddresses) != 1 || cert.IPAddresses[0].String() != test.ipAddress) {
+			t.Errorf("%s: unexpected IP addresses %v", name, cert.IPAddresses)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package generator
+
+import (
+	"errors"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptGenerator implements Generator interface. It hashes the input
+// expression, usually a reference to a password parameter, with bcrypt.
+//
+// Example:
+//   - "${PASSWORD}"
+type BcryptGenerator struct {
+	cost int
+}
+
+// NewBcryptGenerator creates new BcryptGenerator hashing with the default
+// bcrypt cost.
+func NewBcryptGenerator() BcryptGenerator {
+	return BcryptGenerator{cost: bcrypt.DefaultCost}
+}
+
+// GenerateValue returns the bcrypt hash of the input expression.
+func (g BcryptGenerator) GenerateValue(expression string) (interface{}, error) {
+	if len(expression) == 0 {
+		return "", errors.New("the password to hash is empty")
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(expression), g.cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+// HtpasswdGenerator implements Generator interface. It generates an htpasswd
+// file entry from an input expression of the form "<user>:<password>". The
+// password is hashed with bcrypt.
+//
+// Example:
+//   - "${USERNAME}:${PASSWORD}"
+type HtpasswdGenerator struct {
+	bcrypt BcryptGenerator
+}
+
+// NewHtpasswdGenerator creates new HtpasswdGenerator.
+func NewHtpasswdGenerator() HtpasswdGenerator {
+	return HtpasswdGenerator{bcrypt: NewBcryptGenerator()}
+}
+
+// GenerateValue returns the "<user>:<hash>" htpasswd entry of the input
+// expression.
+func (g HtpasswdGenerator) GenerateValue(expression string) (interface{}, error) {
+	parts := strings.SplitN(expression, ":", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return "", errors.New("the expression must be of the form <user>:<password>")
+	}
+	hash, err := g.bcrypt.GenerateValue(parts[1])
+	if err != nil {
+		return "", err
+	}
+	return parts[0] + ":" + hash.(string), nil
+}
@@ -0,0 +1,43 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestBcryptGenerator(t *testing.T) {
+	g := BcryptGenerator{cost: bcrypt.MinCost}
+	value, err := g.GenerateValue("secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(value.(string)), []byte("secret")); err != nil {
+		t.Errorf("the hash does not match the password: %v", err)
+	}
+	if _, err := g.GenerateValue(""); err == nil {
+		t.Errorf("expected an error for an empty password")
+	}
+}
+
+func TestHtpasswdGenerator(t *testing.T) {
+	g := HtpasswdGenerator{bcrypt: BcryptGenerator{cost: bcrypt.MinCost}}
+	value, err := g.GenerateValue("admin:pass:word")
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := strings.SplitN(value.(string), ":", 2)
+	if parts[0] != "admin" {
+		t.Errorf("unexpected user in %s", value)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(parts[1]), []byte("pass:word")); err != nil {
+		t.Errorf("the hash does not match the password: %v", err)
+	}
+
+	for _, expression := range []string{"admin", ":password", "admin:"} {
+		if _, err := g.GenerateValue(expression); err == nil {
+			t.Errorf("%s: expected an error", expression)
+		}
+	}
+}
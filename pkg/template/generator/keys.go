@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultRSAKeySize is the size in bits of the keys generated by RSAKeyGenerator
+	// when the expression is empty.
+	DefaultRSAKeySize = 2048
+	// MinRSAKeySize is the smallest size in bits of the keys generated by RSAKeyGenerator.
+	MinRSAKeySize = 1024
+	// MaxRSAKeySize is the largest size in bits of the keys generated by RSAKeyGenerator. Larger keys
+	// take seconds to generate, and templates are processed by the API server.
+	MaxRSAKeySize = 4096
+)
+
+var curves = map[string]elliptic.Curve{
+	"P256": elliptic.P256(),
+	"P384": elliptic.P384(),
+	"P521": elliptic.P521(),
+}
+
+// RSAKeyGenerator implements Generator interface. It generates a PEM encoded
+// RSA private key whose size in bits is given by the input expression, and
+// defaults to 2048 bits.
+//
+// Examples:
+//   - ""
+//   - "4096"
+type RSAKeyGenerator struct{}
+
+// NewRSAKeyGenerator creates new RSAKeyGenerator.
+func NewRSAKeyGenerator() RSAKeyGenerator {
+	return RSAKeyGenerator{}
+}
+
+// GenerateValue generates a PEM encoded RSA private key.
+func (g RSAKeyGenerator) GenerateValue(expression string) (interface{}, error) {
+	bits := DefaultRSAKeySize
+	if expression = strings.TrimSpace(expression); len(expression) > 0 {
+		var err error
+		if bits, err = strconv.Atoi(expression); err != nil {
+			return "", fmt.Errorf("invalid key size %q", expression)
+		}
+	}
+	if bits < MinRSAKeySize || bits > MaxRSAKeySize {
+		return "", fmt.Errorf("the key size must be between %d and %d bits", MinRSAKeySize, MaxRSAKeySize)
+	}
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})), nil
+}
+
+// ECDSAKeyGenerator implements Generator interface. It generates a PEM
+// encoded ECDSA private key on the curve given by the input expression, one
+// of P256, P384 or P521. The curve defaults to P256.
+//
+// Examples:
+//   - ""
+//   - "P384"
+type ECDSAKeyGenerator struct{}
+
+// NewECDSAKeyGenerator creates new ECDSAKeyGenerator.
+func NewECDSAKeyGenerator() ECDSAKeyGenerator {
+	return ECDSAKeyGenerator{}
+}
+
+// GenerateValue generates a PEM encoded ECDSA private key.
+func (g ECDSAKeyGenerator) GenerateValue(expression string) (interface{}, error) {
+	name := strings.TrimSpace(expression)
+	if len(name) == 0 {
+		name = "P256"
+	}
+	curve, ok := curves[name]
+	if !ok {
+		return "", fmt.Errorf("unknown curve %q, must be one of P256, P384 or P521", name)
+	}
+	key, err := ecdsa.GenerateKey(curve, rand.Reader)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})), nil
+}
+
+// PublicKeyGenerator implements Generator interface. It returns the PEM
+// encoded public key of the RSA or ECDSA private key given by the input
+// expression, usually a reference to a parameter generated by the rsa or
+// ecdsa generator.
+//
+// Example:
+//   - "${PRIVATE_KEY}"
+type PublicKeyGenerator struct{}
+
+// NewPublicKeyGenerator creates new PublicKeyGenerator.
+func NewPublicKeyGenerator() PublicKeyGenerator {
+	return PublicKeyGenerator{}
+}
+
+// GenerateValue returns the PEM encoded public key of a private key.
+func (g PublicKeyGenerator) GenerateValue(expression string) (interface{}, error) {
+	key, err := parsePrivateKey(expression)
+	if err != nil {
+		return "", err
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// parsePrivateKey decodes a PEM encoded RSA or ECDSA private key.
+func parsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(data)))
+	if block == nil {
+		return nil, errors.New("the private key must be PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, errors.New("the private key must be an RSA or ECDSA key")
+	}
+	switch t := key.(type) {
+	case *rsa.PrivateKey:
+		return t, nil
+	case *ecdsa.PrivateKey:
+		return t, nil
+	}
+	return nil, errors.New("the private key must be an RSA or ECDSA key")
+}
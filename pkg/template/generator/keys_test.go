@@ -0,0 +1,101 @@
+package generator
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+)
+
+func TestRSAKeyGenerator(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		bits       int
+	}{
+		"default size":   {expression: "", bits: DefaultRSAKeySize},
+		"explicit size":  {expression: "1024", bits: 1024},
+		"invalid size":   {expression: "large"},
+		"too small size": {expression: "512"},
+	}
+	for name, test := range tests {
+		value, err := NewRSAKeyGenerator().GenerateValue(test.expression)
+		if test.bits == 0 {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		key, err := parsePrivateKey(value.(string))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if bits := key.(*rsa.PrivateKey).N.BitLen(); bits != test.bits {
+			t.Errorf("%s: expected a %d bits key, got %d", name, test.bits, bits)
+		}
+	}
+}
+
+func TestECDSAKeyGenerator(t *testing.T) {
+	tests := map[string]struct {
+		expression string
+		curve      string
+	}{
+		"default curve": {expression: "", curve: "P-256"},
+		"P384":          {expression: "P384", curve: "P-384"},
+		"unknown curve": {expression: "P192"},
+	}
+	for name, test := range tests {
+		value, err := NewECDSAKeyGenerator().GenerateValue(test.expression)
+		if len(test.curve) == 0 {
+			if err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		key, err := parsePrivateKey(value.(string))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if curve := key.(*ecdsa.PrivateKey).Curve.Params().Name; curve != test.curve {
+			t.Errorf("%s: expected curve %s, got %s", name, test.curve, curve)
+		}
+	}
+}
+
+func TestPublicKeyGenerator(t *testing.T) {
+	privateKey, err := NewECDSAKeyGenerator().GenerateValue("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	value, err := NewPublicKeyGenerator().GenerateValue(privateKey.(string))
+	if err != nil {
+		t.Fatal(err)
+	}
+	block, _ := pem.Decode([]byte(value.(string)))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		t.Fatalf("unexpected public key: %s", value)
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, _ := parsePrivateKey(privateKey.(string))
+	if publicKey.(*ecdsa.PublicKey).X.Cmp(key.(*ecdsa.PrivateKey).X) != 0 {
+		t.Errorf("the public key does not match the private key")
+	}
+
+	if _, err := NewPublicKeyGenerator().GenerateValue("not a key"); err == nil {
+		t.Errorf("expected an error for an invalid private key")
+	}
+}
@@ -0,0 +1,20 @@
+package generator
+
+import (
+	"github.com/pborman/uuid"
+)
+
+// UUIDGenerator implements Generator interface. It generates a random
+// (version 4) UUID, such as "9f1e62b4-5a36-4d0c-a3c6-0b0e1f6b8a2d". The
+// input expression is ignored.
+type UUIDGenerator struct{}
+
+// NewUUIDGenerator creates new UUIDGenerator.
+func NewUUIDGenerator() UUIDGenerator {
+	return UUIDGenerator{}
+}
+
+// GenerateValue generates a random UUID.
+func (g UUIDGenerator) GenerateValue(expression string) (interface{}, error) {
+	return uuid.NewRandom().String(), nil
+}
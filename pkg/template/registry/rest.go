@@ -1,13 +1,17 @@
 package registry
 
 import (
+	"fmt"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/golang/glog"
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	"github.com/openshift/origin/pkg/template"
 	"github.com/openshift/origin/pkg/template/api"
@@ -15,6 +19,14 @@ import (
 	"github.com/openshift/origin/pkg/template/generator"
 )
 
+// MaxExpensiveParameters is the largest number of parameters of a template whose values are generated by
+// one of the expensiveGenerators.
+const MaxExpensiveParameters = 5
+
+// expensiveGenerators take a noticeable amount of CPU for each value they generate. Templates are
+// processed synchronously by the API server, so their use is limited.
+var expensiveGenerators = sets.NewString("rsa", "bcrypt", "htpasswd")
+
 // REST implements RESTStorage interface for processing Template objects.
 type REST struct {
 }
@@ -42,9 +54,20 @@ func (s *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 	if errs := templatevalidation.ValidateProcessedTemplate(tpl); len(errs) > 0 {
 		return nil, errors.NewInvalid(api.Kind("Template"), tpl.Name, errs)
 	}
+	if err := validateExpensiveParameters(tpl); err != nil {
+		return nil, errors.NewInvalid(api.Kind("Template"), tpl.Name, field.ErrorList{err})
+	}
 
 	generators := map[string]generator.Generator{
-		"expression": generator.NewExpressionValueGenerator(rand.New(rand.NewSource(time.Now().UnixNano()))),
+		"expression":  generator.NewExpressionValueGenerator(rand.New(rand.NewSource(time.Now().UnixNano()))),
+		"uuid":        generator.NewUUIDGenerator(),
+		"rsa":         generator.NewRSAKeyGenerator(),
+		"ecdsa":       generator.NewECDSAKeyGenerator(),
+		"publickey":   generator.NewPublicKeyGenerator(),
+		"certificate": generator.NewCertificateGenerator(),
+		"bcrypt":      generator.NewBcryptGenerator(),
+		"htpasswd":    generator.NewHtpasswdGenerator(),
+		"base64":      generator.NewBase64Generator(),
 	}
 	processor := template.NewProcessor(generators)
 	if errs := processor.Process(tpl); len(errs) > 0 {
@@ -61,3 +84,18 @@ func (s *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 
 	return tpl, nil
 }
+
+// validateExpensiveParameters returns an error if more than MaxExpensiveParameters parameter values of
+// the template must be generated by one of the expensiveGenerators.
+func validateExpensiveParameters(tpl *api.Template) *field.Error {
+	count := 0
+	for _, param := range tpl.Parameters {
+		if len(param.Value) == 0 && expensiveGenerators.Has(param.Generate) {
+			count++
+		}
+	}
+	if count > MaxExpensiveParameters {
+		return field.Forbidden(field.NewPath("parameters"), fmt.Sprintf("at most %d parameters may be generated by the %s generators, got %d", MaxExpensiveParameters, strings.Join(expensiveGenerators.List(), ", "), count))
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package registry
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
@@ -210,3 +212,25 @@ func TestNewRESTTemplateLabelsList(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRESTTooManyExpensiveParameters(t *testing.T) {
+	storage := NewREST()
+	newTemplate := func() *template.Template {
+		tpl := &template.Template{ObjectMeta: kapi.ObjectMeta{Name: "test"}}
+		for i := 0; i <= MaxExpensiveParameters; i++ {
+			tpl.Parameters = append(tpl.Parameters, template.Parameter{Name: fmt.Sprintf("KEY_%d", i), Generate: "bcrypt", From: "password"})
+		}
+		return tpl
+	}
+
+	// values given by the user are not generated
+	tpl := newTemplate()
+	tpl.Parameters[0].Value = "hash"
+	if _, err := storage.Create(nil, tpl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := storage.Create(nil, newTemplate()); err == nil || !strings.Contains(err.Error(), "at most") {
+		t.Errorf("expected too many expensive parameters to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package etcd
+
+import (
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	etcdgeneric "k8s.io/kubernetes/pkg/registry/generic/etcd"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/storage"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/template/registry/templateinstance"
+)
+
+// REST implements a RESTStorage for template instances against etcd
+type REST struct {
+	*etcdgeneric.Etcd
+}
+
+// NewREST returns a RESTStorage object that will work against template instances, and the
+// RESTStorage for their status.
+func NewREST(s storage.Interface) (*REST, *StatusREST) {
+	prefix := "/templateinstances"
+
+	store := etcdgeneric.Etcd{
+		NewFunc:     func() runtime.Object { return &api.TemplateInstance{} },
+		NewListFunc: func() runtime.Object { return &api.TemplateInstanceList{} },
+		KeyRootFunc: func(ctx kapi.Context) string {
+			return etcdgeneric.NamespaceKeyRootFunc(ctx, prefix)
+		},
+		KeyFunc: func(ctx kapi.Context, name string) (string, error) {
+			return etcdgeneric.NamespaceKeyFunc(ctx, prefix, name)
+		},
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return obj.(*api.TemplateInstance).Name, nil
+		},
+		PredicateFunc: func(label labels.Selector, field fields.Selector) generic.Matcher {
+			return templateinstance.Matcher(label, field)
+		},
+		QualifiedResource: api.Resource("templateinstances"),
+
+		CreateStrategy: templateinstance.Strategy,
+		UpdateStrategy: templateinstance.Strategy,
+		DeleteStrategy: templateinstance.Strategy,
+
+		ReturnDeletedObject: true,
+
+		Storage: s,
+	}
+
+	statusStore := store
+	statusStore.UpdateStrategy = templateinstance.StatusStrategy
+
+	return &REST{&store}, &StatusREST{store: &statusStore}
+}
+
+// Create records the user creating the template instance as its requester.
+func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, error) {
+	if templateInstance, ok := obj.(*api.TemplateInstance); ok {
+		templateInstance.Spec.Requester = requesterFrom(ctx)
+	}
+	return r.Etcd.Create(ctx, obj)
+}
+
+// Update records the user updating the template instance as its requester.
+func (r *REST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
+	if templateInstance, ok := obj.(*api.TemplateInstance); ok {
+		templateInstance.Spec.Requester = requesterFrom(ctx)
+	}
+	return r.Etcd.Update(ctx, obj)
+}
+
+// requesterFrom returns the user of the request, or nil if the request has no user. The scopes of the
+// user are kept so that the objects of the instance are created with no more permissions than the user has.
+func requesterFrom(ctx kapi.Context) *api.TemplateInstanceRequester {
+	user, ok := kapi.UserFrom(ctx)
+	if !ok {
+		return nil
+	}
+	scopes, _ := authapi.ScopesFor(user)
+	return &api.TemplateInstanceRequester{Username: user.GetName(), Groups: user.GetGroups(), Scopes: scopes}
+}
+
+// StatusREST implements the REST endpoint for changing the status of a template instance.
+type StatusREST struct {
+	store *etcdgeneric.Etcd
+}
+
+func (r *StatusREST) New() runtime.Object {
+	return &api.TemplateInstance{}
+}
+
+// Update alters the status subset of an object.
+func (r *StatusREST) Update(ctx kapi.Context, obj runtime.Object) (runtime.Object, bool, error) {
+	return r.store.Update(ctx, obj)
+}
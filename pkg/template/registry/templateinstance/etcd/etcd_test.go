@@ -0,0 +1,158 @@
+package etcd
+
+import (
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/auth/user"
+	"k8s.io/kubernetes/pkg/registry/registrytest"
+	"k8s.io/kubernetes/pkg/runtime"
+	etcdtesting "k8s.io/kubernetes/pkg/storage/etcd/testing"
+
+	authapi "github.com/openshift/origin/pkg/auth/api"
+	"github.com/openshift/origin/pkg/template/api"
+	_ "github.com/openshift/origin/pkg/template/api/install"
+)
+
+func newStorage(t *testing.T) (*REST, *StatusREST, *etcdtesting.EtcdTestServer) {
+	etcdStorage, server := registrytest.NewEtcdStorage(t, "")
+	storage, statusStorage := NewREST(etcdStorage)
+	return storage, statusStorage, server
+}
+
+func validTemplateInstance() *api.TemplateInstance {
+	return &api.TemplateInstance{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: api.TemplateInstanceSpec{
+			Template: api.Template{
+				Objects: []runtime.Object{
+					&kapi.Service{ObjectMeta: kapi.ObjectMeta{Name: "svc"}},
+				},
+			},
+		},
+	}
+}
+
+func TestCreate(t *testing.T) {
+	storage, _, server := newStorage(t)
+	defer server.Terminate(t)
+	test := registrytest.New(t, storage.Etcd)
+	valid := validTemplateInstance()
+	valid.Name = ""
+	valid.GenerateName = "test-"
+	test.TestCreate(
+		valid,
+		// invalid
+		&api.TemplateInstance{},
+	)
+}
+
+func TestRequesterAndGeneration(t *testing.T) {
+	storage, statusStorage, server := newStorage(t)
+	defer server.Terminate(t)
+
+	ctx := kapi.WithUser(kapi.NewDefaultContext(), &user.DefaultInfo{Name: "alice", Groups: []string{"developers"}})
+	templateInstance := validTemplateInstance()
+	templateInstance.Spec.Requester = &api.TemplateInstanceRequester{Username: "system:admin"}
+	templateInstance.Status.ObservedGeneration = 5
+	obj, err := storage.Create(ctx, templateInstance)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := obj.(*api.TemplateInstance)
+	if created.Spec.Requester == nil || created.Spec.Requester.Username != "alice" || len(created.Spec.Requester.Groups) != 1 {
+		t.Errorf("expected the requester to be the creating user, got %#v", created.Spec.Requester)
+	}
+	if created.Generation != 1 || created.Status.ObservedGeneration != 0 {
+		t.Errorf("unexpected generation %d and status %#v", created.Generation, created.Status)
+	}
+
+	// updating the status keeps the spec
+	created.Status.ObservedGeneration = 1
+	created.Spec.Template.ObjectLabels = map[string]string{"app": "foo"}
+	obj, _, err = statusStorage.Update(ctx, created)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated := obj.(*api.TemplateInstance)
+	if updated.Status.ObservedGeneration != 1 || len(updated.Spec.Template.ObjectLabels) != 0 || updated.Generation != 1 {
+		t.Errorf("unexpected status update: %#v", updated)
+	}
+
+	// changing the requester alone does not change the generation
+	bobCtx := kapi.WithUser(kapi.NewDefaultContext(), &user.DefaultInfo{Name: "bob"})
+	obj, _, err = storage.Update(bobCtx, updated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated = obj.(*api.TemplateInstance)
+	if updated.Generation != 1 || updated.Spec.Requester.Username != "bob" {
+		t.Errorf("unexpected generation %d and requester %#v", updated.Generation, updated.Spec.Requester)
+	}
+
+	// changing the template increments the generation and keeps the status
+	updated.Spec.Template.ObjectLabels = map[string]string{"app": "foo"}
+	updated.Status = api.TemplateInstanceStatus{}
+	obj, _, err = storage.Update(ctx, updated)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated = obj.(*api.TemplateInstance)
+	if updated.Generation != 2 || updated.Status.ObservedGeneration != 1 {
+		t.Errorf("unexpected generation %d and status %#v", updated.Generation, updated.Status)
+	}
+}
+
+func TestRequesterScopes(t *testing.T) {
+	storage, _, server := newStorage(t)
+	defer server.Terminate(t)
+
+	info := &authapi.DefaultScopedUserInfo{DefaultInfo: user.DefaultInfo{Name: "alice"}, Scopes: []string{"user:info"}}
+	obj, err := storage.Create(kapi.WithUser(kapi.NewDefaultContext(), info), validTemplateInstance())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	requester := obj.(*api.TemplateInstance).Spec.Requester
+	if requester == nil || requester.Username != "alice" || !reflect.DeepEqual(requester.Scopes, []string{"user:info"}) {
+		t.Errorf("expected the scopes of the creating user to be kept, got %#v", requester)
+	}
+}
+
+func TestGracefulDelete(t *testing.T) {
+	storage, statusStorage, server := newStorage(t)
+	defer server.Terminate(t)
+
+	ctx := kapi.WithUser(kapi.NewDefaultContext(), &user.DefaultInfo{Name: "alice"})
+	obj, err := storage.Create(ctx, validTemplateInstance())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	created := obj.(*api.TemplateInstance)
+	created.Status.Objects = []kapi.ObjectReference{{Kind: "Service", APIVersion: "v1", Namespace: created.Namespace, Name: "svc"}}
+	if _, _, err := statusStorage.Update(ctx, created); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// an instance with objects is kept until the controller deleted them
+	if _, err := storage.Delete(ctx, created.Name, &kapi.DeleteOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	obj, err = storage.Get(ctx, created.Name)
+	if err != nil {
+		t.Fatalf("expected the instance to be kept: %v", err)
+	}
+	if obj.(*api.TemplateInstance).DeletionTimestamp == nil {
+		t.Errorf("expected the instance to be marked as deleted")
+	}
+
+	if _, err := storage.Delete(ctx, created.Name, kapi.NewDeleteOptions(0)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := storage.Get(ctx, created.Name); !errors.IsNotFound(err) {
+		t.Errorf("expected the instance to be removed, got %v", err)
+	}
+}
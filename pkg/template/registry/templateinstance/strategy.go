@@ -0,0 +1,135 @@
+package templateinstance
+
+import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/registry/generic"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/template/api/validation"
+)
+
+// templateInstanceStrategy implements behavior for TemplateInstances
+type templateInstanceStrategy struct {
+	runtime.ObjectTyper
+	kapi.NameGenerator
+}
+
+// Strategy is the default logic that applies when creating and updating TemplateInstance
+// objects via the REST API.
+var Strategy = templateInstanceStrategy{kapi.Scheme, kapi.SimpleNameGenerator}
+
+// NamespaceScoped is true for template instances.
+func (templateInstanceStrategy) NamespaceScoped() bool {
+	return true
+}
+
+// PrepareForCreate clears fields that are not allowed to be set by end users on creation.
+func (templateInstanceStrategy) PrepareForCreate(obj runtime.Object) {
+	templateInstance := obj.(*api.TemplateInstance)
+	templateInstance.Generation = 1
+	templateInstance.Status = api.TemplateInstanceStatus{}
+}
+
+// PrepareForUpdate clears fields that are not allowed to be set by end users on update, and
+// increments the generation of the template instance when its spec changes.
+func (templateInstanceStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	templateInstance := obj.(*api.TemplateInstance)
+	oldTemplateInstance := old.(*api.TemplateInstance)
+	templateInstance.Status = oldTemplateInstance.Status
+	templateInstance.Generation = oldTemplateInstance.Generation
+	if specChanged(&templateInstance.Spec, &oldTemplateInstance.Spec) {
+		templateInstance.Generation++
+	}
+}
+
+// specChanged returns true if the template or the secret of a template instance changed. Changing
+// only the requester does not require the objects to be applied again.
+func specChanged(spec, oldSpec *api.TemplateInstanceSpec) bool {
+	a, b := *spec, *oldSpec
+	a.Requester, b.Requester = nil, nil
+	return !kapi.Semantic.DeepEqual(a, b)
+}
+
+// Canonicalize normalizes the object after validation.
+func (templateInstanceStrategy) Canonicalize(obj runtime.Object) {
+}
+
+// Validate validates a new template instance.
+func (templateInstanceStrategy) Validate(ctx kapi.Context, obj runtime.Object) field.ErrorList {
+	return validation.ValidateTemplateInstance(obj.(*api.TemplateInstance))
+}
+
+// AllowCreateOnUpdate is false for template instances.
+func (templateInstanceStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (templateInstanceStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (templateInstanceStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateTemplateInstanceUpdate(obj.(*api.TemplateInstance), old.(*api.TemplateInstance))
+}
+
+// DeletionGracePeriodSeconds is the grace period of template instances deleted without one. It is only
+// informative: the controller removes the instance as soon as its objects are deleted.
+const DeletionGracePeriodSeconds = int64(30)
+
+// CheckGracefulDelete keeps a deleted template instance until the controller deleted its objects, so that
+// they are deleted even if the controller is not running when the instance is deleted. Instances with no
+// objects, and instances deleted with a grace period of zero, are removed immediately.
+func (templateInstanceStrategy) CheckGracefulDelete(obj runtime.Object, options *kapi.DeleteOptions) bool {
+	if options == nil {
+		return false
+	}
+	period := DeletionGracePeriodSeconds
+	if options.GracePeriodSeconds != nil {
+		period = *options.GracePeriodSeconds
+	}
+	if len(obj.(*api.TemplateInstance).Status.Objects) == 0 {
+		period = 0
+	}
+	options.GracePeriodSeconds = &period
+	return true
+}
+
+// templateInstanceStatusStrategy implements the behavior of status updates of TemplateInstances
+type templateInstanceStatusStrategy struct {
+	templateInstanceStrategy
+}
+
+// StatusStrategy is the logic that applies when updating the status of TemplateInstance objects
+// via the REST API.
+var StatusStrategy = templateInstanceStatusStrategy{Strategy}
+
+// PrepareForUpdate keeps the spec of the template instance on a status update.
+func (templateInstanceStatusStrategy) PrepareForUpdate(obj, old runtime.Object) {
+	templateInstance := obj.(*api.TemplateInstance)
+	oldTemplateInstance := old.(*api.TemplateInstance)
+	templateInstance.Spec = oldTemplateInstance.Spec
+	templateInstance.Generation = oldTemplateInstance.Generation
+}
+
+// ValidateUpdate validates a status update.
+func (templateInstanceStatusStrategy) ValidateUpdate(ctx kapi.Context, obj, old runtime.Object) field.ErrorList {
+	return validation.ValidateTemplateInstanceStatusUpdate(obj.(*api.TemplateInstance), old.(*api.TemplateInstance))
+}
+
+// Matcher returns a generic matcher for a given label and field selector.
+func Matcher(label labels.Selector, field fields.Selector) generic.Matcher {
+	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
+		o, ok := obj.(*api.TemplateInstance)
+		if !ok {
+			return false, fmt.Errorf("not a template instance")
+		}
+		return label.Matches(labels.Set(o.Labels)) && field.Matches(api.TemplateInstanceToSelectableFields(o)), nil
+	})
+}
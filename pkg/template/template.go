@@ -10,6 +10,7 @@ import (
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	"github.com/openshift/origin/pkg/template/api"
+	"github.com/openshift/origin/pkg/template/api/validation"
 	. "github.com/openshift/origin/pkg/template/generator"
 	"github.com/openshift/origin/pkg/util"
 	"github.com/openshift/origin/pkg/util/stringreplace"
@@ -30,7 +31,11 @@ func NewProcessor(generators map[string]Generator) *Processor {
 // Process transforms Template object into List object. It generates
 // Parameter values using the defined set of generators first, and then it
 // substitutes all Parameter expression occurrences with their corresponding
-// values (currently in the containers' Environment variables only).
+// values. The ${PARAMETER_NAME} expressions are replaced in the string fields
+// of the objects, and the fields consisting only of a ${{PARAMETER_NAME}}
+// expression are replaced by the parameter value parsed as JSON. An error is
+// returned for each Parameter value that does not satisfy the type, pattern,
+// allowed values or length constraints of the Parameter.
 func (p *Processor) Process(template *api.Template) field.ErrorList {
 	templateErrors := field.ErrorList{}
 
@@ -38,6 +43,18 @@ func (p *Processor) Process(template *api.Template) field.ErrorList {
 		templatePath := field.NewPath("template")
 		return append(templateErrors, field.Invalid(templatePath.Child("parameters"), badParam, err.Error()))
 	}
+	paramsPath := field.NewPath("template", "parameters")
+	for i := range template.Parameters {
+		templateErrors = append(templateErrors, validation.ValidateParameterValue(&template.Parameters[i], paramsPath.Index(i))...)
+	}
+	if len(templateErrors) > 0 {
+		return templateErrors
+	}
+
+	paramMap := make(map[string]string, len(template.Parameters))
+	for _, param := range template.Parameters {
+		paramMap[param.Name] = param.Value
+	}
 
 	itemPath := field.NewPath("item")
 	for i, item := range template.Objects {
@@ -56,6 +73,9 @@ func (p *Processor) Process(template *api.Template) field.ErrorList {
 		if err != nil {
 			templateErrors = append(templateErrors, field.Invalid(idxPath.Child("parameters"), template.Parameters, err.Error()))
 		}
+		if unstructured, ok := newItem.(*runtime.Unstructured); ok {
+			templateErrors = append(templateErrors, p.SubstituteValueParameters(paramMap, unstructured, idxPath)...)
+		}
 		// If an object definition's metadata includes a namespace field, the field will be stripped out of
 		// the definition during template instantiation.  This is necessary because all objects created during
 		// instantiation are placed into the target namespace, so it would be invalid for the object to declare
@@ -120,6 +140,9 @@ func GetParameterByName(t *api.Template, name string) *api.Parameter {
 // Example of Parameter expression:
 //   - ${PARAMETER_NAME}
 //
+// Fields consisting only of a ${{PARAMETER_NAME}} expression are not
+// replaced, see SubstituteValueParameters.
+//
 func (p *Processor) SubstituteParameters(params []api.Parameter, item runtime.Object) (runtime.Object, error) {
 	// Make searching for given parameter name/value more effective
 	paramMap := make(map[string]string, len(params))
@@ -143,43 +166,93 @@ func (p *Processor) SubstituteParameters(params []api.Parameter, item runtime.Ob
 
 // GenerateParameterValues generates Value for each Parameter of the given
 // Template that has Generate field specified where Value is not already
-// supplied.
+// supplied. The ${PARAMETER_NAME} expressions in the From field are replaced
+// by the values of the referenced parameters before the generator is called,
+// generating them first if needed, so that generators can derive a value from
+// other parameters.
 //
 // Examples:
 //
-// from             | value
-// -----------------------------
-// "test[0-9]{1}x"  | "test7x"
-// "[0-1]{8}"       | "01001100"
-// "0x[A-F0-9]{4}"  | "0xB3AF"
-// "[a-zA-Z0-9]{8}" | "hW4yQU5i"
+// generate    | from                   | value
+// -----------------------------------------------------------
+// expression  | "test[0-9]{1}x"        | "test7x"
+// expression  | "[0-1]{8}"             | "01001100"
+// expression  | "0x[A-F0-9]{4}"        | "0xB3AF"
+// expression  | "[a-zA-Z0-9]{8}"       | "hW4yQU5i"
+// base64      | "${PASSWORD}"          | base64 encoded value of PASSWORD
+// certificate | "${HOST} ${TLS_KEY}"   | certificate for HOST signed by TLS_KEY
 // If an error occurs, the parameter that caused the error is returned along with the error message.
 func (p *Processor) GenerateParameterValues(t *api.Template) (error, *api.Parameter) {
+	index := make(map[string]int, len(t.Parameters))
+	for i, param := range t.Parameters {
+		index[param.Name] = i
+	}
+	states := make([]generateState, len(t.Parameters))
 	for i := range t.Parameters {
+		if err, badParam := p.generateParameterValue(t, i, index, states); err != nil {
+			return err, badParam
+		}
 		param := &t.Parameters[i]
-		if len(param.Value) > 0 {
-			continue
+		if len(param.Value) == 0 && param.Required {
+			return fmt.Errorf("template.parameters[%v]: parameter %s is required and must be specified", i, param.Name), param
 		}
-		if param.Generate != "" {
-			generator, ok := p.Generators[param.Generate]
-			if !ok {
-				return fmt.Errorf("template.parameters[%v]: Unable to find the '%v' generator for parameter %s", i, param.Generate, param.Name), param
-			}
-			if generator == nil {
-				return fmt.Errorf("template.parameters[%v]: Invalid '%v' generator for parameter %s", i, param.Generate, param.Name), param
-			}
-			value, err := generator.GenerateValue(param.From)
-			if err != nil {
-				return fmt.Errorf("template.parameters[%v]: Error %v generating value for parameter %s", i, err.Error(), param.Name), param
-			}
-			param.Value, ok = value.(string)
-			if !ok {
-				return fmt.Errorf("template.parameters[%v]: Unable to convert the generated value '%#v' to string for parameter %s", i, value, param.Name), param
+	}
+	return nil, nil
+}
+
+// generateState records the progress of the generation of a parameter value.
+type generateState int
+
+const (
+	notGenerated generateState = iota
+	generating
+	generated
+)
+
+// generateParameterValue generates the value of the parameter at index i of the template, after
+// generating the parameters its From field references. index maps parameter names to their index.
+func (p *Processor) generateParameterValue(t *api.Template, i int, index map[string]int, states []generateState) (error, *api.Parameter) {
+	param := &t.Parameters[i]
+	switch {
+	case states[i] == generated:
+		return nil, nil
+	case states[i] == generating:
+		return fmt.Errorf("template.parameters[%v]: the generator input of parameter %s references itself", i, param.Name), param
+	case len(param.Value) > 0 || len(param.Generate) == 0:
+		states[i] = generated
+		return nil, nil
+	}
+	states[i] = generating
+
+	generator, ok := p.Generators[param.Generate]
+	if !ok {
+		return fmt.Errorf("template.parameters[%v]: Unable to find the '%v' generator for parameter %s", i, param.Generate, param.Name), param
+	}
+	if generator == nil {
+		return fmt.Errorf("template.parameters[%v]: Invalid '%v' generator for parameter %s", i, param.Generate, param.Name), param
+	}
+	for _, match := range parameterExp.FindAllStringSubmatch(param.From, -1) {
+		if j, ok := index[match[1]]; ok {
+			if err, badParam := p.generateParameterValue(t, j, index, states); err != nil {
+				return err, badParam
 			}
 		}
-		if len(param.Value) == 0 && param.Required {
-			return fmt.Errorf("template.parameters[%v]: parameter %s is required and must be specified", i, param.Name), param
+	}
+	from := parameterExp.ReplaceAllStringFunc(param.From, func(match string) string {
+		if j, ok := index[match[2:len(match)-1]]; ok {
+			return t.Parameters[j].Value
 		}
+		return match
+	})
+
+	value, err := generator.GenerateValue(from)
+	if err != nil {
+		return fmt.Errorf("template.parameters[%v]: Error %v generating value for parameter %s", i, err.Error(), param.Name), param
+	}
+	param.Value, ok = value.(string)
+	if !ok {
+		return fmt.Errorf("template.parameters[%v]: Unable to convert the generated value '%#v' to string for parameter %s", i, value, param.Name), param
 	}
+	states[i] = generated
 	return nil, nil
 }
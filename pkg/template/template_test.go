@@ -1,11 +1,14 @@
 package template
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 	"testing"
 
@@ -130,6 +133,57 @@ func TestParameterGenerators(t *testing.T) {
 	}
 }
 
+func TestParameterGeneratorReferences(t *testing.T) {
+	generators := map[string]generator.Generator{
+		"foo":    FooGenerator{},
+		"base64": generator.NewBase64Generator(),
+	}
+	tests := map[string]struct {
+		parameters []api.Parameter
+		shouldPass bool
+		expected   string
+	}{
+		"parameters generated before their references": {
+			parameters: []api.Parameter{
+				{Name: "ENCODED", Generate: "base64", From: "${USER}:${PASSWORD}"},
+				{Name: "PASSWORD", Generate: "foo"},
+				{Name: "USER", Value: "admin"},
+			},
+			shouldPass: true,
+			expected:   base64.StdEncoding.EncodeToString([]byte("admin:foo")),
+		},
+		"unknown references are kept": {
+			parameters: []api.Parameter{
+				{Name: "ENCODED", Generate: "base64", From: "${MISSING}"},
+			},
+			shouldPass: true,
+			expected:   base64.StdEncoding.EncodeToString([]byte("${MISSING}")),
+		},
+		"circular references": {
+			parameters: []api.Parameter{
+				{Name: "ENCODED", Generate: "base64", From: "${OTHER}"},
+				{Name: "OTHER", Generate: "base64", From: "${ENCODED}"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		template := api.Template{Parameters: test.parameters}
+		err, _ := NewProcessor(generators).GenerateParameterValues(&template)
+		if err != nil && test.shouldPass {
+			t.Errorf("%s: Unexpected error %v", name, err)
+			continue
+		}
+		if err == nil && !test.shouldPass {
+			t.Errorf("%s: Expected error", name)
+			continue
+		}
+		if test.shouldPass && template.Parameters[0].Value != test.expected {
+			t.Errorf("%s: Expected value %q, got %q", name, test.expected, template.Parameters[0].Value)
+		}
+	}
+}
+
 func TestProcessValueEscape(t *testing.T) {
 	var template api.Template
 	if err := runtime.DecodeInto(kapi.Codecs.UniversalDecoder(), []byte(`{
@@ -369,3 +423,148 @@ func TestProcessTemplateParameters(t *testing.T) {
 		t.Errorf("unexpected output: %s", util.StringDiff(string(exp), string(result)))
 	}
 }
+
+func TestProcessValueParameters(t *testing.T) {
+	input := `{
+		"kind":"Template", "apiVersion":"v1",
+		"objects": [
+			{
+				"kind": "DeploymentConfig", "apiVersion": "v1",
+				"metadata": {"name": "${NAME}"},
+				"spec": {
+					"replicas": "${{REPLICAS}}",
+					"selector": "${{SELECTOR}}",
+					"template": {
+						"spec": {
+							"containers": [
+								{
+									"name": "${NAME}",
+									"ports": [{"containerPort": "${{PORT}}"}],
+									"resources": {"limits": {"memory": "${{MEMORY}}"}},
+									"securityContext": {"privileged": "${{PRIVILEGED}}"}
+								}
+							]
+						}
+					}
+				}
+			},
+			{
+				"kind": "Custom", "apiVersion": "example.com/v1",
+				"spec": {"size": "${{REPLICAS}}", "other": "${{UNKNOWN}}"}
+			}
+		]
+	}`
+
+	tests := []struct {
+		name     string
+		params   map[string]string
+		expected string
+		errs     []string
+	}{
+		{
+			name: "typed values",
+			params: map[string]string{
+				"NAME":       "app",
+				"REPLICAS":   "3",
+				"SELECTOR":   `{"name": "app"}`,
+				"PORT":       "8080",
+				"MEMORY":     `"512Mi"`,
+				"PRIVILEGED": "false",
+			},
+			expected: `[{"apiVersion":"v1","kind":"DeploymentConfig","metadata":{"name":"app"},"spec":{"replicas":3,"selector":{"name":"app"},"template":{"spec":{"containers":[{"name":"app","ports":[{"containerPort":8080}],"resources":{"limits":{"memory":"512Mi"}},"securityContext":{"privileged":false}}]}}}},` +
+				`{"apiVersion":"example.com/v1","kind":"Custom","spec":{"other":"${{UNKNOWN}}","size":3}}]`,
+		},
+		{
+			name: "invalid JSON",
+			params: map[string]string{
+				"NAME":       "app",
+				"REPLICAS":   "three",
+				"SELECTOR":   `{"name": "app"}`,
+				"PORT":       "",
+				"MEMORY":     `"512Mi"`,
+				"PRIVILEGED": "false true",
+			},
+			errs: []string{
+				"item[0].spec.replicas",
+				"item[0].spec.template.spec.containers[0].ports[0].containerPort",
+				"item[0].spec.template.spec.containers[0].securityContext.privileged",
+				"item[1].spec.size",
+			},
+		},
+		{
+			name: "mistyped values",
+			params: map[string]string{
+				"NAME":       "app",
+				"REPLICAS":   `"3"`,
+				"SELECTOR":   `["app"]`,
+				"PORT":       "8080",
+				"MEMORY":     "[]",
+				"PRIVILEGED": "false",
+			},
+			errs: []string{
+				"item[0].spec.replicas",
+				"item[0].spec.selector",
+				"item[0].spec.template.spec.containers[0].resources.limits.memory",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var template api.Template
+		if err := runtime.DecodeInto(kapi.Codecs.UniversalDecoder(), []byte(input), &template); err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+		for name, value := range test.params {
+			AddParameter(&template, makeParameter(name, value, "", false))
+		}
+
+		errs := NewProcessor(nil).Process(&template)
+		fields := []string{}
+		for _, err := range errs {
+			fields = append(fields, err.Field)
+		}
+		sort.Strings(fields)
+		if !reflect.DeepEqual(fields, test.errs) && !(len(fields) == 0 && len(test.errs) == 0) {
+			t.Errorf("%s: unexpected errors: %v", test.name, errs)
+			continue
+		}
+		if len(test.errs) > 0 {
+			continue
+		}
+
+		objects := []string{}
+		for _, obj := range template.Objects {
+			data, err := runtime.Encode(runtime.UnstructuredJSONScheme, obj)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", test.name, err)
+			}
+			objects = append(objects, strings.TrimSpace(string(data)))
+		}
+		if actual := "[" + strings.Join(objects, ",") + "]"; actual != test.expected {
+			t.Errorf("%s: unexpected output: %s", test.name, util.StringDiff(test.expected, actual))
+		}
+	}
+}
+
+func TestProcessParameterConstraints(t *testing.T) {
+	template := api.Template{
+		Parameters: []api.Parameter{
+			{Name: "REPLICAS", Type: api.ParameterTypeInt, Value: "two"},
+			{Name: "SIZE", AllowedValues: []string{"small", "large"}, Value: "large"},
+			{Name: "PASSWORD", MinLength: 8, Generate: "expression", From: "[a-z]{4}"},
+		},
+	}
+	generators := map[string]generator.Generator{
+		"expression": generator.NewExpressionValueGenerator(rand.New(rand.NewSource(1337))),
+	}
+	processor := NewProcessor(generators)
+
+	errs := processor.Process(&template)
+	fields := []string{}
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+	}
+	if expected := []string{"template.parameters[0].value", "template.parameters[2].value"}; !reflect.DeepEqual(expected, fields) {
+		t.Errorf("expected errors for %v, got %v", expected, errs)
+	}
+}
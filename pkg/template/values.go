@@ -0,0 +1,197 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+)
+
+// valueParameterExp matches a field consisting only of a ${{PARAMETER_NAME}} expression. The field is
+// replaced by the value of the parameter parsed as JSON, which lets parameters provide numbers,
+// booleans, lists and objects.
+var valueParameterExp = regexp.MustCompile(`^\$\{\{([a-zA-Z0-9\_]+)\}\}$`)
+
+// valueSubstitution records a field that was replaced by the JSON value of a parameter.
+type valueSubstitution struct {
+	path *field.Path
+	// keys are the map keys and list indexes leading to the field.
+	keys  []interface{}
+	param string
+	value interface{}
+}
+
+// valueSubstituter replaces the ${{PARAMETER_NAME}} fields of an unstructured object.
+type valueSubstituter struct {
+	params        map[string]string
+	substitutions []valueSubstitution
+	errs          field.ErrorList
+}
+
+// SubstituteValueParameters replaces every field of an unstructured template object whose value is
+// exactly ${{PARAMETER_NAME}} with the value of the parameter parsed as JSON. It must be called before
+// the object is decoded, as the parsed value usually does not have the type of a string field. An error
+// is returned for each parameter value that is not valid JSON, or that cannot be decoded into the field
+// it replaces when the kind of the object is known. Expressions referencing unknown parameters are left
+// untouched.
+func (p *Processor) SubstituteValueParameters(params map[string]string, item *runtime.Unstructured, fldPath *field.Path) field.ErrorList {
+	s := &valueSubstituter{params: params}
+	s.visit(item.Object, fldPath, nil)
+	if len(s.errs) > 0 || len(s.substitutions) == 0 {
+		return s.errs
+	}
+	return checkValueTypes(item, s.substitutions)
+}
+
+func (s *valueSubstituter) visit(value interface{}, fldPath *field.Path, keys []interface{}) interface{} {
+	switch t := value.(type) {
+	case map[string]interface{}:
+		for k, v := range t {
+			t[k] = s.visit(v, fldPath.Child(k), appendKey(keys, k))
+		}
+	case []interface{}:
+		for i, v := range t {
+			t[i] = s.visit(v, fldPath.Index(i), appendKey(keys, i))
+		}
+	case string:
+		match := valueParameterExp.FindStringSubmatch(t)
+		if match == nil {
+			return t
+		}
+		paramValue, ok := s.params[match[1]]
+		if !ok {
+			return t
+		}
+		parsed, err := parseJSONValue(paramValue)
+		if err != nil {
+			s.errs = append(s.errs, field.Invalid(fldPath, paramValue, fmt.Sprintf("the value of parameter %s must be valid JSON to replace this field: %v", match[1], err)))
+			return t
+		}
+		s.substitutions = append(s.substitutions, valueSubstitution{path: fldPath, keys: keys, param: match[1], value: parsed})
+		return parsed
+	}
+	return value
+}
+
+// appendKey returns a copy of keys with key appended.
+func appendKey(keys []interface{}, key interface{}) []interface{} {
+	out := make([]interface{}, len(keys), len(keys)+1)
+	copy(out, keys)
+	return append(out, key)
+}
+
+// parseJSONValue parses a single JSON value, preserving the precision of numbers.
+func parseJSONValue(value string) (interface{}, error) {
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.UseNumber()
+	var parsed interface{}
+	if err := decoder.Decode(&parsed); err != nil {
+		if err == io.EOF {
+			return nil, errors.New("the value is empty")
+		}
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, errors.New("the value must be a single JSON value")
+	}
+	return parsed, nil
+}
+
+// checkValueTypes returns an error for each substituted value that cannot be decoded into the type of
+// the field it replaced. Objects whose kind is not registered are not checked.
+func checkValueTypes(item *runtime.Unstructured, substitutions []valueSubstitution) field.ErrorList {
+	apiVersion, _ := item.Object["apiVersion"].(string)
+	kind, _ := item.Object["kind"].(string)
+	gv, err := unversioned.ParseGroupVersion(apiVersion)
+	if err != nil || len(kind) == 0 {
+		return nil
+	}
+	obj, err := kapi.Scheme.New(gv.WithKind(kind))
+	if err != nil {
+		return nil
+	}
+
+	errs := field.ErrorList{}
+	for _, substitution := range substitutions {
+		fieldType := jsonFieldType(reflect.TypeOf(obj), substitution.keys)
+		if fieldType == nil {
+			continue
+		}
+		data, err := json.Marshal(substitution.value)
+		if err != nil {
+			continue
+		}
+		if err := json.NewDecoder(bytes.NewReader(data)).Decode(reflect.New(fieldType).Interface()); err != nil {
+			errs = append(errs, field.Invalid(substitution.path, string(data), fmt.Sprintf("the value of parameter %s does not have the type of this field: %v", substitution.param, err)))
+		}
+	}
+	return errs
+}
+
+// jsonFieldType returns the Go type that the JSON value reached by following keys, a list of map keys
+// and list indexes, is decoded into from t. Returns nil if the field cannot be found.
+func jsonFieldType(t reflect.Type, keys []interface{}) reflect.Type {
+	for _, key := range keys {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			name, ok := key.(string)
+			if !ok {
+				return nil
+			}
+			if t = structFieldType(t, name); t == nil {
+				return nil
+			}
+		case reflect.Map:
+			if _, ok := key.(string); !ok {
+				return nil
+			}
+			t = t.Elem()
+		case reflect.Slice, reflect.Array:
+			if _, ok := key.(int); !ok {
+				return nil
+			}
+			t = t.Elem()
+		default:
+			return nil
+		}
+	}
+	return t
+}
+
+// structFieldType returns the type of the field of the struct t serialized with the JSON name, looking
+// into inlined structs. Returns nil if there is no such field.
+func structFieldType(t reflect.Type, name string) reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := strings.Split(f.Tag.Get("json"), ",")
+		switch {
+		case tag[0] == "-":
+			continue
+		case len(tag[0]) == 0 && f.Anonymous:
+			inline := f.Type
+			if inline.Kind() == reflect.Ptr {
+				inline = inline.Elem()
+			}
+			if inline.Kind() == reflect.Struct {
+				if fieldType := structFieldType(inline, name); fieldType != nil {
+					return fieldType
+				}
+			}
+		case tag[0] == name, len(tag[0]) == 0 && f.Name == name:
+			return f.Type
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package jsonmerge
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/kubernetes/pkg/util/yaml"
+)
+
+// Operation describes how a field differs between two documents.
+type Operation string
+
+const (
+	// Added indicates a field only present in the new document.
+	Added Operation = "added"
+	// Removed indicates a field only present in the old document.
+	Removed Operation = "removed"
+	// Changed indicates a field whose value differs between the documents.
+	Changed Operation = "changed"
+)
+
+// Change is a difference in a single field between two JSON documents. Unlike a Delta, which
+// records the changes to apply to a document, Changes are meant to be shown to users.
+type Change struct {
+	// Operation is the kind of change.
+	Operation Operation
+	// Path locates the field, e.g. "spec.containers[name=web].image". Elements
+	// of lists whose items all carry a unique name are identified by that name,
+	// other elements by their index.
+	Path string
+	// From is the old value of the field, or nil if it was added.
+	From interface{}
+	// To is the new value of the field, or nil if it was removed.
+	To interface{}
+}
+
+// String returns a single line, human readable representation of the change.
+func (c Change) String() string {
+	switch c.Operation {
+	case Added:
+		return fmt.Sprintf("+ %s: %s", c.Path, formatValue(c.To))
+	case Removed:
+		return fmt.Sprintf("- %s: %s", c.Path, formatValue(c.From))
+	default:
+		return fmt.Sprintf("~ %s: %s -> %s", c.Path, formatValue(c.From), formatValue(c.To))
+	}
+}
+
+// Changes accepts two JSON or YAML documents and returns the field level
+// changes which turn from into to. Changes are ordered by path.
+func Changes(from, to []byte) ([]Change, error) {
+	before, err := yaml.ToJSON(from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := yaml.ToJSON(to)
+	if err != nil {
+		return nil, err
+	}
+	var a, b interface{}
+	if err := json.Unmarshal(before, &a); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &b); err != nil {
+		return nil, err
+	}
+	return diff("", a, b, nil), nil
+}
+
+// ObjectChanges returns the field level changes between the JSON
+// serializations of from and to.
+func ObjectChanges(from, to interface{}) ([]Change, error) {
+	a, err := json.Marshal(from)
+	if err != nil {
+		return nil, err
+	}
+	b, err := json.Marshal(to)
+	if err != nil {
+		return nil, err
+	}
+	return Changes(a, b)
+}
+
+func diff(path string, a, b interface{}, changes []Change) []Change {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		if bv, ok := b.(map[string]interface{}); ok {
+			return diffMaps(path, av, bv, changes)
+		}
+	case []interface{}:
+		if bv, ok := b.([]interface{}); ok {
+			return diffLists(path, av, bv, changes)
+		}
+	default:
+		if a == b {
+			return changes
+		}
+	}
+	return append(changes, Change{Operation: Changed, Path: path, From: a, To: b})
+}
+
+func diffMaps(path string, a, b map[string]interface{}, changes []Change) []Change {
+	keys := make([]string, 0, len(a)+len(b))
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		child := k
+		if len(path) > 0 {
+			child = path + "." + k
+		}
+		av, inA := a[k]
+		bv, inB := b[k]
+		switch {
+		case !inA:
+			changes = append(changes, Change{Operation: Added, Path: child, To: bv})
+		case !inB:
+			changes = append(changes, Change{Operation: Removed, Path: child, From: av})
+		default:
+			changes = diff(child, av, bv, changes)
+		}
+	}
+	return changes
+}
+
+func diffLists(path string, a, b []interface{}, changes []Change) []Change {
+	aNames, aNamed := namedItems(a)
+	bNames, bNamed := namedItems(b)
+	if aNamed && bNamed {
+		for i, name := range aNames {
+			child := fmt.Sprintf("%s[name=%s]", path, name)
+			if j := indexOf(bNames, name); j >= 0 {
+				changes = diff(child, a[i], b[j], changes)
+			} else {
+				changes = append(changes, Change{Operation: Removed, Path: child, From: a[i]})
+			}
+		}
+		for j, name := range bNames {
+			if indexOf(aNames, name) < 0 {
+				changes = append(changes, Change{Operation: Added, Path: fmt.Sprintf("%s[name=%s]", path, name), To: b[j]})
+			}
+		}
+		return changes
+	}
+
+	for i := 0; i < len(a) || i < len(b); i++ {
+		child := fmt.Sprintf("%s[%d]", path, i)
+		switch {
+		case i >= len(a):
+			changes = append(changes, Change{Operation: Added, Path: child, To: b[i]})
+		case i >= len(b):
+			changes = append(changes, Change{Operation: Removed, Path: child, From: a[i]})
+		default:
+			changes = diff(child, a[i], b[i], changes)
+		}
+	}
+	return changes
+}
+
+// namedItems returns the names of the items of list and true if every item is
+// an object with a unique, non-empty string name.
+func namedItems(list []interface{}) ([]string, bool) {
+	names := make([]string, 0, len(list))
+	seen := map[string]bool{}
+	for _, item := range list {
+		obj, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := obj["name"].(string)
+		if !ok || len(name) == 0 || seen[name] {
+			return nil, false
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names, true
+}
+
+func indexOf(list []string, s string) int {
+	for i := range list {
+		if list[i] == s {
+			return i
+		}
+	}
+	return -1
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
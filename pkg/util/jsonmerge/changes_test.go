@@ -0,0 +1,91 @@
+package jsonmerge
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChanges(t *testing.T) {
+	testCases := []struct {
+		name     string
+		from, to string
+		expected []string
+	}{
+		{
+			name:     "identical",
+			from:     `{"a":1,"b":["x"]}`,
+			to:       `{"a":1,"b":["x"]}`,
+			expected: []string{},
+		},
+		{
+			name:     "scalar change",
+			from:     `{"a":1,"b":"x"}`,
+			to:       `{"a":2,"b":"x"}`,
+			expected: []string{"~ a: 1 -> 2"},
+		},
+		{
+			name:     "added and removed fields",
+			from:     `{"a":{"b":true}}`,
+			to:       `{"a":{"c":"d"}}`,
+			expected: []string{`- a.b: true`, `+ a.c: "d"`},
+		},
+		{
+			name:     "type change",
+			from:     `{"a":{"b":1}}`,
+			to:       `{"a":[1]}`,
+			expected: []string{`~ a: {"b":1} -> [1]`},
+		},
+		{
+			name:     "indexed list",
+			from:     `{"args":["a","b"]}`,
+			to:       `{"args":["a","c","d"]}`,
+			expected: []string{`~ args[1]: "b" -> "c"`, `+ args[2]: "d"`},
+		},
+		{
+			name: "named list",
+			from: `{"containers":[{"name":"web","image":"a"},{"name":"db","image":"b"}]}`,
+			to:   `{"containers":[{"name":"db","image":"b"},{"name":"web","image":"c"},{"name":"cache","image":"d"}]}`,
+			expected: []string{
+				`~ containers[name=web].image: "a" -> "c"`,
+				`+ containers[name=cache]: {"image":"d","name":"cache"}`,
+			},
+		},
+		{
+			name:     "list with duplicate names is indexed",
+			from:     `{"env":[{"name":"A","value":"1"},{"name":"A","value":"2"}]}`,
+			to:       `{"env":[{"name":"A","value":"1"},{"name":"A","value":"3"}]}`,
+			expected: []string{`~ env[1].value: "2" -> "3"`},
+		},
+	}
+
+	for _, tc := range testCases {
+		changes, err := Changes([]byte(tc.from), []byte(tc.to))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		actual := []string{}
+		for _, c := range changes {
+			actual = append(actual, c.String())
+		}
+		if !reflect.DeepEqual(tc.expected, actual) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, actual)
+		}
+	}
+}
+
+func TestChangesYAML(t *testing.T) {
+	changes, err := Changes([]byte("a: 1\n"), []byte(`{"a":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].String() != "~ a: 1 -> 2" {
+		t.Errorf("unexpected changes: %v", changes)
+	}
+}
+
+func TestChangesInvalid(t *testing.T) {
+	if _, err := Changes([]byte(`{`), []byte(`{}`)); err == nil {
+		t.Errorf("expected an error for invalid JSON")
+	}
+}
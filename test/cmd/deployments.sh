@@ -87,6 +87,12 @@ os::cmd::expect_success 'oc rollback rc/database-1 -o=yaml'
 os::cmd::expect_failure 'oc rollback database -o yaml'
 echo "rollback: ok"
 
+os::cmd::expect_success_and_text 'oc history database' 'REVISION'
+os::cmd::expect_success_and_text 'oc history dc/database --revision=1' 'database-1'
+os::cmd::expect_success_and_text 'oc history database --diff=1' 'identical'
+os::cmd::expect_failure_and_text 'oc history database --revision=2' 'not found'
+echo "history: ok"
+
 os::cmd::expect_success 'oc get dc/database'
 os::cmd::expect_success 'oc expose dc/database --name=fromdc'
 # should be a service